@@ -0,0 +1,757 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// archiveOrganizationPattern restricts organization names used as archive file names
+var archiveOrganizationPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// archiveExtraMergeKeys are the merge keys of archived node labels outside the graph type registry
+var archiveExtraMergeKeys = map[string]string{
+	"Scan":           "id",
+	"CodeownersBlob": "sha",
+	"OwnershipEvent": "id",
+	"OrphanAnalysis": "id",
+	"OrphanFinding":  "id",
+}
+
+// OrganizationAccessTracker records when organizations were last queried until the
+// next archival run persists the timestamps to Neo4j
+type OrganizationAccessTracker struct {
+	mu       sync.Mutex
+	accessed map[string]time.Time
+}
+
+// ArchivedNode is a node of an archived organization subgraph
+type ArchivedNode struct {
+	Label      string                 `json:"label"`
+	Key        interface{}            `json:"key"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// ArchivedRelationship is a relationship of an archived organization subgraph
+type ArchivedRelationship struct {
+	Type       string                 `json:"type"`
+	StartLabel string                 `json:"start_label"`
+	StartKey   interface{}            `json:"start_key"`
+	EndLabel   string                 `json:"end_label"`
+	EndKey     interface{}            `json:"end_key"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// OrganizationArchive is the on-disk export of an archived organization
+type OrganizationArchive struct {
+	Organization  string                 `json:"organization"`
+	ArchivedAt    string                 `json:"archived_at"`
+	LastAccessed  string                 `json:"last_accessed_at,omitempty"`
+	Nodes         []ArchivedNode         `json:"nodes"`
+	Relationships []ArchivedRelationship `json:"relationships"`
+}
+
+// ArchiveSummary describes a stored organization archive
+type ArchiveSummary struct {
+	Organization string `json:"organization"`
+	ArchivedAt   string `json:"archived_at"`
+	SizeBytes    int64  `json:"size_bytes"`
+}
+
+// ArchiveRestoreResponse represents the result of restoring an archived organization
+type ArchiveRestoreResponse struct {
+	Organization  string `json:"organization"`
+	ArchivedAt    string `json:"archived_at"`
+	RestoredAt    string `json:"restored_at"`
+	Nodes         int    `json:"nodes"`
+	Relationships int    `json:"relationships"`
+}
+
+// ArchiveRestoreConflictError is returned when an archived organization already exists in the graph
+type ArchiveRestoreConflictError struct {
+	Organization string
+}
+
+// Error implements the error interface for ArchiveRestoreConflictError
+func (e ArchiveRestoreConflictError) Error() string {
+	return fmt.Sprintf("organization %s already exists in the graph; delete it or keep the archive", e.Organization)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ArchiveRestoreConflictError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// newOrganizationAccessTracker creates an empty access tracker
+func newOrganizationAccessTracker() *OrganizationAccessTracker {
+	return &OrganizationAccessTracker{accessed: make(map[string]time.Time)}
+}
+
+// record remembers that an organization was queried
+func (t *OrganizationAccessTracker) record(org string) {
+	t.recordAt(org, time.Now().UTC())
+}
+
+// recordAt remembers an access time, keeping the latest per organization
+func (t *OrganizationAccessTracker) recordAt(org string, at time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at.After(t.accessed[org]) {
+		t.accessed[org] = at
+	}
+}
+
+// drain returns and clears the recorded access times
+func (t *OrganizationAccessTracker) drain() map[string]time.Time {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	accessed := t.accessed
+	t.accessed = make(map[string]time.Time)
+	return accessed
+}
+
+// buildRecordOrganizationAccessQuery builds a query persisting organization access times (Pure Core)
+func buildRecordOrganizationAccessQuery() string {
+	return `
+		UNWIND $rows AS row
+		MATCH (org:Organization {login: row.organization})
+		WHERE coalesce(org.last_accessed_at, '') < row.accessed_at
+		SET org.last_accessed_at = row.accessed_at
+	`
+}
+
+// buildSeedOrganizationAccessQuery builds a query starting the inactivity clock of organizations never seen by archival (Pure Core)
+func buildSeedOrganizationAccessQuery() string {
+	return `
+		MATCH (org:Organization)
+		WHERE org.last_accessed_at IS NULL
+		SET org.last_accessed_at = $now
+	`
+}
+
+// buildInactiveOrganizationsQuery builds a query returning organizations neither queried nor scanned since a cutoff (Pure Core)
+func buildInactiveOrganizationsQuery() string {
+	return `
+		MATCH (org:Organization)
+		WHERE org.last_accessed_at < $cutoff
+			AND NOT EXISTS { MATCH (org)-[:HAS_SCAN]->(scan:Scan) WHERE scan.started_at >= $cutoff }
+		RETURN org.login AS organization, org.last_accessed_at AS last_accessed_at
+		ORDER BY organization
+	`
+}
+
+// buildOrganizationMembersClause builds the clause collecting the nodes owned by an organization:
+// the organization, its repositories, its scans and custom entities scoped to it (Pure Core)
+func buildOrganizationMembersClause() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
+		WITH org, collect(DISTINCT repo) AS repos
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan)
+		WITH org, repos, collect(DISTINCT scan) AS scans
+		OPTIONAL MATCH (custom) WHERE custom.organization = $orgName
+		WITH [org] + repos + scans + collect(DISTINCT custom) AS candidates
+		UNWIND candidates AS candidate
+		WITH collect(DISTINCT candidate) AS members
+	`
+}
+
+// buildArchiveNodesQuery builds a query returning an organization's nodes and their direct neighbours (Pure Core)
+func buildArchiveNodesQuery() string {
+	return buildOrganizationMembersClause() + `
+		UNWIND members AS member
+		OPTIONAL MATCH (member)--(neighbour) WHERE NOT neighbour IN members
+		WITH members, collect(DISTINCT neighbour) AS neighbours
+		UNWIND members + neighbours AS node
+		RETURN labels(node) AS labels, properties(node) AS properties
+	`
+}
+
+// buildArchiveRelationshipsQuery builds a query returning every relationship touching an organization's nodes (Pure Core)
+func buildArchiveRelationshipsQuery() string {
+	return buildOrganizationMembersClause() + `
+		UNWIND members AS member
+		MATCH (member)-[rel]-()
+		WITH DISTINCT rel
+		RETURN type(rel) AS type,
+			properties(rel) AS properties,
+			labels(startNode(rel)) AS start_labels,
+			properties(startNode(rel)) AS start_properties,
+			labels(endNode(rel)) AS end_labels,
+			properties(endNode(rel)) AS end_properties
+	`
+}
+
+// buildDeleteOrganizationSubgraphQuery builds a query removing an organization's nodes and the
+// neighbours left without relationships, such as users owning only its repositories (Pure Core)
+func buildDeleteOrganizationSubgraphQuery() string {
+	return buildOrganizationMembersClause() + `
+		UNWIND members AS member
+		OPTIONAL MATCH (member)--(neighbour) WHERE NOT neighbour IN members
+		WITH members, collect(DISTINCT neighbour) AS neighbours
+		FOREACH (member IN members | DETACH DELETE member)
+		WITH neighbours
+		UNWIND neighbours AS neighbour
+		WITH neighbour WHERE NOT (neighbour)--()
+		DELETE neighbour
+		RETURN count(neighbour) AS orphans_deleted
+	`
+}
+
+// buildOrganizationExistsQuery builds a query checking whether an organization is in the graph (Pure Core)
+func buildOrganizationExistsQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		RETURN count(org) > 0 AS found
+	`
+}
+
+// buildRestoreNodesQuery builds a query restoring archived nodes of one label; nodes that
+// still exist keep their current properties (Pure Core)
+func buildRestoreNodesQuery(label, key string) string {
+	return fmt.Sprintf(`
+		UNWIND $rows AS row
+		MERGE (n:%s {%s: row.key})
+		ON CREATE SET n += row.properties
+	`, quoteCypherIdentifier("label", label), quoteCypherIdentifier("merge_key", key))
+}
+
+// buildRestoreRelationshipsQuery builds a query restoring archived relationships of one type between two labels (Pure Core)
+func buildRestoreRelationshipsQuery(startLabel, startKey, relType, endLabel, endKey string) string {
+	return fmt.Sprintf(`
+		UNWIND $rows AS row
+		MATCH (source:%s {%s: row.start_key})
+		MATCH (target:%s {%s: row.end_key})
+		CREATE (source)-[rel:%s]->(target)
+		SET rel = row.properties
+	`, quoteCypherIdentifier("label", startLabel), quoteCypherIdentifier("merge_key", startKey),
+		quoteCypherIdentifier("label", endLabel), quoteCypherIdentifier("merge_key", endKey),
+		quoteCypherIdentifier("relationship", relType))
+}
+
+// archiveMergeKey returns the property identifying nodes of a label (Pure Core)
+func archiveMergeKey(registry *GraphTypeRegistry, label string) (string, bool) {
+	if def, exists := registry.nodeType(label); exists {
+		return def.MergeKey, true
+	}
+	key, exists := archiveExtraMergeKeys[label]
+	return key, exists
+}
+
+// resolveArchivedNodeIdentity picks the first label with a merge key and the node's key value (Pure Core)
+func resolveArchivedNodeIdentity(registry *GraphTypeRegistry, labels []string, properties map[string]interface{}) (string, interface{}, error) {
+	for _, label := range labels {
+		key, exists := archiveMergeKey(registry, label)
+		if !exists {
+			continue
+		}
+		value, exists := properties[key]
+		if !exists || value == nil {
+			return "", nil, fmt.Errorf("%s node has no %s property", label, key)
+		}
+		return label, value, nil
+	}
+	return "", nil, fmt.Errorf("node with labels %v has no known merge key", labels)
+}
+
+// buildOrganizationArchive converts exported records into an archive, failing if any node
+// cannot be identified on restore (Pure Core)
+func buildOrganizationArchive(registry *GraphTypeRegistry, org, lastAccessed string, nodeRecords, relationshipRecords []map[string]interface{}, now time.Time) (OrganizationArchive, error) {
+	archive := OrganizationArchive{
+		Organization:  org,
+		ArchivedAt:    now.UTC().Format(time.RFC3339),
+		LastAccessed:  lastAccessed,
+		Nodes:         make([]ArchivedNode, 0, len(nodeRecords)),
+		Relationships: make([]ArchivedRelationship, 0, len(relationshipRecords)),
+	}
+
+	for _, record := range nodeRecords {
+		properties := getMapFromMap(record, "properties")
+		label, key, err := resolveArchivedNodeIdentity(registry, getStringSliceFromMap(record, "labels"), properties)
+		if err != nil {
+			return OrganizationArchive{}, err
+		}
+		archive.Nodes = append(archive.Nodes, ArchivedNode{Label: label, Key: key, Properties: properties})
+	}
+
+	for _, record := range relationshipRecords {
+		startLabel, startKey, err := resolveArchivedNodeIdentity(registry, getStringSliceFromMap(record, "start_labels"), getMapFromMap(record, "start_properties"))
+		if err != nil {
+			return OrganizationArchive{}, err
+		}
+		endLabel, endKey, err := resolveArchivedNodeIdentity(registry, getStringSliceFromMap(record, "end_labels"), getMapFromMap(record, "end_properties"))
+		if err != nil {
+			return OrganizationArchive{}, err
+		}
+		archive.Relationships = append(archive.Relationships, ArchivedRelationship{
+			Type:       getStringFromMap(record, "type"),
+			StartLabel: startLabel,
+			StartKey:   startKey,
+			EndLabel:   endLabel,
+			EndKey:     endKey,
+			Properties: getMapFromMap(record, "properties"),
+		})
+	}
+
+	return archive, nil
+}
+
+// normalizeArchivedValue converts decoded JSON numbers back to the integer or float values Neo4j stored (Pure Core)
+func normalizeArchivedValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer
+		}
+		float, _ := typed.Float64()
+		return float
+	case []interface{}:
+		normalized := make([]interface{}, len(typed))
+		for i, item := range typed {
+			normalized[i] = normalizeArchivedValue(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			normalized[key] = normalizeArchivedValue(item)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
+
+// archiveFilePath returns the archive file of an organization, rejecting names unsafe as file names
+func archiveFilePath(directory, org string) (string, error) {
+	if !archiveOrganizationPattern.MatchString(org) {
+		return "", &gofrhttp.ErrorInvalidParam{Params: []string{"org"}}
+	}
+	return filepath.Join(directory, org+".json"), nil
+}
+
+// writeOrganizationArchive stores an archive atomically so a crash never leaves a partial file
+func writeOrganizationArchive(directory string, archive OrganizationArchive) (string, error) {
+	path, err := archiveFilePath(directory, archive.Organization)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode archive: %w", err)
+	}
+
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		os.Remove(temporary)
+		return "", fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	return path, nil
+}
+
+// readOrganizationArchive loads the stored archive of an organization
+func readOrganizationArchive(directory, org string) (OrganizationArchive, string, error) {
+	path, err := archiveFilePath(directory, org)
+	if err != nil {
+		return OrganizationArchive{}, "", err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return OrganizationArchive{}, "", &gofrhttp.ErrorEntityNotFound{Name: "archive", Value: org}
+	}
+	if err != nil {
+		return OrganizationArchive{}, "", fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+
+	var archive OrganizationArchive
+	if err := decoder.Decode(&archive); err != nil {
+		return OrganizationArchive{}, "", fmt.Errorf("failed to decode archive: %w", err)
+	}
+
+	for i := range archive.Nodes {
+		archive.Nodes[i].Key = normalizeArchivedValue(archive.Nodes[i].Key)
+		archive.Nodes[i].Properties, _ = normalizeArchivedValue(archive.Nodes[i].Properties).(map[string]interface{})
+	}
+	for i := range archive.Relationships {
+		archive.Relationships[i].StartKey = normalizeArchivedValue(archive.Relationships[i].StartKey)
+		archive.Relationships[i].EndKey = normalizeArchivedValue(archive.Relationships[i].EndKey)
+		archive.Relationships[i].Properties, _ = normalizeArchivedValue(archive.Relationships[i].Properties).(map[string]interface{})
+	}
+
+	return archive, path, nil
+}
+
+// listOrganizationArchives returns the stored archives sorted by organization
+func listOrganizationArchives(directory string) ([]ArchiveSummary, error) {
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return []ArchiveSummary{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archives: %w", err)
+	}
+
+	summaries := []ArchiveSummary{}
+	for _, entry := range entries {
+		org, isArchive := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !isArchive || !archiveOrganizationPattern.MatchString(org) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, ArchiveSummary{
+			Organization: org,
+			ArchivedAt:   info.ModTime().UTC().Format(time.RFC3339),
+			SizeBytes:    info.Size(),
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Organization < summaries[j].Organization })
+	return summaries, nil
+}
+
+// groupRestoreNodeRows groups archived nodes into UNWIND rows per label (Pure Core)
+func groupRestoreNodeRows(nodes []ArchivedNode) map[string][]map[string]interface{} {
+	groups := make(map[string][]map[string]interface{})
+	for _, node := range nodes {
+		groups[node.Label] = append(groups[node.Label], map[string]interface{}{
+			"key":        node.Key,
+			"properties": node.Properties,
+		})
+	}
+	return groups
+}
+
+// groupRestoreRelationshipRows groups archived relationships into UNWIND rows per start label, type and end label (Pure Core)
+func groupRestoreRelationshipRows(relationships []ArchivedRelationship) map[[3]string][]map[string]interface{} {
+	groups := make(map[[3]string][]map[string]interface{})
+	for _, rel := range relationships {
+		group := [3]string{rel.StartLabel, rel.Type, rel.EndLabel}
+		groups[group] = append(groups[group], map[string]interface{}{
+			"start_key":  rel.StartKey,
+			"end_key":    rel.EndKey,
+			"properties": rel.Properties,
+		})
+	}
+	return groups
+}
+
+// persistOrganizationAccess writes recorded access times and starts the inactivity clock of
+// organizations without one (Orchestrator)
+func persistOrganizationAccess(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession) error {
+	accessed := deps.Access.drain()
+	if len(accessed) > 0 {
+		rows := make([]map[string]interface{}, 0, len(accessed))
+		for org, at := range accessed {
+			rows = append(rows, map[string]interface{}{
+				"organization": org,
+				"accessed_at":  at.UTC().Format(time.RFC3339),
+			})
+		}
+
+		if _, err := executeNeo4jWrite(ctx, session, buildRecordOrganizationAccessQuery(), map[string]interface{}{"rows": rows}); err != nil {
+			for org, at := range accessed {
+				deps.Access.recordAt(org, at)
+			}
+			return fmt.Errorf("failed to record organization access: %w", err)
+		}
+	}
+
+	_, err := executeNeo4jWrite(ctx, session, buildSeedOrganizationAccessQuery(), map[string]interface{}{
+		"now": time.Now().UTC().Format(time.RFC3339),
+	})
+	return err
+}
+
+// exportOrganizationSubgraph reads an organization's nodes and relationships into an archive (Orchestrator)
+func exportOrganizationSubgraph(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession, org, lastAccessed string) (OrganizationArchive, error) {
+	params := map[string]interface{}{"orgName": org}
+
+	nodes, err := executeNeo4jReadQuery(ctx, session, buildArchiveNodesQuery(), params)
+	if err != nil {
+		return OrganizationArchive{}, fmt.Errorf("failed to export nodes: %w", err)
+	}
+
+	relationships, err := executeNeo4jReadQuery(ctx, session, buildArchiveRelationshipsQuery(), params)
+	if err != nil {
+		return OrganizationArchive{}, fmt.Errorf("failed to export relationships: %w", err)
+	}
+
+	return buildOrganizationArchive(deps.GraphTypes, org, lastAccessed, nodes.Records, relationships.Records, time.Now())
+}
+
+// deleteOrganizationSubgraph removes an organization's nodes and the neighbours they orphan (Orchestrator)
+func deleteOrganizationSubgraph(ctx *gofr.Context, session *Neo4jSession, org string) (int, error) {
+	result, err := executeNeo4jWrite(ctx, session, buildDeleteOrganizationSubgraphQuery(), map[string]interface{}{"orgName": org})
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Records) == 0 {
+		return 0, nil
+	}
+	return getIntFromMap(result.Records[0], "orphans_deleted"), nil
+}
+
+// archiveOrganization exports an inactive organization to the archive directory and removes it from the graph
+func archiveOrganization(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession, config ArchivalConfig, org, lastAccessed string) error {
+	if deps.Scans.isRunning(org) {
+		return fmt.Errorf("a scan of organization %s is running", org)
+	}
+
+	archive, err := exportOrganizationSubgraph(ctx, deps, session, org, lastAccessed)
+	if err != nil {
+		return err
+	}
+
+	path, err := writeOrganizationArchive(config.Directory, archive)
+	if err != nil {
+		return err
+	}
+
+	if deps.Scans.isRunning(org) {
+		os.Remove(path)
+		return fmt.Errorf("a scan of organization %s started during archival", org)
+	}
+
+	orphans, err := deleteOrganizationSubgraph(ctx, session, org)
+	if err != nil {
+		return fmt.Errorf("failed to remove archived subgraph: %w", err)
+	}
+	deps.GraphChanges.notify(org)
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("organization_archives_total", 1, MetricLabels{
+		"organization": org,
+	})
+	logInfo(ctx, "Archived inactive organization", LogFields{
+		"component":        "archival",
+		"operation":        "archive_organization",
+		"organization":     org,
+		"last_accessed_at": lastAccessed,
+		"archive":          path,
+		"nodes":            len(archive.Nodes),
+		"relationships":    len(archive.Relationships),
+		"orphans_deleted":  orphans,
+	})
+
+	return nil
+}
+
+// archiveInactiveOrganizations archives every organization neither queried nor scanned within the inactivity window
+func archiveInactiveOrganizations(ctx *gofr.Context, deps *AppDependencies) {
+	config := deps.currentConfig().Archival
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logError(ctx, "Failed to create Neo4j session for archival", LogFields{
+			"component": "archival",
+			"operation": "archive_inactive",
+			"error":     err.Error(),
+		})
+		return
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	if err := persistOrganizationAccess(ctx, deps, session); err != nil {
+		logError(ctx, "Failed to persist organization access times", LogFields{
+			"component": "archival",
+			"operation": "archive_inactive",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	cutoff := time.Now().Add(-config.InactiveAfter).UTC().Format(time.RFC3339)
+	result, err := executeNeo4jReadQuery(ctx, session, buildInactiveOrganizationsQuery(), map[string]interface{}{"cutoff": cutoff})
+	if err != nil {
+		logError(ctx, "Failed to look up inactive organizations", LogFields{
+			"component": "archival",
+			"operation": "archive_inactive",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	for _, record := range result.Records {
+		org := getStringFromMap(record, "organization")
+		if err := archiveOrganization(ctx, deps, session, config, org, getStringFromMap(record, "last_accessed_at")); err != nil {
+			logWarn(ctx, "Failed to archive organization", LogFields{
+				"component":    "archival",
+				"operation":    "archive_organization",
+				"organization": org,
+				"error":        err.Error(),
+			})
+		}
+	}
+}
+
+// writeArchivedSubgraph recreates the nodes and relationships of an archive (Orchestrator)
+func writeArchivedSubgraph(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession, archive OrganizationArchive) error {
+	for label, rows := range groupRestoreNodeRows(archive.Nodes) {
+		key, _ := archiveMergeKey(deps.GraphTypes, label)
+		if _, err := executeNeo4jWrite(ctx, session, buildRestoreNodesQuery(label, key), map[string]interface{}{"rows": rows}); err != nil {
+			return fmt.Errorf("failed to restore %s nodes: %w", label, err)
+		}
+	}
+
+	for group, rows := range groupRestoreRelationshipRows(archive.Relationships) {
+		startKey, _ := archiveMergeKey(deps.GraphTypes, group[0])
+		endKey, _ := archiveMergeKey(deps.GraphTypes, group[2])
+		query := buildRestoreRelationshipsQuery(group[0], startKey, group[1], group[2], endKey)
+		if _, err := executeNeo4jWrite(ctx, session, query, map[string]interface{}{"rows": rows}); err != nil {
+			return fmt.Errorf("failed to restore %s relationships: %w", group[1], err)
+		}
+	}
+
+	_, err := executeNeo4jWrite(ctx, session, buildRecordOrganizationAccessQuery(), map[string]interface{}{
+		"rows": []map[string]interface{}{{
+			"organization": archive.Organization,
+			"accessed_at":  time.Now().UTC().Format(time.RFC3339),
+		}},
+	})
+	return err
+}
+
+// restoreOrganization recreates an archived organization and deletes its archive; a failed
+// restore is rolled back so it can be retried
+func restoreOrganization(ctx *gofr.Context, deps *AppDependencies, org string) (ArchiveRestoreResponse, error) {
+	archive, path, err := readOrganizationArchive(deps.currentConfig().Archival.Directory, org)
+	if err != nil {
+		return ArchiveRestoreResponse{}, err
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return ArchiveRestoreResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOrganizationExistsQuery(), map[string]interface{}{"orgName": org})
+	if err != nil {
+		return ArchiveRestoreResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) > 0 && getBoolFromMap(result.Records[0], "found") {
+		return ArchiveRestoreResponse{}, ArchiveRestoreConflictError{Organization: org}
+	}
+
+	if err := writeArchivedSubgraph(ctx, deps, session, archive); err != nil {
+		if _, rollbackErr := deleteOrganizationSubgraph(ctx, session, org); rollbackErr != nil {
+			logError(ctx, "Failed to roll back partial organization restore", LogFields{
+				"component":    "archival",
+				"operation":    "restore_organization",
+				"organization": org,
+				"error":        rollbackErr.Error(),
+			})
+		}
+		return ArchiveRestoreResponse{}, err
+	}
+	deps.Access.record(org)
+	deps.GraphChanges.notify(org)
+
+	if err := os.Remove(path); err != nil {
+		logWarn(ctx, "Failed to delete restored archive", LogFields{
+			"component":    "archival",
+			"operation":    "restore_organization",
+			"organization": org,
+			"archive":      path,
+			"error":        err.Error(),
+		})
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("organization_restores_total", 1, MetricLabels{
+		"organization": org,
+	})
+
+	return ArchiveRestoreResponse{
+		Organization:  org,
+		ArchivedAt:    archive.ArchivedAt,
+		RestoredAt:    time.Now().UTC().Format(time.RFC3339),
+		Nodes:         len(archive.Nodes),
+		Relationships: len(archive.Relationships),
+	}, nil
+}
+
+// handleListArchives lists the archived organizations
+func (h *AppHandler) handleListArchives(_ *gofr.Context) (interface{}, error) {
+	return listOrganizationArchives(h.deps.currentConfig().Archival.Directory)
+}
+
+// handleRestoreArchive restores an archived organization into the graph
+func (h *AppHandler) handleRestoreArchive(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	response, err := restoreOrganization(ctx, h.deps, orgName)
+	if err != nil {
+		logError(ctx, "Failed to restore archived organization", LogFields{
+			"component":    "archival",
+			"operation":    "restore_organization",
+			"organization": orgName,
+			"error":        err.Error(),
+		})
+		return nil, err
+	}
+
+	logWarn(ctx, "Archived organization restored", LogFields{
+		"component":     "archival",
+		"operation":     "restore_organization",
+		"organization":  orgName,
+		"nodes":         response.Nodes,
+		"relationships": response.Relationships,
+	})
+
+	return response, nil
+}
+
+// registerArchival schedules the archival of inactive organizations when enabled
+func registerArchival(app *gofr.App, deps *AppDependencies) {
+	config := deps.Config.Archival
+	if !config.Enabled {
+		return
+	}
+
+	app.AddCronJob(config.Schedule, "organization-archival", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "organization_archival", func() {
+			runAsLeader(ctx, deps, "organization_archival", func() {
+				archiveInactiveOrganizations(ctx, deps)
+			})
+		})
+	})
+}
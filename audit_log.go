@@ -0,0 +1,401 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// auditLogPageSize is the number of audit log entries requested per page
+const auditLogPageSize = 100
+
+// auditLogMaxPages bounds the pages fetched per category in one ingestion
+const auditLogMaxPages = 50
+
+// defaultOwnershipHistoryLimit is the number of timeline events returned when no limit is given
+const defaultOwnershipHistoryLimit = 100
+
+// auditLogCategories are the audit log action categories searched for ownership events
+var auditLogCategories = []string{"team", "protected_branch"}
+
+// ownershipAuditActions are the audit log actions that change who owns code
+var ownershipAuditActions = []string{
+	"team.create",
+	"team.destroy",
+	"team.add_member",
+	"team.remove_member",
+	"team.add_repository",
+	"team.remove_repository",
+	"team.update_repository_permission",
+	"team.change_parent_team",
+	"protected_branch.update_require_code_owner_review",
+}
+
+// OwnershipEvent is an ownership change in an organization's history timeline
+type OwnershipEvent struct {
+	ID         string `json:"id"`
+	Action     string `json:"action"`
+	Actor      string `json:"actor,omitempty"`
+	Team       string `json:"team,omitempty"`
+	User       string `json:"user,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Source     string `json:"source"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// OwnershipHistoryResponse is the ownership timeline of an organization, newest first
+type OwnershipHistoryResponse struct {
+	Organization string           `json:"organization"`
+	Events       []OwnershipEvent `json:"events"`
+}
+
+// AuditLogIngestionResult summarizes an audit log ingestion run
+type AuditLogIngestionResult struct {
+	Organization    string `json:"organization"`
+	Since           string `json:"since"`
+	EntriesRead     int    `json:"entries_read"`
+	OwnershipEvents int    `json:"ownership_events"`
+	IngestedUntil   string `json:"ingested_until,omitempty"`
+}
+
+// buildAuditLogCursorQuery builds a query returning where the last audit log ingestion stopped (Pure Core)
+func buildAuditLogCursorQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		RETURN org.audit_log_ingested_until AS ingested_until
+	`
+}
+
+// buildStoreOwnershipEventsQuery builds an UNWIND query merging audit log events into the organization timeline
+// and advancing its ingestion cursor (Pure Core)
+func buildStoreOwnershipEventsQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		FOREACH (row IN $rows |
+			MERGE (event:OwnershipEvent {id: row.id})
+			ON CREATE SET event.organization = $orgName,
+				event.action = row.action,
+				event.actor = row.actor,
+				event.team = row.team,
+				event.user = row.user,
+				event.repository = row.repository,
+				event.source = 'audit_log',
+				event.occurred_at = row.occurred_at
+			MERGE (org)-[:HAS_OWNERSHIP_EVENT]->(event)
+		)
+		SET org.audit_log_ingested_until = CASE
+			WHEN $ingested_until > coalesce(org.audit_log_ingested_until, '') THEN $ingested_until
+			ELSE org.audit_log_ingested_until
+		END
+		RETURN org.audit_log_ingested_until AS ingested_until
+	`
+}
+
+// buildOwnershipHistoryQuery builds a query returning an organization's ownership events, newest first (Pure Core)
+func buildOwnershipHistoryQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[:HAS_OWNERSHIP_EVENT]->(event:OwnershipEvent)
+		WHERE ($team = '' OR event.team = $team) AND ($repository = '' OR event.repository = $repository)
+		WITH org, event ORDER BY event.occurred_at DESC
+		WITH org, collect(event)[0..$limit] AS events
+		RETURN org.login AS organization, [event IN events | {
+			id: event.id,
+			action: event.action,
+			actor: event.actor,
+			team: event.team,
+			user: event.user,
+			repository: event.repository,
+			source: event.source,
+			occurred_at: event.occurred_at
+		}] AS events
+	`
+}
+
+// buildAuditLogOrganizationsQuery builds a query returning every organization in the graph (Pure Core)
+func buildAuditLogOrganizationsQuery() string {
+	return `
+		MATCH (org:Organization)
+		RETURN org.login AS organization
+		ORDER BY organization
+	`
+}
+
+// convertAuditLogEntry converts a raw audit log entry into an ownership event; ok is false for
+// actions that do not change ownership (Pure Core)
+func convertAuditLogEntry(orgName string, entry map[string]interface{}) (OwnershipEvent, bool) {
+	action := getStringFromMap(entry, "action")
+	if !lo.Contains(ownershipAuditActions, action) {
+		return OwnershipEvent{}, false
+	}
+
+	id := getStringFromMap(entry, "_document_id")
+	timestamp := int64(getFloatFromMap(entry, "@timestamp"))
+	if id == "" || timestamp == 0 {
+		return OwnershipEvent{}, false
+	}
+
+	return OwnershipEvent{
+		ID:         id,
+		Action:     action,
+		Actor:      getStringFromMap(entry, "actor"),
+		Team:       strings.TrimPrefix(getStringFromMap(entry, "team"), orgName+"/"),
+		User:       getStringFromMap(entry, "user"),
+		Repository: getStringFromMap(entry, "repo"),
+		Source:     "audit_log",
+		OccurredAt: time.UnixMilli(timestamp).UTC().Format(time.RFC3339),
+	}, true
+}
+
+// buildAuditLogPhrase builds the audit log search phrase of a category since a date (Pure Core)
+func buildAuditLogPhrase(category string, since time.Time) string {
+	return fmt.Sprintf("action:%s created:>=%s", category, since.UTC().Format(time.DateOnly))
+}
+
+// fetchAuditLogPage fetches one page of an organization's audit log
+func fetchAuditLogPage(ctx *gofr.Context, orgName, phrase string, page int) ([]map[string]interface{}, error) {
+	resp, err := githubGet(ctx, fmt.Sprintf("orgs/%s/audit-log", orgName), map[string]any{
+		"phrase":   phrase,
+		"include":  "web",
+		"order":    "asc",
+		"page":     fmt.Sprintf("%d", page),
+		"per_page": fmt.Sprintf("%d", auditLogPageSize),
+	}, buildGitHubRequestHeaders())
+	if err != nil {
+		return nil, &gofrhttp.ErrorRequestTimeout{}
+	}
+	logRateLimitInfo(ctx, resp)
+
+	var entries []map[string]interface{}
+	if err := decodeGitHubResponse(resp, "audit_log", &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// fetchOwnershipEvents reads the ownership events of an organization's audit log since a time
+func fetchOwnershipEvents(ctx *gofr.Context, orgName string, since time.Time) ([]OwnershipEvent, int, error) {
+	events := []OwnershipEvent{}
+	read := 0
+
+	for _, category := range auditLogCategories {
+		phrase := buildAuditLogPhrase(category, since)
+		for page := 1; page <= auditLogMaxPages; page++ {
+			entries, err := fetchAuditLogPage(ctx, orgName, phrase, page)
+			if err != nil {
+				return nil, read, err
+			}
+
+			read += len(entries)
+			for _, entry := range entries {
+				if event, ok := convertAuditLogEntry(orgName, entry); ok {
+					events = append(events, event)
+				}
+			}
+			if len(entries) < auditLogPageSize {
+				break
+			}
+		}
+	}
+
+	return events, read, nil
+}
+
+// ingestAuditLog backfills an organization's ownership timeline from its audit log, resuming from
+// the last ingested event or the configured backfill window (Orchestrator)
+func ingestAuditLog(ctx *gofr.Context, deps *AppDependencies, orgName string) (AuditLogIngestionResult, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return AuditLogIngestionResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	cursor, err := executeNeo4jReadQuery(ctx, session, buildAuditLogCursorQuery(), map[string]interface{}{"orgName": orgName})
+	if err != nil {
+		return AuditLogIngestionResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(cursor.Records) == 0 {
+		return AuditLogIngestionResult{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	since := time.Now().UTC().Add(-deps.currentConfig().AuditLog.Backfill)
+	if until, err := time.Parse(time.RFC3339, getStringFromMap(cursor.Records[0], "ingested_until")); err == nil {
+		since = until
+	}
+
+	events, read, err := fetchOwnershipEvents(ctx, orgName, since)
+	if err != nil {
+		return AuditLogIngestionResult{}, err
+	}
+
+	result := AuditLogIngestionResult{
+		Organization:    orgName,
+		Since:           since.Format(time.RFC3339),
+		EntriesRead:     read,
+		OwnershipEvents: len(events),
+	}
+
+	rows := make([]map[string]interface{}, 0, len(events))
+	ingestedUntil := ""
+	for _, event := range events {
+		rows = append(rows, map[string]interface{}{
+			"id":          event.ID,
+			"action":      event.Action,
+			"actor":       event.Actor,
+			"team":        event.Team,
+			"user":        event.User,
+			"repository":  event.Repository,
+			"occurred_at": event.OccurredAt,
+		})
+		if event.OccurredAt > ingestedUntil {
+			ingestedUntil = event.OccurredAt
+		}
+	}
+
+	stored, err := executeNeo4jWrite(ctx, session, buildStoreOwnershipEventsQuery(), map[string]interface{}{
+		"orgName":        orgName,
+		"rows":           rows,
+		"ingested_until": ingestedUntil,
+	})
+	if err != nil {
+		return AuditLogIngestionResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(stored.Records) > 0 {
+		result.IngestedUntil = getStringFromMap(stored.Records[0], "ingested_until")
+	}
+
+	logInfo(ctx, "Audit log ingested", LogFields{
+		"component":        "audit_log",
+		"operation":        "ingest",
+		"organization":     orgName,
+		"since":            result.Since,
+		"entries_read":     result.EntriesRead,
+		"ownership_events": result.OwnershipEvents,
+		"ingested_until":   result.IngestedUntil,
+	})
+
+	return result, nil
+}
+
+// ingestAuditLogForAllOrganizations ingests the audit log of every organization in the graph
+func ingestAuditLogForAllOrganizations(ctx *gofr.Context, deps *AppDependencies) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logError(ctx, "Failed to create Neo4j session for audit log ingestion", LogFields{
+			"component": "audit_log",
+			"operation": "ingest_all",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildAuditLogOrganizationsQuery(), nil)
+	closeNeo4jSession(ctx, session)
+	if err != nil {
+		logError(ctx, "Failed to list organizations for audit log ingestion", LogFields{
+			"component": "audit_log",
+			"operation": "ingest_all",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	for _, record := range result.Records {
+		org := getStringFromMap(record, "organization")
+		if _, err := ingestAuditLog(ctx, deps, org); err != nil {
+			logWarn(ctx, "Audit log ingestion failed", LogFields{
+				"component":    "audit_log",
+				"operation":    "ingest_all",
+				"organization": org,
+				"error":        err.Error(),
+			})
+		}
+	}
+}
+
+// fetchOwnershipHistory retrieves an organization's ownership timeline (Orchestrator)
+func fetchOwnershipHistory(ctx *gofr.Context, deps *AppDependencies, orgName, team, repository string, limit int) (OwnershipHistoryResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnershipHistoryResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOwnershipHistoryQuery(), map[string]interface{}{
+		"orgName":    orgName,
+		"team":       team,
+		"repository": repository,
+		"limit":      limit,
+	})
+	if err != nil {
+		return OwnershipHistoryResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return OwnershipHistoryResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	response := OwnershipHistoryResponse{Organization: orgName, Events: []OwnershipEvent{}}
+	for _, event := range getMapSliceFromMap(result.Records[0], "events") {
+		response.Events = append(response.Events, OwnershipEvent{
+			ID:         getStringFromMap(event, "id"),
+			Action:     getStringFromMap(event, "action"),
+			Actor:      getStringFromMap(event, "actor"),
+			Team:       getStringFromMap(event, "team"),
+			User:       getStringFromMap(event, "user"),
+			Repository: getStringFromMap(event, "repository"),
+			Source:     getStringFromMap(event, "source"),
+			OccurredAt: getStringFromMap(event, "occurred_at"),
+		})
+	}
+	return response, nil
+}
+
+// handleIngestAuditLog backfills an organization's ownership timeline from the GitHub Enterprise audit log
+func (h *AppHandler) handleIngestAuditLog(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return ingestAuditLog(ctx, h.deps, orgName)
+}
+
+// handleGetOwnershipHistory returns an organization's ownership timeline, optionally for one team or repository
+func (h *AppHandler) handleGetOwnershipHistory(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	limit := defaultOwnershipHistoryLimit
+	if value := ctx.Param("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+		limit = parsed
+	}
+
+	return fetchOwnershipHistory(ctx, h.deps, orgName, ctx.Param("team"), ctx.Param("repository"), limit)
+}
+
+// registerAuditLogIngestion schedules audit log ingestion for every organization when enabled
+func registerAuditLogIngestion(app *gofr.App, deps *AppDependencies) {
+	config := deps.Config.AuditLog
+	if !config.Enabled {
+		return
+	}
+
+	app.AddCronJob(config.Schedule, "audit-log-ingestion", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "audit_log_ingestion", func() {
+			runAsLeader(ctx, deps, "audit_log_ingestion", func() {
+				ingestAuditLogForAllOrganizations(ctx, deps)
+			})
+		})
+	})
+}
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Ways a caller can authenticate, recorded on its principal
+const (
+	authMethodAPIKey = "api_key"
+	authMethodOIDC   = "oidc"
+)
+
+// oidcClockSkew is the leeway given to the exp and nbf claims of bearer tokens
+const oidcClockSkew = time.Minute
+
+// oidcKeysTTL is how long the identity provider's signing keys are used before they are fetched again
+const oidcKeysTTL = time.Hour
+
+// oidcKeyRefreshInterval limits how often a token signed with an unknown key fetches the keys again
+const oidcKeyRefreshInterval = time.Minute
+
+// AuthPrincipal is the authenticated caller of a request and the role it was granted
+type AuthPrincipal struct {
+	Method  string
+	Subject string
+	Role    string
+	Claims  map[string]interface{}
+	// MissingRoleClaim reports an OIDC token whose roles claim listed neither role
+	MissingRoleClaim bool
+}
+
+// authPrincipalContextKey stores the authenticated caller in the request context
+type authPrincipalContextKey struct{}
+
+// AuthError is a request the authentication middleware rejected: 401 when the caller could not be
+// authenticated, 403 when it was but its credentials are not meant for this API
+type AuthError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Error implements the error interface
+func (e AuthError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// Rejections of the authentication middleware
+var (
+	errAuthMissing = AuthError{Status: http.StatusUnauthorized, Code: "missing_credentials",
+		Message: "authentication required: send an API key in X-API-Key or a bearer token in Authorization"}
+	errAuthInvalidAPIKey = AuthError{Status: http.StatusUnauthorized, Code: "invalid_api_key", Message: "API key is not valid"}
+)
+
+// newInvalidTokenError rejects a bearer token that could not be verified (Pure Core)
+func newInvalidTokenError(reason string) AuthError {
+	return AuthError{Status: http.StatusUnauthorized, Code: "invalid_token", Message: "bearer token is not valid: " + reason}
+}
+
+// isAuthEnabled reports whether requests must authenticate: API keys or an OIDC issuer are configured (Pure Core)
+func isAuthEnabled(config AuthConfig) bool {
+	return len(config.APIKeys) > 0 || len(config.OperatorAPIKeys) > 0 || config.OIDC.Issuer != ""
+}
+
+// isAuthExemptPath reports whether a path is served without authentication: the GitHub webhook
+// listener, which verifies its own signature, and the configured exempt paths; an exempt path ending
+// in /* covers everything below it (Pure Core)
+func isAuthExemptPath(path string, exemptPaths []string) bool {
+	if path == githubWebhookPath {
+		return true
+	}
+	for _, exempt := range exemptPaths {
+		if prefix, wildcard := strings.CutSuffix(exempt, "/*"); wildcard {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if path == exempt {
+			return true
+		}
+	}
+	return false
+}
+
+// extractBearerToken returns the token of an Authorization: Bearer header (Pure Core)
+func extractBearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// isJWT reports whether a bearer token has the three segments of a JWS compact serialization (Pure Core)
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// matchAPIKey reports whether apiKey is one of the accepted keys, comparing in constant time (Pure Core)
+func matchAPIKey(apiKey string, keys []string) bool {
+	matched := false
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// authenticateAPIKey returns the principal of an API key: operator keys and the admin keys of team
+// visibility are granted the operator role, the other configured keys the reader role (Pure Core)
+func authenticateAPIKey(apiKey string, config AppConfig) (AuthPrincipal, error) {
+	principal := AuthPrincipal{Method: authMethodAPIKey, Subject: tenantFromAPIKey(apiKey)}
+	switch {
+	case matchAPIKey(apiKey, config.Auth.OperatorAPIKeys), matchAPIKey(apiKey, config.TeamVisibility.AdminAPIKeys):
+		principal.Role = roleOperator
+	case matchAPIKey(apiKey, config.Auth.APIKeys):
+		principal.Role = roleReader
+	default:
+		return AuthPrincipal{}, errAuthInvalidAPIKey
+	}
+	return principal, nil
+}
+
+// authenticateRequest authenticates a request with an API key in X-API-Key, or a bearer token that is
+// an OIDC ID or access token when an issuer is configured and an API key otherwise; a token whose roles
+// claim lists neither role is granted the configured default role
+func authenticateRequest(ctx context.Context, r *http.Request, config AppConfig, verifier *OIDCVerifier) (AuthPrincipal, error) {
+	if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+		return authenticateAPIKey(apiKey, config)
+	}
+
+	token, ok := extractBearerToken(r.Header.Get("Authorization"))
+	if !ok {
+		return AuthPrincipal{}, errAuthMissing
+	}
+	if verifier == nil || !isJWT(token) {
+		return authenticateAPIKey(token, config)
+	}
+
+	claims, err := verifier.verify(ctx, token, time.Now())
+	if err != nil {
+		return AuthPrincipal{}, err
+	}
+	subject, _ := claims["sub"].(string)
+	principal := AuthPrincipal{
+		Method:  authMethodOIDC,
+		Subject: subject,
+		Role:    roleFromClaims(claims, config.Auth.OIDC.RolesClaim),
+		Claims:  claims,
+	}
+	if principal.Role == "" {
+		principal.Role = config.Auth.OIDC.DefaultRole
+		principal.MissingRoleClaim = true
+	}
+	return principal, nil
+}
+
+// authMiddleware rejects requests without valid credentials once API keys or an OIDC issuer are
+// configured, and attaches the authenticated caller to the request context
+func authMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			config := deps.currentConfig()
+			if !isAuthEnabled(config.Auth) || isAuthExemptPath(r.URL.Path, config.Auth.ExemptPaths) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authenticateRequest(r.Context(), r, config, deps.OIDC)
+			if err != nil {
+				writeAuthError(w, err)
+				return
+			}
+			inner.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authPrincipalContextKey{}, principal)))
+		})
+	}
+}
+
+// authPrincipalFromContext returns the authenticated caller of a request, if authentication is enabled
+func authPrincipalFromContext(ctx context.Context) (AuthPrincipal, bool) {
+	principal, ok := ctx.Value(authPrincipalContextKey{}).(AuthPrincipal)
+	return principal, ok
+}
+
+// writeAuthError writes a rejected request in GoFr's error envelope, with the structured error fields
+// of AppError
+func writeAuthError(w http.ResponseWriter, err error) {
+	var authErr AuthError
+	if !errors.As(err, &authErr) {
+		authErr = AuthError{Status: http.StatusServiceUnavailable, Code: "identity_provider_unavailable",
+			Message: "bearer tokens cannot be verified right now"}
+	}
+
+	appErr := newAppError(ErrorCategoryValidation, ErrorTypeAuthentication, authErr.Code, authErr.Message, "")
+	appErr.HTTPStatus = authErr.Status
+	if authErr.Status == http.StatusServiceUnavailable {
+		appErr.Category, appErr.Type, appErr.Retryable = ErrorCategoryExternal, ErrorTypeExternal, true
+		appErr.Details = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if authErr.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error=%q`, authErr.Code))
+	}
+	w.WriteHeader(authErr.Status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": appErr})
+}
+
+// OIDCVerifier verifies bearer tokens signed by an OpenID Connect provider, caching its signing keys
+type OIDCVerifier struct {
+	mu        sync.Mutex
+	config    OIDCConfig
+	client    *http.Client
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// newOIDCVerifier creates a verifier for the configured issuer, or nil when none is configured; the
+// provider is first contacted when a token arrives
+func newOIDCVerifier(config OIDCConfig) *OIDCVerifier {
+	if config.Issuer == "" {
+		return nil
+	}
+	return &OIDCVerifier{config: config, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// jwtHeader is the protected header of a JWS
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verify checks a token's signature against the provider's keys and its iss, exp, nbf and aud claims,
+// returning its claims
+func (v *OIDCVerifier) verify(ctx context.Context, token string, now time.Time) (map[string]interface{}, error) {
+	segments := strings.Split(token, ".")
+	var header jwtHeader
+	if err := decodeJWTSegment(segments[0], &header); err != nil {
+		return nil, newInvalidTokenError("malformed header")
+	}
+	var claims map[string]interface{}
+	if err := decodeJWTSegment(segments[1], &claims); err != nil {
+		return nil, newInvalidTokenError("malformed claims")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, newInvalidTokenError("malformed signature")
+	}
+
+	key, err := v.signingKey(ctx, header.Kid, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJWTSignature(header.Alg, key, segments[0]+"."+segments[1], signature); err != nil {
+		return nil, newInvalidTokenError(err.Error())
+	}
+	if err := validateJWTClaims(claims, v.config, now); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// decodeJWTSegment decodes a base64url JSON segment of a JWS (Pure Core)
+func decodeJWTSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// verifyJWTSignature checks an RS256/384/512 or ES256/384/512 signature over the signing input (Pure Core)
+func verifyJWTSignature(alg string, key crypto.PublicKey, input string, signature []byte) error {
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(input))
+	digest := hasher.Sum(nil)
+
+	switch public := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %q does not match an RSA key", alg)
+		}
+		if rsa.VerifyPKCS1v15(public, hash, digest, signature) != nil {
+			return errors.New("signature mismatch")
+		}
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			return fmt.Errorf("algorithm %q does not match an EC key", alg)
+		}
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(public, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+	default:
+		return errors.New("unsupported key type")
+	}
+	return nil
+}
+
+// validateJWTClaims checks the issuer, lifetime and audience of verified claims; a token for another
+// audience is forbidden rather than unauthenticated (Pure Core)
+func validateJWTClaims(claims map[string]interface{}, config OIDCConfig, now time.Time) error {
+	if issuer, _ := claims["iss"].(string); issuer != config.Issuer {
+		return newInvalidTokenError("unexpected issuer")
+	}
+	expiry, ok := claims["exp"].(float64)
+	if !ok {
+		return newInvalidTokenError("missing exp claim")
+	}
+	if now.After(time.Unix(int64(expiry), 0).Add(oidcClockSkew)) {
+		return newInvalidTokenError("token expired")
+	}
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(notBefore), 0).Add(-oidcClockSkew)) {
+		return newInvalidTokenError("token not yet valid")
+	}
+
+	if config.Audience == "" {
+		return nil
+	}
+	audiences := []string{}
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = append(audiences, aud)
+	case []interface{}:
+		for _, value := range aud {
+			if audience, ok := value.(string); ok {
+				audiences = append(audiences, audience)
+			}
+		}
+	}
+	for _, audience := range audiences {
+		if audience == config.Audience {
+			return nil
+		}
+	}
+	return AuthError{Status: http.StatusForbidden, Code: "invalid_audience", Message: "bearer token was not issued for this API"}
+}
+
+// signingKey returns the provider key with a key ID, fetching the keys when they are stale or, at most
+// once a minute, when the ID is unknown; a token without a key ID uses the provider's only key
+func (v *OIDCVerifier) signingKey(ctx context.Context, kid string, now time.Time) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	stale := now.Sub(v.fetchedAt) > oidcKeysTTL
+	_, known := v.keys[kid]
+	if stale || (!known && kid != "" && now.Sub(v.fetchedAt) > oidcKeyRefreshInterval) {
+		keys, err := v.fetchKeys(ctx)
+		switch {
+		case err == nil:
+			v.keys, v.fetchedAt = keys, now
+		case v.keys == nil:
+			return nil, err
+		default:
+			// Keep the known keys while the provider is unreachable, trying again in a minute
+			v.fetchedAt = now.Add(oidcKeyRefreshInterval - oidcKeysTTL)
+		}
+	}
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	return nil, newInvalidTokenError("unknown signing key")
+}
+
+// fetchKeys reads the provider's JSON Web Key Set, discovering its location from the issuer's
+// OpenID configuration unless OIDC_JWKS_URL sets it
+func (v *OIDCVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	jwksURL := v.config.JWKSURL
+	if jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(ctx, strings.TrimSuffix(v.config.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, err
+		}
+		if discovery.Issuer != v.config.Issuer || discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("OpenID configuration of %s names issuer %q and no usable jwks_uri", v.config.Issuer, discovery.Issuer)
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, jwksURL, &set); err != nil {
+		return nil, err
+	}
+	return parseJSONWebKeys(set.Keys), nil
+}
+
+// getJSON fetches and decodes a JSON document from the identity provider
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, target interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	response, err := v.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", url, response.StatusCode)
+	}
+	return json.NewDecoder(response.Body).Decode(target)
+}
+
+// jsonWebKey is the subset of a JSON Web Key describing an RSA or EC signing key
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJSONWebKeys converts the signing keys of a key set by key ID, skipping encryption keys and
+// keys it cannot read (Pure Core)
+func parseJSONWebKeys(keys []jsonWebKey) map[string]crypto.PublicKey {
+	parsed := make(map[string]crypto.PublicKey, len(keys))
+	for _, key := range keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if public, ok := parseJSONWebKey(key); ok {
+			parsed[key.Kid] = public
+		}
+	}
+	return parsed
+}
+
+// parseJSONWebKey converts an RSA or P-256/384/521 EC JSON Web Key (Pure Core)
+func parseJSONWebKey(key jsonWebKey) (crypto.PublicKey, bool) {
+	decode := func(value string) (*big.Int, bool) {
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		return new(big.Int).SetBytes(data), err == nil && len(data) > 0
+	}
+
+	switch key.Kty {
+	case "RSA":
+		n, okN := decode(key.N)
+		e, okE := decode(key.E)
+		if !okN || !okE || !e.IsInt64() {
+			return nil, false
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, true
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, known := curves[key.Crv]
+		x, okX := decode(key.X)
+		y, okY := decode(key.Y)
+		if !known || !okX || !okY {
+			return nil, false
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Roles granted to authenticated callers; an operator may do everything a reader may
+const (
+	roleReader   = "reader"
+	roleOperator = "operator"
+)
+
+// roleRanks orders roles so a higher role satisfies a lower requirement
+var roleRanks = map[string]int{roleReader: 1, roleOperator: 2}
+
+// readOnlyPostPaths are POST routes that compute an answer without changing anything, so readers may
+// call them and they stay available during maintenance
+var readOnlyPostPaths = map[string]bool{
+	"/api/owners/resolve":      true,
+	"/api/validate/codeowners": true,
+}
+
+// requiredRole returns the role a request needs: operator for scans, admin routes and every other
+// request that changes state, reader for the rest, including reading scan jobs (Pure Core)
+func requiredRole(method, path string) string {
+	switch {
+	case method == http.MethodGet && strings.HasPrefix(path, "/api/scan/jobs/"):
+		return roleReader
+	case path == "/api/scan" || strings.HasPrefix(path, "/api/scan/"):
+		return roleOperator
+	case path == "/api/admin" || strings.HasPrefix(path, "/api/admin/"):
+		return roleOperator
+	case method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions:
+		return roleReader
+	case method == http.MethodPost && readOnlyPostPaths[path]:
+		return roleReader
+	default:
+		return roleOperator
+	}
+}
+
+// hasRole reports whether a granted role satisfies a required one (Pure Core)
+func hasRole(granted, required string) bool {
+	return roleRanks[granted] >= roleRanks[required]
+}
+
+// roleFromClaims returns the highest known role listed in a token claim, which is a string or a list
+// and may be nested, e.g. realm_access.roles; a token without one is granted no role (Pure Core)
+func roleFromClaims(claims map[string]interface{}, claimPath string) string {
+	var value interface{} = claims
+	for _, key := range strings.Split(claimPath, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+
+	roles := []string{}
+	switch listed := value.(type) {
+	case string:
+		roles = strings.Fields(listed)
+	case []interface{}:
+		for _, entry := range listed {
+			if role, ok := entry.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	granted := ""
+	for _, role := range roles {
+		if roleRanks[role] > roleRanks[granted] {
+			granted = role
+		}
+	}
+	return granted
+}
+
+// authorizationMiddleware rejects authenticated callers whose role does not allow the request with
+// 403; requests the authentication middleware let through without a principal are not checked
+func authorizationMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, authenticated := authPrincipalFromContext(r.Context())
+			if !authenticated {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			required := requiredRole(r.Method, r.URL.Path)
+			if !hasRole(principal.Role, required) {
+				authErr := buildInsufficientRoleError(r.Method, r.URL.Path, required, principal, deps.currentConfig().Auth.OIDC.RolesClaim)
+				logWarn(deps.requestContext(r), "Request rejected for its role", LogFields{
+					"component": "authorization",
+					"operation": "authorize",
+					"method":    r.Method,
+					"path":      r.URL.Path,
+					"subject":   principal.Subject,
+					"role":      principal.Role,
+					"required":  required,
+					"code":      authErr.Code,
+				})
+				writeAuthError(w, authErr)
+				return
+			}
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
+
+// buildInsufficientRoleError builds the 403 of a caller whose role does not allow a request; an OIDC
+// token that was granted no role names the claim it lacks (Pure Core)
+func buildInsufficientRoleError(method, path, required string, principal AuthPrincipal, rolesClaim string) AuthError {
+	if principal.MissingRoleClaim && principal.Role == "" {
+		return AuthError{
+			Status: http.StatusForbidden,
+			Code:   "missing_role_claim",
+			Message: fmt.Sprintf("%s %s requires the %s role, and the token's %q claim lists neither %s nor %s",
+				method, path, required, rolesClaim, roleReader, roleOperator),
+		}
+	}
+	return AuthError{
+		Status:  http.StatusForbidden,
+		Code:    "insufficient_role",
+		Message: fmt.Sprintf("%s %s requires the %s role", method, path, required),
+	}
+}
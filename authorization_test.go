@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   string
+	}{
+		{name: "read graph", method: http.MethodGet, path: "/api/graph/acme", want: roleReader},
+		{name: "head stats", method: http.MethodHead, path: "/api/stats/acme", want: roleReader},
+		{name: "options preflight", method: http.MethodOptions, path: "/api/graph/acme", want: roleReader},
+		{name: "poll scan job", method: http.MethodGet, path: "/api/scan/jobs/abc123", want: roleReader},
+		{name: "scan organization", method: http.MethodPost, path: "/api/scan/acme", want: roleOperator},
+		{name: "scan several organizations", method: http.MethodPost, path: "/api/scan", want: roleOperator},
+		{name: "post to scan jobs", method: http.MethodPost, path: "/api/scan/jobs/abc123", want: roleOperator},
+		{name: "read admin route", method: http.MethodGet, path: "/api/admin/maintenance", want: roleOperator},
+		{name: "admin root", method: http.MethodGet, path: "/api/admin", want: roleOperator},
+		{name: "resolve owners", method: http.MethodPost, path: "/api/owners/resolve", want: roleReader},
+		{name: "validate codeowners", method: http.MethodPost, path: "/api/validate/codeowners", want: roleReader},
+		{name: "publish snapshot", method: http.MethodPost, path: "/api/publish/acme", want: roleOperator},
+		{name: "delete", method: http.MethodDelete, path: "/api/orgs/acme", want: roleOperator},
+		{name: "scan prefix is not a scan route", method: http.MethodGet, path: "/api/scanner", want: roleReader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requiredRole(tt.method, tt.path); got != tt.want {
+				t.Errorf("requiredRole(%s, %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasRole(t *testing.T) {
+	tests := []struct {
+		granted  string
+		required string
+		want     bool
+	}{
+		{granted: roleOperator, required: roleReader, want: true},
+		{granted: roleOperator, required: roleOperator, want: true},
+		{granted: roleReader, required: roleReader, want: true},
+		{granted: roleReader, required: roleOperator, want: false},
+		{granted: "", required: roleReader, want: false},
+		{granted: "admin", required: roleReader, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := hasRole(tt.granted, tt.required); got != tt.want {
+			t.Errorf("hasRole(%q, %q) = %v, want %v", tt.granted, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestBuildInsufficientRoleError(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal AuthPrincipal
+		wantCode  string
+		wantClaim bool
+	}{
+		{name: "reader key", principal: AuthPrincipal{Method: authMethodAPIKey, Role: roleReader}, wantCode: "insufficient_role"},
+		{name: "token granted the default role", principal: AuthPrincipal{Method: authMethodOIDC, Role: roleReader, MissingRoleClaim: true}, wantCode: "insufficient_role"},
+		{name: "token without a role", principal: AuthPrincipal{Method: authMethodOIDC, MissingRoleClaim: true}, wantCode: "missing_role_claim", wantClaim: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildInsufficientRoleError(http.MethodPost, "/api/scan/acme", roleOperator, tt.principal, "realm_access.roles")
+			if got.Status != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", got.Status, http.StatusForbidden)
+			}
+			if got.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", got.Code, tt.wantCode)
+			}
+			if named := strings.Contains(got.Message, `"realm_access.roles"`); named != tt.wantClaim {
+				t.Errorf("message %q names the claim = %v, want %v", got.Message, named, tt.wantClaim)
+			}
+		})
+	}
+}
+
+func TestParseOIDCDefaultRole(t *testing.T) {
+	tests := map[string]string{
+		roleReader:   roleReader,
+		roleOperator: roleOperator,
+		"none":       "",
+		"None":       "",
+	}
+
+	for value, want := range tests {
+		if got := parseOIDCDefaultRole(value); got != want {
+			t.Errorf("parseOIDCDefaultRole(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
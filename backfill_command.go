@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Exit codes of the backfill command
+const (
+	backfillExitSuccess = 0
+	backfillExitFailed  = 1
+	backfillExitUsage   = 2
+)
+
+// Intervals between backfilled snapshots
+const (
+	BackfillIntervalDaily   = "daily"
+	BackfillIntervalWeekly  = "weekly"
+	BackfillIntervalMonthly = "monthly"
+)
+
+// BackfillCommandOptions represents the flags of the backfill command
+type BackfillCommandOptions struct {
+	Organization string
+	Since        time.Time
+	Interval     string
+	MaxRepos     int
+	Throttle     time.Duration
+}
+
+// BackfillSnapshot is the approximate ownership of an organization at a historical date
+type BackfillSnapshot struct {
+	Organization        string `json:"organization"`
+	ScanID              string `json:"scan_id"`
+	Date                string `json:"date"`
+	RepositoryCount     int    `json:"repository_count"`
+	ReposWithCodeowners int    `json:"repos_with_codeowners"`
+	RuleCount           int    `json:"rule_count"`
+	Errors              int    `json:"errors"`
+	Error               string `json:"error,omitempty"`
+}
+
+// historicalCodeowners is the CODEOWNERS file of a repository at a historical commit
+type historicalCodeowners struct {
+	found     bool
+	ruleCount int
+}
+
+// parseBackfillCommandOptions parses the backfill command flags (Pure Core)
+func parseBackfillCommandOptions(args []string, now time.Time) (BackfillCommandOptions, error) {
+	var options BackfillCommandOptions
+	var since string
+
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&options.Organization, "org", "", "GitHub organization to backfill")
+	flags.StringVar(&since, "since", "", "first snapshot date (YYYY-MM-DD)")
+	flags.StringVar(&options.Interval, "interval", BackfillIntervalMonthly, "time between snapshots: daily, weekly or monthly")
+	flags.IntVar(&options.MaxRepos, "max-repos", 100, "maximum number of repositories to reconstruct")
+	flags.DurationVar(&options.Throttle, "throttle", 250*time.Millisecond, "pause between GitHub requests")
+
+	if err := flags.Parse(args); err != nil {
+		return BackfillCommandOptions{}, err
+	}
+	if options.Organization == "" {
+		return BackfillCommandOptions{}, errors.New("missing required flag --org")
+	}
+	if since == "" {
+		return BackfillCommandOptions{}, errors.New("missing required flag --since")
+	}
+	parsed, err := time.Parse(time.DateOnly, since)
+	if err != nil {
+		return BackfillCommandOptions{}, errors.New("--since must be a date such as 2023-01-01")
+	}
+	if !parsed.Before(now) {
+		return BackfillCommandOptions{}, errors.New("--since must be in the past")
+	}
+	options.Since = parsed
+
+	switch options.Interval {
+	case BackfillIntervalDaily, BackfillIntervalWeekly, BackfillIntervalMonthly:
+	default:
+		return BackfillCommandOptions{}, errors.New("--interval must be daily, weekly or monthly")
+	}
+	if options.MaxRepos <= 0 {
+		return BackfillCommandOptions{}, errors.New("--max-repos must be positive")
+	}
+	if options.Throttle < 0 {
+		return BackfillCommandOptions{}, errors.New("--throttle cannot be negative")
+	}
+
+	return options, nil
+}
+
+// buildBackfillDates returns the snapshot dates from since, one interval apart, strictly before now;
+// monthly dates keep the day of since, clamped to the last day of shorter months (Pure Core)
+func buildBackfillDates(since time.Time, interval string, now time.Time) []time.Time {
+	var dates []time.Time
+	for step := 0; ; step++ {
+		var date time.Time
+		switch interval {
+		case BackfillIntervalDaily:
+			date = since.AddDate(0, 0, step)
+		case BackfillIntervalWeekly:
+			date = since.AddDate(0, 0, 7*step)
+		default:
+			first := time.Date(since.Year(), since.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+			lastDay := first.AddDate(0, 1, -1).Day()
+			date = first.AddDate(0, 0, min(since.Day(), lastDay)-1)
+		}
+		if !date.Before(now) {
+			return dates
+		}
+		dates = append(dates, date)
+	}
+}
+
+// buildBackfillScanID builds the identifier of a backfilled snapshot; reruns overwrite the same date (Pure Core)
+func buildBackfillScanID(orgLogin string, date time.Time) string {
+	validateOrgLoginNotEmpty(orgLogin)
+	return fmt.Sprintf("%s-backfill-%s", orgLogin, date.UTC().Format(time.DateOnly))
+}
+
+// buildStoreBackfillSnapshotQuery builds a query recording a backfilled snapshot as a Scan with status
+// 'backfilled' activated at the historical date: coverage trends include it, but it holds no graph
+// data and never becomes the active scan (Pure Core)
+func buildStoreBackfillSnapshotQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})
+		MERGE (scan:Scan {id: $scan_id})
+		SET scan.organization = $org_login,
+			scan.status = 'backfilled',
+			scan.started_at = $date,
+			scan.activated_at = $date,
+			scan.repository_count = $repository_count,
+			scan.repos_with_codeowners = $repos_with_codeowners,
+			scan.rule_count = $rule_count,
+			scan.backfilled_at = $backfilled_at
+		MERGE (org)-[:HAS_SCAN]->(scan)
+		RETURN scan.id AS scan_id
+	`
+}
+
+// backfillPause waits for the throttle between GitHub requests, returning early when ctx is done
+func backfillPause(ctx context.Context, throttle time.Duration) error {
+	if throttle <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(throttle):
+		return nil
+	}
+}
+
+// fetchCommitAt returns the SHA of the last default branch commit of a repository at a date, or ""
+// when the repository had no commits yet
+func fetchCommitAt(ctx *gofr.Context, fullName string, date time.Time) (string, error) {
+	resp, err := githubGet(ctx, fmt.Sprintf("repos/%s/commits", fullName), map[string]any{
+		"until":    date.UTC().Format(time.RFC3339),
+		"per_page": 1,
+	}, buildGitHubRequestHeaders())
+	if err != nil {
+		return "", err
+	}
+	logRateLimitInfo(ctx, resp)
+
+	// GitHub answers 409 for repositories without any commit
+	if resp.StatusCode == http.StatusConflict {
+		resp.Body.Close()
+		return "", nil
+	}
+
+	var commits []struct {
+		SHA string `json:"sha"`
+	}
+	if err := decodeGitHubResponse(resp, "list_commits", &commits); err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].SHA, nil
+}
+
+// fetchCodeownersAtCommit reads the CODEOWNERS file of a repository at a commit, checking the
+// locations in order of precedence
+func fetchCodeownersAtCommit(ctx *gofr.Context, fullName, sha string, throttle time.Duration) (historicalCodeowners, error) {
+	for _, location := range currentCodeownersSearch().locations {
+		if err := backfillPause(ctx, throttle); err != nil {
+			return historicalCodeowners{}, err
+		}
+
+		resp, err := githubGet(ctx, fmt.Sprintf("repos/%s/contents/%s", fullName, location.path), map[string]any{
+			"ref": sha,
+		}, buildGitHubRequestHeaders())
+		if err != nil {
+			return historicalCodeowners{}, err
+		}
+		logRateLimitInfo(ctx, resp)
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			continue
+		}
+
+		var file struct {
+			Content string `json:"content"`
+		}
+		if err := decodeGitHubResponse(resp, "get_historical_codeowners", &file); err != nil {
+			return historicalCodeowners{}, err
+		}
+		content, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return historicalCodeowners{}, fmt.Errorf("failed to decode %s of %s at %s: %w", location.path, fullName, sha, err)
+		}
+		return historicalCodeowners{found: true, ruleCount: len(parseCodeownersLines(string(content)))}, nil
+	}
+	return historicalCodeowners{}, nil
+}
+
+// reconstructSnapshot reads the CODEOWNERS file every repository had at a date; repositories created
+// later are left out and repositories that cannot be read are counted as errors
+func reconstructSnapshot(ctx *gofr.Context, orgLogin string, repos []GitHubRepository, date time.Time, throttle time.Duration) (BackfillSnapshot, error) {
+	snapshot := BackfillSnapshot{
+		Organization: orgLogin,
+		ScanID:       buildBackfillScanID(orgLogin, date),
+		Date:         date.UTC().Format(time.DateOnly),
+	}
+
+	for _, repo := range repos {
+		if repo.CreatedAt.After(date) {
+			continue
+		}
+		snapshot.RepositoryCount++
+
+		if err := backfillPause(ctx, throttle); err != nil {
+			return BackfillSnapshot{}, err
+		}
+		sha, err := fetchCommitAt(ctx, repo.FullName, date)
+		if err == nil && sha != "" {
+			var codeowners historicalCodeowners
+			if codeowners, err = fetchCodeownersAtCommit(ctx, repo.FullName, sha, throttle); err == nil && codeowners.found {
+				snapshot.ReposWithCodeowners++
+				snapshot.RuleCount += codeowners.ruleCount
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return BackfillSnapshot{}, ctx.Err()
+			}
+			snapshot.Errors++
+			logWarn(ctx, "Failed to read historical CODEOWNERS", LogFields{
+				"component":  "backfill",
+				"operation":  "reconstruct_snapshot",
+				"repository": repo.FullName,
+				"date":       snapshot.Date,
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	return snapshot, nil
+}
+
+// storeBackfillSnapshot records a reconstructed snapshot (Orchestrator)
+func storeBackfillSnapshot(ctx *gofr.Context, deps *AppDependencies, snapshot BackfillSnapshot, date time.Time) error {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return fmt.Errorf("failed to create Neo4j session: %w", err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	_, err = executeNeo4jWrite(ctx, session, buildStoreBackfillSnapshotQuery(), map[string]interface{}{
+		"org_login":             snapshot.Organization,
+		"scan_id":               snapshot.ScanID,
+		"date":                  date.UTC().Format(time.RFC3339),
+		"repository_count":      snapshot.RepositoryCount,
+		"repos_with_codeowners": snapshot.ReposWithCodeowners,
+		"rule_count":            snapshot.RuleCount,
+		"backfilled_at":         time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store backfilled snapshot %s: %w", snapshot.ScanID, err)
+	}
+	return nil
+}
+
+// writeBackfillSnapshot writes a snapshot to stdout as a single JSON line
+func writeBackfillSnapshot(snapshot BackfillSnapshot) {
+	if err := json.NewEncoder(os.Stdout).Encode(snapshot); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write backfill snapshot: %v\n", err)
+	}
+}
+
+// runBackfill reconstructs and stores one snapshot per date, oldest first, returning the exit code
+func runBackfill(ctx *gofr.Context, deps *AppDependencies, options BackfillCommandOptions) int {
+	org, err := fetchGitHubOrganizationWithService(ctx, options.Organization)
+	if err == nil {
+		err = storeOrganizationRecord(ctx, deps, org)
+	}
+	var repos []GitHubRepository
+	if err == nil {
+		repos, err = fetchGitHubRepositoriesWithService(ctx, options.Organization, options.MaxRepos)
+	}
+	if err != nil {
+		writeBackfillSnapshot(BackfillSnapshot{Organization: options.Organization, Error: err.Error()})
+		return backfillExitFailed
+	}
+
+	for _, date := range buildBackfillDates(options.Since, options.Interval, time.Now()) {
+		snapshot, err := reconstructSnapshot(ctx, org.Login, repos, date, options.Throttle)
+		if err == nil {
+			err = storeBackfillSnapshot(ctx, deps, snapshot, date)
+		}
+		if err != nil {
+			writeBackfillSnapshot(BackfillSnapshot{Organization: org.Login, Date: date.UTC().Format(time.DateOnly), Error: err.Error()})
+			return backfillExitFailed
+		}
+		writeBackfillSnapshot(snapshot)
+	}
+
+	return backfillExitSuccess
+}
+
+// storeOrganizationRecord stores the organization node snapshots are attached to (Orchestrator)
+func storeOrganizationRecord(ctx *gofr.Context, deps *AppDependencies, org GitHubOrganization) error {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return fmt.Errorf("failed to create Neo4j session: %w", err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	return storeOrganization(ctx, session, org)
+}
+
+// runBackfillCommand reconstructs approximate historical ownership (backfill --org acme --since
+// 2023-01-01 --interval monthly) by reading CODEOWNERS at historical commits, so coverage trends have
+// history before the first scan
+func runBackfillCommand(args []string) int {
+	ctx := context.Background()
+
+	options, err := parseBackfillCommandOptions(args, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nUsage: backfill --org <organization> --since <YYYY-MM-DD> [--interval daily|weekly|monthly] [--max-repos 100] [--throttle 250ms]\n", err)
+		return backfillExitUsage
+	}
+
+	deps, err := createAppDependencies(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create app dependencies: %v\n", err)
+		return backfillExitFailed
+	}
+	defer func() {
+		if err := cleanupAppDependencies(ctx, deps); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to cleanup dependencies: %v\n", err)
+		}
+	}()
+
+	// GoFr routes commands from os.Args, so only the command name is left for it to match
+	os.Args = []string{os.Args[0], "backfill"}
+
+	app := gofr.NewCMD()
+	registerGitHubService(app, deps.Config.GitHub)
+
+	exitCode := backfillExitFailed
+	app.SubCommand("backfill", func(ctx *gofr.Context) (interface{}, error) {
+		exitCode = runBackfill(ctx, deps, options)
+		return nil, nil
+	})
+	app.Run()
+
+	return exitCode
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// bitbucketPageSize is the page size of Bitbucket list requests, the API's maximum
+const bitbucketPageSize = 100
+
+// bitbucketMaxCodeownersBytes caps how much of a CODEOWNERS file is read, like GitHub's 3 MB limit
+const bitbucketMaxCodeownersBytes = 3 << 20
+
+// bitbucketCodeownersLocations are the paths a CODEOWNERS file is read from, in order of precedence
+var bitbucketCodeownersLocations = []string{".bitbucket/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// BitbucketAPIError represents Bitbucket API errors that implement GoFr error patterns
+type BitbucketAPIError struct {
+	AppError
+}
+
+// newBitbucketAPIError creates an external error answered with status, classified like GitHub errors (Pure Core)
+func newBitbucketAPIError(code, message, details string, status int) BitbucketAPIError {
+	return BitbucketAPIError{AppError: newGitHubAPIError(code, message, details, status).AppError}
+}
+
+// Error implements the error interface for BitbucketAPIError
+func (e BitbucketAPIError) Error() string {
+	return fmt.Sprintf("Bitbucket API error [%s]: %s - %s", e.Code, e.Message, e.Details)
+}
+
+// bitbucketLinks are the links of a Bitbucket API object
+type bitbucketLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+// bitbucketWorkspace is a workspace of the Bitbucket workspaces API
+type bitbucketWorkspace struct {
+	UUID      string         `json:"uuid"`
+	Slug      string         `json:"slug"`
+	Name      string         `json:"name"`
+	Links     bitbucketLinks `json:"links"`
+	CreatedOn time.Time      `json:"created_on"`
+}
+
+// bitbucketRepository is a repository of the Bitbucket repositories API
+type bitbucketRepository struct {
+	UUID        string         `json:"uuid"`
+	Slug        string         `json:"slug"`
+	FullName    string         `json:"full_name"`
+	Description string         `json:"description"`
+	IsPrivate   bool           `json:"is_private"`
+	Language    string         `json:"language"`
+	Links       bitbucketLinks `json:"links"`
+	CreatedOn   time.Time      `json:"created_on"`
+	UpdatedOn   time.Time      `json:"updated_on"`
+	MainBranch  *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Project *struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Parent *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+}
+
+// bitbucketPage is a page of a paginated Bitbucket list endpoint
+type bitbucketPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// isBitbucketConfigured reports whether Bitbucket credentials are configured (Pure Core)
+func isBitbucketConfigured(config BitbucketConfig) bool {
+	return config.Token != "" || (config.Username != "" && config.AppPassword != "")
+}
+
+// registerBitbucketService registers Bitbucket Cloud as an HTTP service when its credentials are configured
+func registerBitbucketService(app *gofr.App, config BitbucketConfig) {
+	if !isBitbucketConfigured(config) {
+		return
+	}
+	app.AddHTTPService("bitbucket", config.BaseURL)
+	app.Logger().Infof("Scanning Bitbucket workspaces - component=bitbucket_client operation=register_service base_url=%s", config.BaseURL)
+}
+
+// buildBitbucketRequestHeaders builds the headers of Bitbucket API requests, authenticated with
+// BITBUCKET_TOKEN or, without it, BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD
+func buildBitbucketRequestHeaders() map[string]string {
+	headers := map[string]string{
+		"Accept":     "application/json",
+		"User-Agent": "overseer-codeowners-scanner/1.0",
+	}
+	if token := os.Getenv("BITBUCKET_TOKEN"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	} else if username, password := os.Getenv("BITBUCKET_USERNAME"), os.Getenv("BITBUCKET_APP_PASSWORD"); username != "" && password != "" {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+	return headers
+}
+
+// bitbucketGet performs a GET request through the registered Bitbucket service and accounts for the call
+func bitbucketGet(ctx *gofr.Context, endpoint string, query map[string]any) (*http.Response, error) {
+	resp, err := ctx.GetHTTPService("bitbucket").GetWithHeaders(ctx, endpoint, query, buildBitbucketRequestHeaders())
+	if err != nil {
+		return nil, err
+	}
+	recordGitHubAPICall(ctx)
+	newMetricsCollector(ctx, "codeowners-scanner").recordAPICallCount("bitbucket", endpoint, resp.StatusCode)
+	reportScanProgress(ctx, "")
+	return resp, nil
+}
+
+// checkBitbucketResponse returns the error of an unsuccessful Bitbucket response, closing its body
+func checkBitbucketResponse(resp *http.Response, operation string) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	details, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return newBitbucketAPIError(
+		"bitbucket_"+operation,
+		fmt.Sprintf("Bitbucket returned status %d", resp.StatusCode),
+		strings.TrimSpace(string(details)),
+		resp.StatusCode,
+	)
+}
+
+// decodeBitbucketResponse decodes a successful Bitbucket response into target and closes its body
+func decodeBitbucketResponse(resp *http.Response, operation string, target interface{}) error {
+	if err := checkBitbucketResponse(resp, operation); err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// nextBitbucketPage returns the page number of a page's next link, or 0 on the last page (Pure Core)
+func nextBitbucketPage(next string) int {
+	if next == "" {
+		return 0
+	}
+	parsed, err := url.Parse(next)
+	if err != nil {
+		return 0
+	}
+	page, _ := strconv.Atoi(parsed.Query().Get("page"))
+	return page
+}
+
+// fetchBitbucketPages lists every item of a paginated Bitbucket endpoint, up to limit items when
+// limit is positive; pages are followed through the page number of their next link
+func fetchBitbucketPages[T any](ctx *gofr.Context, endpoint string, query map[string]any, operation string, limit int) ([]T, error) {
+	items := []T{}
+	for page := 1; page > 0; {
+		pageQuery := map[string]any{"pagelen": bitbucketPageSize, "page": page}
+		for key, value := range query {
+			pageQuery[key] = value
+		}
+
+		resp, err := bitbucketGet(ctx, endpoint, pageQuery)
+		if err != nil {
+			return nil, err
+		}
+		var result bitbucketPage[T]
+		if err := decodeBitbucketResponse(resp, operation, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result.Values...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		page = nextBitbucketPage(result.Next)
+	}
+	return items, nil
+}
+
+// convertBitbucketWorkspace maps a workspace to the organization of the graph, keyed by its slug (Pure Core)
+func convertBitbucketWorkspace(workspace bitbucketWorkspace) GitHubOrganization {
+	return GitHubOrganization{
+		ID:        generateUserID(workspace.UUID),
+		Login:     workspace.Slug,
+		Name:      workspace.Name,
+		URL:       workspace.Links.HTML.Href,
+		CreatedAt: workspace.CreatedOn,
+		UpdatedAt: workspace.CreatedOn,
+	}
+}
+
+// convertBitbucketRepository maps a Bitbucket repository to a repository of the graph; its project
+// key is its only topic, so topic grouping groups repositories by project (Pure Core)
+func convertBitbucketRepository(repo bitbucketRepository) GitHubRepository {
+	visibility := "public"
+	if repo.IsPrivate {
+		visibility = "private"
+	}
+	topics := []string{}
+	if repo.Project != nil && repo.Project.Key != "" {
+		topics = append(topics, strings.ToLower(repo.Project.Key))
+	}
+
+	return GitHubRepository{
+		ID:          generateUserID(repo.UUID),
+		Name:        repo.Slug,
+		FullName:    repo.FullName,
+		Description: repo.Description,
+		URL:         repo.Links.HTML.Href,
+		Private:     repo.IsPrivate,
+		Visibility:  visibility,
+		Fork:        repo.Parent != nil,
+		Language:    repo.Language,
+		Topics:      topics,
+		CreatedAt:   repo.CreatedOn,
+		UpdatedAt:   repo.UpdatedOn,
+		PushedAt:    repo.UpdatedOn,
+	}
+}
+
+// splitBitbucketOwners splits the owners of a CODEOWNERS rule on whitespace, keeping quoted display
+// names such as @"Jane Doe" together without their quotes (Pure Core)
+func splitBitbucketOwners(value string) []string {
+	owners := []string{}
+	var current strings.Builder
+	quoted := false
+	for _, r := range value {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				owners = append(owners, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		owners = append(owners, current.String())
+	}
+	return owners
+}
+
+// parseBitbucketCodeowners parses a Bitbucket CODEOWNERS file; owners are users, e-mail addresses or
+// @workspace/group groups, and rules are numbered in file order like GitHub rules (Pure Core)
+func parseBitbucketCodeowners(content string) []GitHubCodeownersRule {
+	rules := []GitHubCodeownersRule{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, owners, _ := strings.Cut(strings.ReplaceAll(line, "\t", " "), " ")
+		rules = append(rules, GitHubCodeownersRule{
+			Pattern: pattern,
+			Owners:  splitBitbucketOwners(owners),
+			Line:    len(rules) + 1,
+		})
+	}
+	return rules
+}
+
+// collectCodeownerTeams builds the teams named as owners in CODEOWNERS files, for hosts without a
+// team API; their members are unknown (Pure Core)
+func collectCodeownerTeams(codeowners []GitHubCodeowners) []GitHubTeam {
+	seen := make(map[string]bool)
+	teams := []GitHubTeam{}
+	for _, file := range codeowners {
+		for _, rule := range file.Rules {
+			for _, owner := range rule.Owners {
+				if !isTeamOwner(owner) {
+					continue
+				}
+				slug := extractTeamSlug(owner)
+				if seen[slug] {
+					continue
+				}
+				seen[slug] = true
+				teams = append(teams, GitHubTeam{ID: generateUserID(slug), Slug: slug, Name: slug})
+			}
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Slug < teams[j].Slug })
+	return teams
+}
+
+// fetchBitbucketCodeowners reads the first CODEOWNERS file found in a repository's main branch among
+// the Bitbucket locations and the configured extra paths; repositories without one have no rules
+func fetchBitbucketCodeowners(ctx *gofr.Context, workspace string, repo bitbucketRepository) (GitHubCodeowners, error) {
+	codeowners := GitHubCodeowners{
+		Repository: repo.FullName,
+		Rules:      []GitHubCodeownersRule{},
+		Errors:     []GitHubCodeownersError{},
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return codeowners, nil
+	}
+
+	locations := append([]string{}, bitbucketCodeownersLocations...)
+	for _, location := range currentCodeownersSearch().locations[len(builtinCodeownersLocations):] {
+		locations = append(locations, location.path)
+	}
+
+	for _, location := range locations {
+		endpoint := fmt.Sprintf("repositories/%s/%s/src/%s/%s", url.PathEscape(workspace), url.PathEscape(repo.Slug), url.PathEscape(repo.MainBranch.Name), location)
+		resp, err := bitbucketGet(ctx, endpoint, nil)
+		if err != nil {
+			return codeowners, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			continue
+		}
+		if err := checkBitbucketResponse(resp, "get_codeowners"); err != nil {
+			return codeowners, err
+		}
+
+		content, err := io.ReadAll(io.LimitReader(resp.Body, bitbucketMaxCodeownersBytes))
+		resp.Body.Close()
+		if err != nil {
+			return codeowners, fmt.Errorf("failed to read CODEOWNERS of %s: %w", repo.FullName, err)
+		}
+
+		codeowners.Path, codeowners.Content = location, string(content)
+		codeowners.Rules = parseBitbucketCodeowners(codeowners.Content)
+		return codeowners, nil
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("codeowners_not_found", 1, MetricLabels{
+		"owner":      workspace,
+		"repository": repo.Slug,
+		"service":    "codeowners-scanner",
+	})
+	return codeowners, nil
+}
+
+// fetchBitbucketScanSource reads a Bitbucket Cloud workspace as an organization: its repositories,
+// their CODEOWNERS files and, as teams, the groups those files name; with topics, repositories are
+// grouped by project. Incremental scans are not supported (Orchestrator)
+func fetchBitbucketScanSource(ctx *gofr.Context, _ *AppDependencies, request ScanRequest) (scanSourceData, error) {
+	if request.Mode == ScanModeIncremental {
+		return scanSourceData{}, &gofrhttp.ErrorInvalidParam{Params: []string{"mode"}}
+	}
+	workspaceSlug := url.PathEscape(request.Organization)
+
+	reportScanProgress(ctx, ScanPhaseFetchOrganization)
+	resp, err := bitbucketGet(ctx, "workspaces/"+workspaceSlug, nil)
+	if err != nil {
+		return scanSourceData{}, err
+	}
+	var workspace bitbucketWorkspace
+	if err := decodeBitbucketResponse(resp, "get_workspace", &workspace); err != nil {
+		return scanSourceData{}, err
+	}
+	org := convertBitbucketWorkspace(workspace)
+
+	reportScanProgress(ctx, ScanPhaseFetchRepositories)
+	bitbucketRepos, err := fetchBitbucketPages[bitbucketRepository](ctx, "repositories/"+workspaceSlug, map[string]any{
+		"sort": "slug",
+	}, "list_repositories", request.MaxRepos)
+	if err != nil {
+		return scanSourceData{}, err
+	}
+	if err := reserveScanItems(ctx, ScanBufferRepositories, len(bitbucketRepos)); err != nil {
+		return scanSourceData{}, err
+	}
+	repos := make([]GitHubRepository, 0, len(bitbucketRepos))
+	for _, repo := range bitbucketRepos {
+		repos = append(repos, convertBitbucketRepository(repo))
+	}
+
+	reportScanProgress(ctx, ScanPhaseFetchCodeowners)
+	codeowners := []GitHubCodeowners{}
+	for i, repo := range bitbucketRepos {
+		reportScanBatchProgress(ctx, i, len(bitbucketRepos))
+		codeowner, err := fetchBitbucketCodeowners(ctx, workspace.Slug, repo)
+		if err != nil {
+			return scanSourceData{}, err
+		}
+		if len(codeowner.Rules) == 0 {
+			continue
+		}
+		if err := reserveScanItems(ctx, ScanBufferRules, len(codeowner.Rules)); err != nil {
+			return scanSourceData{}, err
+		}
+		codeowners = append(codeowners, codeowner)
+	}
+
+	// Bitbucket Cloud has no team API, so teams are the groups CODEOWNERS files name
+	var teams []GitHubTeam
+	var topics []GitHubTopic
+	reportScanProgress(ctx, ScanPhaseFetchTeams)
+	if request.UseTopics {
+		topics = collectTopicsFromRepositories(repos)
+	} else {
+		teams = collectCodeownerTeams(codeowners)
+		if request.MaxTeams > 0 && len(teams) > request.MaxTeams {
+			teams = teams[:request.MaxTeams]
+		}
+		if err := reserveScanItems(ctx, ScanBufferTeams, len(teams)); err != nil {
+			return scanSourceData{}, err
+		}
+	}
+
+	logInfo(ctx, "Bitbucket workspace fetched", LogFields{
+		"component":        "bitbucket_client",
+		"operation":        "fetch_workspace",
+		"workspace":        workspace.Slug,
+		"repositories":     len(repos),
+		"teams":            len(teams),
+		"codeowners_files": len(codeowners),
+	})
+	return scanSourceData{org: org, repos: repos, teams: teams, topics: topics, codeowners: codeowners}, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// githubBotAccountType is the account type the GitHub API reports for GitHub App bot accounts
+const githubBotAccountType = "Bot"
+
+// isBotLogin reports whether a login names a GitHub App bot account, such as dependabot[bot] (Pure Core)
+func isBotLogin(login string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimPrefix(login, "@")), "[bot]")
+}
+
+// isBotAccount reports whether an account is a bot from its API account type or, when the type is
+// unknown, its login (Pure Core)
+func isBotAccount(login, accountType string) bool {
+	return accountType == githubBotAccountType || isBotLogin(login)
+}
+
+// botOwnerExpression evaluates to true when a graph owner is a bot user: the bot flag scans store on
+// users or, for users written before it, a login ending in [bot]. Teams are never bots (Pure Core)
+func botOwnerExpression(variable string) string {
+	return fmt.Sprintf("coalesce(%[1]s.bot, toLower(coalesce(%[1]s.login, '')) ENDS WITH '[bot]')", variable)
+}
+
+// countedOwnerCondition evaluates to true unless $excludeBots is set and the owner is a bot (Pure Core)
+func countedOwnerCondition(variable string) string {
+	return "NOT ($excludeBots AND " + botOwnerExpression(variable) + ")"
+}
+
+// memberLogins returns the logins of accounts, leaving out bots when excludeBots is set (Pure Core)
+func memberLogins(accounts []GitHubUser, excludeBots bool) []string {
+	logins := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		if excludeBots && isBotAccount(account.Login, account.Type) {
+			continue
+		}
+		logins = append(logins, account.Login)
+	}
+	return logins
+}
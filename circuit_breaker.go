@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"gofr.dev/pkg/gofr"
+)
+
+// Circuit breaker states: closed passes calls, open rejects them until the open timeout passes, and
+// half-open lets a few probe calls through to decide whether to close again
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// Dependencies guarded by a circuit breaker
+const (
+	circuitGitHub = "github"
+	circuitNeo4j  = "neo4j"
+)
+
+// CircuitBreakerStatus is the state of one dependency's circuit breaker
+type CircuitBreakerStatus struct {
+	Dependency          string `json:"dependency"`
+	State               string `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	OpenedAt            string `json:"opened_at,omitempty"`
+	RetryAt             string `json:"retry_at,omitempty"`
+}
+
+// CircuitBreaker fails calls to a dependency fast once it has failed FailureThreshold times in a row,
+// instead of letting every caller wait for its own timeout. After OpenTimeout it lets
+// HalfOpenRequests probe calls through: a success closes the circuit, a failure opens it again
+type CircuitBreaker struct {
+	dependency string
+
+	mu       sync.Mutex
+	config   CircuitBreakerConfig
+	state    string
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// circuitBreakers are the breakers shared by every GitHub request and Neo4j query of the process
+var circuitBreakers = map[string]*CircuitBreaker{
+	circuitGitHub: {dependency: circuitGitHub, state: CircuitClosed},
+	circuitNeo4j:  {dependency: circuitNeo4j, state: CircuitClosed},
+}
+
+// configureCircuitBreakers applies the thresholds to every breaker, keeping their current state
+func configureCircuitBreakers(config CircuitBreakerConfig) {
+	for _, breaker := range circuitBreakers {
+		breaker.mu.Lock()
+		breaker.config = config
+		breaker.mu.Unlock()
+	}
+}
+
+// circuitBreakerStatuses returns the state of every breaker, GitHub first
+func circuitBreakerStatuses(now time.Time) []CircuitBreakerStatus {
+	return []CircuitBreakerStatus{
+		circuitBreakers[circuitGitHub].status(now),
+		circuitBreakers[circuitNeo4j].status(now),
+	}
+}
+
+// nextCircuitState returns the state of a breaker after a call, given its consecutive failures
+// including that call (Pure Core)
+func nextCircuitState(state string, failed bool, failures, threshold int) string {
+	switch {
+	case !failed:
+		return CircuitClosed
+	case state == CircuitHalfOpen || failures >= threshold:
+		return CircuitOpen
+	default:
+		return state
+	}
+}
+
+// allow reports whether a call may go through, and otherwise how long until the circuit lets probes
+// through. An open circuit turns half-open once its timeout has passed
+func (b *CircuitBreaker) allow(ctx *gofr.Context, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.config.FailureThreshold <= 0 {
+		return true, 0
+	}
+	if b.state == CircuitOpen {
+		retryAt := b.openedAt.Add(b.config.OpenTimeout)
+		if now.Before(retryAt) {
+			b.reject(ctx)
+			return false, retryAt.Sub(now)
+		}
+		b.transition(ctx, CircuitHalfOpen)
+	}
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.config.HalfOpenRequests {
+			b.reject(ctx)
+			return false, b.config.OpenTimeout
+		}
+		b.probes++
+	}
+	return true, 0
+}
+
+// record accounts for the outcome of an allowed call; only failures showing the dependency is down
+// or overloaded count, not errors in the caller's request
+func (b *CircuitBreaker) record(ctx *gofr.Context, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.config.FailureThreshold <= 0 {
+		return
+	}
+	if failed {
+		b.failures++
+	} else {
+		b.failures = 0
+	}
+
+	next := nextCircuitState(b.state, failed, b.failures, b.config.FailureThreshold)
+	if next == CircuitOpen {
+		b.openedAt = now
+	}
+	if next != b.state {
+		b.transition(ctx, next)
+	}
+}
+
+// release returns the probe slot of an allowed call whose outcome says nothing about the dependency,
+// such as a request cancelled by its caller
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// transition moves the breaker to state, resetting its half-open probes; callers hold b.mu
+func (b *CircuitBreaker) transition(ctx *gofr.Context, state string) {
+	previous := b.state
+	b.state, b.probes = state, 0
+
+	fields := LogFields{
+		"component":  "circuit_breaker",
+		"operation":  "transition",
+		"dependency": b.dependency,
+		"from":       previous,
+		"to":         state,
+		"failures":   b.failures,
+	}
+	if state == CircuitOpen {
+		fields["open_timeout"] = b.config.OpenTimeout.String()
+		logWarn(ctx, "Circuit breaker opened", fields)
+	} else {
+		logInfo(ctx, "Circuit breaker state changed", fields)
+	}
+
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	metrics.recordCounter("circuit_breaker_transitions_total", 1, MetricLabels{"dependency": b.dependency, "state": state})
+	open := 0.0
+	if state != CircuitClosed {
+		open = 1
+	}
+	metrics.recordGauge("circuit_breaker_open", open, MetricLabels{"dependency": b.dependency})
+}
+
+// reject accounts for a call failed fast; callers hold b.mu
+func (b *CircuitBreaker) reject(ctx *gofr.Context) {
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("circuit_breaker_rejected_total", 1, MetricLabels{
+		"dependency": b.dependency,
+	})
+}
+
+// status returns the state of the breaker
+func (b *CircuitBreaker) status(now time.Time) CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitBreakerStatus{
+		Dependency:          b.dependency,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state == CircuitOpen {
+		status.OpenedAt = b.openedAt.UTC().Format(time.RFC3339)
+		status.RetryAt = b.openedAt.Add(b.config.OpenTimeout).UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// allowGitHubCall fails a GitHub request fast with 503 while the GitHub circuit is open
+func allowGitHubCall(ctx *gofr.Context, endpoint string) error {
+	allowed, wait := circuitBreakers[circuitGitHub].allow(ctx, time.Now())
+	if allowed {
+		return nil
+	}
+	err := newGitHubAPIError(
+		"circuit_open",
+		"GitHub is unavailable; requests are failing fast until it recovers",
+		fmt.Sprintf("circuit open for %s, next probe in %s", endpoint, wait.Round(time.Second)),
+		http.StatusServiceUnavailable,
+	)
+	err.RetryAfter = wait
+	return err
+}
+
+// allowNeo4jCall fails a Neo4j query fast with 503 while the Neo4j circuit is open
+func allowNeo4jCall(ctx *gofr.Context) error {
+	allowed, wait := circuitBreakers[circuitNeo4j].allow(ctx, time.Now())
+	if allowed {
+		return nil
+	}
+	err := newNeo4jError(ErrorCategoryExternal, "CIRCUIT_OPEN",
+		"Neo4j is unavailable; queries are failing fast until it recovers",
+		fmt.Sprintf("circuit open, next probe in %s", wait.Round(time.Second)), true)
+	err.RetryAfter = wait
+	return err
+}
+
+// recordGitHubCall accounts for a GitHub request; network errors and server errors count against the
+// circuit, rate limits and client errors do not
+func recordGitHubCall(ctx *gofr.Context, resp *http.Response, err error) {
+	if errors.Is(err, context.Canceled) {
+		circuitBreakers[circuitGitHub].release()
+		return
+	}
+	reason, _ := classifyGitHubRetry(resp, err)
+	failed := reason == githubRetryNetwork || reason == githubRetryServerError
+	circuitBreakers[circuitGitHub].record(ctx, failed, time.Now())
+}
+
+// recordNeo4jCall accounts for a Neo4j query; timeouts and connectivity failures count against the
+// circuit, query and constraint errors do not
+func recordNeo4jCall(ctx *gofr.Context, err error) {
+	if errors.Is(err, context.Canceled) {
+		circuitBreakers[circuitNeo4j].release()
+		return
+	}
+	failed := false
+	if err != nil {
+		errorType := extractErrorType(err)
+		failed = neo4j.IsConnectivityError(err) || errorType == "timeout" || errorType == "connection"
+	}
+	circuitBreakers[circuitNeo4j].record(ctx, failed, time.Now())
+}
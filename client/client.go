@@ -0,0 +1,508 @@
+// Package client is a Go client for the codeowners scanner API. It wraps the scan,
+// graph and stats endpoints with retries so services can consume ownership data
+// without hand-rolling HTTP calls.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiKeyHeader is the request header identifying the calling API key
+const apiKeyHeader = "X-API-Key"
+
+// ndjsonContentType is the media type requested to stream a graph
+const ndjsonContentType = "application/x-ndjson"
+
+// Client calls the codeowners scanner API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// APIError is returned when the API responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface for APIError
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// envelope is the GoFr response body wrapping data or an error
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey sets the API key sent with every request
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithRetries sets how many times failed requests are retried and the initial backoff
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New creates a client for the API served at baseURL
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Scan scans an organization and publishes or stages its graph, waiting for the scan to finish
+func (c *Client) Scan(ctx context.Context, org string, options ScanOptions) (*ScanResponse, error) {
+	query := scanQuery(options)
+	query.Set("wait", "true")
+
+	var response ScanResponse
+	if err := c.do(ctx, http.MethodPost, "/api/scan/"+url.PathEscape(org), query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// StartScan queues a background scan of an organization; poll its progress with ScanJob
+func (c *Client) StartScan(ctx context.Context, org string, options ScanOptions) (*ScanJob, error) {
+	var job ScanJob
+	if err := c.do(ctx, http.MethodPost, "/api/scan/"+url.PathEscape(org), scanQuery(options), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ScanJob returns the state and progress of a background scan
+func (c *Client) ScanJob(ctx context.Context, id string) (*ScanJob, error) {
+	var job ScanJob
+	if err := c.do(ctx, http.MethodGet, "/api/scan/jobs/"+url.PathEscape(id), nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// scanQuery encodes the optional scan parameters
+func scanQuery(options ScanOptions) url.Values {
+	query := url.Values{}
+	if options.MaxRepos > 0 {
+		query.Set("max_repos", strconv.Itoa(options.MaxRepos))
+	}
+	if options.MaxTeams > 0 {
+		query.Set("max_teams", strconv.Itoa(options.MaxTeams))
+	}
+	if options.UseTopics != nil {
+		query.Set("use_topics", strconv.FormatBool(*options.UseTopics))
+	}
+	if options.Mode != "" {
+		query.Set("mode", options.Mode)
+	}
+	return query
+}
+
+// Graph returns the ownership graph of an organization. Graphs above the server's size limits are
+// rejected with a 422 *APIError; use GraphSummary or EachGraphElement for those.
+func (c *Client) Graph(ctx context.Context, org string, useTopics bool) (*GraphResponse, error) {
+	return c.graph(ctx, org, url.Values{"useTopics": {strconv.FormatBool(useTopics)}})
+}
+
+// GraphGroupedBy returns the ownership graph of an organization with repositories grouped by
+// "teams", "topics" or "both"; with both, team and topic edges are told apart by their types
+func (c *Client) GraphGroupedBy(ctx context.Context, org, groupBy string) (*GraphResponse, error) {
+	return c.graph(ctx, org, url.Values{"group_by": {groupBy}})
+}
+
+// GraphSummary returns the organization and its teams, or topics, with the number of repositories
+// each one covers, without individual repositories and users
+func (c *Client) GraphSummary(ctx context.Context, org string, useTopics bool) (*GraphResponse, error) {
+	return c.graph(ctx, org, url.Values{"useTopics": {strconv.FormatBool(useTopics)}, "summarize": {"true"}})
+}
+
+// GraphPage returns a page of an organization's repositories with the teams, topics and users
+// connected to them and the edges between those nodes. Pages are not subject to the server's graph
+// size limits; load the next page from Page.NextOffset until it is nil.
+func (c *Client) GraphPage(ctx context.Context, org string, options GraphPageOptions) (*GraphResponse, error) {
+	query := url.Values{"offset": {strconv.Itoa(options.Offset)}}
+	if options.GroupBy != "" {
+		query.Set("group_by", options.GroupBy)
+	}
+	if len(options.Types) > 0 {
+		query.Set("types", strings.Join(options.Types, ","))
+	}
+	if options.Limit > 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	return c.graph(ctx, org, query)
+}
+
+// GraphExport downloads the ownership graph of an organization serialized as "graphml", "gexf",
+// "dot" or "csv" (an edge list)
+func (c *Client) GraphExport(ctx context.Context, org, format string) ([]byte, error) {
+	query := url.Values{"format": {format}}
+	resp, err := c.send(ctx, http.MethodGet, "/api/graph/"+url.PathEscape(org)+"/export", query, "*/*")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read graph export: %w", err)
+	}
+	return content, nil
+}
+
+// OwnershipReport downloads the flat ownership report of an organization as "csv" or "xlsx"
+func (c *Client) OwnershipReport(ctx context.Context, org, format string, options ReportOptions) ([]byte, error) {
+	query := url.Values{}
+	if options.Timezone != "" {
+		query.Set("tz", options.Timezone)
+	}
+	if options.Locale != "" {
+		query.Set("locale", options.Locale)
+	}
+
+	path := "/api/reports/" + url.PathEscape(org) + "/ownership." + url.PathEscape(format)
+	resp, err := c.send(ctx, http.MethodGet, path, query, "*/*")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ownership report: %w", err)
+	}
+	return content, nil
+}
+
+// graph fetches the graph endpoint with the given query
+func (c *Client) graph(ctx context.Context, org string, query url.Values) (*GraphResponse, error) {
+	var response GraphResponse
+	if err := c.do(ctx, http.MethodGet, "/api/graph/"+url.PathEscape(org), query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Stats returns the ownership statistics of an organization
+func (c *Client) Stats(ctx context.Context, org string) (*StatsResponse, error) {
+	var response StatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/stats/"+url.PathEscape(org), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// StatsWithTeamExpansion returns the ownership statistics of an organization with team owners
+// expanded into their current members
+func (c *Client) StatsWithTeamExpansion(ctx context.Context, org string) (*StatsResponse, error) {
+	var response StatsResponse
+	query := url.Values{"expandTeams": []string{"true"}}
+	if err := c.do(ctx, http.MethodGet, "/api/stats/"+url.PathEscape(org), query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Coverage returns the share of files owned by a CODEOWNERS rule in each repository of an
+// organization, computed from the repository trees on GitHub
+func (c *Client) Coverage(ctx context.Context, org string, options CoverageOptions) (*CoverageReportResponse, error) {
+	query := url.Values{}
+	if options.Limit > 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Repository != "" {
+		query.Set("repository", options.Repository)
+	}
+
+	var response CoverageReportResponse
+	if err := c.do(ctx, http.MethodGet, "/api/coverage/"+url.PathEscape(org), query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Organizations lists the scanned organizations with their last scan time and basic stats
+func (c *Client) Organizations(ctx context.Context) (*OrganizationListResponse, error) {
+	var response OrganizationListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/orgs", nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// TeamImpact returns the repositories downstream of the code a team owns, up to depth dependency hops;
+// depth 0 uses the server default
+func (c *Client) TeamImpact(ctx context.Context, org, team string, depth int) (*TeamImpactResponse, error) {
+	query := url.Values{}
+	if depth > 0 {
+		query.Set("depth", strconv.Itoa(depth))
+	}
+
+	var response TeamImpactResponse
+	path := "/api/impact/" + url.PathEscape(org) + "/team/" + url.PathEscape(team)
+	if err := c.do(ctx, http.MethodGet, path, query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Schedule returns the recurring scan schedule with its next run and recent runs
+func (c *Client) Schedule(ctx context.Context) (*ScheduleResponse, error) {
+	var response ScheduleResponse
+	if err := c.do(ctx, http.MethodGet, "/api/schedules", nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RepositoryDependencies returns the owners of the repositories a repository given as "org/repo" depends on
+func (c *Client) RepositoryDependencies(ctx context.Context, fullName string) (*RepositoryDependenciesResponse, error) {
+	org, repo, found := strings.Cut(fullName, "/")
+	if !found || org == "" || repo == "" {
+		return nil, fmt.Errorf("repository must be given as org/repo, got %q", fullName)
+	}
+
+	var response RepositoryDependenciesResponse
+	path := "/api/repositories/" + url.PathEscape(org) + "/" + url.PathEscape(repo) + "/dependencies"
+	if err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CodeownersErrors returns GitHub's CODEOWNERS errors for a repository given as "org/repo", merged with
+// the server's lint findings
+func (c *Client) CodeownersErrors(ctx context.Context, fullName string) (*CodeownersErrorsResponse, error) {
+	org, repo, found := strings.Cut(fullName, "/")
+	if !found || org == "" || repo == "" {
+		return nil, fmt.Errorf("repository must be given as org/repo, got %q", fullName)
+	}
+
+	var response CodeownersErrorsResponse
+	path := "/api/codeowners/" + url.PathEscape(org) + "/" + url.PathEscape(repo) + "/errors"
+	if err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// TeamMemberships returns every team of an organization with its members and the repositories
+// and CODEOWNERS rules it owns, one row per member
+func (c *Client) TeamMemberships(ctx context.Context, org string) ([]TeamMembershipRow, error) {
+	var rows []TeamMembershipRow
+	query := url.Values{"format": {"json"}}
+	if err := c.do(ctx, http.MethodGet, "/api/teams/"+url.PathEscape(org)+"/export", query, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// OwnershipManifest returns the ownership manifest of an organization's active scan
+func (c *Client) OwnershipManifest(ctx context.Context, org string) (*OwnershipManifest, error) {
+	var manifest OwnershipManifest
+	if err := c.do(ctx, http.MethodGet, "/api/manifest/"+url.PathEscape(org), nil, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// ScanDiff compares the ownership of two scans of an organization; empty ids compare the previous
+// active scan with the active one
+func (c *Client) ScanDiff(ctx context.Context, org, from, to string) (*ScanDiffResponse, error) {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+	var diff ScanDiffResponse
+	if err := c.do(ctx, http.MethodGet, "/api/diff/"+url.PathEscape(org), query, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// OwnershipTimeline returns the CODEOWNERS history of a repository given as "org/repo" across scans
+func (c *Client) OwnershipTimeline(ctx context.Context, fullName string) (*RepositoryOwnershipTimeline, error) {
+	org, repo, found := strings.Cut(fullName, "/")
+	if !found || org == "" || repo == "" {
+		return nil, fmt.Errorf("repository must be given as org/repo, got %q", fullName)
+	}
+
+	var timeline RepositoryOwnershipTimeline
+	path := "/api/history/" + url.PathEscape(org) + "/" + url.PathEscape(repo)
+	if err := c.do(ctx, http.MethodGet, path, nil, &timeline); err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
+
+// EachGraphElement streams the graph of an organization and calls fn for every node and
+// edge as it arrives, so large graphs are processed without loading them whole
+func (c *Client) EachGraphElement(ctx context.Context, org string, useTopics bool, fn func(GraphStreamLine) error) error {
+	query := url.Values{"useTopics": {strconv.FormatBool(useTopics)}}
+	resp, err := c.send(ctx, http.MethodGet, "/api/graph/"+url.PathEscape(org), query, ndjsonContentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line GraphStreamLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return fmt.Errorf("failed to decode graph stream line: %w", err)
+		}
+		switch line.Type {
+		case "error":
+			return &APIError{StatusCode: http.StatusInternalServerError, Message: line.Error}
+		case "end":
+			return nil
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read graph stream: %w", err)
+	}
+	return nil
+}
+
+// do sends a request and decodes the data of the response envelope into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out interface{}) error {
+	resp, err := c.send(ctx, method, path, query, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body envelope
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return json.Unmarshal(body.Data, out)
+}
+
+// send performs a request, retrying network errors and retryable statuses with exponential backoff
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, accept string) (*http.Response, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		if c.apiKey != "" {
+			req.Header.Set(apiKeyHeader, c.apiKey)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		retryable := isRetryable(method, resp, err)
+		wait := retryDelay(resp, backoff)
+		if err == nil {
+			err = readAPIError(resp)
+		}
+		if !retryable || attempt >= c.maxRetries {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable reports whether a failed request can be sent again; scans are only
+// retried when the server rejected them before doing any work
+func isRetryable(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return method == http.MethodGet
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusInternalServerError:
+		return method == http.MethodGet
+	default:
+		return false
+	}
+}
+
+// retryDelay honours a Retry-After header in seconds and falls back to the backoff
+func retryDelay(resp *http.Response, backoff time.Duration) time.Duration {
+	if resp == nil {
+		return backoff
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return backoff
+}
+
+// readAPIError reads and closes an error response
+func readAPIError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var body envelope
+	if err := json.Unmarshal(content, &body); err == nil && body.Error != nil {
+		return &APIError{StatusCode: resp.StatusCode, Message: body.Error.Message}
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: string(bytes.TrimSpace(content))}
+}
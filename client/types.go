@@ -0,0 +1,568 @@
+package client
+
+// ScanOptions represents the optional parameters of a scan
+type ScanOptions struct {
+	MaxRepos  int
+	MaxTeams  int
+	UseTopics *bool
+	// Mode is "full" (default) or "incremental"
+	Mode string
+}
+
+// ScanResponse represents the response from scanning an organization
+type ScanResponse struct {
+	Success            bool                      `json:"success"`
+	Organization       string                    `json:"organization"`
+	ScanID             string                    `json:"scan_id"`
+	ScanStatus         string                    `json:"scan_status"`
+	Mode               string                    `json:"mode"`
+	IncrementalSince   string                    `json:"incremental_since,omitempty"`
+	ValidationFailures []string                  `json:"validation_failures,omitempty"`
+	ConversionFailures map[string]map[string]int `json:"conversion_failures,omitempty"`
+	Summary            ScanSummary               `json:"summary"`
+	Errors             []string                  `json:"errors"`
+	Data               map[string]interface{}    `json:"data"`
+}
+
+// ScanJob represents a background scan started by StartScan
+type ScanJob struct {
+	ID              string         `json:"id"`
+	Organization    string         `json:"organization"`
+	Mode            string         `json:"mode"`
+	State           string         `json:"state"`
+	Phase           string         `json:"phase,omitempty"`
+	ProgressPercent int            `json:"progress_percent"`
+	Batch           *BatchProgress `json:"batch,omitempty"`
+	ScanID          string         `json:"scan_id,omitempty"`
+	CreatedAt       string         `json:"created_at"`
+	StartedAt       string         `json:"started_at,omitempty"`
+	FinishedAt      string         `json:"finished_at,omitempty"`
+	LogArtifact     string         `json:"log_artifact,omitempty"`
+	Error           *ScanJobError  `json:"error,omitempty"`
+	Result          *ScanResponse  `json:"result,omitempty"`
+}
+
+// BatchProgress counts the items of the current scan phase that have been processed
+type BatchProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ScanJobError describes why a scan job failed
+type ScanJobError struct {
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+	Category   string `json:"category"`
+	Retryable  bool   `json:"retryable"`
+}
+
+// ScanSummary represents scan statistics
+type ScanSummary struct {
+	TotalRepos          int      `json:"total_repos"`
+	ReposWithCodeowners int      `json:"repos_with_codeowners"`
+	TotalTeams          int      `json:"total_teams"`
+	TotalTopics         int      `json:"total_topics"`
+	UniqueOwners        []string `json:"unique_owners"`
+	APICallsUsed        int      `json:"api_calls_used"`
+	ProcessingTimeMs    int64    `json:"processing_time_ms"`
+}
+
+// GraphResponse represents graph visualization data
+type GraphResponse struct {
+	SchemaVersion int         `json:"schema_version"`
+	Nodes         []GraphNode `json:"nodes"`
+	Edges         []GraphEdge `json:"edges"`
+	Summarized    bool        `json:"summarized,omitempty"`
+	Page          *GraphPage  `json:"page,omitempty"`
+	Stale         bool        `json:"stale,omitempty"`
+	CachedAt      string      `json:"cached_at,omitempty"`
+}
+
+// GraphPage locates a page of repositories within an organization's graph; NextOffset is nil on
+// the last page
+type GraphPage struct {
+	Offset            int  `json:"offset"`
+	Limit             int  `json:"limit"`
+	TotalRepositories int  `json:"total_repositories"`
+	NextOffset        *int `json:"next_offset,omitempty"`
+}
+
+// GraphPageOptions selects a page of a graph and the node types it returns
+type GraphPageOptions struct {
+	// GroupBy is "teams" (default), "topics" or "both"
+	GroupBy string
+	// Types limits the nodes to these types, e.g. "repository" and "team"; edges whose endpoints
+	// are filtered out are pruned
+	Types []string
+	// Offset is the number of repositories, ordered by full name, skipped before the page
+	Offset int
+	// Limit is the number of repositories on the page, 100 by default and at most 500
+	Limit int
+}
+
+// GraphNode represents a node in the graph
+type GraphNode struct {
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Label    string                 `json:"label"`
+	Data     map[string]interface{} `json:"data"`
+	Position GraphPosition          `json:"position"`
+}
+
+// GraphEdge represents an edge in the graph
+type GraphEdge struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Label  string `json:"label"`
+}
+
+// GraphPosition represents node position in the graph
+type GraphPosition struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// GraphStreamLine represents a single NDJSON line of a streamed graph
+type GraphStreamLine struct {
+	Type      string     `json:"type"`
+	Node      *GraphNode `json:"node,omitempty"`
+	Edge      *GraphEdge `json:"edge,omitempty"`
+	NodeCount int        `json:"node_count,omitempty"`
+	EdgeCount int        `json:"edge_count,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// StatsResponse represents organization statistics
+type StatsResponse struct {
+	Organization       string                  `json:"organization"`
+	TotalRepositories  int                     `json:"total_repositories"`
+	TotalTeams         int                     `json:"total_teams"`
+	TotalTopics        int                     `json:"total_topics"`
+	TotalUsers         int                     `json:"total_users"`
+	TotalCodeowners    int                     `json:"total_codeowners"`
+	CodeownerCoverage  string                  `json:"codeowner_coverage"`
+	LastScanTime       string                  `json:"last_scan_time"`
+	LastSuccessfulScan string                  `json:"last_successful_scan,omitempty"`
+	ActiveScanID       string                  `json:"active_scan_id,omitempty"`
+	Breakdown          *StatsBreakdown         `json:"breakdown,omitempty"`
+	CoverageTarget     *CoverageTargetProgress `json:"coverage_target,omitempty"`
+	DataFreshness      *DataFreshness          `json:"data_freshness,omitempty"`
+	TeamExpansion      *TeamExpansionStats     `json:"team_expansion,omitempty"`
+	Stale              bool                    `json:"stale,omitempty"`
+	CachedAt           string                  `json:"cached_at,omitempty"`
+}
+
+// RepositoryEffectiveOwners counts the people a repository's CODEOWNERS rules reach once teams are
+// expanded to their members
+type RepositoryEffectiveOwners struct {
+	Repository      string   `json:"repository"`
+	Teams           []string `json:"teams"`
+	Users           []string `json:"users"`
+	EffectiveOwners int      `json:"effective_owners"`
+	EmptyTeams      []string `json:"empty_teams,omitempty"`
+}
+
+// TeamExpansionStats reports repository ownership with team owners expanded into their members
+type TeamExpansionStats struct {
+	TeamsExpanded          int                         `json:"teams_expanded"`
+	TeamMembers            map[string]int              `json:"team_members"`
+	AverageEffectiveOwners float64                     `json:"average_effective_owners"`
+	EmptyTeamRepositories  []string                    `json:"empty_team_repositories"`
+	Repositories           []RepositoryEffectiveOwners `json:"repositories"`
+}
+
+// CoverageSegment is the CODEOWNERS coverage of a subset of repositories
+type CoverageSegment struct {
+	Repositories    int     `json:"repositories"`
+	WithCodeowners  int     `json:"with_codeowners"`
+	CoveragePercent float64 `json:"coverage_percent"`
+}
+
+// StatsBreakdown segments repository coverage by visibility, archived and fork status
+type StatsBreakdown struct {
+	Visibility map[string]CoverageSegment `json:"visibility"`
+	Archived   map[string]CoverageSegment `json:"archived"`
+	Fork       map[string]CoverageSegment `json:"fork"`
+	Compliance CoverageSegment            `json:"compliance"`
+}
+
+// CoverageTargetProgress describes the progress of an organization towards its CODEOWNERS coverage target
+type CoverageTargetProgress struct {
+	TargetPercent       float64 `json:"target_percent"`
+	Deadline            string  `json:"deadline"`
+	CurrentPercent      float64 `json:"current_percent"`
+	WeeksRemaining      float64 `json:"weeks_remaining"`
+	RequiredWeeklyDelta float64 `json:"required_weekly_delta"`
+	ObservedWeeklyDelta float64 `json:"observed_weekly_delta"`
+	TrendSamples        int     `json:"trend_samples"`
+	ProjectedPercent    float64 `json:"projected_percent"`
+	Status              string  `json:"status"`
+}
+
+// ReportOptions represents the optional timestamp formatting of a CSV or XLSX report; empty fields
+// use the server's REPORT_TIMEZONE and REPORT_LOCALE
+type ReportOptions struct {
+	// Timezone is an IANA timezone name such as America/New_York
+	Timezone string
+	// Locale selects the timestamp layout, such as en-US or de-DE
+	Locale string
+}
+
+// CoverageOptions represents the optional parameters of a coverage report
+type CoverageOptions struct {
+	// Limit is the number of repositories covered, 50 by default
+	Limit int
+	// Repository limits the report to one repository name
+	Repository string
+}
+
+// CoverageReportResponse is the file-level CODEOWNERS coverage of an organization's repositories
+type CoverageReportResponse struct {
+	Organization string               `json:"organization"`
+	ActiveScanID string               `json:"active_scan_id"`
+	Truncated    bool                 `json:"truncated"`
+	Summary      OrganizationCoverage `json:"summary"`
+	Repositories []RepositoryCoverage `json:"repositories"`
+}
+
+// OrganizationCoverage rolls repository coverage up to the organization
+type OrganizationCoverage struct {
+	Repositories               int     `json:"repositories"`
+	RepositoriesWithCodeowners int     `json:"repositories_with_codeowners"`
+	FullyCoveredRepositories   int     `json:"fully_covered_repositories"`
+	FailedRepositories         int     `json:"failed_repositories"`
+	TotalFiles                 int     `json:"total_files"`
+	OwnedFiles                 int     `json:"owned_files"`
+	UnownedFiles               int     `json:"unowned_files"`
+	CoveragePercent            float64 `json:"coverage_percent"`
+	Estimated                  bool    `json:"estimated"`
+}
+
+// RepositoryCoverage is the CODEOWNERS coverage of the files of one repository
+type RepositoryCoverage struct {
+	Repository         string              `json:"repository"`
+	HasCodeowners      bool                `json:"has_codeowners"`
+	TotalFiles         int                 `json:"total_files"`
+	OwnedFiles         int                 `json:"owned_files"`
+	UnownedFiles       int                 `json:"unowned_files"`
+	CoveragePercent    float64             `json:"coverage_percent"`
+	UnownedPercent     float64             `json:"unowned_percent"`
+	Estimated          bool                `json:"estimated"`
+	Patterns           []PatternCoverage   `json:"patterns"`
+	UnusedPatterns     []string            `json:"unused_patterns"`
+	UnownedDirectories []DirectoryCoverage `json:"unowned_directories"`
+	Error              string              `json:"error,omitempty"`
+}
+
+// PatternCoverage is the number of files a CODEOWNERS rule decides the owners of
+type PatternCoverage struct {
+	Pattern string   `json:"pattern"`
+	Line    int      `json:"line"`
+	Owners  []string `json:"owners"`
+	Files   int      `json:"files"`
+}
+
+// DirectoryCoverage counts the files without owners in a top-level directory
+type DirectoryCoverage struct {
+	Directory    string `json:"directory"`
+	UnownedFiles int    `json:"unowned_files"`
+}
+
+// OrganizationListResponse lists every organization in the graph
+type OrganizationListResponse struct {
+	Organizations []OrganizationSummary `json:"organizations"`
+}
+
+// OrganizationSummary is a scanned organization with its last scan and the size of its active scan
+type OrganizationSummary struct {
+	Organization               string  `json:"organization"`
+	Name                       string  `json:"name,omitempty"`
+	ActiveScanID               string  `json:"active_scan_id,omitempty"`
+	LastScannedAt              string  `json:"last_scanned_at,omitempty"`
+	Scanning                   bool    `json:"scanning"`
+	Repositories               int     `json:"repositories"`
+	RepositoriesWithCodeowners int     `json:"repositories_with_codeowners"`
+	Teams                      int     `json:"teams"`
+	CoveragePercent            float64 `json:"coverage_percent"`
+}
+
+// RepositoryOwner is a team or user owner and the CODEOWNERS patterns assigning it
+type RepositoryOwner struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
+}
+
+// RepositoryDependenciesResponse lists the owners of a repository's upstream dependencies
+type RepositoryDependenciesResponse struct {
+	Organization string               `json:"organization"`
+	Repository   string               `json:"repository"`
+	Dependencies []UpstreamDependency `json:"dependencies"`
+}
+
+// UpstreamDependency is a repository another repository depends on, with its owners in the active scan
+type UpstreamDependency struct {
+	Repository string            `json:"repository"`
+	Ecosystem  string            `json:"ecosystem"`
+	Dependency string            `json:"dependency"`
+	Teams      []RepositoryOwner `json:"teams"`
+	Users      []RepositoryOwner `json:"users"`
+}
+
+// CodeownersValidationError is a problem found in a CODEOWNERS line
+type CodeownersValidationError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Kind    string `json:"kind"`
+	Source  string `json:"source"`
+	Owner   string `json:"owner,omitempty"`
+	Message string `json:"message"`
+}
+
+// CombinedCodeownersError is a CODEOWNERS problem reported by GitHub, by the server's lint, or by both
+type CombinedCodeownersError struct {
+	CodeownersValidationError
+	Suggestion    string   `json:"suggestion,omitempty"`
+	GitHubKind    string   `json:"github_kind,omitempty"`
+	GitHubMessage string   `json:"github_message,omitempty"`
+	ReportedBy    []string `json:"reported_by"`
+}
+
+// CodeownersErrorsResponse combines GitHub's CODEOWNERS errors with the server's lint findings
+type CodeownersErrorsResponse struct {
+	Organization string                    `json:"organization"`
+	Repository   string                    `json:"repository"`
+	Path         string                    `json:"path,omitempty"`
+	Valid        bool                      `json:"valid"`
+	RuleCount    int                       `json:"rule_count"`
+	GitHubErrors int                       `json:"github_errors"`
+	LintErrors   int                       `json:"lint_errors"`
+	Errors       []CombinedCodeownersError `json:"errors"`
+	CheckedAt    string                    `json:"checked_at"`
+	Stored       bool                      `json:"stored"`
+}
+
+// DataFreshness describes how current an organization's graph data is relative to the SLA
+type DataFreshness struct {
+	Status             string  `json:"status"`
+	LastSuccessfulScan string  `json:"last_successful_scan,omitempty"`
+	AgeSeconds         float64 `json:"age_seconds,omitempty"`
+	SLASeconds         float64 `json:"sla_seconds"`
+}
+
+// ScheduleResponse is the recurring scan schedule, its next run and its recent runs
+type ScheduleResponse struct {
+	Cron          string             `json:"cron"`
+	Organizations []string           `json:"organizations"`
+	MaxRepos      int                `json:"max_repos"`
+	MaxTeams      int                `json:"max_teams"`
+	Enabled       bool               `json:"enabled"`
+	Running       bool               `json:"running"`
+	UpdatedAt     string             `json:"updated_at,omitempty"`
+	NextRunAt     string             `json:"next_run_at,omitempty"`
+	Runs          []ScheduledScanRun `json:"runs"`
+}
+
+// ScheduledScanRun is the outcome of one run of the scan schedule
+type ScheduledScanRun struct {
+	ID            string                 `json:"id"`
+	Cron          string                 `json:"cron"`
+	Status        string                 `json:"status"`
+	StartedAt     string                 `json:"started_at"`
+	FinishedAt    string                 `json:"finished_at,omitempty"`
+	Organizations []string               `json:"organizations"`
+	Succeeded     []string               `json:"succeeded"`
+	Failed        []ScheduledScanFailure `json:"failed"`
+}
+
+// ScheduledScanFailure is an organization whose scheduled scan failed
+type ScheduledScanFailure struct {
+	Organization string `json:"organization"`
+	Error        string `json:"error"`
+}
+
+// TeamImpactResponse lists the repositories affected by the code a team owns, through dependency edges
+type TeamImpactResponse struct {
+	Organization      string               `json:"organization"`
+	Team              string               `json:"team"`
+	Depth             int                  `json:"depth"`
+	Truncated         bool                 `json:"truncated"`
+	Summary           TeamImpactSummary    `json:"summary"`
+	OwnedRepositories []string             `json:"owned_repositories"`
+	Downstream        []ImpactedRepository `json:"downstream"`
+	AffectedTeams     []AffectedTeam       `json:"affected_teams"`
+}
+
+// TeamImpactSummary counts a team's owned and downstream repositories
+type TeamImpactSummary struct {
+	OwnedRepositories      int                `json:"owned_repositories"`
+	DownstreamRepositories int                `json:"downstream_repositories"`
+	AffectedTeams          int                `json:"affected_teams"`
+	ByDepth                []ImpactDepthCount `json:"by_depth"`
+}
+
+// ImpactDepthCount is the number of impacted repositories at one dependency depth
+type ImpactDepthCount struct {
+	Depth        int `json:"depth"`
+	Repositories int `json:"repositories"`
+}
+
+// ImpactedRepository is a repository depending, directly or transitively, on code a team owns
+type ImpactedRepository struct {
+	Repository string   `json:"repository"`
+	Depth      int      `json:"depth"`
+	Path       []string `json:"path"`
+	Teams      []string `json:"teams"`
+}
+
+// AffectedTeam is a team owning repositories impacted by another team's code
+type AffectedTeam struct {
+	Team         string `json:"team"`
+	Repositories int    `json:"repositories"`
+}
+
+// TeamMembershipRow is one member of a team with the team's ownership counts; teams without members
+// have a single row with an empty Member, and MemberCount is nil until a scan has listed the members
+type TeamMembershipRow struct {
+	Team              string `json:"team"`
+	TeamName          string `json:"team_name"`
+	Member            string `json:"member,omitempty"`
+	MemberCount       *int   `json:"member_count"`
+	OwnedRepositories int    `json:"owned_repositories"`
+	OwnershipRules    int    `json:"ownership_rules"`
+}
+
+// OwnershipManifest is a machine-readable record of who owns what in an organization's active scan.
+// Repositories, rules and owners are sorted, so manifests of unchanged scans differ only in Scan and
+// GeneratedAt, and Digest identifies the ownership data regardless of the scan that found it
+type OwnershipManifest struct {
+	SchemaVersion string                        `json:"schema_version"`
+	Organization  string                        `json:"organization"`
+	Scan          OwnershipManifestScan         `json:"scan"`
+	GeneratedAt   string                        `json:"generated_at"`
+	Digest        string                        `json:"digest"`
+	Summary       OwnershipManifestSummary      `json:"summary"`
+	Repositories  []OwnershipManifestRepository `json:"repositories"`
+}
+
+// OwnershipManifestScan identifies the scan a manifest was built from
+type OwnershipManifestScan struct {
+	ID          string `json:"id,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	ActivatedAt string `json:"activated_at,omitempty"`
+}
+
+// OwnershipManifestSummary counts the contents of a manifest
+type OwnershipManifestSummary struct {
+	Repositories          int `json:"repositories"`
+	RepositoriesWithRules int `json:"repositories_with_rules"`
+	Rules                 int `json:"rules"`
+	DistinctOwners        int `json:"distinct_owners"`
+}
+
+// OwnershipManifestRepository is a repository with its CODEOWNERS file and rules; Digest hashes the
+// file reference and rules, so changed repositories can be found without comparing rules
+type OwnershipManifestRepository struct {
+	FullName   string                  `json:"full_name"`
+	Codeowners *OwnershipManifestFile  `json:"codeowners,omitempty"`
+	Owners     []string                `json:"owners"`
+	Rules      []OwnershipManifestRule `json:"rules"`
+	Digest     string                  `json:"digest"`
+}
+
+// OwnershipManifestFile is the CODEOWNERS file of a repository with its git blob SHA
+type OwnershipManifestFile struct {
+	Path    string `json:"path"`
+	BlobSHA string `json:"blob_sha"`
+}
+
+// OwnershipManifestRule is a CODEOWNERS line with its sorted owners
+type OwnershipManifestRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// ScanDiffResponse is the ownership change between two scans of an organization
+type ScanDiffResponse struct {
+	Organization        string               `json:"organization"`
+	From                ScanDiffScan         `json:"from"`
+	To                  ScanDiffScan         `json:"to"`
+	Summary             ScanDiffSummary      `json:"summary"`
+	AddedRepositories   []string             `json:"added_repositories"`
+	RemovedRepositories []string             `json:"removed_repositories"`
+	ChangedRules        []ScanDiffRule       `json:"changed_rules"`
+	OwnerChurn          []ScanDiffOwnerChurn `json:"owner_churn"`
+}
+
+// ScanDiffScan identifies one side of a diff
+type ScanDiffScan struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	ActivatedAt string `json:"activated_at,omitempty"`
+}
+
+// ScanDiffSummary counts the changes of a diff
+type ScanDiffSummary struct {
+	AddedRepositories   int `json:"added_repositories"`
+	RemovedRepositories int `json:"removed_repositories"`
+	AddedRules          int `json:"added_rules"`
+	RemovedRules        int `json:"removed_rules"`
+	ChangedRules        int `json:"changed_rules"`
+	NewOwners           int `json:"new_owners"`
+	DepartedOwners      int `json:"departed_owners"`
+}
+
+// ScanDiffRule is a CODEOWNERS pattern of a repository that was added, removed or reassigned
+type ScanDiffRule struct {
+	Repository    string   `json:"repository"`
+	Pattern       string   `json:"pattern"`
+	Change        string   `json:"change"`
+	AddedOwners   []string `json:"added_owners,omitempty"`
+	RemovedOwners []string `json:"removed_owners,omitempty"`
+}
+
+// ScanDiffOwnerChurn is an owner whose set of owned repositories changed
+type ScanDiffOwnerChurn struct {
+	Owner              string   `json:"owner"`
+	Change             string   `json:"change"`
+	GainedRepositories []string `json:"gained_repositories"`
+	LostRepositories   []string `json:"lost_repositories"`
+	RepositoriesBefore int      `json:"repositories_before"`
+	RepositoriesAfter  int      `json:"repositories_after"`
+}
+
+// RepositoryOwnershipTimeline is the CODEOWNERS history of a repository across scans: every version
+// of an owner assignment with the interval it was valid in, and the changes between versions
+type RepositoryOwnershipTimeline struct {
+	Organization string                    `json:"organization"`
+	Repository   string                    `json:"repository"`
+	TrackedSince string                    `json:"tracked_since,omitempty"`
+	Versions     []OwnershipRuleVersion    `json:"versions"`
+	Changes      []OwnershipTimelineChange `json:"changes"`
+}
+
+// OwnershipRuleVersion is an owner assigned by a CODEOWNERS pattern from ValidFrom until ValidTo;
+// versions still in the active scan have no ValidTo
+type OwnershipRuleVersion struct {
+	Pattern   string `json:"pattern"`
+	Owner     string `json:"owner"`
+	OwnerType string `json:"owner_type"`
+	Line      int    `json:"line,omitempty"`
+	ValidFrom string `json:"valid_from"`
+	ValidTo   string `json:"valid_to,omitempty"`
+}
+
+// OwnershipTimelineChange is the set of owner assignments added and removed at one point in time
+type OwnershipTimelineChange struct {
+	At      string                    `json:"at"`
+	Added   []OwnershipTimelineAssign `json:"added"`
+	Removed []OwnershipTimelineAssign `json:"removed"`
+}
+
+// OwnershipTimelineAssign is an owner of a CODEOWNERS pattern
+type OwnershipTimelineAssign struct {
+	Pattern string `json:"pattern"`
+	Owner   string `json:"owner"`
+}
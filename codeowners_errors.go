@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Reporters of a combined CODEOWNERS error
+const (
+	codeownersReporterGitHub   = "github"
+	codeownersReporterOverseer = "overseer"
+)
+
+// githubCodeownersError is an entry of GitHub's CODEOWNERS errors API
+type githubCodeownersError struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Kind       string `json:"kind"`
+	Source     string `json:"source"`
+	Suggestion string `json:"suggestion"`
+	Message    string `json:"message"`
+	Path       string `json:"path"`
+}
+
+// CombinedCodeownersError is a CODEOWNERS problem reported by GitHub, by the local lint, or by both
+type CombinedCodeownersError struct {
+	CodeownersValidationError
+	Suggestion    string   `json:"suggestion,omitempty"`
+	GitHubKind    string   `json:"github_kind,omitempty"`
+	GitHubMessage string   `json:"github_message,omitempty"`
+	ReportedBy    []string `json:"reported_by"`
+}
+
+// CodeownersErrorsResponse combines GitHub's CODEOWNERS errors with the local lint findings for a repository
+type CodeownersErrorsResponse struct {
+	Organization string                    `json:"organization"`
+	Repository   string                    `json:"repository"`
+	Path         string                    `json:"path,omitempty"`
+	Valid        bool                      `json:"valid"`
+	RuleCount    int                       `json:"rule_count"`
+	GitHubErrors int                       `json:"github_errors"`
+	LintErrors   int                       `json:"lint_errors"`
+	Errors       []CombinedCodeownersError `json:"errors"`
+	CheckedAt    string                    `json:"checked_at"`
+	Stored       bool                      `json:"stored"`
+}
+
+// buildStoreCodeownersErrorsQuery builds a query recording the combined CODEOWNERS errors on a
+// repository; errors are kept as a JSON document since node properties cannot hold maps (Pure Core)
+func buildStoreCodeownersErrorsQuery() string {
+	return `
+		MATCH (repo:Repository {full_name: $fullName})
+		SET repo.codeowners_errors = $errors,
+			repo.codeowners_error_count = $errorCount,
+			repo.codeowners_errors_checked_at = $checkedAt
+		RETURN count(repo) AS stored
+	`
+}
+
+// mergeCodeownersErrors combines GitHub's errors with the lint findings; entries at the same line and
+// column are reported once, keeping the lint kind and GitHub's suggestion, sorted by position (Pure Core)
+func mergeCodeownersErrors(githubErrors []githubCodeownersError, lintErrors []CodeownersValidationError) []CombinedCodeownersError {
+	combined := make([]CombinedCodeownersError, 0, len(githubErrors)+len(lintErrors))
+	positions := make(map[[2]int]int)
+	for _, lint := range lintErrors {
+		key := [2]int{lint.Line, lint.Column}
+		if _, ok := positions[key]; !ok {
+			positions[key] = len(combined)
+		}
+		combined = append(combined, CombinedCodeownersError{
+			CodeownersValidationError: lint,
+			ReportedBy:                []string{codeownersReporterOverseer},
+		})
+	}
+
+	for _, reported := range githubErrors {
+		if index, ok := positions[[2]int{reported.Line, reported.Column}]; ok {
+			entry := &combined[index]
+			entry.Suggestion = reported.Suggestion
+			entry.GitHubKind = reported.Kind
+			entry.GitHubMessage = reported.Message
+			entry.ReportedBy = append(entry.ReportedBy, codeownersReporterGitHub)
+			continue
+		}
+		combined = append(combined, CombinedCodeownersError{
+			CodeownersValidationError: CodeownersValidationError{
+				Line:    reported.Line,
+				Column:  reported.Column,
+				Kind:    reported.Kind,
+				Source:  reported.Source,
+				Message: reported.Message,
+			},
+			Suggestion:    reported.Suggestion,
+			GitHubKind:    reported.Kind,
+			GitHubMessage: reported.Message,
+			ReportedBy:    []string{codeownersReporterGitHub},
+		})
+	}
+
+	sort.SliceStable(combined, func(i, j int) bool {
+		if combined[i].Line != combined[j].Line {
+			return combined[i].Line < combined[j].Line
+		}
+		return combined[i].Column < combined[j].Column
+	})
+	return combined
+}
+
+// fetchGitHubCodeownersErrors reads the errors GitHub reports for a repository's CODEOWNERS file,
+// returning false when the repository has none
+func fetchGitHubCodeownersErrors(ctx *gofr.Context, orgName, repoName string) ([]githubCodeownersError, bool, error) {
+	resp, err := githubGet(ctx, fmt.Sprintf("repos/%s/%s/codeowners/errors", orgName, repoName), nil, buildGitHubRequestHeaders())
+	if err != nil {
+		return nil, false, err
+	}
+	logRateLimitInfo(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, false, nil
+	}
+
+	var body struct {
+		Errors []githubCodeownersError `json:"errors"`
+	}
+	if err := decodeGitHubResponse(resp, "codeowners_errors", &body); err != nil {
+		return nil, false, err
+	}
+	return body.Errors, true, nil
+}
+
+// storeCodeownersErrors records the combined errors on the repository node, reporting whether the
+// repository is in the graph (Orchestrator)
+func storeCodeownersErrors(ctx *gofr.Context, deps *AppDependencies, response CodeownersErrorsResponse) (bool, error) {
+	encoded, err := json.Marshal(response.Errors)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode CODEOWNERS errors: %w", err)
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return false, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jWrite(ctx, session, buildStoreCodeownersErrorsQuery(), map[string]interface{}{
+		"fullName":   response.Organization + "/" + response.Repository,
+		"errors":     string(encoded),
+		"errorCount": len(response.Errors),
+		"checkedAt":  response.CheckedAt,
+	})
+	if err != nil {
+		return false, convertNeo4jErrorToGoFr(err)
+	}
+	return len(result.Records) > 0 && getIntFromMap(result.Records[0], "stored") > 0, nil
+}
+
+// getCodeownersErrors fetches GitHub's CODEOWNERS errors for a repository, merges them with the local
+// lint of the same file and stores the result on the repository (Orchestrator)
+func getCodeownersErrors(ctx *gofr.Context, deps *AppDependencies, orgName, repoName string) (CodeownersErrorsResponse, error) {
+	githubErrors, found, err := fetchGitHubCodeownersErrors(ctx, orgName, repoName)
+	if err != nil {
+		return CodeownersErrorsResponse{}, err
+	}
+	if !found {
+		return CodeownersErrorsResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "CODEOWNERS", Value: orgName + "/" + repoName}
+	}
+
+	lint, err := validateCodeowners(ctx, CodeownersValidationRequest{Organization: orgName, Repository: repoName})
+	if err != nil {
+		return CodeownersErrorsResponse{}, err
+	}
+
+	combined := mergeCodeownersErrors(githubErrors, lint.Errors)
+	response := CodeownersErrorsResponse{
+		Organization: orgName,
+		Repository:   repoName,
+		Path:         lint.Path,
+		Valid:        len(combined) == 0,
+		RuleCount:    lint.RuleCount,
+		GitHubErrors: len(githubErrors),
+		LintErrors:   len(lint.Errors),
+		Errors:       combined,
+		CheckedAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if response.Stored, err = storeCodeownersErrors(ctx, deps, response); err != nil {
+		return CodeownersErrorsResponse{}, err
+	}
+
+	logInfo(ctx, "Checked CODEOWNERS errors", LogFields{
+		"component":     "codeowners_validation",
+		"operation":     "codeowners_errors",
+		"organization":  orgName,
+		"repository":    repoName,
+		"github_errors": response.GitHubErrors,
+		"lint_errors":   response.LintErrors,
+		"stored":        response.Stored,
+	})
+	return response, nil
+}
+
+// handleGetCodeownersErrors returns GitHub's CODEOWNERS errors for a repository merged with the local lint findings
+func (h *AppHandler) handleGetCodeownersErrors(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	repoName := ctx.PathParam("repo")
+	if repoName == "" {
+		return nil, createMissingParamError("repo")
+	}
+
+	return getCodeownersErrors(ctx, h.deps, orgName, repoName)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// CodeownersExportRule is a single stored owner assignment of a CODEOWNERS pattern
+type CodeownersExportRule struct {
+	Pattern string
+	Line    int
+	Owner   string
+}
+
+// canonicalCodeownersEntry is a pattern with its distinct owners
+type canonicalCodeownersEntry struct {
+	pattern string
+	line    int
+	owners  []string
+}
+
+// buildCodeownersExportQuery builds a query returning the stored CODEOWNERS rules of a repository (Pure Core)
+func buildCodeownersExportQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository {full_name: $fullName})
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN repo.full_name AS repository,
+			org.active_scan_id AS scan_id,
+			collect(CASE WHEN owner IS NULL THEN NULL ELSE {
+				pattern: rule.pattern,
+				line: rule.line,
+				owner: CASE WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END
+			} END) AS rules
+	`
+}
+
+// convertToCodeownersExportRules converts collected rule maps into export rules (Pure Core)
+func convertToCodeownersExportRules(value interface{}) []CodeownersExportRule {
+	list, _ := value.([]interface{})
+	rules := make([]CodeownersExportRule, 0, len(list))
+	for _, item := range list {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules = append(rules, CodeownersExportRule{
+			Pattern: getStringFromMap(rule, "pattern"),
+			Line:    getIntFromMap(rule, "line"),
+			Owner:   getStringFromMap(rule, "owner"),
+		})
+	}
+	return rules
+}
+
+// canonicalizeCodeownersRules groups rules by pattern with sorted, distinct owners. Patterns keep
+// the order of their first appearance because later CODEOWNERS rules take precedence. (Pure Core)
+func canonicalizeCodeownersRules(rules []CodeownersExportRule) []canonicalCodeownersEntry {
+	byPattern := make(map[string]*canonicalCodeownersEntry)
+	for _, rule := range rules {
+		if rule.Pattern == "" || rule.Owner == "" {
+			continue
+		}
+
+		entry, exists := byPattern[rule.Pattern]
+		if !exists {
+			entry = &canonicalCodeownersEntry{pattern: rule.Pattern, line: rule.Line}
+			byPattern[rule.Pattern] = entry
+		}
+		if rule.Line < entry.line {
+			entry.line = rule.Line
+		}
+		// GitHub compares owners case-insensitively
+		if !lo.ContainsBy(entry.owners, func(owner string) bool { return strings.EqualFold(owner, rule.Owner) }) {
+			entry.owners = append(entry.owners, rule.Owner)
+		}
+	}
+
+	entries := make([]canonicalCodeownersEntry, 0, len(byPattern))
+	for _, entry := range byPattern {
+		sort.Strings(entry.owners)
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].line != entries[j].line {
+			return entries[i].line < entries[j].line
+		}
+		return entries[i].pattern < entries[j].pattern
+	})
+
+	return entries
+}
+
+// buildCanonicalCodeowners renders a canonical CODEOWNERS file with an annotated header (Pure Core)
+func buildCanonicalCodeowners(repository, scanID string, generatedAt time.Time, rules []CodeownersExportRule) string {
+	entries := canonicalizeCodeownersRules(rules)
+
+	width := 0
+	for _, entry := range entries {
+		if len(entry.pattern) > width {
+			width = len(entry.pattern)
+		}
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# CODEOWNERS for %s\n", repository)
+	fmt.Fprintf(&builder, "# Generated by codeowners-scanner from scan %s at %s\n", scanID, generatedAt.UTC().Format(time.RFC3339))
+	builder.WriteString("# Rules keep their original order (later rules take precedence); owners are sorted and deduplicated.\n")
+
+	if len(entries) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(&builder, "%-*s %s\n", width, entry.pattern, strings.Join(entry.owners, " "))
+	}
+
+	return builder.String()
+}
+
+// exportRepositoryCodeowners regenerates the CODEOWNERS file of a repository from the graph (Orchestrator)
+func exportRepositoryCodeowners(ctx *gofr.Context, deps *AppDependencies, orgName, repoName string) (string, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return "", convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	fullName := orgName + "/" + repoName
+	result, err := executeNeo4jReadQuery(ctx, session, buildCodeownersExportQuery(), map[string]interface{}{
+		"orgName":  orgName,
+		"fullName": fullName,
+	})
+	if err != nil {
+		return "", convertNeo4jErrorToGoFr(err)
+	}
+
+	if len(result.Records) == 0 {
+		return "", &gofrhttp.ErrorEntityNotFound{
+			Name:  "repository",
+			Value: fullName,
+		}
+	}
+
+	record := result.Records[0]
+	rules := convertToCodeownersExportRules(record["rules"])
+	return buildCanonicalCodeowners(getStringFromMap(record, "repository"), getStringFromMap(record, "scan_id"), time.Now(), rules), nil
+}
+
+// handleExportCodeowners returns a canonical CODEOWNERS file regenerated from the stored rules
+func (h *AppHandler) handleExportCodeowners(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	repoName := ctx.PathParam("repo")
+	if repoName == "" {
+		return nil, createMissingParamError("repo")
+	}
+
+	content, err := exportRepositoryCodeowners(ctx, h.deps, orgName, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.File{Content: []byte(content), ContentType: "text/plain; charset=utf-8"}, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// codeownersLocation is a path checked for a CODEOWNERS file, with the alias its blob is read under in
+// GraphQL queries
+type codeownersLocation struct {
+	alias string
+	path  string
+}
+
+// builtinCodeownersLocations are the paths GitHub reads a CODEOWNERS file from, in its order of precedence
+var builtinCodeownersLocations = []codeownersLocation{
+	{"root", "CODEOWNERS"},
+	{"github", ".github/CODEOWNERS"},
+	{"docs", "docs/CODEOWNERS"},
+}
+
+// codeownersSearch is where scans look for CODEOWNERS files: the first file found among locations
+// and, when nested is set, the file of each top-level directory
+type codeownersSearch struct {
+	locations []codeownersLocation
+	nested    bool
+}
+
+// codeownersSearchState is the CODEOWNERS search of every scan of the process
+var (
+	codeownersSearchMu    sync.RWMutex
+	codeownersSearchState = codeownersSearch{locations: buildCodeownersLocations(nil)}
+)
+
+// parseCodeownersPaths splits a comma-separated list of repository paths, dropping blanks, leading
+// slashes and repeats (Pure Core)
+func parseCodeownersPaths(value string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimLeft(strings.TrimSpace(entry), "/")
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		paths = append(paths, entry)
+	}
+	return paths
+}
+
+// isValidCodeownersPath reports whether a path names a file inside a repository and can be embedded
+// in a GraphQL object expression (Pure Core)
+func isValidCodeownersPath(filePath string) bool {
+	return filePath != "" &&
+		path.Clean(filePath) == filePath &&
+		!path.IsAbs(filePath) &&
+		filePath != ".." && !strings.HasPrefix(filePath, "../") &&
+		!strings.ContainsAny(filePath, "\"\\")
+}
+
+// buildCodeownersLocations lists the built-in locations followed by the extra paths not already
+// among them, aliased extra0, extra1, ... (Pure Core)
+func buildCodeownersLocations(extraPaths []string) []codeownersLocation {
+	locations := append(make([]codeownersLocation, 0, len(builtinCodeownersLocations)+len(extraPaths)), builtinCodeownersLocations...)
+	seen := make(map[string]bool)
+	for _, builtin := range builtinCodeownersLocations {
+		seen[builtin.path] = true
+	}
+	for _, extra := range extraPaths {
+		if seen[extra] {
+			continue
+		}
+		seen[extra] = true
+		locations = append(locations, codeownersLocation{alias: fmt.Sprintf("extra%d", len(locations)-len(builtinCodeownersLocations)), path: extra})
+	}
+	return locations
+}
+
+// configureCodeownersSearch sets the locations and nested file support of the next CODEOWNERS fetches
+func configureCodeownersSearch(config CodeownersConfig) {
+	codeownersSearchMu.Lock()
+	defer codeownersSearchMu.Unlock()
+	codeownersSearchState = codeownersSearch{locations: buildCodeownersLocations(config.ExtraPaths), nested: config.Nested}
+}
+
+// currentCodeownersSearch returns where CODEOWNERS files are looked for
+func currentCodeownersSearch() codeownersSearch {
+	codeownersSearchMu.RLock()
+	defer codeownersSearchMu.RUnlock()
+	return codeownersSearchState
+}
+
+// isCodeownersPath reports whether a repository file is a CODEOWNERS file the search reads: one of its
+// locations or, with nested files, one of them inside a top-level directory (Pure Core)
+func isCodeownersPath(file string, search codeownersSearch) bool {
+	dir, rest, inDirectory := strings.Cut(file, "/")
+	for _, location := range search.locations {
+		if file == location.path {
+			return true
+		}
+		if search.nested && inDirectory && dir != "" && rest == location.path {
+			return true
+		}
+	}
+	return false
+}
+
+// findNestedCodeownersFiles returns the CODEOWNERS file of each top-level directory of a repository, the
+// first of the search locations found within it, sorted by directory. Files that are themselves
+// repository-level locations, such as docs/CODEOWNERS, are not nested files (Pure Core)
+func findNestedCodeownersFiles(files []string, locations []codeownersLocation) []string {
+	present := make(map[string]bool, len(files))
+	directories := make(map[string]bool)
+	for _, file := range files {
+		present[file] = true
+		if dir, _, ok := strings.Cut(file, "/"); ok && dir != "" {
+			directories[dir] = true
+		}
+	}
+	rootLevel := make(map[string]bool, len(locations))
+	for _, location := range locations {
+		rootLevel[location.path] = true
+	}
+
+	nested := []string{}
+	for dir := range directories {
+		for _, location := range locations {
+			candidate := dir + "/" + location.path
+			if present[candidate] && !rootLevel[candidate] {
+				nested = append(nested, candidate)
+				break
+			}
+		}
+	}
+	sort.Strings(nested)
+	return nested
+}
+
+// scopeNestedCodeownersPattern rewrites a pattern of the CODEOWNERS file of directory dir to match the
+// same paths from the repository root: anchored patterns are resolved against dir and unanchored ones
+// match at any depth beneath it (Pure Core)
+func scopeNestedCodeownersPattern(dir, pattern string) string {
+	trimmed := strings.TrimSuffix(pattern, "/")
+	if strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/") {
+		return "/" + dir + "/" + strings.TrimPrefix(pattern, "/")
+	}
+	return "/" + dir + "/**/" + pattern
+}
+
+// scopeNestedCodeownersRules scopes the rules of a nested CODEOWNERS file to its directory, numbering
+// them from after firstLine so they follow, and take precedence over, the rules before them. Each
+// rule keeps its file and line there as its source (Pure Core)
+func scopeNestedCodeownersRules(source string, rules []GitHubCodeownersRule, firstLine int) []GitHubCodeownersRule {
+	dir, _, _ := strings.Cut(source, "/")
+	scoped := make([]GitHubCodeownersRule, 0, len(rules))
+	for _, rule := range rules {
+		scoped = append(scoped, GitHubCodeownersRule{
+			Pattern:    scopeNestedCodeownersPattern(dir, rule.Pattern),
+			Owners:     rule.Owners,
+			Line:       firstLine + rule.Line,
+			Source:     source,
+			SourceLine: rule.Line,
+		})
+	}
+	return scoped
+}
+
+// lastCodeownersLine returns the highest line number among rules (Pure Core)
+func lastCodeownersLine(rules []GitHubCodeownersRule) int {
+	last := 0
+	for _, rule := range rules {
+		if rule.Line > last {
+			last = rule.Line
+		}
+	}
+	return last
+}
+
+// fetchCodeownersFileRules reads and parses one CODEOWNERS file of a repository's default branch;
+// a missing or empty file has no rules
+func fetchCodeownersFileRules(ctx *gofr.Context, fullName, filePath string) ([]GitHubCodeownersRule, error) {
+	resp, err := githubGet(ctx, fmt.Sprintf("repos/%s/contents/%s", fullName, filePath), nil, buildGitHubRequestHeaders())
+	if err != nil {
+		return nil, err
+	}
+	logRateLimitInfo(ctx, resp)
+	newMetricsCollector(ctx, "codeowners-scanner").recordAPICallCount("github", "codeowners", resp.StatusCode)
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, nil
+	}
+
+	var file struct {
+		Content string `json:"content"`
+	}
+	if err := decodeGitHubResponse(resp, "get_nested_codeowners", &file); err != nil {
+		return nil, err
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(file.Content); err != nil || strings.TrimSpace(string(decoded)) == "" {
+		return nil, nil
+	}
+	return parseCodeownersContent(file.Content), nil
+}
+
+// appendNestedCodeowners adds the scoped rules of the CODEOWNERS files in a repository's top-level
+// directories after the rules of its repository-level file and returns how many were added. A
+// repository whose tree or files cannot be read keeps its repository-level rules (Orchestrator)
+func appendNestedCodeowners(ctx *gofr.Context, codeowners *GitHubCodeowners, search codeownersSearch) int {
+	if !search.nested {
+		return 0
+	}
+
+	files, truncated, err := fetchRepositoryFiles(ctx, codeowners.Repository)
+	if err != nil {
+		logWarn(ctx, "Failed to list repository files for nested CODEOWNERS", LogFields{
+			"component":  "github_client",
+			"operation":  "fetch_nested_codeowners",
+			"repository": codeowners.Repository,
+			"error":      err.Error(),
+		})
+		return 0
+	}
+	if truncated {
+		logWarn(ctx, "Repository tree truncated; nested CODEOWNERS files may be missed", LogFields{
+			"component":  "github_client",
+			"operation":  "fetch_nested_codeowners",
+			"repository": codeowners.Repository,
+		})
+	}
+
+	rules := append([]GitHubCodeownersRule{}, codeowners.Rules...)
+	added := 0
+	for _, source := range findNestedCodeownersFiles(files, search.locations) {
+		fileRules, err := fetchCodeownersFileRules(ctx, codeowners.Repository, source)
+		if err != nil {
+			logWarn(ctx, "Failed to read nested CODEOWNERS file", LogFields{
+				"component":  "github_client",
+				"operation":  "fetch_nested_codeowners",
+				"repository": codeowners.Repository,
+				"path":       source,
+				"error":      err.Error(),
+			})
+			continue
+		}
+		if len(fileRules) == 0 {
+			continue
+		}
+
+		rules = append(rules, scopeNestedCodeownersRules(source, fileRules, lastCodeownersLine(rules))...)
+		added += len(fileRules)
+		newMetricsCollector(ctx, "codeowners-scanner").recordCounter("codeowners_nested_files_total", 1, MetricLabels{})
+		logDebug(ctx, "Nested CODEOWNERS file found", LogFields{
+			"component":   "github_client",
+			"operation":   "fetch_nested_codeowners",
+			"repository":  codeowners.Repository,
+			"path":        source,
+			"rules_count": len(fileRules),
+		})
+	}
+
+	codeowners.Rules = rules
+	return added
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// codeownersPatternCache memoizes compiled CODEOWNERS patterns; invalid patterns are cached as nil
+var codeownersPatternCache sync.Map
+
+// CodeownersMatcher resolves the owners of paths against one repository's rules
+type CodeownersMatcher struct {
+	rules []compiledCodeownersRule
+}
+
+// compiledCodeownersRule is a CODEOWNERS rule with its compiled pattern
+type compiledCodeownersRule struct {
+	entry   canonicalCodeownersEntry
+	pattern *regexp.Regexp
+}
+
+// CodeownersMatch is the rule deciding the owners of a path
+type CodeownersMatch struct {
+	Pattern string
+	Line    int
+	Owners  []string
+}
+
+// buildCodeownersPatternRegexp translates a CODEOWNERS pattern into a regular expression
+// following gitignore rules: a leading or inner slash anchors the pattern to the repository
+// root, a trailing slash matches directories only, * and ? stay within a path segment and
+// ** spans segments. Patterns naming a directory also match everything beneath it. (Pure Core)
+func buildCodeownersPatternRegexp(pattern string) string {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var builder strings.Builder
+	if anchored {
+		builder.WriteString("^")
+	} else {
+		builder.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch char := trimmed[i]; {
+		case char == '*' && i+1 < len(trimmed) && trimmed[i+1] == '*':
+			if i+2 < len(trimmed) && trimmed[i+2] == '/' {
+				builder.WriteString("(?:.*/)?")
+				i += 2
+			} else {
+				builder.WriteString(".*")
+				i++
+			}
+		case char == '*':
+			builder.WriteString("[^/]*")
+		case char == '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case directoryOnly:
+		builder.WriteString("/.*$")
+	case strings.ContainsAny(lastSegment, "*?"):
+		builder.WriteString("$")
+	default:
+		builder.WriteString("(?:/.*)?$")
+	}
+
+	return builder.String()
+}
+
+// compileCodeownersPattern returns the cached compiled form of a pattern, or nil if it is invalid
+func compileCodeownersPattern(pattern string) *regexp.Regexp {
+	if cached, ok := codeownersPatternCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	compiled, err := regexp.Compile(buildCodeownersPatternRegexp(pattern))
+	if err != nil {
+		compiled = nil
+	}
+	actual, _ := codeownersPatternCache.LoadOrStore(pattern, compiled)
+	return actual.(*regexp.Regexp)
+}
+
+// newCodeownersMatcher compiles the canonical rules of a repository
+func newCodeownersMatcher(rules []CodeownersExportRule) *CodeownersMatcher {
+	entries := canonicalizeCodeownersRules(rules)
+	compiled := make([]compiledCodeownersRule, 0, len(entries))
+	for _, entry := range entries {
+		if pattern := compileCodeownersPattern(entry.pattern); pattern != nil {
+			compiled = append(compiled, compiledCodeownersRule{entry: entry, pattern: pattern})
+		}
+	}
+	return &CodeownersMatcher{rules: compiled}
+}
+
+// resolveRule returns the index of the last rule matching a path, or -1 when no rule matches
+func (m *CodeownersMatcher) resolveRule(path string) int {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.rules[i].pattern.MatchString(path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolve returns the last rule matching a path, as later CODEOWNERS rules take precedence
+func (m *CodeownersMatcher) resolve(path string) (CodeownersMatch, bool) {
+	index := m.resolveRule(path)
+	if index < 0 {
+		return CodeownersMatch{}, false
+	}
+	rule := m.rules[index].entry
+	return CodeownersMatch{Pattern: rule.pattern, Line: rule.line, Owners: rule.owners}, true
+}
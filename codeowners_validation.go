@@ -0,0 +1,423 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// maxCodeownersBytes is the largest CODEOWNERS file GitHub reads; larger files are ignored entirely
+const maxCodeownersBytes = 3 << 20
+
+// Kinds of CODEOWNERS validation errors
+const (
+	codeownersErrorSyntax       = "syntax_error"
+	codeownersErrorInvalidOwner = "invalid_owner"
+	codeownersErrorUnknownOwner = "unknown_owner"
+	codeownersErrorUnreachable  = "unreachable_pattern"
+	codeownersErrorNoWrite      = "owner_without_write_access"
+)
+
+// codeownersShadowProbe is a path segment no CODEOWNERS pattern names literally, used to test whether a
+// pattern matches every path beneath a directory
+const codeownersShadowProbe = "\x00"
+
+// CODEOWNERS owner forms: @user, @org/team-slug and e-mail addresses
+var (
+	codeownersUserPattern  = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+	codeownersTeamPattern  = regexp.MustCompile(`^@[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	codeownersEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// CodeownersValidationRequest is CODEOWNERS content to validate, or a repository whose file is read from GitHub
+type CodeownersValidationRequest struct {
+	Organization string `json:"organization"`
+	Repository   string `json:"repository"`
+	Content      string `json:"content"`
+}
+
+// CodeownersValidationError is a problem found in a CODEOWNERS line, shaped after GitHub's errors API
+type CodeownersValidationError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Kind    string `json:"kind"`
+	Source  string `json:"source"`
+	Owner   string `json:"owner,omitempty"`
+	Message string `json:"message"`
+}
+
+// CodeownersValidationResponse lists the problems found in a CODEOWNERS file
+type CodeownersValidationResponse struct {
+	Organization string                      `json:"organization"`
+	Repository   string                      `json:"repository,omitempty"`
+	Path         string                      `json:"path,omitempty"`
+	Valid        bool                        `json:"valid"`
+	RuleCount    int                         `json:"rule_count"`
+	Errors       []CodeownersValidationError `json:"errors"`
+}
+
+// codeownersLine is a rule of a CODEOWNERS file with its position in the file
+type codeownersLine struct {
+	line    int
+	source  string
+	pattern string
+	owners  []string
+	columns []int
+}
+
+// parseCodeownersLines splits CODEOWNERS content into rules, keeping file line numbers and the column
+// of every owner; trailing comments are dropped (Pure Core)
+func parseCodeownersLines(content string) []codeownersLine {
+	var lines []codeownersLine
+	for index, raw := range strings.Split(content, "\n") {
+		source := strings.TrimRight(raw, "\r")
+		trimmed := strings.TrimSpace(source)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		parsed := codeownersLine{line: index + 1, source: source}
+		offset := 0
+		for _, field := range strings.Fields(source) {
+			column := strings.Index(source[offset:], field) + offset + 1
+			offset = column - 1 + len(field)
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			if parsed.pattern == "" {
+				parsed.pattern = field
+				continue
+			}
+			parsed.owners = append(parsed.owners, field)
+			parsed.columns = append(parsed.columns, column)
+		}
+		lines = append(lines, parsed)
+	}
+	return lines
+}
+
+// codeownersOwnerKind classifies an owner as "user", "team" or "email", or "" when it is malformed (Pure Core)
+func codeownersOwnerKind(owner string) string {
+	switch {
+	case codeownersTeamPattern.MatchString(owner):
+		return "team"
+	case codeownersUserPattern.MatchString(owner):
+		return "user"
+	case codeownersEmailPattern.MatchString(owner):
+		return "email"
+	}
+	return ""
+}
+
+// lintCodeownersSyntax reports patterns GitHub rejects and malformed owners (Pure Core)
+func lintCodeownersSyntax(lines []codeownersLine) []CodeownersValidationError {
+	var errors []CodeownersValidationError
+	for _, line := range lines {
+		patternColumn := strings.Index(line.source, line.pattern) + 1
+		switch {
+		case strings.HasPrefix(line.pattern, "!"):
+			errors = append(errors, CodeownersValidationError{Line: line.line, Column: patternColumn, Kind: codeownersErrorSyntax, Source: line.source,
+				Message: "negated patterns are not supported in CODEOWNERS"})
+		case strings.ContainsAny(line.pattern, "[]"):
+			errors = append(errors, CodeownersValidationError{Line: line.line, Column: patternColumn, Kind: codeownersErrorSyntax, Source: line.source,
+				Message: "character ranges are not supported in CODEOWNERS"})
+		case compileCodeownersPattern(line.pattern) == nil:
+			errors = append(errors, CodeownersValidationError{Line: line.line, Column: patternColumn, Kind: codeownersErrorSyntax, Source: line.source,
+				Message: "pattern cannot be parsed"})
+		}
+
+		for i, owner := range line.owners {
+			if codeownersOwnerKind(owner) == "" {
+				errors = append(errors, CodeownersValidationError{Line: line.line, Column: line.columns[i], Kind: codeownersErrorInvalidOwner, Source: line.source, Owner: owner,
+					Message: "owner must be @user, @org/team or an e-mail address"})
+			}
+		}
+	}
+	return errors
+}
+
+// codeownersShadowedPaths returns probe paths standing for everything a pattern matches: the static
+// directory before its first wildcard, with arbitrary names beneath it (Pure Core)
+func codeownersShadowedPaths(pattern string) []string {
+	trimmed := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var static []string
+	if anchored {
+		for _, segment := range strings.Split(trimmed, "/") {
+			if strings.ContainsAny(segment, "*?") {
+				break
+			}
+			static = append(static, segment)
+		}
+	}
+
+	base := strings.Join(static, "/")
+	probes := []string{codeownersShadowProbe, codeownersShadowProbe + "/" + codeownersShadowProbe}
+	if base == "" {
+		return probes
+	}
+	paths := []string{base + "/" + probes[0], base + "/" + probes[1]}
+	if !strings.ContainsAny(trimmed, "*?") && !strings.HasSuffix(pattern, "/") {
+		paths = append(paths, base)
+	}
+	return paths
+}
+
+// findUnreachableCodeownersRules reports rules whose every match is also matched by a later rule, which
+// takes precedence, so the earlier owners never apply (Pure Core)
+func findUnreachableCodeownersRules(lines []codeownersLine) []CodeownersValidationError {
+	var errors []CodeownersValidationError
+	for i, line := range lines {
+		// Lines with syntax errors are reported once, by lintCodeownersSyntax
+		if strings.HasPrefix(line.pattern, "!") || strings.ContainsAny(line.pattern, "[]") || compileCodeownersPattern(line.pattern) == nil {
+			continue
+		}
+		probes := codeownersShadowedPaths(line.pattern)
+
+		for j := len(lines) - 1; j > i; j-- {
+			later := compileCodeownersPattern(lines[j].pattern)
+			if later == nil {
+				continue
+			}
+			covered := true
+			for _, probe := range probes {
+				if !later.MatchString(probe) {
+					covered = false
+					break
+				}
+			}
+			if covered {
+				errors = append(errors, CodeownersValidationError{
+					Line:    line.line,
+					Column:  strings.Index(line.source, line.pattern) + 1,
+					Kind:    codeownersErrorUnreachable,
+					Source:  line.source,
+					Message: fmt.Sprintf("pattern is shadowed by %q on line %d and never applies", lines[j].pattern, lines[j].line),
+				})
+				break
+			}
+		}
+	}
+	return errors
+}
+
+// githubStatusRequest sends a GET request to GitHub and returns the response for status inspection
+func githubStatusRequest(ctx *gofr.Context, endpoint, accept string) (*http.Response, error) {
+	headers := buildGitHubRequestHeaders()
+	if accept != "" {
+		headers["Accept"] = accept
+	}
+	resp, err := githubGet(ctx, endpoint, nil, headers)
+	if err != nil {
+		return nil, newGitHubAPIError("owner_lookup_failed", "failed to look up CODEOWNERS owner", err.Error(), http.StatusBadGateway)
+	}
+	logRateLimitInfo(ctx, resp)
+	return resp, nil
+}
+
+// unexpectedOwnerLookupStatus converts an unexpected GitHub status into an error
+func unexpectedOwnerLookupStatus(endpoint string, status int) error {
+	return newGitHubAPIError(
+		"owner_lookup_status",
+		"GitHub returned an unexpected status while validating CODEOWNERS owners",
+		fmt.Sprintf("%s returned status %d", endpoint, status),
+		http.StatusBadGateway,
+	)
+}
+
+// lookupCodeownersOwner reports whether a user is a member of the organization or a team exists in it
+func lookupCodeownersOwner(ctx *gofr.Context, orgName, owner string) (bool, error) {
+	var endpoint string
+	switch codeownersOwnerKind(owner) {
+	case "team":
+		teamOrg, slug, _ := strings.Cut(strings.TrimPrefix(owner, "@"), "/")
+		if !strings.EqualFold(teamOrg, orgName) {
+			return false, nil
+		}
+		endpoint = fmt.Sprintf("orgs/%s/teams/%s", orgName, slug)
+	case "user":
+		endpoint = fmt.Sprintf("orgs/%s/members/%s", orgName, strings.TrimPrefix(owner, "@"))
+	default:
+		// E-mail owners resolve to accounts only GitHub can see; they are not checked
+		return true, nil
+	}
+
+	resp, err := githubStatusRequest(ctx, endpoint, "")
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return true, nil
+	case http.StatusNotFound, http.StatusFound:
+		return false, nil
+	}
+	return false, unexpectedOwnerLookupStatus(endpoint, resp.StatusCode)
+}
+
+// lookupCodeownersOwnerWriteAccess reports whether a user or team can push to the repository
+func lookupCodeownersOwnerWriteAccess(ctx *gofr.Context, orgName, repoName, owner string) (bool, error) {
+	kind := codeownersOwnerKind(owner)
+	var endpoint, accept string
+	switch kind {
+	case "team":
+		_, slug, _ := strings.Cut(strings.TrimPrefix(owner, "@"), "/")
+		endpoint = fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", orgName, slug, orgName, repoName)
+		accept = "application/vnd.github.v3.repository+json"
+	case "user":
+		endpoint = fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", orgName, repoName, strings.TrimPrefix(owner, "@"))
+	default:
+		return true, nil
+	}
+
+	resp, err := githubStatusRequest(ctx, endpoint, accept)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, unexpectedOwnerLookupStatus(endpoint, resp.StatusCode)
+	}
+
+	var access struct {
+		Permission  string `json:"permission"`
+		Permissions struct {
+			Admin    bool `json:"admin"`
+			Maintain bool `json:"maintain"`
+			Push     bool `json:"push"`
+		} `json:"permissions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&access); err != nil {
+		return false, fmt.Errorf("failed to decode permission of %s: %w", owner, err)
+	}
+	if kind == "team" {
+		return access.Permissions.Admin || access.Permissions.Maintain || access.Permissions.Push, nil
+	}
+	return access.Permission == "admin" || access.Permission == "write", nil
+}
+
+// validateCodeownersOwners checks every distinct well-formed owner once against GitHub: membership of
+// the organization and, when the file belongs to a repository, write access to it (Orchestrator)
+func validateCodeownersOwners(ctx *gofr.Context, orgName, repoName string, lines []codeownersLine) ([]CodeownersValidationError, error) {
+	known := make(map[string]bool)
+	writable := make(map[string]bool)
+	var errors []CodeownersValidationError
+
+	for _, line := range lines {
+		for i, owner := range line.owners {
+			key := strings.ToLower(owner)
+			if codeownersOwnerKind(owner) == "" {
+				continue
+			}
+
+			exists, checked := known[key]
+			if !checked {
+				var err error
+				if exists, err = lookupCodeownersOwner(ctx, orgName, owner); err != nil {
+					return nil, err
+				}
+				known[key] = exists
+			}
+			if !exists {
+				errors = append(errors, CodeownersValidationError{Line: line.line, Column: line.columns[i], Kind: codeownersErrorUnknownOwner, Source: line.source, Owner: owner,
+					Message: fmt.Sprintf("%s is not a member or team of %s", owner, orgName)})
+				continue
+			}
+			if repoName == "" {
+				continue
+			}
+
+			canWrite, checked := writable[key]
+			if !checked {
+				var err error
+				if canWrite, err = lookupCodeownersOwnerWriteAccess(ctx, orgName, repoName, owner); err != nil {
+					return nil, err
+				}
+				writable[key] = canWrite
+			}
+			if !canWrite {
+				errors = append(errors, CodeownersValidationError{Line: line.line, Column: line.columns[i], Kind: codeownersErrorNoWrite, Source: line.source, Owner: owner,
+					Message: fmt.Sprintf("%s does not have write access to %s/%s, so GitHub ignores it as a code owner", owner, orgName, repoName)})
+			}
+		}
+	}
+	return errors, nil
+}
+
+// validateCodeowners lints CODEOWNERS content, reading the repository's file from GitHub when no
+// content is given (Orchestrator)
+func validateCodeowners(ctx *gofr.Context, request CodeownersValidationRequest) (CodeownersValidationResponse, error) {
+	response := CodeownersValidationResponse{
+		Organization: request.Organization,
+		Repository:   request.Repository,
+		Errors:       []CodeownersValidationError{},
+	}
+
+	content := request.Content
+	if content == "" {
+		codeowners, err := fetchCodeownersForReposWithService(ctx, []GitHubRepository{{FullName: request.Organization + "/" + request.Repository}})
+		if err != nil {
+			return CodeownersValidationResponse{}, err
+		}
+		if len(codeowners) == 0 || codeowners[0].Content == "" {
+			return CodeownersValidationResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "CODEOWNERS", Value: request.Organization + "/" + request.Repository}
+		}
+		content = codeowners[0].Content
+		response.Path = codeowners[0].Path
+	}
+	if len(content) > maxCodeownersBytes {
+		response.Errors = append(response.Errors, CodeownersValidationError{Line: 1, Column: 1, Kind: codeownersErrorSyntax,
+			Message: fmt.Sprintf("file is larger than %d bytes, so GitHub ignores it", maxCodeownersBytes)})
+		return response, nil
+	}
+
+	lines := parseCodeownersLines(content)
+	response.RuleCount = len(lines)
+	response.Errors = append(response.Errors, lintCodeownersSyntax(lines)...)
+	response.Errors = append(response.Errors, findUnreachableCodeownersRules(lines)...)
+
+	ownerErrors, err := validateCodeownersOwners(ctx, request.Organization, request.Repository, lines)
+	if err != nil {
+		return CodeownersValidationResponse{}, err
+	}
+	response.Errors = append(response.Errors, ownerErrors...)
+	response.Valid = len(response.Errors) == 0
+
+	logInfo(ctx, "Validated CODEOWNERS", LogFields{
+		"component":    "codeowners_validation",
+		"operation":    "validate_codeowners",
+		"organization": request.Organization,
+		"repository":   request.Repository,
+		"rule_count":   response.RuleCount,
+		"error_count":  len(response.Errors),
+	})
+	return response, nil
+}
+
+// handleValidateCodeowners validates CODEOWNERS content or a repository's CODEOWNERS file
+func (h *AppHandler) handleValidateCodeowners(ctx *gofr.Context) (interface{}, error) {
+	var request CodeownersValidationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+	if request.Organization == "" {
+		return nil, createMissingParamError("organization")
+	}
+	if request.Content == "" && request.Repository == "" {
+		return nil, createMissingParamError("content")
+	}
+
+	return validateCodeowners(ctx, request)
+}
@@ -4,40 +4,119 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // loadConfigFromEnv loads configuration from environment variables
 func loadConfigFromEnv() AppConfig {
 	return AppConfig{
-		Environment: getEnvOrDefault("ENVIRONMENT", "development"),
-		Port:        getIntEnvOrDefault("HTTP_PORT", 8081),
-		GitHub:      loadGitHubConfig(),
-		Neo4j:       loadNeo4jConfig(),
-		Server:      loadServerConfig(),
+		Environment:      getEnvOrDefault("ENVIRONMENT", "development"),
+		Port:             getIntEnvOrDefault("HTTP_PORT", 8081),
+		SCMProvider:      strings.ToLower(getEnvOrDefault("SCM_PROVIDER", scmProviderGitHub)),
+		GitHub:           loadGitHubConfig(),
+		GitLab:           loadGitLabConfig(),
+		Bitbucket:        loadBitbucketConfig(),
+		Neo4j:            loadNeo4jConfig(),
+		Server:           loadServerConfig(),
+		Maintenance:      loadMaintenanceConfig(),
+		ScanValidation:   loadScanValidationConfig(),
+		GraphTypes:       loadGraphTypesConfig(),
+		Freshness:        loadFreshnessConfig(),
+		Quota:            loadQuotaConfig(),
+		ScanWatchdog:     loadScanWatchdogConfig(),
+		ScanJobs:         loadScanJobsConfig(),
+		ScanBudget:       loadScanBudgetConfig(),
+		ReportFormat:     loadReportFormatConfig(),
+		GraphLimits:      loadGraphLimitsConfig(),
+		GraphConversion:  loadGraphConversionConfig(),
+		StaleCache:       loadStaleCacheConfig(),
+		Warmup:           loadWarmupConfig(),
+		Reconciliation:   loadReconciliationConfig(),
+		Archival:         loadArchivalConfig(),
+		SLO:              loadSLOConfig(),
+		Transfer:         loadTransferConfig(),
+		AuditLog:         loadAuditLogConfig(),
+		Webhook:          loadWebhookConfig(),
+		Dependencies:     loadDependencyAnalysisConfig(),
+		ScanSchedule:     loadScanScheduleConfig(),
+		StateStore:       loadStateStoreConfig(),
+		LeaderElection:   loadLeaderElectionConfig(),
+		CircuitBreaker:   loadCircuitBreakerConfig(),
+		CustomProperties: loadCustomPropertiesConfig(),
+		Codeowners:       loadCodeownersConfig(),
+		Bots:             loadBotsConfig(),
+		TeamVisibility:   loadTeamVisibilityConfig(),
+		Auth:             loadAuthConfig(),
+		Publish:          loadPublishConfig(),
+		RateLimit:        loadRateLimitConfig(),
 	}
 }
 
 // loadGitHubConfig loads GitHub configuration from environment
 func loadGitHubConfig() GitHubConfig {
 	return GitHubConfig{
-		Token:        os.Getenv("GITHUB_TOKEN"),
-		BaseURL:      getEnvOrDefault("GITHUB_BASE_URL", "https://api.github.com"),
-		UserAgent:    getEnvOrDefault("GITHUB_USER_AGENT", "overseer-codeowners-scanner/1.0"),
-		Timeout:      getDurationEnvOrDefault("GITHUB_TIMEOUT", 30*time.Second),
-		MaxRetries:   getIntEnvOrDefault("GITHUB_MAX_RETRIES", 3),
-		RateLimitMin: getIntEnvOrDefault("GITHUB_RATE_LIMIT_MIN", 100),
+		Token:             os.Getenv("GITHUB_TOKEN"),
+		BaseURL:           getEnvOrDefault("GITHUB_BASE_URL", "https://api.github.com"),
+		UserAgent:         getEnvOrDefault("GITHUB_USER_AGENT", "overseer-codeowners-scanner/1.0"),
+		Timeout:           getDurationEnvOrDefault("GITHUB_TIMEOUT", 30*time.Second),
+		MaxRetries:        getIntEnvOrDefault("GITHUB_MAX_RETRIES", 3),
+		ETagCacheBytes:    getIntEnvOrDefault("GITHUB_ETAG_CACHE_BYTES", 64<<20),
+		RateLimitMin:      getIntEnvOrDefault("GITHUB_RATE_LIMIT_MIN", 100),
+		ReservePercent:    getIntEnvOrDefault("GITHUB_INTERACTIVE_RESERVE_PERCENT", 10),
+		ReserveMaxWait:    getDurationEnvOrDefault("GITHUB_RESERVE_MAX_WAIT", 15*time.Minute),
+		DegradePercent:    getIntEnvOrDefault("GITHUB_GRAPHQL_DEGRADE_PERCENT", 25),
+		UseGraphQL:        getBoolEnvOrDefault("GITHUB_USE_GRAPHQL", true),
+		AppID:             int64(getIntEnvOrDefault("GITHUB_APP_ID", 0)),
+		AppInstallationID: int64(getIntEnvOrDefault("GITHUB_APP_INSTALLATION_ID", 0)),
+		AppPrivateKey:     os.Getenv("GITHUB_APP_PRIVATE_KEY"),
+		AppPrivateKeyPath: os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"),
+		Transport: GitHubTransportConfig{
+			MaxIdleConns:        getIntEnvOrDefault("GITHUB_HTTP_MAX_IDLE_CONNS", 100),
+			MaxIdleConnsPerHost: getIntEnvOrDefault("GITHUB_HTTP_MAX_IDLE_CONNS_PER_HOST", 32),
+			IdleConnTimeout:     getDurationEnvOrDefault("GITHUB_HTTP_IDLE_CONN_TIMEOUT", 90*time.Second),
+			HTTP2:               getBoolEnvOrDefault("GITHUB_HTTP2", true),
+		},
+	}
+}
+
+// loadGitLabConfig loads GitLab configuration from environment
+func loadGitLabConfig() GitLabConfig {
+	return GitLabConfig{
+		BaseURL: strings.TrimSuffix(getEnvOrDefault("GITLAB_BASE_URL", "https://gitlab.com/api/v4"), "/"),
+		Token:   os.Getenv("GITLAB_TOKEN"),
+	}
+}
+
+// loadBitbucketConfig loads Bitbucket Cloud configuration from environment
+func loadBitbucketConfig() BitbucketConfig {
+	return BitbucketConfig{
+		BaseURL:     strings.TrimSuffix(getEnvOrDefault("BITBUCKET_BASE_URL", "https://api.bitbucket.org/2.0"), "/"),
+		Token:       os.Getenv("BITBUCKET_TOKEN"),
+		Username:    os.Getenv("BITBUCKET_USERNAME"),
+		AppPassword: os.Getenv("BITBUCKET_APP_PASSWORD"),
 	}
 }
 
 // loadNeo4jConfig loads Neo4j configuration from environment
 func loadNeo4jConfig() Neo4jConfig {
+	provider := strings.ToLower(getEnvOrDefault("NEO4J_PROVIDER", graphProviderNeo4j))
+	defaultDatabase := "neo4j"
+	if provider == graphProviderMemgraph {
+		defaultDatabase = "memgraph"
+	}
+
 	return Neo4jConfig{
+		Provider: provider,
 		URI:      getEnvOrDefault("NEO4J_URI", "bolt://localhost:7687"),
 		Username: getEnvOrDefault("NEO4J_USERNAME", "neo4j"),
 		Password: getEnvOrDefault("NEO4J_PASSWORD", "password"),
-		Database: getEnvOrDefault("NEO4J_DATABASE", "neo4j"),
+		Database: getEnvOrDefault("NEO4J_DATABASE", defaultDatabase),
 		Timeout:  getDurationEnvOrDefault("NEO4J_TIMEOUT", 30*time.Second),
+		Batch: Neo4jBatchConfig{
+			Size:          getIntEnvOrDefault("NEO4J_BATCH_SIZE", 500),
+			FlushInterval: getDurationEnvOrDefault("NEO4J_BATCH_FLUSH_INTERVAL", 250*time.Millisecond),
+		},
 	}
 }
 
@@ -51,6 +130,296 @@ func loadServerConfig() ServerConfig {
 	}
 }
 
+// loadMaintenanceConfig loads maintenance mode configuration from environment
+func loadMaintenanceConfig() MaintenanceConfig {
+	return MaintenanceConfig{
+		Enabled: getBoolEnvOrDefault("MAINTENANCE_MODE", false),
+		Message: os.Getenv("MAINTENANCE_MESSAGE"),
+	}
+}
+
+// loadScanValidationConfig loads scan validation configuration from environment
+func loadScanValidationConfig() ScanValidationConfig {
+	return ScanValidationConfig{
+		Enabled:                   getBoolEnvOrDefault("SCAN_VALIDATION_ENABLED", true),
+		MaxRepoCountChangePercent: getIntEnvOrDefault("SCAN_VALIDATION_MAX_REPO_CHANGE_PERCENT", 50),
+	}
+}
+
+// loadGraphTypesConfig loads custom graph type configuration from environment
+func loadGraphTypesConfig() GraphTypesConfig {
+	return GraphTypesConfig{
+		DefinitionsFile: os.Getenv("GRAPH_TYPES_FILE"),
+	}
+}
+
+// loadFreshnessConfig loads data freshness SLA configuration from environment
+func loadFreshnessConfig() FreshnessConfig {
+	return FreshnessConfig{
+		SLA: getDurationEnvOrDefault("DATA_FRESHNESS_SLA", 24*time.Hour),
+	}
+}
+
+// loadQuotaConfig loads GitHub API quota configuration from environment
+func loadQuotaConfig() QuotaConfig {
+	return QuotaConfig{
+		MonthlyAPICalls: getIntEnvOrDefault("QUOTA_MONTHLY_API_CALLS", 0),
+		TenantLimits:    parseTenantQuotas(os.Getenv("QUOTA_TENANT_LIMITS")),
+	}
+}
+
+// loadScanWatchdogConfig loads stalled scan watchdog configuration from environment
+func loadScanWatchdogConfig() ScanWatchdogConfig {
+	return ScanWatchdogConfig{
+		StallTimeout:   getDurationEnvOrDefault("SCAN_WATCHDOG_STALL_TIMEOUT", 10*time.Minute),
+		RestartStalled: getBoolEnvOrDefault("SCAN_WATCHDOG_RESTART", false),
+		MaxRestarts:    getIntEnvOrDefault("SCAN_WATCHDOG_MAX_RESTARTS", 1),
+	}
+}
+
+// loadScanJobsConfig loads background scan job configuration from environment
+func loadScanJobsConfig() ScanJobsConfig {
+	return ScanJobsConfig{
+		Workers:      getIntEnvOrDefault("SCAN_JOB_WORKERS", 2),
+		Retention:    getDurationEnvOrDefault("SCAN_JOB_RETENTION", 24*time.Hour),
+		LogDirectory: os.Getenv("SCAN_JOB_LOG_DIRECTORY"),
+		MaxQueued:    getIntEnvOrDefault("SCAN_JOB_MAX_QUEUED", 50),
+		DedupWindow:  getDurationEnvOrDefault("SCAN_DEDUP_WINDOW", 0),
+	}
+}
+
+// loadReportFormatConfig loads report timestamp formatting from environment
+func loadReportFormatConfig() ReportFormatConfig {
+	return ReportFormatConfig{
+		Timezone: getEnvOrDefault("REPORT_TIMEZONE", "UTC"),
+		Locale:   os.Getenv("REPORT_LOCALE"),
+	}
+}
+
+// loadScanBudgetConfig loads per-scan resource limits from environment
+func loadScanBudgetConfig() ScanBudgetConfig {
+	return ScanBudgetConfig{
+		MaxBufferedItems: getIntEnvOrDefault("SCAN_MAX_BUFFERED_ITEMS", 500000),
+	}
+}
+
+// loadGraphLimitsConfig loads graph response size limits from environment
+func loadGraphLimitsConfig() GraphLimitsConfig {
+	return GraphLimitsConfig{
+		MaxNodes: getIntEnvOrDefault("GRAPH_MAX_NODES", 20000),
+		MaxEdges: getIntEnvOrDefault("GRAPH_MAX_EDGES", 100000),
+	}
+}
+
+// loadGraphConversionConfig loads graph record conversion configuration from environment
+func loadGraphConversionConfig() GraphConversionConfig {
+	return GraphConversionConfig{
+		Strict: getBoolEnvOrDefault("GRAPH_STRICT_CONVERSION", false),
+	}
+}
+
+// loadStaleCacheConfig loads stale response cache configuration from environment
+func loadStaleCacheConfig() StaleCacheConfig {
+	return StaleCacheConfig{
+		Enabled: getBoolEnvOrDefault("SERVE_STALE_ON_NEO4J_FAILURE", false),
+	}
+}
+
+// loadWarmupConfig loads startup cache warm-up configuration from environment
+func loadWarmupConfig() WarmupConfig {
+	return WarmupConfig{
+		Organizations: parseWarmupOrganizations(os.Getenv("WARMUP_ORGANIZATIONS")),
+		Timeout:       getDurationEnvOrDefault("WARMUP_TIMEOUT", 60*time.Second),
+	}
+}
+
+// loadReconciliationConfig loads GitHub reconciliation configuration from environment
+func loadReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		Enabled:               getBoolEnvOrDefault("RECONCILE_ENABLED", false),
+		Schedule:              getEnvOrDefault("RECONCILE_SCHEDULE", "0 3 * * *"),
+		DriftThresholdPercent: getIntEnvOrDefault("RECONCILE_DRIFT_THRESHOLD_PERCENT", 10),
+		AutoScan:              getBoolEnvOrDefault("RECONCILE_AUTO_SCAN", true),
+		MaxRepos:              getIntEnvOrDefault("RECONCILE_MAX_REPOS", 100),
+		MaxTeams:              getIntEnvOrDefault("RECONCILE_MAX_TEAMS", 50),
+	}
+}
+
+// loadArchivalConfig loads inactive organization archival configuration from environment
+func loadArchivalConfig() ArchivalConfig {
+	return ArchivalConfig{
+		Enabled:       getBoolEnvOrDefault("ARCHIVE_ENABLED", false),
+		Schedule:      getEnvOrDefault("ARCHIVE_SCHEDULE", "30 4 * * *"),
+		InactiveAfter: getDurationEnvOrDefault("ARCHIVE_INACTIVE_AFTER", 90*24*time.Hour),
+		Directory:     getEnvOrDefault("ARCHIVE_DIRECTORY", "data/archives"),
+	}
+}
+
+// loadSLOConfig loads per-endpoint service level objectives from environment
+func loadSLOConfig() SLOConfig {
+	return SLOConfig{
+		Objectives:        parseSLOObjectives(getEnvOrDefault("SLO_OBJECTIVES", defaultSLOObjectives)),
+		FastBurnThreshold: getFloatEnvOrDefault("SLO_FAST_BURN_THRESHOLD", 14.4),
+		SlowBurnThreshold: getFloatEnvOrDefault("SLO_SLOW_BURN_THRESHOLD", 6),
+	}
+}
+
+// loadTransferConfig loads ownership transfer configuration from environment
+func loadTransferConfig() TransferConfig {
+	return TransferConfig{
+		PullRequestsEnabled: getBoolEnvOrDefault("TRANSFER_PULL_REQUESTS_ENABLED", false),
+		BranchPrefix:        getEnvOrDefault("TRANSFER_BRANCH_PREFIX", "codeowners-transfer"),
+	}
+}
+
+// loadAuditLogConfig loads audit log ingestion configuration from environment
+func loadAuditLogConfig() AuditLogConfig {
+	return AuditLogConfig{
+		Enabled:  getBoolEnvOrDefault("AUDIT_LOG_ENABLED", false),
+		Schedule: getEnvOrDefault("AUDIT_LOG_SCHEDULE", "15 * * * *"),
+		Backfill: getDurationEnvOrDefault("AUDIT_LOG_BACKFILL", 180*24*time.Hour),
+	}
+}
+
+// loadWebhookConfig loads GitHub webhook configuration from environment
+func loadWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Secret:              os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		DeadLetterRetention: getDurationEnvOrDefault("WEBHOOK_DEAD_LETTER_RETENTION", 7*24*time.Hour),
+	}
+}
+
+// loadDependencyAnalysisConfig loads dependency analysis configuration from environment
+func loadDependencyAnalysisConfig() DependencyAnalysisConfig {
+	return DependencyAnalysisConfig{
+		Enabled: getBoolEnvOrDefault("DEPENDENCY_ANALYSIS_ENABLED", false),
+	}
+}
+
+// loadScanScheduleConfig loads the recurring scan schedule from environment
+func loadScanScheduleConfig() ScanScheduleConfig {
+	return ScanScheduleConfig{
+		Cron:          strings.TrimSpace(os.Getenv("SCAN_CRON")),
+		Organizations: parseWarmupOrganizations(os.Getenv("SCAN_ORGS")),
+		MaxRepos:      getIntEnvOrDefault("SCAN_SCHEDULE_MAX_REPOS", 100),
+		MaxTeams:      getIntEnvOrDefault("SCAN_SCHEDULE_MAX_TEAMS", 50),
+	}
+}
+
+// loadStateStoreConfig loads the job and schedule state backend from environment
+func loadStateStoreConfig() StateStoreConfig {
+	return StateStoreConfig{
+		Backend:    strings.ToLower(getEnvOrDefault("STATE_STORE", StateStoreMemory)),
+		KeyPrefix:  getEnvOrDefault("STATE_STORE_PREFIX", "overseer"),
+		RedisHost:  os.Getenv("REDIS_HOST"),
+		SQLDialect: os.Getenv("DB_DIALECT"),
+	}
+}
+
+// loadLeaderElectionConfig loads the leader lease settings from environment
+func loadLeaderElectionConfig() LeaderElectionConfig {
+	return LeaderElectionConfig{
+		LeaseTTL: getDurationEnvOrDefault("LEADER_LEASE_TTL", 2*time.Minute),
+		Identity: strings.TrimSpace(os.Getenv("LEADER_IDENTITY")),
+	}
+}
+
+// loadCircuitBreakerConfig loads the GitHub and Neo4j circuit breaker thresholds from environment
+func loadCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: getIntEnvOrDefault("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+		OpenTimeout:      getDurationEnvOrDefault("CIRCUIT_BREAKER_OPEN_TIMEOUT", 30*time.Second),
+		HalfOpenRequests: getIntEnvOrDefault("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 1),
+	}
+}
+
+// loadCustomPropertiesConfig loads the custom properties export from environment
+func loadCustomPropertiesConfig() CustomPropertiesConfig {
+	return CustomPropertiesConfig{
+		SyncEnabled:   getBoolEnvOrDefault("CUSTOM_PROPERTIES_SYNC_ENABLED", false),
+		OwnerProperty: getEnvOrDefault("CUSTOM_PROPERTIES_OWNER", "owner-team"),
+		TierProperty:  getEnvOrDefault("CUSTOM_PROPERTIES_TIER", "ownership-tier"),
+	}
+}
+
+// loadCodeownersConfig loads the CODEOWNERS search locations from environment
+func loadCodeownersConfig() CodeownersConfig {
+	return CodeownersConfig{
+		ExtraPaths: parseCodeownersPaths(os.Getenv("CODEOWNERS_PATHS")),
+		Nested:     getBoolEnvOrDefault("CODEOWNERS_NESTED", false),
+	}
+}
+
+// loadBotsConfig loads bot owner handling from environment
+func loadBotsConfig() BotsConfig {
+	return BotsConfig{
+		Exclude: getBoolEnvOrDefault("EXCLUDE_BOT_OWNERS", false),
+	}
+}
+
+// loadAuthConfig loads API authentication from environment
+func loadAuthConfig() AuthConfig {
+	return AuthConfig{
+		APIKeys:         parseCommaList(os.Getenv("AUTH_API_KEYS")),
+		OperatorAPIKeys: parseCommaList(os.Getenv("AUTH_OPERATOR_API_KEYS")),
+		ExemptPaths:     parseCommaList(getEnvOrDefault("AUTH_EXEMPT_PATHS", "/api/health,/.well-known/health,/.well-known/alive")),
+		OIDC: OIDCConfig{
+			Issuer:      os.Getenv("OIDC_ISSUER"),
+			Audience:    os.Getenv("OIDC_AUDIENCE"),
+			JWKSURL:     os.Getenv("OIDC_JWKS_URL"),
+			RolesClaim:  getEnvOrDefault("OIDC_ROLES_CLAIM", "roles"),
+			DefaultRole: parseOIDCDefaultRole(getEnvOrDefault("OIDC_DEFAULT_ROLE", roleReader)),
+		},
+	}
+}
+
+// parseOIDCDefaultRole reads the role of tokens without one, where none grants no role (Pure Core)
+func parseOIDCDefaultRole(value string) string {
+	if strings.EqualFold(value, "none") {
+		return ""
+	}
+	return value
+}
+
+// loadRateLimitConfig loads per-client API rate limiting from environment
+func loadRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		RequestsPerSecond: getFloatEnvOrDefault("RATE_LIMIT_RPS", 0),
+		Burst:             getIntEnvOrDefault("RATE_LIMIT_BURST", 20),
+		ExemptPaths:       parseCommaList(getEnvOrDefault("RATE_LIMIT_EXEMPT_PATHS", "/api/health,/.well-known/health,/.well-known/alive")),
+		TrustForwardedFor: getBoolEnvOrDefault("RATE_LIMIT_TRUST_FORWARDED_FOR", false),
+	}
+}
+
+// loadPublishConfig loads the graph snapshot publish target from environment
+func loadPublishConfig() PublishConfig {
+	return PublishConfig{
+		Target:    strings.ToLower(getEnvOrDefault("PUBLISH_TARGET", publishTargetFilesystem)),
+		Directory: getEnvOrDefault("PUBLISH_DIRECTORY", "data/published"),
+		BlobURL:   os.Getenv("PUBLISH_BLOB_URL"),
+		Token:     os.Getenv("PUBLISH_BLOB_TOKEN"),
+	}
+}
+
+// loadTeamVisibilityConfig loads secret team handling from environment
+func loadTeamVisibilityConfig() TeamVisibilityConfig {
+	return TeamVisibilityConfig{
+		SecretTeams:  strings.ToLower(getEnvOrDefault("SECRET_TEAMS", secretTeamsMask)),
+		AdminAPIKeys: parseCommaList(os.Getenv("ADMIN_API_KEYS")),
+	}
+}
+
+// parseCommaList splits a comma-separated environment value, dropping empty entries (Pure Core)
+func parseCommaList(value string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 // getEnvOrDefault gets environment variable or returns default
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -69,6 +438,26 @@ func getIntEnvOrDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getBoolEnvOrDefault gets boolean environment variable or returns default
+func getBoolEnvOrDefault(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// getFloatEnvOrDefault gets float environment variable or returns default
+func getFloatEnvOrDefault(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getDurationEnvOrDefault gets duration environment variable or returns default
 func getDurationEnvOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
@@ -86,4 +475,4 @@ func validateConfiguration(config AppConfig) error {
 		return fmt.Errorf("configuration validation failed: %d errors found", len(validationErrors))
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Exit codes of the configuration probe
+const (
+	configExitValid       = 0
+	configExitInvalid     = 1
+	configExitUnreachable = 2
+	configExitUsage       = 3
+)
+
+// Statuses of a configuration probe check
+const (
+	probeCheckPassed  = "passed"
+	probeCheckFailed  = "failed"
+	probeCheckSkipped = "skipped"
+)
+
+// ConfigProbeCheck is the result of a single connectivity check
+type ConfigProbeCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ConfigProbeError is a configuration validation failure
+type ConfigProbeError struct {
+	Field   string      `json:"field"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// ConfigProbeReport is the machine-readable result of --validate-config, written to stdout
+type ConfigProbeReport struct {
+	Valid    bool               `json:"valid"`
+	Offline  bool               `json:"offline"`
+	ExitCode int                `json:"exit_code"`
+	Errors   []ConfigProbeError `json:"errors"`
+	Checks   []ConfigProbeCheck `json:"checks"`
+}
+
+// isSecretConfigField reports whether a configuration field holds a credential (Pure Core)
+func isSecretConfigField(field string) bool {
+	return strings.HasSuffix(field, ".Token") || strings.HasSuffix(field, ".Password") || strings.HasSuffix(field, ".AppPrivateKey")
+}
+
+// convertValidationErrors converts validation errors into report entries without credential values (Pure Core)
+func convertValidationErrors(validationErrors []ValidationError) []ConfigProbeError {
+	probeErrors := make([]ConfigProbeError, 0, len(validationErrors))
+	for _, validationErr := range validationErrors {
+		value := validationErr.Value
+		if isSecretConfigField(validationErr.Field) {
+			value = nil
+		}
+		probeErrors = append(probeErrors, ConfigProbeError{
+			Field:   validationErr.Field,
+			Message: validationErr.Message,
+			Value:   value,
+		})
+	}
+	return probeErrors
+}
+
+// determineConfigExitCode maps a probe report to the process exit code (Pure Core)
+func determineConfigExitCode(report ConfigProbeReport) int {
+	if len(report.Errors) > 0 {
+		return configExitInvalid
+	}
+	for _, check := range report.Checks {
+		if check.Status == probeCheckFailed {
+			return configExitUnreachable
+		}
+	}
+	return configExitValid
+}
+
+// runProbeCheck times a connectivity check and records its outcome
+func runProbeCheck(name string, check func() error) ConfigProbeCheck {
+	startTime := time.Now()
+	err := check()
+	result := ConfigProbeCheck{Name: name, Status: probeCheckPassed, DurationMs: time.Since(startTime).Milliseconds()}
+	if err != nil {
+		result.Status = probeCheckFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// probeNeo4j connects to Neo4j and runs the health check without touching the schema
+func probeNeo4j(ctx context.Context, config Neo4jConfig) error {
+	conn, err := createNeo4jConnection(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer closeNeo4jConnection(ctx, conn)
+
+	return performNeo4jHealthCheck(ctx, conn)
+}
+
+// probeGitHub verifies the GitHub API is reachable and accepts the configured token; with GitHub App
+// credentials it first exchanges an installation token
+func probeGitHub(ctx context.Context, config GitHubConfig) error {
+	token := config.Token
+	if isGitHubAppConfigured(config) {
+		source, err := newGitHubAppTokenSource(GitHubServiceConfig{
+			BaseURL:           config.BaseURL,
+			UserAgent:         config.UserAgent,
+			Timeout:           config.Timeout,
+			AppID:             config.AppID,
+			AppInstallationID: config.AppInstallationID,
+			AppPrivateKey:     config.AppPrivateKey,
+			AppPrivateKeyPath: config.AppPrivateKeyPath,
+		})
+		if err != nil {
+			return err
+		}
+		if token, err = source.Token(ctx); err != nil {
+			return err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(config.BaseURL, "/")+"/rate_limit", nil)
+	if err != nil {
+		return fmt.Errorf("invalid GitHub base URL: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("User-Agent", config.UserAgent)
+
+	client := &http.Client{Timeout: config.Timeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API returned status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// runConfigProbe loads and validates the configuration and, unless offline, checks connectivity
+func runConfigProbe(ctx context.Context, offline bool) ConfigProbeReport {
+	config := loadConfigFromEnv()
+	report := ConfigProbeReport{
+		Offline: offline,
+		Errors:  convertValidationErrors(validateAppConfig(config)),
+	}
+
+	if _, err := loadGraphTypeRegistry(config.GraphTypes); err != nil {
+		report.Errors = append(report.Errors, ConfigProbeError{
+			Field:   "GraphTypes.DefinitionsFile",
+			Message: err.Error(),
+			Value:   config.GraphTypes.DefinitionsFile,
+		})
+	}
+
+	checks := []string{"neo4j", "github"}
+	switch {
+	case offline || len(report.Errors) > 0:
+		for _, name := range checks {
+			report.Checks = append(report.Checks, ConfigProbeCheck{Name: name, Status: probeCheckSkipped})
+		}
+	default:
+		report.Checks = append(report.Checks,
+			runProbeCheck("neo4j", func() error { return probeNeo4j(ctx, config.Neo4j) }),
+			runProbeCheck("github", func() error { return probeGitHub(ctx, config.GitHub) }),
+		)
+	}
+
+	report.ExitCode = determineConfigExitCode(report)
+	report.Valid = report.ExitCode == configExitValid
+	return report
+}
+
+// runValidateConfigCommand validates configuration for init containers, printing a JSON
+// report and returning a non-zero exit code when the configuration is unusable
+func runValidateConfigCommand(args []string) int {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	offline := flags.Bool("offline", false, "skip Neo4j and GitHub connectivity checks")
+	if err := flags.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nUsage: --validate-config [--offline]\n", err)
+		return configExitUsage
+	}
+
+	report := runConfigProbe(context.Background(), *offline)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write configuration report: %v\n", err)
+	}
+
+	return report.ExitCode
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// configReloadPath is the admin route that reloads configuration; it stays writable during maintenance
+const configReloadPath = "/api/admin/config/reload"
+
+// defaultConfigEnvFile is the env file GoFr loads on startup and that is re-read on reload
+const defaultConfigEnvFile = "configs/.env"
+
+// LiveConfig holds the configuration in effect, which reloads replace without a restart
+type LiveConfig struct {
+	mu     sync.RWMutex
+	config AppConfig
+}
+
+// ConfigReloadResult reports which settings a reload applied and which need a restart
+type ConfigReloadResult struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
+	LogLevel        string   `json:"log_level"`
+	ReloadedAt      string   `json:"reloaded_at"`
+}
+
+// ConfigReloadError is returned when the reloaded configuration is invalid; the running configuration is kept
+type ConfigReloadError struct {
+	Errors []ValidationError
+}
+
+// Error implements the error interface for ConfigReloadError
+func (e ConfigReloadError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, validationErr := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s %s", validationErr.Field, validationErr.Message))
+	}
+	return "reloaded configuration is invalid: " + strings.Join(messages, "; ")
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ConfigReloadError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// newLiveConfig creates a live configuration holder
+func newLiveConfig(config AppConfig) *LiveConfig {
+	return &LiveConfig{config: config}
+}
+
+// current returns the configuration in effect
+func (c *LiveConfig) current() AppConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config
+}
+
+// replace swaps in a reloaded configuration
+func (c *LiveConfig) replace(config AppConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = config
+}
+
+// currentConfig returns the configuration in effect, including reloaded settings
+func (d *AppDependencies) currentConfig() AppConfig {
+	if d.LiveConfig == nil {
+		return d.Config
+	}
+	return d.LiveConfig.current()
+}
+
+// parseEnvFile parses KEY=VALUE lines, skipping blanks and comments and unquoting values (Pure Core)
+func parseEnvFile(content string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "export "))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values
+}
+
+// applyEnvFile re-reads the env file into the process environment; values in the file take precedence
+func applyEnvFile(path string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for key, value := range parseEnvFile(string(content)) {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// mergeReloadableConfig applies the reloadable settings of loaded onto current and lists the
+// changed settings that only take effect after a restart. Maintenance mode is runtime state
+// managed through its admin endpoint and is left untouched. (Pure Core)
+func mergeReloadableConfig(current, loaded AppConfig) (AppConfig, []string, []string) {
+	merged := current
+	applied := []string{}
+
+	loadedReconciliation := loaded.Reconciliation
+	loadedReconciliation.Enabled, loadedReconciliation.Schedule = current.Reconciliation.Enabled, current.Reconciliation.Schedule
+	loadedArchival := loaded.Archival
+	loadedArchival.Enabled, loadedArchival.Schedule = current.Archival.Enabled, current.Archival.Schedule
+	loadedAuditLog := loaded.AuditLog
+	loadedAuditLog.Enabled, loadedAuditLog.Schedule = current.AuditLog.Enabled, current.AuditLog.Schedule
+	restartRequired := []string{}
+
+	reloadable := []struct {
+		name  string
+		same  bool
+		apply func()
+	}{
+		{"GitHub.UseTopics", current.GitHub.UseTopics == loaded.GitHub.UseTopics, func() { merged.GitHub.UseTopics = loaded.GitHub.UseTopics }},
+		{"GitHub.RateLimitMin", current.GitHub.RateLimitMin == loaded.GitHub.RateLimitMin, func() { merged.GitHub.RateLimitMin = loaded.GitHub.RateLimitMin }},
+		{"GitHub.ReservePercent", current.GitHub.ReservePercent == loaded.GitHub.ReservePercent, func() { merged.GitHub.ReservePercent = loaded.GitHub.ReservePercent }},
+		{"GitHub.ReserveMaxWait", current.GitHub.ReserveMaxWait == loaded.GitHub.ReserveMaxWait, func() { merged.GitHub.ReserveMaxWait = loaded.GitHub.ReserveMaxWait }},
+		{"GitHub.DegradePercent", current.GitHub.DegradePercent == loaded.GitHub.DegradePercent, func() { merged.GitHub.DegradePercent = loaded.GitHub.DegradePercent }},
+		{"GitHub.MaxRetries", current.GitHub.MaxRetries == loaded.GitHub.MaxRetries, func() { merged.GitHub.MaxRetries = loaded.GitHub.MaxRetries }},
+		{"GitHub.ETagCacheBytes", current.GitHub.ETagCacheBytes == loaded.GitHub.ETagCacheBytes, func() { merged.GitHub.ETagCacheBytes = loaded.GitHub.ETagCacheBytes }},
+		{"Neo4j.Batch", current.Neo4j.Batch == loaded.Neo4j.Batch, func() { merged.Neo4j.Batch = loaded.Neo4j.Batch }},
+		{"ScanValidation", current.ScanValidation == loaded.ScanValidation, func() { merged.ScanValidation = loaded.ScanValidation }},
+		{"Freshness", current.Freshness == loaded.Freshness, func() { merged.Freshness = loaded.Freshness }},
+		{"Quota", reflect.DeepEqual(current.Quota, loaded.Quota), func() { merged.Quota = loaded.Quota }},
+		{"ScanWatchdog", current.ScanWatchdog == loaded.ScanWatchdog, func() { merged.ScanWatchdog = loaded.ScanWatchdog }},
+		{"CircuitBreaker", current.CircuitBreaker == loaded.CircuitBreaker, func() { merged.CircuitBreaker = loaded.CircuitBreaker }},
+		{"ScanJobs.Retention", current.ScanJobs.Retention == loaded.ScanJobs.Retention, func() { merged.ScanJobs.Retention = loaded.ScanJobs.Retention }},
+		{"ScanJobs.LogDirectory", current.ScanJobs.LogDirectory == loaded.ScanJobs.LogDirectory, func() { merged.ScanJobs.LogDirectory = loaded.ScanJobs.LogDirectory }},
+		{"ScanJobs.MaxQueued", current.ScanJobs.MaxQueued == loaded.ScanJobs.MaxQueued, func() { merged.ScanJobs.MaxQueued = loaded.ScanJobs.MaxQueued }},
+		{"ScanJobs.DedupWindow", current.ScanJobs.DedupWindow == loaded.ScanJobs.DedupWindow, func() { merged.ScanJobs.DedupWindow = loaded.ScanJobs.DedupWindow }},
+		{"ScanBudget", current.ScanBudget == loaded.ScanBudget, func() { merged.ScanBudget = loaded.ScanBudget }},
+		{"ReportFormat", current.ReportFormat == loaded.ReportFormat, func() { merged.ReportFormat = loaded.ReportFormat }},
+		{"GraphLimits", current.GraphLimits == loaded.GraphLimits, func() { merged.GraphLimits = loaded.GraphLimits }},
+		{"GraphConversion", current.GraphConversion == loaded.GraphConversion, func() { merged.GraphConversion = loaded.GraphConversion }},
+		{"StaleCache", current.StaleCache == loaded.StaleCache, func() { merged.StaleCache = loaded.StaleCache }},
+		{"Reconciliation", current.Reconciliation == loadedReconciliation, func() { merged.Reconciliation = loadedReconciliation }},
+		{"Archival", current.Archival == loadedArchival, func() { merged.Archival = loadedArchival }},
+		{"SLO", reflect.DeepEqual(current.SLO, loaded.SLO), func() { merged.SLO = loaded.SLO }},
+		{"Transfer", current.Transfer == loaded.Transfer, func() { merged.Transfer = loaded.Transfer }},
+		{"AuditLog", current.AuditLog == loadedAuditLog, func() { merged.AuditLog = loadedAuditLog }},
+		{"Webhook", current.Webhook == loaded.Webhook, func() { merged.Webhook = loaded.Webhook }},
+		{"Dependencies", current.Dependencies == loaded.Dependencies, func() { merged.Dependencies = loaded.Dependencies }},
+		{"CustomProperties", current.CustomProperties == loaded.CustomProperties, func() { merged.CustomProperties = loaded.CustomProperties }},
+		{"Codeowners", reflect.DeepEqual(current.Codeowners, loaded.Codeowners), func() { merged.Codeowners = loaded.Codeowners }},
+		{"Bots", current.Bots == loaded.Bots, func() { merged.Bots = loaded.Bots }},
+		{"TeamVisibility", reflect.DeepEqual(current.TeamVisibility, loaded.TeamVisibility), func() { merged.TeamVisibility = loaded.TeamVisibility }},
+		{"Auth.APIKeys", reflect.DeepEqual(current.Auth.APIKeys, loaded.Auth.APIKeys), func() { merged.Auth.APIKeys = loaded.Auth.APIKeys }},
+		{"Publish", current.Publish == loaded.Publish, func() { merged.Publish = loaded.Publish }},
+		{"RateLimit", reflect.DeepEqual(current.RateLimit, loaded.RateLimit), func() { merged.RateLimit = loaded.RateLimit }},
+		{"Auth.OperatorAPIKeys", reflect.DeepEqual(current.Auth.OperatorAPIKeys, loaded.Auth.OperatorAPIKeys), func() { merged.Auth.OperatorAPIKeys = loaded.Auth.OperatorAPIKeys }},
+		{"Auth.ExemptPaths", reflect.DeepEqual(current.Auth.ExemptPaths, loaded.Auth.ExemptPaths), func() { merged.Auth.ExemptPaths = loaded.Auth.ExemptPaths }},
+	}
+	for _, setting := range reloadable {
+		if !setting.same {
+			setting.apply()
+			applied = append(applied, setting.name)
+		}
+	}
+
+	currentGitHub, loadedGitHub := current.GitHub, loaded.GitHub
+	currentGitHub.UseTopics, currentGitHub.RateLimitMin = loadedGitHub.UseTopics, loadedGitHub.RateLimitMin
+	currentGitHub.ReservePercent, currentGitHub.ReserveMaxWait = loadedGitHub.ReservePercent, loadedGitHub.ReserveMaxWait
+	currentGitHub.DegradePercent, currentGitHub.MaxRetries = loadedGitHub.DegradePercent, loadedGitHub.MaxRetries
+	currentGitHub.ETagCacheBytes = loadedGitHub.ETagCacheBytes
+	currentNeo4j, loadedNeo4j := current.Neo4j, loaded.Neo4j
+	currentNeo4j.Batch = loadedNeo4j.Batch
+
+	structural := []struct {
+		name string
+		same bool
+	}{
+		{"Environment", current.Environment == loaded.Environment},
+		{"Port", current.Port == loaded.Port},
+		{"GitHub", currentGitHub == loadedGitHub},
+		{"Neo4j", currentNeo4j == loadedNeo4j},
+		{"Server", current.Server == loaded.Server},
+		{"GraphTypes", current.GraphTypes == loaded.GraphTypes},
+		{"Warmup", reflect.DeepEqual(current.Warmup, loaded.Warmup)},
+		{"ScanSchedule", reflect.DeepEqual(current.ScanSchedule, loaded.ScanSchedule)},
+		{"ScanJobs.Workers", current.ScanJobs.Workers == loaded.ScanJobs.Workers},
+		{"StateStore", current.StateStore == loaded.StateStore},
+		{"LeaderElection", current.LeaderElection == loaded.LeaderElection},
+		{"Reconciliation.Schedule", current.Reconciliation.Enabled == loaded.Reconciliation.Enabled && current.Reconciliation.Schedule == loaded.Reconciliation.Schedule},
+		{"Archival.Schedule", current.Archival.Enabled == loaded.Archival.Enabled && current.Archival.Schedule == loaded.Archival.Schedule},
+		{"Auth.OIDC", current.Auth.OIDC == loaded.Auth.OIDC},
+		{"AuditLog.Schedule", current.AuditLog.Enabled == loaded.AuditLog.Enabled && current.AuditLog.Schedule == loaded.AuditLog.Schedule},
+	}
+	for _, setting := range structural {
+		if !setting.same {
+			restartRequired = append(restartRequired, setting.name)
+		}
+	}
+
+	return merged, applied, restartRequired
+}
+
+// reloadConfiguration re-reads the environment and applies reloadable settings without
+// touching in-flight requests or the Neo4j connection pool
+func reloadConfiguration(deps *AppDependencies) (ConfigReloadResult, error) {
+	if err := applyEnvFile(getEnvOrDefault("CONFIG_ENV_FILE", defaultConfigEnvFile)); err != nil {
+		return ConfigReloadResult{}, err
+	}
+
+	loaded := loadConfigFromEnv()
+	if validationErrors := validateAppConfig(loaded); len(validationErrors) > 0 {
+		return ConfigReloadResult{}, ConfigReloadError{Errors: validationErrors}
+	}
+
+	merged, applied, restartRequired := mergeReloadableConfig(deps.currentConfig(), loaded)
+	deps.LiveConfig.replace(merged)
+	configureGitHubRateBudget(merged.GitHub.ReservePercent, merged.GitHub.ReserveMaxWait, merged.GitHub.RateLimitMin)
+	configureGitHubRetries(merged.GitHub.MaxRetries)
+	configureGitHubETagCache(merged.GitHub.ETagCacheBytes)
+	configureCircuitBreakers(merged.CircuitBreaker)
+	configureCodeownersSearch(merged.Codeowners)
+	configureGraphQLDegradation(merged.GitHub.DegradePercent)
+
+	level := getEnvOrDefault("LOG_LEVEL", "INFO")
+	if isValidLogLevel(level) && normalizeLogLevel(level) != componentLogLevels.status().DefaultLevel {
+		componentLogLevels.set("", level)
+		applied = append(applied, "LogLevel")
+	}
+
+	return ConfigReloadResult{
+		Applied:         applied,
+		RestartRequired: restartRequired,
+		LogLevel:        componentLogLevels.status().DefaultLevel,
+		ReloadedAt:      time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// handleReloadConfig reloads configuration on demand
+func (h *AppHandler) handleReloadConfig(ctx *gofr.Context) (interface{}, error) {
+	result, err := reloadConfiguration(h.deps)
+	if err != nil {
+		logError(ctx, "Configuration reload failed", LogFields{
+			"component": "admin",
+			"operation": "reload_config",
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	logWarn(ctx, "Configuration reloaded", LogFields{
+		"component":        "admin",
+		"operation":        "reload_config",
+		"applied":          strings.Join(result.Applied, ","),
+		"restart_required": strings.Join(result.RestartRequired, ","),
+	})
+
+	return result, nil
+}
+
+// registerConfigReloadSignal reloads configuration whenever the process receives SIGHUP
+func registerConfigReloadSignal(app *gofr.App, deps *AppDependencies) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			result, err := reloadConfiguration(deps)
+			if err != nil {
+				app.Logger().Errorf("Configuration reload failed: %v - component=main operation=reload_config trigger=sighup", err)
+				continue
+			}
+			app.Logger().Infof("Configuration reloaded - component=main operation=reload_config trigger=sighup applied=%v restart_required=%v log_level=%s",
+				result.Applied, result.RestartRequired, result.LogLevel)
+		}
+	}()
+}
@@ -2,36 +2,106 @@ package main
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 )
 
 // AppConfig represents the complete application configuration
 type AppConfig struct {
-	Environment string
-	Port        int
-	GitHub      GitHubConfig
-	Neo4j       Neo4jConfig
-	Server      ServerConfig
+	Environment      string
+	Port             int
+	SCMProvider      string
+	GitHub           GitHubConfig
+	GitLab           GitLabConfig
+	Bitbucket        BitbucketConfig
+	Neo4j            Neo4jConfig
+	Server           ServerConfig
+	Maintenance      MaintenanceConfig
+	ScanValidation   ScanValidationConfig
+	GraphTypes       GraphTypesConfig
+	Freshness        FreshnessConfig
+	Quota            QuotaConfig
+	ScanWatchdog     ScanWatchdogConfig
+	ScanJobs         ScanJobsConfig
+	ScanBudget       ScanBudgetConfig
+	ReportFormat     ReportFormatConfig
+	GraphLimits      GraphLimitsConfig
+	GraphConversion  GraphConversionConfig
+	StaleCache       StaleCacheConfig
+	Warmup           WarmupConfig
+	Reconciliation   ReconciliationConfig
+	Archival         ArchivalConfig
+	SLO              SLOConfig
+	Transfer         TransferConfig
+	AuditLog         AuditLogConfig
+	Webhook          WebhookConfig
+	Dependencies     DependencyAnalysisConfig
+	ScanSchedule     ScanScheduleConfig
+	StateStore       StateStoreConfig
+	LeaderElection   LeaderElectionConfig
+	CircuitBreaker   CircuitBreakerConfig
+	CustomProperties CustomPropertiesConfig
+	Codeowners       CodeownersConfig
+	Bots             BotsConfig
+	TeamVisibility   TeamVisibilityConfig
+	Auth             AuthConfig
+	Publish          PublishConfig
+	RateLimit        RateLimitConfig
 }
 
 // GitHubConfig represents GitHub API configuration
 type GitHubConfig struct {
-	Token        string
-	BaseURL      string
-	UserAgent    string
-	Timeout      time.Duration
-	MaxRetries   int
-	RateLimitMin int
-	UseTopics    bool
+	Token             string
+	BaseURL           string
+	UserAgent         string
+	Timeout           time.Duration
+	MaxRetries        int
+	ETagCacheBytes    int
+	RateLimitMin      int
+	ReservePercent    int
+	ReserveMaxWait    time.Duration
+	DegradePercent    int
+	Transport         GitHubTransportConfig
+	UseTopics         bool
+	UseGraphQL        bool
+	AppID             int64
+	AppInstallationID int64
+	AppPrivateKey     string
+	AppPrivateKeyPath string
+}
+
+// GitLabConfig represents the GitLab API scans read groups from when SCMProvider is gitlab
+type GitLabConfig struct {
+	// BaseURL is the REST API root, such as https://gitlab.com/api/v4 or a self-managed instance's
+	BaseURL string
+	Token   string
+}
+
+// BitbucketConfig represents the Bitbucket Cloud API scans read workspaces from, authenticated with an
+// access token or, without one, a username and app password
+type BitbucketConfig struct {
+	BaseURL     string
+	Token       string
+	Username    string
+	AppPassword string
 }
 
 // Neo4jConfig represents Neo4j database configuration
 type Neo4jConfig struct {
+	Provider string
 	URI      string
 	Username string
 	Password string
 	Database string
 	Timeout  time.Duration
+	Batch    Neo4jBatchConfig
+}
+
+// Neo4jBatchConfig represents the flush thresholds of buffered Neo4j writes
+type Neo4jBatchConfig struct {
+	Size          int
+	FlushInterval time.Duration
 }
 
 // ServerConfig represents HTTP server configuration
@@ -42,6 +112,257 @@ type ServerConfig struct {
 	MaxHeaderBytes int
 }
 
+// MaintenanceConfig represents read-only maintenance mode configuration
+type MaintenanceConfig struct {
+	Enabled bool
+	Message string
+}
+
+// ScanValidationConfig represents the sanity checks applied before a scan is published
+type ScanValidationConfig struct {
+	Enabled                   bool
+	MaxRepoCountChangePercent int
+}
+
+// GraphTypesConfig represents custom node and relationship type configuration
+type GraphTypesConfig struct {
+	DefinitionsFile string
+}
+
+// FreshnessConfig represents the data freshness SLA configuration
+type FreshnessConfig struct {
+	SLA time.Duration
+}
+
+// QuotaConfig represents monthly GitHub API quotas per tenant; a limit of 0 means unlimited
+type QuotaConfig struct {
+	MonthlyAPICalls int
+	TenantLimits    map[string]int
+}
+
+// ScanWatchdogConfig represents the detection and recovery of stalled scans
+type ScanWatchdogConfig struct {
+	StallTimeout   time.Duration
+	RestartStalled bool
+	MaxRestarts    int
+}
+
+// ScanJobsConfig represents background scan jobs accepted by POST /api/scan/{org}
+type ScanJobsConfig struct {
+	Workers   int
+	Retention time.Duration
+	// LogDirectory receives one NDJSON file of log events per job; empty disables the artifacts
+	LogDirectory string
+	// MaxQueued caps the jobs queued or running at once; zero means unlimited
+	MaxQueued int
+	// DedupWindow answers scan requests for an organization scanned successfully this recently with
+	// that scan instead of a new one; zero disables deduplication
+	DedupWindow time.Duration
+}
+
+// ReportFormatConfig represents the default timezone and locale of timestamps in CSV and XLSX reports
+type ReportFormatConfig struct {
+	// Timezone is an IANA timezone name such as Europe/Berlin
+	Timezone string
+	// Locale selects the timestamp layout; empty keeps RFC 3339
+	Locale string
+}
+
+// ScanBudgetConfig represents the fetched items a single scan may hold in memory; zero means unlimited
+type ScanBudgetConfig struct {
+	MaxBufferedItems int
+}
+
+// GraphLimitsConfig represents the largest graph GET /api/graph/{org} returns in full; zero disables a limit
+type GraphLimitsConfig struct {
+	MaxNodes int
+	MaxEdges int
+}
+
+// GraphConversionConfig represents how graph records failing to convert are handled: they are always
+// logged and counted, and in strict mode fail the request instead of being left out of the response
+type GraphConversionConfig struct {
+	Strict bool
+}
+
+// StaleCacheConfig represents serving cached graph and stats responses while Neo4j is unavailable
+type StaleCacheConfig struct {
+	Enabled bool
+}
+
+// WarmupConfig represents the organizations whose graph and stats are preloaded on startup
+type WarmupConfig struct {
+	Organizations []string
+	Timeout       time.Duration
+}
+
+// ReconciliationConfig represents the scheduled comparison of graph counts with GitHub
+type ReconciliationConfig struct {
+	Enabled               bool
+	Schedule              string
+	DriftThresholdPercent int
+	AutoScan              bool
+	MaxRepos              int
+	MaxTeams              int
+}
+
+// ArchivalConfig represents the scheduled archival of organizations that are neither queried nor scanned
+type ArchivalConfig struct {
+	Enabled       bool
+	Schedule      string
+	InactiveAfter time.Duration
+	Directory     string
+}
+
+// SLOConfig represents per-endpoint service level objectives and the burn rates that alert
+type SLOConfig struct {
+	Objectives        []EndpointObjective
+	FastBurnThreshold float64
+	SlowBurnThreshold float64
+}
+
+// TransferConfig represents ownership transfer between teams and the pull requests it may open
+type TransferConfig struct {
+	PullRequestsEnabled bool
+	BranchPrefix        string
+}
+
+// AuditLogConfig represents scheduled ingestion of GitHub Enterprise audit log ownership events
+type AuditLogConfig struct {
+	Enabled  bool
+	Schedule string
+	Backfill time.Duration
+}
+
+// WebhookConfig represents the GitHub webhook listener that keeps the active scan current
+type WebhookConfig struct {
+	Secret              string
+	DeadLetterRetention time.Duration
+}
+
+// DependencyAnalysisConfig represents the optional scan stage linking repositories through their manifests
+type DependencyAnalysisConfig struct {
+	Enabled bool
+}
+
+// CodeownersConfig represents where scans look for CODEOWNERS files beyond the locations GitHub reads
+type CodeownersConfig struct {
+	// ExtraPaths are checked, in order, after CODEOWNERS, .github/CODEOWNERS and docs/CODEOWNERS
+	ExtraPaths []string
+	// Nested also reads the CODEOWNERS file of each top-level directory, for monorepos
+	Nested bool
+}
+
+// BotsConfig represents how bot accounts named as code owners count in coverage and insights
+type BotsConfig struct {
+	// Exclude leaves bot users out of user counts, coverage and insights; they stay in the graph, tagged as bots
+	Exclude bool
+}
+
+// TeamVisibilityConfig represents how secret teams appear in graph, listing and stats responses
+type TeamVisibilityConfig struct {
+	// SecretTeams is show, mask or omit; it applies to callers without an admin API key
+	SecretTeams string
+	// AdminAPIKeys are the X-API-Key values whose callers always see secret teams
+	AdminAPIKeys []string
+}
+
+// AuthConfig represents how API callers authenticate; requests are unauthenticated until API keys or
+// an OIDC issuer are configured
+type AuthConfig struct {
+	// APIKeys are accepted in X-API-Key or as bearer tokens and grant the reader role
+	APIKeys []string
+	// OperatorAPIKeys grant the operator role, as do the team visibility admin keys
+	OperatorAPIKeys []string
+	// ExemptPaths are served without authentication; an entry ending in /* covers the paths below it
+	ExemptPaths []string
+	OIDC        OIDCConfig
+}
+
+// OIDCConfig represents the OpenID Connect provider whose bearer tokens authenticate callers
+type OIDCConfig struct {
+	Issuer   string
+	Audience string
+	// JWKSURL overrides the key set location of the issuer's OpenID configuration
+	JWKSURL string
+	// RolesClaim is the claim, dotted for nested claims, listing a token's reader or operator role
+	RolesClaim string
+	// DefaultRole is granted to tokens whose roles claim lists neither role; empty grants none
+	DefaultRole string
+}
+
+// RateLimitConfig represents the token bucket each API client draws from; a rate of 0 disables limiting
+type RateLimitConfig struct {
+	// RequestsPerSecond is the rate a client's bucket refills at
+	RequestsPerSecond float64
+	// Burst is the size of a client's bucket, the requests it may send at once
+	Burst int
+	// ExemptPaths are never limited; an entry ending in /* covers the paths below it
+	ExemptPaths []string
+	// TrustForwardedFor keys anonymous clients by the first X-Forwarded-For address instead of the
+	// connection's, for deployments behind a proxy that sets it
+	TrustForwardedFor bool
+}
+
+// PublishConfig represents where POST /api/publish/{org} writes graph snapshots
+type PublishConfig struct {
+	// Target is filesystem or blob
+	Target    string
+	Directory string
+	// BlobURL is the container or bucket URL artifacts are PUT below, with any SAS or signing query
+	BlobURL string
+	// Token is sent as a bearer token with blob uploads, for stores that authenticate with one
+	Token string
+}
+
+// ScanScheduleConfig represents the recurring scan of a list of organizations; an empty Cron disables it
+type ScanScheduleConfig struct {
+	Cron          string
+	Organizations []string
+	MaxRepos      int
+	MaxTeams      int
+}
+
+// StateStoreConfig represents where the state of background scan jobs and the scan schedule is kept
+type StateStoreConfig struct {
+	// Backend is memory, neo4j, redis or postgres
+	Backend string
+	// KeyPrefix namespaces the keys of deployments sharing a backend
+	KeyPrefix string
+	// RedisHost and SQLDialect are GoFr's REDIS_HOST and DB_DIALECT, read to check that the redis
+	// and postgres backends have a datasource to connect through
+	RedisHost  string
+	SQLDialect string
+}
+
+// LeaderElectionConfig represents the lease that elects the replica running the scheduler, the
+// orphaned scan sweep and the other cluster-wide cron jobs
+type LeaderElectionConfig struct {
+	// LeaseTTL is how long the lease outlives its last renewal, and so how long failover takes
+	LeaseTTL time.Duration
+	// Identity names this replica in the lease; empty uses the hostname with a random suffix
+	Identity string
+}
+
+// CircuitBreakerConfig represents the circuit breakers failing GitHub requests and Neo4j queries fast
+// while the dependency is down
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive failures open a circuit; 0 disables the breakers
+	FailureThreshold int
+	// OpenTimeout is how long an open circuit fails calls before letting probes through
+	OpenTimeout time.Duration
+	// HalfOpenRequests is how many probe calls a half-open circuit lets through at once
+	HalfOpenRequests int
+}
+
+// CustomPropertiesConfig represents the export of owner teams and ownership tiers into GitHub
+// repository custom properties
+type CustomPropertiesConfig struct {
+	SyncEnabled   bool
+	OwnerProperty string
+	TierProperty  string
+}
+
 // ValidationError represents configuration validation errors
 type ValidationError struct {
 	Field   string
@@ -54,11 +375,6 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("validation failed for field '%s': %s (value: %v)", e.Field, e.Message, e.Value)
 }
 
-
-
-
-
-
 // validateAppConfig validates the complete application configuration (Pure Core)
 func validateAppConfig(config AppConfig) []ValidationError {
 	var errors []ValidationError
@@ -81,9 +397,22 @@ func validateAppConfig(config AppConfig) []ValidationError {
 		})
 	}
 
-	// Validate GitHub config
-	githubErrors := validateGitHubConfig(config.GitHub)
-	errors = append(errors, githubErrors...)
+	// Validate source code host config; the GitHub credentials are only required when scans read GitHub
+	// by default, and scan requests naming another provider are checked against its credentials
+	switch config.SCMProvider {
+	case scmProviderGitHub:
+		errors = append(errors, validateGitHubConfig(config.GitHub)...)
+	case scmProviderGitLab:
+		errors = append(errors, validateGitLabConfig(config.GitLab)...)
+	case scmProviderBitbucket:
+		errors = append(errors, validateBitbucketConfig(config.Bitbucket)...)
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "SCMProvider",
+			Message: "must be github, gitlab or bitbucket",
+			Value:   config.SCMProvider,
+		})
+	}
 
 	// Validate Neo4j config
 	neo4jErrors := validateNeo4jConfig(config.Neo4j)
@@ -93,6 +422,451 @@ func validateAppConfig(config AppConfig) []ValidationError {
 	serverErrors := validateServerConfig(config.Server)
 	errors = append(errors, serverErrors...)
 
+	// Validate scan validation config
+	scanValidationErrors := validateScanValidationConfig(config.ScanValidation)
+	errors = append(errors, scanValidationErrors...)
+
+	// Validate quota config
+	if config.Quota.MonthlyAPICalls < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Quota.MonthlyAPICalls",
+			Message: "cannot be negative",
+			Value:   config.Quota.MonthlyAPICalls,
+		})
+	}
+
+	// Validate scan watchdog config
+	if config.ScanWatchdog.StallTimeout <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanWatchdog.StallTimeout",
+			Message: "must be positive",
+			Value:   config.ScanWatchdog.StallTimeout,
+		})
+	}
+
+	if config.ScanWatchdog.MaxRestarts < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanWatchdog.MaxRestarts",
+			Message: "cannot be negative",
+			Value:   config.ScanWatchdog.MaxRestarts,
+		})
+	}
+
+	// Validate circuit breaker config
+	if config.CircuitBreaker.FailureThreshold < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "CircuitBreaker.FailureThreshold",
+			Message: "cannot be negative",
+			Value:   config.CircuitBreaker.FailureThreshold,
+		})
+	}
+
+	if config.CircuitBreaker.OpenTimeout <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "CircuitBreaker.OpenTimeout",
+			Message: "must be positive",
+			Value:   config.CircuitBreaker.OpenTimeout,
+		})
+	}
+
+	if config.CircuitBreaker.HalfOpenRequests <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "CircuitBreaker.HalfOpenRequests",
+			Message: "must be positive",
+			Value:   config.CircuitBreaker.HalfOpenRequests,
+		})
+	}
+
+	// Validate scan jobs config
+	if config.ScanJobs.Workers <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanJobs.Workers",
+			Message: "must be positive",
+			Value:   config.ScanJobs.Workers,
+		})
+	}
+
+	if config.ScanJobs.Retention <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanJobs.Retention",
+			Message: "must be positive",
+			Value:   config.ScanJobs.Retention,
+		})
+	}
+
+	if config.Webhook.DeadLetterRetention <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Webhook.DeadLetterRetention",
+			Message: "must be positive",
+			Value:   config.Webhook.DeadLetterRetention,
+		})
+	}
+
+	if config.ScanJobs.MaxQueued < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanJobs.MaxQueued",
+			Message: "cannot be negative",
+			Value:   config.ScanJobs.MaxQueued,
+		})
+	}
+
+	if config.ScanJobs.DedupWindow < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanJobs.DedupWindow",
+			Message: "cannot be negative",
+			Value:   config.ScanJobs.DedupWindow,
+		})
+	}
+
+	// Validate report format config
+	if _, err := newReportFormatter(config.ReportFormat.Timezone, config.ReportFormat.Locale); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "ReportFormat",
+			Message: err.Error(),
+			Value:   config.ReportFormat,
+		})
+	}
+
+	// Validate scan budget config
+	if config.ScanBudget.MaxBufferedItems < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanBudget.MaxBufferedItems",
+			Message: "cannot be negative",
+			Value:   config.ScanBudget.MaxBufferedItems,
+		})
+	}
+
+	// Validate graph limits config
+	if config.GraphLimits.MaxNodes < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GraphLimits.MaxNodes",
+			Message: "cannot be negative",
+			Value:   config.GraphLimits.MaxNodes,
+		})
+	}
+
+	if config.GraphLimits.MaxEdges < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GraphLimits.MaxEdges",
+			Message: "cannot be negative",
+			Value:   config.GraphLimits.MaxEdges,
+		})
+	}
+
+	// Validate freshness config
+	if config.Freshness.SLA <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Freshness.SLA",
+			Message: "must be positive",
+			Value:   config.Freshness.SLA,
+		})
+	}
+
+	// Validate reconciliation config
+	if config.Reconciliation.Enabled {
+		errors = append(errors, validateReconciliationConfig(config.Reconciliation)...)
+	}
+
+	// Validate archival config
+	if config.Archival.Enabled {
+		errors = append(errors, validateArchivalConfig(config.Archival)...)
+	}
+
+	// Validate SLO config
+	errors = append(errors, validateSLOConfig(config.SLO)...)
+
+	// Validate scan schedule config
+	if config.ScanSchedule.Cron != "" {
+		errors = append(errors, validateScanScheduleConfig(config.ScanSchedule)...)
+	}
+
+	// Validate state store config
+	errors = append(errors, validateStateStoreConfig(config.StateStore)...)
+
+	// Validate leader election config
+	if config.LeaderElection.LeaseTTL <= time.Minute {
+		errors = append(errors, ValidationError{
+			Field:   "LeaderElection.LeaseTTL",
+			Message: "must be longer than the one minute renewal interval",
+			Value:   config.LeaderElection.LeaseTTL,
+		})
+	}
+
+	// Validate custom properties config
+	if config.CustomProperties.OwnerProperty == "" || config.CustomProperties.TierProperty == "" || config.CustomProperties.OwnerProperty == config.CustomProperties.TierProperty {
+		errors = append(errors, ValidationError{
+			Field:   "CustomProperties",
+			Message: "owner and tier property names must be set and differ",
+			Value:   config.CustomProperties.OwnerProperty + "," + config.CustomProperties.TierProperty,
+		})
+	}
+
+	// Validate CODEOWNERS search config
+	for _, path := range config.Codeowners.ExtraPaths {
+		if !isValidCodeownersPath(path) {
+			errors = append(errors, ValidationError{
+				Field:   "Codeowners.ExtraPaths",
+				Message: "must be relative file paths within the repository",
+				Value:   path,
+			})
+		}
+	}
+
+	// Validate team visibility config
+	if !isValidSecretTeamsMode(config.TeamVisibility.SecretTeams) {
+		errors = append(errors, ValidationError{
+			Field:   "TeamVisibility.SecretTeams",
+			Message: "must be show, mask or omit",
+			Value:   config.TeamVisibility.SecretTeams,
+		})
+	}
+
+	// Validate auth config
+	errors = append(errors, validateAuthConfig(config.Auth)...)
+
+	// Validate rate limit config
+	errors = append(errors, validateRateLimitConfig(config.RateLimit)...)
+
+	// Validate publish config
+	switch {
+	case !isValidPublishTarget(config.Publish.Target):
+		errors = append(errors, ValidationError{
+			Field:   "Publish.Target",
+			Message: "must be filesystem or blob",
+			Value:   config.Publish.Target,
+		})
+	case config.Publish.Target == publishTargetFilesystem && config.Publish.Directory == "":
+		errors = append(errors, ValidationError{
+			Field:   "Publish.Directory",
+			Message: "cannot be empty",
+			Value:   config.Publish.Directory,
+		})
+	case config.Publish.Target == publishTargetBlob && config.Publish.BlobURL == "":
+		errors = append(errors, ValidationError{
+			Field:   "Publish.BlobURL",
+			Message: "cannot be empty when publishing to blob storage",
+			Value:   config.Publish.BlobURL,
+		})
+	}
+
+	// Validate audit log config
+	if config.AuditLog.Enabled && config.AuditLog.Schedule == "" {
+		errors = append(errors, ValidationError{
+			Field:   "AuditLog.Schedule",
+			Message: "cannot be empty",
+			Value:   config.AuditLog.Schedule,
+		})
+	}
+	if config.AuditLog.Backfill <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "AuditLog.Backfill",
+			Message: "must be positive",
+			Value:   config.AuditLog.Backfill,
+		})
+	}
+
+	// Validate transfer config
+	if config.Transfer.PullRequestsEnabled && config.Transfer.BranchPrefix == "" {
+		errors = append(errors, ValidationError{
+			Field:   "Transfer.BranchPrefix",
+			Message: "cannot be empty when pull requests are enabled",
+			Value:   config.Transfer.BranchPrefix,
+		})
+	}
+
+	// Validate warm-up config
+	if len(config.Warmup.Organizations) > 0 && config.Warmup.Timeout <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Warmup.Timeout",
+			Message: "must be positive",
+			Value:   config.Warmup.Timeout,
+		})
+	}
+
+	return errors
+}
+
+// validateReconciliationConfig validates reconciliation configuration (Pure Core)
+func validateReconciliationConfig(config ReconciliationConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.Schedule == "" {
+		errors = append(errors, ValidationError{
+			Field:   "Reconciliation.Schedule",
+			Message: "cannot be empty",
+			Value:   config.Schedule,
+		})
+	}
+
+	if config.DriftThresholdPercent < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Reconciliation.DriftThresholdPercent",
+			Message: "cannot be negative",
+			Value:   config.DriftThresholdPercent,
+		})
+	}
+
+	if config.MaxRepos <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Reconciliation.MaxRepos",
+			Message: "must be positive",
+			Value:   config.MaxRepos,
+		})
+	}
+
+	if config.MaxTeams <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Reconciliation.MaxTeams",
+			Message: "must be positive",
+			Value:   config.MaxTeams,
+		})
+	}
+
+	return errors
+}
+
+// validateArchivalConfig validates organization archival configuration (Pure Core)
+func validateArchivalConfig(config ArchivalConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.Schedule == "" {
+		errors = append(errors, ValidationError{
+			Field:   "Archival.Schedule",
+			Message: "cannot be empty",
+			Value:   config.Schedule,
+		})
+	}
+
+	if config.InactiveAfter <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Archival.InactiveAfter",
+			Message: "must be positive",
+			Value:   config.InactiveAfter,
+		})
+	}
+
+	if config.Directory == "" {
+		errors = append(errors, ValidationError{
+			Field:   "Archival.Directory",
+			Message: "cannot be empty",
+			Value:   config.Directory,
+		})
+	}
+
+	return errors
+}
+
+// validateScanScheduleConfig validates the recurring scan schedule (Pure Core)
+func validateScanScheduleConfig(config ScanScheduleConfig) []ValidationError {
+	var errors []ValidationError
+
+	if _, err := parseCronExpression(config.Cron); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "ScanSchedule.Cron",
+			Message: err.Error(),
+			Value:   config.Cron,
+		})
+	}
+
+	if _, invalid := normalizeScanOrganizations(config.Organizations); invalid != "" || len(config.Organizations) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanSchedule.Organizations",
+			Message: "must list valid organization logins",
+			Value:   config.Organizations,
+		})
+	}
+
+	if config.MaxRepos <= 0 || config.MaxTeams <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanSchedule.MaxRepos",
+			Message: "max repositories and teams must be positive",
+			Value:   []int{config.MaxRepos, config.MaxTeams},
+		})
+	}
+
+	return errors
+}
+
+// validateStateStoreConfig validates the job and schedule state backend and the GoFr datasource it
+// connects through (Pure Core)
+func validateStateStoreConfig(config StateStoreConfig) []ValidationError {
+	var errors []ValidationError
+
+	switch config.Backend {
+	case StateStoreMemory, StateStoreNeo4j:
+	case StateStoreRedis:
+		if config.RedisHost == "" {
+			errors = append(errors, ValidationError{
+				Field:   "StateStore.Backend",
+				Message: "the redis backend requires REDIS_HOST",
+				Value:   config.Backend,
+			})
+		}
+	case StateStorePostgres:
+		if config.SQLDialect != "postgres" {
+			errors = append(errors, ValidationError{
+				Field:   "StateStore.Backend",
+				Message: "the postgres backend requires DB_DIALECT=postgres and the DB_* connection settings",
+				Value:   config.Backend,
+			})
+		}
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "StateStore.Backend",
+			Message: "must be one of " + strings.Join(supportedStateStoreBackends(), ", "),
+			Value:   config.Backend,
+		})
+	}
+
+	return errors
+}
+
+// validateSLOConfig validates service level objective configuration (Pure Core)
+func validateSLOConfig(config SLOConfig) []ValidationError {
+	var errors []ValidationError
+
+	for _, objective := range config.Objectives {
+		if _, _, found := strings.Cut(objective.Route, " /"); !found {
+			errors = append(errors, ValidationError{
+				Field:   "SLO.Objectives.Route",
+				Message: "must be a method followed by a path",
+				Value:   objective.Route,
+			})
+		}
+
+		if objective.Objective <= 0 || objective.Objective >= 100 {
+			errors = append(errors, ValidationError{
+				Field:   "SLO.Objectives.Objective",
+				Message: "must be between 0 and 100 exclusive",
+				Value:   objective.Route,
+			})
+		}
+
+		if objective.Latency <= 0 {
+			errors = append(errors, ValidationError{
+				Field:   "SLO.Objectives.Latency",
+				Message: "must be positive",
+				Value:   objective.Route,
+			})
+		}
+	}
+
+	if config.FastBurnThreshold <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "SLO.FastBurnThreshold",
+			Message: "must be positive",
+			Value:   config.FastBurnThreshold,
+		})
+	}
+
+	if config.SlowBurnThreshold <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "SLO.SlowBurnThreshold",
+			Message: "must be positive",
+			Value:   config.SlowBurnThreshold,
+		})
+	}
+
 	return errors
 }
 
@@ -106,14 +880,60 @@ func validateGitHubConfig(config GitHubConfig) []ValidationError {
 	return errors
 }
 
+// validateGitLabConfig validates the GitLab API settings (Pure Core)
+func validateGitLabConfig(config GitLabConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.BaseURL == "" {
+		errors = append(errors, ValidationError{
+			Field:   "GitLab.BaseURL",
+			Message: "cannot be empty",
+			Value:   config.BaseURL,
+		})
+	}
+	if config.Token == "" {
+		errors = append(errors, ValidationError{
+			Field:   "GitLab.Token",
+			Message: "cannot be empty",
+			Value:   config.Token,
+		})
+	}
+
+	return errors
+}
+
+// validateBitbucketConfig validates the Bitbucket Cloud API settings (Pure Core)
+func validateBitbucketConfig(config BitbucketConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.BaseURL == "" {
+		errors = append(errors, ValidationError{
+			Field:   "Bitbucket.BaseURL",
+			Message: "cannot be empty",
+			Value:   config.BaseURL,
+		})
+	}
+	if !isBitbucketConfigured(config) {
+		errors = append(errors, ValidationError{
+			Field:   "Bitbucket.Token",
+			Message: "cannot be empty unless a username and app password are configured",
+			Value:   config.Token,
+		})
+	}
+
+	return errors
+}
+
 // validateGitHubStringFields validates string fields in GitHub configuration (Pure Core)
 func validateGitHubStringFields(config GitHubConfig) []ValidationError {
 	var errors []ValidationError
 
-	if config.Token == "" {
+	if isGitHubAppConfigured(config) {
+		errors = append(errors, validateGitHubAppFields(config)...)
+	} else if config.Token == "" {
 		errors = append(errors, ValidationError{
 			Field:   "GitHub.Token",
-			Message: "cannot be empty",
+			Message: "cannot be empty unless GitHub App credentials are configured",
 			Value:   config.Token,
 		})
 	}
@@ -137,6 +957,36 @@ func validateGitHubStringFields(config GitHubConfig) []ValidationError {
 	return errors
 }
 
+// validateGitHubAppFields validates GitHub App credentials, which must be configured together (Pure Core)
+func validateGitHubAppFields(config GitHubConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.AppID <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.AppID",
+			Message: "must be set when GitHub App authentication is configured",
+			Value:   config.AppID,
+		})
+	}
+
+	if config.AppInstallationID <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.AppInstallationID",
+			Message: "must be set when GitHub App authentication is configured",
+			Value:   config.AppInstallationID,
+		})
+	}
+
+	if (config.AppPrivateKey == "") == (config.AppPrivateKeyPath == "") {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.AppPrivateKey",
+			Message: "exactly one of GITHUB_APP_PRIVATE_KEY or GITHUB_APP_PRIVATE_KEY_PATH must be set",
+		})
+	}
+
+	return errors
+}
+
 // validateGitHubNumericFields validates numeric fields in GitHub configuration (Pure Core)
 func validateGitHubNumericFields(config GitHubConfig) []ValidationError {
 	var errors []ValidationError
@@ -157,6 +1007,14 @@ func validateGitHubNumericFields(config GitHubConfig) []ValidationError {
 		})
 	}
 
+	if config.ETagCacheBytes < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.ETagCacheBytes",
+			Message: "cannot be negative",
+			Value:   config.ETagCacheBytes,
+		})
+	}
+
 	if config.RateLimitMin < 0 {
 		errors = append(errors, ValidationError{
 			Field:   "GitHub.RateLimitMin",
@@ -165,6 +1023,46 @@ func validateGitHubNumericFields(config GitHubConfig) []ValidationError {
 		})
 	}
 
+	if config.ReservePercent < 0 || config.ReservePercent > 90 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.ReservePercent",
+			Message: "must be between 0 and 90",
+			Value:   config.ReservePercent,
+		})
+	}
+
+	if config.ReserveMaxWait < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.ReserveMaxWait",
+			Message: "cannot be negative",
+			Value:   config.ReserveMaxWait,
+		})
+	}
+
+	if config.Transport.MaxIdleConns < 0 || config.Transport.MaxIdleConnsPerHost < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.Transport",
+			Message: "idle connection limits cannot be negative",
+			Value:   config.Transport,
+		})
+	}
+
+	if config.Transport.IdleConnTimeout < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.Transport.IdleConnTimeout",
+			Message: "cannot be negative",
+			Value:   config.Transport.IdleConnTimeout,
+		})
+	}
+
+	if config.DegradePercent < 0 || config.DegradePercent > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "GitHub.DegradePercent",
+			Message: "must be between 0 and 100",
+			Value:   config.DegradePercent,
+		})
+	}
+
 	return errors
 }
 
@@ -174,6 +1072,7 @@ func validateNeo4jConfig(config Neo4jConfig) []ValidationError {
 
 	errors = append(errors, validateNeo4jStringFields(config)...)
 	errors = append(errors, validateNeo4jTimeoutField(config)...)
+	errors = append(errors, validateNeo4jBatchFields(config.Batch)...)
 
 	return errors
 }
@@ -182,6 +1081,14 @@ func validateNeo4jConfig(config Neo4jConfig) []ValidationError {
 func validateNeo4jStringFields(config Neo4jConfig) []ValidationError {
 	var errors []ValidationError
 
+	if config.Provider != graphProviderNeo4j && config.Provider != graphProviderMemgraph {
+		errors = append(errors, ValidationError{
+			Field:   "Neo4j.Provider",
+			Message: "must be neo4j or memgraph",
+			Value:   config.Provider,
+		})
+	}
+
 	if config.URI == "" {
 		errors = append(errors, ValidationError{
 			Field:   "Neo4j.URI",
@@ -232,6 +1139,29 @@ func validateNeo4jTimeoutField(config Neo4jConfig) []ValidationError {
 	return errors
 }
 
+// validateNeo4jBatchFields validates write batching thresholds in Neo4j configuration (Pure Core)
+func validateNeo4jBatchFields(config Neo4jBatchConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.Size <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Neo4j.Batch.Size",
+			Message: "must be positive",
+			Value:   config.Size,
+		})
+	}
+
+	if config.FlushInterval <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Neo4j.Batch.FlushInterval",
+			Message: "must be positive",
+			Value:   config.FlushInterval,
+		})
+	}
+
+	return errors
+}
+
 // validateServerConfig validates server configuration (Pure Core)
 func validateServerConfig(config ServerConfig) []ValidationError {
 	var errors []ValidationError
@@ -271,3 +1201,104 @@ func validateServerConfig(config ServerConfig) []ValidationError {
 	return errors
 }
 
+// validateScanValidationConfig validates scan validation configuration (Pure Core)
+func validateScanValidationConfig(config ScanValidationConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.MaxRepoCountChangePercent <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "ScanValidation.MaxRepoCountChangePercent",
+			Message: "must be positive",
+			Value:   config.MaxRepoCountChangePercent,
+		})
+	}
+
+	return errors
+}
+
+// validateAuthConfig validates the exempt paths, OIDC provider and OIDC default role of API
+// authentication (Pure Core)
+func validateAuthConfig(config AuthConfig) []ValidationError {
+	var errors []ValidationError
+
+	for _, path := range config.ExemptPaths {
+		if !strings.HasPrefix(path, "/") {
+			errors = append(errors, ValidationError{
+				Field:   "Auth.ExemptPaths",
+				Message: "must be absolute request paths",
+				Value:   path,
+			})
+		}
+	}
+
+	urls := []struct {
+		field string
+		value string
+	}{
+		{"Auth.OIDC.Issuer", config.OIDC.Issuer},
+		{"Auth.OIDC.JWKSURL", config.OIDC.JWKSURL},
+	}
+	for _, setting := range urls {
+		if setting.value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(setting.value); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			errors = append(errors, ValidationError{
+				Field:   setting.field,
+				Message: "must be an http or https URL",
+				Value:   setting.value,
+			})
+		}
+	}
+
+	if config.OIDC.Issuer == "" && (config.OIDC.Audience != "" || config.OIDC.JWKSURL != "") {
+		errors = append(errors, ValidationError{
+			Field:   "Auth.OIDC.Issuer",
+			Message: "is required when an OIDC audience or key set URL is set",
+			Value:   config.OIDC.Issuer,
+		})
+	}
+
+	if config.OIDC.DefaultRole != "" && roleRanks[config.OIDC.DefaultRole] == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "Auth.OIDC.DefaultRole",
+			Message: "must be reader, operator or none",
+			Value:   config.OIDC.DefaultRole,
+		})
+	}
+
+	return errors
+}
+
+// validateRateLimitConfig validates the per-client request rate, burst and exempt paths (Pure Core)
+func validateRateLimitConfig(config RateLimitConfig) []ValidationError {
+	var errors []ValidationError
+
+	if config.RequestsPerSecond < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "RateLimit.RequestsPerSecond",
+			Message: "must not be negative",
+			Value:   config.RequestsPerSecond,
+		})
+	}
+
+	if config.RequestsPerSecond > 0 && config.Burst < 1 {
+		errors = append(errors, ValidationError{
+			Field:   "RateLimit.Burst",
+			Message: "must be at least 1 when rate limiting is enabled",
+			Value:   config.Burst,
+		})
+	}
+
+	for _, path := range config.ExemptPaths {
+		if !strings.HasPrefix(path, "/") {
+			errors = append(errors, ValidationError{
+				Field:   "RateLimit.ExemptPaths",
+				Message: "must be absolute request paths",
+				Value:   path,
+			})
+		}
+	}
+
+	return errors
+}
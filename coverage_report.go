@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Repositories covered by one coverage report; each costs a recursive tree request to GitHub
+const (
+	defaultCoverageReportLimit = 50
+	maxCoverageReportLimit     = 500
+)
+
+// coverageTopDirectories is the number of directories with the most unowned files listed per repository
+const coverageTopDirectories = 10
+
+// PatternCoverage is the number of files a CODEOWNERS rule decides the owners of
+type PatternCoverage struct {
+	Pattern string   `json:"pattern"`
+	Line    int      `json:"line"`
+	Owners  []string `json:"owners"`
+	Files   int      `json:"files"`
+}
+
+// DirectoryCoverage counts the files without owners in a top-level directory
+type DirectoryCoverage struct {
+	Directory    string `json:"directory"`
+	UnownedFiles int    `json:"unowned_files"`
+}
+
+// RepositoryCoverage is the CODEOWNERS coverage of the files of one repository
+type RepositoryCoverage struct {
+	Repository         string              `json:"repository"`
+	HasCodeowners      bool                `json:"has_codeowners"`
+	TotalFiles         int                 `json:"total_files"`
+	OwnedFiles         int                 `json:"owned_files"`
+	UnownedFiles       int                 `json:"unowned_files"`
+	CoveragePercent    float64             `json:"coverage_percent"`
+	UnownedPercent     float64             `json:"unowned_percent"`
+	Estimated          bool                `json:"estimated"`
+	Patterns           []PatternCoverage   `json:"patterns"`
+	UnusedPatterns     []string            `json:"unused_patterns"`
+	UnownedDirectories []DirectoryCoverage `json:"unowned_directories"`
+	Error              string              `json:"error,omitempty"`
+}
+
+// OrganizationCoverage rolls repository coverage up to the organization
+type OrganizationCoverage struct {
+	Repositories               int     `json:"repositories"`
+	RepositoriesWithCodeowners int     `json:"repositories_with_codeowners"`
+	FullyCoveredRepositories   int     `json:"fully_covered_repositories"`
+	FailedRepositories         int     `json:"failed_repositories"`
+	TotalFiles                 int     `json:"total_files"`
+	OwnedFiles                 int     `json:"owned_files"`
+	UnownedFiles               int     `json:"unowned_files"`
+	CoveragePercent            float64 `json:"coverage_percent"`
+	Estimated                  bool    `json:"estimated"`
+}
+
+// CoverageReportResponse is the file-level CODEOWNERS coverage of an organization's repositories
+type CoverageReportResponse struct {
+	Organization string               `json:"organization"`
+	ActiveScanID string               `json:"active_scan_id"`
+	Truncated    bool                 `json:"truncated"`
+	Summary      OrganizationCoverage `json:"summary"`
+	Repositories []RepositoryCoverage `json:"repositories"`
+}
+
+// gitTree is the response of the recursive git trees API
+type gitTree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// buildCoverageRepositoriesQuery builds a query listing the active, unarchived repositories of an
+// organization by name, optionally a single one; an organization without any returns one row with
+// a null full_name (Pure Core)
+func buildCoverageRepositoriesQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND NOT coalesce(repo.archived, false)
+			AND ($repository = '' OR repo.name = $repository)
+		RETURN org.active_scan_id AS scan_id, repo.full_name AS full_name
+		ORDER BY repo.full_name
+		LIMIT $limit
+	`
+}
+
+// roundPercent returns part as a percentage of total with one decimal (Pure Core)
+func roundPercent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
+
+// topLevelDirectory returns the first segment of a file path, or "/" for files in the root (Pure Core)
+func topLevelDirectory(path string) string {
+	directory, _, found := strings.Cut(path, "/")
+	if !found {
+		return "/"
+	}
+	return directory
+}
+
+// computeRepositoryCoverage resolves every file of a repository against its CODEOWNERS rules (Pure Core)
+func computeRepositoryCoverage(fullName string, matcher *CodeownersMatcher, files []string, truncated bool) RepositoryCoverage {
+	coverage := RepositoryCoverage{
+		Repository:         fullName,
+		HasCodeowners:      matcher != nil && len(matcher.rules) > 0,
+		TotalFiles:         len(files),
+		Estimated:          truncated,
+		Patterns:           []PatternCoverage{},
+		UnusedPatterns:     []string{},
+		UnownedDirectories: []DirectoryCoverage{},
+	}
+	if matcher == nil {
+		matcher = &CodeownersMatcher{}
+	}
+
+	ruleFiles := make([]int, len(matcher.rules))
+	unownedByDirectory := make(map[string]int)
+	for _, file := range files {
+		if index := matcher.resolveRule(file); index >= 0 {
+			ruleFiles[index]++
+			coverage.OwnedFiles++
+			continue
+		}
+		unownedByDirectory[topLevelDirectory(file)]++
+	}
+	coverage.UnownedFiles = coverage.TotalFiles - coverage.OwnedFiles
+	coverage.CoveragePercent = roundPercent(coverage.OwnedFiles, coverage.TotalFiles)
+	coverage.UnownedPercent = roundPercent(coverage.UnownedFiles, coverage.TotalFiles)
+
+	for i, rule := range matcher.rules {
+		if ruleFiles[i] == 0 {
+			coverage.UnusedPatterns = append(coverage.UnusedPatterns, rule.entry.pattern)
+			continue
+		}
+		coverage.Patterns = append(coverage.Patterns, PatternCoverage{
+			Pattern: rule.entry.pattern,
+			Line:    rule.entry.line,
+			Owners:  rule.entry.owners,
+			Files:   ruleFiles[i],
+		})
+	}
+
+	for directory, count := range unownedByDirectory {
+		coverage.UnownedDirectories = append(coverage.UnownedDirectories, DirectoryCoverage{Directory: directory, UnownedFiles: count})
+	}
+	sort.Slice(coverage.UnownedDirectories, func(i, j int) bool {
+		a, b := coverage.UnownedDirectories[i], coverage.UnownedDirectories[j]
+		if a.UnownedFiles != b.UnownedFiles {
+			return a.UnownedFiles > b.UnownedFiles
+		}
+		return a.Directory < b.Directory
+	})
+	if len(coverage.UnownedDirectories) > coverageTopDirectories {
+		coverage.UnownedDirectories = coverage.UnownedDirectories[:coverageTopDirectories]
+	}
+
+	return coverage
+}
+
+// summarizeCoverage rolls repository coverage up to the organization; failed repositories are
+// counted but left out of the file totals (Pure Core)
+func summarizeCoverage(repositories []RepositoryCoverage) OrganizationCoverage {
+	summary := OrganizationCoverage{Repositories: len(repositories)}
+	for _, repo := range repositories {
+		if repo.Error != "" {
+			summary.FailedRepositories++
+			continue
+		}
+		if repo.HasCodeowners {
+			summary.RepositoriesWithCodeowners++
+		}
+		if repo.TotalFiles > 0 && repo.UnownedFiles == 0 {
+			summary.FullyCoveredRepositories++
+		}
+		summary.TotalFiles += repo.TotalFiles
+		summary.OwnedFiles += repo.OwnedFiles
+		summary.UnownedFiles += repo.UnownedFiles
+		summary.Estimated = summary.Estimated || repo.Estimated
+	}
+	summary.CoveragePercent = roundPercent(summary.OwnedFiles, summary.TotalFiles)
+	return summary
+}
+
+// fetchRepositoryFiles lists the files of a repository's default branch with one recursive tree request.
+// Empty repositories have no files; GitHub truncates very large trees, making the coverage an estimate.
+func fetchRepositoryFiles(ctx *gofr.Context, fullName string) ([]string, bool, error) {
+	endpoint := fmt.Sprintf("repos/%s/git/trees/HEAD", fullName)
+	resp, err := githubGet(ctx, endpoint, map[string]any{"recursive": "1"}, buildGitHubRequestHeaders())
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch repository tree: %w", err)
+	}
+	defer resp.Body.Close()
+	logRateLimitInfo(ctx, resp)
+	newMetricsCollector(ctx, "codeowners-scanner").recordAPICallCount("github", "git_trees", resp.StatusCode)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusConflict:
+		return []string{}, false, nil
+	default:
+		return nil, false, fmt.Errorf("GitHub returned status %d for the repository tree", resp.StatusCode)
+	}
+
+	var tree gitTree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, false, fmt.Errorf("failed to decode repository tree: %w", err)
+	}
+
+	files := make([]string, 0, len(tree.Tree))
+	for _, entry := range tree.Tree {
+		if entry.Type == "blob" {
+			files = append(files, entry.Path)
+		}
+	}
+	return files, tree.Truncated, nil
+}
+
+// getCoverageReport computes the file-level CODEOWNERS coverage of up to limit repositories of an
+// organization, using the stored rules of the active scan and each repository's tree from GitHub (Orchestrator)
+func getCoverageReport(ctx *gofr.Context, deps *AppDependencies, orgName, repository string, limit int) (CoverageReportResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return CoverageReportResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	result, err := executeNeo4jReadQuery(ctx, session, buildCoverageRepositoriesQuery(), map[string]interface{}{
+		"orgName":    orgName,
+		"repository": repository,
+		"limit":      limit + 1,
+	})
+	closeNeo4jSession(ctx, session)
+	if err != nil {
+		return CoverageReportResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return CoverageReportResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	response := CoverageReportResponse{
+		Organization: orgName,
+		ActiveScanID: getStringFromMap(result.Records[0], "scan_id"),
+		Truncated:    len(result.Records) > limit,
+		Repositories: []RepositoryCoverage{},
+	}
+	records := result.Records[:min(len(result.Records), limit)]
+	names := make([]string, 0, len(records))
+	for _, record := range records {
+		if name := getStringFromMap(record, "full_name"); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	matchers, err := loadCodeownersMatchers(ctx, deps, names)
+	if err != nil {
+		return CoverageReportResponse{}, err
+	}
+
+	for _, name := range names {
+		files, truncated, err := fetchRepositoryFiles(ctx, name)
+		if err != nil {
+			logWarn(ctx, "Failed to compute repository coverage", LogFields{
+				"component":  "coverage_report",
+				"operation":  "fetch_repository_files",
+				"repository": name,
+				"error":      err.Error(),
+			})
+			response.Repositories = append(response.Repositories, RepositoryCoverage{
+				Repository:         name,
+				Patterns:           []PatternCoverage{},
+				UnusedPatterns:     []string{},
+				UnownedDirectories: []DirectoryCoverage{},
+				Error:              err.Error(),
+			})
+			continue
+		}
+		response.Repositories = append(response.Repositories, computeRepositoryCoverage(name, matchers[name], files, truncated))
+	}
+
+	response.Summary = summarizeCoverage(response.Repositories)
+	logInfo(ctx, "Computed CODEOWNERS coverage report", LogFields{
+		"component":        "coverage_report",
+		"operation":        "get_coverage_report",
+		"organization":     orgName,
+		"repositories":     response.Summary.Repositories,
+		"failed":           response.Summary.FailedRepositories,
+		"coverage_percent": response.Summary.CoveragePercent,
+	})
+	return response, nil
+}
+
+// handleGetCoverageReport returns the per-repository and organization-wide CODEOWNERS file coverage
+func (h *AppHandler) handleGetCoverageReport(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	limit := defaultCoverageReportLimit
+	if value := ctx.Param("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxCoverageReportLimit {
+			return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+		limit = parsed
+	}
+
+	repository, err := url.PathUnescape(ctx.Param("repository"))
+	if err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"repository"}}
+	}
+
+	return getCoverageReport(ctx, h.deps, orgName, repository, limit)
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Coverage target states reported with organization stats
+const (
+	CoverageTargetAchieved = "achieved"
+	CoverageTargetOnTrack  = "on_track"
+	CoverageTargetOffTrack = "off_track"
+	CoverageTargetMissed   = "missed"
+)
+
+// coverageTargetDigestSchedule is the cron schedule of the weekly coverage target digest
+const coverageTargetDigestSchedule = "0 9 * * 1"
+
+// coverageTrendWindow is how much scan history the observed weekly coverage trend is computed from
+const coverageTrendWindow = 12 * 7 * 24 * time.Hour
+
+// hoursPerWeek converts durations to weeks
+const hoursPerWeek = 7 * 24
+
+// CoverageTargetRequest represents a request to set an organization's CODEOWNERS coverage target
+type CoverageTargetRequest struct {
+	TargetPercent float64 `json:"target_percent"`
+	Deadline      string  `json:"deadline"`
+}
+
+// CoverageSample is the coverage of an activated scan
+type CoverageSample struct {
+	ActivatedAt     time.Time
+	CoveragePercent float64
+}
+
+// CoverageTargetProgress describes the progress of an organization towards its coverage target
+type CoverageTargetProgress struct {
+	TargetPercent       float64 `json:"target_percent"`
+	Deadline            string  `json:"deadline"`
+	CurrentPercent      float64 `json:"current_percent"`
+	WeeksRemaining      float64 `json:"weeks_remaining"`
+	RequiredWeeklyDelta float64 `json:"required_weekly_delta"`
+	ObservedWeeklyDelta float64 `json:"observed_weekly_delta"`
+	TrendSamples        int     `json:"trend_samples"`
+	ProjectedPercent    float64 `json:"projected_percent"`
+	Status              string  `json:"status"`
+}
+
+// buildSetCoverageTargetQuery builds a query storing an organization's coverage target (Pure Core)
+func buildSetCoverageTargetQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		SET org.coverage_target_percent = $target_percent,
+			org.coverage_target_deadline = $deadline,
+			org.coverage_target_set_at = $set_at
+		RETURN org.login AS organization
+	`
+}
+
+// buildClearCoverageTargetQuery builds a query removing an organization's coverage target (Pure Core)
+func buildClearCoverageTargetQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		REMOVE org.coverage_target_percent, org.coverage_target_deadline, org.coverage_target_set_at
+		RETURN org.login AS organization
+	`
+}
+
+// buildCoverageHistoryQuery builds a query returning the coverage of scans activated since a cutoff (Pure Core)
+func buildCoverageHistoryQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[:HAS_SCAN]->(scan:Scan)
+		WHERE scan.status IN ['active', 'superseded', 'backfilled'] AND scan.activated_at >= $since
+		RETURN scan.activated_at AS activated_at,
+			scan.repository_count AS repository_count,
+			scan.repos_with_codeowners AS repos_with_codeowners
+		ORDER BY activated_at
+	`
+}
+
+// buildCoverageTargetOrganizationsQuery builds a query returning the organizations with a coverage target (Pure Core)
+func buildCoverageTargetOrganizationsQuery() string {
+	return `
+		MATCH (org:Organization)
+		WHERE org.coverage_target_percent IS NOT NULL
+		RETURN org.login AS organization
+		ORDER BY organization
+	`
+}
+
+// convertToCoverageSamples converts scan history records into coverage samples (Pure Core)
+func convertToCoverageSamples(records []map[string]interface{}) []CoverageSample {
+	samples := make([]CoverageSample, 0, len(records))
+	for _, record := range records {
+		activatedAt, err := time.Parse(time.RFC3339, getStringFromMap(record, "activated_at"))
+		if err != nil {
+			continue
+		}
+		samples = append(samples, CoverageSample{
+			ActivatedAt:     activatedAt,
+			CoveragePercent: calculatePercent(getIntFromMap(record, "repos_with_codeowners"), getIntFromMap(record, "repository_count")),
+		})
+	}
+	return samples
+}
+
+// calculateObservedWeeklyDelta returns the coverage change per week between the oldest and newest samples (Pure Core)
+func calculateObservedWeeklyDelta(samples []CoverageSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	weeks := last.ActivatedAt.Sub(first.ActivatedAt).Hours() / hoursPerWeek
+	if weeks <= 0 {
+		return 0
+	}
+	return (last.CoveragePercent - first.CoveragePercent) / weeks
+}
+
+// evaluateCoverageTarget computes the progress towards a coverage target from the current coverage and scan history (Pure Core)
+func evaluateCoverageTarget(targetPercent float64, deadline string, currentPercent float64, samples []CoverageSample, now time.Time) CoverageTargetProgress {
+	progress := CoverageTargetProgress{
+		TargetPercent:       targetPercent,
+		Deadline:            deadline,
+		CurrentPercent:      currentPercent,
+		ObservedWeeklyDelta: calculateObservedWeeklyDelta(samples),
+		TrendSamples:        len(samples),
+	}
+
+	if due, err := time.Parse(time.DateOnly, deadline); err == nil {
+		progress.WeeksRemaining = math.Max(due.Sub(now).Hours()/hoursPerWeek, 0)
+	}
+
+	gap := targetPercent - currentPercent
+	progress.ProjectedPercent = math.Min(currentPercent+progress.ObservedWeeklyDelta*progress.WeeksRemaining, 100)
+
+	switch {
+	case gap <= 0:
+		progress.Status = CoverageTargetAchieved
+	case progress.WeeksRemaining == 0:
+		progress.RequiredWeeklyDelta = gap
+		progress.Status = CoverageTargetMissed
+	default:
+		progress.RequiredWeeklyDelta = gap / progress.WeeksRemaining
+		progress.Status = CoverageTargetOffTrack
+		if progress.ObservedWeeklyDelta >= progress.RequiredWeeklyDelta {
+			progress.Status = CoverageTargetOnTrack
+		}
+	}
+
+	return progress
+}
+
+// validateCoverageTargetRequest checks the target percentage and that the deadline is a future date (Pure Core)
+func validateCoverageTargetRequest(request CoverageTargetRequest, now time.Time) error {
+	if request.TargetPercent <= 0 || request.TargetPercent > 100 {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"target_percent"}}
+	}
+
+	due, err := time.Parse(time.DateOnly, request.Deadline)
+	if err != nil || !due.After(now) {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"deadline"}}
+	}
+	return nil
+}
+
+// attachCoverageTarget adds target progress to stats when the organization has a target (Orchestrator)
+func attachCoverageTarget(ctx *gofr.Context, session *Neo4jSession, stats *StatsResponse, targetPercent float64, deadline string) error {
+	if targetPercent <= 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	result, err := executeNeo4jReadQuery(ctx, session, buildCoverageHistoryQuery(), map[string]interface{}{
+		"orgName": stats.Organization,
+		"since":   now.Add(-coverageTrendWindow).Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	current := calculatePercent(stats.TotalCodeowners, stats.TotalRepositories)
+	progress := evaluateCoverageTarget(targetPercent, deadline, current, convertToCoverageSamples(result.Records), now)
+	stats.CoverageTarget = &progress
+	return nil
+}
+
+// updateCoverageTarget runs a coverage target write query, failing if the organization does not exist (Orchestrator)
+func updateCoverageTarget(ctx *gofr.Context, deps *AppDependencies, query string, params map[string]interface{}) error {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jWrite(ctx, session, query, params)
+	if err != nil {
+		return convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: getStringFromMap(params, "orgName")}
+	}
+	return nil
+}
+
+// handleSetCoverageTarget sets an organization's CODEOWNERS coverage target and returns its progress
+func (h *AppHandler) handleSetCoverageTarget(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	var request CoverageTargetRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+	if err := validateCoverageTargetRequest(request, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	err := updateCoverageTarget(ctx, h.deps, buildSetCoverageTargetQuery(), map[string]interface{}{
+		"orgName":        orgName,
+		"target_percent": request.TargetPercent,
+		"deadline":       request.Deadline,
+		"set_at":         time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo(ctx, "Coverage target set", LogFields{
+		"component":      "coverage_targets",
+		"operation":      "set_target",
+		"organization":   orgName,
+		"target_percent": request.TargetPercent,
+		"deadline":       request.Deadline,
+	})
+
+	stats, err := getOrganizationStats(ctx, h.deps, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return stats.CoverageTarget, nil
+}
+
+// handleClearCoverageTarget removes an organization's CODEOWNERS coverage target
+func (h *AppHandler) handleClearCoverageTarget(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	if err := updateCoverageTarget(ctx, h.deps, buildClearCoverageTargetQuery(), map[string]interface{}{"orgName": orgName}); err != nil {
+		return nil, err
+	}
+
+	logInfo(ctx, "Coverage target cleared", LogFields{
+		"component":    "coverage_targets",
+		"operation":    "clear_target",
+		"organization": orgName,
+	})
+
+	return map[string]string{"organization": orgName, "status": "cleared"}, nil
+}
+
+// sendCoverageTargetDigest reports the progress of every organization with a coverage target,
+// alerting on organizations that are off track or missed their deadline
+func sendCoverageTargetDigest(ctx *gofr.Context, deps *AppDependencies) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logError(ctx, "Failed to create Neo4j session for coverage target digest", LogFields{
+			"component": "coverage_targets",
+			"operation": "digest",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildCoverageTargetOrganizationsQuery(), nil)
+	closeNeo4jSession(ctx, session)
+	if err != nil {
+		logError(ctx, "Failed to list organizations with coverage targets", LogFields{
+			"component": "coverage_targets",
+			"operation": "digest",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	for _, record := range result.Records {
+		org := getStringFromMap(record, "organization")
+		stats, err := getOrganizationStats(ctx, deps, org)
+		if err != nil || stats.CoverageTarget == nil {
+			continue
+		}
+
+		progress := *stats.CoverageTarget
+		labels := MetricLabels{"organization": org}
+		metrics.recordGauge("coverage_target_percent", progress.TargetPercent, labels)
+		metrics.recordGauge("coverage_target_required_weekly_delta", progress.RequiredWeeklyDelta, labels)
+		metrics.recordGauge("coverage_target_observed_weekly_delta", progress.ObservedWeeklyDelta, labels)
+
+		fields := LogFields{
+			"component":             "coverage_targets",
+			"operation":             "digest",
+			"organization":          org,
+			"status":                progress.Status,
+			"target_percent":        progress.TargetPercent,
+			"deadline":              progress.Deadline,
+			"current_percent":       progress.CurrentPercent,
+			"required_weekly_delta": progress.RequiredWeeklyDelta,
+			"observed_weekly_delta": progress.ObservedWeeklyDelta,
+			"projected_percent":     progress.ProjectedPercent,
+		}
+
+		if progress.Status == CoverageTargetOffTrack || progress.Status == CoverageTargetMissed {
+			fields["alert"] = "coverage_target_" + progress.Status
+			logWarn(ctx, "Coverage target digest", fields)
+			continue
+		}
+		logInfo(ctx, "Coverage target digest", fields)
+	}
+}
+
+// registerCoverageTargetDigest schedules the weekly coverage target digest
+func registerCoverageTargetDigest(app *gofr.App, deps *AppDependencies) {
+	app.AddCronJob(coverageTargetDigestSchedule, "coverage-target-digest", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "coverage_target_digest", func() {
+			runAsLeader(ctx, deps, "coverage_target_digest", func() {
+				sendCoverageTargetDigest(ctx, deps)
+			})
+		})
+	})
+}
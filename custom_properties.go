@@ -0,0 +1,376 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// customPropertiesPageSize is the page size of the repository property values listing
+const customPropertiesPageSize = 100
+
+// customPropertiesBatchSize is the most repositories GitHub accepts in one property values update
+const customPropertiesBatchSize = 30
+
+// Ownership tiers written to the tier custom property
+const (
+	OwnershipTierTeam       = "team"
+	OwnershipTierIndividual = "individual"
+	OwnershipTierUnowned    = "unowned"
+)
+
+// catchAllCodeownersPatterns are the patterns matching every file of a repository
+var catchAllCodeownersPatterns = []string{"*", "/*", "**", "/**", "/"}
+
+// RepositoryPropertyValues are the ownership values of a repository's custom properties; an empty
+// owner team clears the property
+type RepositoryPropertyValues struct {
+	Repository string `json:"repository"`
+	OwnerTeam  string `json:"owner_team"`
+	Tier       string `json:"tier"`
+}
+
+// CustomPropertiesSyncResponse reports a sync of ownership into repository custom properties
+type CustomPropertiesSyncResponse struct {
+	Organization  string                     `json:"organization"`
+	DryRun        bool                       `json:"dry_run"`
+	OwnerProperty string                     `json:"owner_property"`
+	TierProperty  string                     `json:"tier_property"`
+	Repositories  int                        `json:"repositories"`
+	Unchanged     int                        `json:"unchanged"`
+	Updated       []RepositoryPropertyValues `json:"updated"`
+	Failed        []string                   `json:"failed"`
+	Requests      int                        `json:"requests"`
+}
+
+// CustomPropertyUndefinedError is returned when the organization has not defined a property the sync writes
+type CustomPropertyUndefinedError struct {
+	Organization string
+	Property     string
+}
+
+// Error implements the error interface for CustomPropertyUndefinedError
+func (e CustomPropertyUndefinedError) Error() string {
+	return fmt.Sprintf("custom property %q is not defined in organization %s; define it as a string property before syncing", e.Property, e.Organization)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (CustomPropertyUndefinedError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// githubPropertyValue is a custom property value as read from and written to GitHub
+type githubPropertyValue struct {
+	PropertyName string      `json:"property_name"`
+	Value        interface{} `json:"value"`
+}
+
+// githubRepositoryPropertyValues is a repository with its custom property values
+type githubRepositoryPropertyValues struct {
+	RepositoryName string                `json:"repository_name"`
+	Properties     []githubPropertyValue `json:"properties"`
+}
+
+// buildRepositoryOwnershipQuery builds a query returning the team rules and user rule count of every
+// repository in the active scan (Pure Core)
+func buildRepositoryOwnershipQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[team_owner:HAS_TEAM_OWNER]->(team:Team)
+		WHERE coalesce(team_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, collect(CASE WHEN team IS NULL THEN NULL ELSE {name: team.slug, pattern: team_owner.pattern} END) AS team_rules
+		OPTIONAL MATCH (repo)-[user_owner:HAS_CODEOWNER]->(:User)
+		WHERE coalesce(user_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN repo.name AS repository, team_rules, count(user_owner) AS user_rules
+		ORDER BY repository
+	`
+}
+
+// selectOwnerTeam picks the team a repository belongs to: the team owning a catch-all pattern, else
+// the team with the most patterns, ties broken by slug (Pure Core)
+func selectOwnerTeam(teams []RepositoryOwner) string {
+	sorted := append([]RepositoryOwner{}, teams...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, team := range sorted {
+		for _, pattern := range team.Patterns {
+			for _, catchAll := range catchAllCodeownersPatterns {
+				if pattern == catchAll {
+					return team.Name
+				}
+			}
+		}
+	}
+
+	owner, most := "", 0
+	for _, team := range sorted {
+		if len(team.Patterns) > most {
+			owner, most = team.Name, len(team.Patterns)
+		}
+	}
+	return owner
+}
+
+// calculateOwnershipTier classifies a repository by who its CODEOWNERS rules assign (Pure Core)
+func calculateOwnershipTier(teams []RepositoryOwner, userRules int) string {
+	switch {
+	case len(teams) > 0:
+		return OwnershipTierTeam
+	case userRules > 0:
+		return OwnershipTierIndividual
+	default:
+		return OwnershipTierUnowned
+	}
+}
+
+// indexCurrentPropertyValues maps each repository to its current values of the synced properties (Pure Core)
+func indexCurrentPropertyValues(repositories []githubRepositoryPropertyValues, ownerProperty, tierProperty string) map[string]RepositoryPropertyValues {
+	index := make(map[string]RepositoryPropertyValues, len(repositories))
+	for _, repository := range repositories {
+		values := RepositoryPropertyValues{Repository: repository.RepositoryName}
+		for _, property := range repository.Properties {
+			value, _ := property.Value.(string)
+			switch property.PropertyName {
+			case ownerProperty:
+				values.OwnerTeam = value
+			case tierProperty:
+				values.Tier = value
+			}
+		}
+		index[repository.RepositoryName] = values
+	}
+	return index
+}
+
+// planPropertyUpdates returns the repositories whose desired values differ from GitHub's (Pure Core)
+func planPropertyUpdates(desired []RepositoryPropertyValues, current map[string]RepositoryPropertyValues) []RepositoryPropertyValues {
+	updates := []RepositoryPropertyValues{}
+	for _, values := range desired {
+		existing := current[values.Repository]
+		if existing.OwnerTeam != values.OwnerTeam || existing.Tier != values.Tier {
+			updates = append(updates, values)
+		}
+	}
+	return updates
+}
+
+// groupPropertyUpdates groups updates sharing the same values into batches GitHub accepts in one
+// request, in a stable order (Pure Core)
+func groupPropertyUpdates(updates []RepositoryPropertyValues) [][]RepositoryPropertyValues {
+	groups := make(map[[2]string][]RepositoryPropertyValues)
+	var keys [][2]string
+	for _, update := range updates {
+		key := [2]string{update.OwnerTeam, update.Tier}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], update)
+	}
+
+	var batches [][]RepositoryPropertyValues
+	for _, key := range keys {
+		group := groups[key]
+		for start := 0; start < len(group); start += customPropertiesBatchSize {
+			batches = append(batches, group[start:min(start+customPropertiesBatchSize, len(group))])
+		}
+	}
+	return batches
+}
+
+// buildPropertyValuesPayload builds the request body updating a batch of repositories; empty values
+// are sent as null to remove the property (Pure Core)
+func buildPropertyValuesPayload(batch []RepositoryPropertyValues, ownerProperty, tierProperty string) map[string]interface{} {
+	names := make([]string, 0, len(batch))
+	for _, values := range batch {
+		names = append(names, values.Repository)
+	}
+
+	value := func(v string) interface{} {
+		if v == "" {
+			return nil
+		}
+		return v
+	}
+	return map[string]interface{}{
+		"repository_names": names,
+		"properties": []githubPropertyValue{
+			{PropertyName: ownerProperty, Value: value(batch[0].OwnerTeam)},
+			{PropertyName: tierProperty, Value: value(batch[0].Tier)},
+		},
+	}
+}
+
+// fetchRepositoryOwnership computes the desired property values of every repository in the active scan (Orchestrator)
+func fetchRepositoryOwnership(ctx *gofr.Context, deps *AppDependencies, orgName string) ([]RepositoryPropertyValues, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildRepositoryOwnershipQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	desired := make([]RepositoryPropertyValues, 0, len(result.Records))
+	for _, record := range result.Records {
+		teams := groupOwnerRules(record["team_rules"])
+		desired = append(desired, RepositoryPropertyValues{
+			Repository: getStringFromMap(record, "repository"),
+			OwnerTeam:  selectOwnerTeam(teams),
+			Tier:       calculateOwnershipTier(teams, getIntFromMap(record, "user_rules")),
+		})
+	}
+	return desired, nil
+}
+
+// checkCustomPropertiesDefined verifies the organization defines the properties the sync writes
+func checkCustomPropertiesDefined(ctx *gofr.Context, orgName string, properties ...string) error {
+	var schema []struct {
+		PropertyName string `json:"property_name"`
+	}
+	resp, err := githubGet(ctx, fmt.Sprintf("orgs/%s/properties/schema", orgName), nil, buildGitHubRequestHeaders())
+	if err == nil {
+		err = decodeGitHubResponse(resp, "get_properties_schema", &schema)
+	}
+	if err != nil {
+		return err
+	}
+
+	defined := make(map[string]bool, len(schema))
+	for _, property := range schema {
+		defined[property.PropertyName] = true
+	}
+	for _, property := range properties {
+		if !defined[property] {
+			return CustomPropertyUndefinedError{Organization: orgName, Property: property}
+		}
+	}
+	return nil
+}
+
+// fetchCurrentPropertyValues lists the custom property values of every repository of an organization
+func fetchCurrentPropertyValues(ctx *gofr.Context, orgName string) ([]githubRepositoryPropertyValues, error) {
+	var repositories []githubRepositoryPropertyValues
+	for page := 1; ; page++ {
+		var values []githubRepositoryPropertyValues
+		resp, err := githubGet(ctx, fmt.Sprintf("orgs/%s/properties/values", orgName), map[string]any{
+			"per_page": customPropertiesPageSize,
+			"page":     page,
+		}, buildGitHubRequestHeaders())
+		if err == nil {
+			err = decodeGitHubResponse(resp, "list_property_values", &values)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		repositories = append(repositories, values...)
+		if len(values) < customPropertiesPageSize {
+			return repositories, nil
+		}
+	}
+}
+
+// syncCustomProperties writes each repository's owner team and ownership tier to its GitHub custom
+// properties, only updating repositories whose values changed (Orchestrator)
+func syncCustomProperties(ctx *gofr.Context, deps *AppDependencies, orgName string, dryRun bool) (CustomPropertiesSyncResponse, error) {
+	config := deps.currentConfig().CustomProperties
+	response := CustomPropertiesSyncResponse{
+		Organization:  orgName,
+		DryRun:        dryRun,
+		OwnerProperty: config.OwnerProperty,
+		TierProperty:  config.TierProperty,
+		Updated:       []RepositoryPropertyValues{},
+		Failed:        []string{},
+	}
+
+	desired, err := fetchRepositoryOwnership(ctx, deps, orgName)
+	if err != nil {
+		return CustomPropertiesSyncResponse{}, err
+	}
+	response.Repositories = len(desired)
+
+	if err := checkCustomPropertiesDefined(ctx, orgName, config.OwnerProperty, config.TierProperty); err != nil {
+		return CustomPropertiesSyncResponse{}, err
+	}
+	current, err := fetchCurrentPropertyValues(ctx, orgName)
+	if err != nil {
+		return CustomPropertiesSyncResponse{}, err
+	}
+
+	updates := planPropertyUpdates(desired, indexCurrentPropertyValues(current, config.OwnerProperty, config.TierProperty))
+	response.Unchanged = len(desired) - len(updates)
+	if dryRun {
+		response.Updated = updates
+		return response, nil
+	}
+
+	for _, batch := range groupPropertyUpdates(updates) {
+		response.Requests++
+		resp, err := githubWrite(ctx, http.MethodPatch, fmt.Sprintf("orgs/%s/properties/values", orgName), buildPropertyValuesPayload(batch, config.OwnerProperty, config.TierProperty))
+		if err == nil {
+			err = decodeGitHubResponse(resp, "update_property_values", nil)
+		}
+		if err != nil {
+			logWarn(ctx, "Failed to update repository custom properties", LogFields{
+				"component":    "custom_properties",
+				"operation":    "update_property_values",
+				"organization": orgName,
+				"repositories": len(batch),
+				"error":        err.Error(),
+			})
+			for _, values := range batch {
+				response.Failed = append(response.Failed, values.Repository)
+			}
+			continue
+		}
+		response.Updated = append(response.Updated, batch...)
+	}
+
+	logInfo(ctx, "Synced ownership to repository custom properties", LogFields{
+		"component":    "custom_properties",
+		"operation":    "sync_custom_properties",
+		"organization": orgName,
+		"repositories": response.Repositories,
+		"updated":      len(response.Updated),
+		"failed":       len(response.Failed),
+		"requests":     response.Requests,
+	})
+	return response, nil
+}
+
+// syncCustomPropertiesAfterScan syncs custom properties after a scan is published when enabled; a
+// failed sync is logged without failing the scan
+func syncCustomPropertiesAfterScan(ctx *gofr.Context, deps *AppDependencies, orgName string) {
+	if !deps.currentConfig().CustomProperties.SyncEnabled {
+		return
+	}
+
+	if _, err := syncCustomProperties(ctx, deps, orgName, false); err != nil {
+		logError(ctx, "Custom properties sync after scan failed", LogFields{
+			"component":    "custom_properties",
+			"operation":    "sync_after_scan",
+			"organization": orgName,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// handleSyncCustomProperties writes the active scan's owner teams and ownership tiers to the
+// organization's repository custom properties; dry_run=true only reports the changes
+func (h *AppHandler) handleSyncCustomProperties(ctx *gofr.Context) (interface{}, error) {
+	orgName := strings.TrimSpace(extractOrgParam(ctx))
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return syncCustomProperties(ctx, h.deps, orgName, parseBoolFromQuery(ctx, "dry_run", false))
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// cypherIdentifierPattern restricts labels, relationship types and property names that are interpolated into Cypher
+var cypherIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateCypherIdentifier checks that a label, relationship type or property name is safe to interpolate (Pure Core)
+func validateCypherIdentifier(kind, name string) error {
+	if !cypherIdentifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid Cypher %s %q: must start with a letter or underscore and contain only letters, digits and underscores", kind, name)
+	}
+	return nil
+}
+
+// quoteCypherIdentifier validates an identifier and backtick-quotes it for interpolation into a query (Pure Core)
+func quoteCypherIdentifier(kind, name string) string {
+	if err := validateCypherIdentifier(kind, name); err != nil {
+		panic(err.Error())
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// escapeCypherString escapes a value for use inside a single-quoted Cypher literal (Pure Core)
+func escapeCypherString(value string) string {
+	escaped := make([]rune, 0, len(value))
+	for _, c := range value {
+		if c == '\'' || c == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, c)
+	}
+	return string(escaped)
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Exit codes of the demo command
+const (
+	demoExitSuccess = 0
+	demoExitFailed  = 1
+	demoExitUsage   = 2
+)
+
+// demoOrganizationLogin is the login of the synthetic organization; it is not a valid GitHub
+// login so it can never collide with a scanned organization
+const demoOrganizationLogin = "overseer-demo--synthetic"
+
+// demoSeed keeps generated sample data identical between runs
+const demoSeed = 42
+
+// sampleDataSizes maps named sample sizes to repository counts
+var sampleDataSizes = map[string]int{"small": 10, "medium": 100, "large": 1000}
+
+// demoPaths are the CODEOWNERS patterns assigned in generated repositories
+var demoPaths = []string{"*", "/docs/", "/src/", "/api/", "/deploy/", "*.tf", "/.github/"}
+
+// DemoCommandOptions represents the flags of the demo command
+type DemoCommandOptions struct {
+	Repositories int
+	Wipe         bool
+}
+
+// SampleDataOptions controls the size and ownership distribution of generated sample data
+type SampleDataOptions struct {
+	Repositories int
+	// Teams defaults to one team per ten repositories when zero
+	Teams int
+	// PowerLaw concentrates ownership on a few teams and users, as in real organizations
+	PowerLaw bool
+}
+
+// SampleData is a synthetic organization with repositories, teams and CODEOWNERS files
+type SampleData struct {
+	Organization GitHubOrganization
+	Repositories []GitHubRepository
+	Teams        []GitHubTeam
+	Codeowners   []GitHubCodeowners
+}
+
+// DemoReport is the machine-readable result of a demo command run
+type DemoReport struct {
+	Organization        string `json:"organization"`
+	Action              string `json:"action"`
+	Success             bool   `json:"success"`
+	ScanID              string `json:"scan_id,omitempty"`
+	Repositories        int    `json:"repositories,omitempty"`
+	Teams               int    `json:"teams,omitempty"`
+	ReposWithCodeowners int    `json:"repos_with_codeowners,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
+
+// parseSampleDataSize parses a named size (small, medium, large) or a repository count (Pure Core)
+func parseSampleDataSize(value string) (int, error) {
+	if repositories, ok := sampleDataSizes[strings.ToLower(value)]; ok {
+		return repositories, nil
+	}
+
+	repositories, err := strconv.Atoi(value)
+	if err != nil || repositories <= 0 || repositories > sampleDataSizes["large"] {
+		return 0, fmt.Errorf("--sample-data must be small, medium, large or a repository count between 1 and %d", sampleDataSizes["large"])
+	}
+	return repositories, nil
+}
+
+// parseDemoCommandOptions parses the demo command flags (Pure Core)
+func parseDemoCommandOptions(args []string) (DemoCommandOptions, error) {
+	var options DemoCommandOptions
+	var size string
+
+	flags := flag.NewFlagSet("demo", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&size, "sample-data", "", "generate a synthetic organization: small, medium, large or a repository count")
+	flags.BoolVar(&options.Wipe, "wipe", false, "remove the synthetic organization")
+
+	if err := flags.Parse(args); err != nil {
+		return DemoCommandOptions{}, err
+	}
+	if options.Wipe == (size != "") {
+		return DemoCommandOptions{}, errors.New("exactly one of --sample-data or --wipe is required")
+	}
+	if options.Wipe {
+		return options, nil
+	}
+
+	repositories, err := parseSampleDataSize(size)
+	if err != nil {
+		return DemoCommandOptions{}, err
+	}
+	options.Repositories = repositories
+	return options, nil
+}
+
+// calculateGitBlobSHA returns the git blob SHA of content, as GitHub reports for files (Pure Core)
+func calculateGitBlobSHA(content string) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// newSampleOwnerPicker returns a function picking an index below n, uniformly or following a
+// power law where low indexes own most paths (Pure Core)
+func newSampleOwnerPicker(random *rand.Rand, n int, powerLaw bool) func() int {
+	if !powerLaw || n < 2 {
+		return func() int { return random.Intn(n) }
+	}
+	zipf := rand.NewZipf(random, 1.2, 1, uint64(n-1))
+	return func() int { return int(zipf.Uint64()) }
+}
+
+// buildSampleCodeowners renders a CODEOWNERS file assigning paths to teams and users (Pure Core)
+func buildSampleCodeowners(random *rand.Rand, teams []GitHubTeam, pickTeam, pickUser func() int) string {
+	var builder strings.Builder
+	builder.WriteString("# Synthetic sample data generated by overseer demo\n")
+
+	rules := 1 + random.Intn(len(demoPaths))
+	for i := 0; i < rules; i++ {
+		owners := []string{"@" + demoOrganizationLogin + "/" + teams[pickTeam()].Slug}
+		if random.Intn(3) == 0 {
+			owners = append(owners, fmt.Sprintf("@demo-user-%d", pickUser()+1))
+		}
+		fmt.Fprintf(&builder, "%s %s\n", demoPaths[i], strings.Join(owners, " "))
+	}
+	return builder.String()
+}
+
+// generateSampleData builds a deterministic synthetic organization; about three quarters of its
+// repositories have a CODEOWNERS file (Pure Core)
+func generateSampleData(options SampleDataOptions, now time.Time) SampleData {
+	random := rand.New(rand.NewSource(demoSeed))
+	repositories := options.Repositories
+	teamCount := options.Teams
+	if teamCount <= 0 {
+		teamCount = max(repositories/10, 3)
+	}
+	pickTeam := newSampleOwnerPicker(random, teamCount, options.PowerLaw)
+	pickUser := newSampleOwnerPicker(random, max(repositories/5, 5), options.PowerLaw)
+
+	data := SampleData{
+		Organization: GitHubOrganization{
+			Login:       demoOrganizationLogin,
+			Name:        "Overseer Demo",
+			Description: "Synthetic sample data; safe to wipe with `overseer demo --wipe`",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		},
+	}
+
+	for i := 1; i <= teamCount; i++ {
+		slug := fmt.Sprintf("demo-team-%d", i)
+		data.Teams = append(data.Teams, GitHubTeam{ID: i, Slug: slug, Name: fmt.Sprintf("Demo Team %d", i)})
+	}
+
+	languages := []string{"Go", "TypeScript", "Python", "Java", ""}
+	for i := 1; i <= repositories; i++ {
+		name := fmt.Sprintf("demo-repo-%d", i)
+		repo := GitHubRepository{
+			ID:         i,
+			Name:       name,
+			FullName:   demoOrganizationLogin + "/" + name,
+			Visibility: "private",
+			Private:    true,
+			Archived:   random.Intn(20) == 0,
+			Language:   languages[random.Intn(len(languages))],
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			PushedAt:   now,
+		}
+		data.Repositories = append(data.Repositories, repo)
+
+		if random.Intn(4) == 0 {
+			continue
+		}
+		content := buildSampleCodeowners(random, data.Teams, pickTeam, pickUser)
+		data.Codeowners = append(data.Codeowners, GitHubCodeowners{
+			Repository: repo.FullName,
+			Path:       ".github/CODEOWNERS",
+			SHA:        calculateGitBlobSHA(content),
+			Content:    content,
+			Rules:      parseCodeownersContent(base64.StdEncoding.EncodeToString([]byte(content))),
+		})
+	}
+
+	return data
+}
+
+// buildMarkSampleDataQuery builds a query labelling the synthetic organization (Pure Core)
+func buildMarkSampleDataQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		SET org:SampleData, org.synthetic = true
+		RETURN org.login AS organization
+	`
+}
+
+// buildSampleDataOrganizationQuery builds a query returning whether the organization is synthetic (Pure Core)
+func buildSampleDataOrganizationQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		RETURN coalesce(org.synthetic, false) AS synthetic
+	`
+}
+
+// loadSampleData stores a synthetic organization through the regular staging pipeline and labels it (Orchestrator)
+func loadSampleData(ctx *gofr.Context, deps *AppDependencies, options SampleDataOptions) DemoReport {
+	data := generateSampleData(options, time.Now().UTC())
+	report := DemoReport{
+		Organization:        demoOrganizationLogin,
+		Action:              "sample_data",
+		Repositories:        len(data.Repositories),
+		Teams:               len(data.Teams),
+		ReposWithCodeowners: len(data.Codeowners),
+	}
+
+	// Validation compares against the previous scan, which would hold a regenerated org of another size
+	outcome, err := storeOrganizationData(ctx, deps.Neo4jConn, deps.Config.Neo4j.Batch, ScanValidationConfig{}, nil,
+		data.Organization, data.Repositories, data.Teams, nil, data.Codeowners, nil)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.ScanID = outcome.ScanID
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	if _, err := executeNeo4jWrite(ctx, session, buildMarkSampleDataQuery(), map[string]interface{}{"orgName": demoOrganizationLogin}); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Success = true
+	return report
+}
+
+// wipeSampleData removes the synthetic organization, refusing to touch an organization that is not labelled synthetic (Orchestrator)
+func wipeSampleData(ctx *gofr.Context, deps *AppDependencies) DemoReport {
+	report := DemoReport{Organization: demoOrganizationLogin, Action: "wipe"}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildSampleDataOrganizationQuery(), map[string]interface{}{"orgName": demoOrganizationLogin})
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	if len(result.Records) == 0 {
+		report.Success = true
+		return report
+	}
+	if !getBoolFromMap(result.Records[0], "synthetic") {
+		report.Error = fmt.Sprintf("organization %s is not labelled as sample data; refusing to wipe it", demoOrganizationLogin)
+		return report
+	}
+
+	if _, err := deleteOrganizationSubgraph(ctx, session, demoOrganizationLogin); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Success = true
+	return report
+}
+
+// runDemoCommand generates or wipes a synthetic organization for demos and local development
+func runDemoCommand(args []string) int {
+	options, err := parseDemoCommandOptions(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nUsage: demo --sample-data <small|medium|large|count> | demo --wipe\n", err)
+		return demoExitUsage
+	}
+
+	ctx := context.Background()
+	deps, err := createAppDependencies(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create app dependencies: %v\n", err)
+		return demoExitFailed
+	}
+	defer func() {
+		if err := cleanupAppDependencies(ctx, deps); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to cleanup dependencies: %v\n", err)
+		}
+	}()
+
+	// GoFr routes commands from os.Args, so only the command name is left for it to match
+	os.Args = []string{os.Args[0], "demo"}
+
+	app := gofr.NewCMD()
+	var report DemoReport
+	app.SubCommand("demo", func(ctx *gofr.Context) (interface{}, error) {
+		if options.Wipe {
+			report = wipeSampleData(ctx, deps)
+		} else {
+			report = loadSampleData(ctx, deps, SampleDataOptions{Repositories: options.Repositories})
+		}
+		return nil, nil
+	})
+	app.Run()
+
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write demo report: %v\n", err)
+	}
+	if !report.Success {
+		return demoExitFailed
+	}
+	return demoExitSuccess
+}
@@ -0,0 +1,565 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Package ecosystems whose manifests link repositories of an organization
+const (
+	DependencyEcosystemGo  = "go"
+	DependencyEcosystemNpm = "npm"
+)
+
+// graphQLManifestBatch is the number of repositories whose manifests are read per GraphQL request
+const graphQLManifestBatch = 50
+
+// manifestLocations are the manifest files read at the root of each repository
+var manifestLocations = []struct {
+	alias string
+	path  string
+}{
+	{"gomod", "go.mod"},
+	{"npm", "package.json"},
+}
+
+// ManifestDependency is a package a repository's manifest depends on
+type ManifestDependency struct {
+	Ecosystem string
+	Name      string
+}
+
+// RepositoryManifest is the package a repository publishes and the packages it depends on
+type RepositoryManifest struct {
+	Repository   string
+	GoModule     string
+	NpmPackage   string
+	Dependencies []ManifestDependency
+}
+
+// RepositoryPackage is the Go module and npm package published by a repository of the scan
+type RepositoryPackage struct {
+	Repository string
+	GoModule   string
+	NpmPackage string
+}
+
+// RepositoryDependency is a DEPENDS_ON edge between two repositories of an organization
+type RepositoryDependency struct {
+	Repository string
+	DependsOn  string
+	Ecosystem  string
+	Dependency string
+}
+
+// UpstreamDependency is a repository another repository depends on, with its owners in the active scan
+type UpstreamDependency struct {
+	Repository string            `json:"repository"`
+	Ecosystem  string            `json:"ecosystem"`
+	Dependency string            `json:"dependency"`
+	Teams      []RepositoryOwner `json:"teams"`
+	Users      []RepositoryOwner `json:"users"`
+}
+
+// RepositoryDependenciesResponse lists the owners of a repository's upstream dependencies
+type RepositoryDependenciesResponse struct {
+	Organization string               `json:"organization"`
+	Repository   string               `json:"repository"`
+	Dependencies []UpstreamDependency `json:"dependencies"`
+}
+
+// RepositoryOwner is a team or user owner and the CODEOWNERS patterns assigning it
+type RepositoryOwner struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
+}
+
+// buildManifestBatchGraphQLQuery builds one query reading the manifests of a batch of repositories,
+// aliased r0, r1, ... in the order given (Pure Core)
+func buildManifestBatchGraphQLQuery(count int) string {
+	var declarations, fields strings.Builder
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			declarations.WriteString(", ")
+		}
+		fmt.Fprintf(&declarations, "$o%d: String!, $n%d: String!", i, i)
+		fmt.Fprintf(&fields, "  r%d: repository(owner: $o%d, name: $n%d) {\n", i, i, i)
+		for _, location := range manifestLocations {
+			fmt.Fprintf(&fields, "    %s: object(expression: \"HEAD:%s\") { ... on Blob { oid text } }\n", location.alias, location.path)
+		}
+		fields.WriteString("  }\n")
+	}
+	return "query(" + declarations.String() + ") {\n" + fields.String() + "}"
+}
+
+// buildStoreRepositoryPackagesQuery builds a batch query staging the packages published by repositories on
+// a scan until it is published; an empty package is staged as an empty string so publishing clears the stored one (Pure Core)
+func buildStoreRepositoryPackagesQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		UNWIND $rows AS row
+		MATCH (repo:Repository {full_name: row.repository})
+		MERGE (scan)-[staged:STAGES_PROPERTIES]->(repo)
+		SET staged.go_module = row.go_module,
+			staged.npm_package = row.npm_package
+	`
+}
+
+// buildScanRepositoryPackagesQuery builds a query returning the packages published by the repositories
+// of a staging scan, preferring the ones it staged (Pure Core)
+func buildScanRepositoryPackagesQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository)
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(:Scan {id: $scan_id})-[staged:STAGES_PROPERTIES]->(repo)
+		RETURN repo.full_name AS repository,
+			coalesce(staged.go_module, repo.go_module) AS go_module,
+			coalesce(staged.npm_package, repo.npm_package) AS npm_package
+	`
+}
+
+// buildBatchCreateDependenciesQuery builds a batch query linking repositories of a staging scan to
+// the repositories they depend on (Pure Core)
+func buildBatchCreateDependenciesQuery() string {
+	return `
+		UNWIND $rows AS row
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository {full_name: row.repository})
+		MATCH (org)-[:OWNS {scan_id: $scan_id}]->(target:Repository {full_name: row.depends_on})
+		MERGE (repo)-[r:DEPENDS_ON {scan_id: $scan_id}]->(target)
+		SET r.ecosystem = row.ecosystem, r.dependency = row.dependency
+	`
+}
+
+// buildUpstreamDependenciesQuery builds a query returning the repositories a repository depends on in
+// the active scan, with their owners (Pure Core)
+func buildUpstreamDependenciesQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository {full_name: $fullName})
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[dep:DEPENDS_ON]->(upstream:Repository)
+		WHERE coalesce(dep.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (upstream)-[team_owner:HAS_TEAM_OWNER]->(team:Team)
+		WHERE coalesce(team_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, dep, upstream,
+			collect(CASE WHEN team IS NULL THEN NULL ELSE {name: team.slug, pattern: team_owner.pattern} END) AS team_rules
+		OPTIONAL MATCH (upstream)-[user_owner:HAS_CODEOWNER]->(user:User)
+		WHERE coalesce(user_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH repo, dep, upstream, team_rules,
+			collect(CASE WHEN user IS NULL THEN NULL ELSE {name: user.login, pattern: user_owner.pattern} END) AS user_rules
+		RETURN repo.full_name AS repository,
+			upstream.full_name AS upstream,
+			dep.ecosystem AS ecosystem,
+			dep.dependency AS dependency,
+			team_rules,
+			user_rules
+		ORDER BY upstream
+	`
+}
+
+// parseGoModManifest reads the module path and required modules of a go.mod file (Pure Core)
+func parseGoModManifest(content string) (string, []ManifestDependency) {
+	module := ""
+	dependencies := []ManifestDependency{}
+	inRequire := false
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			dependencies = append(dependencies, ManifestDependency{Ecosystem: DependencyEcosystemGo, Name: fields[0]})
+		case fields[0] == "module" && len(fields) > 1:
+			module = strings.Trim(fields[1], `"`)
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequire = true
+		case fields[0] == "require" && len(fields) > 1:
+			dependencies = append(dependencies, ManifestDependency{Ecosystem: DependencyEcosystemGo, Name: fields[1]})
+		}
+	}
+	return module, dependencies
+}
+
+// parsePackageJSONManifest reads the package name and every declared dependency of a package.json
+// file, sorted by name (Pure Core)
+func parsePackageJSONManifest(content string) (string, []ManifestDependency) {
+	var manifest struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return "", []ManifestDependency{}
+	}
+
+	seen := make(map[string]bool)
+	for _, group := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		for name := range group {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make([]ManifestDependency, 0, len(names))
+	for _, name := range names {
+		dependencies = append(dependencies, ManifestDependency{Ecosystem: DependencyEcosystemNpm, Name: name})
+	}
+	return manifest.Name, dependencies
+}
+
+// buildRepositoryManifest combines the manifest files read from a repository, keyed by location path (Pure Core)
+func buildRepositoryManifest(fullName string, files map[string]string) RepositoryManifest {
+	manifest := RepositoryManifest{Repository: fullName, Dependencies: []ManifestDependency{}}
+	if content, ok := files["go.mod"]; ok {
+		module, dependencies := parseGoModManifest(content)
+		manifest.GoModule = module
+		manifest.Dependencies = append(manifest.Dependencies, dependencies...)
+	}
+	if content, ok := files["package.json"]; ok {
+		name, dependencies := parsePackageJSONManifest(content)
+		manifest.NpmPackage = name
+		manifest.Dependencies = append(manifest.Dependencies, dependencies...)
+	}
+	return manifest
+}
+
+// resolveGoDependency returns the repository publishing a Go module: the repository declaring the
+// longest matching module path, or github.com/{org}/{repo} for repositories without a go.mod (Pure Core)
+func resolveGoDependency(orgLogin, module string, modules map[string]string, repositories map[string]string) string {
+	best, bestLength := "", 0
+	for path, repository := range modules {
+		if (module == path || strings.HasPrefix(module, path+"/")) && len(path) > bestLength {
+			best, bestLength = repository, len(path)
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	prefix := "github.com/" + strings.ToLower(orgLogin) + "/"
+	if !strings.HasPrefix(strings.ToLower(module), prefix) {
+		return ""
+	}
+	name, _, _ := strings.Cut(module[len(prefix):], "/")
+	return repositories[strings.ToLower(orgLogin+"/"+name)]
+}
+
+// resolveRepositoryDependencies links the dependencies of fetched manifests to the repositories of the
+// organization publishing them, one edge per pair of repositories; packages lists every repository of
+// the scan (Pure Core)
+func resolveRepositoryDependencies(orgLogin string, manifests []RepositoryManifest, packages []RepositoryPackage) []RepositoryDependency {
+	modules := make(map[string]string)
+	npmPackages := make(map[string]string)
+	repositories := make(map[string]string)
+	for _, pkg := range packages {
+		repositories[strings.ToLower(pkg.Repository)] = pkg.Repository
+		if pkg.GoModule != "" {
+			modules[pkg.GoModule] = pkg.Repository
+		}
+		if pkg.NpmPackage != "" {
+			npmPackages[pkg.NpmPackage] = pkg.Repository
+		}
+	}
+
+	dependencies := []RepositoryDependency{}
+	for _, manifest := range manifests {
+		linked := make(map[string]bool)
+		for _, dependency := range manifest.Dependencies {
+			target := ""
+			switch dependency.Ecosystem {
+			case DependencyEcosystemGo:
+				target = resolveGoDependency(orgLogin, dependency.Name, modules, repositories)
+			case DependencyEcosystemNpm:
+				target = npmPackages[dependency.Name]
+			}
+			if target == "" || strings.EqualFold(target, manifest.Repository) || linked[target] {
+				continue
+			}
+			linked[target] = true
+			dependencies = append(dependencies, RepositoryDependency{
+				Repository: manifest.Repository,
+				DependsOn:  target,
+				Ecosystem:  dependency.Ecosystem,
+				Dependency: dependency.Name,
+			})
+		}
+	}
+	return dependencies
+}
+
+// groupOwnerRules groups owner rules by owner, keeping first-seen order and distinct patterns (Pure Core)
+func groupOwnerRules(rules interface{}) []RepositoryOwner {
+	owners := []RepositoryOwner{}
+	index := make(map[string]int)
+
+	list, _ := rules.([]interface{})
+	for _, item := range list {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := getStringFromMap(rule, "name")
+		position, exists := index[name]
+		if !exists {
+			position = len(owners)
+			index[name] = position
+			owners = append(owners, RepositoryOwner{Name: name, Patterns: []string{}})
+		}
+
+		pattern := getStringFromMap(rule, "pattern")
+		if pattern != "" && !lo.Contains(owners[position].Patterns, pattern) {
+			owners[position].Patterns = append(owners[position].Patterns, pattern)
+		}
+	}
+
+	return owners
+}
+
+// convertToUpstreamDependencies converts upstream dependency records, skipping the empty record of a
+// repository without dependencies (Pure Core)
+func convertToUpstreamDependencies(records []map[string]interface{}) []UpstreamDependency {
+	dependencies := []UpstreamDependency{}
+	for _, record := range records {
+		upstream := getStringFromMap(record, "upstream")
+		if upstream == "" {
+			continue
+		}
+		dependencies = append(dependencies, UpstreamDependency{
+			Repository: upstream,
+			Ecosystem:  getStringFromMap(record, "ecosystem"),
+			Dependency: getStringFromMap(record, "dependency"),
+			Teams:      groupOwnerRules(record["team_rules"]),
+			Users:      groupOwnerRules(record["user_rules"]),
+		})
+	}
+	return dependencies
+}
+
+// fetchManifestFile reads a manifest file at the root of a repository through REST, returning false
+// when the repository has none
+func fetchManifestFile(ctx *gofr.Context, fullName, path string) (string, bool, error) {
+	resp, err := githubGet(ctx, fmt.Sprintf("repos/%s/contents/%s", fullName, path), nil, buildGitHubRequestHeaders())
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	logRateLimitInfo(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, newGitHubAPIError("manifest_fetch_failed", "failed to read manifest", fullName+"/"+path, resp.StatusCode)
+	}
+
+	var file struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", false, fmt.Errorf("failed to decode %s of %s: %w", path, fullName, err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decode %s of %s: %w", path, fullName, err)
+	}
+	return string(decoded), true, nil
+}
+
+// fetchManifestsGraphQL reads the manifests of repositories through GraphQL, 50 repositories per request
+func fetchManifestsGraphQL(ctx *gofr.Context, repos []GitHubRepository) ([]RepositoryManifest, error) {
+	manifests := make([]RepositoryManifest, 0, len(repos))
+
+	for start := 0; start < len(repos); start += graphQLManifestBatch {
+		reportScanBatchProgress(ctx, start, len(repos))
+		batch := repos[start:min(start+graphQLManifestBatch, len(repos))]
+
+		variables := make(map[string]interface{}, 2*len(batch))
+		for i, repo := range batch {
+			owner, name := parseRepositoryFullName(repo.FullName)
+			variables[fmt.Sprintf("o%d", i)] = owner
+			variables[fmt.Sprintf("n%d", i)] = name
+		}
+
+		data := map[string]map[string]*graphQLBlob{}
+		partial, err := executeGitHubGraphQL(ctx, buildManifestBatchGraphQLQuery(len(batch)), variables, &data)
+		if err != nil {
+			return nil, err
+		}
+		if len(partial) > 0 {
+			logWarn(ctx, "Some manifests could not be read through GraphQL", LogFields{
+				"component":   "dependency_analysis",
+				"operation":   "fetch_manifests_graphql",
+				"batch_start": start,
+				"errors":      len(partial),
+				"first_error": partial[0].Message,
+			})
+		}
+
+		for i, repo := range batch {
+			files := make(map[string]string)
+			for _, location := range manifestLocations {
+				if blob := data[fmt.Sprintf("r%d", i)][location.alias]; blob != nil {
+					files[location.path] = blob.Text
+				}
+			}
+			manifests = append(manifests, buildRepositoryManifest(repo.FullName, files))
+		}
+	}
+
+	return manifests, nil
+}
+
+// fetchRepositoryManifests reads the go.mod and package.json manifests of repositories; repositories
+// whose manifests cannot be read through REST are skipped
+func fetchRepositoryManifests(ctx *gofr.Context, repos []GitHubRepository) ([]RepositoryManifest, error) {
+	if _, useGraphQL := currentGitHubGraphQL(); useGraphQL {
+		return fetchManifestsGraphQL(ctx, repos)
+	}
+
+	manifests := make([]RepositoryManifest, 0, len(repos))
+	for i, repo := range repos {
+		reportScanBatchProgress(ctx, i, len(repos))
+		files := make(map[string]string)
+		for _, location := range manifestLocations {
+			content, found, err := fetchManifestFile(ctx, repo.FullName, location.path)
+			if err != nil {
+				logWarn(ctx, "Failed to read repository manifest", LogFields{
+					"component":  "dependency_analysis",
+					"operation":  "fetch_manifest",
+					"repository": repo.FullName,
+					"path":       location.path,
+					"error":      err.Error(),
+				})
+				continue
+			}
+			if found {
+				files[location.path] = content
+			}
+		}
+		manifests = append(manifests, buildRepositoryManifest(repo.FullName, files))
+	}
+	return manifests, nil
+}
+
+// storeRepositoryDependencies records the packages published by the fetched repositories, then links
+// each of them to the repositories of the staging scan it depends on (Orchestrator)
+func storeRepositoryDependencies(ctx context.Context, session *Neo4jSession, batch Neo4jBatchConfig, orgLogin, scanID string, manifests []RepositoryManifest) error {
+	params := map[string]interface{}{"org_login": orgLogin, "scan_id": scanID}
+	packages := newNeo4jBatchWriter(session, "repository_packages", buildStoreRepositoryPackagesQuery(), params, batch)
+	for _, manifest := range manifests {
+		err := packages.add(ctx, map[string]interface{}{
+			"repository":  manifest.Repository,
+			"go_module":   manifest.GoModule,
+			"npm_package": manifest.NpmPackage,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store packages of %s: %w", manifest.Repository, err)
+		}
+	}
+	if err := packages.flush(ctx); err != nil {
+		return err
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildScanRepositoryPackagesQuery(), params)
+	if err != nil {
+		return fmt.Errorf("failed to read repository packages: %w", err)
+	}
+	published := make([]RepositoryPackage, 0, len(result.Records))
+	for _, record := range result.Records {
+		published = append(published, RepositoryPackage{
+			Repository: getStringFromMap(record, "repository"),
+			GoModule:   getStringFromMap(record, "go_module"),
+			NpmPackage: getStringFromMap(record, "npm_package"),
+		})
+	}
+
+	dependencies := resolveRepositoryDependencies(orgLogin, manifests, published)
+	edges := newNeo4jBatchWriter(session, "repository_dependencies", buildBatchCreateDependenciesQuery(), params, batch)
+	for _, dependency := range dependencies {
+		err := edges.add(ctx, map[string]interface{}{
+			"repository": dependency.Repository,
+			"depends_on": dependency.DependsOn,
+			"ecosystem":  dependency.Ecosystem,
+			"dependency": dependency.Dependency,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store dependencies of %s: %w", dependency.Repository, err)
+		}
+	}
+	if err := edges.flush(ctx); err != nil {
+		return err
+	}
+
+	logInfo(session.ctx, "Stored repository dependencies", LogFields{
+		"component":    "dependency_analysis",
+		"operation":    "store_dependencies",
+		"organization": orgLogin,
+		"scan_id":      scanID,
+		"manifests":    len(manifests),
+		"dependencies": len(dependencies),
+	})
+	return nil
+}
+
+// getUpstreamDependencies retrieves the repositories a repository depends on and their owners (Orchestrator)
+func getUpstreamDependencies(ctx *gofr.Context, deps *AppDependencies, orgName, repoName string) (RepositoryDependenciesResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return RepositoryDependenciesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	fullName := orgName + "/" + repoName
+	result, err := executeNeo4jReadQuery(ctx, session, buildUpstreamDependenciesQuery(), map[string]interface{}{
+		"orgName":  orgName,
+		"fullName": fullName,
+	})
+	if err != nil {
+		return RepositoryDependenciesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	if len(result.Records) == 0 {
+		return RepositoryDependenciesResponse{}, &gofrhttp.ErrorEntityNotFound{
+			Name:  "repository",
+			Value: fullName,
+		}
+	}
+
+	return RepositoryDependenciesResponse{
+		Organization: orgName,
+		Repository:   getStringFromMap(result.Records[0], "repository"),
+		Dependencies: convertToUpstreamDependencies(result.Records),
+	}, nil
+}
+
+// handleGetRepositoryDependencies returns the owners of the repositories a repository depends on
+func (h *AppHandler) handleGetRepositoryDependencies(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	repoName := ctx.PathParam("repo")
+	if repoName == "" {
+		return nil, createMissingParamError("repo")
+	}
+
+	return getUpstreamDependencies(ctx, h.deps, orgName, repoName)
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
 )
 
 // ErrorType represents different types of errors in the system
@@ -20,6 +24,15 @@ const (
 	ErrorTypeExternal       ErrorType = "external"
 )
 
+// ErrorCategory groups errors by who can fix them: the caller, an external dependency or this service
+type ErrorCategory string
+
+const (
+	ErrorCategoryValidation ErrorCategory = "validation"
+	ErrorCategoryExternal   ErrorCategory = "external"
+	ErrorCategoryInternal   ErrorCategory = "internal"
+)
+
 // ErrorSeverity represents the severity level of an error
 type ErrorSeverity string
 
@@ -31,18 +44,27 @@ const (
 	SeverityInfo     ErrorSeverity = "info"
 )
 
-// AppError represents a structured application error
+// AppError represents a structured application error; Neo4jError and GitHubAPIError embed it so
+// every database and GitHub failure carries a category, a retry-safety flag and an HTTP status
 type AppError struct {
-	Type        ErrorType     `json:"type"`
-	Severity    ErrorSeverity `json:"severity"`
-	Message     string        `json:"message"`
-	Details     string        `json:"details,omitempty"`
-	Code        string        `json:"code"`
-	Context     string        `json:"context,omitempty"`
-	Timestamp   time.Time     `json:"timestamp"`
-	Recoverable bool          `json:"recoverable"`
-	RetryAfter  time.Duration `json:"retry_after,omitempty"`
-	Cause       error         `json:"-"`
+	Type       ErrorType     `json:"type"`
+	Category   ErrorCategory `json:"category"`
+	Severity   ErrorSeverity `json:"severity"`
+	Message    string        `json:"message"`
+	Details    string        `json:"details,omitempty"`
+	Code       string        `json:"code"`
+	Context    string        `json:"context,omitempty"`
+	Timestamp  time.Time     `json:"timestamp"`
+	Retryable  bool          `json:"retryable"`
+	RetryAfter time.Duration `json:"retry_after,omitempty"`
+	HTTPStatus int           `json:"http_status,omitempty"`
+	Cause      error         `json:"-"`
+}
+
+// appErrorCarrier is implemented by AppError and every error type embedding it
+type appErrorCarrier interface {
+	error
+	appError() AppError
 }
 
 // Error implements the error interface
@@ -58,4 +80,82 @@ func (e AppError) Unwrap() error {
 	return e.Cause
 }
 
+// StatusCode returns the HTTP status of the error, derived from its category when not set
+func (e AppError) StatusCode() int {
+	if e.HTTPStatus != 0 {
+		return e.HTTPStatus
+	}
+	return categoryStatusCode(e.Category)
+}
+
+// appError returns the taxonomy fields of an error embedding AppError
+func (e AppError) appError() AppError {
+	return e
+}
+
+// categoryStatusCode maps an error category to its default HTTP status (Pure Core)
+func categoryStatusCode(category ErrorCategory) int {
+	switch category {
+	case ErrorCategoryValidation:
+		return http.StatusBadRequest
+	case ErrorCategoryExternal:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
 
+// newAppError creates an error of a category, stamped with the current time (Pure Core)
+func newAppError(category ErrorCategory, errorType ErrorType, code, message, details string) AppError {
+	return AppError{
+		Type:      errorType,
+		Category:  category,
+		Severity:  SeverityMedium,
+		Message:   message,
+		Details:   details,
+		Code:      code,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// createValidationError creates a non-retryable error rejecting the caller's input with 400 (Pure Core)
+func createValidationError(code, message, details string) AppError {
+	err := newAppError(ErrorCategoryValidation, ErrorTypeValidation, code, message, details)
+	err.Severity = SeverityLow
+	err.HTTPStatus = http.StatusBadRequest
+	return err
+}
+
+// classifyError returns the taxonomy of any error: errors embedding AppError carry their own, GoFr
+// request errors are validation errors, timeouts are retryable and anything else is internal (Pure Core)
+func classifyError(err error) AppError {
+	var carrier appErrorCarrier
+	if errors.As(err, &carrier) {
+		return carrier.appError()
+	}
+
+	var timeout *gofrhttp.ErrorRequestTimeout
+	if errors.As(err, &timeout) {
+		classified := newAppError(ErrorCategoryExternal, ErrorTypeTimeout, "TIMEOUT_ERROR", "request timed out", err.Error())
+		classified.Retryable = true
+		classified.HTTPStatus = timeout.StatusCode()
+		return classified
+	}
+
+	classified := newAppError(ErrorCategoryInternal, ErrorTypeInternal, "INTERNAL_ERROR", "unclassified error", err.Error())
+	var coded interface{ StatusCode() int }
+	if errors.As(err, &coded) {
+		classified.HTTPStatus = coded.StatusCode()
+		if classified.HTTPStatus >= http.StatusBadRequest && classified.HTTPStatus < http.StatusInternalServerError {
+			classified.Category = ErrorCategoryValidation
+			classified.Type = ErrorTypeValidation
+		}
+	}
+	classified.Cause = err
+	return classified
+}
+
+// isRetryableError reports whether repeating the failed operation may succeed (Pure Core)
+func isRetryableError(err error) bool {
+	return err != nil && classifyError(err).Retryable
+}
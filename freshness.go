@@ -0,0 +1,154 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Freshness states reported for organization data
+const (
+	FreshnessFresh   = "fresh"
+	FreshnessStale   = "stale"
+	FreshnessUnknown = "unknown"
+)
+
+// freshnessCheckSchedule is the cron schedule of the background SLA check
+const freshnessCheckSchedule = "*/5 * * * *"
+
+// DataFreshness describes how stale an organization's data is compared to the freshness SLA
+type DataFreshness struct {
+	Status             string  `json:"status"`
+	LastSuccessfulScan string  `json:"last_successful_scan,omitempty"`
+	AgeSeconds         float64 `json:"age_seconds,omitempty"`
+	SLASeconds         float64 `json:"sla_seconds"`
+}
+
+// FreshnessSummary summarizes data freshness across all organizations for the health endpoint
+type FreshnessSummary struct {
+	SLASeconds    float64  `json:"sla_seconds"`
+	Organizations int      `json:"organizations"`
+	Stale         []string `json:"stale"`
+}
+
+// evaluateDataFreshness compares the last successful scan against the SLA (Pure Core)
+func evaluateDataFreshness(lastSuccessfulScan string, sla time.Duration, now time.Time) DataFreshness {
+	freshness := DataFreshness{
+		Status:     FreshnessUnknown,
+		SLASeconds: sla.Seconds(),
+	}
+
+	scannedAt, err := time.Parse(time.RFC3339, lastSuccessfulScan)
+	if err != nil {
+		return freshness
+	}
+
+	age := now.Sub(scannedAt)
+	freshness.LastSuccessfulScan = lastSuccessfulScan
+	freshness.AgeSeconds = age.Round(time.Second).Seconds()
+	freshness.Status = FreshnessFresh
+	if age > sla {
+		freshness.Status = FreshnessStale
+	}
+
+	return freshness
+}
+
+// buildFreshnessQuery builds a query returning the last successful scan of every organization (Pure Core)
+func buildFreshnessQuery() string {
+	return `
+		MATCH (org:Organization)
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: org.active_scan_id})
+		RETURN org.login AS organization,
+			scan.activated_at AS last_successful_scan
+		ORDER BY organization
+	`
+}
+
+// fetchOrganizationFreshness evaluates the freshness of every organization
+func fetchOrganizationFreshness(ctx *gofr.Context, deps *AppDependencies) (map[string]DataFreshness, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, err
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildFreshnessQuery(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	freshness := make(map[string]DataFreshness, len(result.Records))
+	for _, record := range result.Records {
+		org := getStringFromMap(record, "organization")
+		freshness[org] = evaluateDataFreshness(getStringFromMap(record, "last_successful_scan"), deps.currentConfig().Freshness.SLA, now)
+	}
+
+	return freshness, nil
+}
+
+// summarizeFreshness builds the health endpoint summary from per-organization freshness (Pure Core)
+func summarizeFreshness(freshness map[string]DataFreshness, sla time.Duration) FreshnessSummary {
+	summary := FreshnessSummary{
+		SLASeconds:    sla.Seconds(),
+		Organizations: len(freshness),
+		Stale:         []string{},
+	}
+
+	for org, state := range freshness {
+		if state.Status != FreshnessFresh {
+			summary.Stale = append(summary.Stale, org)
+		}
+	}
+	sort.Strings(summary.Stale)
+
+	return summary
+}
+
+// checkFreshnessSLAs records freshness metrics and raises alerts for organizations breaching the SLA
+func checkFreshnessSLAs(ctx *gofr.Context, deps *AppDependencies) {
+	freshness, err := fetchOrganizationFreshness(ctx, deps)
+	if err != nil {
+		logError(ctx, "Failed to evaluate data freshness", LogFields{
+			"component": "freshness",
+			"operation": "check_sla",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	for org, state := range freshness {
+		labels := MetricLabels{"organization": org, "status": state.Status}
+		metrics.recordGauge("data_freshness_age_seconds", state.AgeSeconds, labels)
+
+		if state.Status == FreshnessFresh {
+			continue
+		}
+
+		metrics.recordCounter("data_freshness_sla_breaches_total", 1, labels)
+		logWarn(ctx, "Data freshness SLA breached", LogFields{
+			"component":            "freshness",
+			"operation":            "check_sla",
+			"alert":                "data_freshness_sla_breach",
+			"organization":         org,
+			"status":               state.Status,
+			"last_successful_scan": state.LastSuccessfulScan,
+			"age_seconds":          state.AgeSeconds,
+			"sla_seconds":          state.SLASeconds,
+		})
+	}
+}
+
+// registerFreshnessCheck schedules the periodic freshness SLA check
+func registerFreshnessCheck(app *gofr.App, deps *AppDependencies) {
+	app.AddCronJob(freshnessCheckSchedule, "data-freshness-sla", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "data_freshness_sla", func() {
+			runAsLeader(ctx, deps, "data_freshness_sla", func() {
+				checkFreshnessSLAs(ctx, deps)
+			})
+		})
+	})
+}
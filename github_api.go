@@ -39,9 +39,9 @@ type GitHubGraphQLResponse struct {
 
 // GitHubGraphQLError represents a GraphQL error
 type GitHubGraphQLError struct {
-	Message string   `json:"message"`
-	Type    string   `json:"type"`
-	Path    []string `json:"path,omitempty"`
+	Message string        `json:"message"`
+	Type    string        `json:"type"`
+	Path    []interface{} `json:"path,omitempty"`
 }
 
 // GitHubOrganization represents a GitHub organization
@@ -56,6 +56,8 @@ type GitHubOrganization struct {
 	Followers   int       `json:"followers"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Provider is the source code host the organization was scanned from
+	Provider string `json:"provider,omitempty"`
 }
 
 // GitHubRepository represents a GitHub repository
@@ -66,9 +68,14 @@ type GitHubRepository struct {
 	Description string    `json:"description"`
 	URL         string    `json:"url"`
 	Private     bool      `json:"private"`
+	Visibility  string    `json:"visibility"`
+	Archived    bool      `json:"archived"`
+	Fork        bool      `json:"fork"`
+	Language    string    `json:"language"`
 	Topics      []string  `json:"topics"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	PushedAt    time.Time `json:"pushed_at"`
 }
 
 // GitHubUser represents a GitHub user
@@ -78,17 +85,21 @@ type GitHubUser struct {
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
 	URL       string    `json:"url"`
+	Type      string    `json:"type"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // GitHubTeam represents a GitHub team
 type GitHubTeam struct {
-	ID          int    `json:"id"`
-	Slug        string `json:"slug"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	URL         string `json:"url"`
+	ID          int      `json:"id"`
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Members     []string `json:"members,omitempty"`
+	// Privacy is secret for teams only their members and organization owners can see, closed otherwise
+	Privacy string `json:"privacy,omitempty"`
 }
 
 // GitHubTopic represents a GitHub repository topic
@@ -100,6 +111,9 @@ type GitHubTopic struct {
 // GitHubCodeowners represents CODEOWNERS file content
 type GitHubCodeowners struct {
 	Repository string                  `json:"repository"`
+	Path       string                  `json:"path,omitempty"`
+	SHA        string                  `json:"sha,omitempty"`
+	Content    string                  `json:"-"`
 	Rules      []GitHubCodeownersRule  `json:"rules"`
 	Errors     []GitHubCodeownersError `json:"errors"`
 }
@@ -109,6 +123,14 @@ type GitHubCodeownersRule struct {
 	Pattern string   `json:"pattern"`
 	Owners  []string `json:"owners"`
 	Line    int      `json:"line"`
+	// Source and SourceLine locate rules read from a nested CODEOWNERS file, whose patterns are scoped
+	// to its directory and whose Line continues the numbering of the rules before them
+	Source     string `json:"source,omitempty"`
+	SourceLine int    `json:"source_line,omitempty"`
+	// Section, Optional and Approvals carry the GitLab CODEOWNERS section a rule belongs to
+	Section   string `json:"section,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+	Approvals int    `json:"approvals,omitempty"`
 }
 
 // GitHubCodeownersError represents a CODEOWNERS parsing error
@@ -119,10 +141,26 @@ type GitHubCodeownersError struct {
 
 // GitHubAPIError represents GitHub API errors that implement GoFr error patterns
 type GitHubAPIError struct {
-	Code       string
-	Message    string
-	Details    string
-	HTTPStatus int
+	AppError
+}
+
+// newGitHubAPIError creates an external error answered with status; rate limits and GitHub server
+// failures are safe to retry (Pure Core)
+func newGitHubAPIError(code, message, details string, status int) GitHubAPIError {
+	err := newAppError(ErrorCategoryExternal, ErrorTypeExternal, code, message, details)
+	err.HTTPStatus = status
+	switch {
+	case status == http.StatusTooManyRequests:
+		err.Type = ErrorTypeRateLimit
+		err.Retryable = true
+	case status == http.StatusNotFound:
+		err.Type = ErrorTypeNotFound
+	case status == http.StatusUnauthorized:
+		err.Type = ErrorTypeAuthentication
+	case status >= http.StatusInternalServerError:
+		err.Retryable = true
+	}
+	return GitHubAPIError{AppError: err}
 }
 
 // Error implements the error interface for GitHubAPIError
@@ -130,14 +168,6 @@ func (e GitHubAPIError) Error() string {
 	return fmt.Sprintf("GitHub API error [%s]: %s - %s", e.Code, e.Message, e.Details)
 }
 
-// StatusCode returns the HTTP status code for the error
-func (e GitHubAPIError) StatusCode() int {
-	if e.HTTPStatus != 0 {
-		return e.HTTPStatus
-	}
-	return http.StatusInternalServerError
-}
-
 // collectTopicsFromRepositories collects all unique topics from repositories with their counts (Pure Core)
 func collectTopicsFromRepositories(repos []GitHubRepository) []GitHubTopic {
 	topicCounts := make(map[string]int)
@@ -188,6 +218,9 @@ func getIntFromMap(m map[string]interface{}, key string) int {
 		if i, ok := value.(int); ok {
 			return i
 		}
+		if i, ok := value.(int64); ok {
+			return int(i)
+		}
 		if f, ok := value.(float64); ok {
 			return int(f)
 		}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// githubAppJWTLifetime is how long an app JWT is valid; GitHub allows at most ten minutes
+const githubAppJWTLifetime = 9 * time.Minute
+
+// githubAppClockSkew backdates the JWT issue time to tolerate clock drift with GitHub
+const githubAppClockSkew = time.Minute
+
+// githubAppTokenRefreshMargin is how long before expiry an installation token is replaced
+const githubAppTokenRefreshMargin = 5 * time.Minute
+
+// GitHubAppTokenSource mints and caches installation access tokens of a GitHub App
+type GitHubAppTokenSource struct {
+	mu             sync.Mutex
+	baseURL        string
+	userAgent      string
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	client         *http.Client
+	token          string
+	expiresAt      time.Time
+}
+
+// githubTokenSource is the GitHub App token source used by buildGitHubRequestHeaders, nil when
+// the service authenticates with a personal access token
+var (
+	githubTokenSourceMu sync.RWMutex
+	githubTokenSource   *GitHubAppTokenSource
+)
+
+// isGitHubAppConfigured reports whether any GitHub App credential is configured (Pure Core)
+func isGitHubAppConfigured(config GitHubConfig) bool {
+	return config.AppID != 0 || config.AppInstallationID != 0 || config.AppPrivateKey != "" || config.AppPrivateKeyPath != ""
+}
+
+// parseGitHubAppPrivateKey parses a PKCS#1 or PKCS#8 PEM encoded RSA private key (Pure Core)
+func parseGitHubAppPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// loadGitHubAppPrivateKey reads the private key from its inline PEM value or from a file
+func loadGitHubAppPrivateKey(inline, path string) (*rsa.PrivateKey, error) {
+	pemData := []byte(strings.ReplaceAll(inline, `\n`, "\n"))
+	if inline == "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+		}
+		pemData = data
+	}
+	return parseGitHubAppPrivateKey(pemData)
+}
+
+// buildGitHubAppJWT builds the RS256 JWT a GitHub App uses to request installation tokens (Pure Core)
+func buildGitHubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	encode := func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	}
+
+	header, err := encode(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := encode(map[string]interface{}{
+		"iat": now.Add(-githubAppClockSkew).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + claims
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// needsGitHubTokenRefresh reports whether a cached installation token is missing or about to expire (Pure Core)
+func needsGitHubTokenRefresh(token string, expiresAt, now time.Time) bool {
+	return token == "" || !now.Add(githubAppTokenRefreshMargin).Before(expiresAt)
+}
+
+// newGitHubAppTokenSource creates a token source for a GitHub App installation
+func newGitHubAppTokenSource(config GitHubServiceConfig) (*GitHubAppTokenSource, error) {
+	if config.AppID == 0 || config.AppInstallationID == 0 {
+		return nil, errors.New("GitHub App authentication needs both an app ID and an installation ID")
+	}
+
+	key, err := loadGitHubAppPrivateKey(config.AppPrivateKey, config.AppPrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubAppTokenSource{
+		baseURL:        strings.TrimSuffix(config.BaseURL, "/"),
+		userAgent:      config.UserAgent,
+		appID:          config.AppID,
+		installationID: config.AppInstallationID,
+		privateKey:     key,
+		client:         &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Token returns a valid installation token, exchanging a fresh app JWT when the cached token nears expiry
+func (s *GitHubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if !needsGitHubTokenRefresh(s.token, s.expiresAt, now) {
+		return s.token, nil
+	}
+
+	jwt, err := buildGitHubAppJWT(s.appID, s.privateKey, now)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.baseURL, s.installationID)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid GitHub base URL: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+jwt)
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("User-Agent", s.userAgent)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to request GitHub App installation token: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("GitHub App installation token request returned status %d", response.StatusCode)
+	}
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode GitHub App installation token: %w", err)
+	}
+
+	s.token, s.expiresAt = body.Token, body.ExpiresAt
+	return s.token, nil
+}
+
+// setGitHubTokenSource installs the GitHub App token source used for API requests
+func setGitHubTokenSource(source *GitHubAppTokenSource) {
+	githubTokenSourceMu.Lock()
+	defer githubTokenSourceMu.Unlock()
+	githubTokenSource = source
+}
+
+// currentGitHubToken returns the installation token when GitHub App authentication is enabled,
+// falling back to the GITHUB_TOKEN personal access token
+func currentGitHubToken(ctx context.Context) (string, error) {
+	githubTokenSourceMu.RLock()
+	source := githubTokenSource
+	githubTokenSourceMu.RUnlock()
+
+	if source == nil {
+		return os.Getenv("GITHUB_TOKEN"), nil
+	}
+	return source.Token(ctx)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// githubCooldownKeyPrefix is the root of the secondary rate limit cool-downs kept in the state store,
+// one per GitHub credential
+const githubCooldownKeyPrefix = "github-cooldowns/"
+
+// GitHubCooldown is a pause GitHub asked for with a secondary rate limit response, kept per
+// credential so a restarted instance waits it out instead of hitting GitHub again at once
+type GitHubCooldown struct {
+	Credential       string `json:"credential"`
+	Status           int    `json:"status"`
+	ObservedAt       string `json:"observed_at"`
+	Until            string `json:"until"`
+	RemainingSeconds int    `json:"remaining_seconds,omitempty"`
+}
+
+// githubCooldownTracker persists the cool-down of the credential this instance authenticates with
+type githubCooldownTracker struct {
+	mu    sync.Mutex
+	state StateStore
+	last  GitHubCooldown
+	until time.Time
+}
+
+// githubCooldownState is the cool-down tracker shared by every GitHub request of the process
+var githubCooldownState = &githubCooldownTracker{}
+
+// githubCredentialFingerprint names the credential requests authenticate with without revealing it:
+// the GitHub App installation, or a hash of the personal access token (Pure Core)
+func githubCredentialFingerprint(installationID int64, token string) string {
+	if installationID != 0 {
+		return fmt.Sprintf("app-installation-%d", installationID)
+	}
+	if token == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "token-" + hex.EncodeToString(sum[:6])
+}
+
+// currentGitHubCredential returns the fingerprint of the credential GitHub requests use
+func currentGitHubCredential() string {
+	githubTokenSourceMu.RLock()
+	source := githubTokenSource
+	githubTokenSourceMu.RUnlock()
+
+	if source != nil {
+		return githubCredentialFingerprint(source.installationID, "")
+	}
+	return githubCredentialFingerprint(0, os.Getenv("GITHUB_TOKEN"))
+}
+
+// configureGitHubCooldownStore sets the state store cool-downs are persisted to
+func configureGitHubCooldownStore(state StateStore) {
+	githubCooldownState.mu.Lock()
+	defer githubCooldownState.mu.Unlock()
+	githubCooldownState.state = state
+}
+
+// record persists a cool-down started by a secondary rate limit response, expiring with it
+func (t *githubCooldownTracker) record(ctx *gofr.Context, status int, until, now time.Time) {
+	cooldown := GitHubCooldown{
+		Credential: currentGitHubCredential(),
+		Status:     status,
+		ObservedAt: now.UTC().Format(time.RFC3339),
+		Until:      until.UTC().Format(time.RFC3339),
+	}
+
+	t.mu.Lock()
+	t.last, t.until = cooldown, until
+	state := t.state
+	t.mu.Unlock()
+
+	logWarn(ctx, "GitHub secondary rate limit; pausing requests", LogFields{
+		"component":   "github_client",
+		"operation":   "secondary_rate_limit",
+		"credential":  cooldown.Credential,
+		"status":      status,
+		"retry_after": until.Sub(now).Round(time.Second).String(),
+		"until":       cooldown.Until,
+	})
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("github_secondary_rate_limits_total", 1, MetricLabels{
+		"status": fmt.Sprintf("%d", status),
+	})
+
+	if state == nil {
+		return
+	}
+	value, err := json.Marshal(cooldown)
+	if err == nil {
+		err = state.Put(ctx, githubCooldownKeyPrefix+cooldown.Credential, value, until.Sub(now))
+	}
+	if err != nil {
+		logWarn(ctx, "Failed to persist GitHub cool-down", LogFields{
+			"component":  "github_client",
+			"operation":  "persist_cooldown",
+			"credential": cooldown.Credential,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// restore resumes a cool-down persisted by a previous instance for the current credential
+func (t *githubCooldownTracker) restore(ctx *gofr.Context, now time.Time) {
+	t.mu.Lock()
+	state := t.state
+	t.mu.Unlock()
+	if state == nil {
+		return
+	}
+
+	credential := currentGitHubCredential()
+	value, ok, err := state.Get(ctx, githubCooldownKeyPrefix+credential)
+	if err != nil {
+		logWarn(ctx, "Failed to read persisted GitHub cool-down", LogFields{
+			"component":  "github_client",
+			"operation":  "restore_cooldown",
+			"credential": credential,
+			"error":      err.Error(),
+		})
+		return
+	}
+	if !ok {
+		return
+	}
+
+	var cooldown GitHubCooldown
+	if err := json.Unmarshal(value, &cooldown); err != nil {
+		return
+	}
+	until, err := time.Parse(time.RFC3339, cooldown.Until)
+	if err != nil || !until.After(now) {
+		return
+	}
+
+	githubRateBudgetState.pause(until)
+	t.mu.Lock()
+	if until.After(t.until) {
+		t.last, t.until = cooldown, until
+	}
+	t.mu.Unlock()
+
+	logWarn(ctx, "Resuming persisted GitHub cool-down", LogFields{
+		"component":  "github_client",
+		"operation":  "restore_cooldown",
+		"credential": credential,
+		"status":     cooldown.Status,
+		"until":      cooldown.Until,
+	})
+}
+
+// active returns the cool-down in force at now, if any
+func (t *githubCooldownTracker) active(now time.Time) (GitHubCooldown, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !now.Before(t.until) {
+		return GitHubCooldown{}, false
+	}
+	cooldown := t.last
+	cooldown.RemainingSeconds = int(t.until.Sub(now).Round(time.Second).Seconds())
+	return cooldown, true
+}
+
+// activeGitHubCooldown returns the cool-down in force at now for health reports, nil when there is none
+func activeGitHubCooldown(now time.Time) *GitHubCooldown {
+	cooldown, ok := githubCooldownState.active(now)
+	if !ok {
+		return nil
+	}
+	return &cooldown
+}
+
+// observeGitHubResponse records the rate limit state of a GitHub response, persisting the cool-down
+// of a secondary rate limit
+func observeGitHubResponse(ctx *gofr.Context, endpoint string, resp *http.Response) {
+	now := time.Now()
+	if until, paused := githubRateBudgetState.observe(endpoint, resp, now); paused {
+		githubCooldownState.record(ctx, resp.StatusCode, until, now)
+	}
+}
+
+// registerGitHubCooldownRestore resumes a persisted cool-down before the server accepts requests
+func registerGitHubCooldownRestore(app *gofr.App) {
+	app.OnStart(func(ctx *gofr.Context) error {
+		githubCooldownState.restore(ctx, time.Now())
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// GraphQL query profiles chosen by the degradation policy: full queries nest team members, slim
+// queries leave them for the REST enrichment pass that follows the listing
+const (
+	GraphQLProfileFull = "full"
+	GraphQLProfileSlim = "slim"
+)
+
+// graphQLDegradationPolicy switches GraphQL listings to slim queries once the GraphQL rate limit
+// window is down to a share of its limit, so a scan spends what is left on the data it cannot
+// get elsewhere
+type graphQLDegradationPolicy struct {
+	mu               sync.Mutex
+	thresholdPercent int
+}
+
+// graphQLDegradationState is the degradation policy shared by every scan of the process
+var graphQLDegradationState = &graphQLDegradationPolicy{}
+
+// configureGraphQLDegradation sets the share of the GraphQL window below which listings degrade;
+// zero always runs the full queries
+func configureGraphQLDegradation(thresholdPercent int) {
+	graphQLDegradationState.mu.Lock()
+	defer graphQLDegradationState.mu.Unlock()
+	graphQLDegradationState.thresholdPercent = thresholdPercent
+}
+
+// threshold returns the configured degradation threshold
+func (p *graphQLDegradationPolicy) threshold() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.thresholdPercent
+}
+
+// selectGraphQLProfile returns the query profile for the last observed GraphQL window; an unknown or
+// already reset window runs the full queries (Pure Core)
+func selectGraphQLProfile(window githubRateWindow, known bool, thresholdPercent int, now time.Time) string {
+	if !known || thresholdPercent <= 0 || window.Limit <= 0 || !now.Before(window.Reset) {
+		return GraphQLProfileFull
+	}
+	if window.Remaining*100 <= window.Limit*thresholdPercent {
+		return GraphQLProfileSlim
+	}
+	return GraphQLProfileFull
+}
+
+// window returns the last observed state of a rate limit window
+func (b *githubRateBudget) window(resource string) (githubRateWindow, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	window, ok := b.windows[resource]
+	return window, ok
+}
+
+// currentGraphQLProfile returns the query profile for the next GraphQL request of a listing stage
+// and accounts for degraded requests
+func currentGraphQLProfile(ctx *gofr.Context, stage string) string {
+	window, known := githubRateBudgetState.window(githubRateResourceGraphQL)
+	profile := selectGraphQLProfile(window, known, graphQLDegradationState.threshold(), time.Now())
+	if profile == GraphQLProfileSlim {
+		newMetricsCollector(ctx, "codeowners-scanner").recordCounter("github_graphql_degraded_queries_total", 1, MetricLabels{"stage": stage})
+		logDebug(ctx, "Running slim GraphQL query", LogFields{
+			"component": "github_client",
+			"operation": "graphql_degradation",
+			"stage":     stage,
+			"remaining": window.Remaining,
+			"limit":     window.Limit,
+		})
+	}
+	return profile
+}
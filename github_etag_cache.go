@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// githubNotModifiedHeader marks a response replayed from the ETag cache because GitHub answered 304
+const githubNotModifiedHeader = "X-Overseer-Not-Modified"
+
+// githubETagEntry is a cached GitHub response body with the ETag it was served with. decoded holds the
+// value a caller decoded from the body, so an unchanged response is not decoded again
+type githubETagEntry struct {
+	key    string
+	etag   string
+	header http.Header
+	body   []byte
+
+	mu      sync.Mutex
+	decoded interface{}
+}
+
+// githubETagCache keeps the bodies of GitHub GET responses that carried an ETag, least recently used
+// first out once their size exceeds maxBytes, so repeated requests can be sent with If-None-Match.
+// GitHub does not count 304 responses against the rate limit
+type githubETagCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// githubETagCacheState is the ETag cache shared by every GitHub request of the process
+var githubETagCacheState = &githubETagCache{entries: make(map[string]*list.Element), order: list.New()}
+
+// githubCachedBody is the body of a response whose content is held in the ETag cache
+type githubCachedBody struct {
+	*bytes.Reader
+	entry *githubETagEntry
+}
+
+// Close implements io.Closer; the cached content needs no release
+func (githubCachedBody) Close() error {
+	return nil
+}
+
+// configureGitHubETagCache sets the size of the ETag cache in bytes, evicting entries above it; zero
+// disables conditional requests
+func configureGitHubETagCache(maxBytes int) {
+	githubETagCacheState.mu.Lock()
+	defer githubETagCacheState.mu.Unlock()
+	githubETagCacheState.maxBytes = maxBytes
+	githubETagCacheState.evict()
+}
+
+// githubETagCacheKey identifies a cached response by credential, Accept header, endpoint and query, so
+// tokens with different access and raw and JSON media types never share an entry (Pure Core)
+func githubETagCacheKey(credential, endpoint string, query map[string]any, headers map[string]string) string {
+	params := make([]string, 0, len(query))
+	for name, value := range query {
+		params = append(params, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(params)
+	return credential + " " + headers["Accept"] + " " + strings.TrimPrefix(endpoint, "/") + "?" + strings.Join(params, "&")
+}
+
+// withIfNoneMatch returns a copy of request headers asking GitHub to answer 304 while etag is current (Pure Core)
+func withIfNoneMatch(headers map[string]string, etag string) map[string]string {
+	conditional := make(map[string]string, len(headers)+1)
+	for name, value := range headers {
+		conditional[name] = value
+	}
+	conditional["If-None-Match"] = etag
+	return conditional
+}
+
+// replayGitHubResponse turns a 304 into a 200 carrying the cached body; headers of the 304, such as the
+// rate limit, take precedence over the cached ones (Pure Core)
+func replayGitHubResponse(notModified *http.Response, entry *githubETagEntry) *http.Response {
+	header := entry.header.Clone()
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+	header.Set(githubNotModifiedHeader, "true")
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          githubCachedBody{Reader: bytes.NewReader(entry.body), entry: entry},
+		ContentLength: int64(len(entry.body)),
+		Request:       notModified.Request,
+	}
+}
+
+// lookup returns the cached entry of a key and marks it recently used
+func (c *githubETagCache) lookup(key string) (*githubETagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxBytes <= 0 {
+		return nil, false
+	}
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*githubETagEntry), true
+}
+
+// store caches an entry, replacing an older response of the same key; bodies larger than the cache are not kept
+func (c *githubETagCache) store(entry *githubETagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxBytes <= 0 || len(entry.body) > c.maxBytes {
+		return
+	}
+	if element, ok := c.entries[entry.key]; ok {
+		c.remove(element)
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	c.size += len(entry.body)
+	c.evict()
+}
+
+// evict drops least recently used entries until the cache fits; callers hold c.mu
+func (c *githubETagCache) evict() {
+	for c.size > c.maxBytes && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops an entry; callers hold c.mu
+func (c *githubETagCache) remove(element *list.Element) {
+	entry := element.Value.(*githubETagEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.body)
+}
+
+// enabled reports whether responses are cached
+func (c *githubETagCache) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxBytes > 0
+}
+
+// bytes returns the size of the cached bodies
+func (c *githubETagCache) bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// resolveConditionalGitHubResponse replays the cached body when GitHub answered 304 to a request sent
+// with the ETag of cached, and caches 200 responses carrying an ETag
+func resolveConditionalGitHubResponse(ctx *gofr.Context, endpoint, key string, cached *githubETagEntry, resp *http.Response) (*http.Response, error) {
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	resource := githubRateResourceForEndpoint(endpoint)
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		metrics.recordCounter("github_conditional_requests_total", 1, MetricLabels{"resource": resource, "result": "not_modified"})
+		return replayGitHubResponse(resp, cached), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" || !githubETagCacheState.enabled() {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub response for %s: %w", endpoint, err)
+	}
+
+	entry := &githubETagEntry{key: key, etag: etag, header: resp.Header.Clone(), body: body}
+	githubETagCacheState.store(entry)
+	resp.Body = githubCachedBody{Reader: bytes.NewReader(body), entry: entry}
+	if cached != nil {
+		metrics.recordCounter("github_conditional_requests_total", 1, MetricLabels{"resource": resource, "result": "modified"})
+	}
+	metrics.recordGauge("github_etag_cache_bytes", float64(githubETagCacheState.bytes()), MetricLabels{})
+	return resp, nil
+}
+
+// isGitHubNotModified reports whether a response was replayed from the ETag cache
+func isGitHubNotModified(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(githubNotModifiedHeader) != ""
+}
+
+// cachedGitHubDecoded returns the value decoded from an unchanged response body before, skipping decoding
+func cachedGitHubDecoded(resp *http.Response) (interface{}, bool) {
+	body, ok := resp.Body.(githubCachedBody)
+	if !ok || !isGitHubNotModified(resp) {
+		return nil, false
+	}
+
+	body.entry.mu.Lock()
+	defer body.entry.mu.Unlock()
+	return body.entry.decoded, body.entry.decoded != nil
+}
+
+// rememberGitHubDecoded keeps the value decoded from a cached response body for when it is unchanged
+func rememberGitHubDecoded(resp *http.Response, decoded interface{}) {
+	body, ok := resp.Body.(githubCachedBody)
+	if !ok {
+		return
+	}
+
+	body.entry.mu.Lock()
+	defer body.entry.mu.Unlock()
+	body.entry.decoded = decoded
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// githubGraphQLEndpoint is the GraphQL endpoint relative to the GitHub API base URL
+const githubGraphQLEndpoint = "graphql"
+
+// Page sizes of the GraphQL queries; CODEOWNERS batches stay small as each repository resolves three blobs
+const (
+	graphQLRepositoryPageSize = 100
+	graphQLTeamPageSize       = 100
+	graphQLTeamMemberPageSize = 100
+	graphQLCodeownersBatch    = 50
+)
+
+// githubGraphQLBaseURL is the API base URL when scans fetch repositories, teams and CODEOWNERS through
+// GraphQL, empty when they use REST
+var (
+	githubGraphQLMu      sync.RWMutex
+	githubGraphQLBaseURL string
+)
+
+// graphQLPageInfo is the cursor state of a GraphQL connection
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// graphQLRepository is a repository as returned by the repositories query
+type graphQLRepository struct {
+	DatabaseID      int    `json:"databaseId"`
+	Name            string `json:"name"`
+	NameWithOwner   string `json:"nameWithOwner"`
+	Description     string `json:"description"`
+	IsPrivate       bool   `json:"isPrivate"`
+	Visibility      string `json:"visibility"`
+	IsArchived      bool   `json:"isArchived"`
+	IsFork          bool   `json:"isFork"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	PushedAt  time.Time `json:"pushedAt"`
+}
+
+// graphQLTeam is a team as returned by the teams query
+type graphQLTeam struct {
+	DatabaseID  int    `json:"databaseId"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Privacy     string `json:"privacy"`
+	Members     struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"members"`
+}
+
+// completeMembers returns the member logins of a team, or nil when the team has more members than
+// the query returned (Pure Core)
+func (t graphQLTeam) completeMembers() []string {
+	if t.Members.TotalCount > len(t.Members.Nodes) {
+		return nil
+	}
+	members := make([]string, 0, len(t.Members.Nodes))
+	for _, member := range t.Members.Nodes {
+		members = append(members, member.Login)
+	}
+	return members
+}
+
+// graphQLBlob is a file resolved with an object(expression:) lookup; nil when the file does not exist
+type graphQLBlob struct {
+	OID  string `json:"oid"`
+	Text string `json:"text"`
+}
+
+// setGitHubGraphQL selects GraphQL for repository, team and CODEOWNERS fetching; an empty base URL selects REST
+func setGitHubGraphQL(baseURL string) {
+	githubGraphQLMu.Lock()
+	defer githubGraphQLMu.Unlock()
+	githubGraphQLBaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// currentGitHubGraphQL returns the API base URL and whether scans fetch through GraphQL
+func currentGitHubGraphQL() (string, bool) {
+	githubGraphQLMu.RLock()
+	defer githubGraphQLMu.RUnlock()
+	return githubGraphQLBaseURL, githubGraphQLBaseURL != ""
+}
+
+// buildRepositoriesGraphQLQuery builds the query listing an organization's repositories, most recently
+// updated first (Pure Core)
+func buildRepositoriesGraphQLQuery() string {
+	return `query($org: String!, $first: Int!, $after: String) {
+  organization(login: $org) {
+    repositories(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        databaseId name nameWithOwner description isPrivate visibility isArchived isFork
+        primaryLanguage { name }
+        repositoryTopics(first: 20) { nodes { topic { name } } }
+        createdAt updatedAt pushedAt
+      }
+    }
+  }
+}`
+}
+
+// buildTeamsGraphQLQuery builds the query listing an organization's teams, with their members in the
+// full profile (Pure Core)
+func buildTeamsGraphQLQuery(profile string) string {
+	members := ""
+	if profile != GraphQLProfileSlim {
+		members = "\n        members(first: " + fmt.Sprint(graphQLTeamMemberPageSize) + ") { totalCount nodes { login } }"
+	}
+	return `query($org: String!, $first: Int!, $after: String) {
+  organization(login: $org) {
+    teams(first: $first, after: $after) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        databaseId slug name description privacy` + members + `
+      }
+    }
+  }
+}`
+}
+
+// buildCodeownersBatchGraphQLQuery builds one query reading every CODEOWNERS location of a batch of
+// repositories, aliased r0, r1, ... in the order given (Pure Core)
+func buildCodeownersBatchGraphQLQuery(count int, locations []codeownersLocation) string {
+	var declarations, fields strings.Builder
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			declarations.WriteString(", ")
+		}
+		fmt.Fprintf(&declarations, "$o%d: String!, $n%d: String!", i, i)
+		fmt.Fprintf(&fields, "  r%d: repository(owner: $o%d, name: $n%d) {\n", i, i, i)
+		for _, location := range locations {
+			fmt.Fprintf(&fields, "    %s: object(expression: \"HEAD:%s\") { ... on Blob { oid text } }\n", location.alias, location.path)
+		}
+		fields.WriteString("  }\n")
+	}
+	return "query(" + declarations.String() + ") {\n" + fields.String() + "}"
+}
+
+// convertGraphQLRepository converts a GraphQL repository to the REST representation stored in the graph (Pure Core)
+func convertGraphQLRepository(baseURL string, node graphQLRepository) GitHubRepository {
+	repo := GitHubRepository{
+		ID:          node.DatabaseID,
+		Name:        node.Name,
+		FullName:    node.NameWithOwner,
+		Description: node.Description,
+		URL:         baseURL + "/repos/" + node.NameWithOwner,
+		Private:     node.IsPrivate,
+		Visibility:  strings.ToLower(node.Visibility),
+		Archived:    node.IsArchived,
+		Fork:        node.IsFork,
+		Topics:      []string{},
+		CreatedAt:   node.CreatedAt,
+		UpdatedAt:   node.UpdatedAt,
+		PushedAt:    node.PushedAt,
+	}
+	if node.PrimaryLanguage != nil {
+		repo.Language = node.PrimaryLanguage.Name
+	}
+	for _, topic := range node.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, topic.Topic.Name)
+	}
+	return repo
+}
+
+// convertGraphQLCodeowners returns the first CODEOWNERS file found in a repository's locations (Pure Core)
+func convertGraphQLCodeowners(fullName string, blobs map[string]*graphQLBlob, locations []codeownersLocation) GitHubCodeowners {
+	codeowners := GitHubCodeowners{
+		Repository: fullName,
+		Rules:      []GitHubCodeownersRule{},
+		Errors:     []GitHubCodeownersError{},
+	}
+	for _, location := range locations {
+		blob := blobs[location.alias]
+		if blob == nil || blob.Text == "" {
+			continue
+		}
+		codeowners.Path = location.path
+		codeowners.SHA = blob.OID
+		codeowners.Content = blob.Text
+		codeowners.Rules = parseCodeownersContent(base64.StdEncoding.EncodeToString([]byte(blob.Text)))
+		break
+	}
+	return codeowners
+}
+
+// executeGitHubGraphQL runs a GraphQL query through the registered GitHub service and decodes its data
+// into target. Errors alongside data are returned as partial failures for the caller to tolerate.
+func executeGitHubGraphQL(ctx *gofr.Context, query string, variables map[string]interface{}, target interface{}) ([]GitHubGraphQLError, error) {
+	resp, err := githubWrite(ctx, http.MethodPost, githubGraphQLEndpoint, GitHubGraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, newGitHubAPIError("graphql_request_failed", "GitHub GraphQL request failed", err.Error(), http.StatusBadGateway)
+	}
+	defer resp.Body.Close()
+	reportScanProgress(ctx, "")
+	logRateLimitInfo(ctx, resp)
+	newMetricsCollector(ctx, "codeowners-scanner").recordAPICallCount("github", "graphql", resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newGitHubAPIError(
+			"graphql_status",
+			"GitHub GraphQL API returned an error status",
+			fmt.Sprintf("status %d", resp.StatusCode),
+			http.StatusBadGateway,
+		)
+	}
+
+	var raw struct {
+		Data   json.RawMessage      `json:"data"`
+		Errors []GitHubGraphQLError `json:"errors,omitempty"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, newGitHubAPIError("graphql_decode", "failed to decode GitHub GraphQL response", err.Error(), http.StatusBadGateway)
+	}
+	if len(raw.Data) == 0 || string(raw.Data) == "null" {
+		details := "no data returned"
+		if len(raw.Errors) > 0 {
+			details = raw.Errors[0].Message
+		}
+		return raw.Errors, newGitHubAPIError("graphql_error", "GitHub GraphQL query failed", details, http.StatusBadGateway)
+	}
+	if err := json.Unmarshal(raw.Data, target); err != nil {
+		return raw.Errors, newGitHubAPIError("graphql_decode", "failed to decode GitHub GraphQL data", err.Error(), http.StatusBadGateway)
+	}
+	return raw.Errors, nil
+}
+
+// fetchGitHubRepositoriesGraphQL fetches up to maxRepos repositories of an organization, 100 per request
+func fetchGitHubRepositoriesGraphQL(ctx *gofr.Context, orgName string, maxRepos int) ([]GitHubRepository, error) {
+	if err := validateRepositoryParams(orgName, maxRepos); err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := currentGitHubGraphQL()
+	repos := []GitHubRepository{}
+	var cursor interface{}
+	for len(repos) < maxRepos {
+		var data struct {
+			Organization *struct {
+				Repositories struct {
+					PageInfo graphQLPageInfo     `json:"pageInfo"`
+					Nodes    []graphQLRepository `json:"nodes"`
+				} `json:"repositories"`
+			} `json:"organization"`
+		}
+		variables := map[string]interface{}{"org": orgName, "first": min(graphQLRepositoryPageSize, maxRepos-len(repos)), "after": cursor}
+		if _, err := executeGitHubGraphQL(ctx, buildRepositoriesGraphQLQuery(), variables, &data); err != nil {
+			return nil, err
+		}
+		if data.Organization == nil {
+			return nil, newGitHubAPIError("organization_not_found", "organization not found", orgName, http.StatusNotFound)
+		}
+
+		page := data.Organization.Repositories
+		if err := reserveScanItems(ctx, ScanBufferRepositories, len(page.Nodes)); err != nil {
+			return nil, err
+		}
+		for _, node := range page.Nodes {
+			repos = append(repos, convertGraphQLRepository(baseURL, node))
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	logInfo(ctx, "Fetched GitHub repositories through GraphQL", LogFields{
+		"component":     "github_client",
+		"operation":     "fetch_repositories_graphql",
+		"organization":  orgName,
+		"max_repos":     maxRepos,
+		"fetched_repos": len(repos),
+	})
+	return limitRepositories(ctx, repos, maxRepos, orgName), nil
+}
+
+// fetchGitHubTeamsGraphQL fetches up to maxTeams teams of an organization, 100 per request. Pages
+// fetched while the GraphQL budget is degraded leave the members unknown for attachTeamMembers.
+func fetchGitHubTeamsGraphQL(ctx *gofr.Context, orgName string, maxTeams int) ([]GitHubTeam, error) {
+	baseURL, _ := currentGitHubGraphQL()
+	teams := []GitHubTeam{}
+	deferred := 0
+	var cursor interface{}
+	for len(teams) < maxTeams {
+		var data struct {
+			Organization *struct {
+				Teams struct {
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+					Nodes    []graphQLTeam   `json:"nodes"`
+				} `json:"teams"`
+			} `json:"organization"`
+		}
+		profile := currentGraphQLProfile(ctx, "teams")
+		variables := map[string]interface{}{"org": orgName, "first": min(graphQLTeamPageSize, maxTeams-len(teams)), "after": cursor}
+		if _, err := executeGitHubGraphQL(ctx, buildTeamsGraphQLQuery(profile), variables, &data); err != nil {
+			return nil, err
+		}
+		if data.Organization == nil {
+			return nil, newGitHubAPIError("organization_not_found", "organization not found", orgName, http.StatusNotFound)
+		}
+
+		page := data.Organization.Teams
+		if err := reserveScanItems(ctx, ScanBufferTeams, len(page.Nodes)); err != nil {
+			return nil, err
+		}
+		for _, node := range page.Nodes {
+			team := GitHubTeam{
+				ID:          node.DatabaseID,
+				Slug:        node.Slug,
+				Name:        node.Name,
+				Description: node.Description,
+				URL:         fmt.Sprintf("%s/orgs/%s/teams/%s", baseURL, orgName, node.Slug),
+				Privacy:     convertGraphQLTeamPrivacy(node.Privacy),
+			}
+			if profile == GraphQLProfileSlim {
+				deferred++
+			} else {
+				team.Members = node.completeMembers()
+			}
+			teams = append(teams, team)
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	logInfo(ctx, "Fetched GitHub teams through GraphQL", LogFields{
+		"component":     "github_client",
+		"operation":     "fetch_teams_graphql",
+		"organization":  orgName,
+		"max_teams":     maxTeams,
+		"fetched_teams": len(teams),
+		"deferred":      deferred,
+	})
+	return teams, nil
+}
+
+// fetchCodeownersGraphQL reads the CODEOWNERS files of repositories 50 at a time, one request per batch
+// instead of three per repository. Repositories GitHub cannot resolve are reported without rules.
+func fetchCodeownersGraphQL(ctx *gofr.Context, repos []GitHubRepository) ([]GitHubCodeowners, error) {
+	codeowners := make([]GitHubCodeowners, 0, len(repos))
+	locations := currentCodeownersSearch().locations
+
+	for start := 0; start < len(repos); start += graphQLCodeownersBatch {
+		reportScanBatchProgress(ctx, start, len(repos))
+		batch := repos[start:min(start+graphQLCodeownersBatch, len(repos))]
+
+		variables := make(map[string]interface{}, 2*len(batch))
+		for i, repo := range batch {
+			owner, name := parseRepositoryFullName(repo.FullName)
+			variables[fmt.Sprintf("o%d", i)] = owner
+			variables[fmt.Sprintf("n%d", i)] = name
+		}
+
+		data := map[string]map[string]*graphQLBlob{}
+		partial, err := executeGitHubGraphQL(ctx, buildCodeownersBatchGraphQLQuery(len(batch), locations), variables, &data)
+		if err != nil {
+			return nil, err
+		}
+		if len(partial) > 0 {
+			logWarn(ctx, "Some repositories could not be read through GraphQL", LogFields{
+				"component":   "github_client",
+				"operation":   "fetch_codeowners_graphql",
+				"batch_start": start,
+				"errors":      len(partial),
+				"first_error": partial[0].Message,
+			})
+		}
+
+		rules := 0
+		for i, repo := range batch {
+			codeowner := convertGraphQLCodeowners(repo.FullName, data[fmt.Sprintf("r%d", i)], locations)
+			rules += len(codeowner.Rules)
+			codeowners = append(codeowners, codeowner)
+		}
+		if err := reserveScanItems(ctx, ScanBufferRules, rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return codeowners, nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// githubBudgetHeartbeatInterval is how often a background scan waiting for the rate limit reports
+// progress, so the watchdog does not take the wait for a stall
+const githubBudgetHeartbeatInterval = 30 * time.Second
+
+// githubInteractiveThrottleWait is the longest an interactive request waits out a paused rate limit
+// window; longer pauses fail with 429, so API callers are not left hanging
+const githubInteractiveThrottleWait = 5 * time.Second
+
+// githubSecondaryLimitPause is how long requests pause after a 429 without a Retry-After header, as
+// GitHub asks clients to wait at least a minute after hitting a secondary rate limit
+const githubSecondaryLimitPause = time.Minute
+
+// Reasons the adaptive throttler holds a GitHub request back
+const (
+	githubThrottlePaced     = "paced"
+	githubThrottleExhausted = "exhausted"
+	githubThrottleSecondary = "secondary_limit"
+)
+
+// GitHub rate limit resources budgeted separately, as named by the X-RateLimit-Resource header
+const (
+	githubRateResourceCore    = "core"
+	githubRateResourceGraphQL = "graphql"
+	githubRateResourceSearch  = "search"
+)
+
+// githubRateWindow is the last observed state of one GitHub rate limit window
+type githubRateWindow struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// githubRateBudget splits each GitHub rate limit window into two tiers: background scans may spend
+// it down to the interactive reserve, after which they wait for the window to reset while
+// interactive requests keep the reserved slice. It also throttles requests adaptively: background
+// requests are paced once a window falls below minRemaining, and every request waits out an
+// exhausted window or a secondary rate limit
+type githubRateBudget struct {
+	mu             sync.Mutex
+	reservePercent int
+	maxWait        time.Duration
+	minRemaining   int
+	windows        map[string]githubRateWindow
+	nextSlot       map[string]time.Time
+	pausedUntil    time.Time
+}
+
+// githubRateBudgetState is the budget shared by every GitHub request of the process
+var githubRateBudgetState = &githubRateBudget{
+	windows:  make(map[string]githubRateWindow),
+	nextSlot: make(map[string]time.Time),
+}
+
+// configureGitHubRateBudget sets the share of each window reserved for interactive requests, the
+// longest a background request waits for the window to reset and the remaining requests below
+// which background requests are paced
+func configureGitHubRateBudget(reservePercent int, maxWait time.Duration, minRemaining int) {
+	githubRateBudgetState.mu.Lock()
+	defer githubRateBudgetState.mu.Unlock()
+	githubRateBudgetState.reservePercent = reservePercent
+	githubRateBudgetState.maxWait = maxWait
+	githubRateBudgetState.minRemaining = minRemaining
+}
+
+// githubRateResourceForEndpoint returns the rate limit resource a GitHub endpoint is charged to (Pure Core)
+func githubRateResourceForEndpoint(endpoint string) string {
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	switch {
+	case endpoint == githubGraphQLEndpoint:
+		return githubRateResourceGraphQL
+	case strings.HasPrefix(endpoint, "search/"):
+		return githubRateResourceSearch
+	default:
+		return githubRateResourceCore
+	}
+}
+
+// parseGitHubRateWindow reads the rate limit window from GitHub response headers (Pure Core)
+func parseGitHubRateWindow(header http.Header) (githubRateWindow, bool) {
+	limit, limitErr := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if limitErr != nil || remainingErr != nil || resetErr != nil {
+		return githubRateWindow{}, false
+	}
+	return githubRateWindow{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}, true
+}
+
+// calculateInteractiveReserve returns the requests of a window kept for interactive requests,
+// rounded up so a non-zero share always reserves at least one request (Pure Core)
+func calculateInteractiveReserve(limit, reservePercent int) int {
+	if limit <= 0 || reservePercent <= 0 {
+		return 0
+	}
+	return (limit*reservePercent + 99) / 100
+}
+
+// calculateBackgroundWait returns how long a background request waits before spending from window;
+// zero when the window is above the reserve or has already reset (Pure Core)
+func calculateBackgroundWait(window githubRateWindow, reservePercent int, now time.Time) time.Duration {
+	if window.Remaining > calculateInteractiveReserve(window.Limit, reservePercent) || !now.Before(window.Reset) {
+		return 0
+	}
+	return window.Reset.Sub(now)
+}
+
+// calculateThrottleDelay returns how long a request waits before spending from window: until the
+// reset once it is exhausted, and below minRemaining an even share of the time to the reset, so
+// the remaining requests are spread over the window instead of running it dry (Pure Core)
+func calculateThrottleDelay(window githubRateWindow, minRemaining int, now time.Time) time.Duration {
+	if !now.Before(window.Reset) {
+		return 0
+	}
+	untilReset := window.Reset.Sub(now)
+	switch {
+	case window.Remaining <= 0:
+		return untilReset
+	case window.Remaining < minRemaining:
+		return untilReset / time.Duration(window.Remaining+1)
+	default:
+		return 0
+	}
+}
+
+// parseGitHubSecondaryLimit returns until when requests pause after a response hit a secondary rate
+// limit: a 403 or 429 with Retry-After in seconds or as an HTTP date, or a 429 without it while the primary window has requests
+// left. A 403 without Retry-After is a permission error, not a rate limit (Pure Core)
+func parseGitHubSecondaryLimit(status int, header http.Header, now time.Time) (time.Time, bool) {
+	if status != http.StatusForbidden && status != http.StatusTooManyRequests {
+		return time.Time{}, false
+	}
+	if wait, ok := parseRetryAfter(header, now); ok {
+		return now.Add(wait), true
+	}
+	if status == http.StatusTooManyRequests && header.Get("X-RateLimit-Remaining") != "0" {
+		return now.Add(githubSecondaryLimitPause), true
+	}
+	return time.Time{}, false
+}
+
+// observe records the rate limit window reported by a GitHub response, and the pause a secondary
+// rate limit response asks for; it returns the end of the pause when the response extended it
+func (b *githubRateBudget) observe(endpoint string, resp *http.Response, now time.Time) (time.Time, bool) {
+	if resp == nil {
+		return time.Time{}, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until, paused := parseGitHubSecondaryLimit(resp.StatusCode, resp.Header, now)
+	paused = paused && until.After(b.pausedUntil)
+	if paused {
+		b.pausedUntil = until
+	}
+
+	if window, ok := parseGitHubRateWindow(resp.Header); ok {
+		resource := resp.Header.Get("X-RateLimit-Resource")
+		if resource == "" {
+			resource = githubRateResourceForEndpoint(endpoint)
+		}
+		b.windows[resource] = window
+	}
+	return until, paused
+}
+
+// pause holds every request until until, unless a longer pause is already in force
+func (b *githubRateBudget) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// throttleWait returns how long a request to endpoint waits and why. Secondary limits and exhausted
+// windows hold every request; pacing applies to background requests only, which take consecutive
+// slots so concurrent scans do not burst once their delays elapse
+func (b *githubRateBudget) throttleWait(endpoint string, background bool, now time.Time) (time.Duration, string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now), githubThrottleSecondary
+	}
+
+	resource := githubRateResourceForEndpoint(endpoint)
+	window, ok := b.windows[resource]
+	if !ok {
+		return 0, ""
+	}
+	delay := calculateThrottleDelay(window, b.minRemaining, now)
+	switch {
+	case delay <= 0:
+		return 0, ""
+	case window.Remaining <= 0:
+		return delay, githubThrottleExhausted
+	case !background:
+		return 0, ""
+	}
+
+	slot := now
+	if b.nextSlot[resource].After(slot) {
+		slot = b.nextSlot[resource]
+	}
+	slot = slot.Add(delay)
+	b.nextSlot[resource] = slot
+	return slot.Sub(now), githubThrottlePaced
+}
+
+// backgroundWait returns how long a background request to endpoint waits and the longest it may wait
+func (b *githubRateBudget) backgroundWait(endpoint string, now time.Time) (time.Duration, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	window, ok := b.windows[githubRateResourceForEndpoint(endpoint)]
+	if !ok {
+		return 0, b.maxWait
+	}
+	return calculateBackgroundWait(window, b.reservePercent, now), b.maxWait
+}
+
+// isBackgroundGitHubRequest reports whether ctx belongs to a background scan rather than an
+// interactive request
+func isBackgroundGitHubRequest(ctx context.Context) bool {
+	_, ok := ctx.Value(runningScanContextKey{}).(*RunningScan)
+	return ok
+}
+
+// awaitGitHubRateBudget holds a background request back while the rate limit window of its endpoint
+// is down to the interactive reserve, until the window resets. Waits longer than the configured
+// maximum fail instead, and interactive requests never wait
+func awaitGitHubRateBudget(ctx *gofr.Context, endpoint string) error {
+	if !isBackgroundGitHubRequest(ctx) {
+		return nil
+	}
+
+	wait, maxWait := githubRateBudgetState.backgroundWait(endpoint, time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	resource := githubRateResourceForEndpoint(endpoint)
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	if wait > maxWait {
+		metrics.recordCounter("github_rate_limit_reserve_holds_total", 1, MetricLabels{"resource": resource, "outcome": "rejected"})
+		return newGitHubAPIError(
+			"rate_limit_reserved",
+			"GitHub rate limit is down to the share reserved for interactive requests",
+			fmt.Sprintf("%s window resets in %s, longer than the %s background wait limit", resource, wait.Round(time.Second), maxWait),
+			http.StatusTooManyRequests,
+		)
+	}
+
+	metrics.recordCounter("github_rate_limit_reserve_holds_total", 1, MetricLabels{"resource": resource, "outcome": "waited"})
+	logWarn(ctx, "Background scan waiting for GitHub rate limit reset", LogFields{
+		"component": "github_client",
+		"operation": "rate_limit_reserve",
+		"resource":  resource,
+		"wait":      wait.Round(time.Second).String(),
+	})
+
+	return sleepForGitHubRateLimit(ctx, wait)
+}
+
+// sleepForGitHubRateLimit waits for a rate limit hold to pass, reporting progress so the watchdog does
+// not take the wait for a stall, and stops early when ctx is cancelled
+func sleepForGitHubRateLimit(ctx *gofr.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	heartbeat := time.NewTicker(githubBudgetHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-heartbeat.C:
+			reportScanProgress(ctx, "")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// awaitGitHubThrottle holds a request back while the adaptive throttler paces or pauses its endpoint.
+// Background requests sleep until the hold passes or ctx is cancelled; interactive requests wait out
+// short holds only and otherwise fail with 429 before GitHub would reject them
+func awaitGitHubThrottle(ctx *gofr.Context, endpoint string) error {
+	background := isBackgroundGitHubRequest(ctx)
+	wait, reason := githubRateBudgetState.throttleWait(endpoint, background, time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	resource := githubRateResourceForEndpoint(endpoint)
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	if !background && wait > githubInteractiveThrottleWait {
+		metrics.recordCounter("github_rate_limit_throttled_total", 1, MetricLabels{"resource": resource, "reason": reason, "outcome": "rejected"})
+		return newGitHubAPIError(
+			"rate_limit_throttled",
+			"GitHub rate limit is paused; retry once it resets",
+			fmt.Sprintf("%s requests are held for %s (%s)", resource, wait.Round(time.Second), reason),
+			http.StatusTooManyRequests,
+		)
+	}
+
+	metrics.recordCounter("github_rate_limit_throttled_total", 1, MetricLabels{"resource": resource, "reason": reason, "outcome": "waited"})
+	metrics.recordDuration("github_rate_limit_throttle_wait", wait, MetricLabels{"resource": resource, "reason": reason})
+	fields := LogFields{
+		"component": "github_client",
+		"operation": "rate_limit_throttle",
+		"resource":  resource,
+		"reason":    reason,
+		"wait":      wait.Round(time.Millisecond).String(),
+	}
+	if reason == githubThrottlePaced {
+		logDebug(ctx, "Pacing GitHub request below the rate limit floor", fields)
+	} else {
+		logWarn(ctx, "Waiting for GitHub rate limit to reset", fields)
+	}
+
+	return sleepForGitHubRateLimit(ctx, wait)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// githubRetryBaseDelay is the backoff before the first retry of a GitHub request; each further
+// retry doubles it
+const githubRetryBaseDelay = 500 * time.Millisecond
+
+// githubRetryMaxDelay caps the backoff between retries, including delays asked for by Retry-After;
+// a longer Retry-After gives up on the request instead
+const githubRetryMaxDelay = 30 * time.Second
+
+// Reasons a GitHub request is retried
+const (
+	githubRetryNetwork     = "network"
+	githubRetryServerError = "server_error"
+	githubRetryRateLimited = "rate_limited"
+)
+
+// githubRetryPolicy is how many times a failed GitHub request is retried
+type githubRetryPolicy struct {
+	mu         sync.Mutex
+	maxRetries int
+}
+
+// githubRetryPolicyState is the retry policy shared by every GitHub request of the process
+var githubRetryPolicyState = &githubRetryPolicy{}
+
+// configureGitHubRetries sets how many times each GitHub request is retried after a transient failure
+func configureGitHubRetries(maxRetries int) {
+	githubRetryPolicyState.mu.Lock()
+	defer githubRetryPolicyState.mu.Unlock()
+	githubRetryPolicyState.maxRetries = maxRetries
+}
+
+// retries returns the retry budget of one GitHub request
+func (p *githubRetryPolicy) retries() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.maxRetries
+}
+
+// classifyGitHubRetry returns why a GitHub response or transport error is worth retrying: network
+// failures, 5xx gateway and server errors and 429 rate limits. Cancelled requests and 4xx errors are
+// final (Pure Core)
+func classifyGitHubRetry(resp *http.Response, err error) (string, bool) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return "", false
+		}
+		return githubRetryNetwork, true
+	}
+	if resp == nil {
+		return "", false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return githubRetryServerError, true
+	case http.StatusTooManyRequests:
+		return githubRetryRateLimited, true
+	}
+	return "", false
+}
+
+// parseRetryAfter returns the delay a Retry-After header asks for, given in seconds or as an HTTP
+// date (Pure Core)
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// calculateGitHubRetryDelay returns the backoff before retry attempt (from 1): the base delay doubled
+// per attempt and capped, with equal jitter spreading it over its upper half so replicas retrying
+// together do not hit GitHub at once. jitter is a random fraction in [0, 1) (Pure Core)
+func calculateGitHubRetryDelay(attempt int, jitter float64) time.Duration {
+	backoff := githubRetryMaxDelay
+	if attempt <= 16 {
+		backoff = githubRetryBaseDelay << (attempt - 1)
+	}
+	if backoff > githubRetryMaxDelay {
+		backoff = githubRetryMaxDelay
+	}
+	half := backoff / 2
+	return half + time.Duration(jitter*float64(half))
+}
+
+// githubRetryDelay returns how long to wait before retrying a failed request, honouring Retry-After
+// on server errors. Rate limited retries only back off: awaitGitHubThrottle already holds the next
+// attempt until the pause observed from the 429 ends. It reports false when Retry-After asks for
+// longer than githubRetryMaxDelay (Pure Core)
+func githubRetryDelay(attempt int, reason string, resp *http.Response, jitter float64, now time.Time) (time.Duration, bool) {
+	delay := calculateGitHubRetryDelay(attempt, jitter)
+	if reason != githubRetryServerError || resp == nil {
+		return delay, true
+	}
+
+	retryAfter, ok := parseRetryAfter(resp.Header, now)
+	if !ok {
+		return delay, true
+	}
+	if retryAfter > githubRetryMaxDelay {
+		return 0, false
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay, true
+}
+
+// retryGitHubRequest sends a GitHub request through the rate budget, throttler and circuit breaker, retrying transient
+// failures with exponential backoff and jitter up to the configured retry budget. Every attempt is
+// accounted as an API call. The last response or error is returned once the budget runs out or the
+// failure is final; bodies of retried responses are closed
+func retryGitHubRequest(ctx *gofr.Context, endpoint string, send func() (*http.Response, error)) (*http.Response, error) {
+	budget := githubRetryPolicyState.retries()
+	resource := githubRateResourceForEndpoint(endpoint)
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+
+	for attempt := 1; ; attempt++ {
+		if err := awaitGitHubRateBudget(ctx, endpoint); err != nil {
+			return nil, err
+		}
+		if err := awaitGitHubThrottle(ctx, endpoint); err != nil {
+			return nil, err
+		}
+
+		if err := allowGitHubCall(ctx, endpoint); err != nil {
+			return nil, err
+		}
+		resp, err := send()
+		recordGitHubCall(ctx, resp, err)
+		observeGitHubResponse(ctx, endpoint, resp)
+		if err == nil {
+			recordGitHubAPICall(ctx)
+		}
+
+		reason, retryable := classifyGitHubRetry(resp, err)
+		if !retryable {
+			return resp, err
+		}
+		if attempt > budget {
+			metrics.recordCounter("github_retry_budget_exhausted_total", 1, MetricLabels{"resource": resource, "reason": reason})
+			return resp, err
+		}
+
+		delay, ok := githubRetryDelay(attempt, reason, resp, rand.Float64(), time.Now())
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		metrics.recordCounter("github_retries_total", 1, MetricLabels{"resource": resource, "reason": reason})
+		metrics.recordDuration("github_retry_delay", delay, MetricLabels{"resource": resource, "reason": reason})
+		fields := LogFields{
+			"component": "github_client",
+			"operation": "retry_request",
+			"endpoint":  endpoint,
+			"reason":    reason,
+			"attempt":   attempt,
+			"budget":    budget,
+			"delay":     delay.Round(time.Millisecond).String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+		}
+		logWarn(ctx, "Retrying GitHub request", fields)
+
+		if err := sleepForGitHubRateLimit(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
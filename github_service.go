@@ -1,11 +1,11 @@
 package main
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -17,18 +17,112 @@ import (
 
 // GitHubServiceConfig represents GitHub service configuration
 type GitHubServiceConfig struct {
-	Token        string
-	BaseURL      string
-	UserAgent    string
-	Timeout      time.Duration
-	MaxRetries   int
-	RateLimitMin int
+	Token             string
+	BaseURL           string
+	UserAgent         string
+	Timeout           time.Duration
+	MaxRetries        int
+	ETagCacheBytes    int
+	RateLimitMin      int
+	ReservePercent    int
+	ReserveMaxWait    time.Duration
+	DegradePercent    int
+	Transport         GitHubTransportConfig
+	UseGraphQL        bool
+	AppID             int64
+	AppInstallationID int64
+	AppPrivateKey     string
+	AppPrivateKeyPath string
 }
 
 // RegisterGitHubService registers GitHub as an HTTP service in GoFr
 func RegisterGitHubService(app *gofr.App, config GitHubServiceConfig) {
 	// Register GitHub API as an HTTP service
+	if !configureGitHubTransport(config.Transport) {
+		app.Logger().Warnf("Default HTTP transport replaced; GitHub connection pool settings not applied - component=github_client operation=register_service")
+	}
 	app.AddHTTPService("github", config.BaseURL)
+	configureGitHubRateBudget(config.ReservePercent, config.ReserveMaxWait, config.RateLimitMin)
+	configureGitHubRetries(config.MaxRetries)
+	configureGitHubETagCache(config.ETagCacheBytes)
+	configureGraphQLDegradation(config.DegradePercent)
+
+	if config.UseGraphQL {
+		setGitHubGraphQL(config.BaseURL)
+	}
+
+	if config.AppID == 0 {
+		return
+	}
+
+	// Requests authenticate as the GitHub App installation instead of with a personal access token
+	source, err := newGitHubAppTokenSource(config)
+	if err != nil {
+		app.Logger().Errorf("GitHub App authentication unavailable: %v - component=github_client operation=register_service", err)
+		return
+	}
+	setGitHubTokenSource(source)
+	app.Logger().Infof("Using GitHub App installation authentication - component=github_client operation=register_service app_id=%d installation_id=%d",
+		config.AppID, config.AppInstallationID)
+}
+
+// githubGet performs a GET request through the registered GitHub service, retrying transient
+// failures, and accounts for the calls. Requests for a cached response are sent with its ETag, and a
+// 304 is answered with the cached body
+func githubGet(ctx *gofr.Context, endpoint string, query map[string]any, headers map[string]string) (*http.Response, error) {
+	key := githubETagCacheKey(currentGitHubCredential(), endpoint, query, headers)
+	cached, ok := githubETagCacheState.lookup(key)
+	if ok {
+		headers = withIfNoneMatch(headers, cached.etag)
+	}
+
+	resp, err := retryGitHubRequest(ctx, endpoint, func() (*http.Response, error) {
+		return ctx.GetHTTPService("github").GetWithHeaders(withGitHubConnectionTrace(ctx), endpoint, query, headers)
+	})
+	if err != nil {
+		return resp, err
+	}
+	reportScanProgress(ctx, "")
+	return resolveConditionalGitHubResponse(ctx, endpoint, key, cached, resp)
+}
+
+// githubWrite sends a JSON payload through the registered GitHub service with a POST, PUT or PATCH request
+func githubWrite(ctx *gofr.Context, method, endpoint string, payload any) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode GitHub request: %w", err)
+	}
+	if err := awaitGitHubRateBudget(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	if err := awaitGitHubThrottle(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	if err := allowGitHubCall(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	headers := buildGitHubRequestHeaders()
+	headers["Content-Type"] = "application/json"
+
+	svc := ctx.GetHTTPService("github")
+	traced := withGitHubConnectionTrace(ctx)
+	var resp *http.Response
+	switch method {
+	case http.MethodPut:
+		resp, err = svc.PutWithHeaders(traced, endpoint, nil, body, headers)
+	case http.MethodPatch:
+		resp, err = svc.PatchWithHeaders(traced, endpoint, nil, body, headers)
+	default:
+		resp, err = svc.PostWithHeaders(traced, endpoint, nil, body, headers)
+	}
+	recordGitHubCall(ctx, resp, err)
+	observeGitHubResponse(ctx, endpoint, resp)
+	if err == nil {
+		recordGitHubAPICall(ctx)
+	}
+	return resp, err
 }
 
 // fetchGitHubOrganizationWithService fetches organization data using GoFr HTTP service
@@ -36,6 +130,7 @@ func fetchGitHubOrganizationWithService(ctx *gofr.Context, orgName string) (GitH
 	// Create span for tracking organization fetch
 	span := createGitHubScanSpan(ctx, orgName, "fetch_organization")
 	defer finishSpan(span)
+	ctx = withSpanContext(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(ctx, "github_fetch_organization")
@@ -116,7 +211,6 @@ func fetchGitHubOrganizationWithService(ctx *gofr.Context, orgName string) (GitH
 
 // makeGitHubOrgAPIRequest makes API request to GitHub for organization data (Pure Core)
 func makeGitHubOrgAPIRequest(ctx *gofr.Context, orgName string) (*http.Response, error) {
-	githubSvc := ctx.GetHTTPService("github")
 	headers := buildGitHubRequestHeaders()
 
 	// Log API request with structured context
@@ -132,7 +226,7 @@ func makeGitHubOrgAPIRequest(ctx *gofr.Context, orgName string) (*http.Response,
 	apiTimer := startPerformanceTimer(ctx, "github_api_call")
 	defer stopPerformanceTimer(apiTimer)
 
-	resp, err := githubSvc.GetWithHeaders(ctx, fmt.Sprintf("orgs/%s", orgName), nil, headers)
+	resp, err := githubGet(ctx, fmt.Sprintf("orgs/%s", orgName), nil, headers)
 	if err != nil {
 		errCtx := ErrorContext{
 			Error:       err,
@@ -239,6 +333,7 @@ func fetchGitHubRepositoriesWithService(ctx *gofr.Context, orgName string, maxRe
 	// Create span for tracking repository fetch
 	span := createGitHubScanSpan(ctx, orgName, "fetch_repositories")
 	defer finishSpan(span)
+	ctx = withSpanContext(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(ctx, "github_fetch_repositories")
@@ -345,7 +440,7 @@ func fetchAllRepositoryPages(ctx *gofr.Context, githubSvc any, orgName string, m
 	})
 
 	for len(allRepos) < maxRepos {
-		repos, shouldContinue, err := fetchRepositoryPage(ctx, githubSvc, orgName, page, perPage)
+		repos, shouldContinue, err := fetchRepositoryPage(ctx, githubSvc, orgName, page, perPage, "updated")
 		if err != nil {
 			errCtx := ErrorContext{
 				Error:       err,
@@ -365,6 +460,9 @@ func fetchAllRepositoryPages(ctx *gofr.Context, githubSvc any, orgName string, m
 			return nil, err
 		}
 
+		if err := reserveScanItems(ctx, ScanBufferRepositories, len(repos)); err != nil {
+			return nil, err
+		}
 		allRepos = append(allRepos, repos...)
 
 		// Log pagination progress
@@ -395,8 +493,8 @@ func fetchAllRepositoryPages(ctx *gofr.Context, githubSvc any, orgName string, m
 	return limitRepositories(ctx, allRepos, maxRepos, orgName), nil
 }
 
-// fetchRepositoryPage fetches a single page of repositories
-func fetchRepositoryPage(ctx *gofr.Context, githubSvc any, orgName string, page, perPage int) ([]GitHubRepository, bool, error) {
+// fetchRepositoryPage fetches a single page of repositories, most recent first by the sort field
+func fetchRepositoryPage(ctx *gofr.Context, githubSvc any, orgName string, page, perPage int, sort string) ([]GitHubRepository, bool, error) {
 	// Start timer for page fetch
 	pageTimer := startPerformanceTimer(ctx, fmt.Sprintf("github_fetch_page_%d", page))
 	defer stopPerformanceTimer(pageTimer)
@@ -409,7 +507,7 @@ func fetchRepositoryPage(ctx *gofr.Context, githubSvc any, orgName string, page,
 		"per_page":     perPage,
 	})
 
-	resp, err := executeRepositoryRequest(ctx, githubSvc, orgName, page, perPage)
+	resp, err := executeRepositoryRequest(ctx, githubSvc, orgName, page, perPage, sort)
 	if err != nil {
 		return nil, false, err
 	}
@@ -443,11 +541,12 @@ func fetchRepositoryPage(ctx *gofr.Context, githubSvc any, orgName string, page,
 }
 
 // executeRepositoryRequest executes a repository API request
-func executeRepositoryRequest(ctx *gofr.Context, githubSvc any, orgName string, page, perPage int) (*http.Response, error) {
+func executeRepositoryRequest(ctx *gofr.Context, githubSvc any, orgName string, page, perPage int, sort string) (*http.Response, error) {
 	query := map[string]any{
-		"page":     fmt.Sprintf("%d", page),
-		"per_page": fmt.Sprintf("%d", perPage),
-		"sort":     "updated",
+		"page":      fmt.Sprintf("%d", page),
+		"per_page":  fmt.Sprintf("%d", perPage),
+		"sort":      sort,
+		"direction": "desc",
 	}
 
 	headers := buildGitHubRequestHeaders()
@@ -469,9 +568,7 @@ func executeRepositoryRequest(ctx *gofr.Context, githubSvc any, orgName string,
 	apiTimer := startPerformanceTimer(ctx, "github_api_call_repos")
 	defer stopPerformanceTimer(apiTimer)
 	
-	// Get the GitHub service from context (same pattern as working organization request)
-	githubHttpSvc := ctx.GetHTTPService("github")
-	resp, err := githubHttpSvc.GetWithHeaders(ctx, endpoint, query, headers)
+	resp, err := githubGet(ctx, endpoint, query, headers)
 	if err != nil {
 		errCtx := ErrorContext{
 			Error:       err,
@@ -546,6 +643,12 @@ func validateRepositoryResponse(ctx *gofr.Context, resp *http.Response, orgName
 
 // decodeRepositoryResponse decodes the JSON response into GitHubRepository slice
 func decodeRepositoryResponse(ctx *gofr.Context, resp *http.Response, orgName string) ([]GitHubRepository, error) {
+	if cached, ok := cachedGitHubDecoded(resp); ok {
+		if repos, ok := cached.([]GitHubRepository); ok {
+			return append([]GitHubRepository(nil), repos...), nil
+		}
+	}
+
 	var repos []GitHubRepository
 	if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
 		errCtx := ErrorContext{
@@ -576,6 +679,7 @@ func decodeRepositoryResponse(ctx *gofr.Context, resp *http.Response, orgName st
 		"content_type": resp.Header.Get("Content-Type"),
 	})
 
+	rememberGitHubDecoded(resp, append([]GitHubRepository(nil), repos...))
 	return repos, nil
 }
 
@@ -609,6 +713,7 @@ func fetchGitHubTeamsWithService(ctx *gofr.Context, orgName string, maxTeams int
 	// Create span for tracking team fetch
 	span := createGitHubScanSpan(ctx, orgName, "fetch_teams")
 	defer finishSpan(span)
+	ctx = withSpanContext(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(ctx, "github_fetch_teams")
@@ -666,8 +771,6 @@ func fetchGitHubTeamsWithService(ctx *gofr.Context, orgName string, maxTeams int
 		}
 	}
 
-	githubSvc := ctx.GetHTTPService("github")
-
 	// Create batch logger for team pagination
 	batchLogger := createBatchLogger(ctx, "team_pagination", maxTeams)
 	defer batchLogger.finishBatch()
@@ -704,7 +807,7 @@ func fetchGitHubTeamsWithService(ctx *gofr.Context, orgName string, maxTeams int
 			"endpoint":     endpoint,
 		})
 
-		resp, err := githubSvc.GetWithHeaders(ctx, endpoint, query, headers)
+		resp, err := githubGet(ctx, endpoint, query, headers)
 		if err != nil {
 			stopPerformanceTimer(pageTimer)
 			errCtx := ErrorContext{
@@ -807,6 +910,9 @@ func fetchGitHubTeamsWithService(ctx *gofr.Context, orgName string, maxTeams int
 			break
 		}
 
+		if err := reserveScanItems(ctx, ScanBufferTeams, len(teams)); err != nil {
+			return nil, err
+		}
 		allTeams = append(allTeams, teams...)
 
 		// Log pagination progress
@@ -868,6 +974,7 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 	// Create span for tracking CODEOWNERS fetch
 	span := createGitHubScanSpan(ctx, owner, "fetch_codeowners")
 	defer finishSpan(span)
+	ctx = withSpanContext(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(ctx, "github_fetch_codeowners")
@@ -927,13 +1034,10 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 		}
 	}
 
-	githubSvc := ctx.GetHTTPService("github")
-
-	// Try different CODEOWNERS locations
-	locations := []string{
-		fmt.Sprintf("repos/%s/%s/contents/CODEOWNERS", owner, repo),
-		fmt.Sprintf("repos/%s/%s/contents/.github/CODEOWNERS", owner, repo),
-		fmt.Sprintf("repos/%s/%s/contents/docs/CODEOWNERS", owner, repo),
+	// Try each configured CODEOWNERS location in order of precedence
+	var locations []string
+	for _, location := range currentCodeownersSearch().locations {
+		locations = append(locations, fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, location.path))
 	}
 
 	logInfo(ctx, "Searching for CODEOWNERS file in multiple locations", LogFields{
@@ -958,15 +1062,15 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 		})
 
 		headers := buildGitHubRequestHeaders()
-		resp, err := githubSvc.GetWithHeaders(ctx, location, nil, headers)
+		resp, err := githubGet(ctx, location, nil, headers)
 		if err != nil {
 			stopPerformanceTimer(locationTimer)
 			logDebug(ctx, "CODEOWNERS location request failed", LogFields{
-				"component":  "github_client",
-				"operation":  "check_location",
-				"location":   location,
-				"error":      err.Error(),
-				"attempt":    i + 1,
+				"component": "github_client",
+				"operation": "check_location",
+				"location":  location,
+				"error":     err.Error(),
+				"attempt":   i + 1,
 			})
 			metrics.recordErrorCount("github_client", "location_request_error")
 			continue
@@ -979,6 +1083,20 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 		// Record API call metrics
 		metrics.recordAPICallCount("github", "codeowners", resp.StatusCode)
 
+		if cached, ok := cachedGitHubDecoded(resp); ok && resp.StatusCode == http.StatusOK {
+			if codeowners, ok := cached.(GitHubCodeowners); ok {
+				stopPerformanceTimer(locationTimer)
+				logDebug(ctx, "CODEOWNERS file unchanged", LogFields{
+					"component":  "github_client",
+					"operation":  "file_unchanged",
+					"owner":      owner,
+					"repository": repo,
+					"location":   location,
+				})
+				return codeowners, nil
+			}
+		}
+
 		if resp.StatusCode == http.StatusOK {
 			logInfo(ctx, "CODEOWNERS file found", LogFields{
 				"component":  "github_client",
@@ -990,6 +1108,8 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 			})
 
 			var fileContent struct {
+				Path    string `json:"path"`
+				SHA     string `json:"sha"`
 				Content string `json:"content"`
 			}
 
@@ -1036,11 +1156,17 @@ func fetchGitHubCodeownersWithService(ctx *gofr.Context, owner, repo string) (Gi
 				"service":    "codeowners-scanner",
 			})
 
-			return GitHubCodeowners{
+			decoded, _ := base64.StdEncoding.DecodeString(fileContent.Content)
+			codeowners := GitHubCodeowners{
 				Repository: fmt.Sprintf("%s/%s", owner, repo),
+				Path:       fileContent.Path,
+				SHA:        fileContent.SHA,
+				Content:    string(decoded),
 				Rules:      rules,
 				Errors:     []GitHubCodeownersError{},
-			}, nil
+			}
+			rememberGitHubDecoded(resp, codeowners)
+			return codeowners, nil
 		} else {
 			stopPerformanceTimer(locationTimer)
 			logDebug(ctx, "CODEOWNERS not found at location", LogFields{
@@ -1251,9 +1377,12 @@ func extractResponseHeaders(resp *http.Response) map[string]string {
 
 // buildGitHubRequestHeaders builds headers for GitHub API requests (Pure Core)
 func buildGitHubRequestHeaders() map[string]string {
-	// Note: In a real implementation, we would get the token from configuration
-	// For now, we'll use a placeholder that expects GITHUB_TOKEN environment variable
-	token := os.Getenv("GITHUB_TOKEN")
+	// GitHub App installation tokens are refreshed here before they expire; a failed refresh
+	// leaves the request unauthenticated so GitHub's 401 surfaces through the normal error path
+	token, err := currentGitHubToken(context.Background())
+	if err != nil {
+		token = ""
+	}
 
 	headers := map[string]string{
 		"Accept":     "application/vnd.github.v3+json",
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// GitHubTransportConfig tunes the connection pool of the HTTP transport behind the GitHub service
+type GitHubTransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	HTTP2               bool
+}
+
+// applyGitHubTransportConfig sets the pool limits and protocol of a transport. Go keeps only two
+// idle connections per host by default, so concurrent scans against api.github.com close and
+// reopen TLS connections unless the per-host limit is raised. (Pure Core)
+func applyGitHubTransportConfig(transport *http.Transport, config GitHubTransportConfig) {
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	transport.ForceAttemptHTTP2 = config.HTTP2
+	if !config.HTTP2 {
+		// A non-nil, empty map disables the transport's automatic HTTP/2 upgrade
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
+
+// configureGitHubTransport tunes the default transport, which GoFr's HTTP services wrap with
+// tracing; other outbound clients of the process share the tuned pool. Reports false when the
+// default transport has been replaced by one it cannot tune.
+func configureGitHubTransport(config GitHubTransportConfig) bool {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return false
+	}
+	applyGitHubTransportConfig(transport, config)
+	return true
+}
+
+// withGitHubConnectionTrace returns ctx with a client trace recording whether each GitHub request
+// reused a pooled connection and how long acquiring a new one took
+func withGitHubConnectionTrace(ctx *gofr.Context) *gofr.Context {
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	var started time.Time
+
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			started = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			metrics.recordCounter("github_http_connections_total", 1, MetricLabels{
+				"reused":   strconv.FormatBool(info.Reused),
+				"was_idle": strconv.FormatBool(info.WasIdle),
+			})
+			if !info.Reused && !started.IsZero() {
+				metrics.recordDuration("github_http_connect_duration", time.Since(started), MetricLabels{})
+			}
+		},
+	}
+
+	traced := *ctx
+	traced.Context = httptrace.WithClientTrace(ctx.Context, trace)
+	return &traced
+}
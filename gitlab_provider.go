@@ -0,0 +1,443 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// gitlabPageSize is the page size of GitLab list requests, the API's maximum
+const gitlabPageSize = 100
+
+// gitlabCodeownersLocations are the paths GitLab reads a CODEOWNERS file from, in its order of precedence
+var gitlabCodeownersLocations = []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// gitlabSectionPattern matches a CODEOWNERS section header: an optional ^, the section name in brackets,
+// an optional approval count in brackets and the section's default owners
+var gitlabSectionPattern = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[(\d+)\])?\s*(.*)$`)
+
+// GitLabAPIError represents GitLab API errors that implement GoFr error patterns
+type GitLabAPIError struct {
+	AppError
+}
+
+// newGitLabAPIError creates an external error answered with status, classified like GitHub errors (Pure Core)
+func newGitLabAPIError(code, message, details string, status int) GitLabAPIError {
+	return GitLabAPIError{AppError: newGitHubAPIError(code, message, details, status).AppError}
+}
+
+// Error implements the error interface for GitLabAPIError
+func (e GitLabAPIError) Error() string {
+	return fmt.Sprintf("GitLab API error [%s]: %s - %s", e.Code, e.Message, e.Details)
+}
+
+// gitlabGroup is a group of the GitLab groups API
+type gitlabGroup struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	FullName    string    `json:"full_name"`
+	FullPath    string    `json:"full_path"`
+	Description string    `json:"description"`
+	WebURL      string    `json:"web_url"`
+	Visibility  string    `json:"visibility"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// gitlabProject is a project of the GitLab projects API
+type gitlabProject struct {
+	ID                int       `json:"id"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       string    `json:"description"`
+	WebURL            string    `json:"web_url"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+	DefaultBranch     string    `json:"default_branch"`
+	Topics            []string  `json:"topics"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	ForkedFromProject *struct {
+		ID int `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+// gitlabMember is a member of a GitLab group
+type gitlabMember struct {
+	Username string `json:"username"`
+	BotUser  bool   `json:"bot"`
+}
+
+// gitlabFile is a repository file of the GitLab repository files API
+type gitlabFile struct {
+	FilePath string `json:"file_path"`
+	BlobID   string `json:"blob_id"`
+	Content  string `json:"content"`
+}
+
+// registerGitLabService registers GitLab as an HTTP service when a GitLab token is configured
+func registerGitLabService(app *gofr.App, config GitLabConfig) {
+	if config.Token == "" {
+		return
+	}
+	app.AddHTTPService("gitlab", config.BaseURL)
+	app.Logger().Infof("Scanning GitLab groups - component=gitlab_client operation=register_service base_url=%s", config.BaseURL)
+}
+
+// buildGitLabRequestHeaders builds the headers of GitLab API requests, authenticated with GITLAB_TOKEN
+func buildGitLabRequestHeaders() map[string]string {
+	headers := map[string]string{
+		"Accept":     "application/json",
+		"User-Agent": "overseer-codeowners-scanner/1.0",
+	}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		headers["PRIVATE-TOKEN"] = token
+	}
+	return headers
+}
+
+// gitlabGet performs a GET request through the registered GitLab service and accounts for the call
+func gitlabGet(ctx *gofr.Context, endpoint string, query map[string]any) (*http.Response, error) {
+	resp, err := ctx.GetHTTPService("gitlab").GetWithHeaders(ctx, endpoint, query, buildGitLabRequestHeaders())
+	if err != nil {
+		return nil, err
+	}
+	recordGitHubAPICall(ctx)
+	newMetricsCollector(ctx, "codeowners-scanner").recordAPICallCount("gitlab", endpoint, resp.StatusCode)
+	reportScanProgress(ctx, "")
+	return resp, nil
+}
+
+// decodeGitLabResponse decodes a successful GitLab response into target and closes its body
+func decodeGitLabResponse(resp *http.Response, operation string, target interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		details, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return newGitLabAPIError(
+			"gitlab_"+operation,
+			fmt.Sprintf("GitLab returned status %d", resp.StatusCode),
+			strings.TrimSpace(string(details)),
+			resp.StatusCode,
+		)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// fetchGitLabPages lists every item of a paginated GitLab endpoint, up to limit items when limit is
+// positive; pages are followed through the X-Next-Page header
+func fetchGitLabPages[T any](ctx *gofr.Context, endpoint string, query map[string]any, operation string, limit int) ([]T, error) {
+	items := []T{}
+	for page := 1; page > 0; {
+		pageQuery := map[string]any{"per_page": gitlabPageSize, "page": page}
+		for key, value := range query {
+			pageQuery[key] = value
+		}
+
+		resp, err := gitlabGet(ctx, endpoint, pageQuery)
+		if err != nil {
+			return nil, err
+		}
+		next, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+
+		var pageItems []T
+		if err := decodeGitLabResponse(resp, operation, &pageItems); err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		page = next
+	}
+	return items, nil
+}
+
+// convertGitLabGroup maps a GitLab group to the organization of the graph, keyed by its full path (Pure Core)
+func convertGitLabGroup(group gitlabGroup) GitHubOrganization {
+	return GitHubOrganization{
+		ID:          group.ID,
+		Login:       group.FullPath,
+		Name:        group.FullName,
+		Description: group.Description,
+		URL:         group.WebURL,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.CreatedAt,
+	}
+}
+
+// convertGitLabProject maps a GitLab project to a repository named by its path with namespace (Pure Core)
+func convertGitLabProject(project gitlabProject) GitHubRepository {
+	return GitHubRepository{
+		ID:          project.ID,
+		Name:        project.Path,
+		FullName:    project.PathWithNamespace,
+		Description: project.Description,
+		URL:         project.WebURL,
+		Private:     project.Visibility != "public",
+		Visibility:  project.Visibility,
+		Archived:    project.Archived,
+		Fork:        project.ForkedFromProject != nil,
+		Topics:      project.Topics,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.LastActivityAt,
+		PushedAt:    project.LastActivityAt,
+	}
+}
+
+// convertGitLabSubgroup maps a subgroup to a team whose slug is its path below the scanned group, so
+// its key is the subgroup's full path. Private subgroups, visible only to their members, are secret (Pure Core)
+func convertGitLabSubgroup(groupPath string, subgroup gitlabGroup) GitHubTeam {
+	privacy := teamPrivacyClosed
+	if subgroup.Visibility == "private" {
+		privacy = teamPrivacySecret
+	}
+	return GitHubTeam{
+		ID:          subgroup.ID,
+		Slug:        strings.TrimPrefix(subgroup.FullPath, groupPath+"/"),
+		Name:        subgroup.Name,
+		Description: subgroup.Description,
+		URL:         subgroup.WebURL,
+		Privacy:     privacy,
+	}
+}
+
+// normalizeGitLabOwners rewrites owners of a GitLab CODEOWNERS rule to the graph's @org/team form:
+// subgroups of the scanned group keep their path below it behind the group's last path segment, so
+// the team slug is that path. Role owners such as @@maintainer name no user or team and are dropped (Pure Core)
+func normalizeGitLabOwners(groupPath string, owners []string) []string {
+	prefix := "@" + groupPath + "/"
+	normalized := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		switch {
+		case strings.HasPrefix(owner, "@@"):
+			continue
+		case len(owner) > len(prefix) && strings.EqualFold(owner[:len(prefix)], prefix):
+			normalized = append(normalized, "@"+path.Base(groupPath)+"/"+owner[len(prefix):])
+		default:
+			normalized = append(normalized, owner)
+		}
+	}
+	return normalized
+}
+
+// parseGitLabCodeowners parses a GitLab CODEOWNERS file. Rules keep the section they belong to, whether
+// it is optional and its required approvals; a rule without owners inside a section takes the
+// section's default owners. Rules are numbered in file order like GitHub rules (Pure Core)
+func parseGitLabCodeowners(groupPath, content string) []GitHubCodeownersRule {
+	rules := []GitHubCodeownersRule{}
+	var section string
+	var optional bool
+	var approvals int
+	var defaults []string
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := gitlabSectionPattern.FindStringSubmatch(line); match != nil {
+			section, optional = strings.TrimSpace(match[2]), match[1] != ""
+			approvals, _ = strconv.Atoi(match[3])
+			defaults = normalizeGitLabOwners(groupPath, strings.Fields(match[4]))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		owners := normalizeGitLabOwners(groupPath, fields[1:])
+		if len(fields) == 1 && section != "" {
+			owners = defaults
+		}
+		rules = append(rules, GitHubCodeownersRule{
+			Pattern:   fields[0],
+			Owners:    owners,
+			Line:      len(rules) + 1,
+			Section:   section,
+			Optional:  optional,
+			Approvals: approvals,
+		})
+	}
+	return rules
+}
+
+// fetchGitLabGroupMemberAccounts lists the direct members of a group, bot users typed as bots; a group
+// GitLab does not know has no members
+func fetchGitLabGroupMemberAccounts(ctx *gofr.Context, groupPath string) ([]GitHubUser, error) {
+	members, err := fetchGitLabPages[gitlabMember](ctx, "groups/"+url.PathEscape(groupPath)+"/members", nil, "list_group_members", 0)
+	if err != nil {
+		var apiErr GitLabAPIError
+		if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusNotFound {
+			return []GitHubUser{}, nil
+		}
+		return nil, err
+	}
+
+	accounts := make([]GitHubUser, 0, len(members))
+	for _, member := range members {
+		account := GitHubUser{Login: member.Username}
+		if member.BotUser {
+			account.Type = githubBotAccountType
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// fetchGitLabCodeowners reads the first CODEOWNERS file found in a project's default branch among
+// GitLab's locations and the configured extra paths; projects without one have no rules
+func fetchGitLabCodeowners(ctx *gofr.Context, groupPath string, project gitlabProject) (GitHubCodeowners, error) {
+	codeowners := GitHubCodeowners{
+		Repository: project.PathWithNamespace,
+		Rules:      []GitHubCodeownersRule{},
+		Errors:     []GitHubCodeownersError{},
+	}
+	if project.DefaultBranch == "" {
+		return codeowners, nil
+	}
+
+	locations := append([]string{}, gitlabCodeownersLocations...)
+	for _, location := range currentCodeownersSearch().locations[len(builtinCodeownersLocations):] {
+		locations = append(locations, location.path)
+	}
+
+	for _, location := range locations {
+		resp, err := gitlabGet(ctx, fmt.Sprintf("projects/%d/repository/files/%s", project.ID, url.PathEscape(location)), map[string]any{
+			"ref": project.DefaultBranch,
+		})
+		if err != nil {
+			return codeowners, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			continue
+		}
+
+		var file gitlabFile
+		if err := decodeGitLabResponse(resp, "get_codeowners", &file); err != nil {
+			return codeowners, err
+		}
+		content, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return codeowners, fmt.Errorf("failed to decode CODEOWNERS of %s: %w", project.PathWithNamespace, err)
+		}
+
+		codeowners.Path, codeowners.SHA, codeowners.Content = file.FilePath, file.BlobID, string(content)
+		codeowners.Rules = parseGitLabCodeowners(groupPath, codeowners.Content)
+		return codeowners, nil
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("codeowners_not_found", 1, MetricLabels{
+		"owner":      groupPath,
+		"repository": project.Path,
+		"service":    "codeowners-scanner",
+	})
+	return codeowners, nil
+}
+
+// fetchGitLabScanSource reads a GitLab group as an organization: the projects of the group and its
+// subgroups as repositories, its subgroups with their members as teams and each project's CODEOWNERS
+// file. Incremental scans are not supported (Orchestrator)
+func fetchGitLabScanSource(ctx *gofr.Context, _ *AppDependencies, request ScanRequest) (scanSourceData, error) {
+	if request.Mode == ScanModeIncremental {
+		return scanSourceData{}, &gofrhttp.ErrorInvalidParam{Params: []string{"mode"}}
+	}
+	groupID := url.PathEscape(request.Organization)
+
+	reportScanProgress(ctx, ScanPhaseFetchOrganization)
+	resp, err := gitlabGet(ctx, "groups/"+groupID, map[string]any{"with_projects": false})
+	if err != nil {
+		return scanSourceData{}, err
+	}
+	var group gitlabGroup
+	if err := decodeGitLabResponse(resp, "get_group", &group); err != nil {
+		return scanSourceData{}, err
+	}
+	org := convertGitLabGroup(group)
+
+	reportScanProgress(ctx, ScanPhaseFetchRepositories)
+	projects, err := fetchGitLabPages[gitlabProject](ctx, "groups/"+groupID+"/projects", map[string]any{
+		"include_subgroups": true,
+		"order_by":          "path",
+		"sort":              "asc",
+	}, "list_group_projects", request.MaxRepos)
+	if err != nil {
+		return scanSourceData{}, err
+	}
+	if err := reserveScanItems(ctx, ScanBufferRepositories, len(projects)); err != nil {
+		return scanSourceData{}, err
+	}
+	repos := make([]GitHubRepository, 0, len(projects))
+	for _, project := range projects {
+		repos = append(repos, convertGitLabProject(project))
+	}
+
+	var teams []GitHubTeam
+	var topics []GitHubTopic
+	reportScanProgress(ctx, ScanPhaseFetchTeams)
+	if request.UseTopics {
+		topics = collectTopicsFromRepositories(repos)
+	} else {
+		subgroups, err := fetchGitLabPages[gitlabGroup](ctx, "groups/"+groupID+"/descendant_groups", nil, "list_descendant_groups", request.MaxTeams)
+		if err != nil {
+			return scanSourceData{}, err
+		}
+		if err := reserveScanItems(ctx, ScanBufferTeams, len(subgroups)); err != nil {
+			return scanSourceData{}, err
+		}
+		for _, subgroup := range subgroups {
+			team := convertGitLabSubgroup(group.FullPath, subgroup)
+			members, err := fetchGitLabGroupMemberAccounts(ctx, subgroup.FullPath)
+			if err != nil {
+				logWarn(ctx, "Failed to list GitLab group members", LogFields{
+					"component": "gitlab_client",
+					"operation": "list_group_members",
+					"group":     subgroup.FullPath,
+					"error":     err.Error(),
+				})
+			} else {
+				team.Members = memberLogins(members, false)
+			}
+			teams = append(teams, team)
+		}
+	}
+
+	reportScanProgress(ctx, ScanPhaseFetchCodeowners)
+	codeowners := []GitHubCodeowners{}
+	for i, project := range projects {
+		reportScanBatchProgress(ctx, i, len(projects))
+		codeowner, err := fetchGitLabCodeowners(ctx, group.FullPath, project)
+		if err != nil {
+			return scanSourceData{}, err
+		}
+		if len(codeowner.Rules) == 0 {
+			continue
+		}
+		if err := reserveScanItems(ctx, ScanBufferRules, len(codeowner.Rules)); err != nil {
+			return scanSourceData{}, err
+		}
+		codeowners = append(codeowners, codeowner)
+	}
+
+	logInfo(ctx, "GitLab group fetched", LogFields{
+		"component":        "gitlab_client",
+		"operation":        "fetch_group",
+		"group":            group.FullPath,
+		"projects":         len(repos),
+		"subgroups":        len(teams),
+		"codeowners_files": len(codeowners),
+	})
+	return scanSourceData{org: org, repos: repos, teams: teams, topics: topics, codeowners: codeowners}, nil
+}
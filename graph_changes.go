@@ -0,0 +1,191 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Long-poll bounds for graph change subscriptions
+const (
+	defaultGraphChangesWait = 30 * time.Second
+	maxGraphChangesWait     = 60 * time.Second
+
+	// graphChangesRecheckInterval bounds how long a waiter relies on in-process notifications alone,
+	// so activations made by other instances are still picked up
+	graphChangesRecheckInterval = 5 * time.Second
+)
+
+// GraphChangeNotifier wakes long-poll waiters when an organization's active scan changes or a webhook
+// updates it in place
+type GraphChangeNotifier struct {
+	mu      sync.Mutex
+	waiters map[string]chan struct{}
+}
+
+// GraphChangesResponse represents the result of a graph change long-poll
+type GraphChangesResponse struct {
+	Organization string `json:"organization"`
+	Changed      bool   `json:"changed"`
+	Cursor       string `json:"cursor"`
+	ActivatedAt  string `json:"activated_at,omitempty"`
+	ChangedAt    string `json:"changed_at,omitempty"`
+}
+
+// newGraphChangeNotifier creates an empty change notifier
+func newGraphChangeNotifier() *GraphChangeNotifier {
+	return &GraphChangeNotifier{waiters: make(map[string]chan struct{})}
+}
+
+// subscribe returns a channel that is closed on the next change for the organization
+func (n *GraphChangeNotifier) subscribe(orgLogin string) <-chan struct{} {
+	if n == nil {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ch, exists := n.waiters[orgLogin]
+	if !exists {
+		ch = make(chan struct{})
+		n.waiters[orgLogin] = ch
+	}
+	return ch
+}
+
+// notify wakes every waiter subscribed to the organization
+func (n *GraphChangeNotifier) notify(orgLogin string) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if ch, exists := n.waiters[orgLogin]; exists {
+		close(ch)
+		delete(n.waiters, orgLogin)
+	}
+}
+
+// parseGraphChangesTimeout parses the timeout query parameter as a duration or whole seconds (Pure Core)
+func parseGraphChangesTimeout(value string) (time.Duration, bool) {
+	if value == "" {
+		return defaultGraphChangesWait, true
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, false
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	if timeout <= 0 || timeout > maxGraphChangesWait {
+		return 0, false
+	}
+	return timeout, true
+}
+
+// buildGraphCursorQuery builds a query returning the organization's change cursor: the active scan,
+// followed by the count of webhook changes applied to the graph once there are any (Pure Core)
+func buildGraphCursorQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: org.active_scan_id})
+		RETURN coalesce(org.active_scan_id, '') + CASE
+				WHEN coalesce(org.graph_revision, 0) > 0 THEN ':' + toString(org.graph_revision)
+				ELSE ''
+			END AS cursor,
+			scan.activated_at AS activated_at,
+			org.graph_changed_at AS changed_at
+	`
+}
+
+// fetchGraphCursor reads the current change cursor of an organization
+func fetchGraphCursor(ctx *gofr.Context, deps *AppDependencies, orgName string) (GraphChangesResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return GraphChangesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildGraphCursorQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return GraphChangesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	if len(result.Records) == 0 {
+		return GraphChangesResponse{}, &gofrhttp.ErrorEntityNotFound{
+			Name:  "organization",
+			Value: orgName,
+		}
+	}
+
+	return GraphChangesResponse{
+		Organization: orgName,
+		Cursor:       getStringFromMap(result.Records[0], "cursor"),
+		ActivatedAt:  getStringFromMap(result.Records[0], "activated_at"),
+		ChangedAt:    getStringFromMap(result.Records[0], "changed_at"),
+	}, nil
+}
+
+// waitForGraphChanges blocks until the organization's cursor moves past since or the timeout elapses
+func waitForGraphChanges(ctx *gofr.Context, deps *AppDependencies, orgName, since string, timeout time.Duration) (GraphChangesResponse, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		// Subscribe before reading so an activation between the read and the wait is not missed
+		changed := deps.GraphChanges.subscribe(orgName)
+
+		current, err := fetchGraphCursor(ctx, deps, orgName)
+		if err != nil {
+			return GraphChangesResponse{}, err
+		}
+
+		if current.Cursor != since {
+			current.Changed = true
+			return current, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return current, nil
+		}
+		if remaining > graphChangesRecheckInterval {
+			remaining = graphChangesRecheckInterval
+		}
+
+		select {
+		case <-changed:
+		case <-time.After(remaining):
+		case <-ctx.Done():
+			return current, nil
+		}
+	}
+}
+
+// handleWaitForGraphChanges long-polls until the organization graph changes or the timeout elapses
+func (h *AppHandler) handleWaitForGraphChanges(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	timeout, ok := parseGraphChangesTimeout(ctx.Param("timeout"))
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{
+			Params: []string{"timeout"},
+		}
+	}
+
+	return waitForGraphChanges(ctx, h.deps, orgName, ctx.Param("since"), timeout)
+}
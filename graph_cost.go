@@ -0,0 +1,356 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// GraphCounts are the element counts of the active scan of an organization
+type GraphCounts struct {
+	Repositories   int
+	Teams          int
+	Topics         int
+	Users          int
+	CodeownerEdges int
+	TeamOwnerEdges int
+	RepoTopicEdges int
+}
+
+// GraphCostEstimate is the expected size of a graph response
+type GraphCostEstimate struct {
+	Nodes int
+	Edges int
+}
+
+// graphCountsEntry is the counts of an organization for the scan they were read from
+type graphCountsEntry struct {
+	scanID string
+	counts GraphCounts
+}
+
+// GraphCountsCache holds graph counts per organization until a different scan becomes active
+type GraphCountsCache struct {
+	mu      sync.Mutex
+	entries map[string]graphCountsEntry
+}
+
+// GraphTooLargeError is returned when a full graph response would exceed the configured limits
+type GraphTooLargeError struct {
+	Organization string
+	Estimate     GraphCostEstimate
+	Limits       GraphLimitsConfig
+}
+
+// Error implements the error interface for GraphTooLargeError
+func (e GraphTooLargeError) Error() string {
+	return fmt.Sprintf("graph of %s is estimated at %d nodes and %d edges, above the limit of %d nodes and %d edges; "+
+		"request ?summarize=true for per-team totals or load it in pages with ?offset= and ?limit=",
+		e.Organization, e.Estimate.Nodes, e.Estimate.Edges, e.Limits.MaxNodes, e.Limits.MaxEdges)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (GraphTooLargeError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// newGraphCountsCache creates an empty graph counts cache
+func newGraphCountsCache() *GraphCountsCache {
+	return &GraphCountsCache{entries: make(map[string]graphCountsEntry)}
+}
+
+// load returns the cached counts of an organization if they were read from scanID
+func (c *GraphCountsCache) load(orgName, scanID string) (GraphCounts, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[orgName]
+	if !ok || entry.scanID != scanID {
+		return GraphCounts{}, false
+	}
+	return entry.counts, true
+}
+
+// store caches the counts of an organization for scanID
+func (c *GraphCountsCache) store(orgName, scanID string, counts GraphCounts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[orgName] = graphCountsEntry{scanID: scanID, counts: counts}
+}
+
+// buildActiveScanIDQuery builds a query returning the active scan of an organization (Pure Core)
+func buildActiveScanIDQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		RETURN coalesce(org.active_scan_id, '') AS scan_id
+	`
+}
+
+// buildGraphElementCountsQuery builds a query counting the graph elements of the active scan (Pure Core)
+func buildGraphElementCountsQuery(version Neo4jServerVersion) string {
+	active := "coalesce(r.scan_id, '') = scan_id"
+	owned := "coalesce(owns.scan_id, '') = scan_id AND " + active
+
+	// Pattern comprehensions cannot count distinct users, so older servers count them in a CALL subquery
+	usersMatch := "MATCH (org)-[owns:OWNS]->(:Repository)-[r:HAS_CODEOWNER]->(user:User) WHERE " + owned
+	usersCall := ""
+	users := "COUNT { " + usersMatch + " RETURN DISTINCT user }"
+	if !version.supportsCountSubqueries() {
+		usersCall = "CALL { WITH org, scan_id " + usersMatch + " RETURN count(DISTINCT user) AS distinct_users }"
+		users = "distinct_users"
+	}
+
+	return `
+		MATCH (org:Organization {login: $orgName})
+		WITH org, coalesce(org.active_scan_id, '') AS scan_id
+		` + usersCall + `
+		RETURN
+			` + buildCountExpression(version, "(org)-[r:OWNS]->(:Repository)", active) + ` AS repositories,
+			` + buildCountExpression(version, "(org)-[r:HAS_TEAM]->(:Team)", active) + ` AS teams,
+			` + buildCountExpression(version, "(org)-[r:HAS_TOPIC]->(:Topic)", active) + ` AS topics,
+			` + users + ` AS users,
+			` + buildCountExpression(version, "(org)-[owns:OWNS]->(:Repository)-[r:HAS_CODEOWNER]->(:User)", owned) + ` AS codeowner_edges,
+			` + buildCountExpression(version, "(org)-[owns:OWNS]->(:Repository)-[r:HAS_TEAM_OWNER]->(:Team)", owned) + ` AS team_owner_edges,
+			` + buildCountExpression(version, "(org)-[owns:OWNS]->(:Repository)-[r:HAS_TOPIC]->(:Topic)", owned) + ` AS repo_topic_edges
+	`
+}
+
+// convertToGraphCounts converts a counts record (Pure Core)
+func convertToGraphCounts(record map[string]interface{}) GraphCounts {
+	return GraphCounts{
+		Repositories:   getIntFromMap(record, "repositories"),
+		Teams:          getIntFromMap(record, "teams"),
+		Topics:         getIntFromMap(record, "topics"),
+		Users:          getIntFromMap(record, "users"),
+		CodeownerEdges: getIntFromMap(record, "codeowner_edges"),
+		TeamOwnerEdges: getIntFromMap(record, "team_owner_edges"),
+		RepoTopicEdges: getIntFromMap(record, "repo_topic_edges"),
+	}
+}
+
+// estimateGraphCost estimates the nodes and edges returned by the graph endpoint; custom graph
+// types are not counted (Pure Core)
+func estimateGraphCost(counts GraphCounts, groupBy GraphGroupBy) GraphCostEstimate {
+	estimate := GraphCostEstimate{
+		Nodes: 1 + counts.Repositories + counts.Users,
+		Edges: counts.Repositories + counts.CodeownerEdges,
+	}
+	if groupBy.includesTeams() {
+		estimate.Nodes += counts.Teams
+		estimate.Edges += counts.Teams + counts.TeamOwnerEdges
+	}
+	if groupBy.includesTopics() {
+		estimate.Nodes += counts.Topics
+		estimate.Edges += counts.Topics + counts.RepoTopicEdges
+	}
+	return estimate
+}
+
+// exceedsGraphLimits reports whether an estimate is above a configured limit; zero disables a limit (Pure Core)
+func exceedsGraphLimits(estimate GraphCostEstimate, limits GraphLimitsConfig) bool {
+	return (limits.MaxNodes > 0 && estimate.Nodes > limits.MaxNodes) ||
+		(limits.MaxEdges > 0 && estimate.Edges > limits.MaxEdges)
+}
+
+// fetchGraphCounts returns the graph counts of an organization, reading them from Neo4j only when
+// a scan other than the cached one is active (Orchestrator)
+func fetchGraphCounts(ctx *gofr.Context, deps *AppDependencies, orgName string) (GraphCounts, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return GraphCounts{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	params := map[string]interface{}{"orgName": orgName}
+	result, err := executeNeo4jReadQuery(ctx, session, buildActiveScanIDQuery(), params)
+	if err != nil {
+		return GraphCounts{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return GraphCounts{}, nil
+	}
+
+	scanID := getStringFromMap(result.Records[0], "scan_id")
+	if counts, ok := deps.GraphCounts.load(orgName, scanID); ok {
+		return counts, nil
+	}
+
+	result, err = executeNeo4jReadQuery(ctx, session, buildGraphElementCountsQuery(session.version), params)
+	if err != nil {
+		return GraphCounts{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return GraphCounts{}, nil
+	}
+
+	counts := convertToGraphCounts(result.Records[0])
+	deps.GraphCounts.store(orgName, scanID, counts)
+	return counts, nil
+}
+
+// checkGraphCost rejects a full graph request whose estimated size exceeds the configured limits (Orchestrator)
+func checkGraphCost(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy) error {
+	limits := deps.currentConfig().GraphLimits
+	if limits.MaxNodes <= 0 && limits.MaxEdges <= 0 {
+		return nil
+	}
+
+	counts, err := fetchGraphCounts(ctx, deps, orgName)
+	if err != nil {
+		return err
+	}
+
+	estimate := estimateGraphCost(counts, groupBy)
+	if !exceedsGraphLimits(estimate, limits) {
+		return nil
+	}
+
+	logWarn(ctx, "Rejected graph request above configured limits", LogFields{
+		"component":       "graph_cost",
+		"operation":       "check_graph_cost",
+		"organization":    orgName,
+		"estimated_nodes": estimate.Nodes,
+		"estimated_edges": estimate.Edges,
+		"max_nodes":       limits.MaxNodes,
+		"max_edges":       limits.MaxEdges,
+	})
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("graph_requests_rejected_total", 1, MetricLabels{
+		"organization": orgName,
+	})
+
+	return GraphTooLargeError{Organization: orgName, Estimate: estimate, Limits: limits}
+}
+
+// buildGraphSummaryQuery builds a query returning the organization and its teams, topics or both,
+// with the number of repositories each one covers (Pure Core)
+func buildGraphSummaryQuery(version Neo4jServerVersion, orgName string, groupBy GraphGroupBy) string {
+	validateOrgNameNotEmpty(orgName)
+	active := "coalesce(r.scan_id, '') = coalesce(org.active_scan_id, '')"
+
+	groups := ""
+	if groupBy.includesTeams() {
+		groups += `
+		OPTIONAL MATCH (org)-[membership:HAS_TEAM]->(grp:Team) WHERE coalesce(membership.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, groups, grp
+		WITH org, groups, CASE WHEN grp IS NULL THEN NULL ELSE {
+			id: grp.id,
+			type: 'team',
+			label: grp.name,
+			data: {
+				name: grp.name,
+				slug: grp.slug,
+				description: grp.description,
+				url: grp.url,
+				secret: coalesce(grp.privacy, '') = 'secret',
+				repositoryCount: ` + buildCountExpression(version, "(:Repository)-[r:HAS_TEAM_OWNER]->(grp)", active) + `
+			}
+		} END AS group_node
+		WITH org, groups, COLLECT(group_node) AS team_groups
+		WITH org, groups + team_groups AS groups
+	`
+	}
+	if groupBy.includesTopics() {
+		groups += `
+		OPTIONAL MATCH (org)-[membership:HAS_TOPIC]->(grp:Topic) WHERE coalesce(membership.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, groups, grp
+		WITH org, groups, CASE WHEN grp IS NULL THEN NULL ELSE {
+			id: grp.name,
+			type: 'topic',
+			label: grp.name,
+			data: {
+				name: grp.name,
+				count: grp.count,
+				repositoryCount: ` + buildCountExpression(version, "(:Repository)-[r:HAS_TOPIC]->(grp)", active) + `
+			}
+		} END AS group_node
+		WITH org, groups, COLLECT(group_node) AS topic_groups
+		WITH org, groups + topic_groups AS groups
+	`
+	}
+
+	return `
+		MATCH (org:Organization {login: $orgName})
+		WITH org, [] AS groups` + groups + `
+		RETURN {
+			id: org.id,
+			type: 'organization',
+			label: org.name,
+			data: {
+				login: org.login,
+				name: org.name,
+				description: org.description,
+				email: org.email,
+				url: org.url,
+				createdAt: org.created_at,
+				updatedAt: org.updated_at
+			}
+		} AS org_node,
+		groups
+	`
+}
+
+// buildGraphSummaryEdges links the organization to each team or topic node, typed after the node (Pure Core)
+func buildGraphSummaryEdges(orgNode GraphNode, groups []GraphNode) []GraphEdge {
+	edges := make([]GraphEdge, 0, len(groups))
+	for _, group := range groups {
+		prefix, edgeType, label := "has-team-", "has_team", "has team"
+		if group.Type == "topic" {
+			prefix, edgeType, label = "has-topic-", "has_topic", "has topic"
+		}
+		edges = append(edges, GraphEdge{
+			ID:     prefix + orgNode.ID + "-" + group.ID,
+			Source: orgNode.ID,
+			Target: group.ID,
+			Type:   edgeType,
+			Label:  label,
+		})
+	}
+	return edges
+}
+
+// getOrganizationGraphSummary returns the organization with its teams, or topics, and their repository
+// counts instead of every repository and user, for graphs too large to return in full (Orchestrator)
+func getOrganizationGraphSummary(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy) (GraphResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildGraphSummaryQuery(session.version, orgName, groupBy), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	response := GraphResponse{SchemaVersion: GraphSchemaVersion, Nodes: []GraphNode{}, Edges: []GraphEdge{}, Summarized: true}
+	if len(result.Records) == 0 {
+		return response, nil
+	}
+
+	record := result.Records[0]
+	report := newGraphConversionReport("node")
+	orgNodes := extractOrganizationNode(record, report)
+	if len(orgNodes) == 0 {
+		if err := reportGraphConversion(ctx, deps, orgName, report); err != nil {
+			return GraphResponse{}, err
+		}
+		return response, nil
+	}
+
+	groups := []GraphNode{}
+	if list, ok := record["groups"].([]interface{}); ok {
+		groups = convertListToGraphNodes(list, graphNodeRowOffsets["teams"], graphNodeSpacing, report)
+	}
+	if err := reportGraphConversion(ctx, deps, orgName, report); err != nil {
+		return GraphResponse{}, err
+	}
+
+	response.Nodes = append(orgNodes, groups...)
+	response.Edges = buildGraphSummaryEdges(orgNodes[0], groups)
+	return response, nil
+}
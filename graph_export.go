@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// Graph export formats accepted by ?format=
+const (
+	GraphExportFormatGraphML = "graphml"
+	GraphExportFormatGEXF    = "gexf"
+	GraphExportFormatDOT     = "dot"
+	GraphExportFormatCSV     = "csv"
+)
+
+// graphExportContentTypes maps each export format to the media type it is served with
+var graphExportContentTypes = map[string]string{
+	GraphExportFormatGraphML: "application/graphml+xml; charset=utf-8",
+	GraphExportFormatGEXF:    "application/gexf+xml; charset=utf-8",
+	GraphExportFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	GraphExportFormatCSV:     csvContentType,
+}
+
+// graphEdgeListCSVHeader is the header of the CSV edge list export
+var graphEdgeListCSVHeader = []string{"source", "target", "type", "label", "source_label", "source_type", "target_label", "target_type"}
+
+// graphDataKeys returns the data keys used by any node, sorted, so every format declares the same
+// node attributes (Pure Core)
+func graphDataKeys(nodes []GraphNode) []string {
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		for key, value := range node.Data {
+			if value != nil {
+				seen[key] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatGraphDataValue formats a node data value as an attribute value; nil values are omitted (Pure Core)
+func formatGraphDataValue(value interface{}) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// escapeGraphXML escapes text for an XML attribute (Pure Core)
+func escapeGraphXML(value string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
+
+// buildGraphML serializes a graph as GraphML with the node type, label and data as attributes (Pure Core)
+func buildGraphML(graph GraphResponse) string {
+	keys := graphDataKeys(graph.Nodes)
+
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	out.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	out.WriteString(`  <key id="type" for="node" attr.name="type" attr.type="string"/>` + "\n")
+	for i, key := range keys {
+		fmt.Fprintf(&out, `  <key id="d%d" for="node" attr.name="%s" attr.type="string"/>`+"\n", i, escapeGraphXML(key))
+	}
+	out.WriteString(`  <key id="edge_label" for="edge" attr.name="label" attr.type="string"/>` + "\n")
+	out.WriteString(`  <key id="edge_type" for="edge" attr.name="type" attr.type="string"/>` + "\n")
+	out.WriteString(`  <graph id="ownership" edgedefault="directed">` + "\n")
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&out, `    <node id="%s">`+"\n", escapeGraphXML(node.ID))
+		fmt.Fprintf(&out, `      <data key="label">%s</data>`+"\n", escapeGraphXML(node.Label))
+		fmt.Fprintf(&out, `      <data key="type">%s</data>`+"\n", escapeGraphXML(node.Type))
+		for i, key := range keys {
+			if value, ok := formatGraphDataValue(node.Data[key]); ok {
+				fmt.Fprintf(&out, `      <data key="d%d">%s</data>`+"\n", i, escapeGraphXML(value))
+			}
+		}
+		out.WriteString("    </node>\n")
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&out, `    <edge id="%s" source="%s" target="%s">`+"\n", escapeGraphXML(edge.ID), escapeGraphXML(edge.Source), escapeGraphXML(edge.Target))
+		fmt.Fprintf(&out, `      <data key="edge_label">%s</data>`+"\n", escapeGraphXML(edge.Label))
+		fmt.Fprintf(&out, `      <data key="edge_type">%s</data>`+"\n", escapeGraphXML(edge.Type))
+		out.WriteString("    </edge>\n")
+	}
+
+	out.WriteString("  </graph>\n</graphml>\n")
+	return out.String()
+}
+
+// buildGEXF serializes a graph as GEXF 1.3 with the node type and data as attributes and the
+// default layout as node positions (Pure Core)
+func buildGEXF(graph GraphResponse) string {
+	keys := graphDataKeys(graph.Nodes)
+
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString(`<gexf xmlns="http://gexf.net/1.3" xmlns:viz="http://gexf.net/1.3/viz" version="1.3">` + "\n")
+	out.WriteString(`  <graph defaultedgetype="directed" mode="static">` + "\n")
+	out.WriteString(`    <attributes class="node">` + "\n")
+	out.WriteString(`      <attribute id="type" title="type" type="string"/>` + "\n")
+	for i, key := range keys {
+		fmt.Fprintf(&out, `      <attribute id="d%d" title="%s" type="string"/>`+"\n", i, escapeGraphXML(key))
+	}
+	out.WriteString("    </attributes>\n")
+	out.WriteString(`    <attributes class="edge">` + "\n")
+	out.WriteString(`      <attribute id="type" title="type" type="string"/>` + "\n")
+	out.WriteString("    </attributes>\n")
+
+	out.WriteString("    <nodes>\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&out, `      <node id="%s" label="%s">`+"\n", escapeGraphXML(node.ID), escapeGraphXML(node.Label))
+		out.WriteString("        <attvalues>\n")
+		fmt.Fprintf(&out, `          <attvalue for="type" value="%s"/>`+"\n", escapeGraphXML(node.Type))
+		for i, key := range keys {
+			if value, ok := formatGraphDataValue(node.Data[key]); ok {
+				fmt.Fprintf(&out, `          <attvalue for="d%d" value="%s"/>`+"\n", i, escapeGraphXML(value))
+			}
+		}
+		out.WriteString("        </attvalues>\n")
+		fmt.Fprintf(&out, `        <viz:position x="%g" y="%g" z="0"/>`+"\n", node.Position.X, node.Position.Y)
+		out.WriteString("      </node>\n")
+	}
+	out.WriteString("    </nodes>\n")
+
+	out.WriteString("    <edges>\n")
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&out, `      <edge id="%s" source="%s" target="%s" label="%s">`+"\n",
+			escapeGraphXML(edge.ID), escapeGraphXML(edge.Source), escapeGraphXML(edge.Target), escapeGraphXML(edge.Label))
+		fmt.Fprintf(&out, `        <attvalues><attvalue for="type" value="%s"/></attvalues>`+"\n", escapeGraphXML(edge.Type))
+		out.WriteString("      </edge>\n")
+	}
+	out.WriteString("    </edges>\n")
+
+	out.WriteString("  </graph>\n</gexf>\n")
+	return out.String()
+}
+
+// quoteDOT quotes an identifier or attribute value for Graphviz (Pure Core)
+func quoteDOT(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// buildDOT serializes a graph as a Graphviz digraph, one node shape per node type (Pure Core)
+func buildDOT(graph GraphResponse, orgName string) string {
+	shapes := map[string]string{
+		"organization": "doubleoctagon",
+		"repository":   "box",
+		"team":         "ellipse",
+		"topic":        "hexagon",
+		"user":         "oval",
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "digraph %s {\n", quoteDOT(orgName))
+	out.WriteString("  rankdir=LR;\n")
+	for _, node := range graph.Nodes {
+		shape, ok := shapes[node.Type]
+		if !ok {
+			shape = "note"
+		}
+		fmt.Fprintf(&out, "  %s [label=%s, type=%s, shape=%s];\n", quoteDOT(node.ID), quoteDOT(node.Label), quoteDOT(node.Type), shape)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&out, "  %s -> %s [label=%s, type=%s];\n", quoteDOT(edge.Source), quoteDOT(edge.Target), quoteDOT(edge.Label), quoteDOT(edge.Type))
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// buildGraphEdgeListCSV serializes a graph as a CSV edge list with the label and type of both
+// endpoints, the import format of Gephi's and Cytoscape's spreadsheet loaders (Pure Core)
+func buildGraphEdgeListCSV(graph GraphResponse) (string, error) {
+	nodes := make(map[string]GraphNode, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+
+	var out strings.Builder
+	writer := csv.NewWriter(&out)
+	if err := writer.Write(graphEdgeListCSVHeader); err != nil {
+		return "", err
+	}
+	for _, edge := range graph.Edges {
+		source, target := nodes[edge.Source], nodes[edge.Target]
+		record := []string{edge.Source, edge.Target, edge.Type, edge.Label, source.Label, source.Type, target.Label, target.Type}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return out.String(), writer.Error()
+}
+
+// serializeGraph serializes a graph in an export format (Pure Core)
+func serializeGraph(graph GraphResponse, orgName, format string) (string, error) {
+	switch format {
+	case GraphExportFormatGraphML:
+		return buildGraphML(graph), nil
+	case GraphExportFormatGEXF:
+		return buildGEXF(graph), nil
+	case GraphExportFormatDOT:
+		return buildDOT(graph, orgName), nil
+	case GraphExportFormatCSV:
+		return buildGraphEdgeListCSV(graph)
+	default:
+		return "", &gofrhttp.ErrorInvalidParam{Params: []string{"format"}}
+	}
+}
+
+// handleExportGraph serializes the ownership graph as GraphML, GEXF, DOT or a CSV edge list; the
+// graph is subject to the same size limits, grouping and node type filter as GET /api/graph/{org}
+func (h *AppHandler) handleExportGraph(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	format := strings.ToLower(ctx.Param("format"))
+	if format == "" {
+		return nil, createMissingParamError("format")
+	}
+	contentType, ok := graphExportContentTypes[format]
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"format"}}
+	}
+
+	groupBy, ok := parseGraphGroupBy(ctx.Param("group_by"), parseBoolFromQuery(ctx, "useTopics", false))
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"group_by"}}
+	}
+	types, ok := parseGraphTypes(ctx.Param("types"), h.deps.GraphTypes)
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"types"}}
+	}
+
+	if err := checkGraphCost(ctx, h.deps, orgName, groupBy); err != nil {
+		return nil, err
+	}
+	graph, err := getOrganizationGraph(ctx, h.deps, orgName, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	h.deps.Access.record(orgName)
+
+	graph = filterGraphByTypes(applyTeamVisibility(graph, secretTeamsMode(ctx, h.deps.currentConfig())), types)
+	content, err := serializeGraph(graph, orgName, format)
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo(ctx, "Exported ownership graph", LogFields{
+		"component":    "graph_export",
+		"operation":    "export_graph",
+		"organization": orgName,
+		"format":       format,
+		"nodes":        len(graph.Nodes),
+		"edges":        len(graph.Edges),
+	})
+	return response.File{Content: []byte(content), ContentType: contentType}, nil
+}
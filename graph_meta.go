@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// graphMetaRoute is the path of GET /api/graph/meta; it is registered before /api/graph/{org} so it
+// is not taken for an organization named "meta"
+const graphMetaRoute = "/api/graph/meta"
+
+// defaultGraphEdgeColor is the color of edge types that do not declare one, matching the frontend edge style
+const defaultGraphEdgeColor = "#30363d"
+
+// customGraphTypeColors are assigned in turn to custom node types that do not declare a color
+var customGraphTypeColors = []string{"#14b8a6", "#e879f9", "#facc15", "#38bdf8", "#a3e635", "#f87171"}
+
+// hexColorPattern matches #rgb and #rrggbb colors
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// GraphNodeTypeMeta describes a node type of the graph for the frontend legend
+type GraphNodeTypeMeta struct {
+	Type    string `json:"type"`
+	Label   string `json:"label"`
+	Color   string `json:"color"`
+	Builtin bool   `json:"builtin"`
+	Count   *int   `json:"count,omitempty"`
+}
+
+// GraphEdgeTypeMeta describes an edge type of the graph for the frontend legend
+type GraphEdgeTypeMeta struct {
+	Type    string `json:"type"`
+	Label   string `json:"label"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Color   string `json:"color"`
+	Builtin bool   `json:"builtin"`
+	Count   *int   `json:"count,omitempty"`
+}
+
+// GraphMetaResponse lists the node and edge types the graph endpoint can return, with their counts in
+// the active scan of an organization when one is requested
+type GraphMetaResponse struct {
+	Organization string              `json:"organization,omitempty"`
+	NodeTypes    []GraphNodeTypeMeta `json:"node_types"`
+	EdgeTypes    []GraphEdgeTypeMeta `json:"edge_types"`
+}
+
+// isHexColor reports whether a color is a #rgb or #rrggbb hex color (Pure Core)
+func isHexColor(color string) bool {
+	return hexColorPattern.MatchString(color)
+}
+
+// builtinGraphEdgeTypes returns the edge types the graph queries project, in the order of
+// graphOwnsEdgeProjection and its neighbours (Pure Core)
+func builtinGraphEdgeTypes() []GraphEdgeTypeMeta {
+	return []GraphEdgeTypeMeta{
+		{Type: "owns", Label: "owns", Source: "organization", Target: "repository"},
+		{Type: "codeowner", Label: "code owner", Source: "repository", Target: "user"},
+		{Type: "has_team", Label: "has team", Source: "organization", Target: "team"},
+		{Type: "team_owner", Label: "team owner", Source: "repository", Target: "team"},
+		{Type: "has_topic", Label: "has topic", Source: "organization", Target: "topic"},
+		{Type: "repo_topic", Label: "uses topic", Source: "repository", Target: "topic"},
+	}
+}
+
+// buildGraphMeta lists the built-in and registered custom graph types with their display labels and
+// colors (Pure Core)
+func buildGraphMeta(registry *GraphTypeRegistry) GraphMetaResponse {
+	response := GraphMetaResponse{}
+	for _, def := range builtinNodeTypes() {
+		response.NodeTypes = append(response.NodeTypes, GraphNodeTypeMeta{
+			Type: def.GraphType, Label: def.Label, Color: def.Color, Builtin: true,
+		})
+	}
+	for i, def := range registry.customNodeTypes() {
+		color := def.Color
+		if color == "" {
+			color = customGraphTypeColors[i%len(customGraphTypeColors)]
+		}
+		response.NodeTypes = append(response.NodeTypes, GraphNodeTypeMeta{Type: def.GraphType, Label: def.Label, Color: color})
+	}
+
+	for _, edge := range builtinGraphEdgeTypes() {
+		edge.Color, edge.Builtin = defaultGraphEdgeColor, true
+		response.EdgeTypes = append(response.EdgeTypes, edge)
+	}
+	for _, def := range registry.customRelationshipTypes() {
+		from, _ := registry.nodeType(def.From)
+		to, _ := registry.nodeType(def.To)
+		color := def.Color
+		if color == "" {
+			color = defaultGraphEdgeColor
+		}
+		label := def.Label
+		if label == "" {
+			label = def.Type
+		}
+		response.EdgeTypes = append(response.EdgeTypes, GraphEdgeTypeMeta{
+			Type: def.GraphType, Label: label, Source: from.GraphType, Target: to.GraphType, Color: color,
+		})
+	}
+	return response
+}
+
+// builtinGraphTypeCounts maps the built-in node and edge types to their counts in the active scan (Pure Core)
+func builtinGraphTypeCounts(counts GraphCounts) map[string]int {
+	return map[string]int{
+		"organization": 1,
+		"repository":   counts.Repositories,
+		"team":         counts.Teams,
+		"topic":        counts.Topics,
+		"user":         counts.Users,
+		"owns":         counts.Repositories,
+		"codeowner":    counts.CodeownerEdges,
+		"has_team":     counts.Teams,
+		"team_owner":   counts.TeamOwnerEdges,
+		"has_topic":    counts.Topics,
+		"repo_topic":   counts.RepoTopicEdges,
+	}
+}
+
+// buildCustomNodeCountQuery builds a query counting the custom entities of a type in an organization (Pure Core)
+func buildCustomNodeCountQuery(def NodeTypeDefinition) string {
+	return cachedQuery(func() string {
+		return fmt.Sprintf(`
+			MATCH (n:%s)
+			WHERE %s
+			RETURN count(n) AS count
+		`, quoteCypherIdentifier("label", def.Label), buildNodeScopeCondition(def, "n"))
+	}, "custom_node_count", def)
+}
+
+// buildCustomEdgeCountQuery builds a query counting the custom relationships of a type in an organization (Pure Core)
+func buildCustomEdgeCountQuery(def RelationshipTypeDefinition, from, to NodeTypeDefinition) string {
+	return cachedQuery(func() string {
+		return fmt.Sprintf(`
+			MATCH (source:%s)-[:%s]->(target:%s)
+			WHERE %s AND %s
+			RETURN count(*) AS count
+		`, quoteCypherIdentifier("label", from.Label), quoteCypherIdentifier("relationship type", def.Type), quoteCypherIdentifier("label", to.Label),
+			buildNodeScopeCondition(from, "source"), buildNodeScopeCondition(to, "target"))
+	}, "custom_edge_count", def, from, to)
+}
+
+// fetchCustomGraphTypeCounts counts the elements of each custom type in an organization; types whose
+// definition overrides the generated query are not counted (Orchestrator)
+func fetchCustomGraphTypeCounts(ctx *gofr.Context, session *Neo4jSession, registry *GraphTypeRegistry, orgName string) (map[string]int, error) {
+	counts := make(map[string]int)
+	params := map[string]interface{}{"orgName": orgName}
+	count := func(graphType, query string) error {
+		result, err := executeNeo4jReadQuery(ctx, session, query, params)
+		if err != nil {
+			return convertNeo4jErrorToGoFr(err)
+		}
+		if len(result.Records) > 0 {
+			counts[graphType] += getIntFromMap(result.Records[0], "count")
+		}
+		return nil
+	}
+
+	for _, def := range registry.customNodeTypes() {
+		if def.Query != "" {
+			continue
+		}
+		if err := count(def.GraphType, buildCustomNodeCountQuery(def)); err != nil {
+			return nil, err
+		}
+	}
+	for _, def := range registry.customRelationshipTypes() {
+		if def.Query != "" {
+			continue
+		}
+		from, _ := registry.nodeType(def.From)
+		to, _ := registry.nodeType(def.To)
+		if err := count(def.GraphType, buildCustomEdgeCountQuery(def, from, to)); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// applyGraphTypeCounts sets the count of every listed type found in counts (Pure Core)
+func applyGraphTypeCounts(meta GraphMetaResponse, counts map[string]int) GraphMetaResponse {
+	for i := range meta.NodeTypes {
+		if count, ok := counts[meta.NodeTypes[i].Type]; ok {
+			meta.NodeTypes[i].Count = &count
+		}
+	}
+	for i := range meta.EdgeTypes {
+		if count, ok := counts[meta.EdgeTypes[i].Type]; ok {
+			meta.EdgeTypes[i].Count = &count
+		}
+	}
+	return meta
+}
+
+// getGraphMeta lists the graph types, counted in the active scan of orgName when it is set (Orchestrator)
+func getGraphMeta(ctx *gofr.Context, deps *AppDependencies, orgName string) (GraphMetaResponse, error) {
+	meta := buildGraphMeta(deps.GraphTypes)
+	if orgName == "" {
+		return meta, nil
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return GraphMetaResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildActiveScanIDQuery(), map[string]interface{}{"orgName": orgName})
+	if err != nil {
+		return GraphMetaResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return GraphMetaResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	counts, err := fetchCustomGraphTypeCounts(ctx, session, deps.GraphTypes, orgName)
+	if err != nil {
+		return GraphMetaResponse{}, err
+	}
+	builtinCounts, err := fetchGraphCounts(ctx, deps, orgName)
+	if err != nil {
+		return GraphMetaResponse{}, err
+	}
+	for graphType, count := range builtinGraphTypeCounts(builtinCounts) {
+		counts[graphType] += count
+	}
+
+	meta.Organization = orgName
+	return applyGraphTypeCounts(meta, counts), nil
+}
+
+// handleGetGraphMeta returns the node and edge types of the graph with their display labels and colors,
+// and their counts in an organization given with ?org=
+func (h *AppHandler) handleGetGraphMeta(ctx *gofr.Context) (interface{}, error) {
+	return getGraphMeta(ctx, h.deps, ctx.Param("org"))
+}
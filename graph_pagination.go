@@ -0,0 +1,248 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Repositories per graph page when ?offset= is given without ?limit=, and the most a page may hold
+const (
+	defaultGraphPageLimit = 100
+	maxGraphPageLimit     = 500
+)
+
+// builtinGraphNodeTypes are the node types of the ownership graph accepted by ?types=
+var builtinGraphNodeTypes = []string{"organization", "repository", "team", "topic", "user"}
+
+// GraphPage locates a page of repositories within the organization's graph
+type GraphPage struct {
+	Offset            int  `json:"offset"`
+	Limit             int  `json:"limit"`
+	TotalRepositories int  `json:"total_repositories"`
+	NextOffset        *int `json:"next_offset,omitempty"`
+}
+
+// GraphQueryOptions are the node type filter and repository page of a graph request; a zero Limit
+// requests the whole graph
+type GraphQueryOptions struct {
+	Types  []string
+	Offset int
+	Limit  int
+}
+
+// paged reports whether the request selects a page of repositories
+func (o GraphQueryOptions) paged() bool {
+	return o.Limit > 0
+}
+
+// filtered reports whether the request narrows the graph in any way
+func (o GraphQueryOptions) filtered() bool {
+	return o.paged() || len(o.Types) > 0
+}
+
+// parseGraphTypes parses a comma-separated node type filter against the built-in and registered
+// custom node types (Pure Core)
+func parseGraphTypes(value string, registry *GraphTypeRegistry) ([]string, bool) {
+	known := make(map[string]bool)
+	for _, nodeType := range builtinGraphNodeTypes {
+		known[nodeType] = true
+	}
+	for _, def := range registry.customNodeTypes() {
+		known[def.GraphType] = true
+	}
+
+	var types []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		nodeType := strings.ToLower(strings.TrimSpace(part))
+		if nodeType == "" || seen[nodeType] {
+			continue
+		}
+		if !known[nodeType] {
+			return nil, false
+		}
+		seen[nodeType] = true
+		types = append(types, nodeType)
+	}
+	return types, true
+}
+
+// parseGraphQueryOptions reads ?types=, ?limit= and ?offset= of a graph request
+func parseGraphQueryOptions(ctx *gofr.Context, registry *GraphTypeRegistry) (GraphQueryOptions, error) {
+	var options GraphQueryOptions
+
+	if value := ctx.Param("types"); value != "" {
+		types, ok := parseGraphTypes(value, registry)
+		if !ok {
+			return GraphQueryOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"types"}}
+		}
+		options.Types = types
+	}
+
+	if value := ctx.Param("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return GraphQueryOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"offset"}}
+		}
+		options.Offset = parsed
+		options.Limit = defaultGraphPageLimit
+	}
+
+	if value := ctx.Param("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxGraphPageLimit {
+			return GraphQueryOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+		options.Limit = parsed
+	}
+
+	return options, nil
+}
+
+// buildGraphPageQuery builds a query returning a page of repositories, ordered by full name, with
+// the teams, topics and users linked to them and the edges between those nodes. Columns match the
+// nodes query plus the edges column, so both converters read the same record. (Pure Core)
+func buildGraphPageQuery(orgName string, groupBy GraphGroupBy) string {
+	validateOrgNameNotEmpty(orgName)
+
+	return cachedQuery(func() string {
+		teams := `
+			WITH org, total_repositories, repo, repo_users, [] AS repo_teams`
+		if groupBy.includesTeams() {
+			teams = `
+			OPTIONAL MATCH (repo)-[team_owner:HAS_TEAM_OWNER]->(team:Team) WHERE coalesce(team_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, total_repositories, repo, repo_users, collect(DISTINCT team) AS repo_teams`
+		}
+
+		topics := `
+			WITH org, total_repositories, repo, repo_users, repo_teams, [] AS repo_topics`
+		if groupBy.includesTopics() {
+			topics = `
+			OPTIONAL MATCH (repo)-[uses_topic:HAS_TOPIC]->(topic:Topic) WHERE coalesce(uses_topic.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, total_repositories, repo, repo_users, repo_teams, collect(DISTINCT topic) AS repo_topics`
+		}
+
+		return `
+			MATCH (org:Organization {login: $orgName})
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, repo
+			ORDER BY repo.full_name
+			WITH org, collect(repo) AS all_repos
+			WITH org, size(all_repos) AS total_repositories, all_repos[$offset..$end] AS page
+			UNWIND CASE WHEN size(page) = 0 THEN [NULL] ELSE page END AS repo
+			OPTIONAL MATCH (repo)-[codeowner:HAS_CODEOWNER]->(owner_user:User) WHERE coalesce(codeowner.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, total_repositories, repo, collect(DISTINCT ` + canonicalUserExpression("owner_user") + `) AS repo_users` + teams + topics + `
+			WITH org, total_repositories,
+				 collect(CASE WHEN repo IS NULL THEN NULL ELSE {repo: repo, users: repo_users, teams: repo_teams, topics: repo_topics} END) AS rows
+			WITH org, total_repositories, rows,
+				 reduce(acc = [], row IN rows | acc + [user IN row.users WHERE NOT user IN acc]) AS page_users,
+				 reduce(acc = [], row IN rows | acc + [team IN row.teams WHERE NOT team IN acc]) AS page_teams,
+				 reduce(acc = [], row IN rows | acc + [topic IN row.topics WHERE NOT topic IN acc]) AS page_topics
+			RETURN ` + projectGraphElement(graphOrganizationNodeProjection, "org") + ` AS org_node,
+				 [row IN rows | ` + projectGraphElement(graphRepositoryNodeProjection, "row.repo") + `] AS repos,
+				 [team IN page_teams | ` + projectGraphElement(graphTeamNodeProjection, "team") + `] AS teams,
+				 [topic IN page_topics | ` + projectGraphElement(graphTopicNodeProjection, "topic") + `] AS topics,
+				 [user IN page_users | ` + projectGraphElement(graphUserNodeProjection, "user") + `] AS users,
+				 [row IN rows | ` + projectGraphElement(graphOwnsEdgeProjection, "org", "row.repo") + `]
+				 + reduce(acc = [], row IN rows | acc + [user IN row.users | ` + projectGraphElement(graphCodeownerEdgeProjection, "row.repo", "user") + `])
+				 + [team IN page_teams | ` + projectGraphElement(graphHasTeamEdgeProjection, "org", "team") + `]
+				 + reduce(acc = [], row IN rows | acc + [team IN row.teams | ` + projectGraphElement(graphTeamOwnerEdgeProjection, "row.repo", "team") + `])
+				 + [topic IN page_topics | ` + projectGraphElement(graphHasTopicEdgeProjection, "org", "topic") + `]
+				 + reduce(acc = [], row IN rows | acc + [topic IN row.topics | ` + projectGraphElement(graphRepoTopicEdgeProjection, "row.repo", "topic") + `]) AS edges,
+				 total_repositories
+		`
+	}, "graph_page", groupBy)
+}
+
+// buildGraphPage describes the page a graph response covers (Pure Core)
+func buildGraphPage(offset, limit, totalRepositories int) *GraphPage {
+	page := &GraphPage{Offset: offset, Limit: limit, TotalRepositories: totalRepositories}
+	if next := offset + limit; next < totalRepositories {
+		page.NextOffset = &next
+	}
+	return page
+}
+
+// filterGraphByTypes keeps the nodes of the given types and prunes every edge left without one of
+// its endpoints; an empty type list keeps the graph as is (Pure Core)
+func filterGraphByTypes(graph GraphResponse, types []string) GraphResponse {
+	if len(types) == 0 {
+		return graph
+	}
+
+	wanted := make(map[string]bool, len(types))
+	for _, nodeType := range types {
+		wanted[nodeType] = true
+	}
+
+	kept := make(map[string]bool)
+	nodes := make([]GraphNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if wanted[node.Type] {
+			kept[node.ID] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	edges := make([]GraphEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if kept[edge.Source] && kept[edge.Target] {
+			edges = append(edges, edge)
+		}
+	}
+
+	graph.Nodes = nodes
+	graph.Edges = edges
+	return graph
+}
+
+// shiftGraphRepositories moves the repositories of a page right of those on earlier pages, so pages
+// loaded one after another do not overlap in the default layout (Pure Core)
+func shiftGraphRepositories(nodes []GraphNode, offset int) {
+	for i := range nodes {
+		if nodes[i].Type == "repository" {
+			nodes[i].Position.X += float64(offset) * graphNodeSpacing
+		}
+	}
+}
+
+// getOrganizationGraphPage retrieves a page of an organization's repositories with the nodes and
+// edges connected to them. Custom entity types are only part of the full graph. (Orchestrator)
+func getOrganizationGraphPage(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy, options GraphQueryOptions) (GraphResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildGraphPageQuery(orgName, groupBy), map[string]interface{}{
+		"orgName": orgName,
+		"offset":  options.Offset,
+		"end":     options.Offset + options.Limit,
+	})
+	if err != nil {
+		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	nodes, nodeReport := convertToGraphNodes(result.Records)
+	edges, edgeReport := convertToGraphEdges(result.Records)
+	if err := reportGraphConversion(ctx, deps, orgName, nodeReport, edgeReport); err != nil {
+		return GraphResponse{}, err
+	}
+	shiftGraphRepositories(nodes, options.Offset)
+
+	total := 0
+	if len(result.Records) > 0 {
+		total = getIntFromMap(result.Records[0], "total_repositories")
+	}
+
+	return GraphResponse{
+		SchemaVersion: GraphSchemaVersion,
+		Nodes:         nodes,
+		Edges:         edges,
+		Page:          buildGraphPage(options.Offset, options.Limit, total),
+	}, nil
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+)
+
+// GraphSchemaVersion is the version of the GraphResponse shape; it changes only when fields are
+// renamed, removed or change meaning, not when fields are added
+const GraphSchemaVersion = 1
+
+// Reasons a graph record is dropped during conversion
+const (
+	graphDropNotAMap      = "not_a_map"
+	graphDropMissingID    = "missing_id"
+	graphDropMissingType  = "missing_type"
+	graphDropMissingEnd   = "missing_endpoint"
+	graphDropInvalidData  = "invalid_data"
+	graphDropMissingField = "missing_field"
+)
+
+// graphNodeRecord is a node map returned by the graph queries, decoded and checked
+type graphNodeRecord struct {
+	ID    string
+	Type  string
+	Label string
+	Data  map[string]interface{}
+}
+
+// graphEdgeRecord is an edge map returned by the graph queries, decoded and checked
+type graphEdgeRecord struct {
+	ID     string
+	Source string
+	Target string
+	Type   string
+	Label  string
+}
+
+// graphNodesRecord is the single row returned by buildGraphNodesQuery
+type graphNodesRecord struct {
+	Organization *graphNodeRecord
+	Repositories []graphNodeRecord
+	Teams        []graphNodeRecord
+	Topics       []graphNodeRecord
+	Users        []graphNodeRecord
+}
+
+// graphConversionReport counts the records kept and dropped while decoding query results
+type graphConversionReport struct {
+	Kind          string
+	Decoded       int
+	Dropped       map[string]int
+	MissingFields []string
+}
+
+// GraphConversionError is returned in strict conversion mode instead of a graph missing records
+type GraphConversionError struct {
+	Organization string
+	Dropped      int
+}
+
+// Error implements the error interface for GraphConversionError
+func (e *GraphConversionError) Error() string {
+	return fmt.Sprintf("%d graph records of organization %s could not be converted", e.Dropped, e.Organization)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (e *GraphConversionError) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// OrganizationConversionFailures counts the graph records dropped for an organization since startup
+type OrganizationConversionFailures struct {
+	Organization  string                    `json:"organization"`
+	Dropped       int                       `json:"dropped"`
+	Reasons       map[string]map[string]int `json:"reasons"`
+	MissingFields []string                  `json:"missing_fields"`
+	LastSeen      string                    `json:"last_seen"`
+}
+
+// ConversionFailuresResponse lists the graph records dropped per organization
+type ConversionFailuresResponse struct {
+	Strict        bool                             `json:"strict"`
+	Dropped       int                              `json:"dropped"`
+	Organizations []OrganizationConversionFailures `json:"organizations"`
+}
+
+// ConversionFailureTracker accumulates the graph records dropped per organization, by kind and reason
+type ConversionFailureTracker struct {
+	mu            sync.Mutex
+	organizations map[string]*OrganizationConversionFailures
+}
+
+// newConversionFailureTracker creates an empty conversion failure tracker
+func newConversionFailureTracker() *ConversionFailureTracker {
+	return &ConversionFailureTracker{organizations: make(map[string]*OrganizationConversionFailures)}
+}
+
+// record adds the records dropped in report to the organization's counts
+func (t *ConversionFailureTracker) record(orgName string, report *graphConversionReport, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	failures, exists := t.organizations[orgName]
+	if !exists {
+		failures = &OrganizationConversionFailures{Organization: orgName, Reasons: map[string]map[string]int{}, MissingFields: []string{}}
+		t.organizations[orgName] = failures
+	}
+	failures.Reasons = mergeConversionReasons(failures.Reasons, report)
+	failures.Dropped += report.droppedCount()
+	for _, field := range report.MissingFields {
+		if !lo.Contains(failures.MissingFields, field) {
+			failures.MissingFields = append(failures.MissingFields, field)
+		}
+	}
+	failures.LastSeen = now.UTC().Format(time.RFC3339)
+}
+
+// snapshot returns a copy of the counts of every organization, ordered by organization
+func (t *ConversionFailureTracker) snapshot(strict bool) ConversionFailuresResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	response := ConversionFailuresResponse{Strict: strict, Organizations: make([]OrganizationConversionFailures, 0, len(t.organizations))}
+	for _, failures := range t.organizations {
+		reasons := make(map[string]map[string]int, len(failures.Reasons))
+		for kind, counts := range failures.Reasons {
+			reasons[kind] = lo.Assign(counts)
+		}
+		entry := *failures
+		entry.Reasons = reasons
+		entry.MissingFields = append([]string{}, failures.MissingFields...)
+		response.Organizations = append(response.Organizations, entry)
+		response.Dropped += failures.Dropped
+	}
+	sort.Slice(response.Organizations, func(i, j int) bool {
+		return response.Organizations[i].Organization < response.Organizations[j].Organization
+	})
+	return response
+}
+
+// newGraphConversionReport creates an empty report for nodes or edges
+func newGraphConversionReport(kind string) *graphConversionReport {
+	return &graphConversionReport{Kind: kind, Dropped: map[string]int{}}
+}
+
+// drop counts a record dropped for reason; a nil report discards the count
+func (r *graphConversionReport) drop(reason string) {
+	if r != nil {
+		r.Dropped[reason]++
+	}
+}
+
+// keep counts a decoded record; a nil report discards the count
+func (r *graphConversionReport) keep() {
+	if r != nil {
+		r.Decoded++
+	}
+}
+
+// missing records a result column the query did not return; a nil report discards it
+func (r *graphConversionReport) missing(field string) {
+	if r != nil {
+		r.MissingFields = append(r.MissingFields, field)
+		r.Dropped[graphDropMissingField]++
+	}
+}
+
+// droppedCount returns the number of records dropped for any reason
+func (r *graphConversionReport) droppedCount() int {
+	total := 0
+	for _, count := range r.Dropped {
+		total += count
+	}
+	return total
+}
+
+// decodeGraphNodeRecord checks a node map has the fields every node needs and returns the reason it
+// was rejected otherwise (Pure Core)
+func decodeGraphNodeRecord(value interface{}) (graphNodeRecord, string) {
+	nodeMap, ok := value.(map[string]interface{})
+	if !ok {
+		return graphNodeRecord{}, graphDropNotAMap
+	}
+
+	record := graphNodeRecord{
+		ID:    getStringFromMap(nodeMap, "id"),
+		Type:  getStringFromMap(nodeMap, "type"),
+		Label: getStringFromMap(nodeMap, "label"),
+		Data:  map[string]interface{}{},
+	}
+	switch {
+	case record.ID == "":
+		return graphNodeRecord{}, graphDropMissingID
+	case record.Type == "":
+		return graphNodeRecord{}, graphDropMissingType
+	}
+
+	if data, exists := nodeMap["data"]; exists && data != nil {
+		dataMap, ok := data.(map[string]interface{})
+		if !ok {
+			return graphNodeRecord{}, graphDropInvalidData
+		}
+		record.Data = dataMap
+	}
+	return record, ""
+}
+
+// decodeGraphEdgeRecord checks an edge map names both endpoints and a type (Pure Core)
+func decodeGraphEdgeRecord(value interface{}) (graphEdgeRecord, string) {
+	edgeMap, ok := value.(map[string]interface{})
+	if !ok {
+		return graphEdgeRecord{}, graphDropNotAMap
+	}
+
+	record := graphEdgeRecord{
+		ID:     getStringFromMap(edgeMap, "id"),
+		Source: getStringFromMap(edgeMap, "source"),
+		Target: getStringFromMap(edgeMap, "target"),
+		Type:   getStringFromMap(edgeMap, "type"),
+		Label:  getStringFromMap(edgeMap, "label"),
+	}
+	switch {
+	case record.ID == "":
+		return graphEdgeRecord{}, graphDropMissingID
+	case record.Source == "" || record.Target == "":
+		return graphEdgeRecord{}, graphDropMissingEnd
+	case record.Type == "":
+		return graphEdgeRecord{}, graphDropMissingType
+	}
+	return record, ""
+}
+
+// decodeGraphNodeList decodes the node maps of a list column, counting the entries dropped (Pure Core)
+func decodeGraphNodeList(record map[string]interface{}, field string, report *graphConversionReport) []graphNodeRecord {
+	value, exists := record[field]
+	if !exists {
+		report.missing(field)
+		return []graphNodeRecord{}
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		report.missing(field)
+		return []graphNodeRecord{}
+	}
+	return decodeGraphNodeValues(list, report)
+}
+
+// decodeGraphNodeValues decodes node maps, counting the entries dropped (Pure Core)
+func decodeGraphNodeValues(list []interface{}, report *graphConversionReport) []graphNodeRecord {
+	nodes := make([]graphNodeRecord, 0, len(list))
+	for _, item := range list {
+		node, reason := decodeGraphNodeRecord(item)
+		if reason != "" {
+			report.drop(reason)
+			continue
+		}
+		report.keep()
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// decodeGraphNodesRecord decodes the row of buildGraphNodesQuery (Pure Core)
+func decodeGraphNodesRecord(record map[string]interface{}, report *graphConversionReport) graphNodesRecord {
+	decoded := graphNodesRecord{
+		Repositories: decodeGraphNodeList(record, "repos", report),
+		Teams:        decodeGraphNodeList(record, "teams", report),
+		Topics:       decodeGraphNodeList(record, "topics", report),
+		Users:        decodeGraphNodeList(record, "users", report),
+	}
+
+	if value, exists := record["org_node"]; !exists {
+		report.missing("org_node")
+	} else if org, reason := decodeGraphNodeRecord(value); reason != "" {
+		report.drop(reason)
+	} else {
+		report.keep()
+		decoded.Organization = &org
+	}
+	return decoded
+}
+
+// decodeGraphEdgeValues decodes edge maps, counting the entries dropped (Pure Core)
+func decodeGraphEdgeValues(list []interface{}, report *graphConversionReport) []graphEdgeRecord {
+	edges := make([]graphEdgeRecord, 0, len(list))
+	for _, item := range list {
+		edge, reason := decodeGraphEdgeRecord(item)
+		if reason != "" {
+			report.drop(reason)
+			continue
+		}
+		report.keep()
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+// toGraphNode places a node record at a layout position (Pure Core)
+func (n graphNodeRecord) toGraphNode(x, y float64) GraphNode {
+	return GraphNode{
+		ID:       n.ID,
+		Type:     n.Type,
+		Label:    n.Label,
+		Data:     n.Data,
+		Position: GraphPosition{X: x, Y: y},
+	}
+}
+
+// toGraphEdge converts an edge record to the response shape (Pure Core)
+func (e graphEdgeRecord) toGraphEdge() GraphEdge {
+	return GraphEdge{ID: e.ID, Source: e.Source, Target: e.Target, Type: e.Type, Label: e.Label}
+}
+
+// layoutGraphNodeRow places node records side by side on one row of the default layout (Pure Core)
+func layoutGraphNodeRow(records []graphNodeRecord, yOffset, xSpacing float64) []GraphNode {
+	nodes := make([]GraphNode, 0, len(records))
+	for i, record := range records {
+		nodes = append(nodes, record.toGraphNode(float64(i)*xSpacing, yOffset))
+	}
+	return nodes
+}
+
+// reportGraphConversion logs and counts the records dropped while converting graph query results, which
+// points at a query whose result shape no longer matches the converter. In strict conversion mode it
+// returns a GraphConversionError so the caller fails instead of returning a graph missing records.
+func reportGraphConversion(ctx *gofr.Context, deps *AppDependencies, orgName string, reports ...*graphConversionReport) error {
+	dropped := 0
+	for _, report := range reports {
+		if report == nil || report.droppedCount() == 0 {
+			continue
+		}
+		logGraphConversion(ctx, orgName, report)
+		deps.ConversionFailures.record(orgName, report, time.Now())
+		dropped += report.droppedCount()
+	}
+
+	if dropped > 0 && deps.currentConfig().GraphConversion.Strict {
+		return &GraphConversionError{Organization: orgName, Dropped: dropped}
+	}
+	return nil
+}
+
+// logGraphConversion logs the records dropped in one report and counts them in graph_records_dropped_total
+func logGraphConversion(ctx *gofr.Context, orgName string, report *graphConversionReport) {
+	reasons := make([]string, 0, len(report.Dropped))
+	for reason := range report.Dropped {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	for _, reason := range reasons {
+		metrics.recordCounter("graph_records_dropped_total", report.Dropped[reason], MetricLabels{
+			"kind":   report.Kind,
+			"reason": reason,
+		})
+	}
+
+	logWarn(ctx, "Dropped graph records that do not match the expected shape", LogFields{
+		"component":      "graph_records",
+		"operation":      "convert_graph_records",
+		"organization":   orgName,
+		"kind":           report.Kind,
+		"decoded":        report.Decoded,
+		"dropped":        report.droppedCount(),
+		"reasons":        report.Dropped,
+		"missing_fields": report.MissingFields,
+		"schema_version": GraphSchemaVersion,
+	})
+}
+
+// checkPublishedGraphConversion reads back the graph of a newly published scan and returns the records
+// that fail to convert by kind and reason, so data loss shows in the scan report rather than only on
+// later reads. The scan is already published, so strict mode does not fail it. (Orchestrator)
+func checkPublishedGraphConversion(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy) map[string]map[string]int {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logWarn(ctx, "Failed to check the conversion of the published graph", LogFields{
+			"component":    "graph_records",
+			"operation":    "check_published_graph",
+			"organization": orgName,
+			"error":        err.Error(),
+		})
+		return nil
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	_, nodeReport, err := fetchGraphNodes(ctx, session, orgName, groupBy)
+	var edgeReport *graphConversionReport
+	if err == nil {
+		_, edgeReport, err = fetchGraphEdges(ctx, session, orgName, groupBy)
+	}
+	if err != nil {
+		logWarn(ctx, "Failed to check the conversion of the published graph", LogFields{
+			"component":    "graph_records",
+			"operation":    "check_published_graph",
+			"organization": orgName,
+			"error":        err.Error(),
+		})
+		return nil
+	}
+
+	_ = reportGraphConversion(ctx, deps, orgName, nodeReport, edgeReport)
+	return mergeConversionReasons(nil, nodeReport, edgeReport)
+}
+
+// mergeConversionReasons adds the records dropped in reports to reasons, keyed by kind and reason (Pure Core)
+func mergeConversionReasons(reasons map[string]map[string]int, reports ...*graphConversionReport) map[string]map[string]int {
+	for _, report := range reports {
+		if report == nil || report.droppedCount() == 0 {
+			continue
+		}
+		if reasons == nil {
+			reasons = map[string]map[string]int{}
+		}
+		if reasons[report.Kind] == nil {
+			reasons[report.Kind] = map[string]int{}
+		}
+		for reason, count := range report.Dropped {
+			reasons[report.Kind][reason] += count
+		}
+	}
+	return reasons
+}
+
+// handleGetConversionFailures returns the graph records dropped per organization since startup
+func (h *AppHandler) handleGetConversionFailures(_ *gofr.Context) (interface{}, error) {
+	return h.deps.ConversionFailures.snapshot(h.deps.currentConfig().GraphConversion.Strict), nil
+}
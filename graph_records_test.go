@@ -0,0 +1,147 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeGraphNodeRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   graphNodeRecord
+		reason string
+	}{
+		{
+			name:  "complete node",
+			value: map[string]interface{}{"id": "repo-1", "type": "repository", "label": "api", "data": map[string]interface{}{"language": "Go"}},
+			want:  graphNodeRecord{ID: "repo-1", Type: "repository", Label: "api", Data: map[string]interface{}{"language": "Go"}},
+		},
+		{
+			name:  "missing data defaults to empty",
+			value: map[string]interface{}{"id": "team-1", "type": "team", "label": "platform"},
+			want:  graphNodeRecord{ID: "team-1", Type: "team", Label: "platform", Data: map[string]interface{}{}},
+		},
+		{
+			name:  "null data defaults to empty",
+			value: map[string]interface{}{"id": "team-1", "type": "team", "data": nil},
+			want:  graphNodeRecord{ID: "team-1", Type: "team", Data: map[string]interface{}{}},
+		},
+		{name: "not a map", value: "repo-1", reason: graphDropNotAMap},
+		{name: "nil", value: nil, reason: graphDropNotAMap},
+		{name: "missing id", value: map[string]interface{}{"type": "repository"}, reason: graphDropMissingID},
+		{name: "missing type", value: map[string]interface{}{"id": "repo-1"}, reason: graphDropMissingType},
+		{name: "invalid data", value: map[string]interface{}{"id": "repo-1", "type": "repository", "data": "Go"}, reason: graphDropInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := decodeGraphNodeRecord(tt.value)
+			if reason != tt.reason {
+				t.Fatalf("reason = %q, want %q", reason, tt.reason)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("record = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeGraphEdgeRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   graphEdgeRecord
+		reason string
+	}{
+		{
+			name:  "complete edge",
+			value: map[string]interface{}{"id": "e-1", "source": "org", "target": "repo-1", "type": "owns", "label": "owns"},
+			want:  graphEdgeRecord{ID: "e-1", Source: "org", Target: "repo-1", Type: "owns", Label: "owns"},
+		},
+		{
+			name:  "label is optional",
+			value: map[string]interface{}{"id": "e-1", "source": "org", "target": "repo-1", "type": "owns"},
+			want:  graphEdgeRecord{ID: "e-1", Source: "org", Target: "repo-1", Type: "owns"},
+		},
+		{name: "not a map", value: []interface{}{"e-1"}, reason: graphDropNotAMap},
+		{name: "missing id", value: map[string]interface{}{"source": "org", "target": "repo-1", "type": "owns"}, reason: graphDropMissingID},
+		{name: "missing source", value: map[string]interface{}{"id": "e-1", "target": "repo-1", "type": "owns"}, reason: graphDropMissingEnd},
+		{name: "missing target", value: map[string]interface{}{"id": "e-1", "source": "org", "type": "owns"}, reason: graphDropMissingEnd},
+		{name: "missing type", value: map[string]interface{}{"id": "e-1", "source": "org", "target": "repo-1"}, reason: graphDropMissingType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := decodeGraphEdgeRecord(tt.value)
+			if reason != tt.reason {
+				t.Fatalf("reason = %q, want %q", reason, tt.reason)
+			}
+			if got != tt.want {
+				t.Errorf("record = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeGraphNodesRecord(t *testing.T) {
+	org := map[string]interface{}{"id": "org", "type": "organization", "label": "acme"}
+	repo := map[string]interface{}{"id": "repo-1", "type": "repository", "label": "api"}
+
+	tests := []struct {
+		name         string
+		record       map[string]interface{}
+		repositories int
+		organization bool
+		decoded      int
+		dropped      map[string]int
+		missing      []string
+	}{
+		{
+			name:         "complete row",
+			record:       map[string]interface{}{"org_node": org, "repos": []interface{}{repo}, "teams": []interface{}{}, "topics": []interface{}{}, "users": []interface{}{}},
+			repositories: 1,
+			organization: true,
+			decoded:      2,
+			dropped:      map[string]int{},
+		},
+		{
+			name:         "invalid entries are dropped",
+			record:       map[string]interface{}{"org_node": "acme", "repos": []interface{}{repo, map[string]interface{}{"type": "repository"}}, "teams": []interface{}{}, "topics": []interface{}{}, "users": []interface{}{}},
+			repositories: 1,
+			decoded:      1,
+			dropped:      map[string]int{graphDropNotAMap: 1, graphDropMissingID: 1},
+		},
+		{
+			name:         "missing columns are reported",
+			record:       map[string]interface{}{"org_node": org, "repos": "api"},
+			organization: true,
+			decoded:      1,
+			dropped:      map[string]int{graphDropMissingField: 4},
+			missing:      []string{"repos", "teams", "topics", "users"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newGraphConversionReport("nodes")
+			got := decodeGraphNodesRecord(tt.record, report)
+
+			if len(got.Repositories) != tt.repositories {
+				t.Errorf("repositories = %d, want %d", len(got.Repositories), tt.repositories)
+			}
+			if (got.Organization != nil) != tt.organization {
+				t.Errorf("organization decoded = %v, want %v", got.Organization != nil, tt.organization)
+			}
+			if report.Decoded != tt.decoded {
+				t.Errorf("decoded = %d, want %d", report.Decoded, tt.decoded)
+			}
+			if !reflect.DeepEqual(report.Dropped, tt.dropped) {
+				t.Errorf("dropped = %v, want %v", report.Dropped, tt.dropped)
+			}
+			if !reflect.DeepEqual(report.MissingFields, tt.missing) {
+				t.Errorf("missing fields = %v, want %v", report.MissingFields, tt.missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// customNodeRowStart is the layout row of the first custom node type, below the built-in rows
+const customNodeRowStart = 700
+
+// customNodeRowSpacing is the vertical distance between custom node type rows
+const customNodeRowSpacing = 100
+
+// NodeTypeDefinition describes a node label that can be written and queried
+type NodeTypeDefinition struct {
+	Label           string `json:"label"`
+	MergeKey        string `json:"merge_key"`
+	GraphType       string `json:"graph_type"`
+	DisplayProperty string `json:"display_property"`
+	// Color is the hex color the frontend legend and graph draw nodes of this type with
+	Color string `json:"color,omitempty"`
+	// Query optionally overrides the generated node query; it receives $orgName and must return `node` maps
+	Query string `json:"query,omitempty"`
+
+	builtin bool
+	idExpr  string
+}
+
+// RelationshipTypeDefinition describes a relationship type between two registered node types
+type RelationshipTypeDefinition struct {
+	Type      string `json:"type"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	GraphType string `json:"graph_type"`
+	Label     string `json:"label"`
+	// Color is the hex color the frontend legend and graph draw edges of this type with
+	Color string `json:"color,omitempty"`
+	// Query optionally overrides the generated edge query; it receives $orgName and must return `edge` maps
+	Query string `json:"query,omitempty"`
+}
+
+// GraphTypeDefinitions is the on-disk format of custom graph type definitions
+type GraphTypeDefinitions struct {
+	Nodes         []NodeTypeDefinition         `json:"nodes"`
+	Relationships []RelationshipTypeDefinition `json:"relationships"`
+}
+
+// GraphTypeRegistry holds built-in and custom node and relationship types
+type GraphTypeRegistry struct {
+	nodes         map[string]NodeTypeDefinition
+	relationships map[string]RelationshipTypeDefinition
+	customNodes   []string
+}
+
+// CustomGraphQuery is a generated or templated query contributing nodes or edges to the graph
+type CustomGraphQuery struct {
+	Group string
+	Query string
+}
+
+// EntityLink represents a relationship to create from or to a custom entity
+type EntityLink struct {
+	Relationship string `json:"relationship"`
+	Key          string `json:"key"`
+}
+
+// EntityUpsertRequest represents a request to create or update a custom entity
+type EntityUpsertRequest struct {
+	Organization string                 `json:"organization"`
+	Properties   map[string]interface{} `json:"properties"`
+	Links        []EntityLink           `json:"links"`
+}
+
+// EntityUpsertResponse represents the stored custom entity
+type EntityUpsertResponse struct {
+	Type         string `json:"type"`
+	Key          string `json:"key"`
+	Organization string `json:"organization"`
+	LinksCreated int    `json:"links_created"`
+}
+
+// builtinNodeTypes returns the node types the scanner writes itself (Pure Core)
+func builtinNodeTypes() []NodeTypeDefinition {
+	return []NodeTypeDefinition{
+		{Label: "Organization", MergeKey: "login", GraphType: "organization", DisplayProperty: "name", Color: "#238636", builtin: true, idExpr: "%s.id"},
+		{Label: "Repository", MergeKey: "full_name", GraphType: "repository", DisplayProperty: "name", Color: "#1f6feb", builtin: true, idExpr: "%s.id"},
+		{Label: "Team", MergeKey: "key", GraphType: "team", DisplayProperty: "name", Color: "#f59e0b", builtin: true, idExpr: "%s.id"},
+		{Label: "User", MergeKey: "login", GraphType: "user", DisplayProperty: "login", Color: "#8b5cf6", builtin: true, idExpr: "%s.id"},
+		{Label: "Topic", MergeKey: "name", GraphType: "topic", DisplayProperty: "name", Color: "#fb7185", builtin: true, idExpr: "%s.name"},
+	}
+}
+
+// newGraphTypeRegistry creates a registry containing only the built-in types
+func newGraphTypeRegistry() *GraphTypeRegistry {
+	registry := &GraphTypeRegistry{
+		nodes:         make(map[string]NodeTypeDefinition),
+		relationships: make(map[string]RelationshipTypeDefinition),
+	}
+
+	for _, def := range builtinNodeTypes() {
+		registry.nodes[def.Label] = def
+	}
+
+	return registry
+}
+
+// loadGraphTypeRegistry builds the registry from built-in types and the optional definitions file
+func loadGraphTypeRegistry(config GraphTypesConfig) (*GraphTypeRegistry, error) {
+	registry := newGraphTypeRegistry()
+	if config.DefinitionsFile == "" {
+		return registry, nil
+	}
+
+	content, err := os.ReadFile(config.DefinitionsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read graph type definitions: %w", err)
+	}
+
+	var definitions GraphTypeDefinitions
+	if err := json.Unmarshal(content, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse graph type definitions: %w", err)
+	}
+
+	if err := registry.register(definitions); err != nil {
+		return nil, fmt.Errorf("invalid graph type definitions: %w", err)
+	}
+
+	return registry, nil
+}
+
+// register validates and adds custom definitions to the registry
+func (r *GraphTypeRegistry) register(definitions GraphTypeDefinitions) error {
+	for _, def := range definitions.Nodes {
+		if err := validateNodeTypeDefinition(def); err != nil {
+			return err
+		}
+		if _, exists := r.nodes[def.Label]; exists {
+			return fmt.Errorf("node type %s is already registered", def.Label)
+		}
+		def.idExpr = fmt.Sprintf("'%s:' + toString(%%s.%s)", escapeCypherString(def.GraphType), quoteCypherIdentifier("merge_key", def.MergeKey))
+		r.nodes[def.Label] = def
+		r.customNodes = append(r.customNodes, def.Label)
+	}
+
+	for _, def := range definitions.Relationships {
+		if err := r.validateRelationshipTypeDefinition(def); err != nil {
+			return err
+		}
+		r.relationships[def.Type] = def
+	}
+
+	sort.Strings(r.customNodes)
+	return nil
+}
+
+// validateNodeTypeDefinition checks a custom node type definition (Pure Core)
+func validateNodeTypeDefinition(def NodeTypeDefinition) error {
+	for field, value := range map[string]string{
+		"label":            def.Label,
+		"merge_key":        def.MergeKey,
+		"graph_type":       def.GraphType,
+		"display_property": def.DisplayProperty,
+	} {
+		if err := validateCypherIdentifier(field, value); err != nil {
+			return fmt.Errorf("node type %q: %w", def.Label, err)
+		}
+	}
+	if def.Color != "" && !isHexColor(def.Color) {
+		return fmt.Errorf("node type %q: color %q must be a hex color such as #1f6feb", def.Label, def.Color)
+	}
+	return nil
+}
+
+// validateRelationshipTypeDefinition checks a custom relationship type against the registered node types
+func (r *GraphTypeRegistry) validateRelationshipTypeDefinition(def RelationshipTypeDefinition) error {
+	if err := validateCypherIdentifier("type", def.Type); err != nil {
+		return fmt.Errorf("relationship type %q: %w", def.Type, err)
+	}
+	if err := validateCypherIdentifier("graph_type", def.GraphType); err != nil {
+		return fmt.Errorf("relationship type %q: %w", def.Type, err)
+	}
+	if def.Color != "" && !isHexColor(def.Color) {
+		return fmt.Errorf("relationship type %q: color %q must be a hex color such as #1f6feb", def.Type, def.Color)
+	}
+	if _, exists := r.relationships[def.Type]; exists {
+		return fmt.Errorf("relationship type %s is already registered", def.Type)
+	}
+
+	from, fromExists := r.nodes[def.From]
+	to, toExists := r.nodes[def.To]
+	if !fromExists || !toExists {
+		return fmt.Errorf("relationship type %s references unregistered node types %s -> %s", def.Type, def.From, def.To)
+	}
+
+	if from.builtin && to.builtin {
+		return fmt.Errorf("relationship type %s must involve at least one custom node type", def.Type)
+	}
+	if !isScopableNodeType(from) || !isScopableNodeType(to) {
+		return fmt.Errorf("relationship type %s may only connect custom types with Organization, Repository or Team", def.Type)
+	}
+
+	return nil
+}
+
+// isScopableNodeType reports whether nodes of a type can be scoped to a single organization (Pure Core)
+func isScopableNodeType(def NodeTypeDefinition) bool {
+	if !def.builtin {
+		return true
+	}
+	return def.Label == "Organization" || def.Label == "Repository" || def.Label == "Team"
+}
+
+// nodeType returns a registered node type
+func (r *GraphTypeRegistry) nodeType(label string) (NodeTypeDefinition, bool) {
+	if r == nil {
+		return NodeTypeDefinition{}, false
+	}
+	def, exists := r.nodes[label]
+	return def, exists
+}
+
+// customNodeTypes returns the custom node types in a stable order
+func (r *GraphTypeRegistry) customNodeTypes() []NodeTypeDefinition {
+	if r == nil {
+		return nil
+	}
+
+	defs := make([]NodeTypeDefinition, 0, len(r.customNodes))
+	for _, label := range r.customNodes {
+		defs = append(defs, r.nodes[label])
+	}
+	return defs
+}
+
+// customRelationshipTypes returns the custom relationship types in a stable order
+func (r *GraphTypeRegistry) customRelationshipTypes() []RelationshipTypeDefinition {
+	if r == nil {
+		return nil
+	}
+
+	types := make([]string, 0, len(r.relationships))
+	for relType := range r.relationships {
+		types = append(types, relType)
+	}
+	sort.Strings(types)
+
+	defs := make([]RelationshipTypeDefinition, 0, len(types))
+	for _, relType := range types {
+		defs = append(defs, r.relationships[relType])
+	}
+	return defs
+}
+
+// customNodeRowOffset returns the layout row of a custom node group
+func (r *GraphTypeRegistry) customNodeRowOffset(graphType string) float64 {
+	for i, def := range r.customNodeTypes() {
+		if def.GraphType == graphType {
+			return float64(customNodeRowStart + i*customNodeRowSpacing)
+		}
+	}
+	return customNodeRowStart
+}
+
+// buildNodeScopeCondition builds a predicate limiting a node variable to the organization's active data (Pure Core)
+func buildNodeScopeCondition(def NodeTypeDefinition, variable string) string {
+	switch def.Label {
+	case "Organization":
+		return fmt.Sprintf("%s.login = $orgName", variable)
+	case "Repository":
+		return fmt.Sprintf("EXISTS { MATCH (o:Organization {login: $orgName})-[owns:OWNS]->(%s) WHERE coalesce(owns.scan_id, '') = coalesce(o.active_scan_id, '') }", variable)
+	case "Team":
+		return fmt.Sprintf("EXISTS { MATCH (o:Organization {login: $orgName})-[has_team:HAS_TEAM]->(%s) WHERE coalesce(has_team.scan_id, '') = coalesce(o.active_scan_id, '') }", variable)
+	default:
+		return fmt.Sprintf("%s.organization = $orgName", variable)
+	}
+}
+
+// buildCustomNodesQuery builds a query returning one node map per custom entity of a type (Pure Core)
+func buildCustomNodesQuery(def NodeTypeDefinition) string {
+	return cachedQuery(func() string {
+		if def.Query != "" {
+			return def.Query
+		}
+
+		return fmt.Sprintf(`
+			MATCH (n:%s)
+			WHERE %s
+			RETURN {
+				id: %s,
+				type: '%s',
+				label: toString(n.%s),
+				data: properties(n)
+			} AS node
+			ORDER BY n.%s
+		`, quoteCypherIdentifier("label", def.Label), buildNodeScopeCondition(def, "n"), fmt.Sprintf(def.idExpr, "n"),
+			escapeCypherString(def.GraphType), quoteCypherIdentifier("display_property", def.DisplayProperty), quoteCypherIdentifier("merge_key", def.MergeKey))
+	}, "custom_nodes", def)
+}
+
+// buildCustomEdgesQuery builds a query returning one edge map per custom relationship (Pure Core)
+func buildCustomEdgesQuery(def RelationshipTypeDefinition, from, to NodeTypeDefinition) string {
+	return cachedQuery(func() string {
+		if def.Query != "" {
+			return def.Query
+		}
+
+		sourceID := fmt.Sprintf(from.idExpr, "source")
+		targetID := fmt.Sprintf(to.idExpr, "target")
+
+		return fmt.Sprintf(`
+			MATCH (source:%s)-[:%s]->(target:%s)
+			WHERE %s AND %s
+			RETURN {
+				id: '%s-' + %s + '-' + %s,
+				source: %s,
+				target: %s,
+				type: '%s',
+				label: '%s'
+			} AS edge
+		`, quoteCypherIdentifier("label", from.Label), quoteCypherIdentifier("relationship type", def.Type), quoteCypherIdentifier("label", to.Label),
+			buildNodeScopeCondition(from, "source"), buildNodeScopeCondition(to, "target"),
+			escapeCypherString(def.GraphType), sourceID, targetID, sourceID, targetID, escapeCypherString(def.GraphType), escapeCypherString(def.Label))
+	}, "custom_edges", def, from, to)
+}
+
+// buildCustomEntityMergeQuery builds a query upserting a custom entity by its merge key (Pure Core)
+func buildCustomEntityMergeQuery(def NodeTypeDefinition) string {
+	return cachedQuery(func() string {
+		return fmt.Sprintf(`
+			MERGE (n:%s {%s: $key})
+			SET n += $properties,
+				n.organization = $organization,
+				n.updated_at = $updated_at
+			RETURN n.%s AS key
+		`, quoteCypherIdentifier("label", def.Label), quoteCypherIdentifier("merge_key", def.MergeKey), quoteCypherIdentifier("merge_key", def.MergeKey))
+	}, "custom_entity_merge", def)
+}
+
+// buildCustomEntityLinkQuery builds a query linking a custom entity to another registered node (Pure Core)
+func buildCustomEntityLinkQuery(rel RelationshipTypeDefinition, entity, other NodeTypeDefinition, outgoing bool) string {
+	return cachedQuery(func() string {
+		pattern := "(n)-[:%s]->(other)"
+		if !outgoing {
+			pattern = "(other)-[:%s]->(n)"
+		}
+
+		return fmt.Sprintf(`
+			MATCH (n:%s {%s: $key})
+			MATCH (other:%s {%s: $other_key})
+			MERGE `+pattern+`
+			RETURN count(*) AS linked
+		`, quoteCypherIdentifier("label", entity.Label), quoteCypherIdentifier("merge_key", entity.MergeKey),
+			quoteCypherIdentifier("label", other.Label), quoteCypherIdentifier("merge_key", other.MergeKey),
+			quoteCypherIdentifier("relationship type", rel.Type))
+	}, "custom_entity_link", rel, entity, other, outgoing)
+}
+
+// customGraphNodeQueries returns the node queries of all custom types (Pure Core)
+func customGraphNodeQueries(registry *GraphTypeRegistry) []CustomGraphQuery {
+	defs := registry.customNodeTypes()
+	queries := make([]CustomGraphQuery, 0, len(defs))
+	for _, def := range defs {
+		queries = append(queries, CustomGraphQuery{Group: def.GraphType, Query: buildCustomNodesQuery(def)})
+	}
+	return queries
+}
+
+// customGraphEdgeQueries returns the edge queries of all custom relationship types (Pure Core)
+func customGraphEdgeQueries(registry *GraphTypeRegistry) []CustomGraphQuery {
+	defs := registry.customRelationshipTypes()
+	queries := make([]CustomGraphQuery, 0, len(defs))
+	for _, def := range defs {
+		from, _ := registry.nodeType(def.From)
+		to, _ := registry.nodeType(def.To)
+		queries = append(queries, CustomGraphQuery{Group: def.GraphType, Query: buildCustomEdgesQuery(def, from, to)})
+	}
+	return queries
+}
+
+// fetchCustomGraphElements fetches nodes and edges of custom entity types for an organization, counting
+// records dropped during conversion in the node and edge reports (Orchestrator)
+func fetchCustomGraphElements(ctx context.Context, session *Neo4jSession, registry *GraphTypeRegistry, orgName string, nodeReport, edgeReport *graphConversionReport) ([]GraphNode, []GraphEdge, error) {
+	params := map[string]interface{}{"orgName": orgName}
+
+	var nodes []GraphNode
+	for _, query := range customGraphNodeQueries(registry) {
+		result, err := executeNeo4jReadQuery(ctx, session, query.Query, params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		list := make([]interface{}, 0, len(result.Records))
+		for _, record := range result.Records {
+			list = append(list, record["node"])
+		}
+		nodes = append(nodes, convertListToGraphNodes(list, registry.customNodeRowOffset(query.Group), graphNodeSpacing, nodeReport)...)
+	}
+
+	var edges []GraphEdge
+	for _, query := range customGraphEdgeQueries(registry) {
+		result, err := executeNeo4jReadQuery(ctx, session, query.Query, params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		list := make([]interface{}, 0, len(result.Records))
+		for _, record := range result.Records {
+			list = append(list, record["edge"])
+		}
+		for _, edge := range decodeGraphEdgeValues(list, edgeReport) {
+			edges = append(edges, edge.toGraphEdge())
+		}
+	}
+
+	return nodes, edges, nil
+}
+
+// createCustomNodeConstraints creates uniqueness constraints on the merge keys of custom node types (Orchestrator)
+func createCustomNodeConstraints(ctx context.Context, conn *Neo4jConnection, registry *GraphTypeRegistry) error {
+	defs := registry.customNodeTypes()
+	if len(defs) == 0 {
+		return nil
+	}
+
+	session, err := createNeo4jSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	for _, def := range defs {
+		if _, err := executeNeo4jSchemaWrite(ctx, session, buildNeo4jConstraintQuery(session.version, def.Label, def.MergeKey)); err != nil {
+			return wrapNeo4jError(err, fmt.Sprintf("failed to create constraint for %s.%s", def.Label, def.MergeKey))
+		}
+	}
+
+	return nil
+}
+
+// resolveEntityLink finds the relationship definition and the other endpoint of a link
+func resolveEntityLink(registry *GraphTypeRegistry, entity NodeTypeDefinition, link EntityLink) (RelationshipTypeDefinition, NodeTypeDefinition, bool, error) {
+	rel, exists := registry.relationships[link.Relationship]
+	if !exists {
+		return RelationshipTypeDefinition{}, NodeTypeDefinition{}, false, fmt.Errorf("unknown relationship type %s", link.Relationship)
+	}
+
+	switch entity.Label {
+	case rel.From:
+		other, _ := registry.nodeType(rel.To)
+		return rel, other, true, nil
+	case rel.To:
+		other, _ := registry.nodeType(rel.From)
+		return rel, other, false, nil
+	default:
+		return RelationshipTypeDefinition{}, NodeTypeDefinition{}, false, fmt.Errorf("relationship type %s does not involve %s", rel.Type, entity.Label)
+	}
+}
+
+// storeCustomEntity upserts a custom entity and its links (Orchestrator)
+func storeCustomEntity(ctx *gofr.Context, session *Neo4jSession, registry *GraphTypeRegistry, entity NodeTypeDefinition, key string, request EntityUpsertRequest) (int, error) {
+	properties := request.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+
+	_, err := executeNeo4jWrite(ctx, session, buildCustomEntityMergeQuery(entity), map[string]interface{}{
+		"key":          key,
+		"properties":   properties,
+		"organization": request.Organization,
+		"updated_at":   time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	linksCreated := 0
+	for _, link := range request.Links {
+		rel, other, outgoing, err := resolveEntityLink(registry, entity, link)
+		if err != nil {
+			return linksCreated, &gofrhttp.ErrorInvalidParam{Params: []string{"links"}}
+		}
+
+		result, err := executeNeo4jWrite(ctx, session, buildCustomEntityLinkQuery(rel, entity, other, outgoing), map[string]interface{}{
+			"key":       key,
+			"other_key": link.Key,
+		})
+		if err != nil {
+			return linksCreated, err
+		}
+
+		if len(result.Records) > 0 && getIntFromMap(result.Records[0], "linked") > 0 {
+			linksCreated++
+		}
+	}
+
+	return linksCreated, nil
+}
+
+// handleUpsertEntity creates or updates a custom entity registered in the graph type registry
+func (h *AppHandler) handleUpsertEntity(ctx *gofr.Context) (interface{}, error) {
+	typeName := ctx.PathParam("type")
+	key := ctx.PathParam("key")
+	if typeName == "" || key == "" {
+		return nil, createMissingParamError("type/key")
+	}
+
+	entity, exists := h.deps.GraphTypes.nodeType(typeName)
+	if !exists || entity.builtin {
+		return nil, &gofrhttp.ErrorEntityNotFound{
+			Name:  "entity type",
+			Value: typeName,
+		}
+	}
+
+	var request EntityUpsertRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+	if request.Organization == "" {
+		return nil, createMissingParamError("organization")
+	}
+
+	session, err := createNeo4jSession(ctx, h.deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	linksCreated, err := storeCustomEntity(ctx, session, h.deps.GraphTypes, entity, key, request)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	return EntityUpsertResponse{
+		Type:         entity.Label,
+		Key:          key,
+		Organization: request.Organization,
+		LinksCreated: linksCreated,
+	}, nil
+}
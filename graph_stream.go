@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// ndjsonContentType is the media type clients send in Accept to receive a streamed graph
+const ndjsonContentType = "application/x-ndjson"
+
+// graphRoutePrefix is the path prefix of the graph endpoint
+const graphRoutePrefix = "/api/graph/"
+
+// graphNodeSpacing is the horizontal distance between nodes of the same kind
+const graphNodeSpacing = 200
+
+// graphNodeRowOffsets maps node groups to their row in the default layout
+var graphNodeRowOffsets = map[string]float64{
+	"organization": 0,
+	"repos":        200,
+	"teams":        400,
+	"topics":       500,
+	"users":        600,
+}
+
+// GraphStreamLine represents a single NDJSON line of a streamed graph
+type GraphStreamLine struct {
+	Type      string     `json:"type"`
+	Node      *GraphNode `json:"node,omitempty"`
+	Edge      *GraphEdge `json:"edge,omitempty"`
+	NodeCount int        `json:"node_count,omitempty"`
+	EdgeCount int        `json:"edge_count,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// buildGraphNodesStreamQuery builds a query returning one graph node per record (Pure Core)
+func buildGraphNodesStreamQuery(orgName string, groupBy GraphGroupBy) string {
+	validateOrgNameNotEmpty(orgName)
+
+	return cachedQuery(func() string {
+		return `
+			CALL {` + buildGraphNodesQuery(orgName, groupBy) + `}
+			UNWIND [
+				{grp: 'organization', nodes: [org_node]},
+				{grp: 'repos', nodes: repos},
+				{grp: 'teams', nodes: teams},
+				{grp: 'topics', nodes: topics},
+				{grp: 'users', nodes: users}
+			] AS node_group
+			UNWIND node_group.nodes AS node
+			RETURN node_group.grp AS node_group, node
+		`
+	}, "graph_nodes_stream", groupBy)
+}
+
+// buildGraphEdgesStreamQuery builds a query returning one graph edge per record (Pure Core)
+func buildGraphEdgesStreamQuery(orgName string, groupBy GraphGroupBy) string {
+	validateOrgNameNotEmpty(orgName)
+
+	return cachedQuery(func() string {
+		return `
+			CALL {` + buildGraphEdgesQuery(orgName, groupBy) + `}
+			UNWIND edges AS edge
+			RETURN edge
+		`
+	}, "graph_edges_stream", groupBy)
+}
+
+// acceptsNDJSON reports whether the client asked for a streamed response (Pure Core)
+func acceptsNDJSON(accept string) bool {
+	return strings.Contains(accept, ndjsonContentType)
+}
+
+// extractGraphOrgFromPath returns the organization of a graph route path (Pure Core)
+func extractGraphOrgFromPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, graphRoutePrefix) {
+		return "", false
+	}
+
+	orgName := strings.TrimPrefix(path, graphRoutePrefix)
+	if orgName == "" || strings.Contains(orgName, "/") || path == graphMetaRoute {
+		return "", false
+	}
+
+	return orgName, true
+}
+
+// parseUseTopicsParam parses the useTopics query parameter the same way the JSON handler does (Pure Core)
+func parseUseTopicsParam(value string) bool {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false
+	}
+	return parsed
+}
+
+// checkGraphStreamCost applies the graph size limits to a streamed graph, which is served outside
+// GoFr's handlers
+func checkGraphStreamCost(r *http.Request, deps *AppDependencies, orgName string, groupBy GraphGroupBy) error {
+	return checkGraphCost(deps.requestContext(r), deps, orgName, groupBy)
+}
+
+// writeGraphStreamRejection answers a graph stream refused before it started in GoFr's error
+// envelope, with the status the JSON handler would return for the same error
+func writeGraphStreamRejection(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(classifyError(err).StatusCode())
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"message": err.Error()},
+	})
+}
+
+// graphStreamMiddleware serves GET /api/graph/{org} as NDJSON when the client accepts it
+func graphStreamMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			orgName, isGraphRoute := extractGraphOrgFromPath(r.URL.Path)
+			if r.Method != http.MethodGet || !isGraphRoute || !acceptsNDJSON(r.Header.Get("Accept")) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			query := r.URL.Query()
+			groupBy, ok := parseGraphGroupBy(query.Get("group_by"), parseUseTopicsParam(query.Get("useTopics")))
+			if !ok {
+				http.Error(w, "group_by must be teams, topics or both", http.StatusBadRequest)
+				return
+			}
+			// The limits are checked before the 200 header is written, as a stream cannot fail afterwards
+			if err := checkGraphStreamCost(r, deps, orgName, groupBy); err != nil {
+				writeGraphStreamRejection(w, err)
+				return
+			}
+			streamOrganizationGraph(r.Context(), w, deps, orgName, groupBy)
+		})
+	}
+}
+
+// ndjsonWriter encodes lines and flushes them to the client immediately
+type ndjsonWriter struct {
+	encoder *json.Encoder
+	flusher http.Flusher
+}
+
+// newNDJSONWriter prepares a response writer for NDJSON streaming
+func newNDJSONWriter(w http.ResponseWriter) *ndjsonWriter {
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	return &ndjsonWriter{encoder: json.NewEncoder(w), flusher: flusher}
+}
+
+// write encodes a single line and flushes it
+func (n *ndjsonWriter) write(line GraphStreamLine) error {
+	if err := n.encoder.Encode(line); err != nil {
+		return err
+	}
+	if n.flusher != nil {
+		n.flusher.Flush()
+	}
+	return nil
+}
+
+// streamOrganizationGraph writes graph nodes followed by edges as NDJSON lines, masking or omitting
+// secret teams as the caller's scope requires (Orchestrator)
+func streamOrganizationGraph(ctx context.Context, w http.ResponseWriter, deps *AppDependencies, orgName string, groupBy GraphGroupBy) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	writer := newNDJSONWriter(w)
+	params := map[string]interface{}{"orgName": orgName}
+
+	visibility := newTeamVisibilityFilter(secretTeamsMode(ctx, deps.currentConfig()))
+	nodeReport := newGraphConversionReport("node")
+	edgeReport := newGraphConversionReport("edge")
+	groupCounts := make(map[string]int)
+	nodeCount, err := streamNeo4jReadQuery(ctx, session, buildGraphNodesStreamQuery(orgName, groupBy), params, func(record map[string]interface{}) error {
+		node, ok := convertStreamRecordToGraphNode(record, groupCounts, nodeReport)
+		if !ok {
+			return nil
+		}
+		if node, ok = visibility.node(node); !ok {
+			return nil
+		}
+		return writer.write(GraphStreamLine{Type: "node", Node: &node})
+	})
+	if err != nil {
+		writeGraphStreamError(session.ctx, writer, orgName, err)
+		return
+	}
+
+	for _, query := range customGraphNodeQueries(deps.GraphTypes) {
+		rowOffset := deps.GraphTypes.customNodeRowOffset(query.Group)
+		index := 0
+		count, err := streamNeo4jReadQuery(ctx, session, query.Query, params, func(record map[string]interface{}) error {
+			decoded, reason := decodeGraphNodeRecord(record["node"])
+			if reason != "" {
+				nodeReport.drop(reason)
+				return nil
+			}
+			nodeReport.keep()
+			node := decoded.toGraphNode(float64(index*graphNodeSpacing), rowOffset)
+			index++
+			return writer.write(GraphStreamLine{Type: "node", Node: &node})
+		})
+		if err != nil {
+			writeGraphStreamError(session.ctx, writer, orgName, err)
+			return
+		}
+		nodeCount += count
+	}
+
+	edgeCount, err := streamNeo4jReadQuery(ctx, session, buildGraphEdgesStreamQuery(orgName, groupBy), params, writeEdgeRecord(writer, edgeReport, visibility))
+	if err != nil {
+		writeGraphStreamError(session.ctx, writer, orgName, err)
+		return
+	}
+
+	for _, query := range customGraphEdgeQueries(deps.GraphTypes) {
+		count, err := streamNeo4jReadQuery(ctx, session, query.Query, params, writeEdgeRecord(writer, edgeReport, visibility))
+		if err != nil {
+			writeGraphStreamError(session.ctx, writer, orgName, err)
+			return
+		}
+		edgeCount += count
+	}
+
+	// In strict conversion mode a stream that dropped records ends with an error line instead of the end line
+	if err := reportGraphConversion(session.ctx, deps, orgName, nodeReport, edgeReport); err != nil {
+		writeGraphStreamError(session.ctx, writer, orgName, err)
+		return
+	}
+
+	_ = writer.write(GraphStreamLine{
+		Type:      "end",
+		NodeCount: nodeCount - len(visibility.omitted),
+		EdgeCount: edgeCount - visibility.skippedEdges,
+	})
+}
+
+// writeEdgeRecord returns a record handler writing `edge` maps as edge lines, counting the records
+// dropped in report and skipping edges of teams the caller may not see
+func writeEdgeRecord(writer *ndjsonWriter, report *graphConversionReport, visibility *teamVisibilityFilter) func(map[string]interface{}) error {
+	return func(record map[string]interface{}) error {
+		decoded, reason := decodeGraphEdgeRecord(record["edge"])
+		if reason != "" {
+			report.drop(reason)
+			return nil
+		}
+		report.keep()
+		edge := decoded.toGraphEdge()
+		if !visibility.edge(edge) {
+			return nil
+		}
+		return writer.write(GraphStreamLine{Type: "edge", Edge: &edge})
+	}
+}
+
+// convertStreamRecordToGraphNode converts a streamed node record and lays it out within its group,
+// counting it in report (Pure Core)
+func convertStreamRecordToGraphNode(record map[string]interface{}, groupCounts map[string]int, report *graphConversionReport) (GraphNode, bool) {
+	decoded, reason := decodeGraphNodeRecord(record["node"])
+	if reason != "" {
+		report.drop(reason)
+		return GraphNode{}, false
+	}
+	report.keep()
+
+	group := getStringFromMap(record, "node_group")
+	index := groupCounts[group]
+	groupCounts[group] = index + 1
+
+	return decoded.toGraphNode(float64(index*graphNodeSpacing), graphNodeRowOffsets[group]), true
+}
+
+// writeGraphStreamError reports a failure after streaming has started as a final error line
+func writeGraphStreamError(ctx *gofr.Context, writer *ndjsonWriter, orgName string, err error) {
+	logError(ctx, "Graph stream aborted", LogFields{
+		"component":    "graph_stream",
+		"operation":    "stream_graph",
+		"organization": orgName,
+		"error":        err.Error(),
+	})
+
+	_ = writer.write(GraphStreamLine{Type: "error", Error: "graph stream aborted"})
+}
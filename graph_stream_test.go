@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestExtractGraphOrgFromPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "/api/graph/acme", want: "acme", wantOK: true},
+		{path: "/api/graph/acme-labs", want: "acme-labs", wantOK: true},
+		{path: "/api/graph/", wantOK: false},
+		{path: "/api/graph", wantOK: false},
+		{path: "/api/graph/meta", wantOK: false},
+		{path: "/api/graph/acme/changes/wait", wantOK: false},
+		{path: "/api/graph/acme/export", wantOK: false},
+		{path: "/api/stats/acme", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := extractGraphOrgFromPath(tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("extractGraphOrgFromPath(%q) = (%q, %v), want (%q, %v)", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -21,8 +21,22 @@ func (h *AppHandler) handleScanOrganization(ctx *gofr.Context) (interface{}, err
 		return nil, createMissingParamError("org")
 	}
 
-	scanRequest := buildScanRequest(ctx, h.deps.Config, orgName)
-	response, err := scanOrganization(ctx, h.deps, scanRequest)
+	scanRequest := buildScanRequest(ctx, h.deps.currentConfig(), orgName)
+	if !isValidScanMode(scanRequest.Mode) {
+		return nil, &gofrhttp.ErrorInvalidParam{
+			Params: []string{"mode"},
+		}
+	}
+	if err := validateScanProvider(scanRequest.Provider, h.deps.currentConfig()); err != nil {
+		return nil, err
+	}
+
+	// wait=true keeps the blocking behaviour for callers that need the scan result in the response
+	if !parseBoolFromQuery(ctx, "wait", false) {
+		return enqueueScanJob(ctx, h.deps, scanRequest)
+	}
+
+	response, err := runLockedScan(ctx, h.deps, scanRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -37,13 +51,54 @@ func (h *AppHandler) handleGetGraph(ctx *gofr.Context) (interface{}, error) {
 		return nil, createMissingParamError("org")
 	}
 
-	useTopics := parseBoolFromQuery(ctx, "useTopics", false)
-	response, err := getOrganizationGraph(ctx, h.deps, orgName, useTopics)
+	groupBy, ok := parseGraphGroupBy(ctx.Param("group_by"), parseBoolFromQuery(ctx, "useTopics", false))
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"group_by"}}
+	}
+	mode := secretTeamsMode(ctx, h.deps.currentConfig())
+	if parseBoolFromQuery(ctx, "summarize", false) {
+		summary, err := getOrganizationGraphSummary(ctx, h.deps, orgName, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		return applyTeamVisibility(summary, mode), nil
+	}
+
+	options, err := parseGraphQueryOptions(ctx, h.deps.GraphTypes)
 	if err != nil {
 		return nil, err
 	}
+	if options.paged() {
+		// A page is bounded by its repository limit, so the full graph cost check does not apply
+		page, err := getOrganizationGraphPage(ctx, h.deps, orgName, groupBy, options)
+		if err != nil {
+			return nil, err
+		}
+		h.deps.Access.record(orgName)
+		return filterGraphByTypes(applyTeamVisibility(page, mode), options.Types), nil
+	}
 
-	return response, nil
+	if err := checkGraphCost(ctx, h.deps, orgName, groupBy); err != nil {
+		return serveStaleGraph(ctx, h.deps, orgName, groupBy, options, err)
+	}
+
+	response, err := getOrganizationGraph(ctx, h.deps, orgName, groupBy)
+	if err != nil {
+		return serveStaleGraph(ctx, h.deps, orgName, groupBy, options, err)
+	}
+	h.deps.ResponseCache.store(graphCacheKey(orgName, groupBy), response)
+	h.deps.Access.record(orgName)
+
+	return filterGraphByTypes(applyTeamVisibility(response, mode), options.Types), nil
+}
+
+// serveStaleGraph answers a failed graph request with the cached full graph when allowed; requests
+// filtering node types get the error instead, since only the unfiltered graph is cached
+func serveStaleGraph(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy, options GraphQueryOptions, err error) (interface{}, error) {
+	if options.filtered() {
+		return nil, err
+	}
+	return serveStaleResponse(ctx, deps, graphCacheKey(orgName, groupBy), err)
 }
 
 // handleGetStats handles statistics retrieval
@@ -53,12 +108,32 @@ func (h *AppHandler) handleGetStats(ctx *gofr.Context) (interface{}, error) {
 		return nil, createMissingParamError("org")
 	}
 
+	if rollup := ctx.Param("rollup"); rollup != "" {
+		if !isValidRollupLevel(rollup) {
+			return nil, &gofrhttp.ErrorInvalidParam{
+				Params: []string{"rollup"},
+			}
+		}
+		return getOrganizationRollupStats(ctx, h.deps, orgName, rollup)
+	}
+
 	response, err := getOrganizationStats(ctx, h.deps, orgName)
 	if err != nil {
-		return nil, err
+		return serveStaleResponse(ctx, h.deps, statsCacheKey(orgName), err)
+	}
+	h.deps.ResponseCache.store(statsCacheKey(orgName), response)
+	h.deps.Access.record(orgName)
+
+	// Team expansion reads live membership from GitHub, so it is never cached with the stats
+	if parseBoolFromQuery(ctx, "expandTeams", false) {
+		expansion, err := getTeamExpansion(ctx, h.deps, orgName)
+		if err != nil {
+			return nil, err
+		}
+		response.TeamExpansion = &expansion
 	}
 
-	return response, nil
+	return applyTeamVisibilityToStats(response, secretTeamsMode(ctx, h.deps.currentConfig())), nil
 }
 
 // handleHealth handles health check
@@ -67,7 +142,13 @@ func (h *AppHandler) handleHealth(ctx *gofr.Context) (interface{}, error) {
 		return nil, fmt.Errorf("database health check failed: %w", err)
 	}
 
-	return buildHealthResponse(), nil
+	var freshness *FreshnessSummary
+	if states, err := fetchOrganizationFreshness(ctx, h.deps); err == nil {
+		summary := summarizeFreshness(states, h.deps.currentConfig().Freshness.SLA)
+		freshness = &summary
+	}
+
+	return buildHealthResponse(h.deps.Maintenance.isEnabled(ctx), freshness, circuitBreakerStatuses(time.Now()), activeGitHubCooldown(time.Now())), nil
 }
 
 // handleOpenAPI serves the OpenAPI documentation UI
@@ -105,15 +186,39 @@ func buildScanRequest(ctx *gofr.Context, config AppConfig, orgName string) ScanR
 		MaxRepos:     maxRepos,
 		MaxTeams:     maxTeams,
 		UseTopics:    useTopics,
+		Mode:         ctx.Param("mode"),
+		Provider:     ctx.Param("provider"),
 	}
 }
 
-// buildHealthResponse constructs health check response
-func buildHealthResponse() map[string]interface{} {
-	return map[string]interface{}{
-		"status":    "healthy",
-		"database":  "connected",
-		"version":   "1.0.0",
-		"timestamp": time.Now().Format(time.RFC3339),
+// buildHealthResponse constructs health check response; any circuit breaker that is not closed and a
+// GitHub secondary rate limit cool-down report the service as degraded
+func buildHealthResponse(maintenance bool, freshness *FreshnessSummary, circuits []CircuitBreakerStatus, cooldown *GitHubCooldown) map[string]interface{} {
+	status := "healthy"
+	for _, circuit := range circuits {
+		if circuit.State != CircuitClosed {
+			status = "degraded"
+		}
+	}
+	if cooldown != nil {
+		status = "degraded"
+	}
+
+	response := map[string]interface{}{
+		"status":           status,
+		"database":         "connected",
+		"version":          "1.0.0",
+		"maintenance":      maintenance,
+		"circuit_breakers": circuits,
+		"timestamp":        time.Now().Format(time.RFC3339),
 	}
+
+	if freshness != nil {
+		response["data_freshness"] = freshness
+	}
+	if cooldown != nil {
+		response["github_cooldown"] = cooldown
+	}
+
+	return response
 }
\ No newline at end of file
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Supported rollup levels for organization statistics
+const (
+	RollupDepartment = "department"
+	RollupDivision   = "division"
+)
+
+// HierarchyEntry maps a team to its department and optional division
+type HierarchyEntry struct {
+	Team       string `json:"team"`
+	Department string `json:"department"`
+	Division   string `json:"division,omitempty"`
+}
+
+// HierarchyImportRequest represents an organizational hierarchy import, as JSON entries or CSV text
+type HierarchyImportRequest struct {
+	Entries []HierarchyEntry `json:"entries"`
+	CSV     string           `json:"csv"`
+}
+
+// HierarchyImportResponse represents the result of a hierarchy import
+type HierarchyImportResponse struct {
+	Organization   string   `json:"organization"`
+	EntriesTotal   int      `json:"entries_total"`
+	TeamsMatched   int      `json:"teams_matched"`
+	UnmatchedTeams []string `json:"unmatched_teams"`
+}
+
+// RollupUnitStats represents ownership statistics for one department or division
+type RollupUnitStats struct {
+	Name              string `json:"name"`
+	TotalTeams        int    `json:"total_teams"`
+	TotalRepositories int    `json:"total_repositories"`
+	Coverage          string `json:"coverage"`
+}
+
+// RollupStatsResponse represents organization statistics rolled up by hierarchy level
+type RollupStatsResponse struct {
+	Organization           string            `json:"organization"`
+	Rollup                 string            `json:"rollup"`
+	TotalRepositories      int               `json:"total_repositories"`
+	UnassignedRepositories int               `json:"unassigned_repositories"`
+	Units                  []RollupUnitStats `json:"units"`
+}
+
+// parseHierarchyCSV parses team,department[,division] rows with a header line (Pure Core)
+func parseHierarchyCSV(content string) ([]HierarchyEntry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	teamCol, hasTeam := columns["team"]
+	deptCol, hasDept := columns["department"]
+	if !hasTeam || !hasDept {
+		return nil, fmt.Errorf("CSV header must contain team and department columns")
+	}
+	divCol, hasDiv := columns["division"]
+
+	var entries []HierarchyEntry
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row: %w", err)
+		}
+
+		entry := HierarchyEntry{
+			Team:       csvField(row, teamCol),
+			Department: csvField(row, deptCol),
+		}
+		if hasDiv {
+			entry.Division = csvField(row, divCol)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// csvField returns a trimmed CSV field or an empty string when the row is short (Pure Core)
+func csvField(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// validateHierarchyEntries checks that every entry names a team and a department (Pure Core)
+func validateHierarchyEntries(entries []HierarchyEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("hierarchy must contain at least one entry")
+	}
+
+	for i, entry := range entries {
+		if entry.Team == "" || entry.Department == "" {
+			return fmt.Errorf("entry %d must have team and department", i+1)
+		}
+	}
+	return nil
+}
+
+// convertHierarchyEntriesToParams converts entries to Cypher parameters (Pure Core)
+func convertHierarchyEntriesToParams(entries []HierarchyEntry) []map[string]interface{} {
+	params := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		params = append(params, map[string]interface{}{
+			"team":       entry.Team,
+			"department": entry.Department,
+			"division":   entry.Division,
+		})
+	}
+	return params
+}
+
+// isValidRollupLevel reports whether a rollup level is supported (Pure Core)
+func isValidRollupLevel(level string) bool {
+	return level == RollupDepartment || level == RollupDivision
+}
+
+// buildImportHierarchyQuery builds a query replacing an organization's hierarchy (Pure Core)
+func buildImportHierarchyQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (old_dept:Department {organization: $orgName})
+		DETACH DELETE old_dept
+		WITH DISTINCT org
+		OPTIONAL MATCH (old_div:Division {organization: $orgName})
+		DETACH DELETE old_div
+		WITH DISTINCT org
+		UNWIND $entries AS entry
+		MERGE (dept:Department {key: $orgName + '/' + entry.department})
+		SET dept.name = entry.department, dept.organization = $orgName
+		FOREACH (_ IN CASE WHEN entry.division <> '' THEN [1] ELSE [] END |
+			MERGE (div:Division {key: $orgName + '/' + entry.division})
+			SET div.name = entry.division, div.organization = $orgName
+			MERGE (dept)-[:IN_DIVISION]->(div)
+		)
+		WITH org, dept, entry
+		OPTIONAL MATCH (team:Team {key: $orgName + '/' + entry.team}) WHERE EXISTS { (org)-[:HAS_TEAM]->(team) }
+		FOREACH (_ IN CASE WHEN team IS NULL THEN [] ELSE [1] END |
+			MERGE (team)-[:IN_DEPARTMENT]->(dept)
+		)
+		RETURN count(team) AS teams_matched,
+			[e IN collect(CASE WHEN team IS NULL THEN entry.team END) WHERE e IS NOT NULL] AS unmatched_teams
+	`
+}
+
+// buildRollupUnitsQuery builds a query returning ownership statistics per hierarchy unit (Pure Core)
+func buildRollupUnitsQuery(level string) string {
+	return cachedQuery(func() string {
+		teamPattern := "(team:Team)-[:IN_DEPARTMENT]->(unit)"
+		unitLabel := "Department"
+		if level == RollupDivision {
+			teamPattern = "(team:Team)-[:IN_DEPARTMENT]->(:Department)-[:IN_DIVISION]->(unit)"
+			unitLabel = "Division"
+		}
+
+		return fmt.Sprintf(`
+			MATCH (org:Organization {login: $orgName})
+			MATCH (unit:%s {organization: $orgName})
+			OPTIONAL MATCH %s
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[owner:HAS_TEAM_OWNER]->(team)
+			WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+				AND coalesce(owner.scan_id, '') = coalesce(org.active_scan_id, '')
+			RETURN unit.name AS name,
+				count(DISTINCT team) AS total_teams,
+				count(DISTINCT repo) AS total_repositories
+			ORDER BY name
+		`, quoteCypherIdentifier("label", unitLabel), teamPattern)
+	}, "rollup_units", level)
+}
+
+// buildRollupTotalsQuery builds a query counting the organization's repositories and those assigned to a unit (Pure Core)
+func buildRollupTotalsQuery(version Neo4jServerVersion) string {
+	assigned := buildExistsExpression(version,
+		"(r)-[owner:HAS_TEAM_OWNER]->(:Team)-[:IN_DEPARTMENT]->(:Department {organization: $orgName})",
+		"coalesce(owner.scan_id, '') = coalesce(org.active_scan_id, '')")
+
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, collect(DISTINCT repo) AS repos
+		RETURN size(repos) AS total_repositories,
+			size([r IN repos WHERE ` + assigned + `]) AS assigned_repositories
+	`
+}
+
+// calculateRollupCoverage formats a unit's share of the organization's repositories (Pure Core)
+func calculateRollupCoverage(unitRepos, totalRepos int) string {
+	if totalRepos == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.0f%%", 100.0*float64(unitRepos)/float64(totalRepos))
+}
+
+// convertToRollupUnits converts rollup records to unit statistics (Pure Core)
+func convertToRollupUnits(records []map[string]interface{}, totalRepos int) []RollupUnitStats {
+	units := make([]RollupUnitStats, 0, len(records))
+	for _, record := range records {
+		repos := getIntFromMap(record, "total_repositories")
+		units = append(units, RollupUnitStats{
+			Name:              getStringFromMap(record, "name"),
+			TotalTeams:        getIntFromMap(record, "total_teams"),
+			TotalRepositories: repos,
+			Coverage:          calculateRollupCoverage(repos, totalRepos),
+		})
+	}
+	return units
+}
+
+// importOrganizationHierarchy replaces the hierarchy of an organization
+func importOrganizationHierarchy(ctx *gofr.Context, deps *AppDependencies, orgName string, entries []HierarchyEntry) (HierarchyImportResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return HierarchyImportResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jWrite(ctx, session, buildImportHierarchyQuery(), map[string]interface{}{
+		"orgName": orgName,
+		"entries": convertHierarchyEntriesToParams(entries),
+	})
+	if err != nil {
+		return HierarchyImportResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	if len(result.Records) == 0 {
+		return HierarchyImportResponse{}, &gofrhttp.ErrorEntityNotFound{
+			Name:  "organization",
+			Value: orgName,
+		}
+	}
+
+	return HierarchyImportResponse{
+		Organization:   orgName,
+		EntriesTotal:   len(entries),
+		TeamsMatched:   getIntFromMap(result.Records[0], "teams_matched"),
+		UnmatchedTeams: getStringSliceFromMap(result.Records[0], "unmatched_teams"),
+	}, nil
+}
+
+// getOrganizationRollupStats retrieves statistics rolled up by department or division
+func getOrganizationRollupStats(ctx *gofr.Context, deps *AppDependencies, orgName, level string) (RollupStatsResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return RollupStatsResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	params := map[string]interface{}{"orgName": orgName}
+
+	totals, err := executeNeo4jReadQuery(ctx, session, buildRollupTotalsQuery(session.version), params)
+	if err != nil {
+		return RollupStatsResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(totals.Records) == 0 {
+		return RollupStatsResponse{}, &gofrhttp.ErrorEntityNotFound{
+			Name:  "organization",
+			Value: orgName,
+		}
+	}
+
+	units, err := executeNeo4jReadQuery(ctx, session, buildRollupUnitsQuery(level), params)
+	if err != nil {
+		return RollupStatsResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	totalRepos := getIntFromMap(totals.Records[0], "total_repositories")
+	assignedRepos := getIntFromMap(totals.Records[0], "assigned_repositories")
+
+	return RollupStatsResponse{
+		Organization:           orgName,
+		Rollup:                 level,
+		TotalRepositories:      totalRepos,
+		UnassignedRepositories: totalRepos - assignedRepos,
+		Units:                  convertToRollupUnits(units.Records, totalRepos),
+	}, nil
+}
+
+// handleImportHierarchy imports a team → department → division hierarchy for an organization
+func (h *AppHandler) handleImportHierarchy(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	var request HierarchyImportRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	entries := request.Entries
+	if request.CSV != "" {
+		parsed, err := parseHierarchyCSV(request.CSV)
+		if err != nil {
+			return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"csv"}}
+		}
+		entries = append(entries, parsed...)
+	}
+
+	if err := validateHierarchyEntries(entries); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"entries"}}
+	}
+
+	return importOrganizationHierarchy(ctx, h.deps, orgName, entries)
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Depth limits of the dependency traversal of the impact endpoint
+const (
+	defaultImpactDepth = 3
+	maxImpactDepth     = 10
+)
+
+// RepositoryEdge is a DEPENDS_ON edge of the active scan
+type RepositoryEdge struct {
+	Repository string
+	DependsOn  string
+}
+
+// ImpactedRepository is a repository depending, directly or transitively, on code a team owns
+type ImpactedRepository struct {
+	Repository string   `json:"repository"`
+	Depth      int      `json:"depth"`
+	Path       []string `json:"path"`
+	Teams      []string `json:"teams"`
+}
+
+// AffectedTeam is a team owning repositories impacted by another team's code
+type AffectedTeam struct {
+	Team         string `json:"team"`
+	Repositories int    `json:"repositories"`
+}
+
+// ImpactDepthCount is the number of impacted repositories at one dependency depth
+type ImpactDepthCount struct {
+	Depth        int `json:"depth"`
+	Repositories int `json:"repositories"`
+}
+
+// TeamImpactSummary counts a team's owned and downstream repositories
+type TeamImpactSummary struct {
+	OwnedRepositories      int                `json:"owned_repositories"`
+	DownstreamRepositories int                `json:"downstream_repositories"`
+	AffectedTeams          int                `json:"affected_teams"`
+	ByDepth                []ImpactDepthCount `json:"by_depth"`
+}
+
+// TeamImpactResponse lists the repositories affected by the code a team owns, through dependency edges
+type TeamImpactResponse struct {
+	Organization      string               `json:"organization"`
+	Team              string               `json:"team"`
+	Depth             int                  `json:"depth"`
+	Truncated         bool                 `json:"truncated"`
+	Summary           TeamImpactSummary    `json:"summary"`
+	OwnedRepositories []string             `json:"owned_repositories"`
+	Downstream        []ImpactedRepository `json:"downstream"`
+	AffectedTeams     []AffectedTeam       `json:"affected_teams"`
+}
+
+// buildTeamOwnedRepositoriesQuery builds a query returning whether a team is in the active scan and the
+// repositories its CODEOWNERS rules cover (Pure Core)
+func buildTeamOwnedRepositoriesQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[has_team:HAS_TEAM]->(team:Team {key: $orgName + '/' + $slug})
+		WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[rule:HAS_TEAM_OWNER]->(team)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH team, repo ORDER BY repo.full_name
+		RETURN team.slug AS team, collect(DISTINCT repo.full_name) AS repositories
+	`
+}
+
+// buildActiveDependencyEdgesQuery builds a query returning the DEPENDS_ON edges of the active scan (Pure Core)
+func buildActiveDependencyEdgesQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)-[dep:DEPENDS_ON]->(target:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND coalesce(dep.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN repo.full_name AS repository, target.full_name AS depends_on
+	`
+}
+
+// buildRepositoryTeamOwnersQuery builds a query returning the owning teams of repositories in the active scan (Pure Core)
+func buildRepositoryTeamOwnersQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)-[rule:HAS_TEAM_OWNER]->(team:Team)
+		WHERE repo.full_name IN $repositories
+			AND coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH repo, team ORDER BY team.slug
+		RETURN repo.full_name AS repository, collect(DISTINCT team.slug) AS teams
+	`
+}
+
+// buildDownstreamImpact walks dependency edges backwards from the owned repositories, breadth first up
+// to maxDepth, returning each downstream repository once at its shortest depth with the path reaching
+// it and whether dependents beyond maxDepth were left out (Pure Core)
+func buildDownstreamImpact(owned []string, edges []RepositoryEdge, maxDepth int) ([]ImpactedRepository, bool) {
+	dependents := make(map[string][]string)
+	for _, edge := range edges {
+		dependents[edge.DependsOn] = append(dependents[edge.DependsOn], edge.Repository)
+	}
+	for target := range dependents {
+		sort.Strings(dependents[target])
+	}
+
+	paths := make(map[string][]string, len(owned))
+	frontier := make([]string, 0, len(owned))
+	for _, repository := range owned {
+		if _, seen := paths[repository]; !seen {
+			paths[repository] = []string{repository}
+			frontier = append(frontier, repository)
+		}
+	}
+
+	impacted := []ImpactedRepository{}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []string
+		for _, repository := range frontier {
+			for _, dependent := range dependents[repository] {
+				if _, seen := paths[dependent]; seen {
+					continue
+				}
+				if depth > maxDepth {
+					return impacted, true
+				}
+				path := append(append([]string{}, paths[repository]...), dependent)
+				paths[dependent] = path
+				next = append(next, dependent)
+				impacted = append(impacted, ImpactedRepository{Repository: dependent, Depth: depth, Path: path, Teams: []string{}})
+			}
+		}
+		frontier = next
+	}
+	return impacted, false
+}
+
+// buildTeamImpactResponse attaches the owning teams of impacted repositories and counts the impact (Pure Core)
+func buildTeamImpactResponse(orgName, team string, depth int, owned []string, impacted []ImpactedRepository, truncated bool, teams map[string][]string) TeamImpactResponse {
+	byDepth := make(map[int]int)
+	teamRepositories := make(map[string]int)
+	for i := range impacted {
+		if owners, ok := teams[impacted[i].Repository]; ok {
+			impacted[i].Teams = owners
+		}
+		byDepth[impacted[i].Depth]++
+		for _, owner := range impacted[i].Teams {
+			if owner != team {
+				teamRepositories[owner]++
+			}
+		}
+	}
+
+	affected := make([]AffectedTeam, 0, len(teamRepositories))
+	for owner, count := range teamRepositories {
+		affected = append(affected, AffectedTeam{Team: owner, Repositories: count})
+	}
+	sort.Slice(affected, func(i, j int) bool {
+		if affected[i].Repositories != affected[j].Repositories {
+			return affected[i].Repositories > affected[j].Repositories
+		}
+		return affected[i].Team < affected[j].Team
+	})
+
+	counts := make([]ImpactDepthCount, 0, len(byDepth))
+	for level := 1; level <= depth; level++ {
+		if byDepth[level] > 0 {
+			counts = append(counts, ImpactDepthCount{Depth: level, Repositories: byDepth[level]})
+		}
+	}
+
+	return TeamImpactResponse{
+		Organization: orgName,
+		Team:         team,
+		Depth:        depth,
+		Truncated:    truncated,
+		Summary: TeamImpactSummary{
+			OwnedRepositories:      len(owned),
+			DownstreamRepositories: len(impacted),
+			AffectedTeams:          len(affected),
+			ByDepth:                counts,
+		},
+		OwnedRepositories: owned,
+		Downstream:        impacted,
+		AffectedTeams:     affected,
+	}
+}
+
+// getTeamImpact retrieves the repositories downstream of a team's code in the active scan (Orchestrator)
+func getTeamImpact(ctx *gofr.Context, deps *AppDependencies, orgName, slug string, depth int) (TeamImpactResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return TeamImpactResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildTeamOwnedRepositoriesQuery(), map[string]interface{}{
+		"orgName": orgName,
+		"slug":    slug,
+	})
+	if err != nil {
+		return TeamImpactResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return TeamImpactResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "team", Value: orgName + "/" + slug}
+	}
+	owned := getStringSliceFromMap(result.Records[0], "repositories")
+
+	result, err = executeNeo4jReadQuery(ctx, session, buildActiveDependencyEdgesQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return TeamImpactResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	edges := make([]RepositoryEdge, 0, len(result.Records))
+	for _, record := range result.Records {
+		edges = append(edges, RepositoryEdge{
+			Repository: getStringFromMap(record, "repository"),
+			DependsOn:  getStringFromMap(record, "depends_on"),
+		})
+	}
+
+	impacted, truncated := buildDownstreamImpact(owned, edges, depth)
+	repositories := make([]string, 0, len(impacted))
+	for _, repository := range impacted {
+		repositories = append(repositories, repository.Repository)
+	}
+
+	result, err = executeNeo4jReadQuery(ctx, session, buildRepositoryTeamOwnersQuery(), map[string]interface{}{
+		"orgName":      orgName,
+		"repositories": repositories,
+	})
+	if err != nil {
+		return TeamImpactResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	teams := make(map[string][]string, len(result.Records))
+	for _, record := range result.Records {
+		teams[getStringFromMap(record, "repository")] = getStringSliceFromMap(record, "teams")
+	}
+
+	return buildTeamImpactResponse(orgName, slug, depth, owned, impacted, truncated, teams), nil
+}
+
+// handleGetTeamImpact returns the downstream repositories affected by the code a team owns
+func (h *AppHandler) handleGetTeamImpact(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	slug := ctx.PathParam("slug")
+	if slug == "" {
+		return nil, createMissingParamError("slug")
+	}
+
+	depth := defaultImpactDepth
+	if value := ctx.Param("depth"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxImpactDepth {
+			return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"depth"}}
+		}
+		depth = parsed
+	}
+
+	return getTeamImpact(ctx, h.deps, orgName, slug, depth)
+}
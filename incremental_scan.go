@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Scan modes accepted by POST /api/scan/{org}
+const (
+	ScanModeFull        = "full"
+	ScanModeIncremental = "incremental"
+)
+
+// incrementalScanOverlap widens the incremental window so repositories updated while the
+// previous scan was fetching are not missed
+const incrementalScanOverlap = 15 * time.Minute
+
+// carriedOrganizationRelationships are the organization relationships copied unchanged into an incremental scan
+var carriedOrganizationRelationships = []string{"HAS_TEAM", "HAS_TOPIC"}
+
+// carriedRepositoryRelationships are the relationships of unchanged repositories copied into an incremental scan
+var carriedRepositoryRelationships = []string{"HAS_CODEOWNER", "HAS_TEAM_OWNER", "HAS_TOPIC", "HAS_CODEOWNERS_FILE", "DEPENDS_ON"}
+
+// IncrementalScanBase is the active scan an incremental scan merges its changes into
+type IncrementalScanBase struct {
+	PreviousScanID string
+	Since          time.Time
+	Changed        []string
+}
+
+// isValidScanMode reports whether a scan mode is supported (Pure Core)
+func isValidScanMode(mode string) bool {
+	return mode == "" || mode == ScanModeFull || mode == ScanModeIncremental
+}
+
+// buildIncrementalScanBaseQuery builds a query returning the active scan of an organization and when it started (Pure Core)
+func buildIncrementalScanBaseQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan)
+		WHERE scan.id = org.active_scan_id
+		RETURN scan.id AS scan_id, scan.started_at AS started_at
+	`
+}
+
+// buildUnchangedRepositoryMetricsQuery builds a query counting the repositories an incremental scan carries forward (Pure Core)
+func buildUnchangedRepositoryMetricsQuery(version Neo4jServerVersion) string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[owns:OWNS {scan_id: $previous_scan_id}]->(repo:Repository)
+		WHERE NOT repo.full_name IN $changed
+		RETURN count(DISTINCT repo) AS repository_count,
+			count(DISTINCT CASE
+				WHEN ` + buildExistsExpression(version, "(repo)-[o:HAS_CODEOWNER|HAS_TEAM_OWNER]->()", "o.scan_id = $previous_scan_id") + ` THEN repo
+			END) AS repos_with_codeowners
+	`
+}
+
+// buildCarryForwardOwnsQuery builds a query copying the OWNS relationships of unchanged repositories into a new scan (Pure Core)
+func buildCarryForwardOwnsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[owns:OWNS {scan_id: $previous_scan_id}]->(repo:Repository)
+		WHERE NOT repo.full_name IN $changed
+		CREATE (org)-[copy:OWNS]->(repo)
+		SET copy = properties(owns), copy.scan_id = $scan_id
+	`
+}
+
+// buildCarryForwardOrganizationRelationshipsQuery builds a query copying an organization relationship type into a new scan (Pure Core)
+func buildCarryForwardOrganizationRelationshipsQuery(relationship string) string {
+	return fmt.Sprintf(`
+		MATCH (org:Organization {login: $org_login})-[r:%[1]s {scan_id: $previous_scan_id}]->(target)
+		WHERE NOT EXISTS { MATCH (org)-[:%[1]s {scan_id: $scan_id}]->(target) }
+		CREATE (org)-[copy:%[1]s]->(target)
+		SET copy = properties(r), copy.scan_id = $scan_id
+	`, quoteCypherIdentifier("relationship", relationship))
+}
+
+// buildCarryForwardRepositoryRelationshipsQuery builds a query copying a relationship type of unchanged
+// repositories into a new scan (Pure Core)
+func buildCarryForwardRepositoryRelationshipsQuery(relationship string) string {
+	return fmt.Sprintf(`
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $previous_scan_id}]->(repo:Repository)
+		WHERE NOT repo.full_name IN $changed
+		MATCH (repo)-[r:%[1]s {scan_id: $previous_scan_id}]->(target)
+		CREATE (repo)-[copy:%[1]s]->(target)
+		SET copy = properties(r), copy.scan_id = $scan_id
+	`, quoteCypherIdentifier("relationship", relationship))
+}
+
+// isChangedSince reports whether a repository was updated or pushed to since a time (Pure Core)
+func isChangedSince(repo GitHubRepository, since time.Time) bool {
+	return !repo.UpdatedAt.Before(since) || !repo.PushedAt.Before(since)
+}
+
+// resolveIncrementalScanBase returns the active scan an incremental scan builds on, or nil when the
+// organization has never been scanned and a full scan is needed (Orchestrator)
+func resolveIncrementalScanBase(ctx *gofr.Context, conn *Neo4jConnection, orgName string) (*IncrementalScanBase, error) {
+	session, err := createNeo4jSession(ctx, conn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildIncrementalScanBaseQuery(), map[string]interface{}{
+		"org_login": orgName,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return nil, nil
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, getStringFromMap(result.Records[0], "started_at"))
+	if err != nil {
+		return nil, nil
+	}
+
+	return &IncrementalScanBase{
+		PreviousScanID: getStringFromMap(result.Records[0], "scan_id"),
+		Since:          startedAt.Add(-incrementalScanOverlap),
+	}, nil
+}
+
+// fetchGitHubRepositoriesChangedSince fetches the repositories updated or pushed to since a time. Both orders
+// are walked newest first and stop at the first repository older than the cutoff.
+func fetchGitHubRepositoriesChangedSince(ctx *gofr.Context, orgName string, since time.Time, maxRepos int) ([]GitHubRepository, error) {
+	if err := validateRepositoryParams(orgName, maxRepos); err != nil {
+		return nil, err
+	}
+
+	githubSvc := ctx.GetHTTPService("github")
+	changed := []GitHubRepository{}
+	seen := make(map[string]bool)
+	perPage := 100
+
+	for _, sort := range []string{"updated", "pushed"} {
+		for page := 1; len(changed) < maxRepos; page++ {
+			repos, shouldContinue, err := fetchRepositoryPage(ctx, githubSvc, orgName, page, perPage, sort)
+			if err != nil {
+				return nil, err
+			}
+
+			reachedCutoff := false
+			before := len(changed)
+			for _, repo := range repos {
+				timestamp := repo.UpdatedAt
+				if sort == "pushed" {
+					timestamp = repo.PushedAt
+				}
+				if timestamp.Before(since) {
+					reachedCutoff = true
+					break
+				}
+				if !seen[repo.FullName] && isChangedSince(repo, since) {
+					seen[repo.FullName] = true
+					changed = append(changed, repo)
+				}
+			}
+			if err := reserveScanItems(ctx, ScanBufferRepositories, len(changed)-before); err != nil {
+				return nil, err
+			}
+
+			if reachedCutoff || !shouldContinue {
+				break
+			}
+		}
+	}
+
+	if len(changed) >= maxRepos {
+		logWarn(ctx, "Incremental scan reached max_repos; remaining changes wait for the next full scan", LogFields{
+			"component":    "github_client",
+			"operation":    "fetch_changed_repositories",
+			"organization": orgName,
+			"since":        since.Format(time.RFC3339),
+			"max_repos":    maxRepos,
+		})
+	}
+
+	return limitRepositories(ctx, changed, maxRepos, orgName), nil
+}
+
+// fetchUnchangedRepositoryMetrics counts the repositories and CODEOWNERS coverage an incremental scan carries forward (Orchestrator)
+func fetchUnchangedRepositoryMetrics(ctx context.Context, session *Neo4jSession, orgLogin string, base IncrementalScanBase) (ScanMetrics, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildUnchangedRepositoryMetricsQuery(session.version), map[string]interface{}{
+		"org_login":        orgLogin,
+		"previous_scan_id": base.PreviousScanID,
+		"changed":          base.Changed,
+	})
+	if err != nil {
+		return ScanMetrics{}, fmt.Errorf("failed to count unchanged repositories: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return ScanMetrics{}, nil
+	}
+
+	return ScanMetrics{
+		RepositoryCount:     getIntFromMap(result.Records[0], "repository_count"),
+		ReposWithCodeowners: getIntFromMap(result.Records[0], "repos_with_codeowners"),
+	}, nil
+}
+
+// carryForwardScanData copies the teams, topics and unchanged repositories of the previous scan into a
+// staging scan, so only changed repositories are written from GitHub data (Orchestrator)
+func carryForwardScanData(ctx context.Context, session *Neo4jSession, orgLogin, scanID string, base IncrementalScanBase) error {
+	params := map[string]interface{}{
+		"org_login":        orgLogin,
+		"scan_id":          scanID,
+		"previous_scan_id": base.PreviousScanID,
+		"changed":          base.Changed,
+	}
+
+	queries := []string{buildCarryForwardOwnsQuery()}
+	for _, relationship := range carriedRepositoryRelationships {
+		queries = append(queries, buildCarryForwardRepositoryRelationshipsQuery(relationship))
+	}
+	for _, relationship := range carriedOrganizationRelationships {
+		queries = append(queries, buildCarryForwardOrganizationRelationshipsQuery(relationship))
+	}
+
+	for _, query := range queries {
+		if _, err := executeNeo4jWrite(ctx, session, query, params); err != nil {
+			return fmt.Errorf("failed to carry forward previous scan data: %w", err)
+		}
+	}
+
+	logInfo(session.ctx, "Carried forward unchanged scan data", LogFields{
+		"component":        "incremental_scan",
+		"operation":        "carry_forward",
+		"organization":     orgLogin,
+		"scan_id":          scanID,
+		"previous_scan_id": base.PreviousScanID,
+		"changed_repos":    len(base.Changed),
+	})
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// ScanPanicError is returned when a scan job panics; the server keeps running
+type ScanPanicError struct {
+	Organization string
+	ScanID       string
+	Value        interface{}
+	StackTrace   string
+}
+
+// Error implements the error interface for ScanPanicError
+func (e ScanPanicError) Error() string {
+	return fmt.Sprintf("scan of organization %s failed unexpectedly: %v", e.Organization, e.Value)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ScanPanicError) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// buildRecordFailedScanQuery builds a query recording a failed scan and its stack trace in the scan ledger (Pure Core)
+func buildRecordFailedScanQuery() string {
+	return `
+		MERGE (scan:Scan {id: $scan_id})
+		ON CREATE SET scan.organization = $org_login,
+			scan.started_at = $started_at
+		SET scan.status = $status,
+			scan.failed_at = $failed_at,
+			scan.phase = $phase,
+			scan.attempt = $attempt,
+			scan.error = $error,
+			scan.stack_trace = $stack_trace
+		WITH scan
+		OPTIONAL MATCH (org:Organization {login: $org_login})
+		FOREACH (o IN CASE WHEN org IS NULL THEN [] ELSE [org] END |
+			MERGE (o)-[:HAS_SCAN]->(scan)
+		)
+		RETURN scan.id AS scan_id
+	`
+}
+
+// recordFailedScan discards a failed scan's staging data and records the failure in the scan ledger (Orchestrator)
+func recordFailedScan(ctx *gofr.Context, deps *AppDependencies, progress ScanProgress, failure ScanPanicError) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err == nil {
+		defer closeNeo4jSession(ctx, session)
+
+		if progress.ScanID != "" {
+			discardStagingScan(ctx, session, progress.Request.Organization, progress.ScanID, failure)
+		}
+
+		_, err = executeNeo4jWrite(ctx, session, buildRecordFailedScanQuery(), map[string]interface{}{
+			"scan_id":     failure.ScanID,
+			"status":      ScanStatusFailed,
+			"org_login":   progress.Request.Organization,
+			"started_at":  progress.StartedAt.UTC().Format(time.RFC3339),
+			"failed_at":   time.Now().UTC().Format(time.RFC3339),
+			"phase":       progress.Phase,
+			"attempt":     progress.Attempt,
+			"error":       failure.Error(),
+			"stack_trace": failure.StackTrace,
+		})
+	}
+
+	if err != nil {
+		logError(ctx, "Failed to record failed scan", LogFields{
+			"component":    "job_recovery",
+			"operation":    "record_failed_scan",
+			"organization": progress.Request.Organization,
+			"scan_id":      failure.ScanID,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// recoverScanPanic converts a panic in a scan job into a failed scan, leaving the server running.
+// It must be deferred directly by the function running the scan.
+func recoverScanPanic(ctx *gofr.Context, deps *AppDependencies, scan *RunningScan, errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	progress := scan.snapshot()
+	failure := ScanPanicError{
+		Organization: progress.Request.Organization,
+		ScanID:       progress.ScanID,
+		Value:        r,
+		StackTrace:   captureStackTrace(3),
+	}
+	if failure.ScanID == "" {
+		failure.ScanID = generateScanID(progress.Request.Organization, progress.StartedAt)
+	}
+
+	logErrorWithStackTrace(ctx, ErrorContext{
+		Error:       failure,
+		Operation:   "scan_job",
+		Component:   "job_recovery",
+		StackTrace:  failure.StackTrace,
+		Severity:    "critical",
+		Recoverable: true,
+		UserImpact:  "scan_failed",
+		Context: map[string]interface{}{
+			"organization": progress.Request.Organization,
+			"scan_id":      failure.ScanID,
+			"phase":        progress.Phase,
+			"attempt":      progress.Attempt,
+		},
+	})
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("scan_job_panics_total", 1, MetricLabels{
+		"organization": progress.Request.Organization,
+		"phase":        progress.Phase,
+	})
+
+	recordFailedScan(ctx, deps, progress, failure)
+	*errp = failure
+}
+
+// runCronJobWithRecovery runs a background job, containing any panic to the job's current run
+func runCronJobWithRecovery(ctx *gofr.Context, job string, fn func()) {
+	_ = withErrorRecovery(ctx, job, func() error {
+		fn()
+		return nil
+	})
+}
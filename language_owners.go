@@ -0,0 +1,165 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// unknownLanguage groups repositories without a primary language
+const unknownLanguage = "unknown"
+
+// LanguageOwner is an owner of repositories written in a language
+type LanguageOwner struct {
+	Owner        string  `json:"owner"`
+	Kind         string  `json:"kind"`
+	Repositories int     `json:"repositories"`
+	SharePercent float64 `json:"share_percent"`
+}
+
+// LanguageOwnership aggregates the owners and CODEOWNERS coverage of a primary language
+type LanguageOwnership struct {
+	Language            string          `json:"language"`
+	Repositories        int             `json:"repositories"`
+	OwnedRepositories   int             `json:"owned_repositories"`
+	CoveragePercent     float64         `json:"coverage_percent"`
+	Owners              []LanguageOwner `json:"owners"`
+	UnownedRepositories []string        `json:"unowned_repositories"`
+}
+
+// LanguageOwnershipResponse lists ownership per primary language
+type LanguageOwnershipResponse struct {
+	Organization string              `json:"organization"`
+	Languages    []LanguageOwnership `json:"languages"`
+}
+
+// buildRepositoryLanguageOwnersQuery builds a query returning the primary language and owners of every active repository,
+// leaving out bot users when $excludeBots is set (Pure Core)
+func buildRepositoryLanguageOwnersQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(rule_owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, ` + canonicalUserExpression("rule_owner") + ` AS owner
+		WITH org, repo, collect(DISTINCT CASE
+			WHEN owner IS NULL OR NOT ` + countedOwnerCondition("owner") + ` THEN NULL
+			WHEN owner:Team THEN '@' + org.login + '/' + owner.slug
+			ELSE '@' + owner.login
+		END) AS owners
+		RETURN repo.full_name AS repository, coalesce(repo.language, '') AS language, owners
+		ORDER BY repository
+	`
+}
+
+// normalizeLanguage maps a missing primary language to the unknown group (Pure Core)
+func normalizeLanguage(language string) string {
+	if language == "" {
+		return unknownLanguage
+	}
+	return language
+}
+
+// calculatePercent returns part as a percentage of total (Pure Core)
+func calculatePercent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// buildLanguageOwnership aggregates repository owners per primary language, optionally keeping
+// only one language matched case-insensitively (Pure Core)
+func buildLanguageOwnership(orgName, languageFilter string, records []map[string]interface{}) LanguageOwnershipResponse {
+	byLanguage := make(map[string]*LanguageOwnership)
+	ownerCounts := make(map[string]map[string]int)
+
+	for _, record := range records {
+		language := normalizeLanguage(getStringFromMap(record, "language"))
+		if languageFilter != "" && !strings.EqualFold(language, languageFilter) {
+			continue
+		}
+
+		entry, exists := byLanguage[language]
+		if !exists {
+			entry = &LanguageOwnership{Language: language, Owners: []LanguageOwner{}, UnownedRepositories: []string{}}
+			byLanguage[language] = entry
+			ownerCounts[language] = make(map[string]int)
+		}
+
+		entry.Repositories++
+		owners := getStringSliceFromMap(record, "owners")
+		if len(owners) == 0 {
+			entry.UnownedRepositories = append(entry.UnownedRepositories, getStringFromMap(record, "repository"))
+			continue
+		}
+
+		entry.OwnedRepositories++
+		for _, owner := range owners {
+			ownerCounts[language][owner]++
+		}
+	}
+
+	response := LanguageOwnershipResponse{Organization: orgName, Languages: make([]LanguageOwnership, 0, len(byLanguage))}
+	for language, entry := range byLanguage {
+		entry.CoveragePercent = calculatePercent(entry.OwnedRepositories, entry.Repositories)
+		for owner, repositories := range ownerCounts[language] {
+			kind := "user"
+			if isTeamOwner(owner) {
+				kind = "team"
+			}
+			entry.Owners = append(entry.Owners, LanguageOwner{
+				Owner:        owner,
+				Kind:         kind,
+				Repositories: repositories,
+				SharePercent: calculatePercent(repositories, entry.Repositories),
+			})
+		}
+		sort.Slice(entry.Owners, func(i, j int) bool {
+			if entry.Owners[i].Repositories != entry.Owners[j].Repositories {
+				return entry.Owners[i].Repositories > entry.Owners[j].Repositories
+			}
+			return entry.Owners[i].Owner < entry.Owners[j].Owner
+		})
+		response.Languages = append(response.Languages, *entry)
+	}
+
+	sort.Slice(response.Languages, func(i, j int) bool {
+		if response.Languages[i].Repositories != response.Languages[j].Repositories {
+			return response.Languages[i].Repositories > response.Languages[j].Repositories
+		}
+		return response.Languages[i].Language < response.Languages[j].Language
+	})
+
+	return response
+}
+
+// fetchLanguageOwnership retrieves the ownership of an organization's repositories per primary language (Orchestrator)
+func fetchLanguageOwnership(ctx *gofr.Context, deps *AppDependencies, orgName, languageFilter string) (LanguageOwnershipResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return LanguageOwnershipResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildRepositoryLanguageOwnersQuery(), map[string]interface{}{
+		"orgName":     orgName,
+		"excludeBots": deps.currentConfig().Bots.Exclude,
+	})
+	if err != nil {
+		return LanguageOwnershipResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	return buildLanguageOwnership(orgName, languageFilter, result.Records), nil
+}
+
+// handleGetLanguageOwnership returns owners and coverage per primary repository language
+func (h *AppHandler) handleGetLanguageOwnership(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return fetchLanguageOwnership(ctx, h.deps, orgName, strings.TrimSpace(ctx.Param("language")))
+}
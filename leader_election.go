@@ -0,0 +1,229 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// leaderLeaseKey is the state store key of the lease held by the leader replica
+const leaderLeaseKey = "leader/lease"
+
+// leaderElectionSchedule is how often every replica renews or contends for the lease; the lease
+// TTL must outlast it
+const leaderElectionSchedule = "* * * * *"
+
+// scanHeartbeatKeyPrefix is the root of the heartbeats replicas publish for the scans they run, so
+// the leader does not take another replica's long-running staging scan for an orphan
+const scanHeartbeatKeyPrefix = "scan-heartbeats/"
+
+// scanHeartbeatTTL is how long a scan heartbeat lasts; the watchdog refreshes it every minute
+const scanHeartbeatTTL = 3 * time.Minute
+
+// LeaderStatus is this replica's view of the leader lease
+type LeaderStatus struct {
+	Identity       string `json:"identity"`
+	Leader         bool   `json:"leader"`
+	Holder         string `json:"holder,omitempty"`
+	LeaderSince    string `json:"leader_since,omitempty"`
+	LeaseExpiresAt string `json:"lease_expires_at,omitempty"`
+	LeaseTTL       string `json:"lease_ttl"`
+}
+
+// LeaderElector elects one replica to run cluster-wide background work through a lease in the state
+// store. The holder renews the lease every minute; when it stops, the lease expires and the next
+// replica to contend takes over. With the memory state store every replica leads itself
+type LeaderElector struct {
+	state    StateStore
+	identity string
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	holder    string
+	since     time.Time
+	renewedAt time.Time
+}
+
+// newLeaderElector creates the elector of this replica
+func newLeaderElector(config LeaderElectionConfig, state StateStore) *LeaderElector {
+	identity := config.Identity
+	if identity == "" {
+		identity = generateReplicaIdentity()
+	}
+	return &LeaderElector{state: state, identity: identity, ttl: config.LeaseTTL}
+}
+
+// generateReplicaIdentity returns the hostname, which is the pod name under Kubernetes, with a
+// random suffix so restarted replicas do not inherit a stale lease
+func generateReplicaIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "replica"
+	}
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return hostname
+	}
+	return hostname + "-" + hex.EncodeToString(buf)
+}
+
+// isLeaseHeld reports whether a lease renewed at renewedAt is still valid at now (Pure Core)
+func isLeaseHeld(renewedAt time.Time, ttl time.Duration, now time.Time) bool {
+	return !renewedAt.IsZero() && now.Before(renewedAt.Add(ttl))
+}
+
+// campaign renews the lease when this replica holds it and takes it when it is free. Renewal reads
+// the holder before extending the lease; renewing every minute within a longer TTL keeps the lease
+// from expiring in between. Store errors keep the last known state until the lease would expire
+func (e *LeaderElector) campaign(ctx *gofr.Context, now time.Time) bool {
+	holder, held, err := e.state.Get(ctx, leaderLeaseKey)
+	switch {
+	case err != nil:
+	case held && string(holder) == e.identity:
+		err = e.state.Put(ctx, leaderLeaseKey, []byte(e.identity), e.ttl)
+	case !held:
+		var acquired bool
+		if acquired, err = e.state.PutIfAbsent(ctx, leaderLeaseKey, []byte(e.identity), e.ttl); err == nil && acquired {
+			holder, held = []byte(e.identity), true
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	wasLeader := isLeaseHeld(e.renewedAt, e.ttl, now)
+	if err != nil {
+		logWarn(ctx, "Leader lease unavailable", LogFields{
+			"component": "leader_election",
+			"operation": "campaign",
+			"identity":  e.identity,
+			"leader":    wasLeader,
+			"error":     err.Error(),
+		})
+		return wasLeader
+	}
+
+	e.holder = ""
+	if held {
+		e.holder = string(holder)
+	}
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	if e.holder != e.identity {
+		metrics.recordGauge("leader_lease_held", 0, MetricLabels{})
+		e.renewedAt = time.Time{}
+		if wasLeader {
+			logWarn(ctx, "Lost leadership", LogFields{
+				"component": "leader_election",
+				"operation": "campaign",
+				"identity":  e.identity,
+				"holder":    e.holder,
+			})
+		}
+		return false
+	}
+
+	e.renewedAt = now
+	if !wasLeader {
+		e.since = now
+		logInfo(ctx, "Became leader", LogFields{
+			"component": "leader_election",
+			"operation": "campaign",
+			"identity":  e.identity,
+			"lease_ttl": e.ttl.String(),
+		})
+	}
+	metrics.recordGauge("leader_lease_held", 1, MetricLabels{})
+	return true
+}
+
+// isLeader reports whether this replica held the lease at its last renewal and it has not expired since
+func (e *LeaderElector) isLeader(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return isLeaseHeld(e.renewedAt, e.ttl, now)
+}
+
+// status returns this replica's view of the lease
+func (e *LeaderElector) status(now time.Time) LeaderStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := LeaderStatus{
+		Identity: e.identity,
+		Leader:   isLeaseHeld(e.renewedAt, e.ttl, now),
+		Holder:   e.holder,
+		LeaseTTL: e.ttl.String(),
+	}
+	if status.Leader {
+		status.LeaderSince = e.since.UTC().Format(time.RFC3339)
+		status.LeaseExpiresAt = e.renewedAt.Add(e.ttl).UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// runAsLeader runs a cluster-wide cron job only on the leader replica, and not at all while
+// maintenance mode is active: the middleware only holds back HTTP writes, and these jobs scan,
+// archive and reconcile on their own. The flag is read from the state store, as maintenance may
+// have been turned on through another replica
+func runAsLeader(ctx *gofr.Context, deps *AppDependencies, job string, fn func()) {
+	if !deps.Leader.isLeader(time.Now()) {
+		logDebug(ctx, "Skipping cron job on follower replica", LogFields{
+			"component": "leader_election",
+			"operation": "run_as_leader",
+			"job":       job,
+		})
+		return
+	}
+	if deps.Maintenance.isEnabledNow(ctx) {
+		logInfo(ctx, "Skipping cron job during maintenance mode", LogFields{
+			"component": "leader_election",
+			"operation": "run_as_leader",
+			"job":       job,
+		})
+		return
+	}
+	fn()
+}
+
+// publishScanHeartbeats records that this replica is still running its tracked scans
+func publishScanHeartbeats(ctx *gofr.Context, deps *AppDependencies) {
+	for scanID := range deps.Scans.trackedScanIDs() {
+		if err := deps.State.Put(ctx, scanHeartbeatKeyPrefix+scanID, []byte(deps.Leader.identity), scanHeartbeatTTL); err != nil {
+			logWarn(ctx, "Failed to publish scan heartbeat", LogFields{
+				"component": "leader_election",
+				"operation": "publish_scan_heartbeat",
+				"scan_id":   scanID,
+				"error":     err.Error(),
+			})
+		}
+	}
+}
+
+// hasScanHeartbeat reports whether any replica is still running a scan; lookup failures count as a
+// heartbeat, so an unreadable store never discards a live scan
+func hasScanHeartbeat(ctx *gofr.Context, deps *AppDependencies, scanID string) bool {
+	_, ok, err := deps.State.Get(ctx, scanHeartbeatKeyPrefix+scanID)
+	return ok || err != nil
+}
+
+// registerLeaderElection contends for the lease at startup and every minute after
+func registerLeaderElection(app *gofr.App, deps *AppDependencies) {
+	app.OnStart(func(ctx *gofr.Context) error {
+		deps.Leader.campaign(ctx, time.Now())
+		return nil
+	})
+	app.AddCronJob(leaderElectionSchedule, "leader-election", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "leader_election", func() {
+			deps.Leader.campaign(ctx, time.Now())
+		})
+	})
+}
+
+// handleGetLeader returns this replica's identity and view of the leader lease
+func (h *AppHandler) handleGetLeader(_ *gofr.Context) (interface{}, error) {
+	return h.deps.Leader.status(time.Now()), nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// logLevelPath is the admin route that stays writable while maintenance mode is active
+const logLevelPath = "/api/admin/loglevel"
+
+// logLevelSeverity orders the supported log levels from most to least verbose
+var logLevelSeverity = map[string]int{
+	"debug":  1,
+	"info":   2,
+	"notice": 3,
+	"warn":   4,
+	"error":  5,
+}
+
+// LogLevelRegistry holds the default log level and per-component overrides.
+// The underlying GoFr logger is lowered to the most verbose level in use, and
+// logWithContext filters every other component back to the default level.
+type LogLevelRegistry struct {
+	mu           sync.RWMutex
+	defaultLevel string
+	components   map[string]string
+	logger       logging.Logger
+}
+
+// LogLevelRequest represents a request to change a component's log level; an empty
+// component changes the default level and an empty or "default" level clears an override
+type LogLevelRequest struct {
+	Component string `json:"component"`
+	Level     string `json:"level"`
+}
+
+// LogLevelStatus represents the current log levels
+type LogLevelStatus struct {
+	DefaultLevel string            `json:"default_level"`
+	Components   map[string]string `json:"components"`
+}
+
+// componentLogLevels is the process-wide log level registry consulted by logWithContext
+var componentLogLevels = &LogLevelRegistry{defaultLevel: "info", components: make(map[string]string)}
+
+// normalizeLogLevel lowercases a level and maps aliases to supported names (Pure Core)
+func normalizeLogLevel(level string) string {
+	normalized := strings.ToLower(strings.TrimSpace(level))
+	if normalized == "warning" {
+		return "warn"
+	}
+	return normalized
+}
+
+// isValidLogLevel reports whether a level is supported (Pure Core)
+func isValidLogLevel(level string) bool {
+	_, exists := logLevelSeverity[normalizeLogLevel(level)]
+	return exists
+}
+
+// initLogLevels sets the default level from LOG_LEVEL and attaches the GoFr logger
+func initLogLevels(logger logging.Logger, level string) {
+	componentLogLevels.mu.Lock()
+	defer componentLogLevels.mu.Unlock()
+
+	if isValidLogLevel(level) {
+		componentLogLevels.defaultLevel = normalizeLogLevel(level)
+	}
+	componentLogLevels.logger = logger
+}
+
+// allows reports whether a message of the given level is emitted for a component
+func (r *LogLevelRegistry) allows(component, level string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	threshold, exists := r.components[component]
+	if !exists {
+		threshold = r.defaultLevel
+	}
+
+	severity, known := logLevelSeverity[normalizeLogLevel(level)]
+	if !known {
+		return true
+	}
+	return severity >= logLevelSeverity[threshold]
+}
+
+// set changes the default level or a component override and adjusts the GoFr logger
+func (r *LogLevelRegistry) set(component, level string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	level = normalizeLogLevel(level)
+	switch {
+	case component == "":
+		r.defaultLevel = level
+	case level == "" || level == "default":
+		delete(r.components, component)
+	default:
+		r.components[component] = level
+	}
+
+	if r.logger != nil {
+		r.logger.ChangeLevel(logging.GetLevelFromString(strings.ToUpper(r.mostVerboseLevel())))
+	}
+}
+
+// mostVerboseLevel returns the most verbose level across the default and overrides
+func (r *LogLevelRegistry) mostVerboseLevel() string {
+	verbose := r.defaultLevel
+	for _, level := range r.components {
+		if logLevelSeverity[level] < logLevelSeverity[verbose] {
+			verbose = level
+		}
+	}
+	return verbose
+}
+
+// status returns a snapshot of the configured levels
+func (r *LogLevelRegistry) status() LogLevelStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	components := make(map[string]string, len(r.components))
+	for component, level := range r.components {
+		components[component] = level
+	}
+
+	return LogLevelStatus{DefaultLevel: r.defaultLevel, Components: components}
+}
+
+// handleGetLogLevels returns the default log level and per-component overrides
+func (*AppHandler) handleGetLogLevels(_ *gofr.Context) (interface{}, error) {
+	return componentLogLevels.status(), nil
+}
+
+// handleSetLogLevel changes the log level of a component without a restart
+func (*AppHandler) handleSetLogLevel(ctx *gofr.Context) (interface{}, error) {
+	var request LogLevelRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	request.Component = strings.TrimSpace(request.Component)
+	clearing := request.Level == "" || normalizeLogLevel(request.Level) == "default"
+	if request.Component == "" && clearing {
+		return nil, &gofrhttp.ErrorMissingParam{Params: []string{"level"}}
+	}
+	if !clearing && !isValidLogLevel(request.Level) {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"level"}}
+	}
+
+	componentLogLevels.set(request.Component, request.Level)
+
+	logWarn(ctx, "Log level changed", LogFields{
+		"component":        "admin",
+		"operation":        "set_log_level",
+		"target_component": request.Component,
+		"level":            normalizeLogLevel(request.Level),
+	})
+
+	return componentLogLevels.status(), nil
+}
@@ -22,12 +22,43 @@ func main() {
 		app.Logger().Fatalf("Failed to create app dependencies: %v", err)
 	}
 
+	initLogLevels(app.Logger(), getEnvOrDefault("LOG_LEVEL", "INFO"))
+	registerAppMetrics(app.Metrics())
 	logApplicationStartup(app, deps)
+	logWebhookDeadLetterStore(app, deps.Config)
 	registerGitHubService(app, deps.Config.GitHub)
+	registerGitLabService(app, deps.Config.GitLab)
+	registerBitbucketService(app, deps.Config.Bitbucket)
 
 	handler := NewAppHandler(deps)
+	registerAppContext(app, deps)
 	setupGracefulShutdown(app, ctx, deps)
+	app.UseMiddleware(addressRateLimitMiddleware(deps, app.Metrics()))
+	app.UseMiddleware(authMiddleware(deps))
+	app.UseMiddleware(authorizationMiddleware(deps))
+	app.UseMiddleware(rateLimitMiddleware(deps, app.Metrics()))
+	app.UseMiddleware(maintenanceModeMiddleware(deps))
+	app.UseMiddleware(sloMiddleware(deps))
+	app.UseMiddleware(tenantContextMiddleware())
+	app.UseMiddleware(callerScopeMiddleware(deps))
+	app.UseMiddleware(graphStreamMiddleware(deps))
+	app.UseMiddleware(teamExportMiddleware(deps))
+	app.UseMiddleware(scanDedupMiddleware(deps))
+	app.UseMiddleware(webhookMiddleware(deps))
 	registerAPIRoutes(app, handler)
+	registerAdminRoutes(app, handler)
+	registerFreshnessCheck(app, deps)
+	registerScanWatchdog(app, deps)
+	registerReconciliation(app, deps)
+	registerArchival(app, deps)
+	registerSLOExport(app, deps)
+	registerCoverageTargetDigest(app, deps)
+	registerAuditLogIngestion(app, deps)
+	registerScanScheduler(app, deps)
+	registerGitHubCooldownRestore(app)
+	registerCacheWarmup(app, deps)
+	registerConfigReloadSignal(app, deps)
+	registerLeaderElection(app, deps)
 	logServerReady(app, deps)
 
 	app.Run()
@@ -40,11 +71,19 @@ func shouldHandleCommand() bool {
 		case "--cleanup", "cleanup":
 			emergencyCleanup()
 			return true
+		case "--validate-config", "validate-config":
+			os.Exit(runValidateConfigCommand(os.Args[2:]))
+		case "scan":
+			os.Exit(runScanCommand(os.Args[2:]))
+		case "demo":
+			os.Exit(runDemoCommand(os.Args[2:]))
+		case "backfill":
+			os.Exit(runBackfillCommand(os.Args[2:]))
 		case "api":
 			return false
 		default:
 			fmt.Printf("Unknown command: %s\n", os.Args[1])
-			fmt.Println("Available commands: api, --cleanup, cleanup")
+			fmt.Println("Available commands: api, scan, demo, backfill, --validate-config, --cleanup, cleanup")
 			return true
 		}
 	}
@@ -62,15 +101,33 @@ func logApplicationStartup(app *gofr.App, deps *AppDependencies) {
 	)
 }
 
+// logWebhookDeadLetterStore reports a state store that cannot keep failed webhook deliveries through
+// a restart or a Neo4j outage
+func logWebhookDeadLetterStore(app *gofr.App, config AppConfig) {
+	if problem := deadLetterStoreProblem(config); problem != "" {
+		app.Logger().Errorf("Failed webhook deliveries are not kept durably: %s; set STATE_STORE=redis or postgres - component=webhooks operation=check_dead_letter_store state_store=%s", problem, config.StateStore.Backend)
+	}
+}
+
 // registerGitHubService registers GitHub as an HTTP service
 func registerGitHubService(app *gofr.App, config GitHubConfig) {
 	RegisterGitHubService(app, GitHubServiceConfig{
-		Token:        config.Token,
-		BaseURL:      config.BaseURL,
-		UserAgent:    config.UserAgent,
-		Timeout:      config.Timeout,
-		MaxRetries:   config.MaxRetries,
-		RateLimitMin: config.RateLimitMin,
+		Token:             config.Token,
+		BaseURL:           config.BaseURL,
+		UserAgent:         config.UserAgent,
+		Timeout:           config.Timeout,
+		MaxRetries:        config.MaxRetries,
+		ETagCacheBytes:    config.ETagCacheBytes,
+		RateLimitMin:      config.RateLimitMin,
+		ReservePercent:    config.ReservePercent,
+		ReserveMaxWait:    config.ReserveMaxWait,
+		DegradePercent:    config.DegradePercent,
+		UseGraphQL:        config.UseGraphQL,
+		AppID:             config.AppID,
+		AppInstallationID: config.AppInstallationID,
+		AppPrivateKey:     config.AppPrivateKey,
+		AppPrivateKeyPath: config.AppPrivateKeyPath,
+		Transport:         config.Transport,
 	})
 }
 
@@ -93,17 +150,81 @@ func setupGracefulShutdown(app *gofr.App, ctx context.Context, deps *AppDependen
 
 // registerAPIRoutes registers all API routes
 func registerAPIRoutes(app *gofr.App, handler *AppHandler) {
+	app.POST("/api/scan", handler.handleScanOrganizations)
 	app.POST("/api/scan/{org}", handler.handleScanOrganization)
+	app.GET("/api/orgs", handler.handleListOrganizations)
+	app.GET("/api/scan/jobs/{id}", handler.handleGetScanJob)
+	app.GET("/api/schedules", handler.handleGetSchedule)
+	app.PUT("/api/schedules", handler.handleSetSchedule)
+	app.GET(graphMetaRoute, handler.handleGetGraphMeta)
 	app.GET("/api/graph/{org}", handler.handleGetGraph)
+	app.GET("/api/graph/{org}/export", handler.handleExportGraph)
+	app.POST("/api/publish/{org}", handler.handlePublishGraph)
+	app.GET("/api/graph/{org}/changes/wait", handler.handleWaitForGraphChanges)
 	app.GET("/api/stats/{org}", handler.handleGetStats)
+	app.PUT("/api/stats/{org}/coverage-target", handler.handleSetCoverageTarget)
+	app.DELETE("/api/stats/{org}/coverage-target", handler.handleClearCoverageTarget)
+	app.GET("/api/coverage/{org}", handler.handleGetCoverageReport)
+	app.GET("/api/reports/{org}/ownership.csv", handler.handleGetOwnershipReportCSV)
+	app.GET("/api/reports/{org}/ownership.xlsx", handler.handleGetOwnershipReportXLSX)
+	app.GET("/api/manifest/{org}", handler.handleGetOwnershipManifest)
+	app.GET("/api/repositories/{org}/{repo}/dependencies", handler.handleGetRepositoryDependencies)
+	app.GET("/api/export/{org}/{repo}/codeowners", handler.handleExportCodeowners)
+	app.POST("/api/owners/resolve", handler.handleResolveOwners)
+	app.GET("/api/owners/{org}/{repo}/pull/{number}", handler.handleGetPullRequestOwners)
+	app.POST("/api/validate/codeowners", handler.handleValidateCodeowners)
+	app.GET("/api/codeowners/{org}/{repo}/errors", handler.handleGetCodeownersErrors)
+	app.GET("/api/teams/{org}/export", handler.handleExportTeamMemberships)
+	app.GET("/api/insights/{org}/review-load", handler.handleGetReviewLoad)
+	app.GET("/api/insights/{org}/shared-codeowners", handler.handleGetSharedCodeowners)
+	app.GET("/api/insights/{org}/by-language", handler.handleGetLanguageOwnership)
+	app.GET("/api/analysis/{org}/orphans", handler.handleGetOrphanAnalysis)
+	app.GET("/api/impact/{org}/team/{slug}", handler.handleGetTeamImpact)
+	app.GET("/api/history/{org}", handler.handleGetOwnershipHistory)
+	app.GET("/api/history/{org}/{repo}", handler.handleGetRepositoryOwnershipTimeline)
+	app.GET("/api/diff/{org}", handler.handleGetScanDiff)
+	app.PUT("/api/hierarchy/{org}", handler.handleImportHierarchy)
+	app.PUT("/api/entities/{type}/{key}", handler.handleUpsertEntity)
+	app.GET("/api/repositories/{org}", handler.handleListRepositories)
+	app.GET("/api/teams/{org}", handler.handleListTeams)
+	app.GET("/api/users/{org}", handler.handleListUsers)
+	app.POST(githubWebhookPath, handler.handleGitHubWebhook)
 	app.GET("/api/health", handler.handleHealth)
 	app.GET("/api/docs", handler.handleOpenAPI)
 	app.GET("/api/openapi.yaml", handler.handleOpenAPISpec)
 }
 
+// registerAdminRoutes registers operational admin routes
+func registerAdminRoutes(app *gofr.App, handler *AppHandler) {
+	app.GET("/api/admin/maintenance", handler.handleGetMaintenance)
+	app.PUT("/api/admin/maintenance", handler.handleSetMaintenance)
+	app.GET("/api/admin/scans/pending", handler.handleListPendingScans)
+	app.POST("/api/admin/scans/{scanId}/approve", handler.handleApproveScan)
+	app.POST("/api/admin/scans/{scanId}/reject", handler.handleRejectScan)
+	app.GET("/api/admin/usage", handler.handleGetUsage)
+	app.GET("/api/admin/support-bundle", handler.handleSupportBundle)
+	app.GET(logLevelPath, handler.handleGetLogLevels)
+	app.PUT(logLevelPath, handler.handleSetLogLevel)
+	app.POST(configReloadPath, handler.handleReloadConfig)
+	app.GET("/api/admin/archives", handler.handleListArchives)
+	app.GET("/api/admin/slo", handler.handleGetSLO)
+	app.GET("/api/admin/conversion-failures", handler.handleGetConversionFailures)
+	app.GET("/api/admin/leader", handler.handleGetLeader)
+	app.POST("/api/admin/transfer", handler.handleTransferOwnership)
+	app.GET("/api/admin/aliases", handler.handleListOwnerAliases)
+	app.POST("/api/admin/aliases", handler.handleMergeOwnerAliases)
+	app.DELETE("/api/admin/aliases/{login}", handler.handleDeleteOwnerAlias)
+	app.POST("/api/admin/audit-log/{org}/ingest", handler.handleIngestAuditLog)
+	app.POST("/api/admin/seed", handler.handleSeedSyntheticOrganization)
+	app.POST("/api/admin/archives/{org}/restore", handler.handleRestoreArchive)
+	app.POST("/api/admin/custom-properties/{org}/sync", handler.handleSyncCustomProperties)
+	app.GET("/api/admin/webhooks/failed", handler.handleListFailedWebhooks)
+	app.POST("/api/admin/webhooks/failed/{id}/replay", handler.handleReplayFailedWebhook)
+}
+
 // logServerReady logs server ready information
 func logServerReady(app *gofr.App, deps *AppDependencies) {
-	app.Logger().Infof("API server routes registered successfully - component=main operation=register_routes routes_count=6 api_endpoints=[/api/scan/{org},/api/graph/{org},/api/stats/{org},/api/health] docs_endpoints=[/api/docs,/api/openapi.yaml]")
+	app.Logger().Infof("API server routes registered successfully - component=main operation=register_routes routes_count=66 api_endpoints=[/api/scan,/api/scan/{org},/api/orgs,/api/scan/jobs/{id},/api/schedules,/api/graph/meta,/api/graph/{org},/api/graph/{org}/export,/api/publish/{org},/api/graph/{org}/changes/wait,/api/stats/{org},/api/stats/{org}/coverage-target,/api/coverage/{org},/api/reports/{org}/ownership.csv,/api/reports/{org}/ownership.xlsx,/api/manifest/{org},/api/repositories/{org}/{repo}/dependencies,/api/export/{org}/{repo}/codeowners,/api/owners/resolve,/api/owners/{org}/{repo}/pull/{number},/api/validate/codeowners,/api/codeowners/{org}/{repo}/errors,/api/teams/{org}/export,/api/insights/{org}/review-load,/api/insights/{org}/shared-codeowners,/api/insights/{org}/by-language,/api/analysis/{org}/orphans,/api/impact/{org}/team/{slug},/api/history/{org},/api/history/{org}/{repo},/api/diff/{org},/api/hierarchy/{org},/api/entities/{type}/{key},/api/repositories/{org},/api/teams/{org},/api/users/{org},/api/webhooks/github,/api/health] docs_endpoints=[/api/docs,/api/openapi.yaml] admin_endpoints=[/api/admin/maintenance,/api/admin/scans/pending,/api/admin/scans/{scanId}/approve,/api/admin/scans/{scanId}/reject,/api/admin/usage,/api/admin/support-bundle,/api/admin/loglevel,/api/admin/config/reload,/api/admin/archives,/api/admin/archives/{org}/restore,/api/admin/slo,/api/admin/conversion-failures,/api/admin/leader,/api/admin/transfer,/api/admin/aliases,/api/admin/aliases/{login},/api/admin/audit-log/{org}/ingest,/api/admin/custom-properties/{org}/sync,/api/admin/seed,/api/admin/webhooks/failed,/api/admin/webhooks/failed/{id}/replay]")
 	app.Logger().Infof("GitHub Codeowners Visualization API starting on port %d - component=main operation=start_server ready=true", deps.Config.Port)
 }
 
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// defaultMaintenanceMessage is returned to clients when no custom message is configured
+const defaultMaintenanceMessage = "The API is in read-only maintenance mode; write operations are temporarily unavailable"
+
+// maintenanceTogglePath is the admin route that stays writable while maintenance mode is active
+const maintenanceTogglePath = "/api/admin/maintenance"
+
+// maintenanceStateKey is the state store key of the maintenance flag shared by every replica
+const maintenanceStateKey = "maintenance"
+
+// maintenanceCacheTTL is how long a replica answers from the flag it last read from the state store,
+// and so how long a change made through another replica takes to apply
+const maintenanceCacheTTL = 5 * time.Second
+
+// MaintenanceState holds the read-only maintenance flag. The flag lives in the state store, so a
+// change through any replica applies to all of them; until it is first set there, the MAINTENANCE_MODE
+// configuration applies
+type MaintenanceState struct {
+	mu       sync.RWMutex
+	store    StateStore
+	defaults MaintenanceStatus
+	cached   MaintenanceStatus
+	checked  time.Time
+}
+
+// MaintenanceStatus represents the maintenance mode status returned by the admin endpoint
+type MaintenanceStatus struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+	Since   string `json:"since,omitempty"`
+}
+
+// MaintenanceRequest represents a request to toggle maintenance mode
+type MaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// newMaintenanceState creates maintenance state over store, starting from configuration
+func newMaintenanceState(config MaintenanceConfig, store StateStore) *MaintenanceState {
+	defaults := buildMaintenanceStatus(MaintenanceStatus{}, config.Enabled, config.Message, time.Now())
+	return &MaintenanceState{store: store, defaults: defaults, cached: defaults}
+}
+
+// buildMaintenanceStatus applies a toggle to the current status; staying enabled keeps the time
+// maintenance started (Pure Core)
+func buildMaintenanceStatus(current MaintenanceStatus, enabled bool, message string, now time.Time) MaintenanceStatus {
+	status := MaintenanceStatus{Enabled: enabled, Message: resolveMaintenanceMessage(message)}
+	switch {
+	case enabled && current.Enabled && current.Since != "":
+		status.Since = current.Since
+	case enabled:
+		status.Since = now.UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// refresh reads the flag from the state store; a failed read keeps the last known flag, so a store
+// outage neither starts nor ends maintenance
+func (m *MaintenanceState) refresh(ctx *gofr.Context) (MaintenanceStatus, error) {
+	status := m.defaults
+	value, ok, err := m.store.Get(ctx, maintenanceStateKey)
+	if err == nil && ok {
+		err = json.Unmarshal(value, &status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checked = time.Now()
+	if err != nil {
+		return m.cached, fmt.Errorf("failed to read maintenance mode: %w", err)
+	}
+	m.cached = status
+	return status, nil
+}
+
+// status returns the maintenance flag, read from the state store at most once per maintenanceCacheTTL
+func (m *MaintenanceState) status(ctx *gofr.Context) MaintenanceStatus {
+	if m == nil {
+		return MaintenanceStatus{Message: defaultMaintenanceMessage}
+	}
+
+	m.mu.RLock()
+	cached, fresh := m.cached, time.Since(m.checked) < maintenanceCacheTTL
+	m.mu.RUnlock()
+	if fresh {
+		return cached
+	}
+
+	status, err := m.refresh(ctx)
+	if err != nil {
+		logWarn(ctx, "Using last known maintenance mode", LogFields{
+			"component": "maintenance",
+			"operation": "refresh_maintenance",
+			"enabled":   status.Enabled,
+			"error":     err.Error(),
+		})
+	}
+	return status
+}
+
+// isEnabled reports whether the API is in read-only mode
+func (m *MaintenanceState) isEnabled(ctx *gofr.Context) bool {
+	return m.status(ctx).Enabled
+}
+
+// isEnabledNow reads the flag from the state store without the cache, for cron jobs that must not
+// start in the seconds after another replica turned maintenance on
+func (m *MaintenanceState) isEnabledNow(ctx *gofr.Context) bool {
+	if m == nil {
+		return false
+	}
+
+	status, err := m.refresh(ctx)
+	if err != nil {
+		logWarn(ctx, "Using last known maintenance mode", LogFields{
+			"component": "maintenance",
+			"operation": "refresh_maintenance",
+			"enabled":   status.Enabled,
+			"error":     err.Error(),
+		})
+	}
+	return status.Enabled
+}
+
+// set stores the maintenance flag and message for every replica
+func (m *MaintenanceState) set(ctx *gofr.Context, enabled bool, message string) (MaintenanceStatus, error) {
+	current, err := m.refresh(ctx)
+	if err != nil {
+		return MaintenanceStatus{}, err
+	}
+
+	status := buildMaintenanceStatus(current, enabled, message, time.Now())
+	value, err := json.Marshal(status)
+	if err != nil {
+		return MaintenanceStatus{}, err
+	}
+	if err := m.store.Put(ctx, maintenanceStateKey, value, 0); err != nil {
+		return MaintenanceStatus{}, fmt.Errorf("failed to store maintenance mode: %w", err)
+	}
+
+	m.mu.Lock()
+	m.cached, m.checked = status, time.Now()
+	m.mu.Unlock()
+	return status, nil
+}
+
+// resolveMaintenanceMessage returns the given message or the default one (Pure Core)
+func resolveMaintenanceMessage(message string) string {
+	if message == "" {
+		return defaultMaintenanceMessage
+	}
+	return message
+}
+
+// isReadOnlyRequest reports whether a request can be served during maintenance: reads, including the
+// POST routes authorization lets readers call, and the admin routes operating the server (Pure Core)
+func isReadOnlyRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if readOnlyPostPaths[path] {
+			return true
+		}
+	}
+	return path == maintenanceTogglePath || path == logLevelPath || path == configReloadPath
+}
+
+// maintenanceModeMiddleware rejects write requests with 503 while maintenance mode is active
+func maintenanceModeMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isReadOnlyRequest(r.Method, r.URL.Path) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			status := deps.Maintenance.status(deps.requestContext(r))
+			if !status.Enabled {
+				inner.ServeHTTP(w, r)
+				return
+			}
+			writeMaintenanceResponse(w, status)
+		})
+	}
+}
+
+// writeMaintenanceResponse writes a 503 response in GoFr's error envelope
+func writeMaintenanceResponse(w http.ResponseWriter, status MaintenanceStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", "300")
+	w.WriteHeader(http.StatusServiceUnavailable)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"message":     status.Message,
+			"maintenance": true,
+			"since":       status.Since,
+		},
+	})
+}
+
+// handleGetMaintenance returns the current maintenance mode status
+func (h *AppHandler) handleGetMaintenance(ctx *gofr.Context) (interface{}, error) {
+	return h.deps.Maintenance.status(ctx), nil
+}
+
+// handleSetMaintenance enables or disables maintenance mode at runtime
+func (h *AppHandler) handleSetMaintenance(ctx *gofr.Context) (interface{}, error) {
+	var request MaintenanceRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{
+			Params: []string{"body"},
+		}
+	}
+
+	status, err := h.deps.Maintenance.set(ctx, request.Enabled, request.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	logWarn(ctx, "Maintenance mode updated", LogFields{
+		"component": "admin",
+		"operation": "set_maintenance",
+		"enabled":   status.Enabled,
+		"message":   status.Message,
+	})
+
+	return status, nil
+}
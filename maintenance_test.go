@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsReadOnlyRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "get", method: http.MethodGet, path: "/api/graph/acme", want: true},
+		{name: "head", method: http.MethodHead, path: "/api/stats/acme", want: true},
+		{name: "options", method: http.MethodOptions, path: "/api/scan/acme", want: true},
+		{name: "resolve owners", method: http.MethodPost, path: "/api/owners/resolve", want: true},
+		{name: "validate codeowners", method: http.MethodPost, path: "/api/validate/codeowners", want: true},
+		{name: "toggle maintenance", method: http.MethodPost, path: maintenanceTogglePath, want: true},
+		{name: "set log level", method: http.MethodPut, path: logLevelPath, want: true},
+		{name: "reload config", method: http.MethodPost, path: configReloadPath, want: true},
+		{name: "scan", method: http.MethodPost, path: "/api/scan/acme", want: false},
+		{name: "webhook", method: http.MethodPost, path: "/api/webhooks/github", want: false},
+		{name: "delete", method: http.MethodDelete, path: "/api/orgs/acme", want: false},
+		{name: "put to read-only post path", method: http.MethodPut, path: "/api/owners/resolve", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReadOnlyRequest(tt.method, tt.path); got != tt.want {
+				t.Errorf("isReadOnlyRequest(%s, %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
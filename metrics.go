@@ -0,0 +1,162 @@
+package main
+
+import (
+	"sort"
+
+	"gofr.dev/pkg/gofr/metrics"
+)
+
+// Kinds of metric instruments registered with GoFr
+const (
+	metricKindCounter       = "counter"
+	metricKindUpDownCounter = "up_down_counter"
+	metricKindHistogram     = "histogram"
+	metricKindGauge         = "gauge"
+)
+
+// durationBucketsMs are the histogram buckets of durations recorded in milliseconds, from fast
+// Neo4j queries up to half-hour scans
+var durationBucketsMs = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 900000, 1800000}
+
+// MetricDefinition describes a metric instrument exposed on /metrics
+type MetricDefinition struct {
+	Name        string
+	Description string
+	Kind        string
+}
+
+// registeredMetricKinds maps the metrics registered at startup to their kind; it is written once
+// before the app starts serving and only read afterwards
+var registeredMetricKinds = map[string]string{}
+
+// appMetricDefinitions lists every metric recorded through MetricsCollector. Counters recording
+// amounts larger than one are up-down counters, as GoFr counters only increment by one (Pure Core)
+func appMetricDefinitions() []MetricDefinition {
+	return []MetricDefinition{
+		// Scans and GitHub
+		{"scan_duration_ms", "Duration of organization scans in milliseconds", metricKindHistogram},
+		{"operation_duration_ms", "Duration of timed operations in milliseconds", metricKindHistogram},
+		{"repositories_processed", "Repositories fetched from GitHub", metricKindUpDownCounter},
+		{"teams_processed", "Teams fetched from GitHub", metricKindUpDownCounter},
+		{"api_calls_total", "GitHub and HTTP API calls by endpoint and status", metricKindCounter},
+		{"errors_total", "Errors by component and error type", metricKindCounter},
+		{"codeowners_not_found", "Repositories without a CODEOWNERS file", metricKindCounter},
+		{"codeowners_nested_files_total", "CODEOWNERS files read from the top-level directories of repositories", metricKindCounter},
+		{"codeowners_rules_count", "Rules in the last CODEOWNERS file parsed per repository", metricKindGauge},
+		{"github_rate_limit_remaining", "GitHub API requests remaining in the current rate limit window", metricKindGauge},
+		{"github_rate_limit_total", "GitHub API request limit of the current rate limit window", metricKindGauge},
+		{"github_rate_limit_reserve_holds_total", "Background GitHub requests held back by the interactive rate limit reserve", metricKindCounter},
+		{"github_rate_limit_throttled_total", "GitHub requests held back by the adaptive throttler by reason and outcome", metricKindCounter},
+		{"github_rate_limit_throttle_wait", "Time GitHub requests were held by the adaptive throttler in milliseconds", metricKindHistogram},
+		{"circuit_breaker_open", "Whether the circuit breaker of a dependency is open or half-open", metricKindGauge},
+		{"circuit_breaker_transitions_total", "Circuit breaker state changes by dependency and new state", metricKindCounter},
+		{"circuit_breaker_rejected_total", "Calls failed fast by an open circuit breaker by dependency", metricKindCounter},
+		{"github_secondary_rate_limits_total", "GitHub secondary rate limit responses that paused requests by status", metricKindCounter},
+		{"github_retries_total", "GitHub requests retried after a transient failure by reason", metricKindCounter},
+		{"github_retry_delay", "Backoff before retrying a GitHub request in milliseconds", metricKindHistogram},
+		{"github_retry_budget_exhausted_total", "GitHub requests that still failed once their retry budget ran out", metricKindCounter},
+		{"github_conditional_requests_total", "GitHub requests sent with a cached ETag by whether the response was modified", metricKindCounter},
+		{"github_etag_cache_bytes", "Size of the GitHub response bodies kept for conditional requests", metricKindGauge},
+		{"github_http_connections_total", "GitHub requests by whether they reused a pooled connection", metricKindCounter},
+		{"github_http_connect_duration", "Time to open a new GitHub connection, including DNS and TLS, in milliseconds", metricKindHistogram},
+		{"github_graphql_degraded_queries_total", "GraphQL listing requests run with the slim query profile", metricKindCounter},
+		{"github_api_calls_by_tenant_total", "GitHub API calls attributed to each tenant", metricKindUpDownCounter},
+		{"scan_job_panics_total", "Scan jobs that panicked and were recovered", metricKindCounter},
+		{"scan_watchdog_stalled_total", "Scans cancelled and failed by the watchdog for making no progress", metricKindCounter},
+		{"scan_requests_deduplicated_total", "Scan requests answered with a scan from within the deduplication window", metricKindCounter},
+		{"scan_watchdog_orphaned_total", "Scans left running by a previous instance and marked failed", metricKindCounter},
+		{"leader_lease_held", "Whether this replica holds the leader lease", metricKindGauge},
+		{"scan_buffered_items", "Fetched items the running scan of each organization holds in memory", metricKindGauge},
+		{"scan_budget_exceeded_total", "Scans failed for buffering more items than SCAN_MAX_BUFFERED_ITEMS", metricKindCounter},
+		{"scan_jobs_active", "Background scan jobs queued or running", metricKindGauge},
+		{"codeowners_coverage_percentage", "CODEOWNERS coverage of the last scan per organization", metricKindGauge},
+		{"codeowners_orphaned_owners", "CODEOWNERS owners no longer resolving in the organization at the last orphan analysis", metricKindGauge},
+
+		// Graph API, caches and data quality
+		{"graph_requests_rejected_total", "Graph requests rejected for exceeding the size limits", metricKindCounter},
+		{"graph_records_dropped_total", "Graph query records dropped for not matching the expected shape", metricKindUpDownCounter},
+		{"webhook_dead_letters_total", "Failed webhook deliveries by whether they were kept for replay or lost", metricKindCounter},
+		{"rate_limit_requests_total", "API requests checked against per-client rate limits by client type and outcome", metricKindCounter},
+		{"rate_limit_clients", "API clients with a rate limit bucket in this instance", metricKindGauge},
+		{"stale_responses_served_total", "Cached responses served while Neo4j was unavailable", metricKindCounter},
+		{"cache_warmup_duration", "Duration of the startup cache warmup in milliseconds", metricKindHistogram},
+		{"organization_archives_total", "Organizations archived", metricKindCounter},
+		{"organization_restores_total", "Organizations restored from an archive", metricKindCounter},
+		{"reconciliation_repo_drift_percent", "Repository drift between GitHub and the graph", metricKindGauge},
+		{"reconciliation_team_drift_percent", "Team drift between GitHub and the graph", metricKindGauge},
+		{"reconciliation_drift_detected_total", "Reconciliation runs that found drift above the threshold", metricKindCounter},
+		{"data_freshness_age_seconds", "Age of the active scan per organization in seconds", metricKindGauge},
+		{"data_freshness_sla_breaches_total", "Freshness checks that found an organization past its SLA", metricKindCounter},
+		{"coverage_target_percent", "Coverage target per organization", metricKindGauge},
+		{"coverage_target_required_weekly_delta", "Weekly coverage increase needed to meet the target", metricKindGauge},
+		{"coverage_target_observed_weekly_delta", "Observed weekly coverage increase", metricKindGauge},
+		{"slo_error_ratio", "Error ratio of each SLO window", metricKindGauge},
+		{"slo_burn_rate", "Error budget burn rate of each SLO window", metricKindGauge},
+
+		// Neo4j
+		{"neo4j_connections_total", "Neo4j driver connections created", metricKindCounter},
+		{"neo4j_sessions_total", "Neo4j sessions opened", metricKindCounter},
+		{"neo4j_session_duration", "Time spent running queries per Neo4j session in milliseconds", metricKindHistogram},
+		{"neo4j_queries_per_session", "Queries run on closed Neo4j sessions", metricKindUpDownCounter},
+		{"neo4j_queries_total", "Neo4j queries run", metricKindCounter},
+		{"neo4j_query_errors_total", "Neo4j queries that failed", metricKindCounter},
+		{"neo4j_slow_queries_total", "Neo4j queries slower than the slow query threshold", metricKindCounter},
+		{"neo4j_query_duration", "Duration of Neo4j queries in milliseconds", metricKindHistogram},
+		{"neo4j_transaction_duration", "Duration of Neo4j transactions in milliseconds", metricKindHistogram},
+		{"neo4j_collect_duration", "Time spent collecting Neo4j results in milliseconds", metricKindHistogram},
+		{"neo4j_convert_duration", "Time spent converting Neo4j records in milliseconds", metricKindHistogram},
+		{"neo4j_records_returned_total", "Records returned by Neo4j reads", metricKindUpDownCounter},
+		{"neo4j_records_affected_total", "Records returned by Neo4j writes", metricKindUpDownCounter},
+		{"neo4j_records_per_second", "Record throughput of the last measured Neo4j session", metricKindGauge},
+		{"neo4j_avg_query_time_ms", "Average query time of the last measured Neo4j session", metricKindGauge},
+		{"neo4j_batch_flushes_total", "Batched upsert flushes written to Neo4j", metricKindCounter},
+		{"neo4j_batch_rows_total", "Rows written by batched upsert flushes", metricKindUpDownCounter},
+		{"neo4j_health_checks_total", "Neo4j health checks by outcome", metricKindCounter},
+		{"neo4j_health_check_duration", "Duration of Neo4j health checks in milliseconds", metricKindHistogram},
+		{"neo4j_database_available", "Whether the last Neo4j health check succeeded (1) or failed (0)", metricKindGauge},
+		{"neo4j_pool_max_size", "Maximum size of the Neo4j connection pool", metricKindGauge},
+		{"neo4j_constraints_created_total", "Neo4j constraints created", metricKindCounter},
+		{"neo4j_constraint_errors_total", "Neo4j constraints that failed to be created", metricKindCounter},
+		{"neo4j_constraint_operations_total", "Neo4j constraint setup runs", metricKindCounter},
+		{"neo4j_indexes_created_total", "Neo4j indexes created", metricKindCounter},
+		{"neo4j_index_errors_total", "Neo4j indexes that failed to be created", metricKindCounter},
+		{"neo4j_index_operations_total", "Neo4j index setup runs", metricKindCounter},
+	}
+}
+
+// registerAppMetrics registers the application's metric instruments with GoFr, which exposes them
+// in Prometheus format on /metrics of the metrics server (METRICS_PORT)
+func registerAppMetrics(manager metrics.Manager) {
+	if manager == nil {
+		return
+	}
+
+	for _, def := range appMetricDefinitions() {
+		switch def.Kind {
+		case metricKindCounter:
+			manager.NewCounter(def.Name, def.Description)
+		case metricKindUpDownCounter:
+			manager.NewUpDownCounter(def.Name, def.Description)
+		case metricKindHistogram:
+			manager.NewHistogram(def.Name, def.Description, durationBucketsMs...)
+		case metricKindGauge:
+			manager.NewGauge(def.Name, def.Description)
+		}
+		registeredMetricKinds[def.Name] = def.Kind
+	}
+}
+
+// buildMetricLabelPairs flattens labels into the key, value pairs GoFr expects, ordered by key (Pure Core)
+func buildMetricLabelPairs(labels MetricLabels) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key, labels[key])
+	}
+	return pairs
+}
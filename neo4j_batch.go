@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Neo4jBatchWriter buffers upserts sharing one UNWIND query and flushes them when
+// the buffer reaches the configured size or its oldest row exceeds the flush interval.
+// Writers listed in dependsOn are flushed first, so nodes always exist before the
+// relationships that MATCH them are written.
+type Neo4jBatchWriter struct {
+	name      string
+	query     string
+	params    map[string]interface{}
+	session   *Neo4jSession
+	config    Neo4jBatchConfig
+	dependsOn []*Neo4jBatchWriter
+	rows      []map[string]interface{}
+	oldestRow time.Time
+	written   int
+	flushes   int
+}
+
+// ScanBatchWriters holds the batch writers of a staging scan in dependency order
+type ScanBatchWriters struct {
+	topics         *Neo4jBatchWriter
+	teams          *Neo4jBatchWriter
+	repositories   *Neo4jBatchWriter
+	repoTopics     *Neo4jBatchWriter
+	users          *Neo4jBatchWriter
+	userCodeowners *Neo4jBatchWriter
+	teamCodeowners *Neo4jBatchWriter
+	files          *Neo4jBatchWriter
+	seenUsers      map[string]bool
+}
+
+// newNeo4jBatchWriter creates a batch writer for an UNWIND $rows query
+func newNeo4jBatchWriter(session *Neo4jSession, name, query string, params map[string]interface{}, config Neo4jBatchConfig, dependsOn ...*Neo4jBatchWriter) *Neo4jBatchWriter {
+	validateNeo4jSessionNotNil(session)
+	validateQueryNotEmpty(query)
+
+	return &Neo4jBatchWriter{
+		name:      name,
+		query:     query,
+		params:    params,
+		session:   session,
+		config:    config,
+		dependsOn: dependsOn,
+		rows:      make([]map[string]interface{}, 0, config.Size),
+	}
+}
+
+// shouldFlushBatch reports whether a buffer has reached its size or age threshold (Pure Core)
+func shouldFlushBatch(buffered int, oldestRow time.Time, config Neo4jBatchConfig, now time.Time) bool {
+	if buffered == 0 {
+		return false
+	}
+	return buffered >= config.Size || now.Sub(oldestRow) >= config.FlushInterval
+}
+
+// buildBatchParams merges the shared query parameters with the buffered rows (Pure Core)
+func buildBatchParams(params map[string]interface{}, rows []map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(params)+1)
+	for key, value := range params {
+		merged[key] = value
+	}
+	merged["rows"] = rows
+	return merged
+}
+
+// add buffers a row and flushes the batch once a threshold is reached
+func (w *Neo4jBatchWriter) add(ctx context.Context, row map[string]interface{}) error {
+	if len(w.rows) == 0 {
+		w.oldestRow = time.Now()
+	}
+	w.rows = append(w.rows, row)
+
+	if !shouldFlushBatch(len(w.rows), w.oldestRow, w.config, time.Now()) {
+		return nil
+	}
+	return w.flush(ctx)
+}
+
+// flush writes the buffered rows, after flushing every writer this one depends on
+func (w *Neo4jBatchWriter) flush(ctx context.Context) error {
+	for _, dependency := range w.dependsOn {
+		if err := dependency.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	if len(w.rows) == 0 {
+		return nil
+	}
+
+	rows := w.rows
+	if _, err := executeNeo4jWrite(ctx, w.session, w.query, buildBatchParams(w.params, rows)); err != nil {
+		return fmt.Errorf("failed to flush %s batch of %d rows: %w", w.name, len(rows), err)
+	}
+
+	w.rows = make([]map[string]interface{}, 0, w.config.Size)
+	w.written += len(rows)
+	w.flushes++
+	reportScanProgress(ctx, "")
+
+	if w.session.metrics != nil {
+		labels := MetricLabels{"writer": w.name}
+		w.session.metrics.recordCounter("neo4j_batch_flushes_total", 1, labels)
+		w.session.metrics.recordCounter("neo4j_batch_rows_total", len(rows), labels)
+	}
+
+	return nil
+}
+
+// newScanBatchWriters creates the batch writers used to store a staging scan
+func newScanBatchWriters(session *Neo4jSession, orgLogin, scanID string, config Neo4jBatchConfig) *ScanBatchWriters {
+	validateOrgLoginNotEmpty(orgLogin)
+
+	scoped := map[string]interface{}{"org_login": orgLogin, "scan_id": scanID}
+	relationship := map[string]interface{}{"scan_id": scanID}
+
+	topics := newNeo4jBatchWriter(session, "topics", buildBatchCreateTopicsQuery(), scoped, config)
+	teams := newNeo4jBatchWriter(session, "teams", buildBatchCreateTeamsQuery(), scoped, config)
+	repositories := newNeo4jBatchWriter(session, "repositories", buildBatchCreateRepositoriesQuery(), scoped, config)
+	users := newNeo4jBatchWriter(session, "users", buildBatchCreateUsersQuery(), scoped, config)
+
+	return &ScanBatchWriters{
+		topics:         topics,
+		teams:          teams,
+		repositories:   repositories,
+		repoTopics:     newNeo4jBatchWriter(session, "repository_topics", buildBatchCreateRepositoryTopicRelationshipsQuery(), relationship, config, repositories, topics),
+		users:          users,
+		userCodeowners: newNeo4jBatchWriter(session, "user_codeowners", buildBatchCreateCodeownerRelationshipsQuery(), relationship, config, repositories, users),
+		teamCodeowners: newNeo4jBatchWriter(session, "team_codeowners", buildBatchCreateTeamCodeownerRelationshipsQuery(), scoped, config, repositories, teams),
+		files:          newNeo4jBatchWriter(session, "codeowners_files", buildBatchCreateCodeownersFilesQuery(), relationship, config, repositories),
+		seenUsers:      make(map[string]bool),
+	}
+}
+
+// addRepository buffers a repository and its topic relationships
+func (b *ScanBatchWriters) addRepository(ctx context.Context, repo GitHubRepository) error {
+	err := b.repositories.add(ctx, map[string]interface{}{
+		"id":          repo.ID,
+		"name":        repo.Name,
+		"full_name":   repo.FullName,
+		"description": repo.Description,
+		"private":     repo.Private,
+		"visibility":  repositoryVisibility(repo),
+		"archived":    repo.Archived,
+		"fork":        repo.Fork,
+		"url":         repo.URL,
+		"language":    repo.Language,
+		"created_at":  repo.CreatedAt.Format(time.RFC3339),
+		"updated_at":  repo.UpdatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range repo.Topics {
+		if err := b.repoTopics.add(ctx, map[string]interface{}{
+			"repo_full_name": repo.FullName,
+			"topic_name":     topic,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTeam buffers a team; a team whose members could not be listed keeps its stored members
+func (b *ScanBatchWriters) addTeam(ctx context.Context, team GitHubTeam) error {
+	var members interface{}
+	if team.Members != nil {
+		members = team.Members
+	}
+	return b.teams.add(ctx, map[string]interface{}{
+		"id":          team.ID,
+		"slug":        team.Slug,
+		"name":        team.Name,
+		"description": team.Description,
+		"url":         team.URL,
+		"privacy":     team.Privacy,
+		"members":     members,
+	})
+}
+
+// addTopic buffers a topic
+func (b *ScanBatchWriters) addTopic(ctx context.Context, topic GitHubTopic) error {
+	return b.topics.add(ctx, map[string]interface{}{
+		"name":  topic.Name,
+		"count": topic.Count,
+	})
+}
+
+// addCodeowners buffers the file, owners and ownership relationships of a CODEOWNERS file
+func (b *ScanBatchWriters) addCodeowners(ctx context.Context, codeowners GitHubCodeowners) error {
+	validateRepoFullNameNotEmpty(codeowners.Repository)
+
+	if codeowners.SHA != "" {
+		if err := b.files.add(ctx, map[string]interface{}{
+			"repo_full_name": codeowners.Repository,
+			"sha":            codeowners.SHA,
+			"path":           codeowners.Path,
+			"content":        codeowners.Content,
+			"size":           len(codeowners.Content),
+			"rule_count":     len(codeowners.Rules),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range codeowners.Rules {
+		if rule.Source == "" {
+			rule.Source, rule.SourceLine = codeowners.Path, rule.Line
+		}
+		for _, owner := range rule.Owners {
+			validateOwnerNotEmpty(owner)
+			if err := b.addCodeownerRule(ctx, codeowners.Repository, owner, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addCodeownerRule buffers a single user or team ownership relationship with the file and, for GitLab
+// rules, the section the rule came from
+func (b *ScanBatchWriters) addCodeownerRule(ctx context.Context, repoFullName, owner string, rule GitHubCodeownersRule) error {
+	if isTeamOwner(owner) {
+		return b.teamCodeowners.add(ctx, map[string]interface{}{
+			"repo_full_name": repoFullName,
+			"team_slug":      extractTeamSlug(owner),
+			"pattern":        rule.Pattern,
+			"line":           rule.Line,
+			"source":         rule.Source,
+			"source_line":    rule.SourceLine,
+			"section":        rule.Section,
+			"optional":       rule.Optional,
+			"approvals":      rule.Approvals,
+		})
+	}
+
+	login := strings.TrimPrefix(owner, "@")
+	if !b.seenUsers[login] {
+		b.seenUsers[login] = true
+		if err := b.users.add(ctx, map[string]interface{}{
+			"id":    generateUserID(login),
+			"login": login,
+			"name":  login,
+			"email": "",
+			"url":   fmt.Sprintf("https://github.com/%s", login),
+			"bot":   isBotLogin(login),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return b.userCodeowners.add(ctx, map[string]interface{}{
+		"repo_full_name": repoFullName,
+		"owner_login":    login,
+		"pattern":        rule.Pattern,
+		"line":           rule.Line,
+		"source":         rule.Source,
+		"source_line":    rule.SourceLine,
+		"section":        rule.Section,
+		"optional":       rule.Optional,
+		"approvals":      rule.Approvals,
+	})
+}
+
+// flush writes every remaining buffered row; relationship writers flush their node writers first
+func (b *ScanBatchWriters) flush(ctx context.Context) error {
+	for _, writer := range []*Neo4jBatchWriter{b.repoTopics, b.userCodeowners, b.teamCodeowners, b.files} {
+		if err := writer.flush(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writers returns every batch writer in dependency order
+func (b *ScanBatchWriters) writers() []*Neo4jBatchWriter {
+	return []*Neo4jBatchWriter{b.topics, b.teams, b.repositories, b.repoTopics, b.users, b.userCodeowners, b.teamCodeowners, b.files}
+}
+
+// storeScanDataInBatches writes a staging scan's entities through buffered UNWIND batches (Orchestrator)
+func storeScanDataInBatches(ctx context.Context, session *Neo4jSession, config Neo4jBatchConfig, orgLogin, scanID string, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners) error {
+	batches := newScanBatchWriters(session, orgLogin, scanID, config)
+
+	for _, topic := range topics {
+		if err := batches.addTopic(ctx, topic); err != nil {
+			return fmt.Errorf("failed to store topic %s: %w", topic.Name, err)
+		}
+	}
+
+	for _, team := range teams {
+		if err := batches.addTeam(ctx, team); err != nil {
+			return fmt.Errorf("failed to store team %s: %w", team.Name, err)
+		}
+	}
+
+	total := len(repos) + len(codeowners)
+	for i, repo := range repos {
+		reportScanBatchProgress(ctx, i, total)
+		if err := batches.addRepository(ctx, repo); err != nil {
+			return fmt.Errorf("failed to store repository %s: %w", repo.Name, err)
+		}
+	}
+
+	for i, codeowner := range codeowners {
+		reportScanBatchProgress(ctx, len(repos)+i, total)
+		if err := batches.addCodeowners(ctx, codeowner); err != nil {
+			return fmt.Errorf("failed to store CODEOWNERS for %s: %w", codeowner.Repository, err)
+		}
+	}
+
+	if err := batches.flush(ctx); err != nil {
+		return err
+	}
+
+	fields := LogFields{
+		"component":    "neo4j_client",
+		"operation":    "batch_write",
+		"organization": orgLogin,
+		"scan_id":      scanID,
+	}
+	for _, writer := range batches.writers() {
+		fields[writer.name+"_rows"] = writer.written
+		fields[writer.name+"_flushes"] = writer.flushes
+	}
+	logInfo(session.ctx, "Stored scan data in batches", fields)
+
+	return nil
+}
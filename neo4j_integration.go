@@ -41,6 +41,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -56,6 +57,7 @@ type Neo4jConnection struct {
 	timeout  time.Duration
 	metrics  *MetricsCollector
 	ctx      *gofr.Context
+	version  Neo4jServerVersion
 }
 
 // Neo4jSession represents a Neo4j session for transaction management with observability
@@ -64,6 +66,7 @@ type Neo4jSession struct {
 	database      string
 	metrics       *MetricsCollector
 	ctx           *gofr.Context
+	version       Neo4jServerVersion
 	queryCount    int
 	totalDuration time.Duration
 }
@@ -84,9 +87,7 @@ type Neo4jResult struct {
 
 // Neo4jError represents Neo4j-specific errors
 type Neo4jError struct {
-	Code    string
-	Message string
-	Details string
+	AppError
 }
 
 // Error implements the error interface for Neo4jError
@@ -185,6 +186,21 @@ func createNeo4jConnectionWithObservability(ctx context.Context, gofrCtx *gofr.C
 		return nil, wrapNeo4jError(err, "failed to verify Neo4j connectivity")
 	}
 
+	// Query builders pick Cypher variants by server version, so unsupported servers are refused up front
+	version, err := detectNeo4jServerVersion(ctx, driver, config)
+	if err != nil {
+		if gofrCtx != nil {
+			logError(gofrCtx, "Unsupported or unknown Neo4j server version", LogFields{
+				"component": "neo4j_client",
+				"operation": "detect_version",
+				"error":     err.Error(),
+				"uri":       sanitizeURI(config.URI),
+			})
+		}
+		driver.Close(ctx)
+		return nil, err
+	}
+
 	// Log successful connection if observability is available
 	if gofrCtx != nil {
 		logInfo(gofrCtx, "Neo4j connection established successfully", LogFields{
@@ -192,6 +208,7 @@ func createNeo4jConnectionWithObservability(ctx context.Context, gofrCtx *gofr.C
 			"operation": "connection_established",
 			"database":  config.Database,
 			"uri":       sanitizeURI(config.URI),
+			"version":   version.String(),
 		})
 
 		// Record connection success metric
@@ -209,6 +226,7 @@ func createNeo4jConnectionWithObservability(ctx context.Context, gofrCtx *gofr.C
 		timeout:  config.Timeout,
 		metrics:  metrics,
 		ctx:      gofrCtx,
+		version:  version,
 	}
 
 	// Log connection pool status if observability is available
@@ -227,7 +245,7 @@ func closeNeo4jConnection(ctx context.Context, conn *Neo4jConnection) error {
 
 	// Create span for connection close operation
 	if conn.ctx != nil {
-		span := createNeo4jSpan(conn.ctx, "connection.close", "CLOSE CONNECTION")
+		span := createNeo4jSpan(ctx, "connection.close", "CLOSE CONNECTION")
 		defer finishSpan(span)
 
 		// Log connection close
@@ -270,7 +288,7 @@ func createNeo4jSession(ctx context.Context, conn *Neo4jConnection) (*Neo4jSessi
 	validateNeo4jConnectionNotNil(conn)
 
 	// Create span for session creation
-	span := createNeo4jSpan(conn.ctx, "session.create", "CREATE SESSION")
+	span := createNeo4jSpan(ctx, "session.create", "CREATE SESSION")
 	defer finishSpan(span)
 
 	// Log session creation
@@ -304,6 +322,7 @@ func createNeo4jSession(ctx context.Context, conn *Neo4jConnection) (*Neo4jSessi
 		database:      conn.database,
 		metrics:       conn.metrics,
 		ctx:           conn.ctx,
+		version:       conn.version,
 		queryCount:    0,
 		totalDuration: 0,
 	}, nil
@@ -317,7 +336,7 @@ func closeNeo4jSession(ctx context.Context, session *Neo4jSession) error {
 
 	// Create span for session close
 	if session.ctx != nil {
-		span := createNeo4jSpan(session.ctx, "session.close", "CLOSE SESSION")
+		span := createNeo4jSpan(ctx, "session.close", "CLOSE SESSION")
 		defer finishSpan(span)
 
 		// Log session statistics before closing
@@ -374,8 +393,9 @@ func executeNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query str
 	validateQueryNotEmpty(query)
 
 	// Create span for read query
-	span := createNeo4jSpan(session.ctx, "query.read", query)
+	span := createNeo4jSpan(ctx, "query.read", query)
 	defer finishSpan(span)
+	ctx = contextWithSpan(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(session.ctx, "neo4j_read_query")
@@ -387,7 +407,7 @@ func executeNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query str
 
 	// Sanitize parameters for logging
 	sanitizedParams := sanitizeParams(params)
-	queryHash := generateQueryHash(query)
+	queryHash := cachedQueryHash(query)
 
 	// Log query execution start
 	logInfo(session.ctx, "Executing Neo4j read query", LogFields{
@@ -402,16 +422,21 @@ func executeNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query str
 	})
 
 	// Add span attributes for detailed tracing
-	addSpanAttribute(session.ctx, "db.statement", truncateQuery(query, 200))
-	addSpanAttribute(session.ctx, "db.operation", "read")
-	addSpanAttribute(session.ctx, "db.name", session.database)
-	addSpanAttribute(session.ctx, "db.type", "neo4j")
-	addSpanAttribute(session.ctx, "neo4j.query.hash", queryHash)
-	addSpanAttribute(session.ctx, "neo4j.param.count", len(params))
+	addSpanAttribute(span, "db.statement", truncateQuery(query, 200))
+	addSpanAttribute(span, "db.operation", "read")
+	addSpanAttribute(span, "db.name", session.database)
+	addSpanAttribute(span, "db.type", "neo4j")
+	addSpanAttribute(span, "neo4j.query.hash", queryHash)
+	addSpanAttribute(span, "neo4j.param.count", len(params))
 
+	if err := allowNeo4jCall(session.ctx); err != nil {
+		recordSpanError(span, err)
+		return Neo4jResult{}, err
+	}
 	result, err := session.session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		return executeNeo4jQueryInTx(ctx, session, tx, query, params)
 	})
+	recordNeo4jCall(session.ctx, err)
 
 	if err != nil {
 		// Log and record query failure
@@ -432,6 +457,7 @@ func executeNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query str
 				"error_type": extractErrorType(err),
 			})
 		}
+		recordSpanError(span, err)
 		return Neo4jResult{}, wrapNeo4jError(err, "failed to execute read query")
 	}
 
@@ -471,14 +497,98 @@ func executeNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query str
 	return neoResult, nil
 }
 
+// streamNeo4jReadQuery runs a read query in an auto-commit transaction and hands each record to onRecord as it arrives (Orchestrator)
+func streamNeo4jReadQuery(ctx context.Context, session *Neo4jSession, query string, params map[string]interface{}, onRecord func(map[string]interface{}) error) (int, error) {
+	validateNeo4jSessionNotNil(session)
+	validateQueryNotEmpty(query)
+
+	// Create span for streamed read query
+	span := createNeo4jSpan(ctx, "query.stream", query)
+	defer finishSpan(span)
+	ctx = contextWithSpan(ctx, span)
+
+	timer := startPerformanceTimer(session.ctx, "neo4j_stream_query")
+	defer func() {
+		duration := stopPerformanceTimer(timer)
+		session.totalDuration += duration
+		session.queryCount++
+	}()
+
+	queryHash := cachedQueryHash(query)
+
+	logInfo(session.ctx, "Streaming Neo4j read query", LogFields{
+		"component":     "neo4j_client",
+		"operation":     "stream_read_query",
+		"database":      session.database,
+		"query_hash":    queryHash,
+		"query_preview": truncateQuery(query, 100),
+		"param_count":   len(params),
+		"params":        sanitizeParams(params),
+	})
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+
+	if err := allowNeo4jCall(session.ctx); err != nil {
+		recordSpanError(span, err)
+		return 0, err
+	}
+
+	// Auto-commit transactions are not retried, so records already handed out are never replayed
+	result, err := session.session.Run(ctx, query, params)
+	if err != nil {
+		recordNeo4jCall(session.ctx, err)
+		recordSpanError(span, err)
+		return 0, wrapNeo4jError(err, "failed to run streamed query")
+	}
+
+	recordCount := 0
+	for result.Next(ctx) {
+		if err := onRecord(convertNeo4jRecord(result.Record())); err != nil {
+			recordNeo4jCall(session.ctx, nil)
+			return recordCount, err
+		}
+		recordCount++
+	}
+
+	err = result.Err()
+	recordNeo4jCall(session.ctx, err)
+	if err != nil {
+		logError(session.ctx, "Failed while streaming Neo4j read query", LogFields{
+			"component":    "neo4j_client",
+			"operation":    "stream_read_query",
+			"error":        err.Error(),
+			"database":     session.database,
+			"query_hash":   queryHash,
+			"record_count": recordCount,
+		})
+		if session.metrics != nil {
+			session.metrics.recordErrorCount("neo4j_client", "stream_query_failed")
+		}
+		recordSpanError(span, err)
+		return recordCount, wrapNeo4jError(err, "failed to stream query results")
+	}
+
+	if session.metrics != nil {
+		session.metrics.recordCounter("neo4j_records_returned_total", recordCount, MetricLabels{
+			"database":   session.database,
+			"query_type": "stream",
+		})
+	}
+
+	return recordCount, nil
+}
+
 // executeNeo4jWrite executes a write query (Orchestrator)
 func executeNeo4jWrite(ctx context.Context, session *Neo4jSession, query string, params map[string]interface{}) (Neo4jResult, error) {
 	validateNeo4jSessionNotNil(session)
 	validateQueryNotEmpty(query)
 
 	// Create span for write query
-	span := createNeo4jSpan(session.ctx, "query.write", query)
+	span := createNeo4jSpan(ctx, "query.write", query)
 	defer finishSpan(span)
+	ctx = contextWithSpan(ctx, span)
 
 	// Start performance timer
 	timer := startPerformanceTimer(session.ctx, "neo4j_write_query")
@@ -490,7 +600,7 @@ func executeNeo4jWrite(ctx context.Context, session *Neo4jSession, query string,
 
 	// Sanitize parameters for logging
 	sanitizedParams := sanitizeParams(params)
-	queryHash := generateQueryHash(query)
+	queryHash := cachedQueryHash(query)
 
 	// Log query execution start
 	logInfo(session.ctx, "Executing Neo4j write query", LogFields{
@@ -505,16 +615,21 @@ func executeNeo4jWrite(ctx context.Context, session *Neo4jSession, query string,
 	})
 
 	// Add span attributes for detailed tracing
-	addSpanAttribute(session.ctx, "db.statement", truncateQuery(query, 200))
-	addSpanAttribute(session.ctx, "db.operation", "write")
-	addSpanAttribute(session.ctx, "db.name", session.database)
-	addSpanAttribute(session.ctx, "db.type", "neo4j")
-	addSpanAttribute(session.ctx, "neo4j.query.hash", queryHash)
-	addSpanAttribute(session.ctx, "neo4j.param.count", len(params))
+	addSpanAttribute(span, "db.statement", truncateQuery(query, 200))
+	addSpanAttribute(span, "db.operation", "write")
+	addSpanAttribute(span, "db.name", session.database)
+	addSpanAttribute(span, "db.type", "neo4j")
+	addSpanAttribute(span, "neo4j.query.hash", queryHash)
+	addSpanAttribute(span, "neo4j.param.count", len(params))
 
+	if err := allowNeo4jCall(session.ctx); err != nil {
+		recordSpanError(span, err)
+		return Neo4jResult{}, err
+	}
 	result, err := session.session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		return executeNeo4jQueryInTx(ctx, session, tx, query, params)
 	})
+	recordNeo4jCall(session.ctx, err)
 
 	if err != nil {
 		// Log and record query failure
@@ -535,6 +650,7 @@ func executeNeo4jWrite(ctx context.Context, session *Neo4jSession, query string,
 				"error_type": extractErrorType(err),
 			})
 		}
+		recordSpanError(span, err)
 		return Neo4jResult{}, wrapNeo4jError(err, "failed to execute write query")
 	}
 
@@ -579,6 +695,54 @@ func executeNeo4jWrite(ctx context.Context, session *Neo4jSession, query string,
 	return neoResult, nil
 }
 
+// executeNeo4jAutoCommit runs a query outside an explicit transaction, as Memgraph requires for schema
+// and info queries (Orchestrator)
+func executeNeo4jAutoCommit(ctx context.Context, session *Neo4jSession, query string) (Neo4jResult, error) {
+	validateNeo4jSessionNotNil(session)
+	validateQueryNotEmpty(query)
+
+	if err := allowNeo4jCall(session.ctx); err != nil {
+		return Neo4jResult{}, err
+	}
+
+	start := time.Now()
+	result, err := session.session.Run(ctx, query, nil)
+	if err != nil {
+		recordNeo4jCall(session.ctx, err)
+		return Neo4jResult{}, wrapNeo4jError(err, "failed to run auto-commit query")
+	}
+
+	records, err := result.Collect(ctx)
+	if err != nil {
+		recordNeo4jCall(session.ctx, err)
+		return Neo4jResult{}, wrapNeo4jError(err, "failed to collect results")
+	}
+	summary, err := result.Consume(ctx)
+	recordNeo4jCall(session.ctx, err)
+	if err != nil {
+		return Neo4jResult{}, wrapNeo4jError(err, "failed to consume result summary")
+	}
+
+	session.queryCount++
+	session.totalDuration += time.Since(start)
+	return Neo4jResult{
+		Records:       lo.Map(records, func(record *neo4j.Record, _ int) map[string]interface{} { return convertNeo4jRecord(record) }),
+		Summary:       summary,
+		ExecutionTime: time.Since(start),
+		RecordCount:   len(records),
+		QueryHash:     cachedQueryHash(query),
+	}, nil
+}
+
+// executeNeo4jSchemaWrite runs an index or constraint statement; Memgraph refuses schema changes inside
+// explicit transactions, so they are sent as auto-commit queries there (Orchestrator)
+func executeNeo4jSchemaWrite(ctx context.Context, session *Neo4jSession, query string) (Neo4jResult, error) {
+	if session.version.isMemgraph() {
+		return executeNeo4jAutoCommit(ctx, session, query)
+	}
+	return executeNeo4jWrite(ctx, session, query, nil)
+}
+
 // executeNeo4jQueryInTx executes a single query within a transaction (Pure Core)
 func executeNeo4jQueryInTx(ctx context.Context, session *Neo4jSession, tx neo4j.ManagedTransaction, query string, params map[string]interface{}) (Neo4jResult, error) {
 	validateTransactionNotNil(tx)
@@ -592,7 +756,7 @@ func executeNeo4jQueryInTx(ctx context.Context, session *Neo4jSession, tx neo4j.
 	}
 
 	// Create span for transaction execution
-	txSpan := createNeo4jSpan(session.ctx, "transaction.execute", query)
+	txSpan := createNeo4jSpan(ctx, "transaction.execute", query)
 	defer finishSpan(txSpan)
 
 	// Log transaction start
@@ -670,7 +834,11 @@ func executeNeo4jQueryInTx(ctx context.Context, session *Neo4jSession, tx neo4j.
 	// Calculate total execution time
 	totalExecutionTime := time.Since(executionStart)
 	recordCount := len(mappedRecords)
-	queryHash := generateQueryHash(query)
+	queryHash := cachedQueryHash(query)
+
+	if totalExecutionTime > slowQueryThreshold {
+		recordSlowQuery(session, query, totalExecutionTime, recordCount)
+	}
 
 	// Log successful transaction execution with detailed metrics
 	logDebug(session.ctx, "Neo4j transaction executed successfully", LogFields{
@@ -739,20 +907,32 @@ func buildNeo4jHealthQuery() string {
 	return "RETURN 1 as health_check"
 }
 
-// buildNeo4jConstraintQuery builds a query to create constraints (Pure Core)
-func buildNeo4jConstraintQuery(label string, property string) string {
+// buildNeo4jConstraintQuery builds a query to create constraints; the FOR ... REQUIRE form is
+// understood by every supported Neo4j version, from 4.4 on. Memgraph only knows ON ... ASSERT and
+// treats an existing constraint as a no-op (Pure Core)
+func buildNeo4jConstraintQuery(version Neo4jServerVersion, label string, property string) string {
 	validateLabelNotEmpty(label)
 	validatePropertyNotEmpty(property)
 
-	return fmt.Sprintf("CREATE CONSTRAINT IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE", label, property)
+	if version.isMemgraph() {
+		return fmt.Sprintf("CREATE CONSTRAINT ON (n:%s) ASSERT n.%s IS UNIQUE",
+			quoteCypherIdentifier("label", label), quoteCypherIdentifier("property", property))
+	}
+	return fmt.Sprintf("CREATE CONSTRAINT IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE",
+		quoteCypherIdentifier("label", label), quoteCypherIdentifier("property", property))
 }
 
 // buildNeo4jIndexQuery builds a query to create an index (Pure Core)
-func buildNeo4jIndexQuery(label string, property string) string {
+func buildNeo4jIndexQuery(version Neo4jServerVersion, label string, property string) string {
 	validateLabelNotEmpty(label)
 	validatePropertyNotEmpty(property)
 
-	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS FOR (n:%s) ON (n.%s)", label, property)
+	if version.isMemgraph() {
+		return fmt.Sprintf("CREATE INDEX ON :%s(%s)",
+			quoteCypherIdentifier("label", label), quoteCypherIdentifier("property", property))
+	}
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS FOR (n:%s) ON (n.%s)",
+		quoteCypherIdentifier("label", label), quoteCypherIdentifier("property", property))
 }
 
 // checkNeo4jHealth checks database health (Orchestrator)
@@ -760,7 +940,7 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 	validateNeo4jConnectionNotNil(conn)
 
 	// Create span for health check
-	span := createHealthCheckSpan(conn.ctx, "neo4j")
+	span := createHealthCheckSpan(ctx, "neo4j")
 	defer finishSpan(span)
 
 	// Start performance timer for health check
@@ -793,6 +973,9 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 				"status":   "failed",
 				"phase":    "session_creation",
 			})
+			conn.metrics.recordGauge("neo4j_database_available", 0, MetricLabels{
+				"database": conn.database,
+			})
 		}
 		return wrapNeo4jError(err, "failed to create session for health check")
 	}
@@ -808,13 +991,13 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 	if err != nil {
 		// Log health check query failure
 		errorDetails := map[string]interface{}{
-			"error":           err.Error(),
-			"database":        conn.database,
-			"check_phase":     "query_execution",
-			"health_status":   "unhealthy",
-			"query":           query,
-			"execution_time":  result.ExecutionTime.String(),
-			"pool_metrics":    poolMetrics,
+			"error":          err.Error(),
+			"database":       conn.database,
+			"check_phase":    "query_execution",
+			"health_status":  "unhealthy",
+			"query":          query,
+			"execution_time": result.ExecutionTime.String(),
+			"pool_metrics":   poolMetrics,
 		}
 		logHealthCheckResult(conn.ctx, "neo4j", false, errorDetails)
 		if conn.metrics != nil {
@@ -824,6 +1007,9 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 				"status":   "failed",
 				"phase":    "query_execution",
 			})
+			conn.metrics.recordGauge("neo4j_database_available", 0, MetricLabels{
+				"database": conn.database,
+			})
 		}
 		return wrapNeo4jError(err, "health check query failed")
 	}
@@ -832,13 +1018,13 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 	if len(result.Records) == 0 {
 		// Log health check validation failure
 		errorDetails := map[string]interface{}{
-			"database":        conn.database,
-			"check_phase":     "result_validation",
-			"health_status":   "unhealthy",
+			"database":         conn.database,
+			"check_phase":      "result_validation",
+			"health_status":    "unhealthy",
 			"expected_records": 1,
 			"actual_records":   0,
-			"execution_time":  result.ExecutionTime.String(),
-			"pool_metrics":    poolMetrics,
+			"execution_time":   result.ExecutionTime.String(),
+			"pool_metrics":     poolMetrics,
 		}
 		logHealthCheckResult(conn.ctx, "neo4j", false, errorDetails)
 		if conn.metrics != nil {
@@ -847,12 +1033,11 @@ func checkNeo4jHealth(ctx context.Context, conn *Neo4jConnection) error {
 				"status":   "failed",
 				"phase":    "result_validation",
 			})
+			conn.metrics.recordGauge("neo4j_database_available", 0, MetricLabels{
+				"database": conn.database,
+			})
 		}
-		return Neo4jError{
-			Code:    "HEALTH_CHECK_FAILED",
-			Message: "Health check returned no results",
-			Details: "Expected at least one record from health check query",
-		}
+		return newNeo4jError(ErrorCategoryExternal, "HEALTH_CHECK_FAILED", "Health check returned no results", "Expected at least one record from health check query", true)
 	}
 
 	// Health check passed - log success with metrics
@@ -893,7 +1078,7 @@ func createNeo4jConstraints(ctx context.Context, conn *Neo4jConnection) error {
 	validateNeo4jConnectionNotNil(conn)
 
 	// Create span for constraint creation
-	span := createNeo4jSpan(conn.ctx, "schema.create_constraints", "CREATE CONSTRAINTS")
+	span := createNeo4jSpan(ctx, "schema.create_constraints", "CREATE CONSTRAINTS")
 	defer finishSpan(span)
 
 	// Start performance timer
@@ -929,7 +1114,14 @@ func createNeo4jConstraints(ctx context.Context, conn *Neo4jConnection) error {
 		{"Organization", "login"},
 		{"Repository", "full_name"},
 		{"User", "login"},
-		{"Team", "slug"},
+		{"Team", "key"},
+		{"Scan", "id"},
+		{"CodeownersBlob", "sha"},
+		{"OwnershipEvent", "id"},
+		{"OrphanAnalysis", "id"},
+		{"OrphanFinding", "id"},
+		{"ScheduledScanRun", "id"},
+		{"StateEntry", "key"},
 	}
 
 	// Create batch logger for constraint creation
@@ -949,8 +1141,8 @@ func createNeo4jConstraints(ctx context.Context, conn *Neo4jConnection) error {
 			"total_constraints": len(constraints),
 		})
 
-		query := buildNeo4jConstraintQuery(constraint.label, constraint.property)
-		result, err := executeNeo4jWrite(ctx, session, query, nil)
+		query := buildNeo4jConstraintQuery(conn.version, constraint.label, constraint.property)
+		result, err := executeNeo4jSchemaWrite(ctx, session, query)
 
 		if err != nil {
 			// Log constraint creation failure
@@ -1024,7 +1216,7 @@ func createNeo4jIndexes(ctx context.Context, conn *Neo4jConnection) error {
 	validateNeo4jConnectionNotNil(conn)
 
 	// Create span for index creation
-	span := createNeo4jSpan(conn.ctx, "schema.create_indexes", "CREATE INDEXES")
+	span := createNeo4jSpan(ctx, "schema.create_indexes", "CREATE INDEXES")
 	defer finishSpan(span)
 
 	// Start performance timer
@@ -1061,6 +1253,7 @@ func createNeo4jIndexes(ctx context.Context, conn *Neo4jConnection) error {
 		{"Repository", "updated_at"},
 		{"User", "name"},
 		{"Team", "name"},
+		{"Team", "slug"},
 	}
 
 	// Create batch logger for index creation
@@ -1080,8 +1273,8 @@ func createNeo4jIndexes(ctx context.Context, conn *Neo4jConnection) error {
 			"total_indexes": len(indexes),
 		})
 
-		query := buildNeo4jIndexQuery(index.label, index.property)
-		result, err := executeNeo4jWrite(ctx, session, query, nil)
+		query := buildNeo4jIndexQuery(conn.version, index.label, index.property)
+		result, err := executeNeo4jSchemaWrite(ctx, session, query)
 
 		if err != nil {
 			// Log index creation failure
@@ -1150,41 +1343,125 @@ func createNeo4jIndexes(ctx context.Context, conn *Neo4jConnection) error {
 	return nil
 }
 
+// buildTeamSlugConstraintsQuery builds a query returning the names of uniqueness constraints on Team.slug,
+// left by versions that keyed teams by slug alone (Pure Core)
+func buildTeamSlugConstraintsQuery() string {
+	return `
+		SHOW CONSTRAINTS YIELD name, labelsOrTypes, properties
+		WHERE labelsOrTypes = ['Team'] AND properties = ['slug']
+		RETURN name
+	`
+}
+
+// buildBackfillTeamKeysQuery builds a query keying the teams stored before teams were keyed by organization
+// and slug. A team node shared by several organizations is left unkeyed; each organization's next scan
+// creates its own. (Pure Core)
+func buildBackfillTeamKeysQuery() string {
+	return `
+		MATCH (org:Organization)-[:HAS_TEAM]->(team:Team)
+		WHERE team.key IS NULL
+		WITH team, collect(DISTINCT org.login) AS organizations
+		WHERE size(organizations) = 1
+		SET team.key = organizations[0] + '/' + team.slug,
+			team.organization = organizations[0]
+		RETURN count(team) AS migrated
+	`
+}
+
+// migrateTeamKeys drops the Team.slug uniqueness constraint, which prevents two organizations from having
+// a team with the same slug, and keys the existing teams by organization and slug (Orchestrator)
+func migrateTeamKeys(ctx context.Context, conn *Neo4jConnection) error {
+	validateNeo4jConnectionNotNil(conn)
+
+	session, err := createNeo4jSession(ctx, conn)
+	if err != nil {
+		return wrapNeo4jError(err, "failed to create session for team key migration")
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	var drops []string
+	if conn.version.isMemgraph() {
+		drops = append(drops, "DROP CONSTRAINT ON (n:Team) ASSERT n.slug IS UNIQUE")
+	} else {
+		existing, err := executeNeo4jSchemaWrite(ctx, session, buildTeamSlugConstraintsQuery())
+		if err != nil {
+			return wrapNeo4jError(err, "failed to list Team.slug constraints")
+		}
+		for _, record := range existing.Records {
+			name := getStringFromMap(record, "name")
+			if err := validateCypherIdentifier("constraint", name); err != nil {
+				return fmt.Errorf("failed to drop Team.slug constraint: %w", err)
+			}
+			drops = append(drops, fmt.Sprintf("DROP CONSTRAINT %s IF EXISTS", quoteCypherIdentifier("constraint", name)))
+		}
+	}
+	for _, query := range drops {
+		if _, err := executeNeo4jSchemaWrite(ctx, session, query); err != nil {
+			// Memgraph fails dropping a constraint that does not exist
+			logDebug(conn.ctx, "Team.slug constraint not dropped", LogFields{
+				"component": "neo4j_client",
+				"operation": "migrate_team_keys",
+				"database":  conn.database,
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	result, err := executeNeo4jWrite(ctx, session, buildBackfillTeamKeysQuery(), nil)
+	if err != nil {
+		return wrapNeo4jError(err, "failed to key existing teams by organization")
+	}
+	if len(result.Records) > 0 && getIntFromMap(result.Records[0], "migrated") > 0 {
+		logInfo(conn.ctx, "Keyed existing teams by organization and slug", LogFields{
+			"component": "neo4j_client",
+			"operation": "migrate_team_keys",
+			"database":  conn.database,
+			"migrated":  getIntFromMap(result.Records[0], "migrated"),
+		})
+	}
+	return nil
+}
+
 // wrapNeo4jError wraps an error with Neo4j-specific context (Pure Core)
 func wrapNeo4jError(err error, message string) Neo4jError {
 	if err == nil {
-		return Neo4jError{
-			Code:    "INTERNAL_ERROR",
-			Message: message,
-			Details: "nil error wrapped",
-		}
+		return newNeo4jError(ErrorCategoryInternal, "INTERNAL_ERROR", message, "nil error wrapped", false)
 	}
 
 	// Enhanced error classification for better observability
 	errorType := extractErrorType(err)
-	code := "DATABASE_ERROR"
+	code, category, retryable := "DATABASE_ERROR", ErrorCategoryInternal, false
 
-	// Map error types to more specific codes
+	// Map error types to more specific codes; only an unreachable or slow database is worth retrying
 	switch errorType {
 	case "timeout":
-		code = "TIMEOUT_ERROR"
+		code, category, retryable = "TIMEOUT_ERROR", ErrorCategoryExternal, true
 	case "connection":
-		code = "CONNECTION_ERROR"
+		code, category, retryable = "CONNECTION_ERROR", ErrorCategoryExternal, true
 	case "syntax":
 		code = "SYNTAX_ERROR"
 	case "constraint":
 		code = "CONSTRAINT_ERROR"
 	case "authentication":
-		code = "AUTH_ERROR"
+		code, category = "AUTH_ERROR", ErrorCategoryExternal
 	case "permission":
-		code = "PERMISSION_ERROR"
+		code, category = "PERMISSION_ERROR", ErrorCategoryExternal
 	}
 
-	return Neo4jError{
-		Code:    code,
-		Message: message,
-		Details: fmt.Sprintf("%s (type: %s)", err.Error(), errorType),
+	wrapped := newNeo4jError(category, code, message, fmt.Sprintf("%s (type: %s)", err.Error(), errorType), retryable)
+	wrapped.Cause = err
+	return wrapped
+}
+
+// newNeo4jError creates a database error of a category; unreachable or timed out databases are
+// external failures that are safe to retry (Pure Core)
+func newNeo4jError(category ErrorCategory, code, message, details string, retryable bool) Neo4jError {
+	err := newAppError(category, ErrorTypeDatabase, code, message, details)
+	err.Retryable = retryable
+	if category == ErrorCategoryExternal {
+		err.HTTPStatus = http.StatusServiceUnavailable
 	}
+	return Neo4jError{AppError: err}
 }
 
 // Validation helper functions (Pure Core)
@@ -1522,8 +1799,8 @@ func monitorNeo4jPerformance(ctx *gofr.Context, session *Neo4jSession, result Ne
 		})
 	}
 
-	// Alert on slow queries (over 5 seconds)
-	if result.ExecutionTime > 5*time.Second {
+	// Alert on slow queries
+	if result.ExecutionTime > slowQueryThreshold {
 		logWarn(session.ctx, "Slow Neo4j query detected", LogFields{
 			"component":      "neo4j_client",
 			"operation":      "slow_query_alert",
@@ -1532,7 +1809,7 @@ func monitorNeo4jPerformance(ctx *gofr.Context, session *Neo4jSession, result Ne
 			"execution_time": result.ExecutionTime.String(),
 			"query_hash":     result.QueryHash,
 			"record_count":   result.RecordCount,
-			"threshold":      "5s",
+			"threshold":      slowQueryThreshold.String(),
 		})
 
 		if session.metrics != nil {
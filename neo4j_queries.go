@@ -7,217 +7,206 @@ import (
 	"time"
 )
 
-// buildGraphNodesQuery builds a query to fetch graph nodes (Pure Core)
-func buildGraphNodesQuery(orgName string, useTopics bool) string {
-	validateOrgNameNotEmpty(orgName)
+// GraphGroupBy selects the nodes grouping repositories in the graph: teams, topics or both
+type GraphGroupBy string
+
+// Graph grouping modes accepted by ?group_by=
+const (
+	GraphGroupByTeams  GraphGroupBy = "teams"
+	GraphGroupByTopics GraphGroupBy = "topics"
+	GraphGroupByBoth   GraphGroupBy = "both"
+)
+
+// includesTeams reports whether teams and their ownership edges are part of the graph
+func (g GraphGroupBy) includesTeams() bool {
+	return g != GraphGroupByTopics
+}
 
+// includesTopics reports whether topics and their repository edges are part of the graph
+func (g GraphGroupBy) includesTopics() bool {
+	return g == GraphGroupByTopics || g == GraphGroupByBoth
+}
+
+// graphGroupByTopics returns the grouping selected by the legacy useTopics flag (Pure Core)
+func graphGroupByTopics(useTopics bool) GraphGroupBy {
 	if useTopics {
-		return `
-			MATCH (org:Organization {login: $orgName})
-			OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
-			OPTIONAL MATCH (org)-[:HAS_TOPIC]->(topic:Topic)
-			OPTIONAL MATCH (repo)-[:HAS_CODEOWNER]->(user:User)
-			WITH org,
-				 COLLECT(DISTINCT {
-					 id: repo.id,
+		return GraphGroupByTopics
+	}
+	return GraphGroupByTeams
+}
+
+// parseGraphGroupBy parses the group_by query parameter, falling back to the useTopics flag when
+// it is not set (Pure Core)
+func parseGraphGroupBy(value string, useTopics bool) (GraphGroupBy, bool) {
+	switch groupBy := GraphGroupBy(strings.ToLower(strings.TrimSpace(value))); groupBy {
+	case "":
+		return graphGroupByTopics(useTopics), true
+	case GraphGroupByTeams, GraphGroupByTopics, GraphGroupByBoth:
+		return groupBy, true
+	default:
+		return "", false
+	}
+}
+
+// Cypher map projections of graph elements, shared by the full and paged graph queries; %[1]s is the
+// element variable and, for edges, %[1]s and %[2]s are the source and target variables
+const (
+	graphRepositoryNodeProjection = `{
+					 id: %[1]s.id,
 					 type: 'repository',
-					 label: repo.name,
+					 label: %[1]s.name,
+					 data: {
+						 name: %[1]s.name,
+						 fullName: %[1]s.full_name,
+						 description: %[1]s.description,
+						 private: %[1]s.private,
+						 url: %[1]s.url,
+						 createdAt: %[1]s.created_at,
+						 updatedAt: %[1]s.updated_at
+					 }
+				 }`
+	graphTeamNodeProjection = `{
+					 id: %[1]s.id,
+					 type: 'team',
+					 label: %[1]s.name,
 					 data: {
-						 name: repo.name,
-						 fullName: repo.full_name,
-						 description: repo.description,
-						 private: repo.private,
-						 url: repo.url,
-						 createdAt: repo.created_at,
-						 updatedAt: repo.updated_at
+						 name: %[1]s.name,
+						 slug: %[1]s.slug,
+						 description: %[1]s.description,
+						 url: %[1]s.url,
+						 secret: coalesce(%[1]s.privacy, '') = 'secret'
 					 }
-				 }) AS repos,
-				 COLLECT(DISTINCT {
-					 id: topic.name,
+				 }`
+	graphTopicNodeProjection = `{
+					 id: %[1]s.name,
 					 type: 'topic',
-					 label: topic.name,
+					 label: %[1]s.name,
 					 data: {
-						 name: topic.name,
-						 count: topic.count
+						 name: %[1]s.name,
+						 count: %[1]s.count
 					 }
-				 }) AS topics,
-				 COLLECT(DISTINCT {
-					 id: user.id,
+				 }`
+	graphUserNodeProjection = `{
+					 id: %[1]s.id,
 					 type: 'user',
-					 label: user.login,
+					 label: %[1]s.login,
 					 data: {
-						 login: user.login,
-						 name: user.name,
-						 email: user.email,
-						 url: user.url
+						 login: %[1]s.login,
+						 name: %[1]s.name,
+						 email: %[1]s.email,
+						 url: %[1]s.url,
+						 bot: coalesce(%[1]s.bot, toLower(coalesce(%[1]s.login, '')) ENDS WITH '[bot]')
 					 }
-				 }) AS users
-			RETURN {
-				id: org.id,
+				 }`
+	graphOrganizationNodeProjection = `{
+				id: %[1]s.id,
 				type: 'organization',
-				label: org.name,
+				label: %[1]s.name,
 				data: {
-					login: org.login,
-					name: org.name,
-					description: org.description,
-					email: org.email,
-					url: org.url,
-					createdAt: org.created_at,
-					updatedAt: org.updated_at
+					login: %[1]s.login,
+					name: %[1]s.name,
+					description: %[1]s.description,
+					email: %[1]s.email,
+					url: %[1]s.url,
+					createdAt: %[1]s.created_at,
+					updatedAt: %[1]s.updated_at
 				}
-			} AS org_node,
-			repos,
-			[] AS teams,
-			topics,
-			users
-		`
-	} else {
-		return `
+			}`
+	graphOwnsEdgeProjection      = `{id: 'owns-' + %[1]s.id + '-' + %[2]s.id, source: %[1]s.id, target: %[2]s.id, type: 'owns', label: 'owns'}`
+	graphCodeownerEdgeProjection = `{id: 'codeowner-' + %[1]s.id + '-' + %[2]s.id, source: %[1]s.id, target: %[2]s.id, type: 'codeowner', label: 'code owner'}`
+	graphHasTeamEdgeProjection   = `{id: 'has-team-' + %[1]s.id + '-' + %[2]s.id, source: %[1]s.id, target: %[2]s.id, type: 'has_team', label: 'has team'}`
+	graphTeamOwnerEdgeProjection = `{id: 'team-owner-' + %[1]s.id + '-' + %[2]s.id, source: %[1]s.id, target: %[2]s.id, type: 'team_owner', label: 'team owner'}`
+	graphHasTopicEdgeProjection  = `{id: 'has-topic-' + %[1]s.id + '-' + %[2]s.name, source: %[1]s.id, target: %[2]s.name, type: 'has_topic', label: 'has topic'}`
+	graphRepoTopicEdgeProjection = `{id: 'repo-topic-' + %[1]s.id + '-' + %[2]s.name, source: %[1]s.id, target: %[2]s.name, type: 'repo_topic', label: 'uses topic'}`
+)
+
+// projectGraphElement fills a graph element projection with its variables (Pure Core)
+func projectGraphElement(projection string, variables ...interface{}) string {
+	return fmt.Sprintf(projection, variables...)
+}
+
+// buildGraphNodesQuery builds a query to fetch graph nodes; the teams and topics columns are empty
+// unless groupBy includes them (Pure Core)
+func buildGraphNodesQuery(orgName string, groupBy GraphGroupBy) string {
+	validateOrgNameNotEmpty(orgName)
+
+	teams := `
+			WITH org, repos, users, [] AS teams`
+	if groupBy.includesTeams() {
+		teams = `
+			OPTIONAL MATCH (org)-[has_team:HAS_TEAM]->(team:Team) WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, repos, users,
+				 COLLECT(DISTINCT CASE WHEN team IS NULL THEN NULL ELSE ` + projectGraphElement(graphTeamNodeProjection, "team") + ` END) AS teams`
+	}
+
+	topics := `
+			WITH org, repos, users, teams, [] AS topics`
+	if groupBy.includesTopics() {
+		topics = `
+			OPTIONAL MATCH (org)-[has_topic:HAS_TOPIC]->(topic:Topic) WHERE coalesce(has_topic.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, repos, users, teams,
+				 COLLECT(DISTINCT CASE WHEN topic IS NULL THEN NULL ELSE ` + projectGraphElement(graphTopicNodeProjection, "topic") + ` END) AS topics`
+	}
+
+	return `
 			MATCH (org:Organization {login: $orgName})
-			OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
-			OPTIONAL MATCH (org)-[:HAS_TEAM]->(team:Team)
-			OPTIONAL MATCH (repo)-[:HAS_CODEOWNER]->(user:User)
-			OPTIONAL MATCH (repo)-[:HAS_TEAM_OWNER]->(team)
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			OPTIONAL MATCH (repo)-[codeowner:HAS_CODEOWNER]->(owner_user:User) WHERE coalesce(codeowner.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, repo, ` + canonicalUserExpression("owner_user") + ` AS user
 			WITH org,
-				 COLLECT(DISTINCT {
-					 id: repo.id,
-					 type: 'repository',
-					 label: repo.name,
-					 data: {
-						 name: repo.name,
-						 fullName: repo.full_name,
-						 description: repo.description,
-						 private: repo.private,
-						 url: repo.url,
-						 createdAt: repo.created_at,
-						 updatedAt: repo.updated_at
-					 }
-				 }) AS repos,
-				 COLLECT(DISTINCT {
-					 id: team.id,
-					 type: 'team',
-					 label: team.name,
-					 data: {
-						 name: team.name,
-						 slug: team.slug,
-						 description: team.description,
-						 url: team.url
-					 }
-				 }) AS teams,
-				 COLLECT(DISTINCT {
-					 id: user.id,
-					 type: 'user',
-					 label: user.login,
-					 data: {
-						 login: user.login,
-						 name: user.name,
-						 email: user.email,
-						 url: user.url
-					 }
-				 }) AS users
-			RETURN {
-				id: org.id,
-				type: 'organization',
-				label: org.name,
-				data: {
-					login: org.login,
-					name: org.name,
-					description: org.description,
-					email: org.email,
-					url: org.url,
-					createdAt: org.created_at,
-					updatedAt: org.updated_at
-				}
-			} AS org_node,
+				 COLLECT(DISTINCT CASE WHEN repo IS NULL THEN NULL ELSE ` + projectGraphElement(graphRepositoryNodeProjection, "repo") + ` END) AS repos,
+				 COLLECT(DISTINCT CASE WHEN user IS NULL THEN NULL ELSE ` + projectGraphElement(graphUserNodeProjection, "user") + ` END) AS users` + teams + topics + `
+			RETURN ` + projectGraphElement(graphOrganizationNodeProjection, "org") + ` AS org_node,
 			repos,
 			teams,
-			[] AS topics,
+			topics,
 			users
 		`
-	}
 }
 
-// buildGraphEdgesQuery builds a query to fetch graph edges (Pure Core)
-func buildGraphEdgesQuery(orgName string, useTopics bool) string {
+// buildGraphEdgesQuery builds a query to fetch graph edges. Team edges (has_team, team_owner) and
+// topic edges (has_topic, repo_topic) keep distinct types, so both can be returned together. (Pure Core)
+func buildGraphEdgesQuery(orgName string, groupBy GraphGroupBy) string {
 	validateOrgNameNotEmpty(orgName)
 
-	if useTopics {
-		return `
-			MATCH (org:Organization {login: $orgName})
-			OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
-			OPTIONAL MATCH (org)-[:HAS_TOPIC]->(topic:Topic)
-			OPTIONAL MATCH (repo)-[:HAS_TOPIC]->(repo_topic:Topic)
-			OPTIONAL MATCH (repo)-[:HAS_CODEOWNER]->(user:User)
-			WITH org,
-				 COLLECT(DISTINCT {
-					 id: 'owns-' + org.id + '-' + repo.id,
-					 source: org.id,
-					 target: repo.id,
-					 type: 'owns',
-					 label: 'owns'
-				 }) AS owns_edges,
-				 COLLECT(DISTINCT {
-					 id: 'has-topic-' + org.id + '-' + topic.name,
-					 source: org.id,
-					 target: topic.name,
-					 type: 'has_topic',
-					 label: 'has topic'
-				 }) AS topic_edges,
-				 COLLECT(DISTINCT {
-					 id: 'repo-topic-' + repo.id + '-' + repo_topic.name,
-					 source: repo.id,
-					 target: repo_topic.name,
-					 type: 'repo_topic',
-					 label: 'uses topic'
-				 }) AS repo_topic_edges,
-				 COLLECT(DISTINCT {
-					 id: 'codeowner-' + repo.id + '-' + user.id,
-					 source: repo.id,
-					 target: user.id,
-					 type: 'codeowner',
-					 label: 'code owner'
-				 }) AS codeowner_edges
-			RETURN owns_edges + topic_edges + repo_topic_edges + codeowner_edges AS edges
-		`
-	} else {
-		return `
+	teams := ""
+	if groupBy.includesTeams() {
+		teams = `
+			OPTIONAL MATCH (org)-[has_team:HAS_TEAM]->(team:Team) WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+			OPTIONAL MATCH (org)-[team_owns:OWNS]->(owned:Repository)-[team_owner:HAS_TEAM_OWNER]->(team)
+			WHERE coalesce(team_owns.scan_id, '') = coalesce(org.active_scan_id, '')
+				AND coalesce(team_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, edges,
+				 COLLECT(DISTINCT CASE WHEN team IS NULL THEN NULL ELSE ` + projectGraphElement(graphHasTeamEdgeProjection, "org", "team") + ` END) AS team_edges,
+				 COLLECT(DISTINCT CASE WHEN owned IS NULL THEN NULL ELSE ` + projectGraphElement(graphTeamOwnerEdgeProjection, "owned", "team") + ` END) AS team_owner_edges
+			WITH org, edges + team_edges + team_owner_edges AS edges`
+	}
+
+	topics := ""
+	if groupBy.includesTopics() {
+		topics = `
+			OPTIONAL MATCH (org)-[has_topic:HAS_TOPIC]->(topic:Topic) WHERE coalesce(has_topic.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, edges,
+				 COLLECT(DISTINCT CASE WHEN topic IS NULL THEN NULL ELSE ` + projectGraphElement(graphHasTopicEdgeProjection, "org", "topic") + ` END) AS topic_edges
+			OPTIONAL MATCH (org)-[topic_owns:OWNS]->(tagged:Repository)-[uses_topic:HAS_TOPIC]->(repo_topic:Topic)
+			WHERE coalesce(topic_owns.scan_id, '') = coalesce(org.active_scan_id, '')
+				AND coalesce(uses_topic.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, edges, topic_edges,
+				 COLLECT(DISTINCT CASE WHEN repo_topic IS NULL THEN NULL ELSE ` + projectGraphElement(graphRepoTopicEdgeProjection, "tagged", "repo_topic") + ` END) AS repo_topic_edges
+			WITH org, edges + topic_edges + repo_topic_edges AS edges`
+	}
+
+	return `
 			MATCH (org:Organization {login: $orgName})
-			OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
-			OPTIONAL MATCH (org)-[:HAS_TEAM]->(team:Team)
-			OPTIONAL MATCH (repo)-[:HAS_CODEOWNER]->(user:User)
-			OPTIONAL MATCH (repo)-[:HAS_TEAM_OWNER]->(team)
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			OPTIONAL MATCH (repo)-[codeowner:HAS_CODEOWNER]->(owner_user:User) WHERE coalesce(codeowner.scan_id, '') = coalesce(org.active_scan_id, '')
+			WITH org, repo, ` + canonicalUserExpression("owner_user") + ` AS user
 			WITH org,
-				 COLLECT(DISTINCT {
-					 id: 'owns-' + org.id + '-' + repo.id,
-					 source: org.id,
-					 target: repo.id,
-					 type: 'owns',
-					 label: 'owns'
-				 }) AS owns_edges,
-				 COLLECT(DISTINCT {
-					 id: 'has-team-' + org.id + '-' + team.id,
-					 source: org.id,
-					 target: team.id,
-					 type: 'has_team',
-					 label: 'has team'
-				 }) AS team_edges,
-				 COLLECT(DISTINCT {
-					 id: 'codeowner-' + repo.id + '-' + user.id,
-					 source: repo.id,
-					 target: user.id,
-					 type: 'codeowner',
-					 label: 'code owner'
-				 }) AS codeowner_edges,
-				 COLLECT(DISTINCT {
-					 id: 'team-owner-' + repo.id + '-' + team.id,
-					 source: repo.id,
-					 target: team.id,
-					 type: 'team_owner',
-					 label: 'team owner'
-				 }) AS team_owner_edges
-			RETURN owns_edges + team_edges + codeowner_edges + team_owner_edges AS edges
+				 COLLECT(DISTINCT CASE WHEN repo IS NULL THEN NULL ELSE ` + projectGraphElement(graphOwnsEdgeProjection, "org", "repo") + ` END) AS owns_edges,
+				 COLLECT(DISTINCT CASE WHEN user IS NULL THEN NULL ELSE ` + projectGraphElement(graphCodeownerEdgeProjection, "repo", "user") + ` END) AS codeowner_edges
+			WITH org, owns_edges + codeowner_edges AS edges` + teams + topics + `
+			RETURN edges
 		`
-	}
 }
 
 // buildStatsQuery builds a query to fetch organization statistics (Pure Core)
@@ -226,34 +215,54 @@ func buildStatsQuery(orgName string) string {
 
 	return `
 		MATCH (org:Organization {login: $orgName})
-		OPTIONAL MATCH (org)-[:OWNS]->(repo:Repository)
-		OPTIONAL MATCH (org)-[:HAS_TEAM]->(team:Team)
-		OPTIONAL MATCH (org)-[:HAS_TOPIC]->(topic:Topic)
-		OPTIONAL MATCH (repo)-[:HAS_CODEOWNER]->(user:User)
-		OPTIONAL MATCH (repo)-[:HAS_TEAM_OWNER]->(team_owner:Team)
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (org)-[has_team:HAS_TEAM]->(team:Team) WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (org)-[has_topic:HAS_TOPIC]->(topic:Topic) WHERE coalesce(has_topic.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[codeowner:HAS_CODEOWNER]->(owner_user:User) WHERE coalesce(codeowner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, team, topic, ` + canonicalUserExpression("owner_user") + ` AS user
 		WITH org,
 			 COUNT(DISTINCT repo) AS total_repos,
 			 COUNT(DISTINCT team) AS total_teams,
+			 COUNT(DISTINCT CASE WHEN coalesce(team.privacy, '') = 'secret' THEN team END) AS secret_teams,
 			 COUNT(DISTINCT topic) AS total_topics,
-			 COUNT(DISTINCT user) AS total_users,
-			 SIZE([r IN collect(DISTINCT repo) WHERE EXISTS((r)-[:HAS_CODEOWNER]->()) OR EXISTS((r)-[:HAS_TEAM_OWNER]->())]) AS repos_with_codeowners
+			 COUNT(DISTINCT CASE WHEN ` + countedOwnerCondition("user") + ` THEN user END) AS total_users,
+			 COUNT(DISTINCT CASE WHEN ` + botOwnerExpression("user") + ` THEN user END) AS total_bots,
+			 collect(DISTINCT repo) AS repos
+		WITH org, total_repos, total_teams, secret_teams, total_topics, total_users, total_bots,
+			 [r IN repos | {
+				visibility: coalesce(r.visibility, CASE WHEN r.private THEN 'private' ELSE 'public' END),
+				archived: coalesce(r.archived, false),
+				fork: coalesce(r.fork, false),
+				has_codeowners: SIZE([(r)-[o:HAS_CODEOWNER|HAS_TEAM_OWNER]->(o_owner) WHERE coalesce(o.scan_id, '') = coalesce(org.active_scan_id, '') AND ` + countedOwnerCondition("o_owner") + ` | o]) > 0
+			 }] AS segments
+		WITH org, total_repos, total_teams, secret_teams, total_topics, total_users, total_bots, segments,
+			 SIZE([s IN segments WHERE s.has_codeowners]) AS repos_with_codeowners
 		RETURN {
 			organization: org.login,
 			total_repositories: total_repos,
 			total_teams: total_teams,
+			secret_teams: secret_teams,
 			total_topics: total_topics,
 			total_users: total_users,
+			total_bots: total_bots,
+			bots_excluded: $excludeBots,
 			total_codeowners: repos_with_codeowners,
 			codeowner_coverage: CASE
 				WHEN total_repos > 0 THEN toString(round(100.0 * repos_with_codeowners / total_repos)) + '%'
 				ELSE '0%'
 			END,
-			last_scan_time: org.updated_at
+			last_scan_time: org.updated_at,
+			last_successful_scan: [(org)-[:HAS_SCAN]->(scan:Scan) WHERE scan.id = org.active_scan_id | scan.activated_at][0],
+			active_scan_id: org.active_scan_id,
+			coverage_target_percent: org.coverage_target_percent,
+			coverage_target_deadline: org.coverage_target_deadline,
+			segments: segments
 		} AS stats
 	`
 }
 
-// buildCreateOrganizationQuery builds a query to create/update an organization (Pure Core)
+// buildCreateOrganizationQuery builds a query to create/update an organization; writes that name no
+// provider keep the stored one, GitHub for organizations scanned before providers were recorded (Pure Core)
 func buildCreateOrganizationQuery() string {
 	return `
 		MERGE (org:Organization {login: $login})
@@ -262,106 +271,144 @@ func buildCreateOrganizationQuery() string {
 			org.description = $description,
 			org.email = $email,
 			org.url = $url,
+			org.provider = CASE WHEN $provider = '' THEN coalesce(org.provider, 'github') ELSE $provider END,
 			org.created_at = $created_at,
 			org.updated_at = $updated_at
 		RETURN org
 	`
 }
 
-// buildCreateRepositoryQuery builds a query to create/update a repository (Pure Core)
-func buildCreateRepositoryQuery() string {
+// buildBatchCreateRepositoriesQuery builds an UNWIND query linking repositories to a scan and staging their
+// properties on it until the scan is published (Pure Core)
+func buildBatchCreateRepositoriesQuery() string {
 	return `
-		MERGE (repo:Repository {full_name: $full_name})
-		SET repo.id = $id,
-			repo.name = $name,
-			repo.description = $description,
-			repo.private = $private,
-			repo.url = $url,
-			repo.created_at = $created_at,
-			repo.updated_at = $updated_at
-		WITH repo
-		MATCH (org:Organization {login: $org_login})
-		MERGE (org)-[:OWNS]->(repo)
-		RETURN repo
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		UNWIND $rows AS row
+		MERGE (repo:Repository {full_name: row.full_name})
+		MERGE (scan)-[staged:STAGES_PROPERTIES]->(repo)
+		SET staged.id = row.id,
+			staged.name = row.name,
+			staged.description = row.description,
+			staged.private = row.private,
+			staged.visibility = row.visibility,
+			staged.archived = row.archived,
+			staged.fork = row.fork,
+			staged.url = row.url,
+			staged.language = row.language,
+			staged.created_at = row.created_at,
+			staged.updated_at = row.updated_at
+		MERGE (org)-[:OWNS {scan_id: $scan_id}]->(repo)
 	`
 }
 
-// buildCreateRepositoryTopicRelationshipQuery builds a query to create repository-topic relationships (Pure Core)
-func buildCreateRepositoryTopicRelationshipQuery() string {
+// buildBatchCreateRepositoryTopicRelationshipsQuery builds an UNWIND query creating repository-topic relationships (Pure Core)
+func buildBatchCreateRepositoryTopicRelationshipsQuery() string {
 	return `
-		MATCH (repo:Repository {full_name: $repo_full_name})
-		MATCH (topic:Topic {name: $topic_name})
-		MERGE (repo)-[:HAS_TOPIC]->(topic)
-		RETURN repo, topic
+		UNWIND $rows AS row
+		MATCH (repo:Repository {full_name: row.repo_full_name})
+		MATCH (topic:Topic {name: row.topic_name})
+		MERGE (repo)-[:HAS_TOPIC {scan_id: $scan_id}]->(topic)
 	`
 }
 
-// buildCreateTeamQuery builds a query to create/update a team (Pure Core)
-func buildCreateTeamQuery() string {
+// buildBatchCreateTeamsQuery builds an UNWIND query linking the teams of an organization to a scan and
+// staging their properties on it until the scan is published; an empty privacy or missing members are
+// not staged, so the stored values are kept (Pure Core)
+func buildBatchCreateTeamsQuery() string {
 	return `
-		MERGE (team:Team {slug: $slug})
-		SET team.id = $id,
-			team.name = $name,
-			team.description = $description,
-			team.url = $url
-		WITH team
-		MATCH (org:Organization {login: $org_login})
-		MERGE (org)-[:HAS_TEAM]->(team)
-		RETURN team
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		UNWIND $rows AS row
+		MERGE (team:Team {key: $org_login + '/' + row.slug})
+		SET team.slug = row.slug,
+			team.organization = $org_login
+		MERGE (scan)-[staged:STAGES_PROPERTIES]->(team)
+		SET staged.id = row.id,
+			staged.name = row.name,
+			staged.description = row.description,
+			staged.url = row.url,
+			staged.privacy = CASE WHEN row.privacy = '' THEN NULL ELSE row.privacy END,
+			staged.members = row.members
+		MERGE (org)-[:HAS_TEAM {scan_id: $scan_id}]->(team)
 	`
 }
 
-// buildCreateTopicQuery builds a query to create/update a topic (Pure Core)
-func buildCreateTopicQuery() string {
+// buildBatchCreateTopicsQuery builds an UNWIND query linking topics to a scan and staging their counts on
+// it until the scan is published (Pure Core)
+func buildBatchCreateTopicsQuery() string {
 	return `
-		MERGE (topic:Topic {name: $name})
-		SET topic.count = $count
-		WITH topic
-		MATCH (org:Organization {login: $org_login})
-		MERGE (org)-[:HAS_TOPIC]->(topic)
-		RETURN topic
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		UNWIND $rows AS row
+		MERGE (topic:Topic {name: row.name})
+		MERGE (scan)-[staged:STAGES_PROPERTIES]->(topic)
+		SET staged.count = row.count
+		MERGE (org)-[:HAS_TOPIC {scan_id: $scan_id}]->(topic)
 	`
 }
 
-// buildCreateUserQuery builds a query to create/update a user (Pure Core)
-func buildCreateUserQuery() string {
+// buildBatchCreateUsersQuery builds an UNWIND query creating users and staging their properties on a scan
+// until it is published; an empty email is staged as an empty string so publishing clears the stored one (Pure Core)
+func buildBatchCreateUsersQuery() string {
 	return `
-		MERGE (user:User {login: $login})
-		SET user.id = $id,
-			user.name = $name,
-			user.email = CASE 
-				WHEN $email = '' THEN NULL
-				ELSE $email
-			END,
-			user.url = $url
-		RETURN user
+		MATCH (:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		UNWIND $rows AS row
+		MERGE (user:User {login: row.login})
+		MERGE (scan)-[staged:STAGES_PROPERTIES]->(user)
+		SET staged.id = row.id,
+			staged.name = row.name,
+			staged.email = row.email,
+			staged.url = row.url,
+			staged.bot = row.bot
 	`
 }
 
-// buildCreateCodeownerRelationshipQuery builds a query to create codeowner relationships (Pure Core)
-func buildCreateCodeownerRelationshipQuery() string {
+// buildBatchCreateCodeownerRelationshipsQuery builds an UNWIND query creating user codeowner relationships (Pure Core)
+func buildBatchCreateCodeownerRelationshipsQuery() string {
 	return `
-		MATCH (repo:Repository {full_name: $repo_full_name})
-		MATCH (owner:User {login: $owner_login})
-		MERGE (repo)-[r:HAS_CODEOWNER]->(owner)
-		SET r.pattern = $pattern,
-			r.line = $line
-		RETURN r
+		UNWIND $rows AS row
+		MATCH (repo:Repository {full_name: row.repo_full_name})
+		MATCH (owner:User {login: row.owner_login})
+		MERGE (repo)-[r:HAS_CODEOWNER {scan_id: $scan_id}]->(owner)
+		SET r.pattern = row.pattern,
+			r.line = row.line,
+			r.source = row.source,
+			r.source_line = row.source_line,
+			r.section = row.section,
+			r.optional = row.optional,
+			r.approvals = row.approvals
 	`
 }
 
-// buildCreateTeamCodeownerRelationshipQuery builds a query to create team codeowner relationships (Pure Core)
-func buildCreateTeamCodeownerRelationshipQuery() string {
+// buildBatchCreateTeamCodeownerRelationshipsQuery builds an UNWIND query creating team codeowner relationships (Pure Core)
+func buildBatchCreateTeamCodeownerRelationshipsQuery() string {
 	return `
-		MATCH (repo:Repository {full_name: $repo_full_name})
-		MATCH (team:Team {slug: $team_slug})
-		MERGE (repo)-[r:HAS_TEAM_OWNER]->(team)
-		SET r.pattern = $pattern,
-			r.line = $line
-		RETURN r
+		UNWIND $rows AS row
+		MATCH (repo:Repository {full_name: row.repo_full_name})
+		MATCH (team:Team {key: $org_login + '/' + row.team_slug})
+		MERGE (repo)-[r:HAS_TEAM_OWNER {scan_id: $scan_id}]->(team)
+		SET r.pattern = row.pattern,
+			r.line = row.line,
+			r.source = row.source,
+			r.source_line = row.source_line,
+			r.section = row.section,
+			r.optional = row.optional,
+			r.approvals = row.approvals
 	`
 }
 
+// buildBatchCreateCodeownersFilesQuery builds an UNWIND query linking repositories to content-addressed CODEOWNERS blobs;
+// the content of identical files is stored once per blob SHA (Pure Core)
+func buildBatchCreateCodeownersFilesQuery() string {
+	return `
+		UNWIND $rows AS row
+		MATCH (repo:Repository {full_name: row.repo_full_name})
+		MERGE (blob:CodeownersBlob {sha: row.sha})
+		ON CREATE SET blob.content = row.content,
+			blob.size = row.size,
+			blob.rule_count = row.rule_count
+		MERGE (repo)-[file:HAS_CODEOWNERS_FILE {scan_id: $scan_id}]->(blob)
+		SET file.path = row.path
+	`
+}
 
 // storeOrganization stores organization data in Neo4j (Orchestrator)
 func storeOrganization(ctx context.Context, session *Neo4jSession, org GitHubOrganization) error {
@@ -375,6 +422,7 @@ func storeOrganization(ctx context.Context, session *Neo4jSession, org GitHubOrg
 		"description": org.Description,
 		"email":       org.Email,
 		"url":         org.URL,
+		"provider":    org.Provider,
 		"created_at":  org.CreatedAt.Format(time.RFC3339),
 		"updated_at":  org.UpdatedAt.Format(time.RFC3339),
 	}
@@ -387,338 +435,62 @@ func storeOrganization(ctx context.Context, session *Neo4jSession, org GitHubOrg
 	return nil
 }
 
-// storeRepository stores repository data in Neo4j (Orchestrator)
-func storeRepository(ctx context.Context, session *Neo4jSession, repo GitHubRepository, orgLogin string) error {
-	validateNeo4jSessionNotNil(session)
-	validateOrgLoginNotEmpty(orgLogin)
-
-	query := buildCreateRepositoryQuery()
-	params := map[string]interface{}{
-		"id":          repo.ID,
-		"name":        repo.Name,
-		"full_name":   repo.FullName,
-		"description": repo.Description,
-		"private":     repo.Private,
-		"url":         repo.URL,
-		"created_at":  repo.CreatedAt.Format(time.RFC3339),
-		"updated_at":  repo.UpdatedAt.Format(time.RFC3339),
-		"org_login":   orgLogin,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store repository: %w", err)
-	}
-
-	// Create relationships between repository and its topics
-	for _, topic := range repo.Topics {
-		if err := storeRepositoryTopicRelationship(ctx, session, repo.FullName, topic); err != nil {
-			return fmt.Errorf("failed to store repository-topic relationship: %w", err)
-		}
-	}
-
-	return nil
-}
-
-// storeRepositoryTopicRelationship stores a relationship between a repository and a topic (Orchestrator)
-func storeRepositoryTopicRelationship(ctx context.Context, session *Neo4jSession, repoFullName, topicName string) error {
-	validateNeo4jSessionNotNil(session)
-	validateRepoFullNameNotEmpty(repoFullName)
-	validateTopicNameNotEmpty(topicName)
-
-	query := buildCreateRepositoryTopicRelationshipQuery()
-	params := map[string]interface{}{
-		"repo_full_name": repoFullName,
-		"topic_name":     topicName,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store repository-topic relationship: %w", err)
-	}
-
-	return nil
-}
-
-// storeTeam stores team data in Neo4j (Orchestrator)
-func storeTeam(ctx context.Context, session *Neo4jSession, team GitHubTeam, orgLogin string) error {
-	validateNeo4jSessionNotNil(session)
-	validateOrgLoginNotEmpty(orgLogin)
-
-	query := buildCreateTeamQuery()
-	params := map[string]interface{}{
-		"id":          team.ID,
-		"slug":        team.Slug,
-		"name":        team.Name,
-		"description": team.Description,
-		"url":         team.URL,
-		"org_login":   orgLogin,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store team: %w", err)
-	}
-
-	return nil
-}
-
-// storeTopic stores topic data in Neo4j (Orchestrator)
-func storeTopic(ctx context.Context, session *Neo4jSession, topic GitHubTopic, orgLogin string) error {
-	validateNeo4jSessionNotNil(session)
-	validateOrgLoginNotEmpty(orgLogin)
-
-	query := buildCreateTopicQuery()
-	params := map[string]interface{}{
-		"name":      topic.Name,
-		"count":     topic.Count,
-		"org_login": orgLogin,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store topic: %w", err)
-	}
-
-	return nil
-}
-
-// storeUser stores user data in Neo4j (Orchestrator)
-func storeUser(ctx context.Context, session *Neo4jSession, user GitHubUser) error {
-	validateNeo4jSessionNotNil(session)
-
-	query := buildCreateUserQuery()
-	params := map[string]interface{}{
-		"id":    user.ID,
-		"login": user.Login,
-		"name":  user.Name,
-		"email": user.Email,
-		"url":   user.URL,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store user: %w", err)
-	}
-
-	return nil
-}
-
-// storeCodeowners stores CODEOWNERS data in Neo4j (Orchestrator)
-func storeCodeowners(ctx context.Context, session *Neo4jSession, codeowners GitHubCodeowners, orgLogin string) error {
-	validateNeo4jSessionNotNil(session)
-	validateOrgLoginNotEmpty(orgLogin)
-
-	for _, rule := range codeowners.Rules {
-		for _, owner := range rule.Owners {
-			if err := storeCodeownerRule(ctx, session, codeowners.Repository, owner, rule.Pattern, rule.Line); err != nil {
-				return fmt.Errorf("failed to store codeowner rule: %w", err)
-			}
-		}
-	}
-
-	return nil
-}
-
-// storeCodeownerRule stores a single codeowner rule in Neo4j (Orchestrator)
-func storeCodeownerRule(ctx context.Context, session *Neo4jSession, repoFullName, owner, pattern string, line int) error {
-	validateNeo4jSessionNotNil(session)
-	validateRepoFullNameNotEmpty(repoFullName)
-	validateOwnerNotEmpty(owner)
-
-	if isTeamOwner(owner) {
-		return storeTeamCodeownerRule(ctx, session, repoFullName, owner, pattern, line)
-	}
-
-	return storeUserCodeownerRule(ctx, session, repoFullName, owner, pattern, line)
-}
-
-// storeUserCodeownerRule stores a user codeowner rule in Neo4j (Orchestrator)
-func storeUserCodeownerRule(ctx context.Context, session *Neo4jSession, repoFullName, userLogin, pattern string, line int) error {
-	validateNeo4jSessionNotNil(session)
-	validateRepoFullNameNotEmpty(repoFullName)
-
-	// Clean user login (remove @ prefix)
-	cleanUserLogin := strings.TrimPrefix(userLogin, "@")
-
-	// First, ensure the user exists
-	user := GitHubUser{
-		ID:    generateUserID(cleanUserLogin),
-		Login: cleanUserLogin,
-		Name:  cleanUserLogin,
-		Email: "",
-		URL:   fmt.Sprintf("https://github.com/%s", cleanUserLogin),
-	}
-
-	if err := storeUser(ctx, session, user); err != nil {
-		return fmt.Errorf("failed to store user: %w", err)
-	}
-
-	// Create the codeowner relationship
-	query := buildCreateCodeownerRelationshipQuery()
-	params := map[string]interface{}{
-		"repo_full_name": repoFullName,
-		"owner_login":    cleanUserLogin,
-		"pattern":        pattern,
-		"line":           line,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store user codeowner relationship: %w", err)
-	}
-
-	return nil
-}
-
-// storeTeamCodeownerRule stores a team codeowner rule in Neo4j (Orchestrator)
-func storeTeamCodeownerRule(ctx context.Context, session *Neo4jSession, repoFullName, teamSlug, pattern string, line int) error {
-	validateNeo4jSessionNotNil(session)
-	validateRepoFullNameNotEmpty(repoFullName)
-
-	// Clean team slug (remove @org/ prefix)
-	cleanTeamSlug := extractTeamSlug(teamSlug)
-
-	query := buildCreateTeamCodeownerRelationshipQuery()
-	params := map[string]interface{}{
-		"repo_full_name": repoFullName,
-		"team_slug":      cleanTeamSlug,
-		"pattern":        pattern,
-		"line":           line,
-	}
-
-	_, err := executeNeo4jWrite(ctx, session, query, params)
-	if err != nil {
-		return fmt.Errorf("failed to store team codeowner relationship: %w", err)
-	}
-
-	return nil
-}
-
-// convertToGraphNodes converts Neo4j records to graph nodes (Pure Core)
-func convertToGraphNodes(records []map[string]interface{}) []GraphNode {
+// convertToGraphNodes decodes the row of buildGraphNodesQuery and lays its nodes out in rows (Pure Core)
+func convertToGraphNodes(records []map[string]interface{}) ([]GraphNode, *graphConversionReport) {
+	report := newGraphConversionReport("node")
 	if len(records) == 0 {
-		return []GraphNode{}
+		return []GraphNode{}, report
 	}
 
-	record := records[0]
-	var nodes []GraphNode
-
-	nodes = append(nodes, extractOrganizationNode(record)...)
-	nodes = append(nodes, extractRepositoryNodes(record)...)
-	nodes = append(nodes, extractTeamNodes(record)...)
-	nodes = append(nodes, extractTopicNodes(record)...)
-	nodes = append(nodes, extractUserNodes(record)...)
-
-	return nodes
-}
-
-func extractOrganizationNode(record map[string]interface{}) []GraphNode {
-	orgNode, exists := record["org_node"]
-	if !exists {
-		return []GraphNode{}
+	decoded := decodeGraphNodesRecord(records[0], report)
+	nodes := []GraphNode{}
+	if decoded.Organization != nil {
+		nodes = append(nodes, decoded.Organization.toGraphNode(0, 0))
 	}
+	nodes = append(nodes, layoutGraphNodeRow(decoded.Repositories, graphNodeRowOffsets["repos"], graphNodeSpacing)...)
+	nodes = append(nodes, layoutGraphNodeRow(decoded.Teams, graphNodeRowOffsets["teams"], graphNodeSpacing)...)
+	nodes = append(nodes, layoutGraphNodeRow(decoded.Topics, graphNodeRowOffsets["topics"], graphNodeSpacing)...)
+	nodes = append(nodes, layoutGraphNodeRow(decoded.Users, graphNodeRowOffsets["users"], graphNodeSpacing)...)
 
-	orgMap, ok := orgNode.(map[string]interface{})
-	if !ok {
-		return []GraphNode{}
-	}
-
-	return []GraphNode{convertMapToGraphNode(orgMap, 0, 0)}
+	return nodes, report
 }
 
-func extractRepositoryNodes(record map[string]interface{}) []GraphNode {
-	repos, exists := record["repos"]
-	if !exists {
-		return []GraphNode{}
-	}
-
-	repoList, ok := repos.([]interface{})
-	if !ok {
+// extractOrganizationNode returns the organization node of a row's org_node column, if it is valid,
+// counting it in report either way (Pure Core)
+func extractOrganizationNode(record map[string]interface{}, report *graphConversionReport) []GraphNode {
+	org, reason := decodeGraphNodeRecord(record["org_node"])
+	if reason != "" {
+		report.drop(reason)
 		return []GraphNode{}
 	}
-
-	return convertListToGraphNodes(repoList, 200, 200)
+	report.keep()
+	return []GraphNode{org.toGraphNode(0, 0)}
 }
 
-func extractTeamNodes(record map[string]interface{}) []GraphNode {
-	teams, exists := record["teams"]
-	if !exists {
-		return []GraphNode{}
-	}
-
-	teamList, ok := teams.([]interface{})
-	if !ok {
-		return []GraphNode{}
-	}
-
-	return convertListToGraphNodes(teamList, 400, 200)
-}
-
-func extractTopicNodes(record map[string]interface{}) []GraphNode {
-	topics, exists := record["topics"]
-	if !exists {
-		return []GraphNode{}
-	}
-
-	topicList, ok := topics.([]interface{})
-	if !ok {
-		return []GraphNode{}
-	}
-
-	return convertListToGraphNodes(topicList, 500, 200)
+// convertListToGraphNodes decodes a list of node maps into one layout row, counting dropped entries
+// in report when one is given (Pure Core)
+func convertListToGraphNodes(list []interface{}, yOffset, xSpacing float64, report *graphConversionReport) []GraphNode {
+	return layoutGraphNodeRow(decodeGraphNodeValues(list, report), yOffset, xSpacing)
 }
 
-func extractUserNodes(record map[string]interface{}) []GraphNode {
-	users, exists := record["users"]
-	if !exists {
-		return []GraphNode{}
+// convertToGraphEdges decodes the edges column of buildGraphEdgesQuery (Pure Core)
+func convertToGraphEdges(records []map[string]interface{}) ([]GraphEdge, *graphConversionReport) {
+	report := newGraphConversionReport("edge")
+	if len(records) == 0 {
+		return []GraphEdge{}, report
 	}
 
-	userList, ok := users.([]interface{})
+	list, ok := records[0]["edges"].([]interface{})
 	if !ok {
-		return []GraphNode{}
+		report.missing("edges")
+		return []GraphEdge{}, report
 	}
 
-	return convertListToGraphNodes(userList, 600, 200)
-}
-
-func convertListToGraphNodes(list []interface{}, yOffset, xSpacing float64) []GraphNode {
-	var nodes []GraphNode
-
-	for i, item := range list {
-		itemMap, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		node := convertMapToGraphNode(itemMap, float64(i*int(xSpacing)), yOffset)
-		nodes = append(nodes, node)
+	edges := []GraphEdge{}
+	for _, edge := range decodeGraphEdgeValues(list, report) {
+		edges = append(edges, edge.toGraphEdge())
 	}
-
-	return nodes
-}
-
-// convertToGraphEdges converts Neo4j records to graph edges (Pure Core)
-func convertToGraphEdges(records []map[string]interface{}) []GraphEdge {
-	if len(records) == 0 {
-		return []GraphEdge{}
-	}
-
-	record := records[0]
-	var edges []GraphEdge
-
-	if edgeList, exists := record["edges"]; exists {
-		if edgesArray, ok := edgeList.([]interface{}); ok {
-			for _, edge := range edgesArray {
-				if edgeMap, ok := edge.(map[string]interface{}); ok {
-					edges = append(edges, convertMapToGraphEdge(edgeMap))
-				}
-			}
-		}
-	}
-
-	return edges
+	return edges, report
 }
 
 // convertToStatsResponse converts Neo4j record to stats response (Pure Core)
@@ -734,39 +506,20 @@ func convertToStatsResponse(record map[string]interface{}, orgName string) Stats
 	}
 
 	return StatsResponse{
-		Organization:      getStringFromMap(statsMap, "organization"),
-		TotalRepositories: getIntFromMap(statsMap, "total_repositories"),
-		TotalTeams:        getIntFromMap(statsMap, "total_teams"),
-		TotalTopics:       getIntFromMap(statsMap, "total_topics"),
-		TotalUsers:        getIntFromMap(statsMap, "total_users"),
-		TotalCodeowners:   getIntFromMap(statsMap, "total_codeowners"),
-		CodeownerCoverage: getStringFromMap(statsMap, "codeowner_coverage"),
-		LastScanTime:      getStringFromMap(statsMap, "last_scan_time"),
-	}
-}
-
-// convertMapToGraphNode converts a map to a graph node (Pure Core)
-func convertMapToGraphNode(nodeMap map[string]interface{}, x, y float64) GraphNode {
-	return GraphNode{
-		ID:    getStringFromMap(nodeMap, "id"),
-		Type:  getStringFromMap(nodeMap, "type"),
-		Label: getStringFromMap(nodeMap, "label"),
-		Data:  getMapFromMap(nodeMap, "data"),
-		Position: GraphPosition{
-			X: x,
-			Y: y,
-		},
-	}
-}
-
-// convertMapToGraphEdge converts a map to a graph edge (Pure Core)
-func convertMapToGraphEdge(edgeMap map[string]interface{}) GraphEdge {
-	return GraphEdge{
-		ID:     getStringFromMap(edgeMap, "id"),
-		Source: getStringFromMap(edgeMap, "source"),
-		Target: getStringFromMap(edgeMap, "target"),
-		Type:   getStringFromMap(edgeMap, "type"),
-		Label:  getStringFromMap(edgeMap, "label"),
+		Organization:       getStringFromMap(statsMap, "organization"),
+		TotalRepositories:  getIntFromMap(statsMap, "total_repositories"),
+		TotalTeams:         getIntFromMap(statsMap, "total_teams"),
+		SecretTeams:        getIntFromMap(statsMap, "secret_teams"),
+		TotalTopics:        getIntFromMap(statsMap, "total_topics"),
+		TotalUsers:         getIntFromMap(statsMap, "total_users"),
+		TotalBots:          getIntFromMap(statsMap, "total_bots"),
+		BotsExcluded:       getBoolFromMap(statsMap, "bots_excluded"),
+		TotalCodeowners:    getIntFromMap(statsMap, "total_codeowners"),
+		CodeownerCoverage:  getStringFromMap(statsMap, "codeowner_coverage"),
+		LastScanTime:       getStringFromMap(statsMap, "last_scan_time"),
+		LastSuccessfulScan: getStringFromMap(statsMap, "last_successful_scan"),
+		ActiveScanID:       getStringFromMap(statsMap, "active_scan_id"),
+		Breakdown:          buildStatsBreakdown(getMapSliceFromMap(statsMap, "segments")),
 	}
 }
 
@@ -793,16 +546,44 @@ func getMapFromMap(m map[string]interface{}, key string) map[string]interface{}
 	return make(map[string]interface{})
 }
 
+// getFloatFromMap safely extracts a numeric value as a float from a map (Pure Core)
+func getFloatFromMap(m map[string]interface{}, key string) float64 {
+	switch value := m[key].(type) {
+	case float64:
+		return value
+	case int64:
+		return float64(value)
+	case int:
+		return float64(value)
+	}
+	return 0
+}
+
+// getMapSliceFromMap safely extracts a slice of maps from a map (Pure Core)
+func getMapSliceFromMap(m map[string]interface{}, key string) []map[string]interface{} {
+	values, ok := m[key].([]interface{})
+	if !ok {
+		return []map[string]interface{}{}
+	}
+
+	result := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if subMap, ok := value.(map[string]interface{}); ok {
+			result = append(result, subMap)
+		}
+	}
+	return result
+}
+
 func isTeamOwner(owner string) bool {
 	return strings.Contains(owner, "/") && strings.HasPrefix(owner, "@")
 }
 
 func extractTeamSlug(teamOwner string) string {
-	// Extract team slug from @org/team format
+	// Extract team slug from @org/team format; GitLab subgroup slugs keep their nested path
 	cleaned := strings.TrimPrefix(teamOwner, "@")
-	parts := strings.Split(cleaned, "/")
-	if len(parts) >= 2 {
-		return parts[1]
+	if _, slug, found := strings.Cut(cleaned, "/"); found {
+		return slug
 	}
 	return cleaned
 }
@@ -819,9 +600,3 @@ func validateRepoFullNameNotEmpty(repoFullName string) {
 		panic("Repository full name cannot be empty")
 	}
 }
-
-func validateTopicNameNotEmpty(topicName string) {
-	if topicName == "" {
-		panic("Topic name cannot be empty")
-	}
-}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Graph database providers selected with NEO4J_PROVIDER
+const (
+	graphProviderNeo4j    = "neo4j"
+	graphProviderMemgraph = "memgraph"
+)
+
+// memgraphMinimumMajor is the oldest Memgraph major version supported
+const memgraphMinimumMajor = 2
+
+// neo4jCalendarVersionStart is the first major version of Neo4j's calendar versioned releases,
+// which continue the 5.x Cypher dialect
+const neo4jCalendarVersionStart = 2025
+
+// Neo4jServerVersion is the product and version of the Bolt server a connection talks to
+type Neo4jServerVersion struct {
+	Provider string
+	Major    int
+	Minor    int
+	Patch    int
+}
+
+// UnsupportedNeo4jVersionError is returned when connecting to a Neo4j server whose Cypher dialect is not supported
+type UnsupportedNeo4jVersionError struct {
+	Agent string
+}
+
+// Error implements the error interface for UnsupportedNeo4jVersionError
+func (e UnsupportedNeo4jVersionError) Error() string {
+	return fmt.Sprintf("graph database server %q is not supported; overseer requires Neo4j 4.4, 5.x, a calendar versioned Neo4j release (2025.01 or later) or Memgraph %d.x or later", e.Agent, memgraphMinimumMajor)
+}
+
+// String formats the version as provider/major.minor.patch
+func (v Neo4jServerVersion) String() string {
+	return fmt.Sprintf("%s/%d.%d.%d", v.Provider, v.Major, v.Minor, v.Patch)
+}
+
+// isMemgraph reports whether the server is Memgraph rather than Neo4j (Pure Core)
+func (v Neo4jServerVersion) isMemgraph() bool {
+	return v.Provider == graphProviderMemgraph
+}
+
+// atLeast reports whether the version is major.minor or newer (Pure Core)
+func (v Neo4jServerVersion) atLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// supportsCountSubqueries reports whether COUNT { } subqueries are available, added in Neo4j 5.3 (Pure Core)
+func (v Neo4jServerVersion) supportsCountSubqueries() bool {
+	return !v.isMemgraph() && v.atLeast(5, 3)
+}
+
+// supportsExistsExpressions reports whether EXISTS { } subqueries may be used outside WHERE clauses,
+// allowed since Neo4j 5.0 (Pure Core)
+func (v Neo4jServerVersion) supportsExistsExpressions() bool {
+	return !v.isMemgraph() && v.atLeast(5, 0)
+}
+
+// isSupportedNeo4jVersion reports whether the query builders have variants for a server version (Pure Core)
+func isSupportedNeo4jVersion(version Neo4jServerVersion) bool {
+	if version.isMemgraph() {
+		return version.Major >= memgraphMinimumMajor
+	}
+	return (version.Major == 4 && version.Minor >= 4) || version.Major == 5 || version.Major >= neo4jCalendarVersionStart
+}
+
+// parseServerRelease parses a release such as "5.12.0", "4.4.30-enterprise" or "2.18.1+12~abc" (Pure Core)
+func parseServerRelease(provider, release string) (Neo4jServerVersion, error) {
+	release, _, _ = strings.Cut(release, "-")
+	release, _, _ = strings.Cut(release, "+")
+	parts := strings.SplitN(strings.TrimPrefix(release, "v"), ".", 3)
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return Neo4jServerVersion{}, fmt.Errorf("unrecognised %s release %q", provider, release)
+		}
+		numbers[i] = number
+	}
+
+	return Neo4jServerVersion{Provider: provider, Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// parseNeo4jServerAgent parses a server agent such as "Neo4j/5.12.0" or "Neo4j/4.4.30-enterprise" (Pure Core)
+func parseNeo4jServerAgent(agent string) (Neo4jServerVersion, error) {
+	product, release, found := strings.Cut(agent, "/")
+	if !found || !strings.EqualFold(product, "neo4j") {
+		return Neo4jServerVersion{}, fmt.Errorf("unrecognised Neo4j server agent %q", agent)
+	}
+	return parseServerRelease(graphProviderNeo4j, release)
+}
+
+// buildMemgraphVersionQuery builds the query returning the Memgraph release (Pure Core)
+func buildMemgraphVersionQuery() string {
+	return "SHOW VERSION"
+}
+
+// detectMemgraphServerVersion reads the Memgraph release; Memgraph announces itself with a Neo4j
+// compatible agent, so the agent cannot be used (Orchestrator)
+func detectMemgraphServerVersion(ctx context.Context, driver neo4j.DriverWithContext, database string) (Neo4jServerVersion, error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, buildMemgraphVersionQuery(), nil)
+	if err != nil {
+		return Neo4jServerVersion{}, wrapNeo4jError(err, "failed to read Memgraph version")
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return Neo4jServerVersion{}, wrapNeo4jError(err, "failed to read Memgraph version")
+	}
+
+	release, _ := record.Values[0].(string)
+	version, err := parseServerRelease(graphProviderMemgraph, release)
+	if err != nil {
+		return Neo4jServerVersion{}, err
+	}
+	if !isSupportedNeo4jVersion(version) {
+		return Neo4jServerVersion{}, UnsupportedNeo4jVersionError{Agent: "Memgraph/" + release}
+	}
+	return version, nil
+}
+
+// detectNeo4jServerVersion asks the server for its version and rejects versions without query variants (Orchestrator)
+func detectNeo4jServerVersion(ctx context.Context, driver neo4j.DriverWithContext, config Neo4jConfig) (Neo4jServerVersion, error) {
+	if config.Provider == graphProviderMemgraph {
+		return detectMemgraphServerVersion(ctx, driver, config.Database)
+	}
+
+	info, err := driver.GetServerInfo(ctx)
+	if err != nil {
+		return Neo4jServerVersion{}, wrapNeo4jError(err, "failed to read Neo4j server version")
+	}
+
+	version, err := parseNeo4jServerAgent(info.Agent())
+	if err != nil {
+		return Neo4jServerVersion{}, err
+	}
+	if !isSupportedNeo4jVersion(version) {
+		return Neo4jServerVersion{}, UnsupportedNeo4jVersionError{Agent: info.Agent()}
+	}
+	return version, nil
+}
+
+// buildCountExpression builds an expression counting the matches of a pattern: a COUNT { } subquery
+// where supported, otherwise the size of a pattern comprehension (Pure Core)
+func buildCountExpression(version Neo4jServerVersion, pattern, where string) string {
+	if version.supportsCountSubqueries() {
+		return fmt.Sprintf("COUNT { MATCH %s WHERE %s }", pattern, where)
+	}
+	return fmt.Sprintf("size([%s WHERE %s | 1])", pattern, where)
+}
+
+// buildExistsExpression builds an expression testing whether a pattern matches, usable outside WHERE
+// clauses: an EXISTS { } subquery where supported, otherwise a pattern comprehension (Pure Core)
+func buildExistsExpression(version Neo4jServerVersion, pattern, where string) string {
+	if version.supportsExistsExpressions() {
+		return fmt.Sprintf("EXISTS { MATCH %s WHERE %s }", pattern, where)
+	}
+	return fmt.Sprintf("size([%s WHERE %s | 1]) > 0", pattern, where)
+}
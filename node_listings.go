@@ -0,0 +1,363 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Rows per listing page when ?limit= is not given, and the most a page may hold
+const (
+	defaultNodeListingLimit = 100
+	maxNodeListingLimit     = 500
+)
+
+// Node types listed by GET /api/repositories/{org}, /api/teams/{org} and /api/users/{org}
+const (
+	nodeListingRepositories = "repositories"
+	nodeListingTeams        = "teams"
+	nodeListingUsers        = "users"
+)
+
+// nodeListingSorts are the ?sort= keys of each listing with the Cypher expression they order by; every
+// listing sorts by name, which also breaks ties
+var nodeListingSorts = map[string]map[string]string{
+	nodeListingRepositories: {
+		"name":        "repo.full_name",
+		"coverage":    "coverage_percent",
+		"owner_count": "owner_count",
+		"updated_at":  "coalesce(repo.updated_at, '')",
+	},
+	nodeListingTeams: {
+		"name":             "team.slug",
+		"coverage":         "coverage_percent",
+		"member_count":     "member_count",
+		"repository_count": "repository_count",
+	},
+	nodeListingUsers: {
+		"name":             "user.login",
+		"coverage":         "coverage_percent",
+		"repository_count": "repository_count",
+	},
+}
+
+// NodeListingOptions are the sort order and page of a listing request
+type NodeListingOptions struct {
+	Sort   string
+	Order  string
+	Offset int
+	Limit  int
+}
+
+// NodeListingPage locates a page within a listing
+type NodeListingPage struct {
+	Offset     int  `json:"offset"`
+	Limit      int  `json:"limit"`
+	Total      int  `json:"total"`
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+// RepositoryListItem is a repository of the active scan with its ownership
+type RepositoryListItem struct {
+	FullName        string  `json:"full_name"`
+	Name            string  `json:"name"`
+	Visibility      string  `json:"visibility,omitempty"`
+	Language        string  `json:"language,omitempty"`
+	Archived        bool    `json:"archived"`
+	Fork            bool    `json:"fork"`
+	UpdatedAt       string  `json:"updated_at,omitempty"`
+	OwnerCount      int     `json:"owner_count"`
+	CoveragePercent float64 `json:"coverage_percent"`
+}
+
+// TeamListItem is a team of the active scan with the share of repositories it owns
+type TeamListItem struct {
+	Key             string  `json:"key"`
+	Slug            string  `json:"slug"`
+	Name            string  `json:"name"`
+	MemberCount     int     `json:"member_count"`
+	RepositoryCount int     `json:"repository_count"`
+	CoveragePercent float64 `json:"coverage_percent"`
+	Secret          bool    `json:"secret,omitempty"`
+}
+
+// UserListItem is a user named directly in the CODEOWNERS files of the active scan
+type UserListItem struct {
+	Login           string  `json:"login"`
+	Name            string  `json:"name,omitempty"`
+	Bot             bool    `json:"bot"`
+	RepositoryCount int     `json:"repository_count"`
+	CoveragePercent float64 `json:"coverage_percent"`
+}
+
+// NodeListingResponse is one sorted page of a listing
+type NodeListingResponse struct {
+	Organization string          `json:"organization"`
+	Sort         string          `json:"sort"`
+	Order        string          `json:"order"`
+	Items        interface{}     `json:"items"`
+	Page         NodeListingPage `json:"page"`
+}
+
+// parseNodeListingOptions validates ?sort=, ?order=, ?offset= and ?limit= against a listing (Pure Core)
+func parseNodeListingOptions(listing, sortKey, order, offset, limit string) (NodeListingOptions, error) {
+	options := NodeListingOptions{Sort: "name", Order: "asc", Limit: defaultNodeListingLimit}
+
+	if sortKey != "" {
+		if _, ok := nodeListingSorts[listing][sortKey]; !ok {
+			return NodeListingOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"sort"}}
+		}
+		options.Sort = sortKey
+	}
+
+	switch strings.ToLower(order) {
+	case "":
+	case "asc", "desc":
+		options.Order = strings.ToLower(order)
+	default:
+		return NodeListingOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"order"}}
+	}
+
+	if offset != "" {
+		parsed, err := strconv.Atoi(offset)
+		if err != nil || parsed < 0 {
+			return NodeListingOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"offset"}}
+		}
+		options.Offset = parsed
+	}
+
+	if limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 || parsed > maxNodeListingLimit {
+			return NodeListingOptions{}, &gofrhttp.ErrorInvalidParam{Params: []string{"limit"}}
+		}
+		options.Limit = parsed
+	}
+
+	return options, nil
+}
+
+// buildNodeListingOrder builds the ORDER BY of a listing, breaking ties by name (Pure Core)
+func buildNodeListingOrder(listing string, options NodeListingOptions) string {
+	sorts := nodeListingSorts[listing]
+	direction := "ASC"
+	if options.Order == "desc" {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s %s, %s", sorts[options.Sort], direction, sorts["name"])
+}
+
+// buildRepositoryListingQuery builds a query returning a sorted page of the repositories of the active
+// scan with their distinct user and team owners (Pure Core)
+func buildRepositoryListingQuery(options NodeListingOptions) string {
+	return cachedQuery(func() string {
+		return `
+			MATCH (org:Organization {login: $orgName})
+			WITH org, coalesce(org.active_scan_id, '') AS scan_id
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = scan_id
+			OPTIONAL MATCH (repo)-[owned:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner) WHERE coalesce(owned.scan_id, '') = scan_id
+			WITH org, repo, count(DISTINCT owner) AS owner_count
+			WITH org, repo, owner_count, CASE WHEN owner_count > 0 THEN 100.0 ELSE 0.0 END AS coverage_percent
+			` + buildNodeListingOrder(nodeListingRepositories, options) + `
+			WITH org, collect(CASE WHEN repo IS NULL THEN NULL ELSE {
+				full_name: repo.full_name,
+				name: repo.name,
+				visibility: repo.visibility,
+				language: repo.language,
+				archived: coalesce(repo.archived, false),
+				fork: coalesce(repo.fork, false),
+				updated_at: repo.updated_at,
+				owner_count: owner_count,
+				coverage_percent: coverage_percent
+			} END) AS rows
+			RETURN size(rows) AS total, rows[$offset..$end] AS items
+		`
+	}, "repository_listing", options.Sort, options.Order)
+}
+
+// buildTeamListingQuery builds a query returning a sorted page of the teams of the active scan with
+// the share of the organization's repositories each owns; secret teams are masked or left out as
+// $secretTeams selects (Pure Core)
+func buildTeamListingQuery(version Neo4jServerVersion, options NodeListingOptions) string {
+	return cachedQuery(func() string {
+		return `
+			MATCH (org:Organization {login: $orgName})
+			WITH org, coalesce(org.active_scan_id, '') AS scan_id
+			WITH org, scan_id, ` + buildCountExpression(version, "(org)-[r:OWNS]->(:Repository)", "coalesce(r.scan_id, '') = scan_id") + ` AS total_repositories
+			OPTIONAL MATCH (org)-[has_team:HAS_TEAM]->(team:Team)
+			WHERE coalesce(has_team.scan_id, '') = scan_id AND ` + visibleTeamCondition("team") + `
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[owned:HAS_TEAM_OWNER]->(team)
+			WHERE coalesce(owns.scan_id, '') = scan_id AND coalesce(owned.scan_id, '') = scan_id
+			WITH org, team, total_repositories, count(DISTINCT repo) AS repository_count
+			WITH org, team, repository_count, size(coalesce(team.members, [])) AS member_count,
+				CASE WHEN total_repositories = 0 THEN 0.0 ELSE round(10000.0 * repository_count / total_repositories) / 100 END AS coverage_percent
+			` + buildNodeListingOrder(nodeListingTeams, options) + `
+			WITH org, collect(CASE WHEN team IS NULL THEN NULL ELSE {
+				key: CASE WHEN ` + maskedTeamCondition("team") + ` THEN '' ELSE team.key END,
+				slug: CASE WHEN ` + maskedTeamCondition("team") + ` THEN '' ELSE team.slug END,
+				name: CASE WHEN ` + maskedTeamCondition("team") + ` THEN '` + maskedTeamLabel + `' ELSE team.name END,
+				member_count: member_count,
+				repository_count: repository_count,
+				coverage_percent: coverage_percent,
+				secret: coalesce(team.privacy, '') = '` + teamPrivacySecret + `'
+			} END) AS rows
+			RETURN size(rows) AS total, rows[$offset..$end] AS items
+		`
+	}, "team_listing", version.supportsCountSubqueries(), options.Sort, options.Order)
+}
+
+// buildUserListingQuery builds a query returning a sorted page of the users named as code owners in the
+// active scan with the share of the organization's repositories each owns directly (Pure Core)
+func buildUserListingQuery(version Neo4jServerVersion, options NodeListingOptions) string {
+	return cachedQuery(func() string {
+		return `
+			MATCH (org:Organization {login: $orgName})
+			WITH org, coalesce(org.active_scan_id, '') AS scan_id
+			WITH org, scan_id, ` + buildCountExpression(version, "(org)-[r:OWNS]->(:Repository)", "coalesce(r.scan_id, '') = scan_id") + ` AS total_repositories
+			OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[owned:HAS_CODEOWNER]->(owner_user:User)
+			WHERE coalesce(owns.scan_id, '') = scan_id AND coalesce(owned.scan_id, '') = scan_id
+			WITH org, repo, total_repositories, ` + canonicalUserExpression("owner_user") + ` AS user
+			WITH org, user, total_repositories, count(DISTINCT repo) AS repository_count
+			WITH org, user, repository_count,
+				CASE WHEN total_repositories = 0 THEN 0.0 ELSE round(10000.0 * repository_count / total_repositories) / 100 END AS coverage_percent
+			` + buildNodeListingOrder(nodeListingUsers, options) + `
+			WITH org, collect(CASE WHEN user IS NULL THEN NULL ELSE {
+				login: user.login,
+				name: user.name,
+				bot: ` + botOwnerExpression("user") + `,
+				repository_count: repository_count,
+				coverage_percent: coverage_percent
+			} END) AS rows
+			RETURN size(rows) AS total, rows[$offset..$end] AS items
+		`
+	}, "user_listing", version.supportsCountSubqueries(), options.Sort, options.Order)
+}
+
+// convertToNodeListingItems converts the rows of a listing page (Pure Core)
+func convertToNodeListingItems(listing string, rows []map[string]interface{}) interface{} {
+	switch listing {
+	case nodeListingTeams:
+		items := make([]TeamListItem, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, TeamListItem{
+				Key:             getStringFromMap(row, "key"),
+				Slug:            getStringFromMap(row, "slug"),
+				Name:            getStringFromMap(row, "name"),
+				MemberCount:     getIntFromMap(row, "member_count"),
+				RepositoryCount: getIntFromMap(row, "repository_count"),
+				CoveragePercent: getFloatFromMap(row, "coverage_percent"),
+				Secret:          getBoolFromMap(row, "secret"),
+			})
+		}
+		return items
+	case nodeListingUsers:
+		items := make([]UserListItem, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, UserListItem{
+				Login:           getStringFromMap(row, "login"),
+				Name:            getStringFromMap(row, "name"),
+				Bot:             getBoolFromMap(row, "bot"),
+				RepositoryCount: getIntFromMap(row, "repository_count"),
+				CoveragePercent: getFloatFromMap(row, "coverage_percent"),
+			})
+		}
+		return items
+	default:
+		items := make([]RepositoryListItem, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, RepositoryListItem{
+				FullName:        getStringFromMap(row, "full_name"),
+				Name:            getStringFromMap(row, "name"),
+				Visibility:      getStringFromMap(row, "visibility"),
+				Language:        getStringFromMap(row, "language"),
+				Archived:        getBoolFromMap(row, "archived"),
+				Fork:            getBoolFromMap(row, "fork"),
+				UpdatedAt:       getStringFromMap(row, "updated_at"),
+				OwnerCount:      getIntFromMap(row, "owner_count"),
+				CoveragePercent: getFloatFromMap(row, "coverage_percent"),
+			})
+		}
+		return items
+	}
+}
+
+// buildNodeListingPage describes the page a listing response covers (Pure Core)
+func buildNodeListingPage(offset, limit, total int) NodeListingPage {
+	page := NodeListingPage{Offset: offset, Limit: limit, Total: total}
+	if next := offset + limit; next < total {
+		page.NextOffset = &next
+	}
+	return page
+}
+
+// getNodeListing retrieves a sorted page of the repositories, teams or users of an organization's
+// active scan, with secret teams as the caller may see them (Orchestrator)
+func getNodeListing(ctx *gofr.Context, deps *AppDependencies, orgName, listing string, options NodeListingOptions) (NodeListingResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return NodeListingResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	query := buildRepositoryListingQuery(options)
+	switch listing {
+	case nodeListingTeams:
+		query = buildTeamListingQuery(session.version, options)
+	case nodeListingUsers:
+		query = buildUserListingQuery(session.version, options)
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, query, map[string]interface{}{
+		"orgName":     orgName,
+		"offset":      options.Offset,
+		"end":         options.Offset + options.Limit,
+		"secretTeams": secretTeamsMode(ctx, deps.currentConfig()),
+	})
+	if err != nil {
+		return NodeListingResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return NodeListingResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	record := result.Records[0]
+	return NodeListingResponse{
+		Organization: orgName,
+		Sort:         options.Sort,
+		Order:        options.Order,
+		Items:        convertToNodeListingItems(listing, getMapSliceFromMap(record, "items")),
+		Page:         buildNodeListingPage(options.Offset, options.Limit, getIntFromMap(record, "total")),
+	}, nil
+}
+
+// listNodes serves the sorted, paginated listing of one node type
+func (h *AppHandler) listNodes(ctx *gofr.Context, listing string) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	options, err := parseNodeListingOptions(listing, ctx.Param("sort"), ctx.Param("order"), ctx.Param("offset"), ctx.Param("limit"))
+	if err != nil {
+		return nil, err
+	}
+	return getNodeListing(ctx, h.deps, orgName, listing, options)
+}
+
+// handleListRepositories returns a sorted page of the repositories of an organization's active scan
+func (h *AppHandler) handleListRepositories(ctx *gofr.Context) (interface{}, error) {
+	return h.listNodes(ctx, nodeListingRepositories)
+}
+
+// handleListTeams returns a sorted page of the teams of an organization's active scan
+func (h *AppHandler) handleListTeams(ctx *gofr.Context) (interface{}, error) {
+	return h.listNodes(ctx, nodeListingTeams)
+}
+
+// handleListUsers returns a sorted page of the users named as code owners in an organization's active scan
+func (h *AppHandler) handleListUsers(ctx *gofr.Context) (interface{}, error) {
+	return h.listNodes(ctx, nodeListingUsers)
+}
@@ -1,7 +1,7 @@
 // Package main provides comprehensive observability utilities for the GoFr-based
 // GitHub Codeowners Visualization application. This module includes:
 //
-// 1. Custom span creation utilities that wrap GoFr's ctx.Trace() functionality
+// 1. Span creation utilities on the OpenTelemetry tracer GoFr exports through
 // 2. Structured logging helpers with correlation IDs and consistent field formatting
 // 3. Custom metrics helpers for business operations (scan duration, repository counts, etc.)
 // 4. Error logging utilities with stack traces and context
@@ -42,12 +42,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/metrics"
 )
 
 // ObservabilityConfig represents observability configuration
@@ -81,9 +87,13 @@ type PerformanceTimer struct {
 	Tags          map[string]interface{}
 }
 
-// SpanWrapper wraps GoFr context with custom span functionality
+// tracerName is the instrumentation name of the application's spans
+const tracerName = "overseer"
+
+// SpanWrapper holds an OpenTelemetry span and the context carrying it, which child spans start from
 type SpanWrapper struct {
-	ctx       *gofr.Context
+	span      trace.Span
+	spanCtx   context.Context
 	spanName  string
 	tags      map[string]interface{}
 	startTime time.Time
@@ -117,63 +127,113 @@ type ErrorContext struct {
 	UserImpact  string
 }
 
-// createSpan creates a custom span wrapping GoFr's trace functionality
-func createSpan(ctx *gofr.Context, config SpanConfig) *SpanWrapper {
-	// Handle nil context gracefully
-	if ctx == nil {
-		return &SpanWrapper{
-			ctx:       nil,
-			spanName:  config.OperationName,
-			tags:      config.Tags,
-			startTime: time.Now(),
-		}
+// spanParent returns the context a span starts from, or nil when there is none to attach to (Pure Core)
+func spanParent(parent context.Context) context.Context {
+	if gofrCtx, ok := parent.(*gofr.Context); ok && (gofrCtx == nil || gofrCtx.Context == nil) {
+		return nil
+	}
+	return parent
+}
+
+// parseSpanKind converts a SpanConfig kind to an OpenTelemetry span kind (Pure Core)
+func parseSpanKind(kind string) trace.SpanKind {
+	switch kind {
+	case "client":
+		return trace.SpanKindClient
+	case "server":
+		return trace.SpanKindServer
+	case "producer":
+		return trace.SpanKindProducer
+	case "consumer":
+		return trace.SpanKindConsumer
+	}
+	return trace.SpanKindInternal
+}
+
+// buildSpanAttribute converts a tag to a typed span attribute, formatting other types as strings (Pure Core)
+func buildSpanAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case time.Duration:
+		return attribute.String(key, v.String())
+	}
+	return attribute.String(key, fmt.Sprintf("%v", value))
+}
+
+// createSpan starts a span as a child of the span carried by ctx; without a context the returned
+// wrapper records nothing
+func createSpan(ctx context.Context, config SpanConfig) *SpanWrapper {
+	wrapper := &SpanWrapper{
+		spanName:  config.OperationName,
+		tags:      config.Tags,
+		startTime: time.Now(),
 	}
 
-	// Create the span using GoFr's tracing
-	_ = ctx.Trace(config.OperationName)
+	parent := spanParent(ctx)
+	if parent == nil {
+		return wrapper
+	}
 
-	// Log span start with attributes
-	if ctx.Logger != nil {
-		ctx.Logger.Debugf("Starting span: %s", config.OperationName)
-		for key, value := range config.Tags {
-			ctx.Logger.Debugf("Span attribute: %s = %v", key, value)
-		}
+	attributes := make([]attribute.KeyValue, 0, len(config.Tags))
+	for key, value := range config.Tags {
+		attributes = append(attributes, buildSpanAttribute(key, value))
 	}
+	wrapper.spanCtx, wrapper.span = otel.Tracer(tracerName).Start(parent, config.OperationName,
+		trace.WithSpanKind(parseSpanKind(config.Kind)),
+		trace.WithAttributes(attributes...))
+	return wrapper
+}
 
-	return &SpanWrapper{
-		ctx:       ctx, // Use original context
-		spanName:  config.OperationName,
-		tags:      config.Tags,
-		startTime: time.Now(),
+// contextWithSpan returns the context carrying span, so work done with it is traced as its child
+func contextWithSpan(ctx context.Context, span *SpanWrapper) context.Context {
+	if span == nil || span.spanCtx == nil {
+		return ctx
 	}
+	return span.spanCtx
 }
 
-// addSpanAttribute adds an attribute to the span (GoFr implementation dependent)
-func addSpanAttribute(ctx *gofr.Context, key string, value interface{}) {
-	// GoFr's context logger for span attributes
-	// This logs span attributes as structured log entries
-	if ctx != nil && ctx.Logger != nil {
-		ctx.Logger.Debugf("Span attribute: %s = %v", key, value)
+// withSpanContext returns a copy of a GoFr context carrying span, for HTTP service calls and child spans
+func withSpanContext(ctx *gofr.Context, span *SpanWrapper) *gofr.Context {
+	if ctx == nil || span == nil || span.spanCtx == nil {
+		return ctx
 	}
+	scoped := *ctx
+	scoped.Context = span.spanCtx
+	return &scoped
 }
 
-// finishSpan completes the span and logs duration
-func finishSpan(span *SpanWrapper) {
-	if span == nil {
+// addSpanAttribute sets an attribute on the span
+func addSpanAttribute(span *SpanWrapper, key string, value interface{}) {
+	if span == nil || span.span == nil {
 		return
 	}
+	span.span.SetAttributes(buildSpanAttribute(key, value))
+}
 
-	duration := time.Since(span.startTime)
-	
-	// Handle nil context gracefully
-	if span.ctx != nil && span.ctx.Logger != nil {
-		span.ctx.Logger.Debugf("Span '%s' completed in %v", span.spanName, duration)
+// recordSpanError records err on the span and marks it failed
+func recordSpanError(span *SpanWrapper, err error) {
+	if span == nil || span.span == nil || err == nil {
+		return
+	}
+	span.span.RecordError(err)
+	span.span.SetStatus(codes.Error, err.Error())
+}
 
-		// Log span completion with tags
-		for key, value := range span.tags {
-			span.ctx.Logger.Debugf("Span tag: %s = %v", key, value)
-		}
+// finishSpan ends the span
+func finishSpan(span *SpanWrapper) {
+	if span == nil || span.span == nil {
+		return
 	}
+	span.span.End()
 }
 
 // createGitHubScanSpan creates a span for GitHub scanning operations
@@ -194,7 +254,7 @@ func createGitHubScanSpan(ctx *gofr.Context, organization string, operation stri
 }
 
 // createNeo4jSpan creates a span for Neo4j database operations
-func createNeo4jSpan(ctx *gofr.Context, operation string, query string) *SpanWrapper {
+func createNeo4jSpan(ctx context.Context, operation string, query string) *SpanWrapper {
 	return createSpan(ctx, SpanConfig{
 		OperationName: fmt.Sprintf("neo4j.%s", operation),
 		Tags: map[string]interface{}{
@@ -259,6 +319,10 @@ func logWithContext(ctx *gofr.Context, level string, message string, fields LogF
 		return
 	}
 	
+	if !componentLogLevels.allows(extractComponent(fields), level) {
+		return
+	}
+
 	logCtx := createLogContext(ctx, extractComponent(fields))
 
 	// Enhance fields with context
@@ -272,6 +336,10 @@ func logWithContext(ctx *gofr.Context, level string, message string, fields LogF
 	enhancedFields["component"] = logCtx.Component
 	enhancedFields["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
 
+	// Retain for support bundles
+	recordDiagnosticLog(strings.ToLower(level), message, enhancedFields)
+	recordScanLogEvent(ctx, level, message, enhancedFields)
+
 	// Format the log message
 	logMessage := formatLogMessage(message, enhancedFields)
 
@@ -362,28 +430,57 @@ func (mc *MetricsCollector) recordErrorCount(component string, errorType string)
 	mc.recordCounter("errors_total", 1, labels)
 }
 
-// recordDuration records a duration metric (placeholder for GoFr implementation)
+// metricsManager returns GoFr's metrics manager and the kind a metric was registered as; metrics
+// that were not registered at startup are dropped with a debug log
+func (mc *MetricsCollector) metricsManager(metricName string) (metrics.Manager, string) {
+	if mc.ctx == nil || mc.ctx.Container == nil {
+		return nil, ""
+	}
+
+	kind, ok := registeredMetricKinds[metricName]
+	if !ok {
+		if mc.ctx.Logger != nil {
+			mc.ctx.Logger.Debugf("Metric [%s] is not registered; dropping value", metricName)
+		}
+		return nil, ""
+	}
+	return mc.ctx.Metrics(), kind
+}
+
+// recordDuration records a duration in milliseconds on a histogram
 func (mc *MetricsCollector) recordDuration(metricName string, duration time.Duration, labels MetricLabels) {
-	// GoFr should provide metrics recording capabilities
-	if mc.ctx != nil && mc.ctx.Logger != nil {
-		mc.ctx.Logger.Infof("Metric [%s]: %v ms (labels: %v)", metricName, duration.Milliseconds(), labels)
+	manager, kind := mc.metricsManager(metricName)
+	if manager == nil || kind != metricKindHistogram {
+		return
 	}
+	manager.RecordHistogram(mc.ctx, metricName, float64(duration.Milliseconds()), buildMetricLabelPairs(labels)...)
 }
 
-// recordCounter records a counter metric (placeholder for GoFr implementation)
+// recordCounter adds value to a counter; counters registered as up-down counters accept any amount
 func (mc *MetricsCollector) recordCounter(metricName string, value int, labels MetricLabels) {
-	// GoFr should provide metrics recording capabilities
-	if mc.ctx != nil && mc.ctx.Logger != nil {
-		mc.ctx.Logger.Infof("Metric [%s]: %d (labels: %v)", metricName, value, labels)
+	manager, kind := mc.metricsManager(metricName)
+	if manager == nil || value <= 0 {
+		return
+	}
+
+	pairs := buildMetricLabelPairs(labels)
+	switch kind {
+	case metricKindUpDownCounter:
+		manager.DeltaUpDownCounter(mc.ctx, metricName, float64(value), pairs...)
+	case metricKindCounter:
+		for i := 0; i < value; i++ {
+			manager.IncrementCounter(mc.ctx, metricName, pairs...)
+		}
 	}
 }
 
-// recordGauge records a gauge metric (placeholder for GoFr implementation)
+// recordGauge sets a gauge to value
 func (mc *MetricsCollector) recordGauge(metricName string, value float64, labels MetricLabels) {
-	// GoFr should provide metrics recording capabilities
-	if mc.ctx != nil && mc.ctx.Logger != nil {
-		mc.ctx.Logger.Infof("Metric [%s]: %.2f (labels: %v)", metricName, value, labels)
+	manager, kind := mc.metricsManager(metricName)
+	if manager == nil || kind != metricKindGauge {
+		return
 	}
+	manager.SetGauge(metricName, value, buildMetricLabelPairs(labels)...)
 }
 
 // logErrorWithStackTrace logs an error with enhanced context and stack trace
@@ -443,7 +540,7 @@ func stopPerformanceTimer(timer *PerformanceTimer) time.Duration {
 			"operation": timer.OperationName,
 			"service":   "codeowners-scanner",
 		}
-		metrics.recordDuration(fmt.Sprintf("%s_duration_ms", timer.OperationName), duration, labels)
+		metrics.recordDuration("operation_duration_ms", duration, labels)
 	}
 
 	return duration
@@ -462,7 +559,7 @@ func withSpanTracking(ctx *gofr.Context, config SpanConfig, fn func(*gofr.Contex
 	span := createSpan(ctx, config)
 	defer finishSpan(span)
 
-	return fn(span.ctx)
+	return fn(withSpanContext(ctx, span))
 }
 
 // Helper functions
@@ -685,9 +782,11 @@ func createObservabilityMiddleware(serviceName string) func(*gofr.Context, func(
 }
 
 // withErrorRecovery wraps a function with error recovery and logging
-func withErrorRecovery(ctx *gofr.Context, operation string, fn func() error) error {
+func withErrorRecovery(ctx *gofr.Context, operation string, fn func() error) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered in %s: %v", operation, r)
+
 			// Log panic with stack trace
 			logErrorWithStackTrace(ctx, ErrorContext{
 				Error:       fmt.Errorf("panic recovered: %v", r),
@@ -820,17 +919,7 @@ func max(a, b int) int {
 }
 
 // createHealthCheckSpan creates a span for health check operations
-func createHealthCheckSpan(ctx *gofr.Context, checkType string) *SpanWrapper {
-	// Handle nil context gracefully
-	if ctx == nil {
-		return &SpanWrapper{
-			ctx:       nil,
-			spanName:  fmt.Sprintf("health.%s", checkType),
-			tags:      make(map[string]interface{}),
-			startTime: time.Now(),
-		}
-	}
-	
+func createHealthCheckSpan(ctx context.Context, checkType string) *SpanWrapper {
 	return createSpan(ctx, SpanConfig{
 		OperationName: fmt.Sprintf("health.%s", checkType),
 		Tags: map[string]interface{}{
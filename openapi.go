@@ -46,7 +46,16 @@ func buildOpenAPISpec() string {
 	components := buildOpenAPIComponents()
 	tags := buildOpenAPITags()
 
-	return info + servers + paths + components + tags
+	return info + servers + buildOpenAPISecurity() + paths + components + tags
+}
+
+// buildOpenAPISecurity returns the security requirements applying to every operation once
+// authentication is configured
+func buildOpenAPISecurity() string {
+	return `security:
+  - ApiKeyAuth: []
+  - BearerAuth: []
+`
 }
 
 // buildOpenAPIInfo returns the info section of OpenAPI spec
@@ -91,6 +100,7 @@ func buildHealthPathSpec() string {
       operationId: healthCheck
       tags:
         - System
+      security: []
       responses:
         '200':
           description: System is healthy
@@ -104,7 +114,43 @@ func buildHealthPathSpec() string {
                     properties:
                       status:
                         type: string
+                        enum: [healthy, degraded]
                         example: "healthy"
+                      github_cooldown:
+                        type: object
+                        description: Present while GitHub requests pause for a secondary rate limit
+                        properties:
+                          credential:
+                            type: string
+                          status:
+                            type: integer
+                          observed_at:
+                            type: string
+                            format: date-time
+                          until:
+                            type: string
+                            format: date-time
+                          remaining_seconds:
+                            type: integer
+                      circuit_breakers:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            dependency:
+                              type: string
+                              enum: [github, neo4j]
+                            state:
+                              type: string
+                              enum: [closed, open, half_open]
+                            consecutive_failures:
+                              type: integer
+                            opened_at:
+                              type: string
+                              format: date-time
+                            retry_at:
+                              type: string
+                              format: date-time
                       database:
                         type: string
                         example: "connected"
@@ -161,6 +207,75 @@ func buildScanPathSpec() string {
           schema:
             type: boolean
             default: false
+        - name: provider
+          in: query
+          required: false
+          description: Source code host to scan, defaulting to SCM_PROVIDER; a Bitbucket workspace or GitLab group is scanned into the same graph model
+          schema:
+            type: string
+            enum: [github, gitlab, bitbucket]
+        - name: wait
+          in: query
+          required: false
+          description: Block until the scan finishes and return its result instead of a queued scan job
+          schema:
+            type: boolean
+            default: false
+        - name: force
+          in: query
+          required: false
+          description: Scan even when the organization was scanned within SCAN_DEDUP_WINDOW
+          schema:
+            type: boolean
+            default: false
+      responses:
+        '202':
+          description: The organization was scanned within SCAN_DEDUP_WINDOW; references that scan instead of starting another
+          headers:
+            Location:
+              description: Statistics of the recent scan
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+                    properties:
+                      organization:
+                        type: string
+                      deduplicated:
+                        type: boolean
+                      scan_id:
+                        type: string
+                      scanned_at:
+                        type: string
+                        format: date-time
+                      dedup_window:
+                        type: string
+                        example: "15m0s"
+                      stats:
+                        type: string
+                        example: "/api/stats/microsoft"
+                      graph:
+                        type: string
+                        example: "/api/graph/microsoft"
+  /api/scan/jobs/{id}:
+    get:
+      summary: Get scan job
+      description: Returns the state, progress percentage, result and error details of a background scan
+      operationId: getScanJob
+      tags:
+        - Scanning
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: Scan job identifier returned by POST /api/scan/{org}
+          schema:
+            type: string
 
 `
 }
@@ -168,6 +283,17 @@ func buildScanPathSpec() string {
 // buildOpenAPIComponents returns the components section of OpenAPI spec
 func buildOpenAPIComponents() string {
 	return `components:
+  securitySchemes:
+    ApiKeyAuth:
+      type: apiKey
+      in: header
+      name: X-API-Key
+      description: One of AUTH_API_KEYS or ADMIN_API_KEYS; also accepted as a bearer token
+    BearerAuth:
+      type: http
+      scheme: bearer
+      bearerFormat: JWT
+      description: Token of the OIDC_ISSUER provider, issued for OIDC_AUDIENCE
   schemas:
     ScanResponse:
       type: object
@@ -178,6 +304,18 @@ func buildOpenAPIComponents() string {
         organization:
           type: string
           description: Name of the scanned organization
+        scan_id:
+          type: string
+          description: Identifier of the stored scan
+        scan_status:
+          type: string
+          enum: [active, pending_approval]
+          description: Whether the scan was published or held for admin approval
+        validation_failures:
+          type: array
+          description: Sanity checks that failed and caused the scan to be held
+          items:
+            type: string
         summary:
           type: object
           properties:
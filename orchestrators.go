@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/samber/lo"
 	"gofr.dev/pkg/gofr"
 	gofrhttp "gofr.dev/pkg/gofr/http"
 )
@@ -15,15 +17,48 @@ func createAppDependencies(ctx context.Context) (*AppDependencies, error) {
 	if err != nil {
 		return nil, fmt.Errorf("configuration setup failed: %w", err)
 	}
+	configureCircuitBreakers(config.CircuitBreaker)
+	configureCodeownersSearch(config.Codeowners)
+
+	graphTypes, err := loadGraphTypeRegistry(config.GraphTypes)
+	if err != nil {
+		return nil, fmt.Errorf("graph type registry setup failed: %w", err)
+	}
 
 	neo4jConn, err := setupNeo4jConnection(ctx, config.Neo4j)
 	if err != nil {
 		return nil, fmt.Errorf("Neo4j setup failed: %w", err)
 	}
 
+	if err := createCustomNodeConstraints(ctx, neo4jConn, graphTypes); err != nil {
+		return nil, fmt.Errorf("failed to create custom node constraints: %w", err)
+	}
+
+	state, err := newStateStore(config.StateStore, neo4jConn)
+	if err != nil {
+		return nil, fmt.Errorf("state store setup failed: %w", err)
+	}
+	configureGitHubCooldownStore(state)
+
 	return &AppDependencies{
-		Config:    config,
-		Neo4jConn: neo4jConn,
+		Config:             config,
+		LiveConfig:         newLiveConfig(config),
+		Neo4jConn:          neo4jConn,
+		Maintenance:        newMaintenanceState(config.Maintenance, state),
+		GraphChanges:       newGraphChangeNotifier(),
+		GraphTypes:         graphTypes,
+		GraphCounts:        newGraphCountsCache(),
+		Scans:              newScanTracker(),
+		ScanJobs:           newScanJobStore(config.ScanJobs.Workers, state),
+		ResponseCache:      newStaleResponseCache(),
+		Access:             newOrganizationAccessTracker(),
+		SLO:                newSLOTracker(),
+		Scheduler:          newScanScheduler(config.ScanSchedule, state),
+		State:              state,
+		Leader:             newLeaderElector(config.LeaderElection, state),
+		OIDC:               newOIDCVerifier(config.Auth.OIDC),
+		RateLimits:         newRateLimiter(),
+		ConversionFailures: newConversionFailureTracker(),
 	}, nil
 }
 
@@ -56,6 +91,23 @@ func setupNeo4jConnection(ctx context.Context, config Neo4jConfig) (*Neo4jConnec
 	return neo4jConn, nil
 }
 
+// registerAppContext captures the GoFr context before the server accepts requests; it is registered
+// first so every request finds it set
+func registerAppContext(app *gofr.App, deps *AppDependencies) {
+	app.OnStart(func(ctx *gofr.Context) error {
+		deps.AppContext = ctx
+		return nil
+	})
+}
+
+// requestContext returns the startup GoFr context scoped to a request, for middlewares that run
+// outside GoFr's handlers
+func (d *AppDependencies) requestContext(r *http.Request) *gofr.Context {
+	scoped := *d.AppContext
+	scoped.Context = r.Context()
+	return &scoped
+}
+
 // cleanupAppDependencies cleans up application dependencies
 func cleanupAppDependencies(ctx context.Context, deps *AppDependencies) error {
 	if deps == nil {
@@ -71,60 +123,174 @@ func cleanupAppDependencies(ctx context.Context, deps *AppDependencies) error {
 	return nil
 }
 
-// scanOrganization scans a GitHub organization
+// scanOrganization scans an organization on the source code host of the request or, by default, the
+// configured one
 func scanOrganization(ctx *gofr.Context, deps *AppDependencies, request ScanRequest) (ScanResponse, error) {
 	startTime := time.Now()
 
-	org, err := fetchGitHubOrganizationWithService(ctx, request.Organization)
-	if err != nil {
+	tenant := tenantFromContext(ctx)
+	if err := checkTenantQuota(ctx, deps, tenant); err != nil {
 		return ScanResponse{}, err
 	}
 
-	repos, err := fetchGitHubRepositoriesWithService(ctx, request.Organization, request.MaxRepos)
+	usage := &APIUsageCounter{}
+	ctx = withAPIUsage(ctx, usage)
+
+	var scanID string
+	defer func() {
+		recordScanUsage(ctx, deps, tenant, scanID, usage.count())
+	}()
+
+	config := deps.currentConfig()
+	provider := resolveScanProvider(request, config)
+	source, err := selectScanSourceFetcher(provider)(ctx, deps, request)
 	if err != nil {
 		return ScanResponse{}, err
 	}
+	org, base, repos, teams, topics, codeowners := source.org, source.base, source.repos, source.teams, source.topics, source.codeowners
+	org.Provider = provider
+
+	// Manifests are only read from GitHub when dependency analysis is enabled; nil skips the dependency stage
+	var manifests []RepositoryManifest
+	if config.Dependencies.Enabled && provider == scmProviderGitHub {
+		reportScanProgress(ctx, ScanPhaseFetchManifests)
+		manifests, err = fetchRepositoryManifests(ctx, repos)
+		if err != nil {
+			return ScanResponse{}, err
+		}
+	}
 
-	teams, topics, err := fetchTeamsOrTopics(ctx, request, repos)
-	if err != nil {
+	if err := checkScanCancelled(ctx); err != nil {
 		return ScanResponse{}, err
 	}
-
-	codeowners, err := fetchCodeownersForReposWithService(ctx, repos)
+	reportScanProgress(ctx, ScanPhaseStore)
+	outcome, err := storeOrganizationData(ctx, deps.Neo4jConn, config.Neo4j.Batch, config.ScanValidation, base, org, repos, teams, topics, codeowners, manifests)
 	if err != nil {
-		return ScanResponse{}, err
+		return ScanResponse{}, convertNeo4jErrorToGoFr(err)
 	}
 
-	if err := storeOrganizationData(ctx, deps.Neo4jConn, org, repos, teams, topics, codeowners); err != nil {
-		return ScanResponse{}, convertNeo4jErrorToGoFr(err)
+	scanID = outcome.ScanID
+
+	var conversionFailures map[string]map[string]int
+	if outcome.Status == ScanStatusActive {
+		deps.GraphChanges.notify(org.Login)
+		conversionFailures = checkPublishedGraphConversion(ctx, deps, org.Login, graphGroupByTopics(request.UseTopics))
+		if provider == scmProviderGitHub {
+			syncCustomPropertiesAfterScan(ctx, deps, org.Login)
+		}
 	}
 
 	summary := calculateScanSummary(repos, codeowners, teams, topics, time.Since(startTime))
+	summary.APICallsUsed = usage.count()
+
+	response := buildScanResponse(request.Organization, outcome, summary, org, repos, teams, topics, codeowners)
+	response.Mode = ScanModeFull
+	response.ConversionFailures = conversionFailures
+	if base != nil {
+		response.Mode = ScanModeIncremental
+		response.IncrementalSince = base.Since.Format(time.RFC3339)
+	}
+	return response, nil
+}
+
+// scanSourceData is what a scan read from the source code host of an organization
+type scanSourceData struct {
+	org        GitHubOrganization
+	base       *IncrementalScanBase
+	repos      []GitHubRepository
+	teams      []GitHubTeam
+	topics     []GitHubTopic
+	codeowners []GitHubCodeowners
+}
+
+// fetchGitHubScanSource reads a GitHub organization, its repositories (only those changed since the
+// previous scan in incremental mode), teams or topics and CODEOWNERS files (Orchestrator)
+func fetchGitHubScanSource(ctx *gofr.Context, deps *AppDependencies, request ScanRequest) (scanSourceData, error) {
+	reportScanProgress(ctx, ScanPhaseFetchOrganization)
+	org, err := fetchGitHubOrganizationWithService(ctx, request.Organization)
+	if err != nil {
+		return scanSourceData{}, err
+	}
+
+	var base *IncrementalScanBase
+	if request.Mode == ScanModeIncremental {
+		base, err = resolveIncrementalScanBase(ctx, deps.Neo4jConn, request.Organization)
+		if err != nil {
+			return scanSourceData{}, err
+		}
+	}
+
+	reportScanProgress(ctx, ScanPhaseFetchRepositories)
+	var repos []GitHubRepository
+	_, useGraphQL := currentGitHubGraphQL()
+	switch {
+	case base != nil:
+		repos, err = fetchGitHubRepositoriesChangedSince(ctx, request.Organization, base.Since, request.MaxRepos)
+	case useGraphQL:
+		repos, err = fetchGitHubRepositoriesGraphQL(ctx, request.Organization, request.MaxRepos)
+	default:
+		repos, err = fetchGitHubRepositoriesWithService(ctx, request.Organization, request.MaxRepos)
+	}
+	if err != nil {
+		return scanSourceData{}, err
+	}
+
+	// Incremental scans carry teams forward from the previous scan instead of re-fetching them
+	var teams []GitHubTeam
+	var topics []GitHubTopic
+	if base != nil {
+		base.Changed = lo.Map(repos, func(repo GitHubRepository, _ int) string { return repo.FullName })
+		if request.UseTopics {
+			topics = collectTopicsFromRepositories(repos)
+		}
+	} else {
+		reportScanProgress(ctx, ScanPhaseFetchTeams)
+		teams, topics, err = fetchTeamsOrTopics(ctx, request, repos)
+		if err != nil {
+			return scanSourceData{}, err
+		}
+	}
 
-	return buildScanResponse(request.Organization, summary, org, repos, teams, topics, codeowners), nil
+	reportScanProgress(ctx, ScanPhaseFetchCodeowners)
+	codeowners, err := fetchCodeownersForReposWithService(ctx, repos)
+	if err != nil {
+		return scanSourceData{}, err
+	}
+
+	return scanSourceData{org: org, base: base, repos: repos, teams: teams, topics: topics, codeowners: codeowners}, nil
 }
 
-// getOrganizationGraph retrieves graph data for an organization
-func getOrganizationGraph(ctx *gofr.Context, deps *AppDependencies, orgName string, useTopics bool) (GraphResponse, error) {
+// getOrganizationGraph retrieves graph data for an organization, grouped by teams, topics or both
+func getOrganizationGraph(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy) (GraphResponse, error) {
 	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
 	if err != nil {
 		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
 	}
 	defer closeNeo4jSession(ctx, session)
 
-	nodes, err := fetchGraphNodes(ctx, session, orgName, useTopics)
+	nodes, nodeReport, err := fetchGraphNodes(ctx, session, orgName, groupBy)
 	if err != nil {
 		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
 	}
 
-	edges, err := fetchGraphEdges(ctx, session, orgName, useTopics)
+	edges, edgeReport, err := fetchGraphEdges(ctx, session, orgName, groupBy)
 	if err != nil {
 		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
 	}
 
+	customNodes, customEdges, err := fetchCustomGraphElements(ctx, session, deps.GraphTypes, orgName, nodeReport, edgeReport)
+	if err != nil {
+		return GraphResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	if err := reportGraphConversion(ctx, deps, orgName, nodeReport, edgeReport); err != nil {
+		return GraphResponse{}, err
+	}
+
 	return GraphResponse{
-		Nodes: nodes,
-		Edges: edges,
+		SchemaVersion: GraphSchemaVersion,
+		Nodes:         append(nodes, customNodes...),
+		Edges:         append(edges, customEdges...),
 	}, nil
 }
 
@@ -138,7 +304,8 @@ func getOrganizationStats(ctx *gofr.Context, deps *AppDependencies, orgName stri
 
 	query := buildStatsQuery(orgName)
 	result, err := executeNeo4jReadQuery(ctx, session, query, map[string]interface{}{
-		"orgName": orgName,
+		"orgName":     orgName,
+		"excludeBots": deps.currentConfig().Bots.Exclude,
 	})
 	if err != nil {
 		return StatsResponse{}, convertNeo4jErrorToGoFr(err)
@@ -151,16 +318,50 @@ func getOrganizationStats(ctx *gofr.Context, deps *AppDependencies, orgName stri
 		}
 	}
 
-	return convertToStatsResponse(result.Records[0], orgName), nil
+	stats := convertToStatsResponse(result.Records[0], orgName)
+	freshness := evaluateDataFreshness(stats.LastSuccessfulScan, deps.currentConfig().Freshness.SLA, time.Now().UTC())
+	stats.DataFreshness = &freshness
+
+	statsMap := getMapFromMap(result.Records[0], "stats")
+	targetPercent := getFloatFromMap(statsMap, "coverage_target_percent")
+	if err := attachCoverageTarget(ctx, session, &stats, targetPercent, getStringFromMap(statsMap, "coverage_target_deadline")); err != nil {
+		return StatsResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	return stats, nil
 }
 
-// fetchCodeownersForReposWithService fetches CODEOWNERS files for repositories
+// fetchCodeownersForReposWithService fetches CODEOWNERS files for repositories, followed by the rules of
+// their nested CODEOWNERS files when the search includes them
 func fetchCodeownersForReposWithService(ctx *gofr.Context, repos []GitHubRepository) ([]GitHubCodeowners, error) {
+	search := currentCodeownersSearch()
+	if _, useGraphQL := currentGitHubGraphQL(); useGraphQL {
+		fetched, err := fetchCodeownersGraphQL(ctx, repos)
+		if err != nil {
+			return nil, err
+		}
+		nested := 0
+		for i := range fetched {
+			nested += appendNestedCodeowners(ctx, &fetched[i], search)
+		}
+		if err := reserveScanItems(ctx, ScanBufferRules, nested); err != nil {
+			return nil, err
+		}
+		return lo.Filter(fetched, func(codeowner GitHubCodeowners, _ int) bool { return len(codeowner.Rules) > 0 }), nil
+	}
+
 	codeowners := make([]GitHubCodeowners, 0, len(repos))
 
-	for _, repo := range repos {
+	for i, repo := range repos {
+		reportScanBatchProgress(ctx, i, len(repos))
 		codeowner := fetchCodeownersForSingleRepo(ctx, repo)
+		if codeowner != nil {
+			appendNestedCodeowners(ctx, codeowner, search)
+		}
 		if codeowner != nil && len(codeowner.Rules) > 0 {
+			if err := reserveScanItems(ctx, ScanBufferRules, len(codeowner.Rules)); err != nil {
+				return nil, err
+			}
 			codeowners = append(codeowners, *codeowner)
 		}
 	}
@@ -168,28 +369,73 @@ func fetchCodeownersForReposWithService(ctx *gofr.Context, repos []GitHubReposit
 	return codeowners, nil
 }
 
-// storeOrganizationData stores organization data in Neo4j
-func storeOrganizationData(ctx *gofr.Context, conn *Neo4jConnection, org GitHubOrganization, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners) error {
+// storeOrganizationData stores organization data in Neo4j as a staging scan and publishes it once validated.
+// An incremental base carries the unchanged data of the previous scan into the staging scan.
+func storeOrganizationData(ctx *gofr.Context, conn *Neo4jConnection, batch Neo4jBatchConfig, validation ScanValidationConfig, base *IncrementalScanBase, org GitHubOrganization, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners, manifests []RepositoryManifest) (ScanOutcome, error) {
 	session, err := createNeo4jSession(ctx, conn)
 	if err != nil {
-		return fmt.Errorf("failed to create Neo4j session: %w", err)
+		return ScanOutcome{}, fmt.Errorf("failed to create Neo4j session: %w", err)
 	}
 	defer closeNeo4jSession(ctx, session)
 
 	if err := storeOrganization(ctx, session, org); err != nil {
-		return fmt.Errorf("failed to store organization: %w", err)
+		return ScanOutcome{}, fmt.Errorf("failed to store organization: %w", err)
+	}
+
+	scanID := generateScanID(org.Login, time.Now())
+	metrics := calculateScanMetrics(repos, codeowners)
+	if base != nil {
+		unchanged, err := fetchUnchangedRepositoryMetrics(ctx, session, org.Login, *base)
+		if err != nil {
+			return ScanOutcome{}, err
+		}
+		metrics.RepositoryCount += unchanged.RepositoryCount
+		metrics.ReposWithCodeowners += unchanged.ReposWithCodeowners
+	}
+	if err := beginStagingScan(ctx, session, org.Login, scanID, metrics); err != nil {
+		return ScanOutcome{}, err
+	}
+	reportScanStaged(ctx, scanID)
+
+	if base != nil {
+		if err := carryForwardScanData(ctx, session, org.Login, scanID, *base); err != nil {
+			discardStagingScan(ctx, session, org.Login, scanID, err)
+			return ScanOutcome{}, err
+		}
+	}
+
+	if err := checkScanCancelled(ctx); err != nil {
+		return ScanOutcome{}, err
+	}
+	if err := storeStagedScanData(ctx, session, batch, org.Login, scanID, repos, teams, topics, codeowners, manifests); err != nil {
+		discardStagingScan(ctx, session, org.Login, scanID, err)
+		return ScanOutcome{}, err
 	}
 
-	if err := storeRepositories(ctx, session, repos, org.Login); err != nil {
-		return fmt.Errorf("failed to store repositories: %w", err)
+	// A scan cancelled while storing must not publish the staging scan the watchdog discarded
+	if err := checkScanCancelled(ctx); err != nil {
+		return ScanOutcome{}, err
+	}
+	outcome, err := publishStagingScan(ctx, session, validation, org, scanID, metrics)
+	if err != nil {
+		discardStagingScan(ctx, session, org.Login, scanID, err)
+		return ScanOutcome{}, err
 	}
 
-	if err := storeTeamsAndTopics(ctx, session, teams, topics, org.Login); err != nil {
-		return fmt.Errorf("failed to store teams and topics: %w", err)
+	return outcome, nil
+}
+
+// storeStagedScanData writes repositories, teams, topics, codeowners and, when manifests were read,
+// repository dependencies under a staging scan ID
+func storeStagedScanData(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, orgLogin, scanID string, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners, manifests []RepositoryManifest) error {
+	if err := storeScanDataInBatches(ctx, session, batch, orgLogin, scanID, repos, teams, topics, codeowners); err != nil {
+		return fmt.Errorf("failed to store scan data: %w", err)
 	}
 
-	if err := storeCodeownersData(ctx, session, codeowners, org.Login); err != nil {
-		return fmt.Errorf("failed to store codeowners: %w", err)
+	if manifests != nil {
+		if err := storeRepositoryDependencies(ctx, session, batch, orgLogin, scanID, manifests); err != nil {
+			return fmt.Errorf("failed to store repository dependencies: %w", err)
+		}
 	}
 
 	return nil
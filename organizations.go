@@ -0,0 +1,290 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// maxMultiOrgScanOrganizations bounds the organizations queued by one multi-organization scan
+const maxMultiOrgScanOrganizations = 100
+
+// graphQLEnterprisePageSize is the page size of the enterprise organizations query
+const graphQLEnterprisePageSize = 100
+
+// MultiOrgScanRequest names the organizations to scan, directly or as the members of an enterprise
+type MultiOrgScanRequest struct {
+	Organizations []string `json:"organizations"`
+	Enterprise    string   `json:"enterprise"`
+	MaxRepos      int      `json:"max_repos"`
+	MaxTeams      int      `json:"max_teams"`
+	UseTopics     *bool    `json:"use_topics"`
+	Mode          string   `json:"mode"`
+	Provider      string   `json:"provider"`
+}
+
+// MultiOrgScanRejection is an organization whose scan could not be queued
+type MultiOrgScanRejection struct {
+	Organization string `json:"organization"`
+	Error        string `json:"error"`
+	StatusCode   int    `json:"status_code"`
+}
+
+// MultiOrgScanResponse lists the scan job queued for each organization; each job is followed with
+// GET /api/scan/jobs/{id}
+type MultiOrgScanResponse struct {
+	Enterprise string                  `json:"enterprise,omitempty"`
+	Jobs       []ScanJob               `json:"jobs"`
+	Rejected   []MultiOrgScanRejection `json:"rejected"`
+}
+
+// OrganizationSummary is a scanned organization with its last scan and the size of its active scan
+type OrganizationSummary struct {
+	Organization               string  `json:"organization"`
+	Name                       string  `json:"name,omitempty"`
+	Provider                   string  `json:"provider"`
+	ActiveScanID               string  `json:"active_scan_id,omitempty"`
+	LastScannedAt              string  `json:"last_scanned_at,omitempty"`
+	Scanning                   bool    `json:"scanning"`
+	Repositories               int     `json:"repositories"`
+	RepositoriesWithCodeowners int     `json:"repositories_with_codeowners"`
+	Teams                      int     `json:"teams"`
+	CoveragePercent            float64 `json:"coverage_percent"`
+}
+
+// OrganizationListResponse lists every organization in the graph
+type OrganizationListResponse struct {
+	Organizations []OrganizationSummary `json:"organizations"`
+}
+
+// buildEnterpriseOrganizationsGraphQLQuery builds the query listing the organizations of an enterprise (Pure Core)
+func buildEnterpriseOrganizationsGraphQLQuery() string {
+	return `query($slug: String!, $first: Int!, $after: String) {
+  enterprise(slug: $slug) {
+    organizations(first: $first, after: $after) {
+      pageInfo { hasNextPage endCursor }
+      nodes { login }
+    }
+  }
+}`
+}
+
+// buildOrganizationListQuery builds a query returning every organization with its active scan and the
+// repositories and teams in it (Pure Core)
+func buildOrganizationListQuery(version Neo4jServerVersion) string {
+	active := "coalesce(r.scan_id, '') = scan_id"
+	owned := "coalesce(owns.scan_id, '') = scan_id AND " + active
+
+	return `
+		MATCH (org:Organization)
+		WITH org, coalesce(org.active_scan_id, '') AS scan_id
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: scan_id})
+		RETURN org.login AS organization,
+			org.name AS name,
+			coalesce(org.provider, 'github') AS provider,
+			org.active_scan_id AS active_scan_id,
+			scan.activated_at AS last_scanned_at,
+			` + buildCountExpression(version, "(org)-[r:OWNS]->(:Repository)", active) + ` AS repositories,
+			` + buildCountExpression(version, "(org)-[owns:OWNS]->(:Repository)-[r:HAS_CODEOWNERS_FILE]->(:CodeownersBlob)", owned) + ` AS repositories_with_codeowners,
+			` + buildCountExpression(version, "(org)-[r:HAS_TEAM]->(:Team)", active) + ` AS teams
+		ORDER BY organization
+	`
+}
+
+// normalizeScanOrganizations trims and de-duplicates organization logins, case-insensitively and in
+// request order, returning the first invalid login (Pure Core)
+func normalizeScanOrganizations(organizations []string) ([]string, string) {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(organizations))
+	for _, organization := range organizations {
+		login := strings.TrimSpace(organization)
+		if !archiveOrganizationPattern.MatchString(login) {
+			return nil, organization
+		}
+		if key := strings.ToLower(login); !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, login)
+		}
+	}
+	return normalized, ""
+}
+
+// convertToOrganizationSummary converts an organization list record (Pure Core)
+func convertToOrganizationSummary(record map[string]interface{}) OrganizationSummary {
+	summary := OrganizationSummary{
+		Organization:               getStringFromMap(record, "organization"),
+		Name:                       getStringFromMap(record, "name"),
+		Provider:                   getStringFromMap(record, "provider"),
+		ActiveScanID:               getStringFromMap(record, "active_scan_id"),
+		LastScannedAt:              getStringFromMap(record, "last_scanned_at"),
+		Repositories:               getIntFromMap(record, "repositories"),
+		RepositoriesWithCodeowners: getIntFromMap(record, "repositories_with_codeowners"),
+		Teams:                      getIntFromMap(record, "teams"),
+	}
+	summary.CoveragePercent = roundPercent(summary.RepositoriesWithCodeowners, summary.Repositories)
+	return summary
+}
+
+// fetchEnterpriseOrganizations lists the logins of an enterprise's organizations, 100 per request
+func fetchEnterpriseOrganizations(ctx *gofr.Context, enterprise string) ([]string, error) {
+	var logins []string
+	var cursor interface{}
+	for {
+		var data struct {
+			Enterprise *struct {
+				Organizations struct {
+					PageInfo graphQLPageInfo `json:"pageInfo"`
+					Nodes    []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"organizations"`
+			} `json:"enterprise"`
+		}
+		variables := map[string]interface{}{"slug": enterprise, "first": graphQLEnterprisePageSize, "after": cursor}
+		if _, err := executeGitHubGraphQL(ctx, buildEnterpriseOrganizationsGraphQLQuery(), variables, &data); err != nil {
+			return nil, err
+		}
+		if data.Enterprise == nil {
+			return nil, newGitHubAPIError("enterprise_not_found", "enterprise not found", enterprise, http.StatusNotFound)
+		}
+
+		page := data.Enterprise.Organizations
+		for _, node := range page.Nodes {
+			logins = append(logins, node.Login)
+		}
+		if !page.PageInfo.HasNextPage || len(logins) > maxMultiOrgScanOrganizations {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	logInfo(ctx, "Fetched enterprise organizations", LogFields{
+		"component":     "organizations",
+		"operation":     "fetch_enterprise_organizations",
+		"enterprise":    enterprise,
+		"organizations": len(logins),
+	})
+	return logins, nil
+}
+
+// enqueueMultiOrgScan queues a background scan for each organization; organizations already being
+// scanned are reported as rejected rather than failing the request (Orchestrator)
+func enqueueMultiOrgScan(ctx *gofr.Context, deps *AppDependencies, request MultiOrgScanRequest, organizations []string) MultiOrgScanResponse {
+	config := deps.currentConfig()
+	useTopics := config.GitHub.UseTopics
+	if request.UseTopics != nil {
+		useTopics = *request.UseTopics
+	}
+
+	response := MultiOrgScanResponse{Enterprise: request.Enterprise, Jobs: []ScanJob{}, Rejected: []MultiOrgScanRejection{}}
+	for _, organization := range organizations {
+		job, err := enqueueScanJob(ctx, deps, ScanRequest{
+			Organization: organization,
+			MaxRepos:     request.MaxRepos,
+			MaxTeams:     request.MaxTeams,
+			UseTopics:    useTopics,
+			Mode:         request.Mode,
+			Provider:     request.Provider,
+		})
+		if err != nil {
+			rejection := buildScanJobError(err)
+			response.Rejected = append(response.Rejected, MultiOrgScanRejection{Organization: organization, Error: rejection.Message, StatusCode: rejection.StatusCode})
+			continue
+		}
+		response.Jobs = append(response.Jobs, job)
+	}
+
+	logInfo(ctx, "Multi-organization scan queued", LogFields{
+		"component":  "organizations",
+		"operation":  "enqueue_multi_org_scan",
+		"enterprise": request.Enterprise,
+		"queued":     len(response.Jobs),
+		"rejected":   len(response.Rejected),
+	})
+	return response
+}
+
+// listOrganizations returns every organization in the graph with its last scan and basic stats (Orchestrator)
+func listOrganizations(ctx *gofr.Context, deps *AppDependencies) (OrganizationListResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OrganizationListResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOrganizationListQuery(session.version), nil)
+	if err != nil {
+		return OrganizationListResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	response := OrganizationListResponse{Organizations: make([]OrganizationSummary, 0, len(result.Records))}
+	for _, record := range result.Records {
+		summary := convertToOrganizationSummary(record)
+		summary.Scanning = deps.Scans.isRunning(summary.Organization)
+		response.Organizations = append(response.Organizations, summary)
+	}
+	return response, nil
+}
+
+// handleScanOrganizations queues background scans of several organizations or of every organization
+// of a GitHub enterprise
+func (h *AppHandler) handleScanOrganizations(ctx *gofr.Context) (interface{}, error) {
+	var request MultiOrgScanRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+	if len(request.Organizations) == 0 && request.Enterprise == "" {
+		return nil, createMissingParamError("organizations")
+	}
+	if len(request.Organizations) > 0 && request.Enterprise != "" {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"enterprise"}}
+	}
+	if !isValidScanMode(request.Mode) {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"mode"}}
+	}
+	if err := validateScanProvider(request.Provider, h.deps.currentConfig()); err != nil {
+		return nil, err
+	}
+	if request.Enterprise != "" && resolveScanProvider(ScanRequest{Provider: request.Provider}, h.deps.currentConfig()) != scmProviderGitHub {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"enterprise"}}
+	}
+	if request.MaxRepos == 0 {
+		request.MaxRepos = 100
+	}
+	if request.MaxTeams == 0 {
+		request.MaxTeams = 50
+	}
+	if request.MaxRepos < 0 || request.MaxTeams < 0 {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"max_repos", "max_teams"}}
+	}
+
+	organizations := request.Organizations
+	if request.Enterprise != "" {
+		var err error
+		if organizations, err = fetchEnterpriseOrganizations(ctx, request.Enterprise); err != nil {
+			var apiErr GitHubAPIError
+			if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusNotFound {
+				return nil, &gofrhttp.ErrorEntityNotFound{Name: "enterprise", Value: request.Enterprise}
+			}
+			return nil, err
+		}
+	}
+
+	organizations, invalid := normalizeScanOrganizations(organizations)
+	if invalid != "" {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"organizations"}}
+	}
+	if len(organizations) > maxMultiOrgScanOrganizations {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"organizations"}}
+	}
+
+	return enqueueMultiOrgScan(ctx, h.deps, request, organizations), nil
+}
+
+// handleListOrganizations lists the scanned organizations
+func (h *AppHandler) handleListOrganizations(ctx *gofr.Context) (interface{}, error) {
+	return listOrganizations(ctx, h.deps)
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// orphanTrendLimit is the number of past analyses returned as the trend of an organization
+const orphanTrendLimit = 10
+
+// Reasons a CODEOWNERS owner is orphaned. GitHub has no archived state for teams, so archived,
+// deleted and renamed teams all show as a missing team.
+const (
+	orphanReasonDeletedUser = "deleted_user"
+	orphanReasonLeftOrg     = "left_organization"
+	orphanReasonMissingTeam = "missing_team"
+)
+
+// OrphanRule is a CODEOWNERS rule naming an orphaned owner
+type OrphanRule struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Pattern    string `json:"pattern"`
+}
+
+// OrphanFinding is an owner named in CODEOWNERS that no longer resolves to a member or team
+type OrphanFinding struct {
+	Owner        string       `json:"owner"`
+	Kind         string       `json:"kind"`
+	Reason       string       `json:"reason"`
+	Repositories []string     `json:"repositories"`
+	Rules        []OrphanRule `json:"rules"`
+}
+
+// OrphanSummary counts the orphaned owners of an analysis by reason
+type OrphanSummary struct {
+	OrphanedOwners int            `json:"orphaned_owners"`
+	OrphanedRules  int            `json:"orphaned_rules"`
+	ByReason       map[string]int `json:"by_reason"`
+}
+
+// OrphanTrendPoint is a past orphan analysis of an organization
+type OrphanTrendPoint struct {
+	ID             string `json:"id"`
+	ScanID         string `json:"scan_id"`
+	AnalyzedAt     string `json:"analyzed_at"`
+	OwnersChecked  int    `json:"owners_checked"`
+	OrphanedOwners int    `json:"orphaned_owners"`
+	OrphanedRules  int    `json:"orphaned_rules"`
+}
+
+// OrphanAnalysisResponse lists the CODEOWNERS owners of an organization that no longer exist in it
+type OrphanAnalysisResponse struct {
+	ID                  string             `json:"id"`
+	Organization        string             `json:"organization"`
+	ScanID              string             `json:"scan_id"`
+	AnalyzedAt          string             `json:"analyzed_at"`
+	RepositoriesChecked int                `json:"repositories_checked"`
+	OwnersChecked       int                `json:"owners_checked"`
+	Summary             OrphanSummary      `json:"summary"`
+	Findings            []OrphanFinding    `json:"findings"`
+	Trend               []OrphanTrendPoint `json:"trend"`
+}
+
+// codeownersFileRecord is a CODEOWNERS file of the active scan
+type codeownersFileRecord struct {
+	repository string
+	path       string
+	content    string
+}
+
+// ownerReferences is a distinct CODEOWNERS owner with every rule naming it
+type ownerReferences struct {
+	owner string
+	kind  string
+	rules []OrphanRule
+}
+
+// buildOrphanCodeownersQuery builds a query returning the CODEOWNERS files of an organization's active
+// scan; an organization without any returns one row with a null full_name (Pure Core)
+func buildOrphanCodeownersQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[file:HAS_CODEOWNERS_FILE]->(blob:CodeownersBlob)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND coalesce(file.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN org.active_scan_id AS scan_id, repo.full_name AS full_name, file.path AS path, blob.content AS content
+		ORDER BY full_name
+	`
+}
+
+// buildStoreOrphanAnalysisQuery builds a query storing an orphan analysis and its findings under the
+// organization for trend tracking (Pure Core)
+func buildStoreOrphanAnalysisQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		MERGE (analysis:OrphanAnalysis {id: $id})
+		ON CREATE SET analysis.organization = $orgName,
+			analysis.scan_id = $scan_id,
+			analysis.analyzed_at = $analyzed_at,
+			analysis.owners_checked = $owners_checked,
+			analysis.orphaned_owners = $orphaned_owners,
+			analysis.orphaned_rules = $orphaned_rules
+		MERGE (org)-[:HAS_ORPHAN_ANALYSIS]->(analysis)
+		FOREACH (row IN $findings |
+			MERGE (finding:OrphanFinding {id: $id + '|' + row.owner})
+			ON CREATE SET finding.organization = $orgName,
+				finding.owner = row.owner,
+				finding.kind = row.kind,
+				finding.reason = row.reason,
+				finding.rule_count = row.rule_count,
+				finding.repositories = row.repositories
+			MERGE (analysis)-[:FOUND]->(finding)
+		)
+		RETURN analysis.id AS id
+	`
+}
+
+// buildOrphanTrendQuery builds a query returning the latest orphan analyses of an organization, newest first (Pure Core)
+func buildOrphanTrendQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[:HAS_ORPHAN_ANALYSIS]->(analysis:OrphanAnalysis)
+		RETURN analysis.id AS id,
+			analysis.scan_id AS scan_id,
+			analysis.analyzed_at AS analyzed_at,
+			analysis.owners_checked AS owners_checked,
+			analysis.orphaned_owners AS orphaned_owners,
+			analysis.orphaned_rules AS orphaned_rules
+		ORDER BY analysis.analyzed_at DESC
+		LIMIT $limit
+	`
+}
+
+// collectOwnerReferences lists the distinct user and team owners of CODEOWNERS files with the rules
+// naming them, in first-seen order; e-mail and malformed owners are left out (Pure Core)
+func collectOwnerReferences(files []codeownersFileRecord) []*ownerReferences {
+	byOwner := make(map[string]*ownerReferences)
+	var owners []*ownerReferences
+	for _, file := range files {
+		for _, line := range parseCodeownersLines(file.content) {
+			for _, owner := range line.owners {
+				kind := codeownersOwnerKind(owner)
+				if kind != "user" && kind != "team" {
+					continue
+				}
+
+				key := strings.ToLower(owner)
+				refs, exists := byOwner[key]
+				if !exists {
+					refs = &ownerReferences{owner: owner, kind: kind}
+					byOwner[key] = refs
+					owners = append(owners, refs)
+				}
+				refs.rules = append(refs.rules, OrphanRule{Repository: file.repository, Path: file.path, Line: line.line, Pattern: line.pattern})
+			}
+		}
+	}
+	return owners
+}
+
+// buildOrphanFindings turns the owners with an orphan reason into findings and counts them (Pure Core)
+func buildOrphanFindings(owners []*ownerReferences, reasons map[string]string) ([]OrphanFinding, OrphanSummary) {
+	findings := []OrphanFinding{}
+	summary := OrphanSummary{ByReason: map[string]int{}}
+	for _, refs := range owners {
+		reason := reasons[strings.ToLower(refs.owner)]
+		if reason == "" {
+			continue
+		}
+
+		var repositories []string
+		seen := make(map[string]bool)
+		for _, rule := range refs.rules {
+			if !seen[rule.Repository] {
+				seen[rule.Repository] = true
+				repositories = append(repositories, rule.Repository)
+			}
+		}
+		sort.Strings(repositories)
+
+		findings = append(findings, OrphanFinding{Owner: refs.owner, Kind: refs.kind, Reason: reason, Repositories: repositories, Rules: refs.rules})
+		summary.OrphanedOwners++
+		summary.OrphanedRules += len(refs.rules)
+		summary.ByReason[reason]++
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if len(findings[i].Rules) != len(findings[j].Rules) {
+			return len(findings[i].Rules) > len(findings[j].Rules)
+		}
+		return strings.ToLower(findings[i].Owner) < strings.ToLower(findings[j].Owner)
+	})
+	return findings, summary
+}
+
+// classifyOrphanedOwner returns why an owner no longer resolves in the organization, or "" when it
+// does. Users outside the organization are told apart by whether their account still exists, so
+// outside collaborators show as having left it; teams of other organizations are not checked.
+func classifyOrphanedOwner(ctx *gofr.Context, orgName string, refs *ownerReferences) (string, error) {
+	if refs.kind == "team" {
+		teamOrg, _, _ := strings.Cut(strings.TrimPrefix(refs.owner, "@"), "/")
+		if !strings.EqualFold(teamOrg, orgName) {
+			return "", nil
+		}
+	}
+
+	exists, err := lookupCodeownersOwner(ctx, orgName, refs.owner)
+	if err != nil || exists {
+		return "", err
+	}
+	if refs.kind == "team" {
+		return orphanReasonMissingTeam, nil
+	}
+
+	endpoint := "users/" + strings.TrimPrefix(refs.owner, "@")
+	resp, err := githubStatusRequest(ctx, endpoint, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return orphanReasonLeftOrg, nil
+	case http.StatusNotFound:
+		return orphanReasonDeletedUser, nil
+	}
+	return "", unexpectedOwnerLookupStatus(endpoint, resp.StatusCode)
+}
+
+// fetchOrphanCodeownersFiles reads the CODEOWNERS files of the active scan and its ID (Orchestrator)
+func fetchOrphanCodeownersFiles(ctx *gofr.Context, session *Neo4jSession, orgName string) ([]codeownersFileRecord, string, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildOrphanCodeownersQuery(), map[string]interface{}{"orgName": orgName})
+	if err != nil {
+		return nil, "", convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return nil, "", &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	var files []codeownersFileRecord
+	for _, record := range result.Records {
+		if name := getStringFromMap(record, "full_name"); name != "" {
+			files = append(files, codeownersFileRecord{
+				repository: name,
+				path:       getStringFromMap(record, "path"),
+				content:    getStringFromMap(record, "content"),
+			})
+		}
+	}
+	return files, getStringFromMap(result.Records[0], "scan_id"), nil
+}
+
+// storeOrphanAnalysis stores an analysis and its findings, then returns the organization's trend (Orchestrator)
+func storeOrphanAnalysis(ctx *gofr.Context, session *Neo4jSession, response OrphanAnalysisResponse) ([]OrphanTrendPoint, error) {
+	rows := make([]map[string]interface{}, 0, len(response.Findings))
+	for _, finding := range response.Findings {
+		rows = append(rows, map[string]interface{}{
+			"owner":        finding.Owner,
+			"kind":         finding.Kind,
+			"reason":       finding.Reason,
+			"rule_count":   len(finding.Rules),
+			"repositories": finding.Repositories,
+		})
+	}
+
+	if _, err := executeNeo4jWrite(ctx, session, buildStoreOrphanAnalysisQuery(), map[string]interface{}{
+		"orgName":         response.Organization,
+		"id":              response.ID,
+		"scan_id":         response.ScanID,
+		"analyzed_at":     response.AnalyzedAt,
+		"owners_checked":  response.OwnersChecked,
+		"orphaned_owners": response.Summary.OrphanedOwners,
+		"orphaned_rules":  response.Summary.OrphanedRules,
+		"findings":        rows,
+	}); err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOrphanTrendQuery(), map[string]interface{}{
+		"orgName": response.Organization,
+		"limit":   orphanTrendLimit,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	trend := make([]OrphanTrendPoint, 0, len(result.Records))
+	for _, record := range result.Records {
+		trend = append(trend, OrphanTrendPoint{
+			ID:             getStringFromMap(record, "id"),
+			ScanID:         getStringFromMap(record, "scan_id"),
+			AnalyzedAt:     getStringFromMap(record, "analyzed_at"),
+			OwnersChecked:  getIntFromMap(record, "owners_checked"),
+			OrphanedOwners: getIntFromMap(record, "orphaned_owners"),
+			OrphanedRules:  getIntFromMap(record, "orphaned_rules"),
+		})
+	}
+	return trend, nil
+}
+
+// analyzeOrphanedOwners checks every user and team named in the active scan's CODEOWNERS files against
+// the organization on GitHub and stores the owners that no longer resolve (Orchestrator)
+func analyzeOrphanedOwners(ctx *gofr.Context, deps *AppDependencies, orgName string) (OrphanAnalysisResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OrphanAnalysisResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	files, scanID, err := fetchOrphanCodeownersFiles(ctx, session, orgName)
+	if err != nil {
+		return OrphanAnalysisResponse{}, err
+	}
+
+	owners := collectOwnerReferences(files)
+	reasons := make(map[string]string)
+	for _, refs := range owners {
+		reason, err := classifyOrphanedOwner(ctx, orgName, refs)
+		if err != nil {
+			return OrphanAnalysisResponse{}, err
+		}
+		reasons[strings.ToLower(refs.owner)] = reason
+	}
+
+	now := time.Now().UTC()
+	findings, summary := buildOrphanFindings(owners, reasons)
+	response := OrphanAnalysisResponse{
+		ID:                  fmt.Sprintf("%s-orphans-%d", orgName, now.UnixNano()),
+		Organization:        orgName,
+		ScanID:              scanID,
+		AnalyzedAt:          now.Format(time.RFC3339),
+		RepositoriesChecked: len(files),
+		OwnersChecked:       len(owners),
+		Summary:             summary,
+		Findings:            findings,
+	}
+
+	if response.Trend, err = storeOrphanAnalysis(ctx, session, response); err != nil {
+		return OrphanAnalysisResponse{}, err
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordGauge("codeowners_orphaned_owners", float64(summary.OrphanedOwners), MetricLabels{
+		"organization": orgName,
+	})
+	logInfo(ctx, "Analyzed orphaned CODEOWNERS owners", LogFields{
+		"component":       "orphan_analysis",
+		"operation":       "analyze_orphaned_owners",
+		"organization":    orgName,
+		"scan_id":         scanID,
+		"owners_checked":  response.OwnersChecked,
+		"orphaned_owners": summary.OrphanedOwners,
+		"orphaned_rules":  summary.OrphanedRules,
+	})
+	return response, nil
+}
+
+// handleGetOrphanAnalysis flags CODEOWNERS rules naming deleted users, users who left the organization
+// and missing teams, recording the findings for trend tracking
+func (h *AppHandler) handleGetOrphanAnalysis(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return analyzeOrphanedOwners(ctx, h.deps, orgName)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// ownerLoginPattern matches GitHub user logins, including app bot accounts such as dependabot[bot]
+var ownerLoginPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,38}(\[bot\])?$`)
+
+// OwnerAliasRequest merges user logins into a canonical login
+type OwnerAliasRequest struct {
+	Canonical string   `json:"canonical"`
+	Aliases   []string `json:"aliases"`
+}
+
+// OwnerAlias represents a canonical user login and the logins merged into it
+type OwnerAlias struct {
+	Canonical string   `json:"canonical"`
+	Aliases   []string `json:"aliases"`
+}
+
+// OwnerAliasesResponse lists every canonical login with aliases
+type OwnerAliasesResponse struct {
+	Aliases []OwnerAlias `json:"aliases"`
+}
+
+// OwnerAliasConflictError is returned when the canonical login is itself an alias of another login
+type OwnerAliasConflictError struct {
+	Login     string
+	Canonical string
+}
+
+// Error implements the error interface for OwnerAliasConflictError
+func (e OwnerAliasConflictError) Error() string {
+	return fmt.Sprintf("%s is an alias of %s; use %s as the canonical login", e.Login, e.Canonical, e.Canonical)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (OwnerAliasConflictError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// canonicalUserExpression resolves a User variable to the canonical user it is an alias of, or to
+// itself; teams and null values are returned unchanged (Pure Core)
+func canonicalUserExpression(variable string) string {
+	return fmt.Sprintf("coalesce(head([(%[1]s)-[:ALIAS_OF]->(canonical_user:User) | canonical_user]), %[1]s)", variable)
+}
+
+// normalizeOwnerLogin trims whitespace and a leading @ from a user login (Pure Core)
+func normalizeOwnerLogin(login string) string {
+	return strings.TrimPrefix(strings.TrimSpace(login), "@")
+}
+
+// normalizeOwnerAliasRequest normalizes the logins of an alias request, dropping blank and repeated aliases (Pure Core)
+func normalizeOwnerAliasRequest(request OwnerAliasRequest) OwnerAliasRequest {
+	normalized := OwnerAliasRequest{Canonical: normalizeOwnerLogin(request.Canonical), Aliases: []string{}}
+	seen := make(map[string]bool, len(request.Aliases))
+	for _, alias := range request.Aliases {
+		alias = normalizeOwnerLogin(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		normalized.Aliases = append(normalized.Aliases, alias)
+	}
+	return normalized
+}
+
+// validateOwnerAliasRequest checks that the canonical login and every alias are valid, distinct user logins (Pure Core)
+func validateOwnerAliasRequest(request OwnerAliasRequest) error {
+	missing := []string{}
+	if request.Canonical == "" {
+		missing = append(missing, "canonical")
+	}
+	if len(request.Aliases) == 0 {
+		missing = append(missing, "aliases")
+	}
+	if len(missing) > 0 {
+		return &gofrhttp.ErrorMissingParam{Params: missing}
+	}
+
+	if !ownerLoginPattern.MatchString(request.Canonical) {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"canonical"}}
+	}
+	for _, alias := range request.Aliases {
+		if !ownerLoginPattern.MatchString(alias) || strings.EqualFold(alias, request.Canonical) {
+			return &gofrhttp.ErrorInvalidParam{Params: []string{"aliases"}}
+		}
+	}
+	return nil
+}
+
+// buildOwnerAliasParams converts alias logins to Cypher parameters, with the user fields scans set on
+// the users they create (Pure Core)
+func buildOwnerAliasParams(logins []string) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(logins))
+	for _, login := range logins {
+		rows = append(rows, map[string]interface{}{
+			"login": login,
+			"id":    generateUserID(login),
+			"url":   fmt.Sprintf("https://github.com/%s", login),
+			"bot":   isBotLogin(login),
+		})
+	}
+	return rows
+}
+
+// buildCanonicalOfQuery builds a query returning the login a user is an alias of (Pure Core)
+func buildCanonicalOfQuery() string {
+	return `
+		MATCH (:User {login: $login})-[:ALIAS_OF]->(canonical:User)
+		RETURN canonical.login AS canonical
+	`
+}
+
+// buildMergeOwnerAliasesQuery builds a query pointing every alias, and the aliases already merged into
+// it, at the canonical user. Users are created when missing and their ownership relationships are
+// left untouched (Pure Core)
+func buildMergeOwnerAliasesQuery() string {
+	return `
+		MERGE (canonical:User {login: $canonical.login})
+		ON CREATE SET canonical.id = $canonical.id, canonical.name = $canonical.login, canonical.url = $canonical.url, canonical.bot = $canonical.bot
+		WITH canonical
+		UNWIND $aliases AS row
+		MERGE (alias:User {login: row.login})
+		ON CREATE SET alias.id = row.id, alias.name = row.login, alias.url = row.url, alias.bot = row.bot
+		WITH canonical, alias
+		OPTIONAL MATCH (alias)-[previous:ALIAS_OF]->()
+		DELETE previous
+		WITH DISTINCT canonical, alias
+		MERGE (alias)-[:ALIAS_OF]->(canonical)
+		WITH DISTINCT canonical, alias
+		OPTIONAL MATCH (inherited:User)-[moved:ALIAS_OF]->(alias)
+		FOREACH (_ IN CASE WHEN moved IS NULL THEN [] ELSE [1] END |
+			DELETE moved
+			MERGE (inherited)-[:ALIAS_OF]->(canonical)
+		)
+		WITH DISTINCT canonical
+		RETURN canonical.login AS canonical,
+			[(alias:User)-[:ALIAS_OF]->(canonical) | alias.login] AS aliases
+	`
+}
+
+// buildListOwnerAliasesQuery builds a query returning every canonical user with its aliases (Pure Core)
+func buildListOwnerAliasesQuery() string {
+	return `
+		MATCH (alias:User)-[:ALIAS_OF]->(canonical:User)
+		WITH canonical, alias
+		ORDER BY alias.login
+		RETURN canonical.login AS canonical, collect(alias.login) AS aliases
+		ORDER BY canonical
+	`
+}
+
+// buildDeleteOwnerAliasQuery builds a query separating a login from its canonical user (Pure Core)
+func buildDeleteOwnerAliasQuery() string {
+	return `
+		MATCH (alias:User {login: $login})-[link:ALIAS_OF]->(canonical:User)
+		DELETE link
+		RETURN canonical.login AS canonical
+	`
+}
+
+// convertToOwnerAliases converts alias records into canonical logins with their aliases (Pure Core)
+func convertToOwnerAliases(records []map[string]interface{}) []OwnerAlias {
+	aliases := make([]OwnerAlias, 0, len(records))
+	for _, record := range records {
+		aliases = append(aliases, OwnerAlias{
+			Canonical: getStringFromMap(record, "canonical"),
+			Aliases:   getStringSliceFromMap(record, "aliases"),
+		})
+	}
+	return aliases
+}
+
+// mergeOwnerAliases merges the aliases of a request into its canonical login
+func mergeOwnerAliases(ctx *gofr.Context, deps *AppDependencies, request OwnerAliasRequest) (OwnerAlias, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnerAlias{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	existing, err := executeNeo4jReadQuery(ctx, session, buildCanonicalOfQuery(), map[string]interface{}{"login": request.Canonical})
+	if err != nil {
+		return OwnerAlias{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(existing.Records) > 0 {
+		return OwnerAlias{}, OwnerAliasConflictError{Login: request.Canonical, Canonical: getStringFromMap(existing.Records[0], "canonical")}
+	}
+
+	result, err := executeNeo4jWrite(ctx, session, buildMergeOwnerAliasesQuery(), map[string]interface{}{
+		"canonical": buildOwnerAliasParams([]string{request.Canonical})[0],
+		"aliases":   buildOwnerAliasParams(request.Aliases),
+	})
+	if err != nil {
+		return OwnerAlias{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	merged := OwnerAlias{Canonical: request.Canonical, Aliases: request.Aliases}
+	if aliases := convertToOwnerAliases(result.Records); len(aliases) > 0 {
+		merged = aliases[0]
+	}
+
+	logInfo(ctx, "Owner aliases merged", LogFields{
+		"component":     "admin",
+		"operation":     "merge_owner_aliases",
+		"canonical":     merged.Canonical,
+		"aliases_count": len(merged.Aliases),
+	})
+	return merged, nil
+}
+
+// listOwnerAliases lists every canonical login with its aliases
+func listOwnerAliases(ctx *gofr.Context, deps *AppDependencies) (OwnerAliasesResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnerAliasesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildListOwnerAliasesQuery(), nil)
+	if err != nil {
+		return OwnerAliasesResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	return OwnerAliasesResponse{Aliases: convertToOwnerAliases(result.Records)}, nil
+}
+
+// deleteOwnerAlias separates a login from the canonical login it was merged into
+func deleteOwnerAlias(ctx *gofr.Context, deps *AppDependencies, login string) (OwnerAlias, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnerAlias{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jWrite(ctx, session, buildDeleteOwnerAliasQuery(), map[string]interface{}{"login": login})
+	if err != nil {
+		return OwnerAlias{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return OwnerAlias{}, &gofrhttp.ErrorEntityNotFound{Name: "alias", Value: login}
+	}
+	return OwnerAlias{Canonical: getStringFromMap(result.Records[0], "canonical"), Aliases: []string{login}}, nil
+}
+
+// handleMergeOwnerAliases merges user logins into one canonical identity for the graph, stats and insights
+func (h *AppHandler) handleMergeOwnerAliases(ctx *gofr.Context) (interface{}, error) {
+	var request OwnerAliasRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	request = normalizeOwnerAliasRequest(request)
+	if err := validateOwnerAliasRequest(request); err != nil {
+		return nil, err
+	}
+
+	return mergeOwnerAliases(ctx, h.deps, request)
+}
+
+// handleListOwnerAliases lists every canonical login with its aliases
+func (h *AppHandler) handleListOwnerAliases(ctx *gofr.Context) (interface{}, error) {
+	return listOwnerAliases(ctx, h.deps)
+}
+
+// handleDeleteOwnerAlias separates an alias from its canonical login
+func (h *AppHandler) handleDeleteOwnerAlias(ctx *gofr.Context) (interface{}, error) {
+	login := normalizeOwnerLogin(ctx.PathParam("login"))
+	if login == "" {
+		return nil, createMissingParamError("login")
+	}
+
+	return deleteOwnerAlias(ctx, h.deps, login)
+}
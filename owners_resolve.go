@@ -0,0 +1,162 @@
+package main
+
+import (
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// maxOwnerResolveItems caps the number of paths resolved in one request
+const maxOwnerResolveItems = 10000
+
+// OwnerResolveRequest lists the repository paths whose owners are resolved
+type OwnerResolveRequest struct {
+	Items []OwnerResolveItem `json:"items"`
+}
+
+// OwnerResolveItem is a path within a repository given as "org/repo"
+type OwnerResolveItem struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+}
+
+// OwnerResolveResult is the effective ownership of a single path
+type OwnerResolveResult struct {
+	Repo    string   `json:"repo"`
+	Path    string   `json:"path"`
+	Found   bool     `json:"found"`
+	Owners  []string `json:"owners"`
+	Pattern string   `json:"pattern,omitempty"`
+	Line    int      `json:"line,omitempty"`
+}
+
+// OwnerResolveResponse holds results in request order
+type OwnerResolveResponse struct {
+	Results      []OwnerResolveResult `json:"results"`
+	Repositories int                  `json:"repositories"`
+	Unknown      []string             `json:"unknown_repositories,omitempty"`
+}
+
+// buildRepositoryRulesQuery builds a query returning the stored CODEOWNERS rules of many repositories (Pure Core)
+func buildRepositoryRulesQuery() string {
+	return `
+		UNWIND $repos AS full_name
+		MATCH (org:Organization)-[owns:OWNS]->(repo:Repository {full_name: full_name})
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN repo.full_name AS repository,
+			collect(CASE WHEN owner IS NULL THEN NULL ELSE {
+				pattern: rule.pattern,
+				line: rule.line,
+				owner: CASE WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END
+			} END) AS rules
+	`
+}
+
+// validateOwnerResolveRequest checks the item count and that every item names a repository and path (Pure Core)
+func validateOwnerResolveRequest(request OwnerResolveRequest) error {
+	if len(request.Items) == 0 {
+		return &gofrhttp.ErrorMissingParam{Params: []string{"items"}}
+	}
+	if len(request.Items) > maxOwnerResolveItems {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"items"}}
+	}
+	for _, item := range request.Items {
+		if item.Repo == "" || item.Path == "" {
+			return &gofrhttp.ErrorInvalidParam{Params: []string{"items"}}
+		}
+	}
+	return nil
+}
+
+// distinctRepositories returns the repositories of the items in first-seen order (Pure Core)
+func distinctRepositories(items []OwnerResolveItem) []string {
+	seen := make(map[string]bool)
+	var repos []string
+	for _, item := range items {
+		if !seen[item.Repo] {
+			seen[item.Repo] = true
+			repos = append(repos, item.Repo)
+		}
+	}
+	return repos
+}
+
+// resolveOwnerItems resolves every item against its repository's matcher (Pure Core)
+func resolveOwnerItems(items []OwnerResolveItem, matchers map[string]*CodeownersMatcher) []OwnerResolveResult {
+	results := make([]OwnerResolveResult, 0, len(items))
+	for _, item := range items {
+		result := OwnerResolveResult{Repo: item.Repo, Path: item.Path, Owners: []string{}}
+		if matcher, exists := matchers[item.Repo]; exists {
+			if match, matched := matcher.resolve(item.Path); matched {
+				result.Found = true
+				result.Owners = match.Owners
+				result.Pattern = match.Pattern
+				result.Line = match.Line
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// loadCodeownersMatchers loads and compiles the rules of each repository once (Orchestrator)
+func loadCodeownersMatchers(ctx *gofr.Context, deps *AppDependencies, repos []string) (map[string]*CodeownersMatcher, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildRepositoryRulesQuery(), map[string]interface{}{
+		"repos": repos,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	matchers := make(map[string]*CodeownersMatcher, len(result.Records))
+	for _, record := range result.Records {
+		matchers[getStringFromMap(record, "repository")] = newCodeownersMatcher(convertToCodeownersExportRules(record["rules"]))
+	}
+	return matchers, nil
+}
+
+// handleResolveOwners resolves the effective owners of many repository paths at once
+func (h *AppHandler) handleResolveOwners(ctx *gofr.Context) (interface{}, error) {
+	var request OwnerResolveRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	if err := validateOwnerResolveRequest(request); err != nil {
+		return nil, err
+	}
+
+	repos := distinctRepositories(request.Items)
+	matchers, err := loadCodeownersMatchers(ctx, h.deps, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, repo := range repos {
+		if _, exists := matchers[repo]; !exists {
+			unknown = append(unknown, repo)
+		}
+	}
+
+	logInfo(ctx, "Resolved owners for paths", LogFields{
+		"component":    "owners_resolver",
+		"operation":    "resolve_owners",
+		"items":        len(request.Items),
+		"repositories": len(repos),
+		"unknown":      len(unknown),
+	})
+
+	return OwnerResolveResponse{
+		Results:      resolveOwnerItems(request.Items, matchers),
+		Repositories: len(repos),
+		Unknown:      unknown,
+	}, nil
+}
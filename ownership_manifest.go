@@ -0,0 +1,272 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// OwnershipManifestSchemaVersion is the version of the manifest layout; it changes whenever a field is
+// added, removed or hashed differently, so digests are only compared within one version
+const OwnershipManifestSchemaVersion = "1.0"
+
+// OwnershipManifest is a machine-readable record of who owns what in an organization's active scan.
+// Repositories, rules and owners are sorted, so manifests of unchanged scans differ only in Scan and
+// GeneratedAt, and Digest identifies the ownership data regardless of the scan that found it
+type OwnershipManifest struct {
+	SchemaVersion string                        `json:"schema_version"`
+	Organization  string                        `json:"organization"`
+	Scan          OwnershipManifestScan         `json:"scan"`
+	GeneratedAt   string                        `json:"generated_at"`
+	Digest        string                        `json:"digest"`
+	Summary       OwnershipManifestSummary      `json:"summary"`
+	Repositories  []OwnershipManifestRepository `json:"repositories"`
+}
+
+// OwnershipManifestScan identifies the scan a manifest was built from
+type OwnershipManifestScan struct {
+	ID          string `json:"id,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	ActivatedAt string `json:"activated_at,omitempty"`
+}
+
+// OwnershipManifestSummary counts the contents of a manifest
+type OwnershipManifestSummary struct {
+	Repositories          int `json:"repositories"`
+	RepositoriesWithRules int `json:"repositories_with_rules"`
+	Rules                 int `json:"rules"`
+	DistinctOwners        int `json:"distinct_owners"`
+}
+
+// OwnershipManifestRepository is a repository with its CODEOWNERS file and rules; Digest hashes the
+// file reference and rules, so changed repositories can be found without comparing rules
+type OwnershipManifestRepository struct {
+	FullName   string                  `json:"full_name"`
+	Codeowners *OwnershipManifestFile  `json:"codeowners,omitempty"`
+	Owners     []string                `json:"owners"`
+	Rules      []OwnershipManifestRule `json:"rules"`
+	Digest     string                  `json:"digest"`
+}
+
+// OwnershipManifestFile is the CODEOWNERS file of a repository with its git blob SHA
+type OwnershipManifestFile struct {
+	Path    string `json:"path"`
+	BlobSHA string `json:"blob_sha"`
+}
+
+// OwnershipManifestRule is a CODEOWNERS line with its sorted owners
+type OwnershipManifestRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// buildOwnershipManifestQuery builds a query returning each repository of the active scan with its
+// CODEOWNERS file and owner assignments, plus the scan's metadata on every row (Pure Core)
+func buildOwnershipManifestQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: org.active_scan_id})
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[file:HAS_CODEOWNERS_FILE]->(blob:CodeownersBlob)
+		WHERE coalesce(file.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, scan, repo, file, blob,
+			collect(CASE WHEN owner IS NULL THEN NULL ELSE {
+				pattern: rule.pattern,
+				line: rule.line,
+				owner: CASE WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END
+			} END) AS rules
+		RETURN org.active_scan_id AS scan_id,
+			scan.started_at AS scan_started_at,
+			scan.activated_at AS scan_activated_at,
+			repo.full_name AS repository,
+			file.path AS codeowners_path,
+			blob.sha AS codeowners_sha,
+			rules
+		ORDER BY repository
+	`
+}
+
+// groupOwnershipManifestRules groups owner assignments into CODEOWNERS lines, ordered by line and
+// pattern, with sorted owners compared case-insensitively as GitHub does (Pure Core)
+func groupOwnershipManifestRules(assignments []CodeownersExportRule) []OwnershipManifestRule {
+	type lineKey struct {
+		line    int
+		pattern string
+	}
+	byLine := make(map[lineKey]*OwnershipManifestRule)
+	for _, assignment := range assignments {
+		if assignment.Pattern == "" || assignment.Owner == "" {
+			continue
+		}
+		key := lineKey{assignment.Line, assignment.Pattern}
+		rule, exists := byLine[key]
+		if !exists {
+			rule = &OwnershipManifestRule{Line: assignment.Line, Pattern: assignment.Pattern, Owners: []string{}}
+			byLine[key] = rule
+		}
+		if !lo.ContainsBy(rule.Owners, func(owner string) bool { return strings.EqualFold(owner, assignment.Owner) }) {
+			rule.Owners = append(rule.Owners, assignment.Owner)
+		}
+	}
+
+	rules := make([]OwnershipManifestRule, 0, len(byLine))
+	for _, rule := range byLine {
+		sort.Strings(rule.Owners)
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Line != rules[j].Line {
+			return rules[i].Line < rules[j].Line
+		}
+		return rules[i].Pattern < rules[j].Pattern
+	})
+	return rules
+}
+
+// hashManifestContent returns the sha256 digest of a value's JSON encoding (Pure Core)
+func hashManifestContent(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// buildOwnershipManifestRepository builds a repository entry and its digest (Pure Core)
+func buildOwnershipManifestRepository(fullName, path, blobSHA string, assignments []CodeownersExportRule) OwnershipManifestRepository {
+	repo := OwnershipManifestRepository{
+		FullName: fullName,
+		Owners:   []string{},
+		Rules:    groupOwnershipManifestRules(assignments),
+	}
+	if blobSHA != "" {
+		repo.Codeowners = &OwnershipManifestFile{Path: path, BlobSHA: blobSHA}
+	}
+	for _, rule := range repo.Rules {
+		for _, owner := range rule.Owners {
+			if !lo.ContainsBy(repo.Owners, func(existing string) bool { return strings.EqualFold(existing, owner) }) {
+				repo.Owners = append(repo.Owners, owner)
+			}
+		}
+	}
+	sort.Strings(repo.Owners)
+
+	repo.Digest = hashManifestContent(struct {
+		FullName   string                  `json:"full_name"`
+		Codeowners *OwnershipManifestFile  `json:"codeowners"`
+		Rules      []OwnershipManifestRule `json:"rules"`
+	}{repo.FullName, repo.Codeowners, repo.Rules})
+	return repo
+}
+
+// buildOwnershipManifest assembles the manifest from the records of the manifest query; the digest
+// covers the organization and repository digests only, so two scans finding the same ownership
+// share a digest (Pure Core)
+func buildOwnershipManifest(orgName string, records []map[string]interface{}, generatedAt time.Time) OwnershipManifest {
+	manifest := OwnershipManifest{
+		SchemaVersion: OwnershipManifestSchemaVersion,
+		Organization:  orgName,
+		GeneratedAt:   generatedAt.UTC().Format(time.RFC3339),
+		Repositories:  []OwnershipManifestRepository{},
+	}
+
+	owners := make(map[string]bool)
+	for _, record := range records {
+		manifest.Scan = OwnershipManifestScan{
+			ID:          getStringFromMap(record, "scan_id"),
+			StartedAt:   getStringFromMap(record, "scan_started_at"),
+			ActivatedAt: getStringFromMap(record, "scan_activated_at"),
+		}
+
+		fullName := getStringFromMap(record, "repository")
+		if fullName == "" {
+			continue
+		}
+		repo := buildOwnershipManifestRepository(
+			fullName,
+			getStringFromMap(record, "codeowners_path"),
+			getStringFromMap(record, "codeowners_sha"),
+			convertToCodeownersExportRules(record["rules"]),
+		)
+		manifest.Repositories = append(manifest.Repositories, repo)
+
+		manifest.Summary.Rules += len(repo.Rules)
+		if len(repo.Rules) > 0 {
+			manifest.Summary.RepositoriesWithRules++
+		}
+		for _, owner := range repo.Owners {
+			owners[strings.ToLower(owner)] = true
+		}
+	}
+	sort.Slice(manifest.Repositories, func(i, j int) bool {
+		return manifest.Repositories[i].FullName < manifest.Repositories[j].FullName
+	})
+	manifest.Summary.Repositories = len(manifest.Repositories)
+	manifest.Summary.DistinctOwners = len(owners)
+
+	repositoryDigests := make([]string, 0, len(manifest.Repositories))
+	for _, repo := range manifest.Repositories {
+		repositoryDigests = append(repositoryDigests, repo.FullName+" "+repo.Digest)
+	}
+	manifest.Digest = hashManifestContent(struct {
+		SchemaVersion string   `json:"schema_version"`
+		Organization  string   `json:"organization"`
+		Repositories  []string `json:"repositories"`
+	}{manifest.SchemaVersion, manifest.Organization, repositoryDigests})
+
+	return manifest
+}
+
+// getOwnershipManifest builds the ownership manifest of an organization's active scan (Orchestrator)
+func getOwnershipManifest(ctx *gofr.Context, deps *AppDependencies, orgName string) (OwnershipManifest, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnershipManifest{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOwnershipManifestQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return OwnershipManifest{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return OwnershipManifest{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	return buildOwnershipManifest(orgName, result.Records, time.Now()), nil
+}
+
+// handleGetOwnershipManifest returns the ownership manifest of an organization's active scan
+func (h *AppHandler) handleGetOwnershipManifest(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	manifest, err := getOwnershipManifest(ctx, h.deps, orgName)
+	if err != nil {
+		return nil, err
+	}
+	h.deps.Access.record(orgName)
+
+	logInfo(ctx, "Built ownership manifest", LogFields{
+		"component":    "ownership_manifest",
+		"operation":    "build_manifest",
+		"organization": orgName,
+		"scan_id":      manifest.Scan.ID,
+		"repositories": manifest.Summary.Repositories,
+		"digest":       manifest.Digest,
+	})
+	return manifest, nil
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// xlsxContentType is the media type of an Office Open XML spreadsheet
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ownershipReportHeader is the header row of the ownership report in both formats
+var ownershipReportHeader = []string{"repository", "pattern", "owner", "owner_type", "line", "last_updated"}
+
+// OwnershipReportRow is one owner of one CODEOWNERS pattern; repositories without rules have a single
+// row with an empty pattern and owner, so unowned repositories show up in the report
+type OwnershipReportRow struct {
+	Repository  string
+	Pattern     string
+	Owner       string
+	OwnerType   string
+	Line        int
+	LastUpdated string
+}
+
+// buildOwnershipReportQuery builds a query returning one row per owner of every CODEOWNERS rule in the
+// active scan's repositories (Pure Core)
+func buildOwnershipReportQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN repo.full_name AS repository,
+			rule.pattern AS pattern,
+			CASE WHEN owner IS NULL THEN NULL WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END AS owner,
+			CASE WHEN owner IS NULL THEN NULL WHEN owner:Team THEN 'team' ELSE 'user' END AS owner_type,
+			rule.line AS line,
+			repo.updated_at AS last_updated
+		ORDER BY repository, line, pattern, owner
+	`
+}
+
+// convertOwnershipReportRecord converts a record of the report query into a row (Pure Core)
+func convertOwnershipReportRecord(record map[string]interface{}) OwnershipReportRow {
+	return OwnershipReportRow{
+		Repository:  getStringFromMap(record, "repository"),
+		Pattern:     getStringFromMap(record, "pattern"),
+		Owner:       getStringFromMap(record, "owner"),
+		OwnerType:   getStringFromMap(record, "owner_type"),
+		Line:        getIntFromMap(record, "line"),
+		LastUpdated: getStringFromMap(record, "last_updated"),
+	}
+}
+
+// formatOwnershipReportLine formats a rule's line number; rows without a rule have none (Pure Core)
+func formatOwnershipReportLine(line int) string {
+	if line <= 0 {
+		return ""
+	}
+	return strconv.Itoa(line)
+}
+
+// buildOwnershipReportCSV serializes the report as CSV with timestamps rendered by formatter (Pure Core)
+func buildOwnershipReportCSV(rows []OwnershipReportRow, formatter ReportFormatter) ([]byte, error) {
+	var out bytes.Buffer
+	writer := csv.NewWriter(&out)
+	if err := writer.Write(ownershipReportHeader); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := []string{row.Repository, row.Pattern, row.Owner, row.OwnerType, formatOwnershipReportLine(row.Line), formatter.formatTimestamp(row.LastUpdated)}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return out.Bytes(), writer.Error()
+}
+
+// xlsxColumnName returns the spreadsheet column letters of a zero-based column index (Pure Core)
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// writeXLSXStringCell writes an inline string cell; empty values are left out (Pure Core)
+func writeXLSXStringCell(out *strings.Builder, ref, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(out, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeGraphXML(value))
+}
+
+// buildOwnershipReportSheet builds the worksheet XML of the report, with a bold, frozen header row,
+// line numbers as numeric cells and timestamps rendered by formatter (Pure Core)
+func buildOwnershipReportSheet(rows []OwnershipReportRow, formatter ReportFormatter) string {
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	out.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	out.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	out.WriteString(`<sheetData>`)
+
+	out.WriteString(`<row r="1">`)
+	for i, title := range ownershipReportHeader {
+		fmt.Fprintf(&out, `<c r="%s1" t="inlineStr" s="1"><is><t>%s</t></is></c>`, xlsxColumnName(i), escapeGraphXML(title))
+	}
+	out.WriteString(`</row>`)
+
+	for i, row := range rows {
+		rowNumber := i + 2
+		fmt.Fprintf(&out, `<row r="%d">`, rowNumber)
+		writeXLSXStringCell(&out, fmt.Sprintf("A%d", rowNumber), row.Repository)
+		writeXLSXStringCell(&out, fmt.Sprintf("B%d", rowNumber), row.Pattern)
+		writeXLSXStringCell(&out, fmt.Sprintf("C%d", rowNumber), row.Owner)
+		writeXLSXStringCell(&out, fmt.Sprintf("D%d", rowNumber), row.OwnerType)
+		if row.Line > 0 {
+			fmt.Fprintf(&out, `<c r="E%d"><v>%d</v></c>`, rowNumber, row.Line)
+		}
+		writeXLSXStringCell(&out, fmt.Sprintf("F%d", rowNumber), formatter.formatTimestamp(row.LastUpdated))
+		out.WriteString(`</row>`)
+	}
+
+	out.WriteString(`</sheetData>`)
+	if len(rows) > 0 {
+		fmt.Fprintf(&out, `<autoFilter ref="A1:%s%d"/>`, xlsxColumnName(len(ownershipReportHeader)-1), len(rows)+1)
+	}
+	out.WriteString(`</worksheet>`)
+	return out.String()
+}
+
+// buildOwnershipReportXLSX packages the report as a single-sheet XLSX workbook (Pure Core)
+func buildOwnershipReportXLSX(orgName string, rows []OwnershipReportRow, formatter ReportFormatter) ([]byte, error) {
+	sheetName := orgName
+	if len(sheetName) > 31 {
+		// Excel limits sheet names to 31 characters
+		sheetName = sheetName[:31]
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + escapeGraphXML(sheetName) + `" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`},
+		{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+		{"xl/worksheets/sheet1.xml", buildOwnershipReportSheet(rows, formatter)},
+	}
+
+	var out bytes.Buffer
+	archive := zip.NewWriter(&out)
+	for _, part := range parts {
+		writer, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// fetchOwnershipReport reads the ownership report rows of an organization (Orchestrator)
+func fetchOwnershipReport(ctx *gofr.Context, deps *AppDependencies, orgName string) ([]OwnershipReportRow, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOwnershipReportQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	rows := make([]OwnershipReportRow, 0, len(result.Records))
+	for _, record := range result.Records {
+		rows = append(rows, convertOwnershipReportRecord(record))
+	}
+	return rows, nil
+}
+
+// handleGetOwnershipReportCSV returns the organization's ownership report as CSV
+func (h *AppHandler) handleGetOwnershipReportCSV(ctx *gofr.Context) (interface{}, error) {
+	return h.serveOwnershipReport(ctx, "csv")
+}
+
+// handleGetOwnershipReportXLSX returns the organization's ownership report as an Excel workbook
+func (h *AppHandler) handleGetOwnershipReportXLSX(ctx *gofr.Context) (interface{}, error) {
+	return h.serveOwnershipReport(ctx, "xlsx")
+}
+
+// serveOwnershipReport reads the ownership report and serializes it as CSV or XLSX in the requested
+// timezone and locale
+func (h *AppHandler) serveOwnershipReport(ctx *gofr.Context, format string) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+	formatter, err := reportFormatterFromRequest(ctx, h.deps.currentConfig().ReportFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := fetchOwnershipReport(ctx, h.deps, orgName)
+	if err != nil {
+		return nil, err
+	}
+	h.deps.Access.record(orgName)
+
+	var content []byte
+	contentType := csvContentType
+	if format == "xlsx" {
+		content, err = buildOwnershipReportXLSX(orgName, rows, formatter)
+		contentType = xlsxContentType
+	} else {
+		content, err = buildOwnershipReportCSV(rows, formatter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logInfo(ctx, "Exported ownership report", LogFields{
+		"component":    "ownership_report",
+		"operation":    "export_ownership_report",
+		"organization": orgName,
+		"format":       format,
+		"timezone":     formatter.location.String(),
+		"rows":         len(rows),
+	})
+	return response.File{Content: content, ContentType: contentType}, nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// RepositoryOwnershipTimeline is the CODEOWNERS history of a repository across scans: every version
+// of an owner assignment with the interval it was valid in, and the changes between versions
+type RepositoryOwnershipTimeline struct {
+	Organization string                    `json:"organization"`
+	Repository   string                    `json:"repository"`
+	TrackedSince string                    `json:"tracked_since,omitempty"`
+	Versions     []OwnershipRuleVersion    `json:"versions"`
+	Changes      []OwnershipTimelineChange `json:"changes"`
+}
+
+// OwnershipRuleVersion is an owner assigned by a CODEOWNERS pattern from ValidFrom until ValidTo;
+// versions still in the active scan have no ValidTo
+type OwnershipRuleVersion struct {
+	Pattern   string `json:"pattern"`
+	Owner     string `json:"owner"`
+	OwnerType string `json:"owner_type"`
+	Line      int    `json:"line,omitempty"`
+	ValidFrom string `json:"valid_from"`
+	ValidTo   string `json:"valid_to,omitempty"`
+}
+
+// OwnershipTimelineChange is the set of owner assignments added and removed at one point in time
+type OwnershipTimelineChange struct {
+	At      string                    `json:"at"`
+	Added   []OwnershipTimelineAssign `json:"added"`
+	Removed []OwnershipTimelineAssign `json:"removed"`
+}
+
+// OwnershipTimelineAssign is an owner of a CODEOWNERS pattern
+type OwnershipTimelineAssign struct {
+	Pattern string `json:"pattern"`
+	Owner   string `json:"owner"`
+}
+
+// buildOpenCodeownerVersionsQuery builds a query starting the validity interval of the owner assignments
+// of a newly activated scan. Assignments the previous scan also had keep its valid_from; the previous
+// scan's own relationships may predate versioning, in which case it counts from its activation (Pure Core)
+func buildOpenCodeownerVersionsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository)
+		MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER {scan_id: $scan_id}]->(owner)
+		WHERE r.valid_from IS NULL
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(previous:Scan)
+		WHERE previous.id = org.previous_scan_id
+		OPTIONAL MATCH (repo)-[p:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE p.scan_id = org.previous_scan_id AND type(p) = type(r) AND p.pattern = r.pattern
+		WITH r, head(collect(coalesce(p.valid_from, previous.activated_at))) AS continued_from
+		SET r.valid_from = coalesce(continued_from, $activated_at)
+		RETURN count(r) AS opened
+	`
+}
+
+// buildCloseCodeownerVersionsQuery builds a query ending the owner assignments of the previous scan
+// that the newly activated scan no longer has. Closed versions move their scan_id to last_scan_id,
+// which keeps them out of every active-scan reader and out of pruning (Pure Core)
+func buildCloseCodeownerVersionsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS]->(repo:Repository)
+		WITH DISTINCT org, repo
+		MATCH (repo)-[p:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE p.scan_id = org.previous_scan_id
+		OPTIONAL MATCH (repo)-[next:HAS_CODEOWNER|HAS_TEAM_OWNER {scan_id: $scan_id}]->(owner)
+		WITH org, p, collect(next) AS successors
+		WHERE none(n IN successors WHERE type(n) = type(p) AND n.pattern = p.pattern)
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(previous:Scan)
+		WHERE previous.id = org.previous_scan_id
+		SET p.valid_from = coalesce(p.valid_from, previous.activated_at, $activated_at),
+			p.valid_to = $activated_at,
+			p.last_scan_id = p.scan_id
+		REMOVE p.scan_id
+		RETURN count(p) AS closed
+	`
+}
+
+// buildRetireRepositoryCodeownerVersionsQuery builds a query ending every owner assignment of a
+// repository in the active scan before a webhook rewrites them (Pure Core)
+func buildRetireRepositoryCodeownerVersionsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository {full_name: $full_name})
+		MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER {scan_id: $scan_id}]->()
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		SET r.valid_from = coalesce(r.valid_from, scan.activated_at, $changed_at),
+			r.valid_to = $changed_at,
+			r.last_scan_id = r.scan_id
+		REMOVE r.scan_id
+	`
+}
+
+// buildReopenRepositoryCodeownerVersionsQuery builds a query starting the owner assignments a webhook
+// wrote for a repository; assignments it retired moments before are continued rather than ended (Pure Core)
+func buildReopenRepositoryCodeownerVersionsQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository {full_name: $full_name})
+		MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER {scan_id: $scan_id}]->(owner)
+		WHERE r.valid_from IS NULL
+		OPTIONAL MATCH (repo)-[p:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE p.last_scan_id = $scan_id AND p.valid_to = $changed_at AND type(p) = type(r) AND p.pattern = r.pattern
+		WITH r, collect(p) AS retired
+		SET r.valid_from = coalesce(head([p IN retired | p.valid_from]), $changed_at)
+		FOREACH (p IN retired | DELETE p)
+	`
+}
+
+// buildRepositoryOwnershipTimelineQuery builds a query returning every owner assignment version of a
+// repository, leaving out relationships of staging scans (Pure Core)
+func buildRepositoryOwnershipTimelineQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		OPTIONAL MATCH (repo:Repository {full_name: $fullName})
+		OPTIONAL MATCH (repo)-[v:HAS_CODEOWNER|HAS_TEAM_OWNER]->(owner)
+		WHERE v.valid_from IS NOT NULL AND (v.scan_id IS NULL OR v.scan_id = org.active_scan_id)
+		RETURN repo.full_name AS repository, collect(CASE WHEN owner IS NULL THEN NULL ELSE {
+			pattern: v.pattern,
+			owner: CASE WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END,
+			owner_type: CASE WHEN owner:Team THEN 'team' ELSE 'user' END,
+			line: v.line,
+			valid_from: v.valid_from,
+			valid_to: v.valid_to
+		} END) AS versions
+	`
+}
+
+// convertOwnershipRuleVersions converts the versions of a timeline query record (Pure Core)
+func convertOwnershipRuleVersions(value interface{}) []OwnershipRuleVersion {
+	list, _ := value.([]interface{})
+	versions := make([]OwnershipRuleVersion, 0, len(list))
+	for _, item := range list {
+		version, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		versions = append(versions, OwnershipRuleVersion{
+			Pattern:   getStringFromMap(version, "pattern"),
+			Owner:     getStringFromMap(version, "owner"),
+			OwnerType: getStringFromMap(version, "owner_type"),
+			Line:      getIntFromMap(version, "line"),
+			ValidFrom: getStringFromMap(version, "valid_from"),
+			ValidTo:   getStringFromMap(version, "valid_to"),
+		})
+	}
+	return versions
+}
+
+// buildOwnershipTimeline orders the versions of a repository and derives the assignments added and
+// removed at each point in time, newest first. RFC 3339 UTC timestamps sort as strings. (Pure Core)
+func buildOwnershipTimeline(orgName, fullName string, versions []OwnershipRuleVersion) RepositoryOwnershipTimeline {
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].ValidFrom != versions[j].ValidFrom {
+			return versions[i].ValidFrom < versions[j].ValidFrom
+		}
+		if versions[i].Pattern != versions[j].Pattern {
+			return versions[i].Pattern < versions[j].Pattern
+		}
+		return versions[i].Owner < versions[j].Owner
+	})
+
+	timeline := RepositoryOwnershipTimeline{
+		Organization: orgName,
+		Repository:   fullName,
+		Versions:     versions,
+		Changes:      []OwnershipTimelineChange{},
+	}
+	if len(versions) > 0 {
+		timeline.TrackedSince = versions[0].ValidFrom
+	}
+
+	changes := make(map[string]*OwnershipTimelineChange)
+	changeAt := func(at string) *OwnershipTimelineChange {
+		if changes[at] == nil {
+			changes[at] = &OwnershipTimelineChange{At: at, Added: []OwnershipTimelineAssign{}, Removed: []OwnershipTimelineAssign{}}
+		}
+		return changes[at]
+	}
+	for _, version := range versions {
+		assign := OwnershipTimelineAssign{Pattern: version.Pattern, Owner: version.Owner}
+		added := changeAt(version.ValidFrom)
+		added.Added = append(added.Added, assign)
+		if version.ValidTo != "" {
+			removed := changeAt(version.ValidTo)
+			removed.Removed = append(removed.Removed, assign)
+		}
+	}
+
+	for _, change := range changes {
+		timeline.Changes = append(timeline.Changes, *change)
+	}
+	sort.Slice(timeline.Changes, func(i, j int) bool {
+		return timeline.Changes[i].At > timeline.Changes[j].At
+	})
+	return timeline
+}
+
+// recordCodeownerVersions starts the versions of a newly activated scan's owner assignments and ends
+// those it dropped; it must run before the previous scan's relationships are pruned (Orchestrator)
+func recordCodeownerVersions(ctx context.Context, session *Neo4jSession, orgLogin, scanID, activatedAt string) error {
+	params := map[string]interface{}{
+		"org_login":    orgLogin,
+		"scan_id":      scanID,
+		"activated_at": activatedAt,
+	}
+	for _, query := range []string{buildOpenCodeownerVersionsQuery(), buildCloseCodeownerVersionsQuery()} {
+		if _, err := executeNeo4jWrite(ctx, session, query, params); err != nil {
+			return fmt.Errorf("failed to record CODEOWNERS versions: %w", err)
+		}
+	}
+	return nil
+}
+
+// retireRepositoryCodeownerVersions ends the owner assignments of a repository in the active scan, so a
+// webhook can rewrite or remove them without losing their history (Orchestrator)
+func retireRepositoryCodeownerVersions(ctx context.Context, session *Neo4jSession, orgLogin, scanID, fullName, changedAt string) error {
+	_, err := executeNeo4jWrite(ctx, session, buildRetireRepositoryCodeownerVersionsQuery(), map[string]interface{}{
+		"org_login":  orgLogin,
+		"scan_id":    scanID,
+		"full_name":  fullName,
+		"changed_at": changedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retire CODEOWNERS versions of %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// reopenRepositoryCodeownerVersions starts the owner assignments a webhook wrote for a repository,
+// continuing those retired at changedAt (Orchestrator)
+func reopenRepositoryCodeownerVersions(ctx context.Context, session *Neo4jSession, orgLogin, scanID, fullName, changedAt string) error {
+	_, err := executeNeo4jWrite(ctx, session, buildReopenRepositoryCodeownerVersionsQuery(), map[string]interface{}{
+		"org_login":  orgLogin,
+		"scan_id":    scanID,
+		"full_name":  fullName,
+		"changed_at": changedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reopen CODEOWNERS versions of %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// getRepositoryOwnershipTimeline reads the CODEOWNERS history of a repository (Orchestrator)
+func getRepositoryOwnershipTimeline(ctx *gofr.Context, deps *AppDependencies, orgName, repoName string) (RepositoryOwnershipTimeline, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return RepositoryOwnershipTimeline{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	fullName := orgName + "/" + repoName
+	result, err := executeNeo4jReadQuery(ctx, session, buildRepositoryOwnershipTimelineQuery(), map[string]interface{}{
+		"orgName":  orgName,
+		"fullName": fullName,
+	})
+	if err != nil {
+		return RepositoryOwnershipTimeline{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return RepositoryOwnershipTimeline{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	record := result.Records[0]
+	if getStringFromMap(record, "repository") == "" {
+		return RepositoryOwnershipTimeline{}, &gofrhttp.ErrorEntityNotFound{Name: "repository", Value: fullName}
+	}
+	return buildOwnershipTimeline(orgName, fullName, convertOwnershipRuleVersions(record["versions"])), nil
+}
+
+// handleGetRepositoryOwnershipTimeline returns the CODEOWNERS history of a repository across scans
+func (h *AppHandler) handleGetRepositoryOwnershipTimeline(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	repoName := ctx.PathParam("repo")
+	if repoName == "" {
+		return nil, createMissingParamError("repo")
+	}
+
+	return getRepositoryOwnershipTimeline(ctx, h.deps, orgName, repoName)
+}
@@ -0,0 +1,228 @@
+// Code generated by scripts/generate-client.js from api/openapi.yaml; DO NOT EDIT.
+// Change the spec or packages/shared/client.config.json and run `bun run generate:client`.
+
+import {
+  createRequester,
+  type ApiClientConfig,
+  type RequestOptions,
+} from './http'
+import {
+  CoverageReportResponseSchema,
+  GraphResponseSchema,
+  HealthResponseSchema,
+  OrganizationListResponseSchema,
+  ScanJobSchema,
+  ScanResponseSchema,
+  StatsResponseSchema,
+  type CoverageReportResponse,
+  type GraphResponse,
+  type HealthResponse,
+  type OrganizationListResponse,
+  type ScanJob,
+  type ScanResponse,
+  type StatsResponse,
+} from './schemas'
+
+export {
+  ApiError,
+  type ApiClientConfig,
+  type RequestOptions,
+} from './http'
+
+export interface ScanParams {
+  /** GitHub organization name */
+  readonly org: string
+  /** Maximum number of repositories to scan */
+  readonly max_repos?: number
+  /** Maximum number of teams to scan */
+  readonly max_teams?: number
+  /** Fetch repository topics */
+  readonly use_topics?: boolean
+  /** Rescan everything, or only repositories updated since the active scan started */
+  readonly mode?: 'full' | 'incremental'
+}
+
+export interface StartScanParams {
+  /** GitHub organization name */
+  readonly org: string
+  /** Maximum number of repositories to scan */
+  readonly max_repos?: number
+  /** Maximum number of teams to scan */
+  readonly max_teams?: number
+  /** Fetch repository topics */
+  readonly use_topics?: boolean
+  /** Rescan everything, or only repositories updated since the active scan started */
+  readonly mode?: 'full' | 'incremental'
+}
+
+export interface ScanJobParams {
+  /** Scan job identifier */
+  readonly id: string
+}
+
+export interface GraphParams {
+  /** GitHub organization name */
+  readonly org: string
+  /** Use repository topics instead of teams for graph visualization; ignored when group_by is set */
+  readonly useTopics?: boolean
+  /** Group repositories by teams, topics or both; with both, team edges (has_team, team_owner) and topic edges (has_topic, repo_topic) are returned together */
+  readonly group_by?: 'teams' | 'topics' | 'both'
+  /** Return only the organization and its groups with a repositoryCount each */
+  readonly summarize?: boolean
+}
+
+export interface StatsParams {
+  /** GitHub organization name */
+  readonly org: string
+}
+
+export interface CoverageParams {
+  /** GitHub organization name */
+  readonly org: string
+  /** Number of repositories covered */
+  readonly limit?: number
+  /** Cover a single repository */
+  readonly repository?: string
+}
+
+export interface ApiClient {
+  /** Health check endpoint */
+  readonly health: (
+    options?: RequestOptions
+  ) => Promise<HealthResponse>
+  /** Scan a GitHub organization and wait for the result */
+  readonly scan: (
+    params: ScanParams,
+    options?: RequestOptions
+  ) => Promise<ScanResponse>
+  /** Queue a background scan of a GitHub organization */
+  readonly startScan: (
+    params: StartScanParams,
+    options?: RequestOptions
+  ) => Promise<ScanJob>
+  /** Get a background scan job */
+  readonly scanJob: (
+    params: ScanJobParams,
+    options?: RequestOptions
+  ) => Promise<ScanJob>
+  /** List scanned organizations */
+  readonly organizations: (
+    options?: RequestOptions
+  ) => Promise<OrganizationListResponse>
+  /** Get organization graph data */
+  readonly graph: (
+    params: GraphParams,
+    options?: RequestOptions
+  ) => Promise<GraphResponse>
+  /** Get organization statistics */
+  readonly stats: (
+    params: StatsParams,
+    options?: RequestOptions
+  ) => Promise<StatsResponse>
+  /** Get CODEOWNERS file coverage */
+  readonly coverage: (
+    params: CoverageParams,
+    options?: RequestOptions
+  ) => Promise<CoverageReportResponse>
+}
+
+/**
+ * Creates a typed client for the operations of api/openapi.yaml
+ * Responses are validated against the shared schemas
+ */
+export const createApiClient = (config: ApiClientConfig): ApiClient => {
+  const request = createRequester(config)
+
+  return {
+    health: (options) =>
+      request(
+        'GET',
+        '/api/health',
+        {},
+        HealthResponseSchema,
+        'health',
+        options
+      ),
+    scan: (params, options) =>
+      request(
+        'POST',
+        `/api/scan/${encodeURIComponent(params.org)}`,
+        {
+          max_repos: params.max_repos?.toString(),
+          max_teams: params.max_teams?.toString(),
+          use_topics: params.use_topics?.toString(),
+          mode: params.mode,
+          wait: 'true',
+        },
+        ScanResponseSchema,
+        'scan',
+        options
+      ),
+    startScan: (params, options) =>
+      request(
+        'POST',
+        `/api/scan/${encodeURIComponent(params.org)}`,
+        {
+          max_repos: params.max_repos?.toString(),
+          max_teams: params.max_teams?.toString(),
+          use_topics: params.use_topics?.toString(),
+          mode: params.mode,
+        },
+        ScanJobSchema,
+        'startScan',
+        options
+      ),
+    scanJob: (params, options) =>
+      request(
+        'GET',
+        `/api/scan/jobs/${encodeURIComponent(params.id)}`,
+        {},
+        ScanJobSchema,
+        'scanJob',
+        options
+      ),
+    organizations: (options) =>
+      request(
+        'GET',
+        '/api/orgs',
+        {},
+        OrganizationListResponseSchema,
+        'organizations',
+        options
+      ),
+    graph: (params, options) =>
+      request(
+        'GET',
+        `/api/graph/${encodeURIComponent(params.org)}`,
+        {
+          useTopics: params.useTopics?.toString(),
+          group_by: params.group_by,
+          summarize: params.summarize?.toString(),
+        },
+        GraphResponseSchema,
+        'graph',
+        options
+      ),
+    stats: (params, options) =>
+      request(
+        'GET',
+        `/api/stats/${encodeURIComponent(params.org)}`,
+        {},
+        StatsResponseSchema,
+        'stats',
+        options
+      ),
+    coverage: (params, options) =>
+      request(
+        'GET',
+        `/api/coverage/${encodeURIComponent(params.org)}`,
+        {
+          limit: params.limit?.toString(),
+          repository: params.repository,
+        },
+        CoverageReportResponseSchema,
+        'coverage',
+        options
+      ),
+  }
+}
@@ -0,0 +1,154 @@
+import { z } from 'zod'
+import { validateApiResponseSync } from './schemas'
+
+/**
+ * API client configuration
+ * Retries apply to network failures and retryable statuses with exponential backoff
+ */
+export interface ApiClientConfig {
+  readonly baseUrl: string
+  readonly apiKey?: string
+  readonly maxRetries?: number
+  readonly backoffMs?: number
+  readonly fetch?: typeof fetch
+}
+
+export interface RequestOptions {
+  readonly signal?: AbortSignal
+}
+
+// Error raised when the API responds with a non-2xx status
+export class ApiError extends Error {
+  readonly status: number
+
+  constructor(status: number, message: string) {
+    super(`API returned status ${status}: ${message}`)
+    this.name = 'ApiError'
+    this.status = status
+  }
+}
+
+const DEFAULT_MAX_RETRIES = 3
+const DEFAULT_BACKOFF_MS = 500
+
+const ErrorBodySchema = z.object({
+  error: z.object({ message: z.string() }),
+})
+
+// Scans are only retried when the server rejected them before doing any work
+const isRetryableStatus = (method: string, status: number): boolean =>
+  status === 429 ||
+  status === 503 ||
+  (method === 'GET' && (status === 500 || status === 502 || status === 504))
+
+const retryDelayMs = (response: Response | undefined, backoffMs: number) => {
+  const retryAfter = Number(response?.headers.get('Retry-After'))
+  return Number.isInteger(retryAfter) && retryAfter > 0
+    ? retryAfter * 1000
+    : backoffMs
+}
+
+const sleep = (ms: number, signal?: AbortSignal): Promise<void> =>
+  new Promise((resolve, reject) => {
+    const timer = setTimeout(resolve, ms)
+    signal?.addEventListener('abort', () => {
+      clearTimeout(timer)
+      reject(signal.reason)
+    })
+  })
+
+const readApiError = async (response: Response): Promise<ApiError> => {
+  const text = await response.text()
+  try {
+    const parsed = ErrorBodySchema.safeParse(JSON.parse(text))
+    return new ApiError(
+      response.status,
+      parsed.success ? parsed.data.error.message : text
+    )
+  } catch {
+    return new ApiError(response.status, text)
+  }
+}
+
+const buildUrl = (
+  baseUrl: string,
+  path: string,
+  query: Readonly<Record<string, string | undefined>>
+): string => {
+  const params = new URLSearchParams(
+    Object.entries(query).filter(
+      (entry): entry is [string, string] => entry[1] !== undefined
+    )
+  )
+  const search = params.toString()
+  return `${baseUrl.replace(/\/$/, '')}${path}${search ? `?${search}` : ''}`
+}
+
+export type HttpMethod = 'GET' | 'POST'
+
+export type Requester = <T>(
+  method: HttpMethod,
+  path: string,
+  query: Readonly<Record<string, string | undefined>>,
+  schema: z.ZodSchema<T>,
+  context: string,
+  options?: RequestOptions
+) => Promise<T>
+
+/**
+ * Creates the request function the generated client calls for every operation
+ * Responses are validated against the shared schemas
+ */
+export const createRequester = (config: ApiClientConfig): Requester => {
+  const fetchFn = config.fetch ?? fetch
+  const maxRetries = config.maxRetries ?? DEFAULT_MAX_RETRIES
+  const backoffMs = config.backoffMs ?? DEFAULT_BACKOFF_MS
+  const headers: Record<string, string> = config.apiKey
+    ? { Accept: 'application/json', 'X-API-Key': config.apiKey }
+    : { Accept: 'application/json' }
+
+  return async <T>(
+    method: HttpMethod,
+    path: string,
+    query: Readonly<Record<string, string | undefined>>,
+    schema: z.ZodSchema<T>,
+    context: string,
+    options: RequestOptions = {}
+  ): Promise<T> => {
+    const url = buildUrl(config.baseUrl, path, query)
+    const send = async (attempt: number, delayMs: number): Promise<T> => {
+      const result = await fetchFn(url, {
+        method,
+        headers,
+        ...(options.signal ? { signal: options.signal } : {}),
+      }).then(
+        (response) => ({ response, error: undefined }),
+        (error: unknown) => ({ response: undefined, error })
+      )
+
+      if (result.response?.ok) {
+        return validateApiResponseSync(
+          schema,
+          await result.response.json(),
+          context
+        )
+      }
+
+      const retryable = result.response
+        ? isRetryableStatus(method, result.response.status)
+        : method === 'GET'
+      const failure = result.response
+        ? await readApiError(result.response)
+        : result.error
+
+      if (!retryable || attempt >= maxRetries) {
+        throw failure
+      }
+
+      await sleep(retryDelayMs(result.response, delayMs), options.signal)
+      return send(attempt + 1, delayMs * 2)
+    }
+
+    return send(0, backoffMs)
+  }
+}
@@ -67,6 +67,20 @@ export const ScanResponseSchema = z.object({
   data: z.object({
     success: z.boolean().describe('Whether the scan was successful'),
     organization: z.string().describe('Name of the scanned organization'),
+    mode: z
+      .enum(['full', 'incremental'])
+      .optional()
+      .describe('Whether all repositories or only changed ones were fetched'),
+    incremental_since: z
+      .string()
+      .optional()
+      .describe('Repositories changed since this time were re-fetched'),
+    conversion_failures: z
+      .record(z.record(z.number().int().min(0)))
+      .optional()
+      .describe(
+        'Records of the published graph that could not be converted, by kind and reason'
+      ),
     summary: ScanSummarySchema,
     errors: z
       .array(z.string())
@@ -81,6 +95,43 @@ export const ScanResponseSchema = z.object({
   }),
 })
 
+/**
+ * Scan job schema
+ * Background scan accepted by POST /api/scan/{org} and polled at GET /api/scan/jobs/{id}
+ */
+export const ScanJobSchema = z.object({
+  data: z.object({
+    id: z.string().describe('Job identifier'),
+    organization: z.string().describe('Organization being scanned'),
+    mode: z.enum(['full', 'incremental']).describe('Scan mode'),
+    state: z
+      .enum(['queued', 'running', 'completed', 'failed'])
+      .describe('Lifecycle state of the job'),
+    phase: z.string().optional().describe('Current or last scan phase'),
+    progress_percent: z
+      .number()
+      .int()
+      .min(0)
+      .max(100)
+      .describe('Estimated completion in percent'),
+    batch: z
+      .object({ done: z.number().int(), total: z.number().int() })
+      .optional()
+      .describe('Items of the current phase processed so far'),
+    scan_id: z.string().optional().describe('Identifier of the stored scan'),
+    created_at: z.string().describe('When the job was queued'),
+    started_at: z.string().optional().describe('When the scan started'),
+    finished_at: z.string().optional().describe('When the job finished'),
+    error: z
+      .object({ message: z.string(), status_code: z.number().int() })
+      .optional()
+      .describe('Why the job failed'),
+    result: ScanResponseSchema.shape.data
+      .optional()
+      .describe('Scan result once completed'),
+  }),
+})
+
 /**
  * Graph position schema
  * Coordinates for positioning nodes in the graph visualization
@@ -113,6 +164,9 @@ export const GraphEdgeTypeSchema = z.enum([
   'maintained_by',
   'has_topic',
   'has',
+  'has_team',
+  'team_owner',
+  'repo_topic',
 ])
 
 /**
@@ -145,11 +199,46 @@ export const GraphEdgeSchema = z.object({
  */
 export const GraphResponseSchema = z.object({
   data: z.object({
+    schema_version: z
+      .number()
+      .int()
+      .optional()
+      .describe('Version of the graph response shape, raised on breaking changes'),
     nodes: z.array(GraphNodeSchema).describe('List of nodes in the graph'),
     edges: z.array(GraphEdgeSchema).describe('List of edges connecting nodes'),
+    summarized: z
+      .boolean()
+      .optional()
+      .describe('Only the organization and its teams or topics, with repository counts'),
   }),
 })
 
+/**
+ * Coverage segment schema
+ * CODEOWNERS coverage of a subset of repositories
+ */
+export const CoverageSegmentSchema = z.object({
+  repositories: z.number().int().min(0),
+  with_codeowners: z.number().int().min(0),
+  coverage_percent: z.number().min(0).max(100),
+})
+
+/**
+ * Coverage target progress schema
+ * Progress of an organization towards its CODEOWNERS coverage target
+ */
+export const CoverageTargetProgressSchema = z.object({
+  target_percent: z.number().gt(0).max(100),
+  deadline: z.string().describe('Target date in YYYY-MM-DD format'),
+  current_percent: z.number().min(0).max(100),
+  weeks_remaining: z.number().min(0),
+  required_weekly_delta: z.number(),
+  observed_weekly_delta: z.number(),
+  trend_samples: z.number().int().min(0),
+  projected_percent: z.number().max(100),
+  status: z.enum(['achieved', 'on_track', 'off_track', 'missed']),
+})
+
 /**
  * Stats response schema
  * Statistical summary of a scanned organization
@@ -176,6 +265,100 @@ export const StatsResponseSchema = z.object({
       .string()
       .datetime()
       .describe('Timestamp of last scan in ISO 8601 format'),
+    breakdown: z
+      .object({
+        visibility: z.record(CoverageSegmentSchema),
+        archived: z.record(CoverageSegmentSchema),
+        fork: z.record(CoverageSegmentSchema),
+        compliance: CoverageSegmentSchema,
+      })
+      .optional()
+      .describe(
+        'Coverage by visibility, archived and fork status; compliance excludes archived repositories and forks'
+      ),
+    coverage_target: CoverageTargetProgressSchema.optional().describe(
+      'Progress towards the coverage target when one is set'
+    ),
+  }),
+})
+
+/**
+ * Coverage response schema
+ * File-level CODEOWNERS coverage of an organization's repositories
+ */
+export const CoverageReportResponseSchema = z.object({
+  data: z.object({
+    organization: z.string().describe('Organization name'),
+    active_scan_id: z.string().describe('Scan whose CODEOWNERS rules were applied'),
+    truncated: z
+      .boolean()
+      .describe('Whether more repositories exist than the limit covered'),
+    summary: z.object({
+      repositories: z.number().int().min(0),
+      repositories_with_codeowners: z.number().int().min(0),
+      fully_covered_repositories: z.number().int().min(0),
+      failed_repositories: z.number().int().min(0),
+      total_files: z.number().int().min(0),
+      owned_files: z.number().int().min(0),
+      unowned_files: z.number().int().min(0),
+      coverage_percent: z.number().min(0).max(100),
+      estimated: z.boolean(),
+    }),
+    repositories: z.array(
+      z.object({
+        repository: z.string(),
+        has_codeowners: z.boolean(),
+        total_files: z.number().int().min(0),
+        owned_files: z.number().int().min(0),
+        unowned_files: z.number().int().min(0),
+        coverage_percent: z.number().min(0).max(100),
+        unowned_percent: z.number().min(0).max(100),
+        estimated: z
+          .boolean()
+          .describe('Whether GitHub truncated the tree, making the counts an estimate'),
+        patterns: z.array(
+          z.object({
+            pattern: z.string(),
+            line: z.number().int(),
+            owners: z.array(z.string()),
+            files: z.number().int().min(0),
+          })
+        ),
+        unused_patterns: z.array(z.string()),
+        unowned_directories: z.array(
+          z.object({
+            directory: z.string(),
+            unowned_files: z.number().int().min(0),
+          })
+        ),
+        error: z.string().optional(),
+      })
+    ),
+  }),
+})
+
+/**
+ * Organization list response schema
+ * Scanned organizations with their last scan and the size of their active scan
+ */
+export const OrganizationListResponseSchema = z.object({
+  data: z.object({
+    organizations: z.array(
+      z.object({
+        organization: z.string().describe('Organization login'),
+        name: z.string().optional().describe('Organization display name'),
+        active_scan_id: z.string().optional().describe('Active scan'),
+        last_scanned_at: z
+          .string()
+          .optional()
+          .describe('When the active scan was published'),
+        scanning: z.boolean().describe('Whether a scan is running'),
+        repositories: z.number().int().min(0),
+        repositories_with_codeowners: z.number().int().min(0),
+        teams: z.number().int().min(0),
+        coverage_percent: z.number().min(0).max(100),
+      })
+    ),
   }),
 })
 
@@ -201,6 +384,11 @@ export const ScanRequestParamsSchema = z.object({
     .default(50)
     .optional()
     .describe('Maximum number of teams to scan'),
+  mode: z
+    .enum(['full', 'incremental'])
+    .default('full')
+    .optional()
+    .describe('Scan all repositories or only those changed since the last scan'),
 })
 
 /**
@@ -229,6 +417,7 @@ export type HealthResponse = z.infer<typeof HealthResponseSchema>
 export type ErrorResponse = z.infer<typeof ErrorResponseSchema>
 export type ScanSummary = z.infer<typeof ScanSummarySchema>
 export type ScanResponse = z.infer<typeof ScanResponseSchema>
+export type ScanJob = z.infer<typeof ScanJobSchema>
 export type GraphPosition = z.infer<typeof GraphPositionSchema>
 export type GraphNodeType = z.infer<typeof GraphNodeTypeSchema>
 export type GraphEdgeType = z.infer<typeof GraphEdgeTypeSchema>
@@ -236,6 +425,12 @@ export type GraphNode = z.infer<typeof GraphNodeSchema>
 export type GraphEdge = z.infer<typeof GraphEdgeSchema>
 export type GraphResponse = z.infer<typeof GraphResponseSchema>
 export type StatsResponse = z.infer<typeof StatsResponseSchema>
+export type CoverageReportResponse = z.infer<
+  typeof CoverageReportResponseSchema
+>
+export type OrganizationListResponse = z.infer<
+  typeof OrganizationListResponseSchema
+>
 export type ScanRequestParams = z.infer<typeof ScanRequestParamsSchema>
 export type GraphRequestParams = z.infer<typeof GraphRequestParamsSchema>
 export type StatsRequestParams = z.infer<typeof StatsRequestParamsSchema>
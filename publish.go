@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Where published graph snapshots are written, selected with PUBLISH_TARGET
+const (
+	publishTargetFilesystem = "filesystem"
+	publishTargetBlob       = "blob"
+)
+
+// Artifact names of a published snapshot, below the organization's prefix
+const (
+	publishedGraphArtifact = "graph.json"
+	publishedHTMLArtifact  = "index.html"
+)
+
+// publishOrganizationPattern restricts organization names used as artifact prefixes; GitLab group
+// paths keep their slashes as nested prefixes
+var publishOrganizationPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)*$`)
+
+// PublishedGraph is the static snapshot of an organization's graph written by POST /api/publish/{org}
+type PublishedGraph struct {
+	Organization string        `json:"organization"`
+	PublishedAt  string        `json:"published_at"`
+	GroupBy      GraphGroupBy  `json:"group_by"`
+	ScanID       string        `json:"scan_id,omitempty"`
+	Stats        StatsResponse `json:"stats"`
+	Graph        GraphResponse `json:"graph"`
+}
+
+// PublishedArtifact is one file of a published snapshot
+type PublishedArtifact struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Bytes    int    `json:"bytes"`
+}
+
+// PublishResponse reports where a snapshot was published
+type PublishResponse struct {
+	Organization string              `json:"organization"`
+	PublishedAt  string              `json:"published_at"`
+	Target       string              `json:"target"`
+	Nodes        int                 `json:"nodes"`
+	Edges        int                 `json:"edges"`
+	Artifacts    []PublishedArtifact `json:"artifacts"`
+}
+
+// isValidPublishTarget reports whether a target is a supported PUBLISH_TARGET (Pure Core)
+func isValidPublishTarget(target string) bool {
+	return target == publishTargetFilesystem || target == publishTargetBlob
+}
+
+// buildPublishedGraph assembles the snapshot of an organization; secret teams are shown, masked or
+// omitted as configured for callers without admin scope, since the snapshot is read without the API (Pure Core)
+func buildPublishedGraph(orgName string, groupBy GraphGroupBy, stats StatsResponse, graph GraphResponse, mode string, now time.Time) PublishedGraph {
+	stats = applyTeamVisibilityToStats(stats, mode)
+	stats.TeamExpansion = nil
+	return PublishedGraph{
+		Organization: orgName,
+		PublishedAt:  now.UTC().Format(time.RFC3339),
+		GroupBy:      groupBy,
+		ScanID:       stats.ActiveScanID,
+		Stats:        stats,
+		Graph:        applyTeamVisibility(graph, mode),
+	}
+}
+
+// publishedGraphPage renders a snapshot as a standalone page drawing the graph at its stored layout
+var publishedGraphPage = template.Must(template.New("published-graph").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Organization}} code ownership</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 1.5rem; color: #1f2328; }
+dl { display: grid; grid-template-columns: max-content auto; gap: .25rem 1rem; }
+dt { font-weight: 600; }
+svg { width: 100%; height: 70vh; border: 1px solid #d0d7de; border-radius: 6px; }
+.edge { stroke: #afb8c1; stroke-width: 1; }
+.node text { font-size: 10px; }
+.organization circle { fill: #0969da; } .repository circle { fill: #1a7f37; } .team circle { fill: #8250df; }
+.topic circle { fill: #bf8700; } .user circle { fill: #cf222e; }
+</style>
+</head>
+<body>
+<h1>{{.Organization}} code ownership</h1>
+<dl>
+<dt>Published</dt><dd>{{.PublishedAt}}</dd>
+<dt>Repositories</dt><dd>{{.Stats.TotalRepositories}}</dd>
+<dt>Teams</dt><dd>{{.Stats.TotalTeams}}</dd>
+<dt>Users</dt><dd>{{.Stats.TotalUsers}}</dd>
+<dt>CODEOWNERS coverage</dt><dd>{{.Stats.CodeownerCoverage}}</dd>
+</dl>
+<svg id="graph"></svg>
+<script id="snapshot" type="application/json">{{.}}</script>
+<script>
+const snapshot = JSON.parse(document.getElementById("snapshot").textContent);
+const svg = document.getElementById("graph");
+const ns = "http://www.w3.org/2000/svg";
+const nodes = snapshot.graph.nodes;
+const xs = nodes.map(n => n.position.x), ys = nodes.map(n => n.position.y);
+const minX = Math.min(0, ...xs) - 50, minY = Math.min(0, ...ys) - 50;
+svg.setAttribute("viewBox", [minX, minY, Math.max(...xs, 0) - minX + 50, Math.max(...ys, 0) - minY + 50].join(" "));
+const positions = Object.fromEntries(nodes.map(n => [n.id, n.position]));
+for (const edge of snapshot.graph.edges) {
+  const from = positions[edge.source], to = positions[edge.target];
+  if (!from || !to) continue;
+  const line = document.createElementNS(ns, "line");
+  line.setAttribute("class", "edge");
+  line.setAttribute("x1", from.x); line.setAttribute("y1", from.y);
+  line.setAttribute("x2", to.x); line.setAttribute("y2", to.y);
+  svg.appendChild(line);
+}
+for (const node of nodes) {
+  const group = document.createElementNS(ns, "g");
+  group.setAttribute("class", "node " + node.type);
+  group.setAttribute("transform", "translate(" + node.position.x + "," + node.position.y + ")");
+  const circle = document.createElementNS(ns, "circle");
+  circle.setAttribute("r", 8);
+  const label = document.createElementNS(ns, "text");
+  label.setAttribute("y", 20);
+  label.setAttribute("text-anchor", "middle");
+  label.textContent = node.label;
+  group.append(circle, label);
+  svg.appendChild(group);
+}
+</script>
+</body>
+</html>
+`))
+
+// renderPublishedGraph encodes a snapshot as JSON and, when requested, as an HTML page (Pure Core)
+func renderPublishedGraph(snapshot PublishedGraph, includeHTML bool) (map[string][]byte, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode graph snapshot: %w", err)
+	}
+	artifacts := map[string][]byte{publishedGraphArtifact: data}
+	if !includeHTML {
+		return artifacts, nil
+	}
+
+	var page bytes.Buffer
+	if err := publishedGraphPage.Execute(&page, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to render graph page: %w", err)
+	}
+	artifacts[publishedHTMLArtifact] = page.Bytes()
+	return artifacts, nil
+}
+
+// writePublishedArtifact stores an artifact below the publish directory atomically, so readers never
+// see a partial file
+func writePublishedArtifact(directory, orgName, name string, data []byte) (string, error) {
+	dir := filepath.Join(directory, filepath.FromSlash(orgName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create publish directory: %w", err)
+	}
+
+	target := filepath.Join(dir, name)
+	temporary := target + ".tmp"
+	if err := os.WriteFile(temporary, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(temporary, target); err != nil {
+		os.Remove(temporary)
+		return "", fmt.Errorf("failed to store %s: %w", name, err)
+	}
+	return target, nil
+}
+
+// buildBlobArtifactURL places an artifact below the path of the blob container URL, keeping its query
+// so SAS and signed URL parameters still apply (Pure Core)
+func buildBlobArtifactURL(containerURL, orgName, name string) (string, error) {
+	parsed, err := url.Parse(containerURL)
+	if err != nil {
+		return "", err
+	}
+	parsed.Path = path.Join("/", parsed.Path, orgName, name)
+	return parsed.String(), nil
+}
+
+// uploadPublishedArtifact PUTs an artifact to blob storage; the x-ms-blob-type header makes Azure
+// Blob Storage create a block blob and is ignored by S3 and GCS compatible endpoints
+func uploadPublishedArtifact(ctx context.Context, config PublishConfig, orgName, name string, data []byte) (string, error) {
+	location, err := buildBlobArtifactURL(config.BlobURL, orgName, name)
+	if err != nil {
+		return "", err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, location, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", publishedArtifactContentType(name))
+	request.Header.Set("x-ms-blob-type", "BlockBlob")
+	if config.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+config.Token)
+	}
+
+	response, err := (&http.Client{Timeout: 30 * time.Second}).Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to upload %s: blob storage returned %d", name, response.StatusCode)
+	}
+
+	// The returned location leaves out the query, which may carry a SAS token
+	if parsed, err := url.Parse(location); err == nil {
+		parsed.RawQuery = ""
+		location = parsed.String()
+	}
+	return location, nil
+}
+
+// publishedArtifactContentType returns the media type of an artifact (Pure Core)
+func publishedArtifactContentType(name string) string {
+	if name == publishedHTMLArtifact {
+		return "text/html; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// publishOrganizationGraph renders the current graph of an organization and writes it to the
+// configured publish target (Orchestrator)
+func publishOrganizationGraph(ctx *gofr.Context, deps *AppDependencies, orgName string, groupBy GraphGroupBy, includeHTML bool) (PublishResponse, error) {
+	config := deps.currentConfig()
+	if !publishOrganizationPattern.MatchString(orgName) {
+		return PublishResponse{}, &gofrhttp.ErrorInvalidParam{Params: []string{"org"}}
+	}
+
+	if err := checkGraphCost(ctx, deps, orgName, groupBy); err != nil {
+		return PublishResponse{}, err
+	}
+	graph, err := getOrganizationGraph(ctx, deps, orgName, groupBy)
+	if err != nil {
+		return PublishResponse{}, err
+	}
+	stats, err := getOrganizationStats(ctx, deps, orgName)
+	if err != nil {
+		return PublishResponse{}, err
+	}
+
+	snapshot := buildPublishedGraph(orgName, groupBy, stats, graph, config.TeamVisibility.SecretTeams, time.Now())
+	artifacts, err := renderPublishedGraph(snapshot, includeHTML)
+	if err != nil {
+		return PublishResponse{}, err
+	}
+
+	response := PublishResponse{
+		Organization: orgName,
+		PublishedAt:  snapshot.PublishedAt,
+		Target:       config.Publish.Target,
+		Nodes:        len(snapshot.Graph.Nodes),
+		Edges:        len(snapshot.Graph.Edges),
+		Artifacts:    []PublishedArtifact{},
+	}
+	for _, name := range []string{publishedGraphArtifact, publishedHTMLArtifact} {
+		data, ok := artifacts[name]
+		if !ok {
+			continue
+		}
+
+		var location string
+		if config.Publish.Target == publishTargetBlob {
+			location, err = uploadPublishedArtifact(ctx, config.Publish, orgName, name, data)
+		} else {
+			location, err = writePublishedArtifact(config.Publish.Directory, orgName, name, data)
+		}
+		if err != nil {
+			return PublishResponse{}, err
+		}
+		response.Artifacts = append(response.Artifacts, PublishedArtifact{Name: name, Location: location, Bytes: len(data)})
+	}
+
+	return response, nil
+}
+
+// handlePublishGraph publishes the current graph of an organization as a static JSON snapshot and,
+// with ?html=true, a standalone HTML page, for embedding without access to the API
+func (h *AppHandler) handlePublishGraph(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	groupBy, ok := parseGraphGroupBy(ctx.Param("group_by"), parseBoolFromQuery(ctx, "useTopics", false))
+	if !ok {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"group_by"}}
+	}
+
+	response, err := publishOrganizationGraph(ctx, h.deps, orgName, groupBy, parseBoolFromQuery(ctx, "html", false))
+	if err != nil {
+		logError(ctx, "Failed to publish graph snapshot", LogFields{
+			"component":    "publish",
+			"operation":    "publish_graph",
+			"organization": orgName,
+			"error":        err.Error(),
+		})
+		return nil, err
+	}
+
+	logInfo(ctx, "Published graph snapshot", LogFields{
+		"component":    "publish",
+		"operation":    "publish_graph",
+		"organization": orgName,
+		"target":       response.Target,
+		"nodes":        response.Nodes,
+		"edges":        response.Edges,
+	})
+	return response, nil
+}
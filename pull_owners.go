@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Pagination limits of the pull request files API, which returns at most 3000 files
+const (
+	pullFilesPerPage = 100
+	pullFilesMaxPage = 30
+)
+
+// GitHubPullRequestFile is a file changed by a pull request
+type GitHubPullRequestFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+}
+
+// PullRequestOwnersResponse lists the owners of every changed file and a minimal reviewer set
+type PullRequestOwnersResponse struct {
+	Repository string               `json:"repository"`
+	Number     int                  `json:"number"`
+	Files      []OwnerResolveResult `json:"files"`
+	Reviewers  []string             `json:"reviewers"`
+	Unowned    []string             `json:"unowned_files"`
+}
+
+// selectMinimalReviewers picks a small set of owners so every owned file has at least one
+// reviewer, greedily taking the owner covering the most uncovered files and preferring the
+// less-loaded owner when several cover the same number (Pure Core)
+func selectMinimalReviewers(files []OwnerResolveResult, loads map[string]int) []string {
+	uncovered := make(map[int]bool)
+	for index, file := range files {
+		if len(file.Owners) > 0 {
+			uncovered[index] = true
+		}
+	}
+
+	reviewers := []string{}
+	for len(uncovered) > 0 {
+		coverage := make(map[string]int)
+		for index := range uncovered {
+			for _, owner := range files[index].Owners {
+				coverage[owner]++
+			}
+		}
+
+		best := ""
+		for owner, count := range coverage {
+			if best == "" || isPreferredReviewer(owner, best, count, coverage[best], loads) {
+				best = owner
+			}
+		}
+
+		reviewers = append(reviewers, best)
+		for index := range uncovered {
+			for _, owner := range files[index].Owners {
+				if owner == best {
+					delete(uncovered, index)
+					break
+				}
+			}
+		}
+	}
+
+	sort.Strings(reviewers)
+	return reviewers
+}
+
+// isPreferredReviewer reports whether a candidate beats the current best by coverage, then load, then name (Pure Core)
+func isPreferredReviewer(candidate, best string, candidateCoverage, bestCoverage int, loads map[string]int) bool {
+	if candidateCoverage != bestCoverage {
+		return candidateCoverage > bestCoverage
+	}
+	if loads[candidate] != loads[best] {
+		return loads[candidate] < loads[best]
+	}
+	return candidate < best
+}
+
+// fetchPullRequestFiles fetches the files changed by a pull request, following pagination
+func fetchPullRequestFiles(ctx *gofr.Context, owner, repo string, number int) ([]GitHubPullRequestFile, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/files", owner, repo, number)
+
+	var files []GitHubPullRequestFile
+	for page := 1; page <= pullFilesMaxPage; page++ {
+		resp, err := githubGet(ctx, endpoint, map[string]any{
+			"page":     strconv.Itoa(page),
+			"per_page": strconv.Itoa(pullFilesPerPage),
+		}, buildGitHubRequestHeaders())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pull request files: %w", err)
+		}
+
+		pageFiles, err := decodePullRequestFilesPage(ctx, resp, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, pageFiles...)
+		if len(pageFiles) < pullFilesPerPage {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// decodePullRequestFilesPage validates and decodes one page of pull request files
+func decodePullRequestFilesPage(ctx *gofr.Context, resp *http.Response, owner, repo string, number int) ([]GitHubPullRequestFile, error) {
+	defer resp.Body.Close()
+	logRateLimitInfo(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &gofrhttp.ErrorEntityNotFound{
+			Name:  "pull_request",
+			Value: fmt.Sprintf("%s/%s#%d", owner, repo, number),
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &gofrhttp.ErrorInvalidParam{
+			Params: []string{"github_api_status", fmt.Sprintf("status_code_%d", resp.StatusCode)},
+		}
+	}
+
+	var files []GitHubPullRequestFile
+	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
+		return nil, fmt.Errorf("failed to decode pull request files: %w", err)
+	}
+	return files, nil
+}
+
+// getPullRequestOwners resolves the owners of a pull request's changed files (Orchestrator)
+func getPullRequestOwners(ctx *gofr.Context, deps *AppDependencies, orgName, repoName string, number int) (PullRequestOwnersResponse, error) {
+	fullName := orgName + "/" + repoName
+
+	matchers, err := loadCodeownersMatchers(ctx, deps, []string{fullName})
+	if err != nil {
+		return PullRequestOwnersResponse{}, err
+	}
+	if _, exists := matchers[fullName]; !exists {
+		return PullRequestOwnersResponse{}, &gofrhttp.ErrorEntityNotFound{
+			Name:  "repository",
+			Value: fullName,
+		}
+	}
+
+	files, err := fetchPullRequestFiles(ctx, orgName, repoName, number)
+	if err != nil {
+		return PullRequestOwnersResponse{}, err
+	}
+
+	items := make([]OwnerResolveItem, 0, len(files))
+	for _, file := range files {
+		items = append(items, OwnerResolveItem{Repo: fullName, Path: file.Filename})
+	}
+
+	loads, err := fetchOwnerLoads(ctx, deps, orgName)
+	if err != nil {
+		return PullRequestOwnersResponse{}, err
+	}
+
+	results := resolveOwnerItems(items, matchers)
+	unowned := []string{}
+	for _, result := range results {
+		if !result.Found {
+			unowned = append(unowned, result.Path)
+		}
+	}
+
+	return PullRequestOwnersResponse{
+		Repository: fullName,
+		Number:     number,
+		Files:      results,
+		Reviewers:  selectMinimalReviewers(results, indexOwnerLoads(loads)),
+		Unowned:    unowned,
+	}, nil
+}
+
+// handleGetPullRequestOwners returns the owners of a pull request's changed files and a minimal reviewer set
+func (h *AppHandler) handleGetPullRequestOwners(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	repoName := ctx.PathParam("repo")
+	if repoName == "" {
+		return nil, createMissingParamError("repo")
+	}
+
+	number, err := strconv.Atoi(ctx.PathParam("number"))
+	if err != nil || number <= 0 {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"number"}}
+	}
+
+	return getPullRequestOwners(ctx, h.deps, orgName, repoName, number)
+}
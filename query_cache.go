@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// QueryCache memoizes built Cypher texts and their hashes so identical queries are
+// built once and re-sent byte-for-byte, which keeps query hashes, logs and Neo4j's
+// own query plan cache stable
+type QueryCache struct {
+	texts  sync.Map
+	hashes sync.Map
+}
+
+// queryCache is the process-wide cache used by query builders
+var queryCache = &QueryCache{}
+
+// buildQueryCacheKey builds a cache key from a builder name and every option affecting its output (Pure Core)
+func buildQueryCacheKey(builder string, options ...interface{}) string {
+	parts := make([]string, 0, len(options)+1)
+	parts = append(parts, builder)
+	for _, option := range options {
+		parts = append(parts, fmt.Sprintf("%v", option))
+	}
+	return strings.Join(parts, "|")
+}
+
+// cachedQuery returns the cached text of a query, building it on first use
+func cachedQuery(build func() string, builder string, options ...interface{}) string {
+	key := buildQueryCacheKey(builder, options...)
+	if text, ok := queryCache.texts.Load(key); ok {
+		return text.(string)
+	}
+
+	text, _ := queryCache.texts.LoadOrStore(key, build())
+	return text.(string)
+}
+
+// cachedQueryHash returns the hash of a query text, computing it on first use
+func cachedQueryHash(query string) string {
+	if hash, ok := queryCache.hashes.Load(query); ok {
+		return hash.(string)
+	}
+
+	hash, _ := queryCache.hashes.LoadOrStore(query, generateQueryHash(query))
+	return hash.(string)
+}
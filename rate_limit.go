@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/metrics"
+)
+
+// rateLimitPruneInterval is how often buckets of clients that stopped sending requests are dropped
+const rateLimitPruneInterval = time.Minute
+
+// rateLimitClientIP is the client type of buckets keyed by address rather than by authenticated identity
+const rateLimitClientIP = "ip"
+
+// tokenBucket is the request allowance of one client: tokens refill at the configured rate up to the
+// burst, and each request takes one
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter keeps a token bucket per API client in this process, so each instance behind a load
+// balancer limits the requests it serves itself
+type RateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]tokenBucket
+	lastPrune time.Time
+}
+
+// RateLimitDecision is the outcome of a request against its client's bucket
+type RateLimitDecision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// newRateLimiter creates a rate limiter without any clients
+func newRateLimiter() *RateLimiter {
+	return &RateLimiter{buckets: make(map[string]tokenBucket), lastPrune: time.Now()}
+}
+
+// takeRateLimitToken refills a bucket for the time since its last request and takes a token for a
+// new one; a request finding the bucket empty is refused with the time until a token is back (Pure Core)
+func takeRateLimitToken(bucket tokenBucket, now time.Time, rps float64, burst int) (tokenBucket, RateLimitDecision) {
+	elapsed := now.Sub(bucket.updated).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(float64(burst), bucket.tokens+elapsed*rps)
+		bucket.updated = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return bucket, RateLimitDecision{Allowed: true, Remaining: int(bucket.tokens)}
+	}
+	wait := time.Duration((1 - bucket.tokens) / rps * float64(time.Second))
+	return bucket, RateLimitDecision{RetryAfter: wait}
+}
+
+// isBucketIdle reports whether a bucket has refilled completely, so dropping it loses nothing (Pure Core)
+func isBucketIdle(bucket tokenBucket, now time.Time, rps float64, burst int) bool {
+	return bucket.tokens+now.Sub(bucket.updated).Seconds()*rps >= float64(burst)
+}
+
+// retryAfterSeconds rounds a wait up to the whole seconds of a Retry-After header (Pure Core)
+func retryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
+
+// requestClientAddress returns the address a request came from: the first X-Forwarded-For entry when
+// the proxy setting it is trusted, the connection's address otherwise (Pure Core)
+func requestClientAddress(r *http.Request, trustForwardedFor bool) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); trustForwardedFor && forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		if first = strings.TrimSpace(first); first != "" {
+			return first
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// rateLimitAddressKey returns the bucket key of a request's client address (Pure Core)
+func rateLimitAddressKey(r *http.Request, config RateLimitConfig) string {
+	return rateLimitClientIP + ":" + requestClientAddress(r, config.TrustForwardedFor)
+}
+
+// take draws a token from a client's bucket, starting a full bucket for a new client; with consume
+// unset it only reports whether a token is available. It also reports how many clients are tracked
+// after dropping idle buckets once a minute
+func (l *RateLimiter) take(client string, now time.Time, config RateLimitConfig, consume bool) (RateLimitDecision, int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, exists := l.buckets[client]
+	if !exists {
+		bucket = tokenBucket{tokens: float64(config.Burst), updated: now}
+	}
+	taken, decision := takeRateLimitToken(bucket, now, config.RequestsPerSecond, config.Burst)
+	if consume {
+		l.buckets[client] = taken
+	} else {
+		decision.Remaining = int(taken.tokens) + 1
+		if !decision.Allowed {
+			decision.Remaining = 0
+		}
+	}
+
+	pruned := now.Sub(l.lastPrune) >= rateLimitPruneInterval
+	if pruned {
+		for key, tracked := range l.buckets {
+			if isBucketIdle(tracked, now, config.RequestsPerSecond, config.Burst) {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastPrune = now
+	}
+	return decision, len(l.buckets), pruned
+}
+
+// writeRateLimitResponse writes a 429 response in GoFr's error envelope
+func writeRateLimitResponse(w http.ResponseWriter, wait time.Duration) {
+	seconds := retryAfterSeconds(wait)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"message":             fmt.Sprintf("rate limit exceeded; retry in %d seconds", seconds),
+			"code":                "rate_limited",
+			"retry_after_seconds": seconds,
+		},
+	})
+}
+
+// limitRequest checks a request against a client's bucket, recording the outcome and setting the
+// rate limit headers; a refused request is answered with 429 and reported as not allowed
+func limitRequest(w http.ResponseWriter, r *http.Request, deps *AppDependencies, manager metrics.Manager, config RateLimitConfig, client, clientType string, consume bool) bool {
+	decision, clients, pruned := deps.RateLimits.take(client, time.Now(), config, consume)
+
+	outcome := "allowed"
+	if !decision.Allowed {
+		outcome = "limited"
+	}
+	// A check that does not consume a token is counted only when it refuses the request
+	if manager != nil && (consume || !decision.Allowed) {
+		manager.IncrementCounter(r.Context(), "rate_limit_requests_total", "client_type", clientType, "outcome", outcome)
+		if pruned {
+			manager.SetGauge("rate_limit_clients", float64(clients))
+		}
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(config.Burst))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	if !decision.Allowed {
+		writeRateLimitResponse(w, decision.RetryAfter)
+	}
+	return decision.Allowed
+}
+
+// addressRateLimitMiddleware limits traffic that is not authenticated by client address, ahead of
+// authentication. Without authentication configured every request draws from its address's bucket.
+// With it, only requests rejected with 401 draw from the bucket, and an address that has used up its
+// bucket is refused before its credentials are checked, so bad API keys and tokens cannot be retried
+// without limit or trigger a key set fetch each time
+func addressRateLimitMiddleware(deps *AppDependencies, manager metrics.Manager) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := deps.currentConfig()
+			config := current.RateLimit
+			if config.RequestsPerSecond <= 0 || isAuthExemptPath(r.URL.Path, config.ExemptPaths) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			client := rateLimitAddressKey(r, config)
+			if !isAuthEnabled(current.Auth) {
+				if limitRequest(w, r, deps, manager, config, client, rateLimitClientIP, true) {
+					inner.ServeHTTP(w, r)
+				}
+				return
+			}
+
+			if !limitRequest(w, r, deps, manager, config, client, rateLimitClientIP, false) {
+				return
+			}
+			recorder := &sloStatusRecorder{ResponseWriter: w, status: http.StatusOK}
+			inner.ServeHTTP(recorder, r)
+			if recorder.status == http.StatusUnauthorized {
+				deps.RateLimits.take(client, time.Now(), config, true)
+			}
+		})
+	}
+}
+
+// rateLimitMiddleware refuses requests of authenticated callers beyond their token bucket with 429 and
+// Retry-After once RATE_LIMIT_RPS is set. It runs after authentication, so callers are limited by
+// their verified identity rather than their address; requests without a principal were already
+// limited by addressRateLimitMiddleware. Exempt paths and the webhook listener are never limited
+func rateLimitMiddleware(deps *AppDependencies, manager metrics.Manager) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			config := deps.currentConfig().RateLimit
+			principal, authenticated := authPrincipalFromContext(r.Context())
+			if config.RequestsPerSecond <= 0 || !authenticated || isAuthExemptPath(r.URL.Path, config.ExemptPaths) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			client := principal.Method + ":" + principal.Subject
+			if limitRequest(w, r, deps, manager, config, client, principal.Method, true) {
+				inner.ServeHTTP(w, r)
+			}
+		})
+	}
+}
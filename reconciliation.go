@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// linkLastPagePattern extracts the rel="last" URL from a GitHub Link header
+var linkLastPagePattern = regexp.MustCompile(`<([^>]+)>;\s*rel="last"`)
+
+// DriftReport compares an organization's GitHub counts with the graph
+type DriftReport struct {
+	Organization     string  `json:"organization"`
+	GitHubRepos      int     `json:"github_repos"`
+	GraphRepos       int     `json:"graph_repos"`
+	GitHubTeams      int     `json:"github_teams"`
+	GraphTeams       int     `json:"graph_teams"`
+	RepoDriftPercent float64 `json:"repo_drift_percent"`
+	TeamDriftPercent float64 `json:"team_drift_percent"`
+	Drifted          bool    `json:"drifted"`
+}
+
+// buildGraphCountsQuery builds a query counting the active repositories and teams of every organization (Pure Core)
+func buildGraphCountsQuery() string {
+	return `
+		MATCH (org:Organization)
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository) WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, count(DISTINCT repo) AS repos
+		OPTIONAL MATCH (org)-[has_team:HAS_TEAM]->(team:Team) WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+		RETURN org.login AS organization, repos, count(DISTINCT team) AS teams
+		ORDER BY organization
+	`
+}
+
+// parseLastPageFromLink returns the page number of the rel="last" link, or 0 if absent (Pure Core)
+func parseLastPageFromLink(link string) int {
+	match := linkLastPagePattern.FindStringSubmatch(link)
+	if match == nil {
+		return 0
+	}
+
+	parsed, err := url.Parse(match[1])
+	if err != nil {
+		return 0
+	}
+
+	page, err := strconv.Atoi(parsed.Query().Get("page"))
+	if err != nil {
+		return 0
+	}
+	return page
+}
+
+// calculateDriftPercent returns how far the graph count is from the expected count in percent (Pure Core)
+func calculateDriftPercent(expected, actual int) float64 {
+	if expected == 0 {
+		if actual == 0 {
+			return 0
+		}
+		return 100
+	}
+	return math.Abs(float64(expected-actual)) * 100 / float64(expected)
+}
+
+// buildDriftReport compares GitHub and graph counts, capping GitHub counts at the scan limits (Pure Core)
+func buildDriftReport(org string, githubRepos, graphRepos, githubTeams, graphTeams int, config ReconciliationConfig) DriftReport {
+	report := DriftReport{
+		Organization: org,
+		GitHubRepos:  githubRepos,
+		GraphRepos:   graphRepos,
+		GitHubTeams:  githubTeams,
+		GraphTeams:   graphTeams,
+	}
+
+	report.RepoDriftPercent = calculateDriftPercent(min(githubRepos, config.MaxRepos), graphRepos)
+	report.TeamDriftPercent = calculateDriftPercent(min(githubTeams, config.MaxTeams), graphTeams)
+	report.Drifted = report.RepoDriftPercent > float64(config.DriftThresholdPercent) ||
+		report.TeamDriftPercent > float64(config.DriftThresholdPercent)
+
+	return report
+}
+
+// countGitHubCollection counts a paginated GitHub collection with a single one-item page request
+func countGitHubCollection(ctx *gofr.Context, endpoint string) (int, error) {
+	resp, err := githubGet(ctx, endpoint, map[string]any{"per_page": "1"}, buildGitHubRequestHeaders())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GitHub API returned status %d for %s", resp.StatusCode, endpoint)
+	}
+
+	if lastPage := parseLastPageFromLink(resp.Header.Get("Link")); lastPage > 0 {
+		return lastPage, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return 0, fmt.Errorf("failed to decode %s: %w", endpoint, err)
+	}
+	return len(items), nil
+}
+
+// reconcileOrganization compares an organization's GitHub counts with its graph counts
+func reconcileOrganization(ctx *gofr.Context, config ReconciliationConfig, org string, graphRepos, graphTeams int) (DriftReport, error) {
+	githubRepos, err := countGitHubCollection(ctx, fmt.Sprintf("orgs/%s/repos", org))
+	if err != nil {
+		return DriftReport{}, err
+	}
+
+	githubTeams, err := countGitHubCollection(ctx, fmt.Sprintf("orgs/%s/teams", org))
+	if err != nil {
+		return DriftReport{}, err
+	}
+
+	return buildDriftReport(org, githubRepos, graphRepos, githubTeams, graphTeams, config), nil
+}
+
+// rescanDriftedOrganization runs a scan of an organization whose graph drifted from GitHub
+func rescanDriftedOrganization(ctx *gofr.Context, deps *AppDependencies, config ReconciliationConfig, org string) {
+	request := ScanRequest{
+		Organization: org,
+		MaxRepos:     config.MaxRepos,
+		MaxTeams:     config.MaxTeams,
+		UseTopics:    deps.currentConfig().GitHub.UseTopics,
+	}
+
+	response, err := runLockedScan(ctx, deps, request)
+	if err != nil {
+		logError(ctx, "Reconciliation scan failed", LogFields{
+			"component":    "reconciliation",
+			"operation":    "rescan",
+			"organization": org,
+			"error":        err.Error(),
+		})
+		return
+	}
+
+	logInfo(ctx, "Reconciliation scan completed", LogFields{
+		"component":    "reconciliation",
+		"operation":    "rescan",
+		"organization": org,
+		"scan_id":      response.ScanID,
+		"scan_status":  response.ScanStatus,
+	})
+}
+
+// reconcileOrganizations flags organizations whose graph drifted from GitHub and optionally rescans them
+func reconcileOrganizations(ctx *gofr.Context, deps *AppDependencies) {
+	config := deps.currentConfig().Reconciliation
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logError(ctx, "Failed to create Neo4j session for reconciliation", LogFields{
+			"component": "reconciliation",
+			"operation": "reconcile",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildGraphCountsQuery(), nil)
+	closeNeo4jSession(ctx, session)
+	if err != nil {
+		logError(ctx, "Failed to count graph entities for reconciliation", LogFields{
+			"component": "reconciliation",
+			"operation": "reconcile",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	for _, record := range result.Records {
+		org := getStringFromMap(record, "organization")
+		report, err := reconcileOrganization(ctx, config, org, getIntFromMap(record, "repos"), getIntFromMap(record, "teams"))
+		if err != nil {
+			logWarn(ctx, "Failed to reconcile organization", LogFields{
+				"component":    "reconciliation",
+				"operation":    "reconcile",
+				"organization": org,
+				"error":        err.Error(),
+			})
+			continue
+		}
+
+		labels := MetricLabels{"organization": org}
+		metrics.recordGauge("reconciliation_repo_drift_percent", report.RepoDriftPercent, labels)
+		metrics.recordGauge("reconciliation_team_drift_percent", report.TeamDriftPercent, labels)
+
+		if !report.Drifted {
+			continue
+		}
+
+		metrics.recordCounter("reconciliation_drift_detected_total", 1, labels)
+		logWarn(ctx, "Graph drifted from GitHub", LogFields{
+			"component":          "reconciliation",
+			"operation":          "reconcile",
+			"alert":              "graph_drift",
+			"organization":       org,
+			"github_repos":       report.GitHubRepos,
+			"graph_repos":        report.GraphRepos,
+			"github_teams":       report.GitHubTeams,
+			"graph_teams":        report.GraphTeams,
+			"repo_drift_percent": report.RepoDriftPercent,
+			"team_drift_percent": report.TeamDriftPercent,
+			"auto_scan":          config.AutoScan,
+		})
+
+		if config.AutoScan {
+			rescanDriftedOrganization(ctx, deps, config, org)
+		}
+	}
+}
+
+// registerReconciliation schedules the consistency reconciliation against GitHub when enabled
+func registerReconciliation(app *gofr.App, deps *AppDependencies) {
+	config := deps.Config.Reconciliation
+	if !config.Enabled {
+		return
+	}
+
+	app.AddCronJob(config.Schedule, "graph-reconciliation", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "graph_reconciliation", func() {
+			runAsLeader(ctx, deps, "graph_reconciliation", func() {
+				reconcileOrganizations(ctx, deps)
+			})
+		})
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	// Embedded zone data, so report timezones resolve in images without /usr/share/zoneinfo
+	_ "time/tzdata"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// reportTimestampLayouts are the timestamp layouts of the supported report locales; the default
+// locale keeps RFC 3339, shifted to the report timezone. Layouts stay numeric where a locale would
+// spell month names, which Go only formats in English.
+var reportTimestampLayouts = map[string]string{
+	"":      time.RFC3339,
+	"en-US": "01/02/2006 3:04 PM MST",
+	"en-GB": "02/01/2006 15:04 MST",
+	"de-DE": "02.01.2006 15:04 MST",
+	"fr-FR": "02/01/2006 15:04 MST",
+	"es-ES": "02/01/2006 15:04 MST",
+	"nl-NL": "02-01-2006 15:04 MST",
+	"ja-JP": "2006/01/02 15:04 MST",
+	"sv-SE": "2006-01-02 15:04 MST",
+}
+
+// ReportFormatter renders the timestamps of CSV and XLSX reports in a timezone and locale
+type ReportFormatter struct {
+	location *time.Location
+	layout   string
+}
+
+// supportedReportLocales returns the locales accepted by REPORT_LOCALE and ?locale= (Pure Core)
+func supportedReportLocales() []string {
+	locales := make([]string, 0, len(reportTimestampLayouts))
+	for locale := range reportTimestampLayouts {
+		if locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// loadReportLocation resolves an IANA timezone name; an empty name is UTC
+func loadReportLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	return location, nil
+}
+
+// reportTimestampLayout returns the timestamp layout of a locale (Pure Core)
+func reportTimestampLayout(locale string) (string, error) {
+	layout, ok := reportTimestampLayouts[locale]
+	if !ok {
+		return "", fmt.Errorf("unsupported locale %q, expected one of %s", locale, strings.Join(supportedReportLocales(), ", "))
+	}
+	return layout, nil
+}
+
+// newReportFormatter resolves a timezone name and locale into a formatter; an empty timezone is UTC
+// and an empty locale keeps RFC 3339
+func newReportFormatter(timezone, locale string) (ReportFormatter, error) {
+	location, err := loadReportLocation(timezone)
+	if err != nil {
+		return ReportFormatter{}, err
+	}
+	layout, err := reportTimestampLayout(locale)
+	if err != nil {
+		return ReportFormatter{}, err
+	}
+	return ReportFormatter{location: location, layout: layout}, nil
+}
+
+// formatTimestamp renders an RFC 3339 timestamp read from the graph; values that do not parse are
+// returned unchanged rather than dropped (Pure Core)
+func (f ReportFormatter) formatTimestamp(value string) string {
+	if value == "" {
+		return ""
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	location := f.location
+	if location == nil {
+		location = time.UTC
+	}
+	layout := f.layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return parsed.In(location).Format(layout)
+}
+
+// reportFormatterFromRequest builds the formatter of a report request: ?tz= and ?locale= override the
+// configured REPORT_TIMEZONE and REPORT_LOCALE
+func reportFormatterFromRequest(ctx *gofr.Context, config ReportFormatConfig) (ReportFormatter, error) {
+	timezone, locale := config.Timezone, config.Locale
+	if value := ctx.Param("tz"); value != "" {
+		timezone = value
+	}
+	if value := ctx.Param("locale"); value != "" {
+		locale = value
+	}
+
+	location, err := loadReportLocation(timezone)
+	if err != nil {
+		return ReportFormatter{}, &gofrhttp.ErrorInvalidParam{Params: []string{"tz"}}
+	}
+	layout, err := reportTimestampLayout(locale)
+	if err != nil {
+		return ReportFormatter{}, &gofrhttp.ErrorInvalidParam{Params: []string{"locale"}}
+	}
+	return ReportFormatter{location: location, layout: layout}, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// overloadedOwnerFactor is how far above the average repository count an owner is considered overloaded
+const overloadedOwnerFactor = 2.0
+
+// OwnerLoad is the amount of code an owner is responsible for in the active scan
+type OwnerLoad struct {
+	Owner        string `json:"owner"`
+	Kind         string `json:"kind"`
+	Repositories int    `json:"repositories"`
+	Patterns     int    `json:"patterns"`
+}
+
+// ReviewLoadResponse lists per-owner load and the owners carrying a disproportionate share
+type ReviewLoadResponse struct {
+	Organization        string      `json:"organization"`
+	Owners              []OwnerLoad `json:"owners"`
+	AverageRepositories float64     `json:"average_repositories"`
+	Overloaded          []string    `json:"overloaded"`
+}
+
+// buildOwnerLoadQuery builds a query counting the repositories and patterns of every owner, leaving out
+// bot users when $excludeBots is set (Pure Core)
+func buildOwnerLoadQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER]->(rule_owner)
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, rule, ` + canonicalUserExpression("rule_owner") + ` AS owner
+		WHERE ` + countedOwnerCondition("owner") + `
+		WITH org, owner,
+			count(DISTINCT repo) AS repositories,
+			count(DISTINCT repo.full_name + ':' + rule.pattern) AS patterns
+		RETURN CASE WHEN owner:Team THEN '@' + org.login + '/' + owner.slug ELSE '@' + owner.login END AS owner,
+			CASE WHEN owner:Team THEN 'team' ELSE 'user' END AS kind,
+			repositories,
+			patterns
+		ORDER BY repositories DESC, patterns DESC, owner
+	`
+}
+
+// convertToOwnerLoads converts load records into owner loads (Pure Core)
+func convertToOwnerLoads(records []map[string]interface{}) []OwnerLoad {
+	loads := make([]OwnerLoad, 0, len(records))
+	for _, record := range records {
+		loads = append(loads, OwnerLoad{
+			Owner:        getStringFromMap(record, "owner"),
+			Kind:         getStringFromMap(record, "kind"),
+			Repositories: getIntFromMap(record, "repositories"),
+			Patterns:     getIntFromMap(record, "patterns"),
+		})
+	}
+	return loads
+}
+
+// buildReviewLoadResponse computes the average load and the overloaded owners (Pure Core)
+func buildReviewLoadResponse(orgName string, loads []OwnerLoad) ReviewLoadResponse {
+	response := ReviewLoadResponse{Organization: orgName, Owners: loads, Overloaded: []string{}}
+	if len(loads) == 0 {
+		return response
+	}
+
+	total := 0
+	for _, load := range loads {
+		total += load.Repositories
+	}
+	response.AverageRepositories = float64(total) / float64(len(loads))
+
+	for _, load := range loads {
+		if float64(load.Repositories) > overloadedOwnerFactor*response.AverageRepositories {
+			response.Overloaded = append(response.Overloaded, load.Owner)
+		}
+	}
+	return response
+}
+
+// indexOwnerLoads maps owners to the number of repositories they own (Pure Core)
+func indexOwnerLoads(loads []OwnerLoad) map[string]int {
+	index := make(map[string]int, len(loads))
+	for _, load := range loads {
+		index[load.Owner] = load.Repositories
+	}
+	return index
+}
+
+// fetchOwnerLoads retrieves the load of every owner of an organization (Orchestrator)
+func fetchOwnerLoads(ctx *gofr.Context, deps *AppDependencies, orgName string) ([]OwnerLoad, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildOwnerLoadQuery(), map[string]interface{}{
+		"orgName":     orgName,
+		"excludeBots": deps.currentConfig().Bots.Exclude,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	return convertToOwnerLoads(result.Records), nil
+}
+
+// handleGetReviewLoad returns per-owner review load to help rebalance ownership
+func (h *AppHandler) handleGetReviewLoad(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	loads, err := fetchOwnerLoads(ctx, h.deps, orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildReviewLoadResponse(orgName, loads), nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Kinds of fetched items counted against a scan's buffer budget
+const (
+	ScanBufferRepositories = "repositories"
+	ScanBufferTeams        = "teams"
+	ScanBufferRules        = "codeowners_rules"
+)
+
+// scanItemBudget counts the items a scan has fetched and holds in memory until they are written;
+// a limit of zero leaves the scan unbounded
+type scanItemBudget struct {
+	limit    int
+	buffered int
+}
+
+// ScanBudgetExceededError is returned when a scan fetches more items than it may buffer
+type ScanBudgetExceededError struct {
+	Organization string
+	Kind         string
+	Limit        int
+}
+
+// Error implements the error interface for ScanBudgetExceededError
+func (e ScanBudgetExceededError) Error() string {
+	return fmt.Sprintf("scan of organization %s exceeded its budget of %d buffered items while fetching %s; lower max_repos or raise SCAN_MAX_BUFFERED_ITEMS", e.Organization, e.Limit, e.Kind)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ScanBudgetExceededError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// ScanQueueFullError is returned when a background scan is requested while the job queue is full
+type ScanQueueFullError struct {
+	Limit int
+}
+
+// Error implements the error interface for ScanQueueFullError
+func (e ScanQueueFullError) Error() string {
+	return fmt.Sprintf("%d scan jobs are already queued or running; retry once one finishes", e.Limit)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ScanQueueFullError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// reserveBufferedItems adds count items to a budget, returning the new total and whether it is
+// within the limit (Pure Core)
+func reserveBufferedItems(budget scanItemBudget, count int) (scanItemBudget, bool) {
+	budget.buffered += count
+	return budget, budget.limit <= 0 || budget.buffered <= budget.limit
+}
+
+// limitBuffered sets how many fetched items the scan may hold before failing
+func (s *RunningScan) limitBuffered(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.budget = scanItemBudget{limit: limit}
+}
+
+// reserveBuffered accounts for count newly fetched items, reporting the updated budget and whether
+// the scan is still within it
+func (s *RunningScan) reserveBuffered(count int) (scanItemBudget, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	budget, ok := reserveBufferedItems(s.budget, count)
+	s.budget = budget
+	return budget, ok
+}
+
+// reserveScanItems accounts for fetched items held by the running scan of ctx, if any, and fails the
+// scan once they exceed its budget, before the next page is requested
+func reserveScanItems(ctx *gofr.Context, kind string, count int) error {
+	scan, ok := ctx.Value(runningScanContextKey{}).(*RunningScan)
+	if !ok || count <= 0 {
+		return nil
+	}
+
+	budget, ok := scan.reserveBuffered(count)
+	org := scan.snapshot().Request.Organization
+	newMetricsCollector(ctx, "codeowners-scanner").recordGauge("scan_buffered_items", float64(budget.buffered), MetricLabels{
+		"organization": org,
+	})
+	if ok {
+		return nil
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("scan_budget_exceeded_total", 1, MetricLabels{
+		"organization": org,
+		"kind":         kind,
+	})
+	logWarn(ctx, "Scan exceeded its buffered item budget", LogFields{
+		"component":    "scan_budget",
+		"operation":    "reserve_items",
+		"organization": org,
+		"kind":         kind,
+		"buffered":     budget.buffered,
+		"limit":        budget.limit,
+	})
+	return ScanBudgetExceededError{Organization: org, Kind: kind, Limit: budget.limit}
+}
+
+// releaseScanBuffer resets the buffer occupancy gauge of a finished scan
+func releaseScanBuffer(ctx *gofr.Context, scan *RunningScan) {
+	newMetricsCollector(ctx, "codeowners-scanner").recordGauge("scan_buffered_items", 0, MetricLabels{
+		"organization": scan.snapshot().Request.Organization,
+	})
+}
+
+// recordScanJobsActive reports how many background scan jobs are queued or running; the gauge is
+// skipped when the state store cannot be read
+func recordScanJobsActive(ctx *gofr.Context, store *ScanJobStore) {
+	active, err := store.activeCount(ctx)
+	if err != nil {
+		return
+	}
+	newMetricsCollector(ctx, "codeowners-scanner").recordGauge("scan_jobs_active", float64(active), MetricLabels{})
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// Exit codes of the scan command
+const (
+	scanExitSuccess         = 0
+	scanExitFailed          = 1
+	scanExitUsage           = 2
+	scanExitPendingApproval = 3
+)
+
+// ScanCommandOptions represents the flags of the scan command
+type ScanCommandOptions struct {
+	Organization string
+	MaxRepos     int
+	MaxTeams     int
+	UseTopics    bool
+	Mode         string
+	Provider     string
+	Once         bool
+	Interval     time.Duration
+}
+
+// ScanReport is the machine-readable result of a scan command run, written to stdout as one JSON line
+type ScanReport struct {
+	Organization       string      `json:"organization"`
+	Success            bool        `json:"success"`
+	ExitCode           int         `json:"exit_code"`
+	ScanID             string      `json:"scan_id,omitempty"`
+	ScanStatus         string      `json:"scan_status,omitempty"`
+	ValidationFailures []string    `json:"validation_failures,omitempty"`
+	Summary            ScanSummary `json:"summary"`
+	Errors             []string    `json:"errors,omitempty"`
+	Error              string      `json:"error,omitempty"`
+	StartedAt          string      `json:"started_at"`
+	DurationMs         int64       `json:"duration_ms"`
+}
+
+// parseScanCommandOptions parses the scan command flags (Pure Core)
+func parseScanCommandOptions(args []string, config AppConfig) (ScanCommandOptions, error) {
+	var options ScanCommandOptions
+
+	flags := flag.NewFlagSet("scan", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&options.Organization, "org", "", "GitHub organization to scan")
+	flags.IntVar(&options.MaxRepos, "max-repos", 100, "maximum number of repositories to scan")
+	flags.IntVar(&options.MaxTeams, "max-teams", 50, "maximum number of teams to scan")
+	flags.BoolVar(&options.UseTopics, "topics", config.GitHub.UseTopics, "group repositories by topic")
+	flags.StringVar(&options.Mode, "mode", ScanModeFull, "scan mode: full or incremental")
+	flags.StringVar(&options.Provider, "provider", "", "source code host: github, gitlab or bitbucket (default SCM_PROVIDER)")
+	flags.BoolVar(&options.Once, "once", false, "run a single scan and exit")
+	flags.DurationVar(&options.Interval, "interval", time.Hour, "time between scans when not running once")
+
+	if err := flags.Parse(args); err != nil {
+		return ScanCommandOptions{}, err
+	}
+	if options.Organization == "" {
+		return ScanCommandOptions{}, errors.New("missing required flag --org")
+	}
+	if !isValidScanMode(options.Mode) {
+		return ScanCommandOptions{}, errors.New("--mode must be full or incremental")
+	}
+	if validateScanProvider(options.Provider, config) != nil {
+		return ScanCommandOptions{}, errors.New("--provider must be github, gitlab or bitbucket with its credentials configured")
+	}
+	if !options.Once && options.Interval <= 0 {
+		return ScanCommandOptions{}, errors.New("--interval must be positive")
+	}
+
+	return options, nil
+}
+
+// determineScanExitCode maps a scan outcome to the process exit code (Pure Core)
+func determineScanExitCode(response ScanResponse, err error) int {
+	switch {
+	case err != nil:
+		return scanExitFailed
+	case response.ScanStatus == ScanStatusPendingApproval:
+		return scanExitPendingApproval
+	case !response.Success:
+		return scanExitFailed
+	default:
+		return scanExitSuccess
+	}
+}
+
+// buildScanReport builds the machine-readable report of a scan run (Pure Core)
+func buildScanReport(request ScanRequest, response ScanResponse, err error, startTime time.Time, duration time.Duration) ScanReport {
+	report := ScanReport{
+		Organization: request.Organization,
+		ExitCode:     determineScanExitCode(response, err),
+		StartedAt:    startTime.UTC().Format(time.RFC3339),
+		DurationMs:   duration.Milliseconds(),
+	}
+
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Success = report.ExitCode == scanExitSuccess
+	report.ScanID = response.ScanID
+	report.ScanStatus = response.ScanStatus
+	report.ValidationFailures = response.ValidationFailures
+	report.Summary = response.Summary
+	report.Errors = response.Errors
+	return report
+}
+
+// writeScanReport writes a report to stdout as a single JSON line
+func writeScanReport(report ScanReport) {
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write scan report: %v\n", err)
+	}
+}
+
+// runScanJob runs one tracked scan and reports its outcome
+func runScanJob(ctx *gofr.Context, deps *AppDependencies, options ScanCommandOptions) int {
+	request := ScanRequest{
+		Organization: options.Organization,
+		MaxRepos:     options.MaxRepos,
+		MaxTeams:     options.MaxTeams,
+		UseTopics:    options.UseTopics,
+		Mode:         options.Mode,
+		Provider:     options.Provider,
+	}
+
+	startTime := time.Now()
+	response, err := runTrackedScan(ctx, deps, request, 0)
+	report := buildScanReport(request, response, err, startTime, time.Since(startTime))
+	writeScanReport(report)
+
+	return report.ExitCode
+}
+
+// runScanLoop runs scans once, or repeatedly at the configured interval, returning the last exit code
+func runScanLoop(ctx *gofr.Context, deps *AppDependencies, options ScanCommandOptions) int {
+	for {
+		exitCode := runScanJob(ctx, deps, options)
+		if options.Once {
+			return exitCode
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitCode
+		case <-time.After(options.Interval):
+		}
+	}
+}
+
+// runScanCommand runs the scanner as a short-lived job (scan --org acme --once), sharing the
+// API's dependencies and exiting with a status code describing the scan outcome
+func runScanCommand(args []string) int {
+	ctx := context.Background()
+
+	deps, err := createAppDependencies(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create app dependencies: %v\n", err)
+		return scanExitFailed
+	}
+	defer func() {
+		if err := cleanupAppDependencies(ctx, deps); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to cleanup dependencies: %v\n", err)
+		}
+	}()
+
+	options, err := parseScanCommandOptions(args, deps.Config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nUsage: scan --org <organization> [--once] [--interval 1h] [--max-repos 100] [--max-teams 50] [--topics] [--mode full|incremental]\n", err)
+		return scanExitUsage
+	}
+
+	// GoFr routes commands from os.Args, so only the command name is left for it to match
+	os.Args = []string{os.Args[0], "scan"}
+
+	app := gofr.NewCMD()
+	registerGitHubService(app, deps.Config.GitHub)
+	registerGitLabService(app, deps.Config.GitLab)
+	registerBitbucketService(app, deps.Config.Bitbucket)
+
+	exitCode := scanExitFailed
+	app.SubCommand("scan", func(ctx *gofr.Context) (interface{}, error) {
+		exitCode = runScanLoop(ctx, deps, options)
+		return nil, nil
+	})
+	app.Run()
+
+	return exitCode
+}
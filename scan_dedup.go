@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// scanRoutePrefix is the path prefix of POST /api/scan/{org}
+const scanRoutePrefix = "/api/scan/"
+
+// ScanDedupResponse answers a scan request for an organization scanned within the deduplication
+// window with a reference to that scan instead of starting another
+type ScanDedupResponse struct {
+	Organization string `json:"organization"`
+	Deduplicated bool   `json:"deduplicated"`
+	ScanID       string `json:"scan_id"`
+	ScannedAt    string `json:"scanned_at"`
+	DedupWindow  string `json:"dedup_window"`
+	Stats        string `json:"stats"`
+	Graph        string `json:"graph"`
+}
+
+// extractScanOrgFromPath returns the organization of a POST /api/scan/{org} path (Pure Core)
+func extractScanOrgFromPath(path string) (string, bool) {
+	orgName, found := strings.CutPrefix(path, scanRoutePrefix)
+	if !found || orgName == "" || strings.Contains(orgName, "/") {
+		return "", false
+	}
+	return orgName, true
+}
+
+// isScannedWithin reports whether a scan activated at activatedAt is younger than window at now (Pure Core)
+func isScannedWithin(activatedAt string, window time.Duration, now time.Time) bool {
+	scannedAt, err := time.Parse(time.RFC3339, activatedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(scannedAt) < window
+}
+
+// buildScanDedupResponse references the recent scan answering a deduplicated request (Pure Core)
+func buildScanDedupResponse(orgName, scanID, scannedAt string, window time.Duration) ScanDedupResponse {
+	return ScanDedupResponse{
+		Organization: orgName,
+		Deduplicated: true,
+		ScanID:       scanID,
+		ScannedAt:    scannedAt,
+		DedupWindow:  window.String(),
+		Stats:        "/api/stats/" + orgName,
+		Graph:        graphRoutePrefix + orgName,
+	}
+}
+
+// findRecentScan returns the active scan of an organization when it was activated within window; any
+// lookup failure reports no recent scan, so the request scans as usual (Orchestrator)
+func findRecentScan(ctx context.Context, deps *AppDependencies, orgName string, window time.Duration) (ScanDedupResponse, bool) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return ScanDedupResponse{}, false
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildGraphCursorQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil || len(result.Records) == 0 {
+		return ScanDedupResponse{}, false
+	}
+
+	scanID := getStringFromMap(result.Records[0], "cursor")
+	activatedAt := getStringFromMap(result.Records[0], "activated_at")
+	if scanID == "" || !isScannedWithin(activatedAt, window, time.Now()) {
+		return ScanDedupResponse{}, false
+	}
+
+	logInfo(session.ctx, "Scan request answered with a recent scan", LogFields{
+		"component":    "scan_dedup",
+		"operation":    "deduplicate_scan",
+		"organization": orgName,
+		"scan_id":      scanID,
+		"scanned_at":   activatedAt,
+		"dedup_window": window.String(),
+	})
+	newMetricsCollector(session.ctx, "codeowners-scanner").recordCounter("scan_requests_deduplicated_total", 1, MetricLabels{
+		"organization": orgName,
+	})
+	return buildScanDedupResponse(orgName, scanID, activatedAt, window), true
+}
+
+// scanDedupMiddleware answers POST /api/scan/{org} with 202 and a reference to the active scan when
+// the organization was scanned successfully within SCAN_DEDUP_WINDOW; ?force=true always scans
+func scanDedupMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			window := deps.currentConfig().ScanJobs.DedupWindow
+			orgName, isScanRoute := extractScanOrgFromPath(r.URL.Path)
+			query := r.URL.Query()
+			force, _ := strconv.ParseBool(query.Get("force"))
+			if r.Method != http.MethodPost || !isScanRoute || window <= 0 || force || !isValidScanMode(query.Get("mode")) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			recent, ok := findRecentScan(r.Context(), deps, orgName, window)
+			if !ok {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Location", recent.Stats)
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": recent})
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Kinds of CODEOWNERS rule changes between two scans
+const (
+	ScanDiffRuleAdded         = "added"
+	ScanDiffRuleRemoved       = "removed"
+	ScanDiffRuleOwnersChanged = "owners_changed"
+)
+
+// Kinds of owner churn between two scans
+const (
+	ScanDiffOwnerNew      = "new"
+	ScanDiffOwnerDeparted = "departed"
+	ScanDiffOwnerChanged  = "changed"
+)
+
+// ScanDiffResponse is the ownership change between two scans of an organization
+type ScanDiffResponse struct {
+	Organization        string               `json:"organization"`
+	From                ScanDiffScan         `json:"from"`
+	To                  ScanDiffScan         `json:"to"`
+	Summary             ScanDiffSummary      `json:"summary"`
+	AddedRepositories   []string             `json:"added_repositories"`
+	RemovedRepositories []string             `json:"removed_repositories"`
+	ChangedRules        []ScanDiffRule       `json:"changed_rules"`
+	OwnerChurn          []ScanDiffOwnerChurn `json:"owner_churn"`
+}
+
+// ScanDiffScan identifies one side of a diff
+type ScanDiffScan struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	ActivatedAt string `json:"activated_at,omitempty"`
+}
+
+// ScanDiffSummary counts the changes of a diff
+type ScanDiffSummary struct {
+	AddedRepositories   int `json:"added_repositories"`
+	RemovedRepositories int `json:"removed_repositories"`
+	AddedRules          int `json:"added_rules"`
+	RemovedRules        int `json:"removed_rules"`
+	ChangedRules        int `json:"changed_rules"`
+	NewOwners           int `json:"new_owners"`
+	DepartedOwners      int `json:"departed_owners"`
+}
+
+// ScanDiffRule is a CODEOWNERS pattern of a repository that was added, removed or reassigned
+type ScanDiffRule struct {
+	Repository    string   `json:"repository"`
+	Pattern       string   `json:"pattern"`
+	Change        string   `json:"change"`
+	AddedOwners   []string `json:"added_owners,omitempty"`
+	RemovedOwners []string `json:"removed_owners,omitempty"`
+}
+
+// ScanDiffOwnerChurn is an owner whose set of owned repositories changed
+type ScanDiffOwnerChurn struct {
+	Owner              string   `json:"owner"`
+	Change             string   `json:"change"`
+	GainedRepositories []string `json:"gained_repositories"`
+	LostRepositories   []string `json:"lost_repositories"`
+	RepositoriesBefore int      `json:"repositories_before"`
+	RepositoriesAfter  int      `json:"repositories_after"`
+}
+
+// scanSnapshot is the ownership recorded on a Scan node when it was activated
+type scanSnapshot struct {
+	scan         ScanDiffScan
+	recorded     bool
+	repositories []string
+	rules        []string
+}
+
+// buildScanDiffQuery builds a query returning the snapshots of two scans of an organization; a missing
+// $from compares against the previous active scan and a missing $to against the active one (Pure Core)
+func buildScanDiffQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		WITH org, coalesce($from, org.previous_scan_id) AS from_id, coalesce($to, org.active_scan_id) AS to_id
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(scan:Scan)
+		WHERE scan.id IN [from_id, to_id]
+		RETURN from_id, to_id, collect(scan {
+			.id, .status, .activated_at, .snapshot_at, .snapshot_repositories, .snapshot_rules
+		}) AS scans
+	`
+}
+
+// convertScanSnapshots indexes the scans of a diff query record by id (Pure Core)
+func convertScanSnapshots(value interface{}) map[string]scanSnapshot {
+	list, _ := value.([]interface{})
+	snapshots := make(map[string]scanSnapshot, len(list))
+	for _, item := range list {
+		scan, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		snapshot := scanSnapshot{
+			scan: ScanDiffScan{
+				ID:          getStringFromMap(scan, "id"),
+				Status:      getStringFromMap(scan, "status"),
+				ActivatedAt: getStringFromMap(scan, "activated_at"),
+			},
+			recorded:     getStringFromMap(scan, "snapshot_at") != "",
+			repositories: getStringSliceFromMap(scan, "snapshot_repositories"),
+			rules:        getStringSliceFromMap(scan, "snapshot_rules"),
+		}
+		snapshots[snapshot.scan.ID] = snapshot
+	}
+	return snapshots
+}
+
+// snapshotRuleOwners groups the "repository<TAB>pattern<TAB>owner" entries of a snapshot into the
+// owners of each repository and pattern (Pure Core)
+func snapshotRuleOwners(rules []string) map[[2]string]map[string]bool {
+	owners := make(map[[2]string]map[string]bool)
+	for _, rule := range rules {
+		parts := strings.SplitN(rule, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		key := [2]string{parts[0], parts[1]}
+		if owners[key] == nil {
+			owners[key] = make(map[string]bool)
+		}
+		owners[key][parts[2]] = true
+	}
+	return owners
+}
+
+// snapshotOwnerRepositories returns the repositories each owner is assigned in, by any pattern (Pure Core)
+func snapshotOwnerRepositories(ruleOwners map[[2]string]map[string]bool) map[string]map[string]bool {
+	repositories := make(map[string]map[string]bool)
+	for key, owners := range ruleOwners {
+		for owner := range owners {
+			if repositories[owner] == nil {
+				repositories[owner] = make(map[string]bool)
+			}
+			repositories[owner][key[0]] = true
+		}
+	}
+	return repositories
+}
+
+// setDifference returns the sorted members of a that are not in b (Pure Core)
+func setDifference(a, b map[string]bool) []string {
+	difference := []string{}
+	for value := range a {
+		if !b[value] {
+			difference = append(difference, value)
+		}
+	}
+	sort.Strings(difference)
+	return difference
+}
+
+// stringSet builds a set of values (Pure Core)
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// diffScanSnapshots compares the ownership of two scans: repositories added and removed, patterns
+// added, removed or assigned different owners, and owners whose repositories changed (Pure Core)
+func diffScanSnapshots(orgName string, from, to scanSnapshot) ScanDiffResponse {
+	diff := ScanDiffResponse{
+		Organization: orgName,
+		From:         from.scan,
+		To:           to.scan,
+		ChangedRules: []ScanDiffRule{},
+		OwnerChurn:   []ScanDiffOwnerChurn{},
+	}
+
+	fromRepos, toRepos := stringSet(from.repositories), stringSet(to.repositories)
+	diff.AddedRepositories = setDifference(toRepos, fromRepos)
+	diff.RemovedRepositories = setDifference(fromRepos, toRepos)
+
+	fromRules, toRules := snapshotRuleOwners(from.rules), snapshotRuleOwners(to.rules)
+	keys := make(map[[2]string]bool, len(fromRules)+len(toRules))
+	for key := range fromRules {
+		keys[key] = true
+	}
+	for key := range toRules {
+		keys[key] = true
+	}
+	for key := range keys {
+		before, after := fromRules[key], toRules[key]
+		rule := ScanDiffRule{Repository: key[0], Pattern: key[1]}
+		switch {
+		case before == nil:
+			rule.Change = ScanDiffRuleAdded
+			rule.AddedOwners = setDifference(after, nil)
+			diff.Summary.AddedRules++
+		case after == nil:
+			rule.Change = ScanDiffRuleRemoved
+			rule.RemovedOwners = setDifference(before, nil)
+			diff.Summary.RemovedRules++
+		default:
+			rule.AddedOwners = setDifference(after, before)
+			rule.RemovedOwners = setDifference(before, after)
+			if len(rule.AddedOwners) == 0 && len(rule.RemovedOwners) == 0 {
+				continue
+			}
+			rule.Change = ScanDiffRuleOwnersChanged
+			diff.Summary.ChangedRules++
+		}
+		diff.ChangedRules = append(diff.ChangedRules, rule)
+	}
+	sort.Slice(diff.ChangedRules, func(i, j int) bool {
+		if diff.ChangedRules[i].Repository != diff.ChangedRules[j].Repository {
+			return diff.ChangedRules[i].Repository < diff.ChangedRules[j].Repository
+		}
+		return diff.ChangedRules[i].Pattern < diff.ChangedRules[j].Pattern
+	})
+
+	fromOwners, toOwners := snapshotOwnerRepositories(fromRules), snapshotOwnerRepositories(toRules)
+	owners := make(map[string]bool, len(fromOwners)+len(toOwners))
+	for owner := range fromOwners {
+		owners[owner] = true
+	}
+	for owner := range toOwners {
+		owners[owner] = true
+	}
+	for owner := range owners {
+		before, after := fromOwners[owner], toOwners[owner]
+		churn := ScanDiffOwnerChurn{
+			Owner:              owner,
+			GainedRepositories: setDifference(after, before),
+			LostRepositories:   setDifference(before, after),
+			RepositoriesBefore: len(before),
+			RepositoriesAfter:  len(after),
+		}
+		switch {
+		case before == nil:
+			churn.Change = ScanDiffOwnerNew
+			diff.Summary.NewOwners++
+		case after == nil:
+			churn.Change = ScanDiffOwnerDeparted
+			diff.Summary.DepartedOwners++
+		case len(churn.GainedRepositories) == 0 && len(churn.LostRepositories) == 0:
+			continue
+		default:
+			churn.Change = ScanDiffOwnerChanged
+		}
+		diff.OwnerChurn = append(diff.OwnerChurn, churn)
+	}
+	sort.Slice(diff.OwnerChurn, func(i, j int) bool {
+		return diff.OwnerChurn[i].Owner < diff.OwnerChurn[j].Owner
+	})
+
+	diff.Summary.AddedRepositories = len(diff.AddedRepositories)
+	diff.Summary.RemovedRepositories = len(diff.RemovedRepositories)
+	return diff
+}
+
+// getScanDiff compares the snapshots of two scans of an organization (Orchestrator)
+func getScanDiff(ctx *gofr.Context, deps *AppDependencies, orgName, fromID, toID string) (ScanDiffResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return ScanDiffResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	params := map[string]interface{}{"orgName": orgName, "from": nil, "to": nil}
+	if fromID != "" {
+		params["from"] = fromID
+	}
+	if toID != "" {
+		params["to"] = toID
+	}
+	result, err := executeNeo4jReadQuery(ctx, session, buildScanDiffQuery(), params)
+	if err != nil {
+		return ScanDiffResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(result.Records) == 0 {
+		return ScanDiffResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: orgName}
+	}
+
+	record := result.Records[0]
+	fromID, toID = getStringFromMap(record, "from_id"), getStringFromMap(record, "to_id")
+	if fromID == "" {
+		// Without a previous scan there is nothing to compare against by default
+		return ScanDiffResponse{}, createMissingParamError("from")
+	}
+	if toID == "" {
+		return ScanDiffResponse{}, createMissingParamError("to")
+	}
+
+	snapshots := convertScanSnapshots(record["scans"])
+	sides := make([]scanSnapshot, 0, 2)
+	for _, id := range []string{fromID, toID} {
+		snapshot, ok := snapshots[id]
+		if !ok {
+			return ScanDiffResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "scan", Value: id}
+		}
+		if !snapshot.recorded {
+			return ScanDiffResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "scan snapshot", Value: id}
+		}
+		sides = append(sides, snapshot)
+	}
+
+	return diffScanSnapshots(orgName, sides[0], sides[1]), nil
+}
+
+// handleGetScanDiff returns the ownership changes between two scans, by default the previous and
+// the active scan
+func (h *AppHandler) handleGetScanDiff(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return getScanDiff(ctx, h.deps, orgName, ctx.Param("from"), ctx.Param("to"))
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Scan job states reported by GET /api/scan/jobs/{id}
+const (
+	ScanJobQueued    = "queued"
+	ScanJobRunning   = "running"
+	ScanJobCompleted = "completed"
+	ScanJobFailed    = "failed"
+)
+
+// scanJobContextKey stores the job a background scan reports to in the context
+type scanJobContextKey struct{}
+
+// scanJobReference identifies the job a background scan belongs to
+type scanJobReference struct {
+	store     *ScanJobStore
+	id        string
+	retention time.Duration
+}
+
+// ScanJobError describes why a scan job failed
+type ScanJobError struct {
+	Message    string        `json:"message"`
+	StatusCode int           `json:"status_code"`
+	Category   ErrorCategory `json:"category"`
+	Retryable  bool          `json:"retryable"`
+}
+
+// ScanJob represents a background scan and its progress
+type ScanJob struct {
+	ID              string         `json:"id"`
+	Organization    string         `json:"organization"`
+	Mode            string         `json:"mode"`
+	State           string         `json:"state"`
+	Phase           string         `json:"phase,omitempty"`
+	ProgressPercent int            `json:"progress_percent"`
+	Batch           *BatchProgress `json:"batch,omitempty"`
+	ScanID          string         `json:"scan_id,omitempty"`
+	CreatedAt       string         `json:"created_at"`
+	StartedAt       string         `json:"started_at,omitempty"`
+	FinishedAt      string         `json:"finished_at,omitempty"`
+	LogArtifact     string         `json:"log_artifact,omitempty"`
+	Error           *ScanJobError  `json:"error,omitempty"`
+	Result          *ScanResponse  `json:"result,omitempty"`
+}
+
+// Roots of scan job keys in the state store: jobs by id, and the lock on each organization held by
+// its queued or running job or by a synchronous scan
+const (
+	scanJobKeyPrefix     = "scan-jobs/"
+	scanJobLockKeyPrefix = "scan-job-locks/"
+
+	// synchronousScanHolderPrefix marks organization locks held by a scan running outside the job queue
+	synchronousScanHolderPrefix = "sync-"
+)
+
+// ScanJobStore keeps scan jobs in the state store and limits how many scans this instance runs at
+// once. Live progress comes from the scans this instance runs; a job running on another instance
+// reports the phase of its last saved transition
+type ScanJobStore struct {
+	state   StateStore
+	mu      sync.Mutex
+	running map[string]*RunningScan
+	workers chan struct{}
+}
+
+// newScanJobStore creates a job store over state running at most workers scans at once
+func newScanJobStore(workers int, state StateStore) *ScanJobStore {
+	return &ScanJobStore{
+		state:   state,
+		running: make(map[string]*RunningScan),
+		workers: make(chan struct{}, max(workers, 1)),
+	}
+}
+
+// generateScanJobID returns a random job identifier
+func generateScanJobID() string {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return generateScanID("job", time.Now())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// isScanJobActive reports whether a job is waiting or running (Pure Core)
+func isScanJobActive(state string) bool {
+	return state == ScanJobQueued || state == ScanJobRunning
+}
+
+// buildScanJobError converts a scan error into job error details (Pure Core)
+func buildScanJobError(err error) *ScanJobError {
+	classified := classifyError(err)
+	return &ScanJobError{
+		Message:    err.Error(),
+		StatusCode: classified.StatusCode(),
+		Category:   classified.Category,
+		Retryable:  classified.Retryable,
+	}
+}
+
+// scanJobKey returns the state store key of a job (Pure Core)
+func scanJobKey(id string) string {
+	return scanJobKeyPrefix + id
+}
+
+// scanJobLockKey returns the state store key of an organization's job lock (Pure Core)
+func scanJobLockKey(organization string) string {
+	return scanJobLockKeyPrefix + strings.ToLower(organization)
+}
+
+// load reads a stored job
+func (s *ScanJobStore) load(ctx *gofr.Context, id string) (ScanJob, bool, error) {
+	value, ok, err := s.state.Get(ctx, scanJobKey(id))
+	if err != nil || !ok {
+		return ScanJob{}, false, err
+	}
+
+	var job ScanJob
+	if err := json.Unmarshal(value, &job); err != nil {
+		return ScanJob{}, false, fmt.Errorf("failed to decode scan job %s: %w", id, err)
+	}
+	return job, true, nil
+}
+
+// save writes a job, keeping it for retention after its latest transition
+func (s *ScanJobStore) save(ctx *gofr.Context, job ScanJob, retention time.Duration) error {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if err := s.state.Put(ctx, scanJobKey(job.ID), value, retention); err != nil {
+		return fmt.Errorf("failed to store scan job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// update applies a transition to a stored job; only the instance running a job updates it
+func (s *ScanJobStore) update(ctx *gofr.Context, id string, retention time.Duration, apply func(job *ScanJob)) error {
+	job, ok, err := s.load(ctx, id)
+	if err != nil || !ok {
+		return err
+	}
+	apply(&job)
+	return s.save(ctx, job, retention)
+}
+
+// enqueue records a queued job, refusing a second active job for the same organization and, with
+// maxActive set, any job once that many are queued or running. The organization's lock expires with
+// the job's retention, so an instance stopping mid-scan does not block the organization for good
+func (s *ScanJobStore) enqueue(ctx *gofr.Context, request ScanRequest, now time.Time, retention time.Duration, maxActive int) (ScanJob, error) {
+	mode := request.Mode
+	if mode == "" {
+		mode = ScanModeFull
+	}
+	job := ScanJob{
+		ID:           generateScanJobID(),
+		Organization: request.Organization,
+		Mode:         mode,
+		State:        ScanJobQueued,
+		CreatedAt:    now.UTC().Format(time.RFC3339),
+	}
+
+	if err := s.lockOrganization(ctx, request.Organization, job.ID, retention); err != nil {
+		return ScanJob{}, err
+	}
+
+	if maxActive > 0 {
+		active, err := s.activeCount(ctx)
+		if err == nil && active >= maxActive {
+			err = ScanQueueFullError{Limit: maxActive}
+		}
+		if err != nil {
+			s.unlock(ctx, job)
+			return ScanJob{}, err
+		}
+	}
+
+	if err := s.save(ctx, job, retention); err != nil {
+		s.unlock(ctx, job)
+		return ScanJob{}, err
+	}
+	return job, nil
+}
+
+// lockOrganization takes an organization's scan lock for holder, refusing with ScanInProgressError
+// while a job or synchronous scan holds it
+func (s *ScanJobStore) lockOrganization(ctx *gofr.Context, organization, holder string, ttl time.Duration) error {
+	lockKey := scanJobLockKey(organization)
+	acquired, err := s.state.PutIfAbsent(ctx, lockKey, []byte(holder), ttl)
+	if err != nil {
+		return fmt.Errorf("failed to lock scan jobs of %s: %w", organization, err)
+	}
+	if acquired {
+		return nil
+	}
+
+	phase := ScanJobQueued
+	if current, ok, _ := s.state.Get(ctx, lockKey); ok {
+		if strings.HasPrefix(string(current), synchronousScanHolderPrefix) {
+			phase = ScanJobRunning
+		} else if existing, found, _ := s.load(ctx, string(current)); found {
+			phase = existing.State
+		}
+	}
+	return ScanInProgressError{Organization: organization, Phase: phase}
+}
+
+// unlockOrganization releases an organization's scan lock, unless it has expired and another holder
+// took it
+func (s *ScanJobStore) unlockOrganization(ctx *gofr.Context, organization, holder string) {
+	lockKey := scanJobLockKey(organization)
+	current, ok, err := s.state.Get(ctx, lockKey)
+	if err == nil && ok && string(current) == holder {
+		err = s.state.Delete(ctx, lockKey)
+	}
+	if err != nil {
+		logWarn(ctx, "Failed to release scan job lock", LogFields{
+			"component":    "scan_jobs",
+			"operation":    "unlock_scan_job",
+			"holder":       holder,
+			"organization": organization,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// unlock releases the organization lock of a job
+func (s *ScanJobStore) unlock(ctx *gofr.Context, job ScanJob) {
+	s.unlockOrganization(ctx, job.Organization, job.ID)
+}
+
+// start marks a job running and links the scan it reports progress from
+func (s *ScanJobStore) start(ctx *gofr.Context, id string, scan *RunningScan, retention time.Duration) error {
+	s.mu.Lock()
+	s.running[id] = scan
+	s.mu.Unlock()
+
+	return s.update(ctx, id, retention, func(job *ScanJob) {
+		job.State = ScanJobRunning
+		job.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	})
+}
+
+// attachLogArtifact records the NDJSON artifact a job's log events are written to
+func (s *ScanJobStore) attachLogArtifact(ctx *gofr.Context, id, path string, retention time.Duration) error {
+	return s.update(ctx, id, retention, func(job *ScanJob) {
+		job.LogArtifact = path
+	})
+}
+
+// finish records the outcome of a job and releases its organization's lock
+func (s *ScanJobStore) finish(ctx *gofr.Context, queued ScanJob, retention time.Duration, response ScanResponse, err error) error {
+	s.mu.Lock()
+	scan := s.running[queued.ID]
+	delete(s.running, queued.ID)
+	s.mu.Unlock()
+	defer s.unlock(ctx, queued)
+
+	job, ok, loadErr := s.load(ctx, queued.ID)
+	if loadErr != nil || !ok {
+		job = queued
+	}
+
+	job.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	job.Batch = nil
+	if scan != nil {
+		progress := scan.snapshot()
+		job.Phase, job.ScanID = progress.Phase, progress.ScanID
+	}
+
+	if err != nil {
+		job.State = ScanJobFailed
+		job.Error = buildScanJobError(err)
+	} else {
+		job.State = ScanJobCompleted
+		job.ProgressPercent = 100
+		job.ScanID = response.ScanID
+		job.Result = &response
+	}
+	return s.save(ctx, job, retention)
+}
+
+// get returns a job with the live progress of its scan when this instance runs it
+func (s *ScanJobStore) get(ctx *gofr.Context, id string) (ScanJob, bool, error) {
+	job, ok, err := s.load(ctx, id)
+	if err != nil || !ok {
+		return ScanJob{}, false, err
+	}
+
+	s.mu.Lock()
+	scan := s.running[id]
+	s.mu.Unlock()
+
+	if scan != nil && isScanJobActive(job.State) {
+		progress := scan.snapshot()
+		batch := progress.Batch
+		job.Phase = progress.Phase
+		job.ScanID = progress.ScanID
+		job.ProgressPercent = calculateScanProgressPercent(progress.Phase, batch)
+		if batch.Total > 0 {
+			job.Batch = &batch
+		}
+	}
+	return job, true, nil
+}
+
+// activeCount returns how many jobs are queued or running on every instance sharing the state store
+func (s *ScanJobStore) activeCount(ctx *gofr.Context) (int, error) {
+	entries, err := s.state.List(ctx, scanJobKeyPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list scan jobs: %w", err)
+	}
+
+	active := 0
+	for _, value := range entries {
+		var job ScanJob
+		if json.Unmarshal(value, &job) == nil && isScanJobActive(job.State) {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// reportScanJobStarted links the running scan of a background job to the job, if ctx belongs to one
+func reportScanJobStarted(ctx *gofr.Context, scan *RunningScan) {
+	job, ok := ctx.Value(scanJobContextKey{}).(scanJobReference)
+	if !ok {
+		return
+	}
+	if err := job.store.start(ctx, job.id, scan, job.retention); err != nil {
+		logWarn(ctx, "Failed to record scan job start", LogFields{
+			"component": "scan_jobs",
+			"operation": "start_scan_job",
+			"job_id":    job.id,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// detachFromRequest returns a copy of ctx that outlives the HTTP request it was created for
+func detachFromRequest(ctx *gofr.Context) *gofr.Context {
+	scoped := *ctx
+	scoped.Context = context.WithoutCancel(ctx.Context)
+	return &scoped
+}
+
+// runBackgroundScanJob waits for a free worker and runs the scan of a queued job
+func runBackgroundScanJob(ctx *gofr.Context, deps *AppDependencies, job ScanJob, request ScanRequest) {
+	store := deps.ScanJobs
+	store.workers <- struct{}{}
+	defer func() { <-store.workers }()
+
+	config := deps.currentConfig().ScanJobs
+	scoped := *ctx
+	scoped.Context = context.WithValue(ctx.Context, scanJobContextKey{}, scanJobReference{store: store, id: job.ID, retention: config.Retention})
+	if directory := config.LogDirectory; directory != "" {
+		artifact, err := openScanLogArtifact(directory, job.ID)
+		if err != nil {
+			logWarn(ctx, "Scan job log artifact unavailable", LogFields{
+				"component": "scan_jobs",
+				"operation": "open_log_artifact",
+				"job_id":    job.ID,
+				"error":     err.Error(),
+			})
+		} else {
+			defer artifact.close()
+			scoped.Context = withScanLogArtifact(scoped.Context, artifact)
+			if err := store.attachLogArtifact(&scoped, job.ID, artifact.path, config.Retention); err != nil {
+				logWarn(ctx, "Failed to record scan job log artifact", LogFields{
+					"component": "scan_jobs",
+					"operation": "open_log_artifact",
+					"job_id":    job.ID,
+					"error":     err.Error(),
+				})
+			}
+		}
+	}
+	ctx = &scoped
+
+	response, err := runTrackedScan(ctx, deps, request, 0)
+	if finishErr := store.finish(ctx, job, config.Retention, response, err); finishErr != nil {
+		logError(ctx, "Failed to record scan job outcome", LogFields{
+			"component": "scan_jobs",
+			"operation": "finish_scan_job",
+			"job_id":    job.ID,
+			"error":     finishErr.Error(),
+		})
+	}
+	recordScanJobsActive(ctx, store)
+
+	fields := LogFields{
+		"component":    "scan_jobs",
+		"operation":    "run_scan_job",
+		"job_id":       job.ID,
+		"organization": request.Organization,
+		"mode":         job.Mode,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		logError(ctx, "Scan job failed", fields)
+		return
+	}
+	fields["scan_id"] = response.ScanID
+	logInfo(ctx, "Scan job completed", fields)
+}
+
+// enqueueScanJob accepts a scan as a background job and returns it in the queued state
+func enqueueScanJob(ctx *gofr.Context, deps *AppDependencies, request ScanRequest) (ScanJob, error) {
+	if deps.Scans.isRunning(request.Organization) {
+		return ScanJob{}, ScanInProgressError{Organization: request.Organization, Phase: ScanJobRunning}
+	}
+
+	config := deps.currentConfig().ScanJobs
+	job, err := deps.ScanJobs.enqueue(ctx, request, time.Now(), config.Retention, config.MaxQueued)
+	if err != nil {
+		return ScanJob{}, err
+	}
+	recordScanJobsActive(ctx, deps.ScanJobs)
+
+	logInfo(ctx, "Scan job queued", LogFields{
+		"component":    "scan_jobs",
+		"operation":    "enqueue_scan_job",
+		"job_id":       job.ID,
+		"organization": request.Organization,
+		"mode":         job.Mode,
+	})
+
+	go runBackgroundScanJob(detachFromRequest(ctx), deps, job, request)
+	return job, nil
+}
+
+// runLockedScan runs a scan synchronously under the organization's scan lock, so it cannot overlap a
+// queued job or synchronous scan of the same organization on any instance (Orchestrator)
+func runLockedScan(ctx *gofr.Context, deps *AppDependencies, request ScanRequest) (ScanResponse, error) {
+	holder := synchronousScanHolderPrefix + generateScanJobID()
+	if err := deps.ScanJobs.lockOrganization(ctx, request.Organization, holder, deps.currentConfig().ScanJobs.Retention); err != nil {
+		return ScanResponse{}, err
+	}
+	defer deps.ScanJobs.unlockOrganization(ctx, request.Organization, holder)
+
+	return runTrackedScan(ctx, deps, request, 0)
+}
+
+// handleGetScanJob returns the state, progress and outcome of a background scan
+func (h *AppHandler) handleGetScanJob(ctx *gofr.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+	if id == "" {
+		return nil, createMissingParamError("id")
+	}
+
+	job, ok, err := h.deps.ScanJobs.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Jobs are kept in the state store until their retention expires
+		return nil, &gofrhttp.ErrorEntityNotFound{Name: "scan job", Value: id}
+	}
+	return job, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// scanLogContextKey stores the log artifact of a background scan in the context
+type scanLogContextKey struct{}
+
+// ScanLogArtifact appends the structured log events of one scan job to an NDJSON file
+type ScanLogArtifact struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	encoder *json.Encoder
+	failed  bool
+}
+
+// scanLogArtifactPath returns the artifact file of a scan job (Pure Core)
+func scanLogArtifactPath(directory, jobID string) string {
+	return filepath.Join(directory, jobID+".ndjson")
+}
+
+// openScanLogArtifact creates the artifact of a scan job in directory, which may be a mounted object
+// storage bucket
+func openScanLogArtifact(directory, jobID string) (*ScanLogArtifact, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create scan log directory: %w", err)
+	}
+
+	path := scanLogArtifactPath(directory, jobID)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scan log artifact: %w", err)
+	}
+	return &ScanLogArtifact{path: path, file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// write appends one event; after a failed write the artifact stops writing rather than logging the
+// failure through the logger that feeds it
+func (a *ScanLogArtifact) write(level, message string, fields LogFields) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.failed || a.file == nil {
+		return
+	}
+	err := a.encoder.Encode(DiagnosticLogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     strings.ToLower(level),
+		Message:   message,
+		Fields:    LogFields(sanitizeParams(fields)),
+	})
+	if err != nil {
+		a.failed = true
+		fmt.Fprintf(os.Stderr, "failed to write scan log artifact %s: %v\n", a.path, err)
+	}
+}
+
+// close flushes and closes the artifact file
+func (a *ScanLogArtifact) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// withScanLogArtifact returns a copy of ctx whose log events are also written to artifact
+func withScanLogArtifact(ctx context.Context, artifact *ScanLogArtifact) context.Context {
+	return context.WithValue(ctx, scanLogContextKey{}, artifact)
+}
+
+// recordScanLogEvent appends a log event to the scan log artifact of ctx, if any
+func recordScanLogEvent(ctx context.Context, level, message string, fields LogFields) {
+	if artifact, ok := ctx.Value(scanLogContextKey{}).(*ScanLogArtifact); ok {
+		artifact.write(level, message, fields)
+	}
+}
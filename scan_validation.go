@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Scan publication states reported in scan responses
+const (
+	ScanStatusActive          = "active"
+	ScanStatusPendingApproval = "pending_approval"
+	ScanStatusDiscarded       = "discarded"
+	ScanStatusFailed          = "failed"
+)
+
+// ScanMetrics holds the figures recorded on a scan and compared against the previous active scan
+type ScanMetrics struct {
+	RepositoryCount     int
+	ReposWithCodeowners int
+}
+
+// ScanBaseline represents what the graph looked like before a staged scan is published
+type ScanBaseline struct {
+	Previous *ScanMetrics
+}
+
+// ScanOutcome represents how a stored scan was published
+type ScanOutcome struct {
+	ScanID             string
+	Status             string
+	ValidationFailures []string
+}
+
+// PendingScan represents a scan held for manual approval
+type PendingScan struct {
+	ScanID              string   `json:"scan_id"`
+	Organization        string   `json:"organization"`
+	StartedAt           string   `json:"started_at"`
+	HeldAt              string   `json:"held_at"`
+	RepositoryCount     int      `json:"repository_count"`
+	ReposWithCodeowners int      `json:"repos_with_codeowners"`
+	ValidationFailures  []string `json:"validation_failures"`
+}
+
+// PendingScansResponse represents the list of scans awaiting approval
+type PendingScansResponse struct {
+	Scans []PendingScan `json:"scans"`
+	Count int           `json:"count"`
+}
+
+// ScanDecisionResponse represents the result of approving or rejecting a held scan
+type ScanDecisionResponse struct {
+	ScanID       string `json:"scan_id"`
+	Organization string `json:"organization"`
+	Status       string `json:"status"`
+}
+
+// calculateScanMetrics derives validation metrics from scanned data (Pure Core)
+func calculateScanMetrics(repos []GitHubRepository, codeowners []GitHubCodeowners) ScanMetrics {
+	return ScanMetrics{
+		RepositoryCount:     len(repos),
+		ReposWithCodeowners: len(codeowners),
+	}
+}
+
+// evaluateScanValidation runs the sanity checks a staged scan must pass before publishing. The
+// organization checked is the one the scan fetched, since the graph node is written before publishing (Pure Core)
+func evaluateScanValidation(config ScanValidationConfig, org GitHubOrganization, baseline ScanBaseline, current ScanMetrics) []string {
+	if !config.Enabled {
+		return nil
+	}
+
+	var failures []string
+
+	if org.Login == "" || org.ID == 0 {
+		failures = append(failures, "organization fetched by the scan has no login or ID")
+	}
+
+	if baseline.Previous == nil {
+		return failures
+	}
+
+	previous := *baseline.Previous
+	if change, ok := calculateRepoCountChangePercent(previous.RepositoryCount, current.RepositoryCount); ok && change > config.MaxRepoCountChangePercent {
+		failures = append(failures, fmt.Sprintf(
+			"repository count changed by %d%% (%d -> %d), exceeding the %d%% limit",
+			change, previous.RepositoryCount, current.RepositoryCount, config.MaxRepoCountChangePercent,
+		))
+	}
+
+	if previous.ReposWithCodeowners > 0 && current.ReposWithCodeowners == 0 {
+		failures = append(failures, fmt.Sprintf(
+			"codeowner coverage dropped to 0 (previously %d repositories), possibly due to token permissions",
+			previous.ReposWithCodeowners,
+		))
+	}
+
+	return failures
+}
+
+// calculateRepoCountChangePercent returns the absolute change between two repository counts in percent (Pure Core)
+func calculateRepoCountChangePercent(previous, current int) (int, bool) {
+	if previous == 0 {
+		return 0, false
+	}
+
+	delta := current - previous
+	if delta < 0 {
+		delta = -delta
+	}
+
+	return delta * 100 / previous, true
+}
+
+// buildScanBaselineQuery builds a query returning the active scan metrics of an organization (Pure Core)
+func buildScanBaselineQuery() string {
+	return `
+		OPTIONAL MATCH (org:Organization {login: $org_login})
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(active:Scan {status: 'active'})
+		RETURN active IS NOT NULL AS has_active_scan,
+			active.repository_count AS repository_count,
+			active.repos_with_codeowners AS repos_with_codeowners
+		LIMIT 1
+	`
+}
+
+// buildHoldScanQuery builds a query marking a staged scan as pending manual approval (Pure Core)
+func buildHoldScanQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id, status: 'staging'})
+		SET scan.status = 'pending_approval',
+			scan.held_at = $held_at,
+			scan.validation_failures = $validation_failures
+		RETURN scan.id AS scan_id
+	`
+}
+
+// buildPendingScansQuery builds a query listing scans held for approval (Pure Core)
+func buildPendingScansQuery() string {
+	return `
+		MATCH (scan:Scan {status: 'pending_approval'})
+		RETURN scan {
+			.id, .organization, .started_at, .held_at,
+			.repository_count, .repos_with_codeowners, .validation_failures
+		} AS scan
+		ORDER BY scan.held_at DESC
+	`
+}
+
+// buildPendingScanQuery builds a query looking up a single held scan (Pure Core)
+func buildPendingScanQuery() string {
+	return `
+		MATCH (scan:Scan {id: $scan_id, status: 'pending_approval'})
+		RETURN scan.organization AS organization
+	`
+}
+
+// convertToScanBaseline converts a baseline query record to a ScanBaseline (Pure Core)
+func convertToScanBaseline(records []map[string]interface{}) ScanBaseline {
+	if len(records) == 0 {
+		return ScanBaseline{}
+	}
+
+	record := records[0]
+	baseline := ScanBaseline{}
+
+	if getBoolFromMap(record, "has_active_scan") {
+		baseline.Previous = &ScanMetrics{
+			RepositoryCount:     getIntFromMap(record, "repository_count"),
+			ReposWithCodeowners: getIntFromMap(record, "repos_with_codeowners"),
+		}
+	}
+
+	return baseline
+}
+
+// convertToPendingScan converts a pending scan record to a PendingScan (Pure Core)
+func convertToPendingScan(record map[string]interface{}) PendingScan {
+	scanMap, ok := record["scan"].(map[string]interface{})
+	if !ok {
+		return PendingScan{}
+	}
+
+	return PendingScan{
+		ScanID:              getStringFromMap(scanMap, "id"),
+		Organization:        getStringFromMap(scanMap, "organization"),
+		StartedAt:           getStringFromMap(scanMap, "started_at"),
+		HeldAt:              getStringFromMap(scanMap, "held_at"),
+		RepositoryCount:     getIntFromMap(scanMap, "repository_count"),
+		ReposWithCodeowners: getIntFromMap(scanMap, "repos_with_codeowners"),
+		ValidationFailures:  getStringSliceFromMap(scanMap, "validation_failures"),
+	}
+}
+
+// getBoolFromMap safely extracts a bool value from a map (Pure Core)
+func getBoolFromMap(m map[string]interface{}, key string) bool {
+	if val, ok := m[key].(bool); ok {
+		return val
+	}
+	return false
+}
+
+// getStringSliceFromMap safely extracts a string slice from a map (Pure Core)
+func getStringSliceFromMap(m map[string]interface{}, key string) []string {
+	values, ok := m[key].([]interface{})
+	if !ok {
+		return []string{}
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// fetchScanBaseline reads the active scan metrics of an organization (Orchestrator)
+func fetchScanBaseline(ctx context.Context, session *Neo4jSession, orgLogin string) (ScanBaseline, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildScanBaselineQuery(), map[string]interface{}{
+		"org_login": orgLogin,
+	})
+	if err != nil {
+		return ScanBaseline{}, fmt.Errorf("failed to fetch scan baseline: %w", err)
+	}
+
+	return convertToScanBaseline(result.Records), nil
+}
+
+// holdStagingScan keeps a staged scan unpublished until an admin approves it (Orchestrator)
+func holdStagingScan(ctx context.Context, session *Neo4jSession, orgLogin, scanID string, failures []string) error {
+	_, err := executeNeo4jWrite(ctx, session, buildHoldScanQuery(), map[string]interface{}{
+		"org_login":           orgLogin,
+		"scan_id":             scanID,
+		"held_at":             time.Now().UTC().Format(time.RFC3339),
+		"validation_failures": failures,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hold scan for approval: %w", err)
+	}
+
+	logWarn(session.ctx, "Scan held for manual approval", LogFields{
+		"component":           "scan_validation",
+		"operation":           "hold_scan",
+		"organization":        orgLogin,
+		"scan_id":             scanID,
+		"validation_failures": failures,
+	})
+
+	return nil
+}
+
+// publishStagingScan validates a staged scan and either activates it or holds it for approval (Orchestrator)
+func publishStagingScan(ctx context.Context, session *Neo4jSession, config ScanValidationConfig, org GitHubOrganization, scanID string, metrics ScanMetrics) (ScanOutcome, error) {
+	orgLogin := org.Login
+	baseline, err := fetchScanBaseline(ctx, session, orgLogin)
+	if err != nil {
+		return ScanOutcome{}, err
+	}
+
+	if failures := evaluateScanValidation(config, org, baseline, metrics); len(failures) > 0 {
+		if err := holdStagingScan(ctx, session, orgLogin, scanID, failures); err != nil {
+			return ScanOutcome{}, err
+		}
+		return ScanOutcome{ScanID: scanID, Status: ScanStatusPendingApproval, ValidationFailures: failures}, nil
+	}
+
+	if err := activateStagingScan(ctx, session, orgLogin, scanID); err != nil {
+		return ScanOutcome{}, err
+	}
+
+	return ScanOutcome{ScanID: scanID, Status: ScanStatusActive}, nil
+}
+
+// lookupPendingScanOrganization returns the organization a held scan belongs to
+func lookupPendingScanOrganization(ctx *gofr.Context, session *Neo4jSession, scanID string) (string, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildPendingScanQuery(), map[string]interface{}{
+		"scan_id": scanID,
+	})
+	if err != nil {
+		return "", convertNeo4jErrorToGoFr(err)
+	}
+
+	if len(result.Records) == 0 {
+		return "", &gofrhttp.ErrorEntityNotFound{
+			Name:  "pending scan",
+			Value: scanID,
+		}
+	}
+
+	return getStringFromMap(result.Records[0], "organization"), nil
+}
+
+// handleListPendingScans lists scans held for manual approval
+func (h *AppHandler) handleListPendingScans(ctx *gofr.Context) (interface{}, error) {
+	session, err := createNeo4jSession(ctx, h.deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildPendingScansQuery(), map[string]interface{}{})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	scans := make([]PendingScan, 0, len(result.Records))
+	for _, record := range result.Records {
+		scans = append(scans, convertToPendingScan(record))
+	}
+
+	return PendingScansResponse{Scans: scans, Count: len(scans)}, nil
+}
+
+// handleApproveScan publishes a held scan despite failed validation checks
+func (h *AppHandler) handleApproveScan(ctx *gofr.Context) (interface{}, error) {
+	scanID := ctx.PathParam("scanId")
+	if scanID == "" {
+		return nil, &gofrhttp.ErrorMissingParam{Params: []string{"scanId"}}
+	}
+
+	session, err := createNeo4jSession(ctx, h.deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	orgLogin, err := lookupPendingScanOrganization(ctx, session, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := activateStagingScan(ctx, session, orgLogin, scanID); err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	h.deps.GraphChanges.notify(orgLogin)
+
+	logWarn(ctx, "Held scan approved and published", LogFields{
+		"component":    "admin",
+		"operation":    "approve_scan",
+		"organization": orgLogin,
+		"scan_id":      scanID,
+	})
+
+	return ScanDecisionResponse{ScanID: scanID, Organization: orgLogin, Status: ScanStatusActive}, nil
+}
+
+// handleRejectScan discards a held scan and keeps the current active data
+func (h *AppHandler) handleRejectScan(ctx *gofr.Context) (interface{}, error) {
+	scanID := ctx.PathParam("scanId")
+	if scanID == "" {
+		return nil, &gofrhttp.ErrorMissingParam{Params: []string{"scanId"}}
+	}
+
+	session, err := createNeo4jSession(ctx, h.deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	orgLogin, err := lookupPendingScanOrganization(ctx, session, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	discardStagingScan(ctx, session, orgLogin, scanID, fmt.Errorf("rejected by administrator"))
+
+	return ScanDecisionResponse{ScanID: scanID, Organization: orgLogin, Status: ScanStatusDiscarded}, nil
+}
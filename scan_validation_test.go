@@ -0,0 +1,111 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateScanValidation(t *testing.T) {
+	enabled := ScanValidationConfig{Enabled: true, MaxRepoCountChangePercent: 50}
+	org := GitHubOrganization{ID: 42, Login: "acme"}
+	previous := &ScanMetrics{RepositoryCount: 100, ReposWithCodeowners: 40}
+
+	tests := []struct {
+		name     string
+		config   ScanValidationConfig
+		org      GitHubOrganization
+		baseline ScanBaseline
+		current  ScanMetrics
+		want     []string
+	}{
+		{
+			name:     "disabled",
+			config:   ScanValidationConfig{MaxRepoCountChangePercent: 50},
+			baseline: ScanBaseline{Previous: previous},
+		},
+		{
+			name:    "first scan",
+			config:  enabled,
+			org:     org,
+			current: ScanMetrics{RepositoryCount: 10},
+		},
+		{
+			name:     "within limits",
+			config:   enabled,
+			org:      org,
+			baseline: ScanBaseline{Previous: previous},
+			current:  ScanMetrics{RepositoryCount: 140, ReposWithCodeowners: 35},
+		},
+		{
+			name:    "organization without login",
+			config:  enabled,
+			org:     GitHubOrganization{ID: 42},
+			current: ScanMetrics{RepositoryCount: 10},
+			want:    []string{"organization fetched by the scan has no login or ID"},
+		},
+		{
+			name:    "organization without ID",
+			config:  enabled,
+			org:     GitHubOrganization{Login: "acme"},
+			current: ScanMetrics{RepositoryCount: 10},
+			want:    []string{"organization fetched by the scan has no login or ID"},
+		},
+		{
+			name:     "repository count dropped",
+			config:   enabled,
+			org:      org,
+			baseline: ScanBaseline{Previous: previous},
+			current:  ScanMetrics{RepositoryCount: 20, ReposWithCodeowners: 10},
+			want:     []string{"repository count changed by 80% (100 -> 20), exceeding the 50% limit"},
+		},
+		{
+			name:     "codeowner coverage lost",
+			config:   enabled,
+			org:      org,
+			baseline: ScanBaseline{Previous: previous},
+			current:  ScanMetrics{RepositoryCount: 100},
+			want:     []string{"codeowner coverage dropped to 0 (previously 40 repositories), possibly due to token permissions"},
+		},
+		{
+			name:     "every check fails",
+			config:   enabled,
+			baseline: ScanBaseline{Previous: previous},
+			current:  ScanMetrics{RepositoryCount: 300},
+			want: []string{
+				"organization fetched by the scan has no login or ID",
+				"repository count changed by 200% (100 -> 300), exceeding the 50% limit",
+				"codeowner coverage dropped to 0 (previously 40 repositories), possibly due to token permissions",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateScanValidation(tt.config, tt.org, tt.baseline, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateScanValidation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRepoCountChangePercent(t *testing.T) {
+	tests := []struct {
+		previous, current int
+		want              int
+		wantOK            bool
+	}{
+		{previous: 0, current: 10, want: 0, wantOK: false},
+		{previous: 100, current: 100, want: 0, wantOK: true},
+		{previous: 100, current: 150, want: 50, wantOK: true},
+		{previous: 100, current: 25, want: 75, wantOK: true},
+		{previous: 3, current: 4, want: 33, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := calculateRepoCountChangePercent(tt.previous, tt.current)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("calculateRepoCountChangePercent(%d, %d) = (%d, %v), want (%d, %v)", tt.previous, tt.current, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// generateScanID builds a unique identifier for a scan of an organization (Pure Core)
+func generateScanID(orgLogin string, startedAt time.Time) string {
+	validateOrgLoginNotEmpty(orgLogin)
+	return fmt.Sprintf("%s-%d", orgLogin, startedAt.UTC().UnixNano())
+}
+
+// buildCreateStagingScanQuery builds a query to register a staging scan for an organization (Pure Core)
+func buildCreateStagingScanQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})
+		CREATE (scan:Scan {
+			id: $scan_id,
+			organization: $org_login,
+			status: 'staging',
+			started_at: $started_at,
+			repository_count: $repository_count,
+			repos_with_codeowners: $repos_with_codeowners
+		})
+		MERGE (org)-[:HAS_SCAN]->(scan)
+		RETURN scan.id AS scan_id
+	`
+}
+
+// buildApplyStagedPropertiesClause builds the clause copying the node properties staged on a scan onto
+// the nodes and dropping the staging relationships. Only relationships are versioned per scan; node
+// properties are staged on the scan and written in place when it is published, with an empty email or
+// package name clearing the stored one. (Pure Core)
+func buildApplyStagedPropertiesClause() string {
+	return `
+		OPTIONAL MATCH (scan)-[staged:STAGES_PROPERTIES]->(node)
+		FOREACH (_ IN CASE WHEN staged IS NULL THEN [] ELSE [1] END |
+			SET node += properties(staged)
+			SET node.email = CASE WHEN staged.email = '' THEN NULL ELSE node.email END,
+				node.go_module = CASE WHEN staged.go_module = '' THEN NULL ELSE node.go_module END,
+				node.npm_package = CASE WHEN staged.npm_package = '' THEN NULL ELSE node.npm_package END
+			DELETE staged
+		)
+		WITH DISTINCT scan
+	`
+}
+
+// buildActivateScanQuery builds a query that atomically swaps the organization's active scan pointer and
+// publishes the node properties staged by the scan (Pure Core)
+func buildActivateScanQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		WHERE scan.status IN ['staging', 'pending_approval']
+		OPTIONAL MATCH (org)-[:HAS_SCAN]->(previous:Scan {status: 'active'})
+		WITH org, scan, collect(previous) AS previous_scans
+		FOREACH (p IN previous_scans |
+			SET p.status = 'superseded', p.superseded_at = $activated_at
+		)
+		SET scan.status = 'active',
+			scan.activated_at = $activated_at,
+			org.previous_scan_id = org.active_scan_id,
+			org.active_scan_id = $scan_id
+		WITH scan` + buildApplyStagedPropertiesClause() + `
+		RETURN scan.id AS scan_id
+	`
+}
+
+// buildApplyStagedPropertiesQuery builds a query publishing the node properties staged on an already
+// active scan, as webhook deliveries do (Pure Core)
+func buildApplyStagedPropertiesQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		WITH scan` + buildApplyStagedPropertiesClause() + `
+		RETURN scan.id AS scan_id
+	`
+}
+
+// buildStoreScanSnapshotQuery builds a query recording the repositories and owner assignments of a scan
+// on its Scan node, so the scan can still be compared after its relationships are pruned. Rules are
+// stored as "repository<TAB>pattern<TAB>owner" with lower-cased owners. (Pure Core)
+func buildStoreScanSnapshotQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		OPTIONAL MATCH (org)-[:OWNS {scan_id: $scan_id}]->(repo:Repository)
+		OPTIONAL MATCH (repo)-[rule:HAS_CODEOWNER|HAS_TEAM_OWNER {scan_id: $scan_id}]->(owner)
+		WITH scan, repo, collect(DISTINCT CASE WHEN owner IS NULL THEN NULL ELSE
+			repo.full_name + '\t' + rule.pattern + '\t' +
+			toLower(CASE WHEN owner:Team THEN '@' + $org_login + '/' + owner.slug ELSE '@' + owner.login END)
+		END) AS repo_rules
+		WITH scan, collect(repo.full_name) AS repositories, reduce(acc = [], rules IN collect(repo_rules) | acc + rules) AS rules
+		SET scan.snapshot_repositories = repositories,
+			scan.snapshot_rules = rules,
+			scan.snapshot_at = $snapshot_at
+		RETURN size(repositories) AS repositories, size(rules) AS rules
+	`
+}
+
+// buildPruneInactiveRepositoryRelationshipsQuery builds a query removing repository relationships from
+// inactive scans; closed CODEOWNERS versions are kept for the ownership timeline (Pure Core)
+func buildPruneInactiveRepositoryRelationshipsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS]->(repo:Repository)
+		WITH DISTINCT org, repo
+		MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER|HAS_TOPIC|HAS_CODEOWNERS_FILE]->()
+		WHERE coalesce(r.scan_id, '') <> org.active_scan_id AND r.valid_to IS NULL
+		DELETE r
+	`
+}
+
+// buildPruneInactiveOrganizationRelationshipsQuery builds a query removing organization relationships from inactive scans (Pure Core)
+func buildPruneInactiveOrganizationRelationshipsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[r:OWNS|HAS_TEAM|HAS_TOPIC]->()
+		WHERE coalesce(r.scan_id, '') <> org.active_scan_id
+		DELETE r
+	`
+}
+
+// buildPruneOrphanedCodeownersBlobsQuery builds a query removing CODEOWNERS blobs no repository references anymore (Pure Core)
+func buildPruneOrphanedCodeownersBlobsQuery() string {
+	return `
+		MATCH (blob:CodeownersBlob)
+		WHERE NOT EXISTS { MATCH ()-[:HAS_CODEOWNERS_FILE]->(blob) }
+		DELETE blob
+	`
+}
+
+// buildDiscardScanRepositoryRelationshipsQuery builds a query removing repository relationships written by a scan (Pure Core)
+func buildDiscardScanRepositoryRelationshipsQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository)
+		WITH DISTINCT repo
+		MATCH (repo)-[r {scan_id: $scan_id}]->()
+		DELETE r
+	`
+}
+
+// buildDiscardScanQuery builds a query removing organization relationships and staged node properties of
+// a scan and marking it discarded (Pure Core)
+func buildDiscardScanQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})
+		OPTIONAL MATCH (org)-[r {scan_id: $scan_id}]->()
+		DELETE r
+		WITH DISTINCT org
+		MATCH (org)-[:HAS_SCAN]->(scan:Scan {id: $scan_id})
+		OPTIONAL MATCH (scan)-[staged:STAGES_PROPERTIES]->()
+		DELETE staged
+		WITH DISTINCT scan
+		SET scan.status = 'discarded',
+			scan.discarded_at = $discarded_at,
+			scan.error = $error
+		RETURN scan.id AS scan_id
+	`
+}
+
+// beginStagingScan registers a new staging scan that readers cannot see yet (Orchestrator)
+func beginStagingScan(ctx context.Context, session *Neo4jSession, orgLogin, scanID string, metrics ScanMetrics) error {
+	validateNeo4jSessionNotNil(session)
+	validateOrgLoginNotEmpty(orgLogin)
+
+	_, err := executeNeo4jWrite(ctx, session, buildCreateStagingScanQuery(), map[string]interface{}{
+		"org_login":             orgLogin,
+		"scan_id":               scanID,
+		"started_at":            time.Now().UTC().Format(time.RFC3339),
+		"repository_count":      metrics.RepositoryCount,
+		"repos_with_codeowners": metrics.ReposWithCodeowners,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create staging scan: %w", err)
+	}
+
+	return nil
+}
+
+// activateStagingScan swaps the active scan pointer to the staged scan and prunes superseded data (Orchestrator)
+func activateStagingScan(ctx context.Context, session *Neo4jSession, orgLogin, scanID string) error {
+	validateNeo4jSessionNotNil(session)
+	validateOrgLoginNotEmpty(orgLogin)
+
+	activatedAt := time.Now().UTC().Format(time.RFC3339)
+	result, err := executeNeo4jWrite(ctx, session, buildActivateScanQuery(), map[string]interface{}{
+		"org_login":    orgLogin,
+		"scan_id":      scanID,
+		"activated_at": activatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to activate scan: %w", err)
+	}
+
+	if len(result.Records) == 0 {
+		notStaged := newNeo4jError(ErrorCategoryValidation, "SCAN_NOT_STAGED", "Scan could not be activated",
+			fmt.Sprintf("no staged or held scan %s found for organization %s", scanID, orgLogin), false)
+		notStaged.HTTPStatus = http.StatusConflict
+		return notStaged
+	}
+
+	// The snapshot only serves scan diffs, so failing to record it does not undo the activation
+	if _, err := executeNeo4jWrite(ctx, session, buildStoreScanSnapshotQuery(), map[string]interface{}{
+		"org_login":   orgLogin,
+		"scan_id":     scanID,
+		"snapshot_at": time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		logWarn(session.ctx, "Failed to record scan snapshot", LogFields{
+			"component":    "scan_versions",
+			"operation":    "store_scan_snapshot",
+			"organization": orgLogin,
+			"scan_id":      scanID,
+			"error":        err.Error(),
+		})
+	}
+
+	// Versions compare against the previous scan's relationships, so they are recorded before pruning
+	if err := recordCodeownerVersions(ctx, session, orgLogin, scanID, activatedAt); err != nil {
+		logWarn(session.ctx, "Failed to record CODEOWNERS versions", LogFields{
+			"component":    "scan_versions",
+			"operation":    "record_codeowner_versions",
+			"organization": orgLogin,
+			"scan_id":      scanID,
+			"error":        err.Error(),
+		})
+	}
+
+	// Readers already follow the new pointer, so pruning failures only leave unreachable relationships behind
+	params := map[string]interface{}{"org_login": orgLogin}
+	for _, query := range []string{
+		buildPruneInactiveRepositoryRelationshipsQuery(),
+		buildPruneInactiveOrganizationRelationshipsQuery(),
+		buildPruneOrphanedCodeownersBlobsQuery(),
+	} {
+		if _, err := executeNeo4jWrite(ctx, session, query, params); err != nil {
+			logWarn(session.ctx, "Failed to prune relationships of inactive scans", LogFields{
+				"component":    "scan_versions",
+				"operation":    "prune_inactive_scans",
+				"organization": orgLogin,
+				"scan_id":      scanID,
+				"error":        err.Error(),
+			})
+			break
+		}
+	}
+
+	return nil
+}
+
+// applyStagedProperties publishes the node properties staged on an already active scan (Orchestrator)
+func applyStagedProperties(ctx context.Context, session *Neo4jSession, orgLogin, scanID string) error {
+	validateNeo4jSessionNotNil(session)
+	validateOrgLoginNotEmpty(orgLogin)
+
+	_, err := executeNeo4jWrite(ctx, session, buildApplyStagedPropertiesQuery(), map[string]interface{}{
+		"org_login": orgLogin,
+		"scan_id":   scanID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply staged properties: %w", err)
+	}
+	return nil
+}
+
+// discardStagingScan removes everything written by a failed scan, including the node properties it
+// staged, so the published nodes keep their values (Orchestrator)
+func discardStagingScan(ctx context.Context, session *Neo4jSession, orgLogin, scanID string, cause error) {
+	validateNeo4jSessionNotNil(session)
+
+	params := map[string]interface{}{
+		"org_login":    orgLogin,
+		"scan_id":      scanID,
+		"discarded_at": time.Now().UTC().Format(time.RFC3339),
+		"error":        cause.Error(),
+	}
+
+	for _, query := range []string{
+		buildDiscardScanRepositoryRelationshipsQuery(),
+		buildDiscardScanQuery(),
+		buildPruneOrphanedCodeownersBlobsQuery(),
+	} {
+		if _, err := executeNeo4jWrite(ctx, session, query, params); err != nil {
+			logError(session.ctx, "Failed to discard staging scan", LogFields{
+				"component":    "scan_versions",
+				"operation":    "discard_scan",
+				"organization": orgLogin,
+				"scan_id":      scanID,
+				"cause":        cause.Error(),
+				"error":        err.Error(),
+			})
+			return
+		}
+	}
+
+	logWarn(session.ctx, "Staging scan discarded", LogFields{
+		"component":    "scan_versions",
+		"operation":    "discard_scan",
+		"organization": orgLogin,
+		"scan_id":      scanID,
+		"cause":        cause.Error(),
+	})
+}
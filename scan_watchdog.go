@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// scanWatchdogSchedule is the cron schedule of the stalled scan watchdog
+const scanWatchdogSchedule = "* * * * *"
+
+// Scan phases reported as progress by a running scan
+const (
+	ScanPhaseFetchOrganization = "fetch_organization"
+	ScanPhaseFetchRepositories = "fetch_repositories"
+	ScanPhaseFetchTeams        = "fetch_teams"
+	ScanPhaseFetchCodeowners   = "fetch_codeowners"
+	ScanPhaseFetchManifests    = "fetch_manifests"
+	ScanPhaseStore             = "store"
+)
+
+// runningScanContextKey stores the running scan of a request in the context
+type runningScanContextKey struct{}
+
+// scanPhaseProgress maps each scan phase to the share of the overall scan completed when it
+// starts and ends, in percent
+var scanPhaseProgress = map[string][2]int{
+	ScanPhaseFetchOrganization: {0, 5},
+	ScanPhaseFetchRepositories: {5, 20},
+	ScanPhaseFetchTeams:        {20, 25},
+	ScanPhaseFetchCodeowners:   {25, 70},
+	ScanPhaseFetchManifests:    {70, 80},
+	ScanPhaseStore:             {80, 100},
+}
+
+// BatchProgress counts the items of the current scan phase that have been processed
+type BatchProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ScanProgress describes an in-flight scan and its last reported progress
+type ScanProgress struct {
+	Request      ScanRequest
+	Tenant       string
+	Attempt      int
+	ScanID       string
+	Phase        string
+	Batch        BatchProgress
+	StartedAt    time.Time
+	LastProgress time.Time
+}
+
+// RunningScan tracks the progress of an in-flight scan; it doubles as the organization's scan lock.
+// cancel stops the scan's context, so a scan the watchdog gave up on stops writing
+type RunningScan struct {
+	mu     sync.Mutex
+	state  ScanProgress
+	budget scanItemBudget
+	cancel context.CancelFunc
+}
+
+// ScanTracker holds the running scans of this instance, one per organization
+type ScanTracker struct {
+	mu      sync.Mutex
+	running map[string]*RunningScan
+}
+
+// ScanInProgressError is returned when an organization is already being scanned
+type ScanInProgressError struct {
+	Organization string
+	Phase        string
+}
+
+// Error implements the error interface for ScanInProgressError
+func (e ScanInProgressError) Error() string {
+	return fmt.Sprintf("a scan of organization %s is already running (phase: %s)", e.Organization, e.Phase)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (ScanInProgressError) StatusCode() int {
+	return http.StatusConflict
+}
+
+// newScanTracker creates an empty scan tracker
+func newScanTracker() *ScanTracker {
+	return &ScanTracker{running: make(map[string]*RunningScan)}
+}
+
+// acquire registers a scan of an organization, failing if one is already running
+func (t *ScanTracker) acquire(request ScanRequest, tenant string, attempt int) (*RunningScan, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if existing, exists := t.running[request.Organization]; exists {
+		return nil, ScanInProgressError{Organization: request.Organization, Phase: existing.snapshot().Phase}
+	}
+
+	now := time.Now()
+	scan := &RunningScan{state: ScanProgress{
+		Request:      request,
+		Tenant:       tenant,
+		Attempt:      attempt,
+		Phase:        ScanPhaseFetchOrganization,
+		StartedAt:    now,
+		LastProgress: now,
+	}}
+	t.running[request.Organization] = scan
+	return scan, nil
+}
+
+// release removes a scan unless the watchdog already replaced or removed it
+func (t *ScanTracker) release(scan *RunningScan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	org := scan.snapshot().Request.Organization
+	if t.running[org] == scan {
+		delete(t.running, org)
+	}
+}
+
+// removeStalled cancels, removes and returns the scans without progress for longer than timeout
+func (t *ScanTracker) removeStalled(now time.Time, timeout time.Duration) []ScanProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var stalled []ScanProgress
+	for org, scan := range t.running {
+		progress := scan.snapshot()
+		if isScanStalled(progress.LastProgress, now, timeout) {
+			scan.stop()
+			stalled = append(stalled, progress)
+			delete(t.running, org)
+		}
+	}
+	return stalled
+}
+
+// isRunning reports whether a scan of an organization is in flight on this instance
+func (t *ScanTracker) isRunning(org string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, exists := t.running[org]
+	return exists
+}
+
+// trackedScanIDs returns the staging scan IDs owned by running scans of this instance
+func (t *ScanTracker) trackedScanIDs() map[string]bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := make(map[string]bool, len(t.running))
+	for _, scan := range t.running {
+		if id := scan.snapshot().ScanID; id != "" {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// progress records a heartbeat and, when given, the current phase
+func (s *RunningScan) progress(phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.LastProgress = time.Now()
+	if phase != "" && phase != s.state.Phase {
+		s.state.Phase = phase
+		s.state.Batch = BatchProgress{}
+	}
+}
+
+// batchProgress records how many items of the current phase have been processed
+func (s *RunningScan) batchProgress(done, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.LastProgress = time.Now()
+	s.state.Batch = BatchProgress{Done: done, Total: total}
+}
+
+// staged records the staging scan ID once it exists in Neo4j
+func (s *RunningScan) staged(scanID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.ScanID = scanID
+	s.state.LastProgress = time.Now()
+}
+
+// cancellable derives the scan's context from ctx, so stop cancels it
+func (s *RunningScan) cancellable(ctx *gofr.Context) (*gofr.Context, context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scoped := *ctx
+	scoped.Context, s.cancel = context.WithCancel(ctx.Context)
+	return &scoped, s.cancel
+}
+
+// stop cancels the scan's context, if it has one
+func (s *RunningScan) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// snapshot returns a copy of the progress fields
+func (s *RunningScan) snapshot() ScanProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state
+}
+
+// calculateScanProgressPercent estimates overall completion from the phase and its batch progress (Pure Core)
+func calculateScanProgressPercent(phase string, batch BatchProgress) int {
+	bounds, ok := scanPhaseProgress[phase]
+	if !ok {
+		return 0
+	}
+	if batch.Total <= 0 {
+		return bounds[0]
+	}
+	done := min(batch.Done, batch.Total)
+	return bounds[0] + (bounds[1]-bounds[0])*done/batch.Total
+}
+
+// isScanStalled reports whether a scan has gone without progress for longer than timeout (Pure Core)
+func isScanStalled(lastProgress, now time.Time, timeout time.Duration) bool {
+	return now.Sub(lastProgress) > timeout
+}
+
+// shouldRestartScan reports whether a stalled scan is retried automatically (Pure Core)
+func shouldRestartScan(config ScanWatchdogConfig, attempt int) bool {
+	return config.RestartStalled && attempt < config.MaxRestarts
+}
+
+// withRunningScan returns a copy of ctx that reports progress to scan
+func withRunningScan(ctx *gofr.Context, scan *RunningScan) *gofr.Context {
+	scoped := *ctx
+	scoped.Context = context.WithValue(ctx.Context, runningScanContextKey{}, scan)
+	return &scoped
+}
+
+// withTenant returns a copy of ctx attributed to tenant
+func withTenant(ctx *gofr.Context, tenant string) *gofr.Context {
+	scoped := *ctx
+	scoped.Context = context.WithValue(ctx.Context, tenantContextKey{}, tenant)
+	return &scoped
+}
+
+// checkScanCancelled returns an error once the scan of ctx has been cancelled, so it stops before
+// writing to a staging scan the watchdog already discarded
+func checkScanCancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("scan cancelled: %w", err)
+	}
+	return nil
+}
+
+// reportScanProgress records a heartbeat for the running scan of ctx, if any
+func reportScanProgress(ctx context.Context, phase string) {
+	if scan, ok := ctx.Value(runningScanContextKey{}).(*RunningScan); ok {
+		scan.progress(phase)
+	}
+}
+
+// reportScanBatchProgress records how many items of the current phase the running scan of ctx has processed, if any
+func reportScanBatchProgress(ctx context.Context, done, total int) {
+	if scan, ok := ctx.Value(runningScanContextKey{}).(*RunningScan); ok {
+		scan.batchProgress(done, total)
+	}
+}
+
+// reportScanStaged records the staging scan ID of the running scan of ctx, if any
+func reportScanStaged(ctx context.Context, scanID string) {
+	if scan, ok := ctx.Value(runningScanContextKey{}).(*RunningScan); ok {
+		scan.staged(scanID)
+	}
+}
+
+// buildStaleStagingScansQuery builds a query returning staging scans started before a cutoff (Pure Core)
+func buildStaleStagingScansQuery() string {
+	return `
+		MATCH (org:Organization)-[:HAS_SCAN]->(scan:Scan {status: 'staging'})
+		WHERE scan.started_at < $cutoff
+		RETURN org.login AS organization, scan.id AS scan_id
+	`
+}
+
+// failStalledScan discards the staging data of a stalled scan, whose context removeStalled already
+// cancelled, and optionally restarts it
+func failStalledScan(ctx *gofr.Context, deps *AppDependencies, scan ScanProgress, session *Neo4jSession) {
+	stalledFor := time.Since(scan.LastProgress).Round(time.Second)
+	cause := fmt.Errorf("scan stalled in phase %s with no progress for %s", scan.Phase, stalledFor)
+
+	if scan.ScanID != "" && session != nil {
+		discardStagingScan(ctx, session, scan.Request.Organization, scan.ScanID, cause)
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("scan_watchdog_stalled_total", 1, MetricLabels{
+		"organization": scan.Request.Organization,
+		"phase":        scan.Phase,
+	})
+
+	restart := shouldRestartScan(deps.currentConfig().ScanWatchdog, scan.Attempt)
+	logError(ctx, "Stalled scan marked as failed", LogFields{
+		"component":    "scan_watchdog",
+		"operation":    "fail_stalled_scan",
+		"alert":        "scan_stalled",
+		"organization": scan.Request.Organization,
+		"scan_id":      scan.ScanID,
+		"phase":        scan.Phase,
+		"attempt":      scan.Attempt,
+		"stalled_for":  stalledFor.String(),
+		"restart":      restart,
+	})
+
+	if restart {
+		go restartStalledScan(withTenant(ctx, scan.Tenant), deps, scan.Request, scan.Attempt+1)
+	}
+}
+
+// runTrackedScan runs a scan while holding the organization's scan lock and reporting progress to the watchdog
+func runTrackedScan(ctx *gofr.Context, deps *AppDependencies, request ScanRequest, attempt int) (response ScanResponse, err error) {
+	scan, err := deps.Scans.acquire(request, tenantFromContext(ctx), attempt)
+	if err != nil {
+		return ScanResponse{}, err
+	}
+	defer deps.Scans.release(scan)
+	defer releaseScanBuffer(ctx, scan)
+	defer recoverScanPanic(ctx, deps, scan, &err)
+	scan.limitBuffered(deps.currentConfig().ScanBudget.MaxBufferedItems)
+	reportScanJobStarted(ctx, scan)
+
+	scanCtx, cancel := scan.cancellable(withRunningScan(ctx, scan))
+	defer cancel()
+	return scanOrganization(scanCtx, deps, request)
+}
+
+// restartStalledScan runs a new attempt of a stalled scan in the background
+func restartStalledScan(ctx *gofr.Context, deps *AppDependencies, request ScanRequest, attempt int) {
+	if _, err := runTrackedScan(ctx, deps, request, attempt); err != nil {
+		logError(ctx, "Restarted scan failed", LogFields{
+			"component":    "scan_watchdog",
+			"operation":    "restart_scan",
+			"organization": request.Organization,
+			"attempt":      attempt,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// discardOrphanedStagingScans discards staging scans left behind by crashed or restarted instances.
+// It runs on the leader only and spares scans another replica still publishes heartbeats for
+func discardOrphanedStagingScans(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession) {
+	cutoff := time.Now().Add(-deps.currentConfig().ScanWatchdog.StallTimeout).UTC().Format(time.RFC3339)
+	result, err := executeNeo4jReadQuery(ctx, session, buildStaleStagingScansQuery(), map[string]interface{}{"cutoff": cutoff})
+	if err != nil {
+		logError(ctx, "Failed to look up orphaned staging scans", LogFields{
+			"component": "scan_watchdog",
+			"operation": "find_orphaned_scans",
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	tracked := deps.Scans.trackedScanIDs()
+	for _, record := range result.Records {
+		scanID := getStringFromMap(record, "scan_id")
+		if tracked[scanID] || hasScanHeartbeat(ctx, deps, scanID) {
+			continue
+		}
+
+		org := getStringFromMap(record, "organization")
+		discardStagingScan(ctx, session, org, scanID, fmt.Errorf("staging scan orphaned for longer than %s", deps.currentConfig().ScanWatchdog.StallTimeout))
+		newMetricsCollector(ctx, "codeowners-scanner").recordCounter("scan_watchdog_orphaned_total", 1, MetricLabels{
+			"organization": org,
+		})
+	}
+}
+
+// checkStalledScans fails scans without progress for longer than the stall timeout
+func checkStalledScans(ctx *gofr.Context, deps *AppDependencies) {
+	stalled := deps.Scans.removeStalled(time.Now(), deps.currentConfig().ScanWatchdog.StallTimeout)
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		logError(ctx, "Failed to create Neo4j session for scan watchdog", LogFields{
+			"component": "scan_watchdog",
+			"operation": "check_stalled_scans",
+			"error":     err.Error(),
+		})
+	} else {
+		defer closeNeo4jSession(ctx, session)
+	}
+
+	for _, scan := range stalled {
+		failStalledScan(ctx, deps, scan, session)
+	}
+
+	publishScanHeartbeats(ctx, deps)
+	if session != nil && deps.Leader.isLeader(time.Now()) && !deps.Maintenance.isEnabledNow(ctx) {
+		discardOrphanedStagingScans(ctx, deps, session)
+	}
+}
+
+// registerScanWatchdog schedules the periodic stalled scan check
+func registerScanWatchdog(app *gofr.App, deps *AppDependencies) {
+	app.AddCronJob(scanWatchdogSchedule, "scan-watchdog", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "scan_watchdog", func() {
+			checkStalledScans(ctx, deps)
+		})
+	})
+}
@@ -0,0 +1,603 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// scanSchedulerTick is the cron schedule on which the scheduler checks whether a scheduled scan is due;
+// the scan schedule itself is evaluated in-process so it can change without re-registering the job
+const scanSchedulerTick = "* * * * *"
+
+// scheduledScanRunsLimit is the number of recent scheduled runs returned with the schedule
+const scheduledScanRunsLimit = 20
+
+// cronLookahead bounds the search for the next run of a schedule
+const cronLookahead = 366 * 24 * time.Hour
+
+// Keys of the scan schedule in the state store: the schedule set through the API, the lease of the
+// run in progress and the claim of each due minute
+const (
+	scheduleKey            = "schedule/current"
+	scheduleRunningKey     = "schedule/running"
+	scheduleClaimKeyPrefix = "schedule/claims/"
+)
+
+// scheduledRunLease bounds how long a run blocks the next one when the instance running it stops
+// without releasing it
+const scheduledRunLease = 12 * time.Hour
+
+// Outcomes of a scheduled scan run
+const (
+	ScheduledRunRunning   = "running"
+	ScheduledRunSucceeded = "succeeded"
+	ScheduledRunPartial   = "partial"
+	ScheduledRunFailed    = "failed"
+)
+
+// CronExpression is a parsed five-field cron expression; each field is a bit set of allowed values
+type CronExpression struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// A day matches when both day fields do if either is "*", and when either does otherwise
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ScanSchedule is the recurring scan of a list of organizations
+type ScanSchedule struct {
+	Cron          string   `json:"cron"`
+	Organizations []string `json:"organizations"`
+	MaxRepos      int      `json:"max_repos"`
+	MaxTeams      int      `json:"max_teams"`
+}
+
+// ScheduledScanFailure is an organization whose scheduled scan failed
+type ScheduledScanFailure struct {
+	Organization string `json:"organization"`
+	Error        string `json:"error"`
+}
+
+// ScheduledScanRun is the outcome of one run of the scan schedule
+type ScheduledScanRun struct {
+	ID            string                 `json:"id"`
+	Cron          string                 `json:"cron"`
+	Status        string                 `json:"status"`
+	StartedAt     string                 `json:"started_at"`
+	FinishedAt    string                 `json:"finished_at,omitempty"`
+	Organizations []string               `json:"organizations"`
+	Succeeded     []string               `json:"succeeded"`
+	Failed        []ScheduledScanFailure `json:"failed"`
+}
+
+// ScheduleResponse is the current scan schedule, its next run and its recent runs
+type ScheduleResponse struct {
+	ScanSchedule
+	Enabled   bool               `json:"enabled"`
+	Running   bool               `json:"running"`
+	UpdatedAt string             `json:"updated_at,omitempty"`
+	NextRunAt string             `json:"next_run_at,omitempty"`
+	Runs      []ScheduledScanRun `json:"runs"`
+}
+
+// storedScanSchedule is a schedule set through the API, as kept in the state store
+type storedScanSchedule struct {
+	ScanSchedule
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ScanScheduler keeps the runtime scan schedule in the state store, falling back to the configured
+// schedule until one is set through the API; instances sharing a state store run each due minute once
+type ScanScheduler struct {
+	state      StateStore
+	configured ScanSchedule
+}
+
+// newScanScheduler creates the scheduler over state from the configured schedule, which validation
+// has already parsed
+func newScanScheduler(config ScanScheduleConfig, state StateStore) *ScanScheduler {
+	return &ScanScheduler{
+		state: state,
+		configured: ScanSchedule{
+			Cron:          config.Cron,
+			Organizations: config.Organizations,
+			MaxRepos:      config.MaxRepos,
+			MaxTeams:      config.MaxTeams,
+		},
+	}
+}
+
+// parseCronField parses one cron field of lists, ranges and steps into a bit set (Pure Core)
+func parseCronField(field string, low, high int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = parsed
+		}
+
+		start, end := low, high
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var errFrom, errTo error
+			start, errFrom = strconv.Atoi(from)
+			end, errTo = strconv.Atoi(to)
+			if errFrom != nil || errTo != nil || start > end {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start, end = value, value
+			if hasStep {
+				end = high
+			}
+		}
+		if start < low || end > high {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, low, high)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronExpression parses a standard five-field cron expression: minute, hour, day of month, month
+// and day of week, where 7 is also Sunday (Pure Core)
+func parseCronExpression(expression string) (CronExpression, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return CronExpression{}, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var sets [5]uint64
+	for i, field := range fields {
+		bits, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return CronExpression{}, fmt.Errorf("cron field %d: %w", i+1, err)
+		}
+		sets[i] = bits
+	}
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return CronExpression{
+		minutes:       sets[0],
+		hours:         sets[1],
+		daysOfMonth:   sets[2],
+		months:        sets[3],
+		daysOfWeek:    sets[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// matches reports whether the expression fires in the minute of t (Pure Core)
+func (c CronExpression) matches(t time.Time) bool {
+	if c.minutes&(1<<uint(t.Minute())) == 0 || c.hours&(1<<uint(t.Hour())) == 0 || c.months&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	dayOfMonth := c.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := c.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// next returns the first minute after after in which the expression fires, or the zero time when it
+// does not fire within a year (Pure Core)
+func (c CronExpression) next(after time.Time) time.Time {
+	candidate := after.Truncate(time.Minute).Add(time.Minute)
+	for limit := after.Add(cronLookahead); candidate.Before(limit); candidate = candidate.Add(time.Minute) {
+		if c.matches(candidate) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// buildScheduledScanRunStatus derives the status of a finished run from its outcomes (Pure Core)
+func buildScheduledScanRunStatus(succeeded int, failed int) string {
+	switch {
+	case failed == 0:
+		return ScheduledRunSucceeded
+	case succeeded == 0:
+		return ScheduledRunFailed
+	default:
+		return ScheduledRunPartial
+	}
+}
+
+// buildStoreScheduledScanRunQuery builds a query creating or updating a scheduled scan run (Pure Core)
+func buildStoreScheduledScanRunQuery() string {
+	return `
+		MERGE (run:ScheduledScanRun {id: $id})
+		SET run.cron = $cron,
+			run.status = $status,
+			run.started_at = $started_at,
+			run.finished_at = $finished_at,
+			run.organizations = $organizations,
+			run.succeeded = $succeeded,
+			run.failed_organizations = $failed_organizations,
+			run.failed_errors = $failed_errors
+	`
+}
+
+// buildScheduledScanRunsQuery builds a query returning the most recent scheduled scan runs (Pure Core)
+func buildScheduledScanRunsQuery() string {
+	return `
+		MATCH (run:ScheduledScanRun)
+		RETURN run.id AS id,
+			run.cron AS cron,
+			run.status AS status,
+			run.started_at AS started_at,
+			run.finished_at AS finished_at,
+			run.organizations AS organizations,
+			run.succeeded AS succeeded,
+			run.failed_organizations AS failed_organizations,
+			run.failed_errors AS failed_errors
+		ORDER BY run.started_at DESC
+		LIMIT $limit
+	`
+}
+
+// convertToScheduledScanRun converts a scheduled scan run record (Pure Core)
+func convertToScheduledScanRun(record map[string]interface{}) ScheduledScanRun {
+	run := ScheduledScanRun{
+		ID:            getStringFromMap(record, "id"),
+		Cron:          getStringFromMap(record, "cron"),
+		Status:        getStringFromMap(record, "status"),
+		StartedAt:     getStringFromMap(record, "started_at"),
+		FinishedAt:    getStringFromMap(record, "finished_at"),
+		Organizations: getStringSliceFromMap(record, "organizations"),
+		Succeeded:     getStringSliceFromMap(record, "succeeded"),
+		Failed:        []ScheduledScanFailure{},
+	}
+
+	organizations := getStringSliceFromMap(record, "failed_organizations")
+	errors := getStringSliceFromMap(record, "failed_errors")
+	for i, organization := range organizations {
+		failure := ScheduledScanFailure{Organization: organization}
+		if i < len(errors) {
+			failure.Error = errors[i]
+		}
+		run.Failed = append(run.Failed, failure)
+	}
+	return run
+}
+
+// load returns the schedule set through the API, or the configured one, with its parsed expression
+// and when it was set
+func (s *ScanScheduler) load(ctx *gofr.Context) (ScanSchedule, CronExpression, time.Time, error) {
+	schedule, updatedAt := s.configured, time.Time{}
+	value, ok, err := s.state.Get(ctx, scheduleKey)
+	if err != nil {
+		return ScanSchedule{}, CronExpression{}, time.Time{}, fmt.Errorf("failed to read scan schedule: %w", err)
+	}
+	if ok {
+		var stored storedScanSchedule
+		if err := json.Unmarshal(value, &stored); err != nil {
+			return ScanSchedule{}, CronExpression{}, time.Time{}, fmt.Errorf("failed to decode scan schedule: %w", err)
+		}
+		schedule = stored.ScanSchedule
+		updatedAt, _ = time.Parse(time.RFC3339, stored.UpdatedAt)
+	}
+
+	// Schedules are parsed before they are configured or set; an empty one is disabled
+	expression, _ := parseCronExpression(schedule.Cron)
+	return schedule, expression, updatedAt, nil
+}
+
+// set replaces the schedule
+func (s *ScanScheduler) set(ctx *gofr.Context, schedule ScanSchedule, updatedAt time.Time) error {
+	value, err := json.Marshal(storedScanSchedule{ScanSchedule: schedule, UpdatedAt: updatedAt.UTC().Format(time.RFC3339)})
+	if err != nil {
+		return err
+	}
+	if err := s.state.Put(ctx, scheduleKey, value, 0); err != nil {
+		return fmt.Errorf("failed to store scan schedule: %w", err)
+	}
+	return nil
+}
+
+// claim returns the schedule when it is due in the minute of now and no run is in progress, marking
+// a run as started. The minute's claim lets one instance run it, and the running lease skips it while
+// a previous run is still scanning
+func (s *ScanScheduler) claim(ctx *gofr.Context, now time.Time) (ScanSchedule, bool, error) {
+	schedule, expression, _, err := s.load(ctx)
+	if err != nil {
+		return ScanSchedule{}, false, err
+	}
+
+	minute := now.Truncate(time.Minute)
+	if schedule.Cron == "" || !expression.matches(minute) {
+		return ScanSchedule{}, false, nil
+	}
+	startedAt := []byte(minute.UTC().Format(time.RFC3339))
+	claimed, err := s.state.PutIfAbsent(ctx, scheduleClaimKeyPrefix+string(startedAt), startedAt, time.Hour)
+	if err != nil || !claimed {
+		return ScanSchedule{}, false, err
+	}
+	started, err := s.state.PutIfAbsent(ctx, scheduleRunningKey, startedAt, scheduledRunLease)
+	if err != nil || !started {
+		return ScanSchedule{}, false, err
+	}
+	return schedule, true, nil
+}
+
+// release marks the current run as finished
+func (s *ScanScheduler) release(ctx *gofr.Context) error {
+	return s.state.Delete(ctx, scheduleRunningKey)
+}
+
+// status returns a snapshot of the schedule and its next run
+func (s *ScanScheduler) status(ctx *gofr.Context, now time.Time) (ScheduleResponse, error) {
+	schedule, expression, updatedAt, err := s.load(ctx)
+	if err != nil {
+		return ScheduleResponse{}, err
+	}
+	_, running, err := s.state.Get(ctx, scheduleRunningKey)
+	if err != nil {
+		return ScheduleResponse{}, fmt.Errorf("failed to read scheduled run lease: %w", err)
+	}
+
+	response := ScheduleResponse{
+		ScanSchedule: schedule,
+		Enabled:      schedule.Cron != "",
+		Running:      running,
+		Runs:         []ScheduledScanRun{},
+	}
+	if response.Organizations == nil {
+		response.Organizations = []string{}
+	}
+	if !updatedAt.IsZero() {
+		response.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	}
+	if response.Enabled {
+		if next := expression.next(now); !next.IsZero() {
+			response.NextRunAt = next.UTC().Format(time.RFC3339)
+		}
+	}
+	return response, nil
+}
+
+// storeScheduledScanRun records a scheduled scan run in Neo4j (Orchestrator)
+func storeScheduledScanRun(ctx *gofr.Context, deps *AppDependencies, run ScheduledScanRun) error {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return err
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	failedOrganizations := make([]string, 0, len(run.Failed))
+	failedErrors := make([]string, 0, len(run.Failed))
+	for _, failure := range run.Failed {
+		failedOrganizations = append(failedOrganizations, failure.Organization)
+		failedErrors = append(failedErrors, failure.Error)
+	}
+
+	_, err = executeNeo4jWrite(ctx, session, buildStoreScheduledScanRunQuery(), map[string]interface{}{
+		"id":                   run.ID,
+		"cron":                 run.Cron,
+		"status":               run.Status,
+		"started_at":           run.StartedAt,
+		"finished_at":          run.FinishedAt,
+		"organizations":        run.Organizations,
+		"succeeded":            run.Succeeded,
+		"failed_organizations": failedOrganizations,
+		"failed_errors":        failedErrors,
+	})
+	return err
+}
+
+// runScheduledScans scans every organization of the schedule in turn and records the run's outcome (Orchestrator)
+func runScheduledScans(ctx *gofr.Context, deps *AppDependencies, schedule ScanSchedule, startedAt time.Time) ScheduledScanRun {
+	run := ScheduledScanRun{
+		ID:            fmt.Sprintf("scheduled-%d", startedAt.UTC().UnixNano()),
+		Cron:          schedule.Cron,
+		Status:        ScheduledRunRunning,
+		StartedAt:     startedAt.UTC().Format(time.RFC3339),
+		Organizations: schedule.Organizations,
+		Succeeded:     []string{},
+		Failed:        []ScheduledScanFailure{},
+	}
+	recordRun := func() {
+		if err := storeScheduledScanRun(ctx, deps, run); err != nil {
+			logError(ctx, "Failed to record scheduled scan run", LogFields{
+				"component": "scan_scheduler",
+				"operation": "store_run",
+				"run_id":    run.ID,
+				"error":     err.Error(),
+			})
+		}
+	}
+	recordRun()
+
+	for _, organization := range schedule.Organizations {
+		response, err := runLockedScan(ctx, deps, ScanRequest{
+			Organization: organization,
+			MaxRepos:     schedule.MaxRepos,
+			MaxTeams:     schedule.MaxTeams,
+			UseTopics:    deps.currentConfig().GitHub.UseTopics,
+		})
+		if err != nil {
+			run.Failed = append(run.Failed, ScheduledScanFailure{Organization: organization, Error: err.Error()})
+			logError(ctx, "Scheduled scan failed", LogFields{
+				"component":    "scan_scheduler",
+				"operation":    "scheduled_scan",
+				"run_id":       run.ID,
+				"organization": organization,
+				"error":        err.Error(),
+			})
+			continue
+		}
+		run.Succeeded = append(run.Succeeded, organization)
+		logInfo(ctx, "Scheduled scan completed", LogFields{
+			"component":    "scan_scheduler",
+			"operation":    "scheduled_scan",
+			"run_id":       run.ID,
+			"organization": organization,
+			"scan_id":      response.ScanID,
+			"scan_status":  response.ScanStatus,
+		})
+	}
+
+	run.Status = buildScheduledScanRunStatus(len(run.Succeeded), len(run.Failed))
+	run.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	recordRun()
+	return run
+}
+
+// runDueScheduledScans starts the scheduled run when the schedule is due
+func runDueScheduledScans(ctx *gofr.Context, deps *AppDependencies, now time.Time) {
+	schedule, due, err := deps.Scheduler.claim(ctx, now)
+	if err != nil {
+		logError(ctx, "Failed to claim scheduled scan run", LogFields{
+			"component": "scan_scheduler",
+			"operation": "claim_run",
+			"error":     err.Error(),
+		})
+		return
+	}
+	if !due {
+		return
+	}
+	defer func() {
+		if err := deps.Scheduler.release(ctx); err != nil {
+			logWarn(ctx, "Failed to release scheduled scan run; the next run waits for its lease to expire", LogFields{
+				"component": "scan_scheduler",
+				"operation": "release_run",
+				"error":     err.Error(),
+			})
+		}
+	}()
+
+	run := runScheduledScans(ctx, deps, schedule, now)
+	logInfo(ctx, "Scheduled scan run finished", LogFields{
+		"component": "scan_scheduler",
+		"operation": "run_schedule",
+		"run_id":    run.ID,
+		"status":    run.Status,
+		"succeeded": len(run.Succeeded),
+		"failed":    len(run.Failed),
+	})
+}
+
+// fetchScheduledScanRuns returns the most recent scheduled scan runs (Orchestrator)
+func fetchScheduledScanRuns(ctx *gofr.Context, deps *AppDependencies) ([]ScheduledScanRun, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildScheduledScanRunsQuery(), map[string]interface{}{
+		"limit": scheduledScanRunsLimit,
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	runs := make([]ScheduledScanRun, 0, len(result.Records))
+	for _, record := range result.Records {
+		runs = append(runs, convertToScheduledScanRun(record))
+	}
+	return runs, nil
+}
+
+// registerScanScheduler checks every minute, on the leader replica, whether the scan schedule is due
+func registerScanScheduler(app *gofr.App, deps *AppDependencies) {
+	app.AddCronJob(scanSchedulerTick, "scheduled-scans", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "scheduled_scans", func() {
+			runAsLeader(ctx, deps, "scheduled_scans", func() {
+				runDueScheduledScans(ctx, deps, time.Now())
+			})
+		})
+	})
+}
+
+// handleGetSchedule returns the scan schedule, its next run and its recent runs
+func (h *AppHandler) handleGetSchedule(ctx *gofr.Context) (interface{}, error) {
+	response, err := h.deps.Scheduler.status(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	runs, err := fetchScheduledScanRuns(ctx, h.deps)
+	if err != nil {
+		return nil, err
+	}
+	response.Runs = runs
+	return response, nil
+}
+
+// handleSetSchedule replaces the scan schedule at runtime; an empty cron expression disables it.
+// Changes are kept in the state store; with the memory backend they last until the next restart,
+// which restores SCAN_CRON and SCAN_ORGS
+func (h *AppHandler) handleSetSchedule(ctx *gofr.Context) (interface{}, error) {
+	var schedule ScanSchedule
+	if err := ctx.Bind(&schedule); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	schedule.Cron = strings.TrimSpace(schedule.Cron)
+	if schedule.Cron != "" {
+		if _, err := parseCronExpression(schedule.Cron); err != nil {
+			return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"cron"}}
+		}
+		if len(schedule.Organizations) == 0 {
+			return nil, createMissingParamError("organizations")
+		}
+	}
+
+	organizations, invalid := normalizeScanOrganizations(schedule.Organizations)
+	if invalid != "" || len(organizations) > maxMultiOrgScanOrganizations {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"organizations"}}
+	}
+	schedule.Organizations = organizations
+
+	if schedule.MaxRepos == 0 {
+		schedule.MaxRepos = 100
+	}
+	if schedule.MaxTeams == 0 {
+		schedule.MaxTeams = 50
+	}
+	if schedule.MaxRepos < 0 || schedule.MaxTeams < 0 {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"max_repos", "max_teams"}}
+	}
+
+	if err := h.deps.Scheduler.set(ctx, schedule, time.Now()); err != nil {
+		return nil, err
+	}
+	logWarn(ctx, "Scan schedule updated", LogFields{
+		"component":     "scan_scheduler",
+		"operation":     "set_schedule",
+		"cron":          schedule.Cron,
+		"organizations": strings.Join(schedule.Organizations, ","),
+	})
+
+	return h.handleGetSchedule(ctx)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Source code hosts scans read organizations from, selected with SCM_PROVIDER or per scan request
+const (
+	scmProviderGitHub    = "github"
+	scmProviderGitLab    = "gitlab"
+	scmProviderBitbucket = "bitbucket"
+)
+
+// scanSourceFetcher reads an organization from a source code host
+type scanSourceFetcher func(ctx *gofr.Context, deps *AppDependencies, request ScanRequest) (scanSourceData, error)
+
+// teamMemberFetcher lists the current members of an organization's team on a source code host
+type teamMemberFetcher func(ctx *gofr.Context, orgName, slug string) ([]GitHubUser, error)
+
+// isValidSCMProvider reports whether a provider names a supported source code host (Pure Core)
+func isValidSCMProvider(provider string) bool {
+	return provider == scmProviderGitHub || provider == scmProviderGitLab || provider == scmProviderBitbucket
+}
+
+// resolveScanProvider returns the source code host a scan reads from: the request's provider or,
+// when it names none, the configured default (Pure Core)
+func resolveScanProvider(request ScanRequest, config AppConfig) string {
+	if request.Provider != "" {
+		return request.Provider
+	}
+	return config.SCMProvider
+}
+
+// isSCMProviderConfigured reports whether credentials for a source code host are configured (Pure Core)
+func isSCMProviderConfigured(provider string, config AppConfig) bool {
+	switch provider {
+	case scmProviderGitHub:
+		return config.GitHub.Token != "" || isGitHubAppConfigured(config.GitHub)
+	case scmProviderGitLab:
+		return config.GitLab.Token != ""
+	case scmProviderBitbucket:
+		return isBitbucketConfigured(config.Bitbucket)
+	default:
+		return false
+	}
+}
+
+// validateScanProvider checks that the provider of a scan request is supported and has credentials;
+// an empty provider uses the configured default (Pure Core)
+func validateScanProvider(provider string, config AppConfig) error {
+	if provider == "" {
+		return nil
+	}
+	if !isValidSCMProvider(provider) || !isSCMProviderConfigured(provider, config) {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"provider"}}
+	}
+	return nil
+}
+
+// selectScanSourceFetcher returns the fetcher reading organizations from a source code host (Pure Core)
+func selectScanSourceFetcher(provider string) scanSourceFetcher {
+	switch provider {
+	case scmProviderGitLab:
+		return fetchGitLabScanSource
+	case scmProviderBitbucket:
+		return fetchBitbucketScanSource
+	default:
+		return fetchGitHubScanSource
+	}
+}
+
+// selectTeamMemberFetcher returns the fetcher listing team members on a source code host; GitLab teams
+// are subgroups and Bitbucket Cloud, having no team API, reports teams without members (Pure Core)
+func selectTeamMemberFetcher(provider string) teamMemberFetcher {
+	switch provider {
+	case scmProviderGitLab:
+		return func(ctx *gofr.Context, orgName, slug string) ([]GitHubUser, error) {
+			return fetchGitLabGroupMemberAccounts(ctx, orgName+"/"+slug)
+		}
+	case scmProviderBitbucket:
+		return func(*gofr.Context, string, string) ([]GitHubUser, error) {
+			return []GitHubUser{}, nil
+		}
+	default:
+		return fetchTeamMemberAccounts
+	}
+}
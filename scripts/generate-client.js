@@ -0,0 +1,272 @@
+// Generates the TypeScript API client in packages/shared/src/client.ts from api/openapi.yaml.
+// Method names, the response each method validates and fixed query parameters come from
+// packages/shared/client.config.json; requests go through packages/shared/src/http.ts.
+//
+// Usage: bun run generate:client [--check]
+// With --check the client is not written, and the script fails when it is out of date.
+
+import { readFileSync, writeFileSync } from 'node:fs'
+import { parse } from 'yaml'
+
+const CONFIG_PATH = 'packages/shared/client.config.json'
+const SCRIPT_PATH = 'scripts/generate-client.js'
+const HTTP_METHODS = ['get', 'post', 'put', 'patch', 'delete']
+const IDENTIFIER = /^[A-Za-z_$][A-Za-z0-9_$]*$/
+
+const fail = (message) => {
+  console.error(`generate-client: ${message}`)
+  process.exit(1)
+}
+
+const capitalize = (value) => value.charAt(0).toUpperCase() + value.slice(1)
+
+const propertyName = (name) => (IDENTIFIER.test(name) ? name : `'${name}'`)
+
+const accessor = (name) =>
+  IDENTIFIER.test(name) ? `params.${name}` : `params['${name}']`
+
+const componentName = (ref) => ref.split('/').pop()
+
+// The TypeScript type of a parameter schema
+const parameterType = (schema = {}) => {
+  if (Array.isArray(schema.enum)) {
+    return schema.enum.map((value) => `'${value}'`).join(' | ')
+  }
+  switch (schema.type) {
+    case 'integer':
+    case 'number':
+      return 'number'
+    case 'boolean':
+      return 'boolean'
+    default:
+      return 'string'
+  }
+}
+
+// The expression sending a parameter as a string, undefined when an optional one is not set
+const serialize = (parameter) => {
+  const value = accessor(parameter.name)
+  const type = parameterType(parameter.schema)
+  if (type !== 'number' && type !== 'boolean') {
+    return value
+  }
+  return parameter.required ? `String(${value})` : `${value}?.toString()`
+}
+
+// The schemas.ts export validating a response: the component wrapped in GoFr's data envelope
+const responseSchema = (operationId, responses, variant) => {
+  const status =
+    variant.status ??
+    Object.keys(responses).find((code) => /^2\d\d$/.test(code))
+  const response = responses[status]
+  if (!response) {
+    fail(`${operationId} has no ${status ?? '2xx'} response`)
+  }
+  if (variant.schema) {
+    return variant.schema
+  }
+  const ref =
+    response.content?.['application/json']?.schema?.properties?.data?.$ref
+  if (!ref) {
+    fail(`${operationId} ${status} has no data component; set its "schema"`)
+  }
+  return `${componentName(ref)}Schema`
+}
+
+// Builds one client method from an operation and a configuration entry
+const buildMethod = (path, httpMethod, operation, variant) => {
+  const fixed = variant.query ?? {}
+  const parameters = (operation.parameters ?? []).map((parameter) => {
+    if (parameter.$ref) {
+      fail(`${operation.operationId} uses an unsupported parameter $ref`)
+    }
+    return parameter
+  })
+  const pathParameters = parameters.filter((p) => p.in === 'path')
+  const queryParameters = parameters.filter(
+    (p) => p.in === 'query' && !(p.name in fixed)
+  )
+  const schema = responseSchema(
+    operation.operationId,
+    operation.responses ?? {},
+    variant
+  )
+
+  return {
+    name: variant.method ?? operation.operationId,
+    summary: variant.summary ?? operation.summary,
+    httpMethod: httpMethod.toUpperCase(),
+    path,
+    parameters: [...pathParameters, ...queryParameters],
+    pathParameters,
+    queryParameters,
+    fixed,
+    schema,
+    type: schema.replace(/Schema$/, ''),
+  }
+}
+
+const paramsInterfaceName = (method) => `${capitalize(method.name)}Params`
+
+const renderField = (parameter) => {
+  const optional = parameter.required ? '' : '?'
+  const type = parameterType(parameter.schema)
+  return [
+    ...(parameter.description ? [`  /** ${parameter.description} */`] : []),
+    `  readonly ${propertyName(parameter.name)}${optional}: ${type}`,
+  ]
+}
+
+const renderParamsInterface = (method) =>
+  [
+    `export interface ${paramsInterfaceName(method)} {`,
+    ...method.parameters.flatMap(renderField),
+    '}',
+    '',
+  ].join('\n')
+
+const renderSignature = (method) =>
+  [
+    ...(method.summary ? [`  /** ${method.summary} */`] : []),
+    `  readonly ${method.name}: (`,
+    ...(method.parameters.length
+      ? [`    params: ${paramsInterfaceName(method)},`]
+      : []),
+    '    options?: RequestOptions',
+    `  ) => Promise<${method.type}>`,
+  ].join('\n')
+
+const renderPath = (method) => {
+  if (method.pathParameters.length === 0) {
+    return `'${method.path}'`
+  }
+  const path = method.path.replace(/\{([^}]+)\}/g, (_, name) => {
+    const parameter = method.pathParameters.find((p) => p.name === name)
+    if (!parameter) {
+      fail(`${method.path} does not declare the path parameter ${name}`)
+    }
+    const value = serialize({ ...parameter, required: true })
+    return `\${encodeURIComponent(${value})}`
+  })
+  return `\`${path}\``
+}
+
+const renderQuery = (method) => {
+  const entries = [
+    ...method.queryParameters.map(
+      (parameter) =>
+        `${propertyName(parameter.name)}: ${serialize(parameter)},`
+    ),
+    ...Object.entries(method.fixed)
+      .filter(([, value]) => value !== null)
+      .map(([name, value]) => `${propertyName(name)}: '${value}',`),
+  ]
+  if (entries.length === 0) {
+    return '{}'
+  }
+  const lines = entries.map((entry) => `          ${entry}`)
+  return ['{', ...lines, '        }'].join('\n')
+}
+
+const renderImplementation = (method) => {
+  const args = method.parameters.length ? '(params, options)' : '(options)'
+  return [
+    `    ${method.name}: ${args} =>`,
+    '      request(',
+    `        '${method.httpMethod}',`,
+    `        ${renderPath(method)},`,
+    `        ${renderQuery(method)},`,
+    `        ${method.schema},`,
+    `        '${method.name}',`,
+    '        options',
+    '      ),',
+  ].join('\n')
+}
+
+const render = (config, methods) => {
+  const schemas = [...new Set(methods.map((method) => method.schema))].sort()
+  const types = [...new Set(methods.map((method) => method.type))].sort()
+
+  return [
+    `// Code generated by ${SCRIPT_PATH} from ${config.spec}; DO NOT EDIT.`,
+    `// Change the spec or ${CONFIG_PATH} and run \`bun run generate:client\`.`,
+    '',
+    'import {',
+    '  createRequester,',
+    '  type ApiClientConfig,',
+    '  type RequestOptions,',
+    "} from './http'",
+    'import {',
+    ...schemas.map((schema) => `  ${schema},`),
+    ...types.map((type) => `  type ${type},`),
+    "} from './schemas'",
+    '',
+    'export {',
+    '  ApiError,',
+    '  type ApiClientConfig,',
+    '  type RequestOptions,',
+    "} from './http'",
+    '',
+    ...methods
+      .filter((method) => method.parameters.length)
+      .map(renderParamsInterface),
+    'export interface ApiClient {',
+    ...methods.map(renderSignature),
+    '}',
+    '',
+    '/**',
+    ` * Creates a typed client for the operations of ${config.spec}`,
+    ' * Responses are validated against the shared schemas',
+    ' */',
+    'export const createApiClient = (config: ApiClientConfig): ApiClient => {',
+    '  const request = createRequester(config)',
+    '',
+    '  return {',
+    ...methods.map(renderImplementation),
+    '  }',
+    '}',
+    '',
+  ].join('\n')
+}
+
+const config = JSON.parse(readFileSync(CONFIG_PATH, 'utf8'))
+const spec = parse(readFileSync(config.spec, 'utf8'))
+
+const operations = Object.entries(spec.paths ?? {}).flatMap(([path, item]) =>
+  HTTP_METHODS.filter((httpMethod) => item[httpMethod]).map((httpMethod) => {
+    const operation = item[httpMethod]
+    if (!operation.operationId) {
+      fail(`${httpMethod.toUpperCase()} ${path} has no operationId`)
+    }
+    return { path, httpMethod, operation }
+  })
+)
+
+const unknown = Object.keys(config.operations ?? {}).find(
+  (operationId) =>
+    !operations.some(({ operation }) => operation.operationId === operationId)
+)
+if (unknown) {
+  fail(`${CONFIG_PATH} configures ${unknown}, which is not in ${config.spec}`)
+}
+
+const methods = operations.flatMap(({ path, httpMethod, operation }) =>
+  (config.operations?.[operation.operationId] ?? [{}]).map((variant) =>
+    buildMethod(path, httpMethod, operation, variant)
+  )
+)
+
+const names = methods.map((method) => method.name)
+const duplicate = names.find((name, index) => names.indexOf(name) !== index)
+if (duplicate) {
+  fail(`more than one operation generates the method ${duplicate}`)
+}
+
+const client = render(config, methods)
+if (process.argv.includes('--check')) {
+  if (readFileSync(config.output, 'utf8') !== client) {
+    fail(`${config.output} is out of date; run \`bun run generate:client\``)
+  }
+} else {
+  writeFileSync(config.output, client)
+}
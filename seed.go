@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Defaults and bounds of POST /api/admin/seed
+const (
+	defaultSeedRepositories = 5000
+	defaultSeedTeams        = 200
+	maxSeedRepositories     = 50000
+	maxSeedTeams            = 5000
+)
+
+// seedEnvironment is the only environment in which synthetic data may be seeded over the API
+const seedEnvironment = "development"
+
+// SeedDisabledError is returned when seeding is requested outside the development environment
+type SeedDisabledError struct {
+	Environment string
+}
+
+// Error implements the error interface for SeedDisabledError
+func (e SeedDisabledError) Error() string {
+	return "seeding synthetic data is only available when ENVIRONMENT=" + seedEnvironment + ", current environment is " + e.Environment
+}
+
+// StatusCode returns the HTTP status code for the error
+func (SeedDisabledError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// parseSeedCount parses an optional positive count query parameter bounded by limit (Pure Core)
+func parseSeedCount(value, param string, defaultValue, limit int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count <= 0 || count > limit {
+		return 0, &gofrhttp.ErrorInvalidParam{Params: []string{param}}
+	}
+	return count, nil
+}
+
+// handleSeedSyntheticOrganization replaces the synthetic organization with a large generated graph whose
+// ownership follows a power law, for load testing the graph, stats and pagination endpoints
+func (h *AppHandler) handleSeedSyntheticOrganization(ctx *gofr.Context) (interface{}, error) {
+	if environment := h.deps.currentConfig().Environment; environment != seedEnvironment {
+		return nil, SeedDisabledError{Environment: environment}
+	}
+
+	repositories, err := parseSeedCount(ctx.Param("repos"), "repos", defaultSeedRepositories, maxSeedRepositories)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := parseSeedCount(ctx.Param("teams"), "teams", defaultSeedTeams, maxSeedTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	report := loadSampleData(ctx, h.deps, SampleDataOptions{Repositories: repositories, Teams: teams, PowerLaw: true})
+	report.Action = "seed"
+
+	logInfo(ctx, "Seeded synthetic organization", LogFields{
+		"component":             "seed",
+		"operation":             "seed_synthetic_organization",
+		"organization":          report.Organization,
+		"repositories":          report.Repositories,
+		"teams":                 report.Teams,
+		"repos_with_codeowners": report.ReposWithCodeowners,
+		"success":               report.Success,
+	})
+
+	if !report.Success {
+		return nil, errors.New(report.Error)
+	}
+	return report, nil
+}
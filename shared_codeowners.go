@@ -0,0 +1,89 @@
+package main
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// SharedCodeownersFile is a CODEOWNERS blob and the repositories using that exact file
+type SharedCodeownersFile struct {
+	SHA          string   `json:"sha"`
+	Size         int      `json:"size"`
+	RuleCount    int      `json:"rule_count"`
+	Repositories []string `json:"repositories"`
+}
+
+// SharedCodeownersResponse lists the CODEOWNERS files shared by more than one repository
+type SharedCodeownersResponse struct {
+	Organization          string                 `json:"organization"`
+	RepositoriesWithFiles int                    `json:"repositories_with_files"`
+	UniqueFiles           int                    `json:"unique_files"`
+	Shared                []SharedCodeownersFile `json:"shared"`
+}
+
+// buildCodeownersBlobsQuery builds a query grouping the active repositories of an organization by CODEOWNERS blob (Pure Core)
+func buildCodeownersBlobsQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		MATCH (repo)-[file:HAS_CODEOWNERS_FILE]->(blob:CodeownersBlob)
+		WHERE coalesce(file.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH blob, repo ORDER BY repo.full_name
+		WITH blob, collect(DISTINCT repo.full_name) AS repositories
+		RETURN blob.sha AS sha, blob.size AS size, blob.rule_count AS rule_count, repositories
+		ORDER BY size(repositories) DESC, sha
+	`
+}
+
+// buildSharedCodeownersResponse counts unique files and keeps the blobs used by several repositories (Pure Core)
+func buildSharedCodeownersResponse(orgName string, records []map[string]interface{}) SharedCodeownersResponse {
+	response := SharedCodeownersResponse{
+		Organization: orgName,
+		UniqueFiles:  len(records),
+		Shared:       []SharedCodeownersFile{},
+	}
+
+	for _, record := range records {
+		repositories := getStringSliceFromMap(record, "repositories")
+		response.RepositoriesWithFiles += len(repositories)
+		if len(repositories) < 2 {
+			continue
+		}
+
+		response.Shared = append(response.Shared, SharedCodeownersFile{
+			SHA:          getStringFromMap(record, "sha"),
+			Size:         getIntFromMap(record, "size"),
+			RuleCount:    getIntFromMap(record, "rule_count"),
+			Repositories: repositories,
+		})
+	}
+
+	return response
+}
+
+// fetchSharedCodeowners retrieves the CODEOWNERS blobs of an organization (Orchestrator)
+func fetchSharedCodeowners(ctx *gofr.Context, deps *AppDependencies, orgName string) (SharedCodeownersResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return SharedCodeownersResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildCodeownersBlobsQuery(), map[string]interface{}{
+		"orgName": orgName,
+	})
+	if err != nil {
+		return SharedCodeownersResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	return buildSharedCodeownersResponse(orgName, result.Records), nil
+}
+
+// handleGetSharedCodeowners returns the groups of repositories sharing an identical CODEOWNERS file
+func (h *AppHandler) handleGetSharedCodeowners(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	return fetchSharedCodeowners(ctx, h.deps, orgName)
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// sloBurnRateSchedule is the cron schedule exporting burn-rate metrics
+const sloBurnRateSchedule = "* * * * *"
+
+// defaultSLOObjectives are the objectives applied when SLO_OBJECTIVES is not set
+const defaultSLOObjectives = "GET /api/graph/{org}=99.5@2s,GET /api/stats/{org}=99.5@1s,GET /api/health=99.9@500ms"
+
+// sloBucketCount is the number of one-minute buckets kept per endpoint, covering the longest window
+const sloBucketCount = 360
+
+// sloWindows are the burn-rate windows paired for multi-window alerting: a fast burn must be
+// seen over 5m and 1h, a slow burn over 30m and 6h
+var sloWindows = []time.Duration{5 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour}
+
+// EndpointObjective is the SLO of a route: the percentage of requests that must succeed
+// without a server error within the latency threshold
+type EndpointObjective struct {
+	Route     string
+	Objective float64
+	Latency   time.Duration
+}
+
+// SLOTracker counts good and bad requests per SLO route in one-minute buckets
+type SLOTracker struct {
+	mu     sync.Mutex
+	series map[string]*[sloBucketCount]sloBucket
+}
+
+// sloBucket holds the request counts of one minute
+type sloBucket struct {
+	minute int64
+	total  int
+	bad    int
+}
+
+// SLOWindowStatus is the error ratio and burn rate of a route over one window
+type SLOWindowStatus struct {
+	Window     string  `json:"window"`
+	Requests   int     `json:"requests"`
+	Bad        int     `json:"bad"`
+	ErrorRatio float64 `json:"error_ratio"`
+	BurnRate   float64 `json:"burn_rate"`
+}
+
+// SLOEndpointStatus summarizes the SLO compliance of a route
+type SLOEndpointStatus struct {
+	Route     string            `json:"route"`
+	Objective float64           `json:"objective_percent"`
+	Latency   string            `json:"latency_threshold"`
+	Windows   []SLOWindowStatus `json:"windows"`
+	FastBurn  bool              `json:"fast_burn"`
+	SlowBurn  bool              `json:"slow_burn"`
+}
+
+// SLOSummary is the SLO compliance of every configured route
+type SLOSummary struct {
+	GeneratedAt       string              `json:"generated_at"`
+	FastBurnThreshold float64             `json:"fast_burn_threshold"`
+	SlowBurnThreshold float64             `json:"slow_burn_threshold"`
+	Endpoints         []SLOEndpointStatus `json:"endpoints"`
+}
+
+// sloStatusRecorder captures the status code written by the wrapped handler
+type sloStatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// newSLOTracker creates an empty SLO tracker
+func newSLOTracker() *SLOTracker {
+	return &SLOTracker{series: make(map[string]*[sloBucketCount]sloBucket)}
+}
+
+// parseSLOObjectives parses "METHOD /path/{param}=objective@latency" entries; malformed values
+// are kept as zero so configuration validation reports them (Pure Core)
+func parseSLOObjectives(value string) []EndpointObjective {
+	objectives := []EndpointObjective{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, spec, _ := strings.Cut(entry, "=")
+		objective, latency, _ := strings.Cut(spec, "@")
+
+		parsedObjective, _ := strconv.ParseFloat(strings.TrimSpace(objective), 64)
+		parsedLatency, _ := time.ParseDuration(strings.TrimSpace(latency))
+		objectives = append(objectives, EndpointObjective{
+			Route:     strings.Join(strings.Fields(route), " "),
+			Objective: parsedObjective,
+			Latency:   parsedLatency,
+		})
+	}
+	return objectives
+}
+
+// matchSLORoute reports whether a request matches a "METHOD /path/{param}" route (Pure Core)
+func matchSLORoute(route, method, path string) bool {
+	routeMethod, routePath, found := strings.Cut(route, " ")
+	if !found || routeMethod != method {
+		return false
+	}
+
+	routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(routeSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range routeSegments {
+		isParam := strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+		if !isParam && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// findSLOObjective returns the first objective whose route matches a request (Pure Core)
+func findSLOObjective(objectives []EndpointObjective, method, path string) (EndpointObjective, bool) {
+	for _, objective := range objectives {
+		if matchSLORoute(objective.Route, method, path) {
+			return objective, true
+		}
+	}
+	return EndpointObjective{}, false
+}
+
+// isBadSLORequest reports whether a request counts against its SLO (Pure Core)
+func isBadSLORequest(status int, duration, latency time.Duration) bool {
+	return status >= http.StatusInternalServerError || duration > latency
+}
+
+// calculateBurnRate returns how many times faster than sustainable the error budget is consumed (Pure Core)
+func calculateBurnRate(errorRatio, objective float64) float64 {
+	budget := 1 - objective/100
+	if budget <= 0 {
+		return 0
+	}
+	return errorRatio / budget
+}
+
+// record counts a request against a route in the bucket of its minute
+func (t *SLOTracker) record(route string, bad bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buckets, exists := t.series[route]
+	if !exists {
+		buckets = &[sloBucketCount]sloBucket{}
+		t.series[route] = buckets
+	}
+
+	minute := now.Unix() / 60
+	bucket := &buckets[minute%sloBucketCount]
+	if bucket.minute != minute {
+		*bucket = sloBucket{minute: minute}
+	}
+	bucket.total++
+	if bad {
+		bucket.bad++
+	}
+}
+
+// window returns the request and bad request counts of a route over the trailing window
+func (t *SLOTracker) window(route string, window time.Duration, now time.Time) (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buckets, exists := t.series[route]
+	if !exists {
+		return 0, 0
+	}
+
+	current := now.Unix() / 60
+	oldest := current - int64(window/time.Minute)
+	total, bad := 0, 0
+	for _, bucket := range buckets {
+		if bucket.minute > oldest && bucket.minute <= current {
+			total += bucket.total
+			bad += bucket.bad
+		}
+	}
+	return total, bad
+}
+
+// summarize computes the burn rates of every configured route
+func (t *SLOTracker) summarize(config SLOConfig, now time.Time) SLOSummary {
+	summary := SLOSummary{
+		GeneratedAt:       now.UTC().Format(time.RFC3339),
+		FastBurnThreshold: config.FastBurnThreshold,
+		SlowBurnThreshold: config.SlowBurnThreshold,
+		Endpoints:         make([]SLOEndpointStatus, 0, len(config.Objectives)),
+	}
+
+	for _, objective := range config.Objectives {
+		status := SLOEndpointStatus{
+			Route:     objective.Route,
+			Objective: objective.Objective,
+			Latency:   objective.Latency.String(),
+			Windows:   make([]SLOWindowStatus, 0, len(sloWindows)),
+		}
+
+		burnRates := make(map[time.Duration]float64, len(sloWindows))
+		for _, window := range sloWindows {
+			total, bad := t.window(objective.Route, window, now)
+			errorRatio := 0.0
+			if total > 0 {
+				errorRatio = float64(bad) / float64(total)
+			}
+			burnRates[window] = calculateBurnRate(errorRatio, objective.Objective)
+			status.Windows = append(status.Windows, SLOWindowStatus{
+				Window:     window.String(),
+				Requests:   total,
+				Bad:        bad,
+				ErrorRatio: errorRatio,
+				BurnRate:   burnRates[window],
+			})
+		}
+
+		status.FastBurn = burnRates[5*time.Minute] > config.FastBurnThreshold && burnRates[time.Hour] > config.FastBurnThreshold
+		status.SlowBurn = burnRates[30*time.Minute] > config.SlowBurnThreshold && burnRates[6*time.Hour] > config.SlowBurnThreshold
+		summary.Endpoints = append(summary.Endpoints, status)
+	}
+
+	return summary
+}
+
+// WriteHeader records the status code before writing it
+func (r *sloStatusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush forwards flushes so streamed responses keep working
+func (r *sloStatusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (r *sloStatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// sloMiddleware counts requests of routes with an objective as good or bad
+func sloMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			objective, tracked := findSLOObjective(deps.currentConfig().SLO.Objectives, r.Method, r.URL.Path)
+			if !tracked {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &sloStatusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			inner.ServeHTTP(recorder, r)
+
+			deps.SLO.record(objective.Route, isBadSLORequest(recorder.status, time.Since(start), objective.Latency), time.Now())
+		})
+	}
+}
+
+// exportSLOBurnRates records burn-rate gauges and alerts on routes burning their error budget
+func exportSLOBurnRates(ctx *gofr.Context, deps *AppDependencies) {
+	summary := deps.SLO.summarize(deps.currentConfig().SLO, time.Now())
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+
+	for _, endpoint := range summary.Endpoints {
+		for _, window := range endpoint.Windows {
+			labels := MetricLabels{"route": endpoint.Route, "window": window.Window}
+			metrics.recordGauge("slo_error_ratio", window.ErrorRatio, labels)
+			metrics.recordGauge("slo_burn_rate", window.BurnRate, labels)
+		}
+
+		for alert, burning := range map[string]bool{"slo_fast_burn": endpoint.FastBurn, "slo_slow_burn": endpoint.SlowBurn} {
+			if !burning {
+				continue
+			}
+			logWarn(ctx, "Endpoint is burning its error budget", LogFields{
+				"component": "slo",
+				"operation": "export_burn_rates",
+				"alert":     alert,
+				"route":     endpoint.Route,
+				"objective": endpoint.Objective,
+				"windows":   endpoint.Windows,
+			})
+		}
+	}
+}
+
+// handleGetSLO returns the burn rates of every route with an objective
+func (h *AppHandler) handleGetSLO(_ *gofr.Context) (interface{}, error) {
+	return h.deps.SLO.summarize(h.deps.currentConfig().SLO, time.Now()), nil
+}
+
+// registerSLOExport schedules the periodic burn-rate export
+func registerSLOExport(app *gofr.App, deps *AppDependencies) {
+	app.AddCronJob(sloBurnRateSchedule, "slo-burn-rate", func(ctx *gofr.Context) {
+		runCronJobWithRecovery(ctx, "slo_burn_rate", func() {
+			exportSLOBurnRates(ctx, deps)
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// staleWarningHeader is the Warning header value attached to responses served from the stale cache
+const staleWarningHeader = `110 - "Response is stale: graph database unavailable"`
+
+// StaleResponseCache keeps the last successful graph and stats responses so they can be
+// served while Neo4j is unavailable
+type StaleResponseCache struct {
+	mu      sync.RWMutex
+	entries map[string]staleCacheEntry
+}
+
+// staleCacheEntry is a cached response and the time it was produced
+type staleCacheEntry struct {
+	value    interface{}
+	cachedAt time.Time
+}
+
+// newStaleResponseCache creates an empty stale response cache
+func newStaleResponseCache() *StaleResponseCache {
+	return &StaleResponseCache{entries: make(map[string]staleCacheEntry)}
+}
+
+// graphCacheKey builds the stale cache key of a graph response (Pure Core)
+func graphCacheKey(orgName string, groupBy GraphGroupBy) string {
+	return fmt.Sprintf("graph|%s|%s", orgName, groupBy)
+}
+
+// statsCacheKey builds the stale cache key of a stats response (Pure Core)
+func statsCacheKey(orgName string) string {
+	return "stats|" + orgName
+}
+
+// store remembers the latest successful response for a key
+func (c *StaleResponseCache) store(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = staleCacheEntry{value: value, cachedAt: time.Now().UTC()}
+}
+
+// load returns the latest successful response for a key
+func (c *StaleResponseCache) load(key string) (staleCacheEntry, bool) {
+	if c == nil {
+		return staleCacheEntry{}, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, exists := c.entries[key]
+	return entry, exists
+}
+
+// isNeo4jUnavailableError reports whether an error means the database could not be reached (Pure Core)
+func isNeo4jUnavailableError(err error) bool {
+	var timeout *gofrhttp.ErrorRequestTimeout
+	if errors.As(err, &timeout) {
+		return true
+	}
+
+	var neo4jErr Neo4jError
+	if errors.As(err, &neo4jErr) && (neo4jErr.Code == "CONNECTION_ERROR" || neo4jErr.Code == "TIMEOUT_ERROR") {
+		return true
+	}
+
+	return containsErrorKeywords(strings.ToLower(err.Error()), []string{"connectivity", "connection refused", "service unavailable", "unavailable"})
+}
+
+// markStale flags a cached response as stale (Pure Core)
+func markStale(value interface{}, cachedAt time.Time) interface{} {
+	timestamp := cachedAt.Format(time.RFC3339)
+
+	switch cached := value.(type) {
+	case GraphResponse:
+		cached.Stale = true
+		cached.CachedAt = timestamp
+		return cached
+	case StatsResponse:
+		cached.Stale = true
+		cached.CachedAt = timestamp
+		return cached
+	default:
+		return value
+	}
+}
+
+// serveStaleResponse answers with the last cached response when Neo4j is unavailable, or returns the original error
+func serveStaleResponse(ctx *gofr.Context, deps *AppDependencies, key string, err error) (interface{}, error) {
+	if !deps.currentConfig().StaleCache.Enabled || !isNeo4jUnavailableError(err) {
+		return nil, err
+	}
+
+	entry, exists := deps.ResponseCache.load(key)
+	if !exists {
+		return nil, err
+	}
+
+	logWarn(ctx, "Serving stale response while Neo4j is unavailable", LogFields{
+		"component": "stale_cache",
+		"operation": "serve_stale",
+		"cache_key": key,
+		"cached_at": entry.cachedAt.Format(time.RFC3339),
+		"error":     err.Error(),
+	})
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("stale_responses_served_total", 1, MetricLabels{
+		"kind": strings.SplitN(key, "|", 2)[0],
+	})
+
+	return response.Response{
+		Data:    applyTeamVisibilityToResponse(markStale(entry.value, entry.cachedAt), secretTeamsMode(ctx, deps.currentConfig())),
+		Headers: map[string]string{"Warning": staleWarningHeader},
+	}, nil
+}
@@ -0,0 +1,535 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// State store backends selected by STATE_STORE
+const (
+	StateStoreMemory   = "memory"
+	StateStoreNeo4j    = "neo4j"
+	StateStoreRedis    = "redis"
+	StateStorePostgres = "postgres"
+)
+
+// stateStoreTable is the Postgres table of the postgres backend
+const stateStoreTable = "overseer_state"
+
+// StateStore keeps the state of background scan jobs and the scan schedule. The memory backend keeps
+// it in this process; the others share it between instances, so any instance behind a load balancer
+// can answer for a job another one accepted. A zero ttl keeps an entry until it is deleted, and
+// expired entries read as absent
+type StateStore interface {
+	// Get returns the value of key and whether it exists
+	Get(ctx *gofr.Context, key string) ([]byte, bool, error)
+	// Put stores value under key, replacing any previous value
+	Put(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error
+	// PutIfAbsent stores value only when key does not exist, reporting whether it did so; it is the
+	// compare-and-set that job and schedule locks are built on
+	PutIfAbsent(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Delete removes key; removing a missing key is not an error
+	Delete(ctx *gofr.Context, key string) error
+	// List returns every entry whose key starts with prefix
+	List(ctx *gofr.Context, prefix string) (map[string][]byte, error)
+}
+
+// supportedStateStoreBackends returns the backends accepted by STATE_STORE (Pure Core)
+func supportedStateStoreBackends() []string {
+	return []string{StateStoreMemory, StateStoreNeo4j, StateStoreRedis, StateStorePostgres}
+}
+
+// newStateStore creates the configured state store; validation has already checked the backend
+// and the GoFr datasource it needs
+func newStateStore(config StateStoreConfig, conn *Neo4jConnection) (StateStore, error) {
+	switch config.Backend {
+	case StateStoreMemory, "":
+		return newMemoryStateStore(), nil
+	case StateStoreNeo4j:
+		return &neo4jStateStore{conn: conn, prefix: stateKeyPrefix(config.KeyPrefix)}, nil
+	case StateStoreRedis:
+		return &redisStateStore{prefix: stateKeyPrefix(config.KeyPrefix)}, nil
+	case StateStorePostgres:
+		return &postgresStateStore{prefix: stateKeyPrefix(config.KeyPrefix)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported state store backend %q", config.Backend)
+	}
+}
+
+// stateKeyPrefix returns the namespace prepended to keys in a shared backend (Pure Core)
+func stateKeyPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return strings.TrimSuffix(prefix, ":") + ":"
+}
+
+// stateExpiry returns when an entry written at now with ttl expires, or the zero time when it
+// does not (Pure Core)
+func stateExpiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// memoryStateEntry is a value of the memory backend with its expiry
+type memoryStateEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// expired reports whether the entry has expired at now (Pure Core)
+func (e memoryStateEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryStateStore keeps state in this process; it is lost on restart and not shared between
+// instances, which suits development and single-instance deployments
+type memoryStateStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryStateEntry
+}
+
+// newMemoryStateStore creates an empty in-process state store
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{entries: make(map[string]memoryStateEntry)}
+}
+
+// Get implements StateStore
+func (s *memoryStateStore) Get(_ *gofr.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || entry.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Put implements StateStore
+func (s *memoryStateStore) Put(_ *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = memoryStateEntry{value: value, expiresAt: stateExpiry(time.Now(), ttl)}
+	return nil
+}
+
+// PutIfAbsent implements StateStore
+func (s *memoryStateStore) PutIfAbsent(_ *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && !entry.expired(now) {
+		return false, nil
+	}
+	s.entries[key] = memoryStateEntry{value: value, expiresAt: stateExpiry(now, ttl)}
+	return true, nil
+}
+
+// Delete implements StateStore
+func (s *memoryStateStore) Delete(_ *gofr.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// List implements StateStore, dropping expired entries as it goes
+func (s *memoryStateStore) List(_ *gofr.Context, prefix string) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entries := make(map[string][]byte)
+	for key, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, key)
+			continue
+		}
+		if strings.HasPrefix(key, prefix) {
+			entries[key] = entry.value
+		}
+	}
+	return entries, nil
+}
+
+// buildGetStateEntryQuery builds a query returning an unexpired state entry (Pure Core)
+func buildGetStateEntryQuery() string {
+	return `
+		MATCH (s:StateEntry {key: $key})
+		WHERE s.expires_at IS NULL OR s.expires_at > $now
+		RETURN s.value AS value
+	`
+}
+
+// buildPutStateEntryQuery builds a query storing a state entry (Pure Core)
+func buildPutStateEntryQuery() string {
+	return `
+		MERGE (s:StateEntry {key: $key})
+		SET s.value = $value, s.expires_at = $expires_at
+	`
+}
+
+// buildPutStateEntryIfAbsentQuery builds a query storing a state entry unless an unexpired one
+// exists. Setting locked_at first takes the node's write lock, so concurrent callers see each
+// other's writes; the StateEntry key constraint makes MERGE itself safe (Pure Core)
+func buildPutStateEntryIfAbsentQuery() string {
+	return `
+		MERGE (s:StateEntry {key: $key})
+		SET s.locked_at = $now
+		WITH s, s.value IS NULL OR (s.expires_at IS NOT NULL AND s.expires_at <= $now) AS vacant
+		FOREACH (_ IN CASE WHEN vacant THEN [1] ELSE [] END |
+			SET s.value = $value, s.expires_at = $expires_at
+		)
+		RETURN vacant
+	`
+}
+
+// buildDeleteStateEntryQuery builds a query removing a state entry (Pure Core)
+func buildDeleteStateEntryQuery() string {
+	return `
+		MATCH (s:StateEntry {key: $key})
+		DELETE s
+	`
+}
+
+// buildPurgeStateEntriesQuery builds a query removing the expired entries under a prefix (Pure Core)
+func buildPurgeStateEntriesQuery() string {
+	return `
+		MATCH (s:StateEntry)
+		WHERE s.key STARTS WITH $prefix AND s.expires_at IS NOT NULL AND s.expires_at <= $now
+		DELETE s
+	`
+}
+
+// buildListStateEntriesQuery builds a query returning the unexpired entries under a prefix (Pure Core)
+func buildListStateEntriesQuery() string {
+	return `
+		MATCH (s:StateEntry)
+		WHERE s.key STARTS WITH $prefix AND s.value IS NOT NULL
+			AND (s.expires_at IS NULL OR s.expires_at > $now)
+		RETURN s.key AS key, s.value AS value
+	`
+}
+
+// neo4jStateExpiry converts an expiry into the epoch milliseconds stored on StateEntry nodes, or
+// nil when the entry does not expire (Pure Core)
+func neo4jStateExpiry(now time.Time, ttl time.Duration) interface{} {
+	expiresAt := stateExpiry(now, ttl)
+	if expiresAt.IsZero() {
+		return nil
+	}
+	return expiresAt.UnixMilli()
+}
+
+// neo4jStateStore keeps state as StateEntry nodes in the graph database the service already uses
+type neo4jStateStore struct {
+	conn   *Neo4jConnection
+	prefix string
+}
+
+// write runs a state query in its own session (Orchestrator)
+func (s *neo4jStateStore) write(ctx *gofr.Context, query string, params map[string]interface{}) (Neo4jResult, error) {
+	session, err := createNeo4jSession(ctx, s.conn)
+	if err != nil {
+		return Neo4jResult{}, err
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	return executeNeo4jWrite(ctx, session, query, params)
+}
+
+// read runs a read-only state query in its own session (Orchestrator)
+func (s *neo4jStateStore) read(ctx *gofr.Context, query string, params map[string]interface{}) (Neo4jResult, error) {
+	session, err := createNeo4jSession(ctx, s.conn)
+	if err != nil {
+		return Neo4jResult{}, err
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	return executeNeo4jReadQuery(ctx, session, query, params)
+}
+
+// Get implements StateStore
+func (s *neo4jStateStore) Get(ctx *gofr.Context, key string) ([]byte, bool, error) {
+	result, err := s.read(ctx, buildGetStateEntryQuery(), map[string]interface{}{
+		"key": s.prefix + key,
+		"now": time.Now().UnixMilli(),
+	})
+	if err != nil || len(result.Records) == 0 {
+		return nil, false, err
+	}
+	return []byte(getStringFromMap(result.Records[0], "value")), true, nil
+}
+
+// Put implements StateStore
+func (s *neo4jStateStore) Put(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.write(ctx, buildPutStateEntryQuery(), map[string]interface{}{
+		"key":        s.prefix + key,
+		"value":      string(value),
+		"expires_at": neo4jStateExpiry(time.Now(), ttl),
+	})
+	return err
+}
+
+// PutIfAbsent implements StateStore
+func (s *neo4jStateStore) PutIfAbsent(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	result, err := s.write(ctx, buildPutStateEntryIfAbsentQuery(), map[string]interface{}{
+		"key":        s.prefix + key,
+		"value":      string(value),
+		"now":        now.UnixMilli(),
+		"expires_at": neo4jStateExpiry(now, ttl),
+	})
+	if err != nil || len(result.Records) == 0 {
+		return false, err
+	}
+	return getBoolFromMap(result.Records[0], "vacant"), nil
+}
+
+// Delete implements StateStore
+func (s *neo4jStateStore) Delete(ctx *gofr.Context, key string) error {
+	_, err := s.write(ctx, buildDeleteStateEntryQuery(), map[string]interface{}{"key": s.prefix + key})
+	return err
+}
+
+// List implements StateStore, purging expired entries under prefix first
+func (s *neo4jStateStore) List(ctx *gofr.Context, prefix string) (map[string][]byte, error) {
+	params := map[string]interface{}{
+		"prefix": s.prefix + prefix,
+		"now":    time.Now().UnixMilli(),
+	}
+	if _, err := s.write(ctx, buildPurgeStateEntriesQuery(), params); err != nil {
+		return nil, err
+	}
+	result, err := s.read(ctx, buildListStateEntriesQuery(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string][]byte, len(result.Records))
+	for _, record := range result.Records {
+		key := strings.TrimPrefix(getStringFromMap(record, "key"), s.prefix)
+		entries[key] = []byte(getStringFromMap(record, "value"))
+	}
+	return entries, nil
+}
+
+// redisStateStore keeps state in the Redis server GoFr connects to through REDIS_HOST, expiring
+// entries with Redis TTLs
+type redisStateStore struct {
+	prefix string
+}
+
+// Get implements StateStore; MGET reports a missing key as nil rather than an error
+func (s *redisStateStore) Get(ctx *gofr.Context, key string) ([]byte, bool, error) {
+	values, err := ctx.Redis.MGet(ctx, s.prefix+key).Result()
+	if err != nil || len(values) == 0 || values[0] == nil {
+		return nil, false, err
+	}
+	value, _ := values[0].(string)
+	return []byte(value), true, nil
+}
+
+// Put implements StateStore
+func (s *redisStateStore) Put(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	return ctx.Redis.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+// PutIfAbsent implements StateStore with SET NX
+func (s *redisStateStore) PutIfAbsent(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return ctx.Redis.SetNX(ctx, s.prefix+key, value, ttl).Result()
+}
+
+// Delete implements StateStore
+func (s *redisStateStore) Delete(ctx *gofr.Context, key string) error {
+	return ctx.Redis.Del(ctx, s.prefix+key).Err()
+}
+
+// List implements StateStore by scanning the keys under prefix, which never blocks Redis the way
+// KEYS would
+func (s *redisStateStore) List(ctx *gofr.Context, prefix string) (map[string][]byte, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		page, next, err := ctx.Redis.Scan(ctx, cursor, s.prefix+prefix+"*", 200).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	entries := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return entries, nil
+	}
+	values, err := ctx.Redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		// Keys that expired between SCAN and MGET come back nil
+		if text, ok := value.(string); ok && i < len(keys) {
+			entries[strings.TrimPrefix(keys[i], s.prefix)] = []byte(text)
+		}
+	}
+	return entries, nil
+}
+
+// postgresStateStore keeps state in a table of the Postgres database GoFr connects to through the
+// DB_* settings; the table is created on first use
+type postgresStateStore struct {
+	prefix string
+	mu     sync.Mutex
+	ready  bool
+}
+
+// buildCreateStateTableQuery builds the statement creating the state table (Pure Core)
+func buildCreateStateTableQuery() string {
+	return `CREATE TABLE IF NOT EXISTS ` + stateStoreTable + ` (
+		key TEXT PRIMARY KEY,
+		value BYTEA NOT NULL,
+		expires_at TIMESTAMPTZ
+	)`
+}
+
+// escapeLikePattern escapes the LIKE wildcards of a literal prefix (Pure Core)
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// postgresStateExpiry converts an expiry into a TIMESTAMPTZ parameter, or nil when the entry does
+// not expire (Pure Core)
+func postgresStateExpiry(now time.Time, ttl time.Duration) interface{} {
+	expiresAt := stateExpiry(now, ttl)
+	if expiresAt.IsZero() {
+		return nil
+	}
+	return expiresAt.UTC()
+}
+
+// ensureSchema creates the state table once per process, retrying on the next call if it fails
+func (s *postgresStateStore) ensureSchema(ctx *gofr.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ready {
+		return nil
+	}
+	if _, err := ctx.SQL.ExecContext(ctx, buildCreateStateTableQuery()); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", stateStoreTable, err)
+	}
+	s.ready = true
+	return nil
+}
+
+// Get implements StateStore
+func (s *postgresStateStore) Get(ctx *gofr.Context, key string) ([]byte, bool, error) {
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, false, err
+	}
+
+	var value []byte
+	err := ctx.SQL.QueryRowContext(ctx,
+		`SELECT value FROM `+stateStoreTable+` WHERE key = $1 AND (expires_at IS NULL OR expires_at > $2)`,
+		s.prefix+key, time.Now().UTC(),
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Put implements StateStore
+func (s *postgresStateStore) Put(ctx *gofr.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.ensureSchema(ctx); err != nil {
+		return err
+	}
+
+	_, err := ctx.SQL.ExecContext(ctx,
+		`INSERT INTO `+stateStoreTable+` (key, value, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at`,
+		s.prefix+key, value, postgresStateExpiry(time.Now(), ttl),
+	)
+	return err
+}
+
+// PutIfAbsent implements StateStore; the upsert only replaces a row that has expired, so the
+// affected row count tells whether the entry was stored
+func (s *postgresStateStore) PutIfAbsent(ctx *gofr.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if err := s.ensureSchema(ctx); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	result, err := ctx.SQL.ExecContext(ctx,
+		`INSERT INTO `+stateStoreTable+` (key, value, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at
+		WHERE `+stateStoreTable+`.expires_at IS NOT NULL AND `+stateStoreTable+`.expires_at <= $4`,
+		s.prefix+key, value, postgresStateExpiry(now, ttl), now.UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+	stored, err := result.RowsAffected()
+	return stored == 1, err
+}
+
+// Delete implements StateStore
+func (s *postgresStateStore) Delete(ctx *gofr.Context, key string) error {
+	if err := s.ensureSchema(ctx); err != nil {
+		return err
+	}
+
+	_, err := ctx.SQL.ExecContext(ctx, `DELETE FROM `+stateStoreTable+` WHERE key = $1`, s.prefix+key)
+	return err
+}
+
+// List implements StateStore, purging expired entries under prefix first
+func (s *postgresStateStore) List(ctx *gofr.Context, prefix string) (map[string][]byte, error) {
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+
+	pattern := escapeLikePattern(s.prefix+prefix) + "%"
+	now := time.Now().UTC()
+	if _, err := ctx.SQL.ExecContext(ctx,
+		`DELETE FROM `+stateStoreTable+` WHERE key LIKE $1 AND expires_at <= $2`, pattern, now,
+	); err != nil {
+		return nil, err
+	}
+
+	rows, err := ctx.SQL.Query(`SELECT key, value FROM `+stateStoreTable+` WHERE key LIKE $1`, pattern)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[string][]byte)
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		entries[strings.TrimPrefix(key, s.prefix)] = value
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,74 @@
+package main
+
+// CoverageSegment is the CODEOWNERS coverage of a subset of repositories
+type CoverageSegment struct {
+	Repositories    int     `json:"repositories"`
+	WithCodeowners  int     `json:"with_codeowners"`
+	CoveragePercent float64 `json:"coverage_percent"`
+}
+
+// StatsBreakdown segments repository coverage by visibility, archived and fork status.
+// Compliance covers the repositories that are neither archived nor forks.
+type StatsBreakdown struct {
+	Visibility map[string]CoverageSegment `json:"visibility"`
+	Archived   map[string]CoverageSegment `json:"archived"`
+	Fork       map[string]CoverageSegment `json:"fork"`
+	Compliance CoverageSegment            `json:"compliance"`
+}
+
+// repositoryVisibility returns a repository's visibility, deriving it from the private flag when GitHub omits it (Pure Core)
+func repositoryVisibility(repo GitHubRepository) string {
+	if repo.Visibility != "" {
+		return repo.Visibility
+	}
+	if repo.Private {
+		return "private"
+	}
+	return "public"
+}
+
+// addToCoverageSegment counts a repository in a segment and refreshes its coverage (Pure Core)
+func addToCoverageSegment(segment CoverageSegment, hasCodeowners bool) CoverageSegment {
+	segment.Repositories++
+	if hasCodeowners {
+		segment.WithCodeowners++
+	}
+	segment.CoveragePercent = calculatePercent(segment.WithCodeowners, segment.Repositories)
+	return segment
+}
+
+// buildStatsBreakdown aggregates per-repository segment records into coverage breakdowns (Pure Core)
+func buildStatsBreakdown(segments []map[string]interface{}) *StatsBreakdown {
+	breakdown := &StatsBreakdown{
+		Visibility: make(map[string]CoverageSegment),
+		Archived:   make(map[string]CoverageSegment),
+		Fork:       make(map[string]CoverageSegment),
+	}
+
+	for _, segment := range segments {
+		hasCodeowners := getBoolFromMap(segment, "has_codeowners")
+		archived := getBoolFromMap(segment, "archived")
+		fork := getBoolFromMap(segment, "fork")
+
+		visibility := getStringFromMap(segment, "visibility")
+		breakdown.Visibility[visibility] = addToCoverageSegment(breakdown.Visibility[visibility], hasCodeowners)
+
+		archivedKey := "active"
+		if archived {
+			archivedKey = "archived"
+		}
+		breakdown.Archived[archivedKey] = addToCoverageSegment(breakdown.Archived[archivedKey], hasCodeowners)
+
+		forkKey := "source"
+		if fork {
+			forkKey = "fork"
+		}
+		breakdown.Fork[forkKey] = addToCoverageSegment(breakdown.Fork[forkKey], hasCodeowners)
+
+		if !archived && !fork {
+			breakdown.Compliance = addToCoverageSegment(breakdown.Compliance, hasCodeowners)
+		}
+	}
+
+	return breakdown
+}
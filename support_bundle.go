@@ -0,0 +1,389 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+// Capacities of the in-memory diagnostics kept for support bundles
+const (
+	recentLogCapacity   = 500
+	slowQueryCapacity   = 100
+	bundleScanReports   = 50
+	redactedPlaceholder = "***"
+)
+
+// slowQueryThreshold is the execution time above which a Neo4j query is reported as slow
+const slowQueryThreshold = 5 * time.Second
+
+// DiagnosticLogEntry is a log line retained for support bundles
+type DiagnosticLogEntry struct {
+	Timestamp string    `json:"timestamp"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Fields    LogFields `json:"fields,omitempty"`
+}
+
+// SlowQueryEntry is a slow Neo4j query retained for support bundles
+type SlowQueryEntry struct {
+	Timestamp     string `json:"timestamp"`
+	Database      string `json:"database"`
+	QueryHash     string `json:"query_hash"`
+	QueryType     string `json:"query_type"`
+	QueryPreview  string `json:"query_preview"`
+	ExecutionTime string `json:"execution_time"`
+	RecordCount   int    `json:"record_count"`
+}
+
+// SupportBundleManifest describes the contents of a support bundle
+type SupportBundleManifest struct {
+	GeneratedAt string            `json:"generated_at"`
+	Service     string            `json:"service"`
+	Version     string            `json:"version"`
+	Environment string            `json:"environment"`
+	Files       []string          `json:"files"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// diagnosticsRing is a fixed-size buffer keeping the most recent entries
+type diagnosticsRing[T any] struct {
+	mu       sync.Mutex
+	entries  []T
+	next     int
+	capacity int
+}
+
+// recentLogs keeps the most recent non-debug log lines
+var recentLogs = newDiagnosticsRing[DiagnosticLogEntry](recentLogCapacity)
+
+// recentSlowQueries keeps the most recent slow Neo4j queries
+var recentSlowQueries = newDiagnosticsRing[SlowQueryEntry](slowQueryCapacity)
+
+// newDiagnosticsRing creates a ring buffer with the given capacity
+func newDiagnosticsRing[T any](capacity int) *diagnosticsRing[T] {
+	return &diagnosticsRing[T]{entries: make([]T, 0, capacity), capacity: capacity}
+}
+
+// add appends an entry, overwriting the oldest once the buffer is full
+func (r *diagnosticsRing[T]) add(entry T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) < r.capacity {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % r.capacity
+}
+
+// snapshot returns the retained entries from oldest to newest
+func (r *diagnosticsRing[T]) snapshot() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]T, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	entries = append(entries, r.entries[:r.next]...)
+	return entries
+}
+
+// recordDiagnosticLog retains a log line for support bundles, skipping debug output
+func recordDiagnosticLog(level, message string, fields LogFields) {
+	if level == "debug" {
+		return
+	}
+
+	recentLogs.add(DiagnosticLogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     level,
+		Message:   message,
+		Fields:    LogFields(sanitizeParams(fields)),
+	})
+}
+
+// recordSlowQuery retains a slow query for support bundles
+func recordSlowQuery(session *Neo4jSession, query string, executionTime time.Duration, recordCount int) {
+	recentSlowQueries.add(SlowQueryEntry{
+		Timestamp:     time.Now().UTC().Format(time.RFC3339Nano),
+		Database:      session.database,
+		QueryHash:     cachedQueryHash(query),
+		QueryType:     determineQueryType(query),
+		QueryPreview:  truncateQuery(query, 500),
+		ExecutionTime: executionTime.String(),
+		RecordCount:   recordCount,
+	})
+}
+
+// redactSecret hides a secret while still showing whether it is set (Pure Core)
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedPlaceholder
+}
+
+// buildRedactedConfig builds a shareable view of the configuration without credentials (Pure Core)
+func buildRedactedConfig(config AppConfig) map[string]interface{} {
+	github := config.GitHub
+	github.Token = redactSecret(github.Token)
+	github.AppPrivateKey = redactSecret(github.AppPrivateKey)
+
+	neo4jConfig := config.Neo4j
+	neo4jConfig.URI = sanitizeURI(neo4jConfig.URI)
+	neo4jConfig.Password = redactSecret(neo4jConfig.Password)
+
+	tenantLimits := make(map[string]int, len(config.Quota.TenantLimits))
+	for tenant, limit := range config.Quota.TenantLimits {
+		tenantLimits[tenant] = limit
+	}
+
+	return map[string]interface{}{
+		"environment":     config.Environment,
+		"port":            config.Port,
+		"github":          github,
+		"neo4j":           neo4jConfig,
+		"server":          config.Server,
+		"maintenance":     config.Maintenance,
+		"scan_validation": config.ScanValidation,
+		"graph_types":     config.GraphTypes,
+		"freshness":       config.Freshness,
+		"quota": map[string]interface{}{
+			"monthly_api_calls": config.Quota.MonthlyAPICalls,
+			"tenant_limits":     tenantLimits,
+		},
+		"auth": map[string]interface{}{
+			"api_keys":          len(config.Auth.APIKeys),
+			"operator_api_keys": len(config.Auth.OperatorAPIKeys),
+			"exempt_paths":      config.Auth.ExemptPaths,
+			"oidc_issuer":       config.Auth.OIDC.Issuer,
+			"oidc_audience":     config.Auth.OIDC.Audience,
+			"oidc_roles_claim":  config.Auth.OIDC.RolesClaim,
+			"oidc_default_role": config.Auth.OIDC.DefaultRole,
+		},
+		"publish": map[string]interface{}{
+			"target":    config.Publish.Target,
+			"directory": config.Publish.Directory,
+			"blob_url":  config.Publish.BlobURL != "",
+		},
+		"rate_limit": config.RateLimit,
+		"webhook": map[string]interface{}{
+			"enabled":               config.Webhook.Secret != "",
+			"dead_letter_retention": config.Webhook.DeadLetterRetention.String(),
+		},
+		"team_visibility": map[string]interface{}{
+			"secret_teams":   config.TeamVisibility.SecretTeams,
+			"admin_api_keys": len(config.TeamVisibility.AdminAPIKeys),
+		},
+	}
+}
+
+// buildSchemaConstraintsQuery builds a query listing database constraints (Pure Core)
+func buildSchemaConstraintsQuery(version Neo4jServerVersion) string {
+	if version.isMemgraph() {
+		return "SHOW CONSTRAINT INFO"
+	}
+	return "SHOW CONSTRAINTS YIELD name, type, labelsOrTypes, properties RETURN name, type, labelsOrTypes, properties ORDER BY name"
+}
+
+// buildSchemaIndexesQuery builds a query listing database indexes (Pure Core)
+func buildSchemaIndexesQuery(version Neo4jServerVersion) string {
+	if version.isMemgraph() {
+		return "SHOW INDEX INFO"
+	}
+	return "SHOW INDEXES YIELD name, type, labelsOrTypes, properties, state RETURN name, type, labelsOrTypes, properties, state ORDER BY name"
+}
+
+// buildLabelCountsQuery builds a query counting nodes per label (Pure Core)
+func buildLabelCountsQuery() string {
+	return `
+		MATCH (n)
+		UNWIND labels(n) AS label
+		RETURN label, count(*) AS count
+		ORDER BY label
+	`
+}
+
+// buildRelationshipCountsQuery builds a query counting relationships per type (Pure Core)
+func buildRelationshipCountsQuery() string {
+	return `
+		MATCH ()-[r]->()
+		RETURN type(r) AS type, count(*) AS count
+		ORDER BY type
+	`
+}
+
+// buildRecentScanReportsQuery builds a query returning the most recent scans of every organization (Pure Core)
+func buildRecentScanReportsQuery() string {
+	return `
+		MATCH (org:Organization)-[:HAS_SCAN]->(scan:Scan)
+		RETURN org.login AS organization,
+			org.active_scan_id AS active_scan_id,
+			scan {.*} AS scan
+		ORDER BY scan.started_at DESC
+		LIMIT $limit
+	`
+}
+
+// collectSchemaInfo gathers constraints, indexes and element counts (Orchestrator)
+func collectSchemaInfo(ctx *gofr.Context, session *Neo4jSession) (map[string]interface{}, error) {
+	schema := make(map[string]interface{})
+
+	for section, query := range map[string]string{
+		"constraints":         buildSchemaConstraintsQuery(session.version),
+		"indexes":             buildSchemaIndexesQuery(session.version),
+		"label_counts":        buildLabelCountsQuery(),
+		"relationship_counts": buildRelationshipCountsQuery(),
+	} {
+		var result Neo4jResult
+		var err error
+		if session.version.isMemgraph() {
+			// Memgraph only answers info queries outside explicit transactions
+			result, err = executeNeo4jAutoCommit(ctx, session, query)
+		} else {
+			result, err = executeNeo4jReadQuery(ctx, session, query, nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", section, err)
+		}
+		schema[section] = result.Records
+	}
+
+	return schema, nil
+}
+
+// collectScanReports gathers the most recent scan reports (Orchestrator)
+func collectScanReports(ctx *gofr.Context, session *Neo4jSession) ([]map[string]interface{}, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildRecentScanReportsQuery(), map[string]interface{}{
+		"limit": bundleScanReports,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+// collectHealthSnapshot gathers the health endpoint payload with database and pool details (Orchestrator)
+func collectHealthSnapshot(ctx *gofr.Context, deps *AppDependencies) map[string]interface{} {
+	var freshness *FreshnessSummary
+	if states, err := fetchOrganizationFreshness(ctx, deps); err == nil {
+		summary := summarizeFreshness(states, deps.currentConfig().Freshness.SLA)
+		freshness = &summary
+	}
+
+	snapshot := buildHealthResponse(deps.Maintenance.isEnabled(ctx), freshness, circuitBreakerStatuses(time.Now()), activeGitHubCooldown(time.Now()))
+	snapshot["connection_pool"] = getConnectionPoolMetrics(deps.Neo4jConn)
+	if err := checkNeo4jHealth(ctx, deps.Neo4jConn); err != nil {
+		snapshot["database"] = "unhealthy"
+		snapshot["database_error"] = err.Error()
+	}
+
+	return snapshot
+}
+
+// writeBundleJSON adds a JSON document to the bundle archive
+func writeBundleJSON(archive *zip.Writer, name string, content interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+
+	_, err = file.Write(data)
+	return err
+}
+
+// buildSupportBundle collects diagnostics into a zip archive; sections that fail are reported in the manifest
+func buildSupportBundle(ctx *gofr.Context, deps *AppDependencies) ([]byte, error) {
+	manifest := SupportBundleManifest{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Service:     "codeowners-scanner",
+		Version:     "1.0.0",
+		Environment: deps.Config.Environment,
+		Errors:      make(map[string]string),
+	}
+
+	sections := map[string]interface{}{
+		"config.json":              buildRedactedConfig(deps.currentConfig()),
+		"health.json":              collectHealthSnapshot(ctx, deps),
+		"logs.json":                recentLogs.snapshot(),
+		"slow_queries.json":        recentSlowQueries.snapshot(),
+		"conversion_failures.json": deps.ConversionFailures.snapshot(deps.currentConfig().GraphConversion.Strict),
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		manifest.Errors["schema.json"] = err.Error()
+		manifest.Errors["scans.json"] = err.Error()
+	} else {
+		defer closeNeo4jSession(ctx, session)
+
+		if schema, err := collectSchemaInfo(ctx, session); err != nil {
+			manifest.Errors["schema.json"] = err.Error()
+		} else {
+			sections["schema.json"] = schema
+		}
+
+		if scans, err := collectScanReports(ctx, session); err != nil {
+			manifest.Errors["scans.json"] = err.Error()
+		} else {
+			sections["scans.json"] = scans
+		}
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	for _, name := range []string{"config.json", "health.json", "logs.json", "slow_queries.json", "conversion_failures.json", "schema.json", "scans.json"} {
+		content, exists := sections[name]
+		if !exists {
+			continue
+		}
+		if err := writeBundleJSON(archive, name, content); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, name)
+	}
+
+	if err := writeBundleJSON(archive, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize support bundle: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// handleSupportBundle returns a zip archive of redacted diagnostics for bug reports
+func (h *AppHandler) handleSupportBundle(ctx *gofr.Context) (interface{}, error) {
+	bundle, err := buildSupportBundle(ctx, h.deps)
+	if err != nil {
+		logError(ctx, "Failed to build support bundle", LogFields{
+			"component": "support_bundle",
+			"operation": "build_bundle",
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	logInfo(ctx, "Support bundle generated", LogFields{
+		"component":  "support_bundle",
+		"operation":  "build_bundle",
+		"size_bytes": len(bundle),
+	})
+
+	return response.File{Content: bundle, ContentType: "application/zip"}, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// teamMembersPageSize is the page size of the team members listing
+const teamMembersPageSize = 100
+
+// RepositoryEffectiveOwners counts the people a repository's CODEOWNERS rules reach once teams are
+// expanded to their members
+type RepositoryEffectiveOwners struct {
+	Repository      string   `json:"repository"`
+	Teams           []string `json:"teams"`
+	Users           []string `json:"users"`
+	EffectiveOwners int      `json:"effective_owners"`
+	EmptyTeams      []string `json:"empty_teams,omitempty"`
+}
+
+// TeamExpansionStats reports repository ownership with team owners expanded into their members
+type TeamExpansionStats struct {
+	TeamsExpanded          int                         `json:"teams_expanded"`
+	TeamMembers            map[string]int              `json:"team_members"`
+	AverageEffectiveOwners float64                     `json:"average_effective_owners"`
+	EmptyTeamRepositories  []string                    `json:"empty_team_repositories"`
+	Repositories           []RepositoryEffectiveOwners `json:"repositories"`
+}
+
+// buildRepositoryOwnerSetsQuery builds a query returning the owning teams, which of them are secret, and
+// users of every owned repository in the active scan, leaving out bot users when $excludeBots is set (Pure Core)
+func buildRepositoryOwnerSetsQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[team_owner:HAS_TEAM_OWNER]->(team:Team)
+		WHERE coalesce(team_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, collect(DISTINCT team.slug) AS teams,
+			 collect(DISTINCT CASE WHEN coalesce(team.privacy, '') = 'secret' THEN team.slug END) AS secret_teams
+		OPTIONAL MATCH (repo)-[user_owner:HAS_CODEOWNER]->(owner_user:User)
+		WHERE coalesce(user_owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH org, repo, teams, secret_teams, ` + canonicalUserExpression("owner_user") + ` AS user
+		WITH org, repo, teams, secret_teams, collect(DISTINCT CASE WHEN ` + countedOwnerCondition("user") + ` THEN user.login END) AS users
+		WHERE size(teams) > 0 OR size(users) > 0
+		RETURN repo.full_name AS repository, teams, secret_teams, users, coalesce(org.provider, 'github') AS provider
+		ORDER BY repository
+	`
+}
+
+// calculateTeamExpansion expands each repository's owning teams into their members and counts the
+// distinct people who own it; repositories owned only by teams without members are listed as empty
+// team repositories (Pure Core)
+func calculateTeamExpansion(records []map[string]interface{}, members map[string][]string) TeamExpansionStats {
+	stats := TeamExpansionStats{
+		TeamsExpanded:         len(members),
+		TeamMembers:           make(map[string]int, len(members)),
+		EmptyTeamRepositories: []string{},
+		Repositories:          make([]RepositoryEffectiveOwners, 0, len(records)),
+	}
+	for team, logins := range members {
+		stats.TeamMembers[team] = len(logins)
+	}
+
+	total := 0
+	for _, record := range records {
+		owners := RepositoryEffectiveOwners{
+			Repository: getStringFromMap(record, "repository"),
+			Teams:      getStringSliceFromMap(record, "teams"),
+			Users:      getStringSliceFromMap(record, "users"),
+		}
+		sort.Strings(owners.Teams)
+		sort.Strings(owners.Users)
+
+		people := make(map[string]bool)
+		for _, login := range owners.Users {
+			people[strings.ToLower(login)] = true
+		}
+		for _, team := range owners.Teams {
+			if len(members[team]) == 0 {
+				owners.EmptyTeams = append(owners.EmptyTeams, team)
+			}
+			for _, login := range members[team] {
+				people[strings.ToLower(login)] = true
+			}
+		}
+		owners.EffectiveOwners = len(people)
+		total += owners.EffectiveOwners
+
+		if len(owners.Users) == 0 && len(owners.Teams) > 0 && len(owners.EmptyTeams) == len(owners.Teams) {
+			stats.EmptyTeamRepositories = append(stats.EmptyTeamRepositories, owners.Repository)
+		}
+		stats.Repositories = append(stats.Repositories, owners)
+	}
+
+	if len(stats.Repositories) > 0 {
+		stats.AverageEffectiveOwners = float64(total) / float64(len(stats.Repositories))
+	}
+	return stats
+}
+
+// applyTeamVisibilityToExpansion hides the slugs of secret teams from callers without admin scope:
+// masked teams are reported under one label and omitted teams are left out of the listings, while the
+// effective owner counts still include their members (Pure Core)
+func applyTeamVisibilityToExpansion(stats TeamExpansionStats, secret map[string]bool, mode string) TeamExpansionStats {
+	if mode == secretTeamsShow || len(secret) == 0 {
+		return stats
+	}
+
+	hide := func(teams []string) []string {
+		visible := []string{}
+		for _, team := range teams {
+			switch {
+			case !secret[team]:
+				visible = append(visible, team)
+			case mode == secretTeamsMask:
+				visible = append(visible, maskedTeamLabel)
+			}
+		}
+		return visible
+	}
+
+	members := make(map[string]int, len(stats.TeamMembers))
+	for team, count := range stats.TeamMembers {
+		if !secret[team] {
+			members[team] = count
+		}
+	}
+	stats.TeamMembers = members
+
+	for i := range stats.Repositories {
+		stats.Repositories[i].Teams = hide(stats.Repositories[i].Teams)
+		if len(stats.Repositories[i].EmptyTeams) > 0 {
+			stats.Repositories[i].EmptyTeams = hide(stats.Repositories[i].EmptyTeams)
+		}
+	}
+	return stats
+}
+
+// fetchTeamMembers lists the logins of a team's members, including members of child teams; a team
+// GitHub no longer knows has no members
+func fetchTeamMembers(ctx *gofr.Context, orgName, slug string) ([]string, error) {
+	accounts, err := fetchTeamMemberAccounts(ctx, orgName, slug)
+	if err != nil {
+		return nil, err
+	}
+	return memberLogins(accounts, false), nil
+}
+
+// fetchTeamMemberAccounts lists the login and account type of a team's members, including members of
+// child teams; a team GitHub no longer knows has no members
+func fetchTeamMemberAccounts(ctx *gofr.Context, orgName, slug string) ([]GitHubUser, error) {
+	accounts := []GitHubUser{}
+	for page := 1; ; page++ {
+		resp, err := githubGet(ctx, fmt.Sprintf("orgs/%s/teams/%s/members", orgName, slug), map[string]any{
+			"per_page": teamMembersPageSize,
+			"page":     page,
+		}, buildGitHubRequestHeaders())
+		if err != nil {
+			return nil, err
+		}
+		logRateLimitInfo(ctx, resp)
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return accounts, nil
+		}
+
+		var members []GitHubUser
+		if err := decodeGitHubResponse(resp, "list_team_members", &members); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, members...)
+		if len(members) < teamMembersPageSize {
+			return accounts, nil
+		}
+	}
+}
+
+// attachTeamMembers lists the members of every team whose members were not fetched with it; a team
+// whose listing fails is left without members, so its stored members are kept
+func attachTeamMembers(ctx *gofr.Context, orgName string, teams []GitHubTeam) []GitHubTeam {
+	for i := range teams {
+		if teams[i].Members != nil {
+			continue
+		}
+
+		members, err := fetchTeamMembers(ctx, orgName, teams[i].Slug)
+		if err != nil {
+			logWarn(ctx, "Failed to list team members", LogFields{
+				"component":    "github_client",
+				"operation":    "list_team_members",
+				"organization": orgName,
+				"team":         teams[i].Slug,
+				"error":        err.Error(),
+			})
+			continue
+		}
+		teams[i].Members = members
+	}
+	return teams
+}
+
+// getTeamExpansion expands the owning teams of the active scan's repositories into their current
+// members on the source code host the organization was scanned from, hiding secret teams as the
+// caller's scope requires (Orchestrator)
+func getTeamExpansion(ctx *gofr.Context, deps *AppDependencies, orgName string) (TeamExpansionStats, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return TeamExpansionStats{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	excludeBots := deps.currentConfig().Bots.Exclude
+	result, err := executeNeo4jReadQuery(ctx, session, buildRepositoryOwnerSetsQuery(), map[string]interface{}{
+		"orgName":     orgName,
+		"excludeBots": excludeBots,
+	})
+	if err != nil {
+		return TeamExpansionStats{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	members := make(map[string][]string)
+	secret := make(map[string]bool)
+	for _, record := range result.Records {
+		for _, team := range getStringSliceFromMap(record, "secret_teams") {
+			secret[team] = true
+		}
+		fetchAccounts := selectTeamMemberFetcher(getStringFromMap(record, "provider"))
+		for _, team := range getStringSliceFromMap(record, "teams") {
+			if _, fetched := members[team]; fetched {
+				continue
+			}
+			accounts, err := fetchAccounts(ctx, orgName, team)
+			if err != nil {
+				return TeamExpansionStats{}, err
+			}
+			members[team] = memberLogins(accounts, excludeBots)
+		}
+	}
+
+	stats := applyTeamVisibilityToExpansion(calculateTeamExpansion(result.Records, members), secret, secretTeamsMode(ctx, deps.currentConfig()))
+	logInfo(ctx, "Expanded team owners into members", LogFields{
+		"component":               "stats",
+		"operation":               "expand_teams",
+		"organization":            orgName,
+		"teams":                   stats.TeamsExpanded,
+		"repositories":            len(stats.Repositories),
+		"empty_team_repositories": len(stats.EmptyTeamRepositories),
+	})
+	return stats, nil
+}
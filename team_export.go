@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Team membership export formats accepted by ?format=
+const (
+	TeamExportFormatCSV  = "csv"
+	TeamExportFormatJSON = "json"
+)
+
+// csvContentType is the media type of a streamed CSV export
+const csvContentType = "text/csv; charset=utf-8"
+
+// teamExportFlushRows is the number of CSV rows written between flushes to the client
+const teamExportFlushRows = 100
+
+// teamMembershipCSVHeader is the header row of the team membership export
+var teamMembershipCSVHeader = []string{"team", "team_name", "member", "member_count", "owned_repositories", "ownership_rules"}
+
+// TeamMembershipRow is one member of a team with the team's ownership counts; teams without members
+// have a single row with an empty member, and MemberCount is nil until a scan has listed the members
+type TeamMembershipRow struct {
+	Team              string `json:"team"`
+	TeamName          string `json:"team_name"`
+	Member            string `json:"member,omitempty"`
+	MemberCount       *int   `json:"member_count"`
+	OwnedRepositories int    `json:"owned_repositories"`
+	OwnershipRules    int    `json:"ownership_rules"`
+}
+
+// buildTeamMembershipExportQuery builds a query returning one row per team member of the active scan's
+// teams, with the repositories and CODEOWNERS rules each team owns; the members of secret teams are
+// listed only when $secretTeams shows them (Pure Core)
+func buildTeamMembershipExportQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[has_team:HAS_TEAM]->(team:Team)
+		WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND (coalesce(team.privacy, '') <> '` + teamPrivacySecret + `' OR $secretTeams = '` + secretTeamsShow + `')
+		OPTIONAL MATCH (org)-[owns:OWNS]->(repo:Repository)-[owner:HAS_TEAM_OWNER]->(team)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+			AND coalesce(owner.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH team, count(DISTINCT repo) AS owned_repositories, count(owner) AS ownership_rules
+		UNWIND CASE WHEN size(coalesce(team.members, [])) = 0 THEN [NULL] ELSE team.members END AS member
+		RETURN team.slug AS team,
+			team.name AS team_name,
+			member,
+			CASE WHEN team.members IS NULL THEN NULL ELSE size(team.members) END AS member_count,
+			owned_repositories,
+			ownership_rules
+		ORDER BY team, member
+	`
+}
+
+// convertTeamMembershipRecord converts a record of the export query into a row (Pure Core)
+func convertTeamMembershipRecord(record map[string]interface{}) TeamMembershipRow {
+	row := TeamMembershipRow{
+		Team:              getStringFromMap(record, "team"),
+		TeamName:          getStringFromMap(record, "team_name"),
+		Member:            getStringFromMap(record, "member"),
+		OwnedRepositories: getIntFromMap(record, "owned_repositories"),
+		OwnershipRules:    getIntFromMap(record, "ownership_rules"),
+	}
+	if record["member_count"] != nil {
+		count := getIntFromMap(record, "member_count")
+		row.MemberCount = &count
+	}
+	return row
+}
+
+// formatTeamMembershipCSVRecord formats a row as CSV fields in header order (Pure Core)
+func formatTeamMembershipCSVRecord(row TeamMembershipRow) []string {
+	memberCount := ""
+	if row.MemberCount != nil {
+		memberCount = strconv.Itoa(*row.MemberCount)
+	}
+	return []string{
+		row.Team,
+		row.TeamName,
+		row.Member,
+		memberCount,
+		strconv.Itoa(row.OwnedRepositories),
+		strconv.Itoa(row.OwnershipRules),
+	}
+}
+
+// extractTeamExportOrgFromPath returns the organization of a team export route path (Pure Core)
+func extractTeamExportOrgFromPath(path string) (string, bool) {
+	orgName, found := strings.CutPrefix(path, "/api/teams/")
+	if !found {
+		return "", false
+	}
+	orgName, found = strings.CutSuffix(orgName, "/export")
+	if !found || orgName == "" || strings.Contains(orgName, "/") {
+		return "", false
+	}
+	return orgName, true
+}
+
+// teamExportMiddleware streams GET /api/teams/{org}/export as CSV, the default format; other
+// formats are answered by the route handler
+func teamExportMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			orgName, isExportRoute := extractTeamExportOrgFromPath(r.URL.Path)
+			format := strings.ToLower(r.URL.Query().Get("format"))
+			if r.Method != http.MethodGet || !isExportRoute || (format != "" && format != TeamExportFormatCSV) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+			streamTeamMembershipCSV(r.Context(), w, deps, orgName)
+		})
+	}
+}
+
+// streamTeamMembershipCSV writes the team membership export as CSV while the rows are read from the
+// graph; an error after the header ends the file early and is logged (Orchestrator)
+func streamTeamMembershipCSV(ctx context.Context, w http.ResponseWriter, deps *AppDependencies, orgName string) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	w.Header().Set("Content-Type", csvContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", orgName+"-teams.csv"))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	writer := csv.NewWriter(w)
+	flush := func() error {
+		writer.Flush()
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return writer.Error()
+	}
+
+	if err := writer.Write(teamMembershipCSVHeader); err != nil {
+		return
+	}
+
+	written := 0
+	rows, err := streamNeo4jReadQuery(ctx, session, buildTeamMembershipExportQuery(), map[string]interface{}{
+		"orgName":     orgName,
+		"secretTeams": secretTeamsMode(ctx, deps.currentConfig()),
+	}, func(record map[string]interface{}) error {
+		written++
+		if err := writer.Write(formatTeamMembershipCSVRecord(convertTeamMembershipRecord(record))); err != nil {
+			return err
+		}
+		if written%teamExportFlushRows == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		logError(session.ctx, "Team membership export ended early", LogFields{
+			"component":    "team_export",
+			"operation":    "stream_team_memberships",
+			"organization": orgName,
+			"rows":         rows,
+			"error":        err.Error(),
+		})
+		return
+	}
+
+	logInfo(session.ctx, "Exported team memberships", LogFields{
+		"component":    "team_export",
+		"operation":    "stream_team_memberships",
+		"organization": orgName,
+		"rows":         rows,
+	})
+}
+
+// fetchTeamMemberships reads the team membership export as rows (Orchestrator)
+func fetchTeamMemberships(ctx *gofr.Context, deps *AppDependencies, orgName string) ([]TeamMembershipRow, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildTeamMembershipExportQuery(), map[string]interface{}{
+		"orgName":     orgName,
+		"secretTeams": secretTeamsMode(ctx, deps.currentConfig()),
+	})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	rows := make([]TeamMembershipRow, 0, len(result.Records))
+	for _, record := range result.Records {
+		rows = append(rows, convertTeamMembershipRecord(record))
+	}
+	return rows, nil
+}
+
+// handleExportTeamMemberships returns the team membership export as JSON; CSV exports are streamed
+// by teamExportMiddleware before reaching this handler
+func (h *AppHandler) handleExportTeamMemberships(ctx *gofr.Context) (interface{}, error) {
+	orgName := extractOrgParam(ctx)
+	if orgName == "" {
+		return nil, createMissingParamError("org")
+	}
+
+	if format := strings.ToLower(ctx.Param("format")); format != TeamExportFormatJSON {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"format"}}
+	}
+
+	return fetchTeamMemberships(ctx, h.deps, orgName)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Team privacy levels stored on Team nodes, as GitHub reports them
+const (
+	teamPrivacySecret = "secret"
+	teamPrivacyClosed = "closed"
+)
+
+// How secret teams are answered to callers without admin scope, selected with SECRET_TEAMS
+const (
+	secretTeamsShow = "show"
+	secretTeamsMask = "mask"
+	secretTeamsOmit = "omit"
+)
+
+// maskedTeamLabel replaces the name of a masked secret team
+const maskedTeamLabel = "Secret team"
+
+// adminScopeContextKey marks a request made with admin scope in the request context
+type adminScopeContextKey struct{}
+
+// isValidSecretTeamsMode reports whether mode is a supported SECRET_TEAMS value (Pure Core)
+func isValidSecretTeamsMode(mode string) bool {
+	return mode == secretTeamsShow || mode == secretTeamsMask || mode == secretTeamsOmit
+}
+
+// convertGraphQLTeamPrivacy maps a GraphQL TeamPrivacy value to the REST API's privacy (Pure Core)
+func convertGraphQLTeamPrivacy(privacy string) string {
+	switch privacy {
+	case "SECRET":
+		return teamPrivacySecret
+	case "VISIBLE":
+		return teamPrivacyClosed
+	default:
+		return ""
+	}
+}
+
+// isAdminAPIKey reports whether apiKey is one of the keys granted admin scope, comparing in constant time (Pure Core)
+func isAdminAPIKey(apiKey string, adminKeys []string) bool {
+	if apiKey == "" {
+		return false
+	}
+	admin := false
+	for _, key := range adminKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			admin = true
+		}
+	}
+	return admin
+}
+
+// callerScopeMiddleware records in the request context whether the caller presented an admin API key
+func callerScopeMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			admin := isAdminAPIKey(r.Header.Get(apiKeyHeader), deps.currentConfig().TeamVisibility.AdminAPIKeys)
+			inner.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adminScopeContextKey{}, admin)))
+		})
+	}
+}
+
+// hasAdminScope reports whether the request was made with admin scope
+func hasAdminScope(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminScopeContextKey{}).(bool)
+	return admin
+}
+
+// secretTeamsMode returns how secret teams are answered to the caller: in full with admin scope,
+// otherwise as configured
+func secretTeamsMode(ctx context.Context, config AppConfig) string {
+	if hasAdminScope(ctx) {
+		return secretTeamsShow
+	}
+	return config.TeamVisibility.SecretTeams
+}
+
+// visibleTeamCondition evaluates to true unless a team is secret and $secretTeams omits secret teams (Pure Core)
+func visibleTeamCondition(variable string) string {
+	return fmt.Sprintf("(coalesce(%s.privacy, '') <> '%s' OR $secretTeams <> '%s')", variable, teamPrivacySecret, secretTeamsOmit)
+}
+
+// maskedTeamCondition evaluates to true when a team is secret and $secretTeams masks secret teams (Pure Core)
+func maskedTeamCondition(variable string) string {
+	return fmt.Sprintf("(coalesce(%s.privacy, '') = '%s' AND $secretTeams = '%s')", variable, teamPrivacySecret, secretTeamsMask)
+}
+
+// isSecretTeamNode reports whether a graph node is a secret team (Pure Core)
+func isSecretTeamNode(node GraphNode) bool {
+	secret, _ := node.Data["secret"].(bool)
+	return node.Type == "team" && secret
+}
+
+// maskTeamNode hides the name, slug, description and URL of a team node, keeping its ID, position and
+// counts so the graph keeps its shape (Pure Core)
+func maskTeamNode(node GraphNode) GraphNode {
+	data := make(map[string]interface{}, len(node.Data))
+	for key, value := range node.Data {
+		switch key {
+		case "name", "slug", "description", "url":
+			continue
+		}
+		data[key] = value
+	}
+	data["name"] = maskedTeamLabel
+	node.Label = maskedTeamLabel
+	node.Data = data
+	return node
+}
+
+// teamVisibilityFilter applies a secret team mode to graph nodes and edges one at a time, remembering
+// omitted teams so their edges are dropped as well; streamed graphs write every node before any edge
+type teamVisibilityFilter struct {
+	mode         string
+	omitted      map[string]bool
+	skippedEdges int
+}
+
+// newTeamVisibilityFilter creates a filter for a secret team mode
+func newTeamVisibilityFilter(mode string) *teamVisibilityFilter {
+	return &teamVisibilityFilter{mode: mode, omitted: make(map[string]bool)}
+}
+
+// node returns the node as the caller may see it, or false when it is omitted
+func (f *teamVisibilityFilter) node(node GraphNode) (GraphNode, bool) {
+	if f.mode == secretTeamsShow || !isSecretTeamNode(node) {
+		return node, true
+	}
+	if f.mode == secretTeamsMask {
+		return maskTeamNode(node), true
+	}
+	f.omitted[node.ID] = true
+	return GraphNode{}, false
+}
+
+// edge reports whether an edge is visible, which it is unless it touches an omitted team
+func (f *teamVisibilityFilter) edge(edge GraphEdge) bool {
+	if f.omitted[edge.Source] || f.omitted[edge.Target] {
+		f.skippedEdges++
+		return false
+	}
+	return true
+}
+
+// applyTeamVisibility returns the graph as the caller may see it: secret team nodes are masked, or
+// dropped with their edges, unless mode shows them (Pure Core)
+func applyTeamVisibility(graph GraphResponse, mode string) GraphResponse {
+	if mode == secretTeamsShow {
+		return graph
+	}
+
+	filter := newTeamVisibilityFilter(mode)
+	nodes := make([]GraphNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if visible, ok := filter.node(node); ok {
+			nodes = append(nodes, visible)
+		}
+	}
+	edges := make([]GraphEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if filter.edge(edge) {
+			edges = append(edges, edge)
+		}
+	}
+	graph.Nodes, graph.Edges = nodes, edges
+	return graph
+}
+
+// applyTeamVisibilityToStats hides the number of secret teams from callers without admin scope and,
+// when secret teams are omitted, leaves them out of the team count (Pure Core)
+func applyTeamVisibilityToStats(stats StatsResponse, mode string) StatsResponse {
+	if mode == secretTeamsShow {
+		return stats
+	}
+	if mode == secretTeamsOmit {
+		stats.TotalTeams -= stats.SecretTeams
+	}
+	stats.SecretTeams = 0
+	return stats
+}
+
+// applyTeamVisibilityToResponse applies the caller's secret team mode to a cached graph or stats response (Pure Core)
+func applyTeamVisibilityToResponse(value interface{}, mode string) interface{} {
+	switch cached := value.(type) {
+	case GraphResponse:
+		return applyTeamVisibility(cached, mode)
+	case StatsResponse:
+		return applyTeamVisibilityToStats(cached, mode)
+	default:
+		return value
+	}
+}
@@ -19,7 +19,7 @@ test.describe('API Integration Tests', () => {
     try {
       // Test a real scan API call with a smaller organization
       const scanResponse = await page.request.post(
-        'http://localhost:8081/api/scan/microsoft?max_repos=3',
+        'http://localhost:8081/api/scan/microsoft?wait=true&max_repos=3',
         {
           timeout: 30000,
         }
@@ -68,7 +68,7 @@ test.describe('API Integration Tests', () => {
   }) => {
     // First scan an organization (optional - might already be scanned)
     await page.request
-      .post('http://localhost:8081/api/scan/facebook?max_repos=2', {
+      .post('http://localhost:8081/api/scan/facebook?wait=true&max_repos=2', {
         timeout: 30000,
       })
       .catch(() => {
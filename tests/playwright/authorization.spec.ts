@@ -0,0 +1,88 @@
+import { test, expect, type APIRequestContext } from '@playwright/test'
+
+// Role checks need the API started with authentication configured, e.g.
+//   ENVIRONMENT=development AUTH_API_KEYS=reader-key AUTH_OPERATOR_API_KEYS=operator-key task api-start
+// and the same keys exported as OVERSEER_READER_API_KEY and OVERSEER_OPERATOR_API_KEY
+const apiURL = process.env.OVERSEER_API_URL ?? 'http://localhost:8081'
+const readerKey = process.env.OVERSEER_READER_API_KEY ?? ''
+const operatorKey = process.env.OVERSEER_OPERATOR_API_KEY ?? ''
+
+// The synthetic organization seeded by POST /api/admin/seed
+const org = 'overseer-demo--synthetic'
+
+const asReader = { headers: { 'X-API-Key': readerKey } }
+
+// expectForbiddenRole asserts a reader was refused by the role check rather than by authentication
+async function expectForbiddenRole(response: Awaited<ReturnType<APIRequestContext['get']>>) {
+  expect(response.status()).toBe(403)
+  const body = await response.json()
+  expect(body.error.code).toBe('insufficient_role')
+}
+
+test.describe('API roles', () => {
+  test.skip(
+    !readerKey || !operatorKey,
+    'set OVERSEER_READER_API_KEY and OVERSEER_OPERATOR_API_KEY to keys of AUTH_API_KEYS and AUTH_OPERATOR_API_KEYS'
+  )
+
+  test.beforeAll(async ({ request }) => {
+    const seeded = await request.post(`${apiURL}/api/admin/seed?repos=20&teams=5`, {
+      headers: { 'X-API-Key': operatorKey },
+      timeout: 60000,
+    })
+    expect(seeded.status()).toBe(201)
+  })
+
+  test.beforeEach(async () => {
+    test
+      .info()
+      .annotations.push(
+        { type: 'category', description: 'integration' },
+        { type: 'component', description: 'authorization' }
+      )
+  })
+
+  test('reader can read the graph and stats', async ({ request }) => {
+    const graph = await request.get(`${apiURL}/api/graph/${org}`, asReader)
+    expect(graph.status()).toBe(200)
+
+    const stats = await request.get(`${apiURL}/api/stats/${org}`, asReader)
+    expect(stats.status()).toBe(200)
+  })
+
+  test('reader cannot start scans', async ({ request }) => {
+    await expectForbiddenRole(
+      await request.post(`${apiURL}/api/scan`, { ...asReader, data: { organization: org } })
+    )
+    await expectForbiddenRole(await request.post(`${apiURL}/api/scan/${org}`, asReader))
+  })
+
+  test('reader cannot call admin routes', async ({ request }) => {
+    await expectForbiddenRole(await request.get(`${apiURL}/api/admin/leader`, asReader))
+    await expectForbiddenRole(await request.post(`${apiURL}/api/admin/config/reload`, asReader))
+    await expectForbiddenRole(await request.get(`${apiURL}/api/admin/webhooks/failed`, asReader))
+  })
+
+  test('reader can call the read-only POST routes', async ({ request }) => {
+    const resolved = await request.post(`${apiURL}/api/owners/resolve`, {
+      ...asReader,
+      data: { items: [{ repo: `${org}/demo-repo-1`, path: 'README.md' }] },
+    })
+    expect([401, 403]).not.toContain(resolved.status())
+    expect(resolved.status()).toBeLessThan(500)
+
+    const validated = await request.post(`${apiURL}/api/validate/codeowners`, {
+      ...asReader,
+      data: { content: '* @octocat\n' },
+    })
+    expect([401, 403]).not.toContain(validated.status())
+    expect(validated.status()).toBeLessThan(500)
+  })
+
+  test('operator can call admin routes', async ({ request }) => {
+    const leader = await request.get(`${apiURL}/api/admin/leader`, {
+      headers: { 'X-API-Key': operatorKey },
+    })
+    expect(leader.status()).toBe(200)
+  })
+})
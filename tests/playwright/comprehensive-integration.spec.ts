@@ -188,7 +188,7 @@ test.describe('Comprehensive API Integration Tests (Covering Hurl Test Suite)',
 
       // Test 1: Basic organization scan with valid org
       const scanResponse = await page.request.post(
-        `${baseUrl}/api/scan/${testOrgSmall}?max_repos=2&max_teams=2`,
+        `${baseUrl}/api/scan/${testOrgSmall}?wait=true&max_repos=2&max_teams=2`,
         {
           headers: {
             'User-Agent': userAgent,
@@ -212,7 +212,7 @@ test.describe('Comprehensive API Integration Tests (Covering Hurl Test Suite)',
 
       // Test 2: Scan with invalid organization name
       const invalidScanResponse = await page.request.post(
-        `${baseUrl}/api/scan/${testOrgInvalid}`,
+        `${baseUrl}/api/scan/${testOrgInvalid}?wait=true`,
         {
           headers: {
             'User-Agent': userAgent,
@@ -227,7 +227,7 @@ test.describe('Comprehensive API Integration Tests (Covering Hurl Test Suite)',
 
       // Test 3: Scan with minimal parameters
       const minimalScanResponse = await page.request.post(
-        `${baseUrl}/api/scan/${testOrgSmall}?max_repos=1&max_teams=1`,
+        `${baseUrl}/api/scan/${testOrgSmall}?wait=true&max_repos=1&max_teams=1`,
         {
           headers: {
             'User-Agent': userAgent,
@@ -252,7 +252,7 @@ test.describe('Comprehensive API Integration Tests (Covering Hurl Test Suite)',
 
       // First ensure we have data by scanning
       await page.request
-        .post(`${baseUrl}/api/scan/${testOrgSmall}?max_repos=2`, {
+        .post(`${baseUrl}/api/scan/${testOrgSmall}?wait=true&max_repos=2`, {
           timeout: 30000,
         })
         .catch(() => {
@@ -28,7 +28,7 @@ test.describe('GitHub Automation', () => {
     // Step 1: First scan the organization to collect data
     console.log('Step 1: Scanning organization...')
     const scanResponse = await page.request.post(
-      'http://localhost:8081/api/scan/github?useTopics=true&max_repos=5',
+      'http://localhost:8081/api/scan/github?wait=true&useTopics=true&max_repos=5',
       {
         timeout: 60000, // 60 second timeout for scan operation
       }
@@ -90,7 +90,7 @@ test.describe('GitHub Automation', () => {
     // Step 1: Scan organization with topics
     console.log('Testing scan endpoint with topics...')
     const scanResponse = await page.request.post(
-      'http://localhost:8081/api/scan/github?useTopics=true&max_repos=5',
+      'http://localhost:8081/api/scan/github?wait=true&useTopics=true&max_repos=5',
       {
         timeout: 60000,
       }
@@ -140,7 +140,7 @@ test.describe('GitHub Automation', () => {
 
     // Try to scan - this should fail gracefully
     const scanResponse = await page.request.post(
-      `http://localhost:8081/api/scan/${nonExistentOrg}?useTopics=true`,
+      `http://localhost:8081/api/scan/${nonExistentOrg}?wait=true&useTopics=true`,
       {
         timeout: 30000,
       }
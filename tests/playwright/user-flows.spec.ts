@@ -22,7 +22,7 @@ test.describe('Key User Flows', () => {
     // Step 3: Scan the organization first
     console.log('Step 3: Scanning organization...')
     const scanResponse = await page.request.post(
-      'http://localhost:8081/api/scan/github?useTopics=true&max_repos=100',
+      'http://localhost:8081/api/scan/github?wait=true&useTopics=true&max_repos=100',
       {
         timeout: 60000,
       }
@@ -324,7 +324,7 @@ test.describe('Key User Flows', () => {
     try {
       console.log('Attempting scan that will fail...')
       const firstScanResponse = await page.request.post(
-        'http://localhost:8081/api/scan/retry-org',
+        'http://localhost:8081/api/scan/retry-org?wait=true',
         {
           timeout: 30000,
         }
@@ -336,7 +336,7 @@ test.describe('Key User Flows', () => {
       // Retry scan
       console.log('Retrying scan...')
       const retryScanResponse = await page.request.post(
-        'http://localhost:8081/api/scan/retry-org',
+        'http://localhost:8081/api/scan/retry-org?wait=true',
         {
           timeout: 30000,
         }
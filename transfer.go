@@ -0,0 +1,427 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// Pull request outcomes of an ownership transfer
+const (
+	TransferPullRequestOpened  = "opened"
+	TransferPullRequestSkipped = "skipped"
+	TransferPullRequestFailed  = "failed"
+)
+
+// OwnershipTransferRequest represents a request to move CODEOWNERS rules from one team to another
+type OwnershipTransferRequest struct {
+	Organization     string `json:"organization"`
+	FromTeam         string `json:"from_team"`
+	ToTeam           string `json:"to_team"`
+	OpenPullRequests bool   `json:"open_pull_requests"`
+}
+
+// TransferRule is a CODEOWNERS rule assigning the source team
+type TransferRule struct {
+	Pattern string `json:"pattern"`
+	Line    int    `json:"line"`
+}
+
+// TransferPullRequest is the outcome of updating a repository's CODEOWNERS file
+type TransferPullRequest struct {
+	Status string `json:"status"`
+	Branch string `json:"branch,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// TransferRepository lists the affected rules of a repository and its pull request, if any
+type TransferRepository struct {
+	Repository  string               `json:"repository"`
+	Path        string               `json:"path,omitempty"`
+	Rules       []TransferRule       `json:"rules"`
+	PullRequest *TransferPullRequest `json:"pull_request,omitempty"`
+
+	blobSHA string
+	content string
+}
+
+// OwnershipTransferResponse is the blast radius of moving ownership from one team to another
+type OwnershipTransferResponse struct {
+	Organization         string               `json:"organization"`
+	FromTeam             string               `json:"from_team"`
+	ToTeam               string               `json:"to_team"`
+	AffectedRepositories int                  `json:"affected_repositories"`
+	AffectedRules        int                  `json:"affected_rules"`
+	Repositories         []TransferRepository `json:"repositories"`
+}
+
+// TransferPullRequestsDisabledError is returned when pull requests are requested but not enabled
+type TransferPullRequestsDisabledError struct{}
+
+// Error implements the error interface for TransferPullRequestsDisabledError
+func (TransferPullRequestsDisabledError) Error() string {
+	return "opening pull requests requires TRANSFER_PULL_REQUESTS_ENABLED and a GitHub token with write access"
+}
+
+// StatusCode returns the HTTP status code for the error
+func (TransferPullRequestsDisabledError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// buildTransferTeamsQuery builds a query returning which of the requested teams belong to the organization (Pure Core)
+func buildTransferTeamsQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})
+		RETURN org.login AS organization,
+			[(org)-[has_team:HAS_TEAM]->(team:Team)
+				WHERE coalesce(has_team.scan_id, '') = coalesce(org.active_scan_id, '') AND team.slug IN $teams
+				| team.slug] AS teams
+	`
+}
+
+// buildTeamOwnedRulesQuery builds a query returning the active CODEOWNERS rules assigning a team,
+// grouped by repository with the stored CODEOWNERS file (Pure Core)
+func buildTeamOwnedRulesQuery() string {
+	return `
+		MATCH (org:Organization {login: $orgName})-[owns:OWNS]->(repo:Repository)
+		WHERE coalesce(owns.scan_id, '') = coalesce(org.active_scan_id, '')
+		MATCH (repo)-[rule:HAS_TEAM_OWNER]->(:Team {slug: $fromTeam})
+		WHERE coalesce(rule.scan_id, '') = coalesce(org.active_scan_id, '')
+		OPTIONAL MATCH (repo)-[file:HAS_CODEOWNERS_FILE]->(blob:CodeownersBlob)
+		WHERE coalesce(file.scan_id, '') = coalesce(org.active_scan_id, '')
+		WITH repo, file, blob, rule ORDER BY rule.line
+		RETURN repo.full_name AS repository,
+			file.path AS path,
+			blob.sha AS sha,
+			blob.content AS content,
+			collect(DISTINCT {pattern: rule.pattern, line: rule.line}) AS rules
+		ORDER BY repository
+	`
+}
+
+// normalizeTransferTeam strips an optional @org/ prefix from a team reference (Pure Core)
+func normalizeTransferTeam(orgName, team string) string {
+	team = strings.TrimPrefix(strings.TrimSpace(team), "@")
+	if prefix := orgName + "/"; len(team) > len(prefix) && strings.EqualFold(team[:len(prefix)], prefix) {
+		team = team[len(prefix):]
+	}
+	return team
+}
+
+// validateOwnershipTransferRequest checks the organization and distinct source and target teams (Pure Core)
+func validateOwnershipTransferRequest(request OwnershipTransferRequest) error {
+	missing := []string{}
+	if request.Organization == "" {
+		missing = append(missing, "organization")
+	}
+	if request.FromTeam == "" {
+		missing = append(missing, "from_team")
+	}
+	if request.ToTeam == "" {
+		missing = append(missing, "to_team")
+	}
+	if len(missing) > 0 {
+		return &gofrhttp.ErrorMissingParam{Params: missing}
+	}
+
+	if strings.EqualFold(request.FromTeam, request.ToTeam) {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"to_team"}}
+	}
+	return nil
+}
+
+// convertToTransferRepositories converts rule records into affected repositories (Pure Core)
+func convertToTransferRepositories(records []map[string]interface{}) []TransferRepository {
+	repositories := make([]TransferRepository, 0, len(records))
+	for _, record := range records {
+		rules := []TransferRule{}
+		for _, rule := range getMapSliceFromMap(record, "rules") {
+			rules = append(rules, TransferRule{
+				Pattern: getStringFromMap(rule, "pattern"),
+				Line:    getIntFromMap(rule, "line"),
+			})
+		}
+
+		repositories = append(repositories, TransferRepository{
+			Repository: getStringFromMap(record, "repository"),
+			Path:       getStringFromMap(record, "path"),
+			Rules:      rules,
+			blobSHA:    getStringFromMap(record, "sha"),
+			content:    getStringFromMap(record, "content"),
+		})
+	}
+	return repositories
+}
+
+// rewriteCodeownersTeam replaces a team owner with another in CODEOWNERS content, dropping the
+// source team where the target already owns the pattern. Returns the content and the number of
+// rewritten lines. (Pure Core)
+func rewriteCodeownersTeam(content, from, to string) (string, int) {
+	lines := strings.Split(content, "\n")
+	rewritten := 0
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		fields := strings.Fields(trimmed)
+		end := len(fields)
+		for j := 1; j < len(fields); j++ {
+			if strings.HasPrefix(fields[j], "#") {
+				end = j
+				break
+			}
+		}
+
+		owners := make([]string, 0, end-1)
+		changed, hasTarget := false, false
+		for _, owner := range fields[1:end] {
+			hasTarget = hasTarget || strings.EqualFold(owner, to)
+		}
+		for _, owner := range fields[1:end] {
+			if !strings.EqualFold(owner, from) {
+				owners = append(owners, owner)
+				continue
+			}
+			changed = true
+			if !hasTarget {
+				owners = append(owners, to)
+				hasTarget = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		parts := append([]string{fields[0]}, owners...)
+		lines[i] = strings.Join(append(parts, fields[end:]...), " ")
+		rewritten++
+	}
+
+	return strings.Join(lines, "\n"), rewritten
+}
+
+// buildTransferBranchName builds the branch of a transfer pull request (Pure Core)
+func buildTransferBranchName(prefix, from, to string) string {
+	return fmt.Sprintf("%s/%s-to-%s", strings.TrimSuffix(prefix, "/"), from, to)
+}
+
+// buildTransferPullRequestBody describes the rules a transfer pull request moves (Pure Core)
+func buildTransferPullRequestBody(from, to string, rules []TransferRule) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Transfers CODEOWNERS ownership from `%s` to `%s`.\n\nAffected rules:\n", from, to)
+	for _, rule := range rules {
+		fmt.Fprintf(&builder, "- line %d: `%s`\n", rule.Line, rule.Pattern)
+	}
+	return builder.String()
+}
+
+// decodeGitHubResponse decodes a GitHub response, converting error statuses to GitHubAPIError
+func decodeGitHubResponse(resp *http.Response, operation string, target interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		details, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return newGitHubAPIError(
+			"github_"+operation,
+			fmt.Sprintf("GitHub returned status %d", resp.StatusCode),
+			strings.TrimSpace(string(details)),
+			resp.StatusCode,
+		)
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// openTransferPullRequest pushes a branch with the rewritten CODEOWNERS file and opens a pull request (Orchestrator)
+func openTransferPullRequest(ctx *gofr.Context, branch, from, to string, repository TransferRepository) TransferPullRequest {
+	if repository.content == "" || repository.blobSHA == "" {
+		return TransferPullRequest{Status: TransferPullRequestSkipped, Reason: "CODEOWNERS file content is not stored; rescan the organization"}
+	}
+
+	content, rewritten := rewriteCodeownersTeam(repository.content, from, to)
+	if rewritten == 0 {
+		return TransferPullRequest{Status: TransferPullRequestSkipped, Reason: "CODEOWNERS file does not reference the source team"}
+	}
+
+	fail := func(err error) TransferPullRequest {
+		logWarn(ctx, "Failed to open ownership transfer pull request", LogFields{
+			"component":  "transfer",
+			"operation":  "open_pull_request",
+			"repository": repository.Repository,
+			"branch":     branch,
+			"error":      err.Error(),
+		})
+		return TransferPullRequest{Status: TransferPullRequestFailed, Branch: branch, Reason: err.Error()}
+	}
+
+	headers := buildGitHubRequestHeaders()
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	resp, err := githubGet(ctx, "repos/"+repository.Repository, nil, headers)
+	if err == nil {
+		err = decodeGitHubResponse(resp, "get_repository", &repo)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	resp, err = githubGet(ctx, fmt.Sprintf("repos/%s/git/ref/heads/%s", repository.Repository, repo.DefaultBranch), nil, headers)
+	if err == nil {
+		err = decodeGitHubResponse(resp, "get_ref", &ref)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	resp, err = githubWrite(ctx, http.MethodPost, fmt.Sprintf("repos/%s/git/refs", repository.Repository), map[string]string{
+		"ref": "refs/heads/" + branch,
+		"sha": ref.Object.SHA,
+	})
+	if err == nil {
+		err = decodeGitHubResponse(resp, "create_branch", nil)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	resp, err = githubWrite(ctx, http.MethodPut, fmt.Sprintf("repos/%s/contents/%s", repository.Repository, repository.Path), map[string]string{
+		"message": fmt.Sprintf("Transfer CODEOWNERS ownership from %s to %s", from, to),
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"sha":     repository.blobSHA,
+		"branch":  branch,
+	})
+	if err == nil {
+		err = decodeGitHubResponse(resp, "update_codeowners", nil)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	var pull struct {
+		HTMLURL string `json:"html_url"`
+	}
+	resp, err = githubWrite(ctx, http.MethodPost, fmt.Sprintf("repos/%s/pulls", repository.Repository), map[string]string{
+		"title": fmt.Sprintf("Transfer CODEOWNERS ownership from %s to %s", from, to),
+		"head":  branch,
+		"base":  repo.DefaultBranch,
+		"body":  buildTransferPullRequestBody(from, to, repository.Rules),
+	})
+	if err == nil {
+		err = decodeGitHubResponse(resp, "create_pull_request", &pull)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	return TransferPullRequest{Status: TransferPullRequestOpened, Branch: branch, URL: pull.HTMLURL}
+}
+
+// planOwnershipTransfer lists the rules owned by the source team and optionally opens pull requests moving them (Orchestrator)
+func planOwnershipTransfer(ctx *gofr.Context, deps *AppDependencies, request OwnershipTransferRequest) (OwnershipTransferResponse, error) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return OwnershipTransferResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	teams, err := executeNeo4jReadQuery(ctx, session, buildTransferTeamsQuery(), map[string]interface{}{
+		"orgName": request.Organization,
+		"teams":   []string{request.FromTeam, request.ToTeam},
+	})
+	if err != nil {
+		return OwnershipTransferResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+	if len(teams.Records) == 0 {
+		return OwnershipTransferResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "organization", Value: request.Organization}
+	}
+	found := getStringSliceFromMap(teams.Records[0], "teams")
+	for _, team := range []string{request.FromTeam, request.ToTeam} {
+		if !lo.Contains(found, team) {
+			return OwnershipTransferResponse{}, &gofrhttp.ErrorEntityNotFound{Name: "team", Value: team}
+		}
+	}
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildTeamOwnedRulesQuery(), map[string]interface{}{
+		"orgName":  request.Organization,
+		"fromTeam": request.FromTeam,
+	})
+	if err != nil {
+		return OwnershipTransferResponse{}, convertNeo4jErrorToGoFr(err)
+	}
+
+	response := OwnershipTransferResponse{
+		Organization: request.Organization,
+		FromTeam:     request.FromTeam,
+		ToTeam:       request.ToTeam,
+		Repositories: convertToTransferRepositories(result.Records),
+	}
+	response.AffectedRepositories = len(response.Repositories)
+	for _, repository := range response.Repositories {
+		response.AffectedRules += len(repository.Rules)
+	}
+
+	if request.OpenPullRequests {
+		branch := buildTransferBranchName(deps.currentConfig().Transfer.BranchPrefix, request.FromTeam, request.ToTeam)
+		from := "@" + request.Organization + "/" + request.FromTeam
+		to := "@" + request.Organization + "/" + request.ToTeam
+		for i, repository := range response.Repositories {
+			pullRequest := openTransferPullRequest(ctx, branch, from, to, repository)
+			response.Repositories[i].PullRequest = &pullRequest
+		}
+	}
+
+	logInfo(ctx, "Ownership transfer planned", LogFields{
+		"component":             "transfer",
+		"operation":             "plan_transfer",
+		"organization":          request.Organization,
+		"from_team":             request.FromTeam,
+		"to_team":               request.ToTeam,
+		"affected_repositories": response.AffectedRepositories,
+		"affected_rules":        response.AffectedRules,
+		"open_pull_requests":    request.OpenPullRequests,
+	})
+
+	return response, nil
+}
+
+// handleTransferOwnership lists the CODEOWNERS rules affected by moving ownership between teams
+// and, when enabled, opens pull requests updating the affected files
+func (h *AppHandler) handleTransferOwnership(ctx *gofr.Context) (interface{}, error) {
+	var request OwnershipTransferRequest
+	if err := ctx.Bind(&request); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+
+	request.Organization = strings.TrimSpace(request.Organization)
+	request.FromTeam = normalizeTransferTeam(request.Organization, request.FromTeam)
+	request.ToTeam = normalizeTransferTeam(request.Organization, request.ToTeam)
+	if err := validateOwnershipTransferRequest(request); err != nil {
+		return nil, err
+	}
+
+	if request.OpenPullRequests && !h.deps.currentConfig().Transfer.PullRequestsEnabled {
+		return nil, TransferPullRequestsDisabledError{}
+	}
+
+	return planOwnershipTransfer(ctx, h.deps, request)
+}
@@ -1,20 +1,31 @@
 package main
 
+import "gofr.dev/pkg/gofr"
+
 // ScanRequest represents a request to scan a GitHub organization
 type ScanRequest struct {
 	Organization string `json:"organization"`
 	MaxRepos     int    `json:"max_repos"`
 	MaxTeams     int    `json:"max_teams"`
 	UseTopics    bool   `json:"use_topics"`
+	Mode         string `json:"mode,omitempty"`
+	// Provider is the source code host to scan; empty uses SCM_PROVIDER
+	Provider string `json:"provider,omitempty"`
 }
 
 // ScanResponse represents the response from scanning an organization
 type ScanResponse struct {
-	Success      bool                   `json:"success"`
-	Organization string                 `json:"organization"`
-	Summary      ScanSummary            `json:"summary"`
-	Errors       []string               `json:"errors"`
-	Data         map[string]interface{} `json:"data"`
+	Success            bool                      `json:"success"`
+	Organization       string                    `json:"organization"`
+	ScanID             string                    `json:"scan_id"`
+	ScanStatus         string                    `json:"scan_status"`
+	Mode               string                    `json:"mode"`
+	IncrementalSince   string                    `json:"incremental_since,omitempty"`
+	ValidationFailures []string                  `json:"validation_failures,omitempty"`
+	ConversionFailures map[string]map[string]int `json:"conversion_failures,omitempty"`
+	Summary            ScanSummary               `json:"summary"`
+	Errors             []string                  `json:"errors"`
+	Data               map[string]interface{}    `json:"data"`
 }
 
 // ScanSummary represents scan statistics
@@ -30,8 +41,13 @@ type ScanSummary struct {
 
 // GraphResponse represents graph visualization data
 type GraphResponse struct {
-	Nodes []GraphNode `json:"nodes"`
-	Edges []GraphEdge `json:"edges"`
+	SchemaVersion int         `json:"schema_version"`
+	Nodes         []GraphNode `json:"nodes"`
+	Edges         []GraphEdge `json:"edges"`
+	Summarized    bool        `json:"summarized,omitempty"`
+	Page          *GraphPage  `json:"page,omitempty"`
+	Stale         bool        `json:"stale,omitempty"`
+	CachedAt      string      `json:"cached_at,omitempty"`
 }
 
 // GraphNode represents a node in the graph
@@ -60,23 +76,54 @@ type GraphPosition struct {
 
 // StatsResponse represents organization statistics
 type StatsResponse struct {
-	Organization      string `json:"organization"`
-	TotalRepositories int    `json:"total_repositories"`
-	TotalTeams        int    `json:"total_teams"`
-	TotalTopics       int    `json:"total_topics"`
-	TotalUsers        int    `json:"total_users"`
-	TotalCodeowners   int    `json:"total_codeowners"`
-	CodeownerCoverage string `json:"codeowner_coverage"`
-	LastScanTime      string `json:"last_scan_time"`
+	Organization       string                  `json:"organization"`
+	TotalRepositories  int                     `json:"total_repositories"`
+	TotalTeams         int                     `json:"total_teams"`
+	SecretTeams        int                     `json:"secret_teams,omitempty"`
+	TotalTopics        int                     `json:"total_topics"`
+	TotalUsers         int                     `json:"total_users"`
+	TotalBots          int                     `json:"total_bots"`
+	BotsExcluded       bool                    `json:"bots_excluded,omitempty"`
+	TotalCodeowners    int                     `json:"total_codeowners"`
+	CodeownerCoverage  string                  `json:"codeowner_coverage"`
+	LastScanTime       string                  `json:"last_scan_time"`
+	LastSuccessfulScan string                  `json:"last_successful_scan,omitempty"`
+	ActiveScanID       string                  `json:"active_scan_id,omitempty"`
+	Breakdown          *StatsBreakdown         `json:"breakdown,omitempty"`
+	CoverageTarget     *CoverageTargetProgress `json:"coverage_target,omitempty"`
+	DataFreshness      *DataFreshness          `json:"data_freshness,omitempty"`
+	TeamExpansion      *TeamExpansionStats     `json:"team_expansion,omitempty"`
+	Stale              bool                    `json:"stale,omitempty"`
+	CachedAt           string                  `json:"cached_at,omitempty"`
 }
 
 // AppDependencies represents application dependencies
 type AppDependencies struct {
-	Config    AppConfig
-	Neo4jConn *Neo4jConnection
+	Config        AppConfig
+	LiveConfig    *LiveConfig
+	Neo4jConn     *Neo4jConnection
+	Maintenance   *MaintenanceState
+	GraphChanges  *GraphChangeNotifier
+	GraphTypes    *GraphTypeRegistry
+	GraphCounts   *GraphCountsCache
+	Scans         *ScanTracker
+	ScanJobs      *ScanJobStore
+	ResponseCache *StaleResponseCache
+	Access        *OrganizationAccessTracker
+	SLO           *SLOTracker
+	Scheduler     *ScanScheduler
+	State         StateStore
+	Leader        *LeaderElector
+	OIDC          *OIDCVerifier
+	RateLimits    *RateLimiter
+	// ConversionFailures counts graph records dropped during conversion since startup
+	ConversionFailures *ConversionFailureTracker
+	// AppContext is the GoFr context captured at startup, which middlewares scope to their request
+	// to call code that needs GoFr's datasources and logger
+	AppContext *gofr.Context
 }
 
 // AppHandler contains the application dependencies
 type AppHandler struct {
 	deps *AppDependencies
-}
\ No newline at end of file
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// anonymousTenant is the tenant used for requests without an API key
+const anonymousTenant = "anonymous"
+
+// apiKeyHeader is the request header identifying the calling API key
+const apiKeyHeader = "X-API-Key"
+
+// usageMonthLayout is the layout of the month usage is accounted against
+const usageMonthLayout = "2006-01"
+
+// tenantContextKey stores the calling tenant in the request context
+type tenantContextKey struct{}
+
+// apiUsageContextKey stores the API usage counter of a running scan in the context
+type apiUsageContextKey struct{}
+
+// APIUsageCounter counts GitHub API calls made on behalf of a single scan
+type APIUsageCounter struct {
+	calls atomic.Int64
+}
+
+// QuotaExceededError is returned when a tenant has used up its monthly GitHub API quota
+type QuotaExceededError struct {
+	Tenant string
+	Limit  int
+	Used   int
+}
+
+// Error implements the error interface for QuotaExceededError
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("monthly GitHub API quota exceeded for tenant %s: %d of %d calls used", e.Tenant, e.Used, e.Limit)
+}
+
+// StatusCode returns the HTTP status code for the error
+func (QuotaExceededError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// TenantUsage represents a tenant's GitHub API consumption for a month
+type TenantUsage struct {
+	Tenant    string `json:"tenant"`
+	Month     string `json:"month"`
+	APICalls  int    `json:"api_calls"`
+	Scans     int    `json:"scans"`
+	Quota     int    `json:"quota,omitempty"`
+	Remaining *int   `json:"remaining,omitempty"`
+}
+
+// ScanUsage represents the GitHub API consumption of a single scan
+type ScanUsage struct {
+	ScanID       string `json:"scan_id"`
+	Organization string `json:"organization"`
+	Tenant       string `json:"tenant"`
+	APICalls     int    `json:"api_calls"`
+	StartedAt    string `json:"started_at"`
+}
+
+// UsageResponse represents the admin usage report
+type UsageResponse struct {
+	Month   string        `json:"month"`
+	Tenants []TenantUsage `json:"tenants"`
+	Scans   []ScanUsage   `json:"recent_scans"`
+}
+
+// tenantFromAPIKey derives a stable tenant identifier without exposing the key (Pure Core)
+func tenantFromAPIKey(apiKey string) string {
+	if apiKey == "" {
+		return anonymousTenant
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// tenantFromContext returns the tenant attached to the request context
+func tenantFromContext(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantContextKey{}).(string); ok && tenant != "" {
+		return tenant
+	}
+	return anonymousTenant
+}
+
+// tenantContextMiddleware attaches the calling tenant to the request context
+func tenantContextMiddleware() gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant := tenantFromAPIKey(r.Header.Get(apiKeyHeader))
+			inner.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, tenant)))
+		})
+	}
+}
+
+// withAPIUsage returns a copy of ctx that accounts GitHub API calls to counter
+func withAPIUsage(ctx *gofr.Context, counter *APIUsageCounter) *gofr.Context {
+	scoped := *ctx
+	scoped.Context = context.WithValue(ctx.Context, apiUsageContextKey{}, counter)
+	return &scoped
+}
+
+// recordGitHubAPICall increments the API usage counter of the running scan, if any
+func recordGitHubAPICall(ctx context.Context) {
+	if counter, ok := ctx.Value(apiUsageContextKey{}).(*APIUsageCounter); ok {
+		counter.calls.Add(1)
+	}
+}
+
+// count returns the number of API calls recorded so far
+func (c *APIUsageCounter) count() int {
+	return int(c.calls.Load())
+}
+
+// parseTenantQuotas parses "tenant=limit,tenant=limit" quota overrides (Pure Core)
+func parseTenantQuotas(value string) map[string]int {
+	quotas := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		tenant, limit, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			continue
+		}
+		if parsed, err := strconv.Atoi(strings.TrimSpace(limit)); err == nil {
+			quotas[strings.TrimSpace(tenant)] = parsed
+		}
+	}
+	return quotas
+}
+
+// resolveTenantQuota returns the monthly quota of a tenant, 0 meaning unlimited (Pure Core)
+func resolveTenantQuota(config QuotaConfig, tenant string) int {
+	if limit, exists := config.TenantLimits[tenant]; exists {
+		return limit
+	}
+	return config.MonthlyAPICalls
+}
+
+// currentUsageMonth returns the accounting month of a point in time (Pure Core)
+func currentUsageMonth(now time.Time) string {
+	return now.UTC().Format(usageMonthLayout)
+}
+
+// buildTenantUsageQuery builds a query returning a tenant's usage for a month (Pure Core)
+func buildTenantUsageQuery() string {
+	return `
+		OPTIONAL MATCH (usage:ApiUsage {tenant: $tenant, month: $month})
+		RETURN coalesce(usage.calls, 0) AS calls
+	`
+}
+
+// buildRecordUsageQuery builds a query adding a scan's API calls to the tenant's monthly usage (Pure Core)
+func buildRecordUsageQuery() string {
+	return `
+		MERGE (usage:ApiUsage {tenant: $tenant, month: $month})
+		ON CREATE SET usage.calls = 0, usage.scans = 0
+		SET usage.calls = usage.calls + $calls,
+			usage.scans = usage.scans + 1,
+			usage.updated_at = $updated_at
+		WITH usage
+		OPTIONAL MATCH (scan:Scan {id: $scan_id})
+		SET scan.api_calls = $calls, scan.tenant = $tenant
+		RETURN usage.calls AS calls
+	`
+}
+
+// buildMonthlyUsageQuery builds a query listing every tenant's usage for a month (Pure Core)
+func buildMonthlyUsageQuery() string {
+	return `
+		MATCH (usage:ApiUsage {month: $month})
+		RETURN usage.tenant AS tenant, usage.calls AS calls, usage.scans AS scans
+		ORDER BY calls DESC
+	`
+}
+
+// buildRecentScanUsageQuery builds a query listing the API consumption of recent scans (Pure Core)
+func buildRecentScanUsageQuery() string {
+	return `
+		MATCH (scan:Scan)
+		WHERE scan.api_calls IS NOT NULL
+		RETURN scan.id AS scan_id, scan.organization AS organization, scan.tenant AS tenant,
+			scan.api_calls AS api_calls, scan.started_at AS started_at
+		ORDER BY scan.started_at DESC
+		LIMIT 50
+	`
+}
+
+// checkTenantQuota rejects a scan when the tenant has exhausted its monthly quota
+func checkTenantQuota(ctx *gofr.Context, deps *AppDependencies, tenant string) error {
+	limit := resolveTenantQuota(deps.currentConfig().Quota, tenant)
+	if limit <= 0 {
+		return nil
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	result, err := executeNeo4jReadQuery(ctx, session, buildTenantUsageQuery(), map[string]interface{}{
+		"tenant": tenant,
+		"month":  currentUsageMonth(time.Now()),
+	})
+	if err != nil {
+		return convertNeo4jErrorToGoFr(err)
+	}
+
+	used := 0
+	if len(result.Records) > 0 {
+		used = getIntFromMap(result.Records[0], "calls")
+	}
+
+	if used >= limit {
+		logWarn(ctx, "Scan rejected by monthly API quota", LogFields{
+			"component": "usage",
+			"operation": "check_quota",
+			"tenant":    tenant,
+			"used":      used,
+			"limit":     limit,
+		})
+		return QuotaExceededError{Tenant: tenant, Limit: limit, Used: used}
+	}
+
+	return nil
+}
+
+// recordScanUsage persists a scan's GitHub API consumption against the tenant's monthly usage
+func recordScanUsage(ctx *gofr.Context, deps *AppDependencies, tenant, scanID string, calls int) {
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err == nil {
+		defer closeNeo4jSession(ctx, session)
+		_, err = executeNeo4jWrite(ctx, session, buildRecordUsageQuery(), map[string]interface{}{
+			"tenant":     tenant,
+			"month":      currentUsageMonth(time.Now()),
+			"calls":      calls,
+			"scan_id":    scanID,
+			"updated_at": time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	if err != nil {
+		logError(ctx, "Failed to record scan API usage", LogFields{
+			"component": "usage",
+			"operation": "record_usage",
+			"tenant":    tenant,
+			"scan_id":   scanID,
+			"api_calls": calls,
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	newMetricsCollector(ctx, "codeowners-scanner").recordCounter("github_api_calls_by_tenant_total", calls, MetricLabels{
+		"tenant": tenant,
+	})
+}
+
+// handleGetUsage reports GitHub API usage per tenant and per recent scan
+func (h *AppHandler) handleGetUsage(ctx *gofr.Context) (interface{}, error) {
+	month := ctx.Param("month")
+	if month == "" {
+		month = currentUsageMonth(time.Now())
+	}
+	if _, err := time.Parse(usageMonthLayout, month); err != nil {
+		return nil, &gofrhttp.ErrorInvalidParam{Params: []string{"month"}}
+	}
+
+	session, err := createNeo4jSession(ctx, h.deps.Neo4jConn)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	tenants, err := executeNeo4jReadQuery(ctx, session, buildMonthlyUsageQuery(), map[string]interface{}{"month": month})
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	scans, err := executeNeo4jReadQuery(ctx, session, buildRecentScanUsageQuery(), nil)
+	if err != nil {
+		return nil, convertNeo4jErrorToGoFr(err)
+	}
+
+	return UsageResponse{
+		Month:   month,
+		Tenants: convertToTenantUsage(tenants.Records, month, h.deps.currentConfig().Quota),
+		Scans:   convertToScanUsage(scans.Records),
+	}, nil
+}
+
+// convertToTenantUsage converts usage records and annotates them with quotas (Pure Core)
+func convertToTenantUsage(records []map[string]interface{}, month string, config QuotaConfig) []TenantUsage {
+	usage := make([]TenantUsage, 0, len(records))
+	for _, record := range records {
+		entry := TenantUsage{
+			Tenant:   getStringFromMap(record, "tenant"),
+			Month:    month,
+			APICalls: getIntFromMap(record, "calls"),
+			Scans:    getIntFromMap(record, "scans"),
+		}
+
+		if quota := resolveTenantQuota(config, entry.Tenant); quota > 0 {
+			remaining := quota - entry.APICalls
+			if remaining < 0 {
+				remaining = 0
+			}
+			entry.Quota = quota
+			entry.Remaining = &remaining
+		}
+
+		usage = append(usage, entry)
+	}
+	return usage
+}
+
+// convertToScanUsage converts scan usage records (Pure Core)
+func convertToScanUsage(records []map[string]interface{}) []ScanUsage {
+	scans := make([]ScanUsage, 0, len(records))
+	for _, record := range records {
+		scans = append(scans, ScanUsage{
+			ScanID:       getStringFromMap(record, "scan_id"),
+			Organization: getStringFromMap(record, "organization"),
+			Tenant:       getStringFromMap(record, "tenant"),
+			APICalls:     getIntFromMap(record, "api_calls"),
+			StartedAt:    getStringFromMap(record, "started_at"),
+		})
+	}
+	return scans
+}
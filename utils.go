@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -97,6 +98,12 @@ func convertNeo4jErrorToGoFr(err error) error {
 		return err
 	}
 
+	// Errors in the AppError taxonomy already carry their HTTP status
+	var carrier appErrorCarrier
+	if errors.As(err, &carrier) {
+		return err
+	}
+
 	return convertNeo4jErrorByMessage(err)
 }
 
@@ -109,12 +116,16 @@ func fetchTeamsOrTopics(ctx *gofr.Context, request ScanRequest, repos []GitHubRe
 		topics = collectTopicsFromRepositories(repos)
 		ctx.Logger.Infof("Collected %d unique topics from repositories", len(topics))
 	} else {
-		teamsResult, err := fetchGitHubTeamsWithService(ctx, request.Organization, request.MaxTeams)
+		fetchTeams := fetchGitHubTeamsWithService
+		if _, useGraphQL := currentGitHubGraphQL(); useGraphQL {
+			fetchTeams = fetchGitHubTeamsGraphQL
+		}
+		teamsResult, err := fetchTeams(ctx, request.Organization, request.MaxTeams)
 		if err != nil {
 			ctx.Logger.Warnf("Failed to fetch teams for organization %s (likely due to permissions): %v", request.Organization, err)
 			teams = []GitHubTeam{}
 		} else {
-			teams = teamsResult
+			teams = attachTeamMembers(ctx, request.Organization, teamsResult)
 		}
 	}
 
@@ -131,6 +142,10 @@ func performNeo4jHealthCheck(ctx context.Context, neo4jConn *Neo4jConnection) er
 
 // initializeNeo4jSchema initializes Neo4j database schema
 func initializeNeo4jSchema(ctx context.Context, neo4jConn *Neo4jConnection) error {
+	if err := migrateTeamKeys(ctx, neo4jConn); err != nil {
+		return fmt.Errorf("failed to migrate Neo4j team keys: %w", err)
+	}
+
 	if err := createNeo4jConstraints(ctx, neo4jConn); err != nil {
 		return fmt.Errorf("failed to create Neo4j constraints: %w", err)
 	}
@@ -142,39 +157,44 @@ func initializeNeo4jSchema(ctx context.Context, neo4jConn *Neo4jConnection) erro
 	return nil
 }
 
-// fetchGraphNodes fetches graph nodes from Neo4j
-func fetchGraphNodes(ctx *gofr.Context, session *Neo4jSession, orgName string, useTopics bool) ([]GraphNode, error) {
-	nodesQuery := buildGraphNodesQuery(orgName, useTopics)
+// fetchGraphNodes fetches graph nodes from Neo4j with the report of the records dropped converting them
+func fetchGraphNodes(ctx *gofr.Context, session *Neo4jSession, orgName string, groupBy GraphGroupBy) ([]GraphNode, *graphConversionReport, error) {
+	nodesQuery := buildGraphNodesQuery(orgName, groupBy)
 	nodesResult, err := executeNeo4jReadQuery(ctx, session, nodesQuery, map[string]interface{}{
 		"orgName": orgName,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return convertToGraphNodes(nodesResult.Records), nil
+	nodes, report := convertToGraphNodes(nodesResult.Records)
+	return nodes, report, nil
 }
 
-// fetchGraphEdges fetches graph edges from Neo4j
-func fetchGraphEdges(ctx *gofr.Context, session *Neo4jSession, orgName string, useTopics bool) ([]GraphEdge, error) {
-	edgesQuery := buildGraphEdgesQuery(orgName, useTopics)
+// fetchGraphEdges fetches graph edges from Neo4j with the report of the records dropped converting them
+func fetchGraphEdges(ctx *gofr.Context, session *Neo4jSession, orgName string, groupBy GraphGroupBy) ([]GraphEdge, *graphConversionReport, error) {
+	edgesQuery := buildGraphEdgesQuery(orgName, groupBy)
 	edgesResult, err := executeNeo4jReadQuery(ctx, session, edgesQuery, map[string]interface{}{
 		"orgName": orgName,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return convertToGraphEdges(edgesResult.Records), nil
+	edges, report := convertToGraphEdges(edgesResult.Records)
+	return edges, report, nil
 }
 
 // buildScanResponse builds scan response from components
-func buildScanResponse(organization string, summary ScanSummary, org GitHubOrganization, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners) ScanResponse {
+func buildScanResponse(organization string, outcome ScanOutcome, summary ScanSummary, org GitHubOrganization, repos []GitHubRepository, teams []GitHubTeam, topics []GitHubTopic, codeowners []GitHubCodeowners) ScanResponse {
 	return ScanResponse{
-		Success:      true,
-		Organization: organization,
-		Summary:      summary,
-		Errors:       []string{},
+		Success:            true,
+		Organization:       organization,
+		ScanID:             outcome.ScanID,
+		ScanStatus:         outcome.Status,
+		ValidationFailures: outcome.ValidationFailures,
+		Summary:            summary,
+		Errors:             []string{},
 		Data: map[string]interface{}{
 			"organization": org,
 			"repositories": repos,
@@ -185,41 +205,47 @@ func buildScanResponse(organization string, summary ScanSummary, org GitHubOrgan
 	}
 }
 
-// storeRepositories stores multiple repositories in Neo4j
-func storeRepositories(ctx *gofr.Context, session *Neo4jSession, repos []GitHubRepository, orgLogin string) error {
+// storeRepositories stores multiple repositories in Neo4j through UNWIND batches
+func storeRepositories(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, repos []GitHubRepository, orgLogin, scanID string) error {
+	batches := newScanBatchWriters(session, orgLogin, scanID, batch)
 	for _, repo := range repos {
-		if err := storeRepository(ctx, session, repo, orgLogin); err != nil {
+		if err := batches.addRepository(ctx, repo); err != nil {
 			return fmt.Errorf("failed to store repository %s: %w", repo.Name, err)
 		}
 	}
-	return nil
+	return batches.flush(ctx)
 }
 
-// storeTeamsAndTopics stores teams and topics in Neo4j
-func storeTeamsAndTopics(ctx *gofr.Context, session *Neo4jSession, teams []GitHubTeam, topics []GitHubTopic, orgLogin string) error {
+// storeTeamsAndTopics stores teams and topics in Neo4j through UNWIND batches
+func storeTeamsAndTopics(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, teams []GitHubTeam, topics []GitHubTopic, orgLogin, scanID string) error {
+	batches := newScanBatchWriters(session, orgLogin, scanID, batch)
 	for _, team := range teams {
-		if err := storeTeam(ctx, session, team, orgLogin); err != nil {
+		if err := batches.addTeam(ctx, team); err != nil {
 			return fmt.Errorf("failed to store team %s: %w", team.Name, err)
 		}
 	}
 
 	for _, topic := range topics {
-		if err := storeTopic(ctx, session, topic, orgLogin); err != nil {
+		if err := batches.addTopic(ctx, topic); err != nil {
 			return fmt.Errorf("failed to store topic %s: %w", topic.Name, err)
 		}
 	}
 
-	return nil
+	if err := batches.teams.flush(ctx); err != nil {
+		return err
+	}
+	return batches.topics.flush(ctx)
 }
 
-// storeCodeownersData stores codeowners data in Neo4j
-func storeCodeownersData(ctx *gofr.Context, session *Neo4jSession, codeowners []GitHubCodeowners, orgLogin string) error {
+// storeCodeownersData stores codeowners data in Neo4j through UNWIND batches
+func storeCodeownersData(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, codeowners []GitHubCodeowners, orgLogin, scanID string) error {
+	batches := newScanBatchWriters(session, orgLogin, scanID, batch)
 	for _, codeowner := range codeowners {
-		if err := storeCodeowners(ctx, session, codeowner, orgLogin); err != nil {
+		if err := batches.addCodeowners(ctx, codeowner); err != nil {
 			return fmt.Errorf("failed to store CODEOWNERS for %s: %w", codeowner.Repository, err)
 		}
 	}
-	return nil
+	return batches.flush(ctx)
 }
 
 // extractUniqueOwners extracts unique owners from codeowners data
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// parseWarmupOrganizations parses a comma-separated organization list, skipping blanks and duplicates (Pure Core)
+func parseWarmupOrganizations(value string) []string {
+	var organizations []string
+	seen := make(map[string]bool)
+	for _, org := range strings.Split(value, ",") {
+		org = strings.TrimSpace(org)
+		if org == "" || seen[org] {
+			continue
+		}
+		seen[org] = true
+		organizations = append(organizations, org)
+	}
+	return organizations
+}
+
+// warmOrganization loads the graph and stats of an organization into the response cache,
+// which also primes Neo4j's page and query plan caches for the first user request
+func warmOrganization(ctx *gofr.Context, deps *AppDependencies, orgName string) error {
+	graph, err := getOrganizationGraph(ctx, deps, orgName, GraphGroupByTeams)
+	if err != nil {
+		return err
+	}
+	deps.ResponseCache.store(graphCacheKey(orgName, GraphGroupByTeams), graph)
+
+	stats, err := getOrganizationStats(ctx, deps, orgName)
+	if err != nil {
+		return err
+	}
+	deps.ResponseCache.store(statsCacheKey(orgName), stats)
+
+	return nil
+}
+
+// warmCaches preloads the configured organizations; failures are logged and never block startup
+func warmCaches(ctx *gofr.Context, deps *AppDependencies) {
+	organizations := deps.Config.Warmup.Organizations
+	if len(organizations) == 0 {
+		return
+	}
+
+	startTime := time.Now()
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context, deps.Config.Warmup.Timeout)
+	defer cancel()
+
+	scoped := *ctx
+	scoped.Context = timeoutCtx
+
+	warmed := 0
+	for _, org := range organizations {
+		if err := warmOrganization(&scoped, deps, org); err != nil {
+			logWarn(ctx, "Failed to warm caches for organization", LogFields{
+				"component":    "warmup",
+				"operation":    "warm_organization",
+				"organization": org,
+				"error":        err.Error(),
+			})
+			continue
+		}
+		warmed++
+	}
+
+	duration := time.Since(startTime)
+	newMetricsCollector(ctx, "codeowners-scanner").recordDuration("cache_warmup_duration", duration, MetricLabels{})
+	logInfo(ctx, "Cache warm-up completed", LogFields{
+		"component":     "warmup",
+		"operation":     "warm_caches",
+		"organizations": len(organizations),
+		"warmed":        warmed,
+		"duration_ms":   duration.Milliseconds(),
+	})
+}
+
+// registerCacheWarmup preloads the configured organizations before the server accepts requests
+func registerCacheWarmup(app *gofr.App, deps *AppDependencies) {
+	app.OnStart(func(ctx *gofr.Context) error {
+		runCronJobWithRecovery(ctx, "cache_warmup", func() {
+			warmCaches(ctx, deps)
+		})
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// webhookDeadLetterKeyPrefix namespaces failed webhook deliveries in the state store
+const webhookDeadLetterKeyPrefix = "webhook-dead-letters/"
+
+// FailedWebhookDelivery is a webhook delivery that could not be applied to the graph, kept with its
+// payload so it can be replayed once the failure is resolved
+type FailedWebhookDelivery struct {
+	ID            string          `json:"id"`
+	Event         string          `json:"event"`
+	DeliveryID    string          `json:"delivery_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	Error         string          `json:"error"`
+	StatusCode    int             `json:"status_code"`
+	Attempts      int             `json:"attempts"`
+	FailedAt      time.Time       `json:"failed_at"`
+	LastAttemptAt time.Time       `json:"last_attempt_at"`
+}
+
+// FailedWebhookDeliveriesResponse lists the failed webhook deliveries awaiting replay
+type FailedWebhookDeliveriesResponse struct {
+	Deliveries []FailedWebhookDelivery `json:"deliveries"`
+	Count      int                     `json:"count"`
+}
+
+// webhookDeadLetterKey returns the state store key of a failed delivery (Pure Core)
+func webhookDeadLetterKey(id string) string {
+	return webhookDeadLetterKeyPrefix + id
+}
+
+// webhookDeadLetterID identifies a failed delivery by its GitHub delivery ID, or by a random one
+// for deliveries sent without it
+func webhookDeadLetterID(delivery WebhookDelivery) string {
+	if delivery.DeliveryID != "" {
+		return delivery.DeliveryID
+	}
+	return generateScanJobID()
+}
+
+// deadLetterStoreProblem describes why the state store cannot keep failed deliveries through the
+// outages they are kept for, or returns "" when it can: the memory backend loses them on restart, and
+// the neo4j backend fails to store them while Neo4j is down (Pure Core)
+func deadLetterStoreProblem(config AppConfig) string {
+	if config.Webhook.Secret == "" {
+		return ""
+	}
+	switch config.StateStore.Backend {
+	case StateStoreMemory, "":
+		return "the memory state store loses failed webhook deliveries on restart"
+	case StateStoreNeo4j:
+		return "the neo4j state store cannot keep failed webhook deliveries while Neo4j is unavailable"
+	}
+	return ""
+}
+
+// isDeadLetterError reports whether a failed delivery is worth keeping for replay: server-side
+// failures such as a Neo4j or GitHub outage may succeed later, while a malformed payload never will (Pure Core)
+func isDeadLetterError(err error) bool {
+	return err != nil && classifyError(err).StatusCode() >= http.StatusInternalServerError
+}
+
+// buildFailedWebhookDelivery records a failed attempt at a delivery; a delivery failing again keeps
+// its first failure time and counts the attempt (Pure Core)
+func buildFailedWebhookDelivery(previous *FailedWebhookDelivery, delivery WebhookDelivery, id string, err error, now time.Time) FailedWebhookDelivery {
+	failed := FailedWebhookDelivery{
+		ID:            id,
+		Event:         delivery.Event,
+		DeliveryID:    delivery.DeliveryID,
+		Payload:       json.RawMessage(delivery.Payload),
+		Error:         err.Error(),
+		StatusCode:    classifyError(err).StatusCode(),
+		Attempts:      1,
+		FailedAt:      now,
+		LastAttemptAt: now,
+	}
+	if previous != nil {
+		failed.Attempts = previous.Attempts + 1
+		failed.FailedAt = previous.FailedAt
+	}
+	return failed
+}
+
+// sortFailedWebhookDeliveries orders failed deliveries newest first (Pure Core)
+func sortFailedWebhookDeliveries(deliveries []FailedWebhookDelivery) []FailedWebhookDelivery {
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].FailedAt.Equal(deliveries[j].FailedAt) {
+			return deliveries[i].ID < deliveries[j].ID
+		}
+		return deliveries[i].FailedAt.After(deliveries[j].FailedAt)
+	})
+	return deliveries
+}
+
+// loadFailedWebhookDelivery reads a stored failed delivery
+func loadFailedWebhookDelivery(ctx *gofr.Context, state StateStore, id string) (FailedWebhookDelivery, bool, error) {
+	value, ok, err := state.Get(ctx, webhookDeadLetterKey(id))
+	if err != nil || !ok {
+		return FailedWebhookDelivery{}, false, err
+	}
+
+	var failed FailedWebhookDelivery
+	if err := json.Unmarshal(value, &failed); err != nil {
+		return FailedWebhookDelivery{}, false, fmt.Errorf("failed to decode failed webhook delivery %s: %w", id, err)
+	}
+	return failed, true, nil
+}
+
+// storeFailedWebhookDelivery keeps a failed delivery for the configured retention, counting the
+// attempts of a delivery already kept under the same ID; it returns the delivery's error, joined with
+// the store's when the delivery could not be kept, so the caller still answers with its status (Orchestrator)
+func storeFailedWebhookDelivery(ctx *gofr.Context, deps *AppDependencies, delivery WebhookDelivery, id string, deliveryErr error) error {
+	metrics := newMetricsCollector(ctx, "codeowners-scanner")
+	previous, found, err := loadFailedWebhookDelivery(ctx, deps.State, id)
+	if err == nil {
+		var kept *FailedWebhookDelivery
+		if found {
+			kept = &previous
+		}
+		var value []byte
+		value, err = json.Marshal(buildFailedWebhookDelivery(kept, delivery, id, deliveryErr, time.Now().UTC()))
+		if err == nil {
+			err = deps.State.Put(ctx, webhookDeadLetterKey(id), value, deps.currentConfig().Webhook.DeadLetterRetention)
+		}
+	}
+	if err != nil {
+		logError(ctx, "Failed to keep failed webhook delivery for replay; redeliver it from GitHub", LogFields{
+			"component":   "webhooks",
+			"operation":   "store_dead_letter",
+			"event":       delivery.Event,
+			"delivery_id": delivery.DeliveryID,
+			"error":       err.Error(),
+		})
+		metrics.recordCounter("webhook_dead_letters_total", 1, MetricLabels{"outcome": "lost"})
+		return fmt.Errorf("%w; keeping the delivery for replay also failed: %v", deliveryErr, err)
+	}
+	metrics.recordCounter("webhook_dead_letters_total", 1, MetricLabels{"outcome": "stored"})
+
+	logWarn(ctx, "Stored failed webhook delivery for replay", LogFields{
+		"component":   "webhooks",
+		"operation":   "store_dead_letter",
+		"id":          id,
+		"event":       delivery.Event,
+		"delivery_id": delivery.DeliveryID,
+		"attempts":    previous.Attempts + 1,
+		"error":       deliveryErr.Error(),
+	})
+	return deliveryErr
+}
+
+// listFailedWebhookDeliveries returns the stored failed deliveries, newest first (Orchestrator)
+func listFailedWebhookDeliveries(ctx *gofr.Context, state StateStore) (FailedWebhookDeliveriesResponse, error) {
+	entries, err := state.List(ctx, webhookDeadLetterKeyPrefix)
+	if err != nil {
+		return FailedWebhookDeliveriesResponse{}, err
+	}
+
+	deliveries := make([]FailedWebhookDelivery, 0, len(entries))
+	for key, value := range entries {
+		var failed FailedWebhookDelivery
+		if err := json.Unmarshal(value, &failed); err != nil {
+			logWarn(ctx, "Skipped undecodable failed webhook delivery", LogFields{
+				"component": "webhooks",
+				"operation": "list_dead_letters",
+				"key":       key,
+				"error":     err.Error(),
+			})
+			continue
+		}
+		deliveries = append(deliveries, failed)
+	}
+
+	return FailedWebhookDeliveriesResponse{
+		Deliveries: sortFailedWebhookDeliveries(deliveries),
+		Count:      len(deliveries),
+	}, nil
+}
+
+// replayFailedWebhookDelivery applies a failed delivery again: it is removed once applied and kept
+// with the new error when it fails again, until its retention runs out (Orchestrator)
+func replayFailedWebhookDelivery(ctx *gofr.Context, deps *AppDependencies, id string) (WebhookResult, error) {
+	failed, ok, err := loadFailedWebhookDelivery(ctx, deps.State, id)
+	if err != nil {
+		return WebhookResult{}, err
+	}
+	if !ok {
+		return WebhookResult{}, &gofrhttp.ErrorEntityNotFound{Name: "failed webhook delivery", Value: id}
+	}
+
+	delivery := WebhookDelivery{Event: failed.Event, DeliveryID: failed.DeliveryID, Payload: failed.Payload}
+	result, err := applyWebhookDelivery(ctx, deps, delivery)
+	if err != nil {
+		return WebhookResult{}, storeFailedWebhookDelivery(ctx, deps, delivery, id, err)
+	}
+
+	if err := deps.State.Delete(ctx, webhookDeadLetterKey(id)); err != nil {
+		return WebhookResult{}, fmt.Errorf("failed to remove replayed webhook delivery %s: %w", id, err)
+	}
+
+	logInfo(ctx, "Replayed failed webhook delivery", LogFields{
+		"component":   "webhooks",
+		"operation":   "replay_dead_letter",
+		"id":          id,
+		"event":       failed.Event,
+		"delivery_id": failed.DeliveryID,
+		"attempts":    failed.Attempts + 1,
+		"status":      result.Status,
+	})
+	return result, nil
+}
+
+// handleListFailedWebhooks lists webhook deliveries that failed to apply and await replay
+func (h *AppHandler) handleListFailedWebhooks(ctx *gofr.Context) (interface{}, error) {
+	return listFailedWebhookDeliveries(ctx, h.deps.State)
+}
+
+// handleReplayFailedWebhook applies a failed webhook delivery to the graph again
+func (h *AppHandler) handleReplayFailedWebhook(ctx *gofr.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+	if id == "" {
+		return nil, createMissingParamError("id")
+	}
+
+	return replayFailedWebhookDelivery(ctx, h.deps, id)
+}
@@ -0,0 +1,544 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+)
+
+// githubWebhookPath is the route GitHub delivers webhook events to
+const githubWebhookPath = "/api/webhooks/github"
+
+// maxWebhookPayloadBytes matches the largest payload GitHub delivers
+const maxWebhookPayloadBytes = 25 << 20
+
+// Webhook delivery outcomes
+const (
+	webhookStatusApplied = "applied"
+	webhookStatusIgnored = "ignored"
+)
+
+// webhookDeliveryContextKey stores a verified webhook delivery in the request context
+type webhookDeliveryContextKey struct{}
+
+// WebhookDelivery is a GitHub webhook delivery whose signature has been verified
+type WebhookDelivery struct {
+	Event      string
+	DeliveryID string
+	Payload    []byte
+}
+
+// WebhookRepository is the repository of a webhook payload; push payloads use Unix timestamps,
+// so only the fields shared by every event are decoded here
+type WebhookRepository struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// WebhookCommit lists the files a pushed commit touched
+type WebhookCommit struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+}
+
+// GitHubWebhookPayload holds the fields of push, repository, team and membership events the graph is
+// updated from
+type GitHubWebhookPayload struct {
+	Action       string              `json:"action"`
+	Ref          string              `json:"ref"`
+	Repository   json.RawMessage     `json:"repository"`
+	Team         *GitHubTeam         `json:"team"`
+	Member       *GitHubUser         `json:"member"`
+	Scope        string              `json:"scope"`
+	Organization *GitHubOrganization `json:"organization"`
+	Commits      []WebhookCommit     `json:"commits"`
+	Changes      struct {
+		Repository struct {
+			Name struct {
+				From string `json:"from"`
+			} `json:"name"`
+		} `json:"repository"`
+	} `json:"changes"`
+}
+
+// WebhookResult reports how a webhook delivery changed the graph
+type WebhookResult struct {
+	Event        string `json:"event"`
+	DeliveryID   string `json:"delivery_id"`
+	Action       string `json:"action,omitempty"`
+	Organization string `json:"organization,omitempty"`
+	Repository   string `json:"repository,omitempty"`
+	Team         string `json:"team,omitempty"`
+	Member       string `json:"member,omitempty"`
+	Status       string `json:"status"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// WebhookSignatureError is returned when a delivery is unsigned or signed with another secret
+type WebhookSignatureError struct{}
+
+// Error implements the error interface for WebhookSignatureError
+func (WebhookSignatureError) Error() string {
+	return "webhook signature does not match GITHUB_WEBHOOK_SECRET"
+}
+
+// StatusCode returns the HTTP status code for the error
+func (WebhookSignatureError) StatusCode() int {
+	return http.StatusUnauthorized
+}
+
+// verifyWebhookSignature checks an X-Hub-Signature-256 header against the payload HMAC (Pure Core)
+func verifyWebhookSignature(secret string, payload []byte, signature string) bool {
+	digest, found := strings.CutPrefix(signature, "sha256=")
+	if secret == "" || !found {
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// touchesCodeowners reports whether any pushed commit changed a CODEOWNERS file the search reads (Pure Core)
+func touchesCodeowners(commits []WebhookCommit, search codeownersSearch) bool {
+	for _, commit := range commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				if isCodeownersPath(file, search) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// webhookOrganization returns the organization a payload belongs to (Pure Core)
+func webhookOrganization(payload GitHubWebhookPayload, repo WebhookRepository) string {
+	if payload.Organization != nil && payload.Organization.Login != "" {
+		return payload.Organization.Login
+	}
+	return repo.Owner.Login
+}
+
+// buildWebhookActiveScanQuery builds a query returning the active scan webhook changes are applied to (Pure Core)
+func buildWebhookActiveScanQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})
+		RETURN coalesce(org.active_scan_id, '') AS scan_id
+	`
+}
+
+// buildWebhookGraphRevisionQuery builds a query counting an in-place change to the active scan, which
+// moves the graph change cursor without activating a new scan (Pure Core)
+func buildWebhookGraphRevisionQuery() string {
+	return `
+		MATCH (org:Organization {login: $org_login})
+		SET org.graph_revision = coalesce(org.graph_revision, 0) + 1,
+			org.graph_changed_at = $changed_at
+	`
+}
+
+// buildDeleteRepositoryCodeownersQuery builds a query removing a repository's CODEOWNERS relationships from a scan (Pure Core)
+func buildDeleteRepositoryCodeownersQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[:OWNS {scan_id: $scan_id}]->(repo:Repository {full_name: $full_name})
+		MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER|HAS_CODEOWNERS_FILE {scan_id: $scan_id}]->()
+		DELETE r
+	`
+}
+
+// buildDeleteRepositoryFromScanQuery builds a query removing a repository and its relationships from a scan (Pure Core)
+func buildDeleteRepositoryFromScanQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[owns:OWNS {scan_id: $scan_id}]->(repo:Repository {full_name: $full_name})
+		OPTIONAL MATCH (repo)-[r:HAS_CODEOWNER|HAS_TEAM_OWNER|HAS_TOPIC|HAS_CODEOWNERS_FILE {scan_id: $scan_id}]->()
+		DELETE r, owns
+	`
+}
+
+// buildDeleteTeamFromScanQuery builds a query removing a team from an organization's scan (Pure Core)
+func buildDeleteTeamFromScanQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[r:HAS_TEAM {scan_id: $scan_id}]->(:Team {slug: $slug})
+		DELETE r
+	`
+}
+
+// buildAddTeamMemberQuery builds a query adding a login to the members of a team in an organization's
+// scan; teams whose members were never listed are left alone, as their membership is unknown (Pure Core)
+func buildAddTeamMemberQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[:HAS_TEAM {scan_id: $scan_id}]->(team:Team {key: $org_login + '/' + $slug})
+		WHERE team.members IS NOT NULL
+		SET team.members = CASE
+			WHEN any(member IN team.members WHERE toLower(member) = toLower($login)) THEN team.members
+			ELSE team.members + $login
+		END
+		RETURN count(team) AS updated
+	`
+}
+
+// buildRemoveTeamMemberQuery builds a query removing a login from the members of a team in an
+// organization's scan (Pure Core)
+func buildRemoveTeamMemberQuery() string {
+	return `
+		MATCH (:Organization {login: $org_login})-[:HAS_TEAM {scan_id: $scan_id}]->(team:Team {key: $org_login + '/' + $slug})
+		WHERE team.members IS NOT NULL
+		SET team.members = [member IN team.members WHERE toLower(member) <> toLower($login)]
+		RETURN count(team) AS updated
+	`
+}
+
+// webhookMiddleware verifies the signature of GitHub webhook deliveries and hands the verified payload
+// to the webhook route; the raw body is needed for the HMAC, which GoFr's binding does not expose
+func webhookMiddleware(deps *AppDependencies) gofrhttp.Middleware {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != githubWebhookPath {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			secret := deps.currentConfig().Webhook.Secret
+			if secret == "" {
+				http.Error(w, "GitHub webhooks are disabled; set GITHUB_WEBHOOK_SECRET", http.StatusServiceUnavailable)
+				return
+			}
+
+			payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadBytes))
+			if err != nil {
+				http.Error(w, "failed to read webhook payload", http.StatusRequestEntityTooLarge)
+				return
+			}
+			if !verifyWebhookSignature(secret, payload, r.Header.Get("X-Hub-Signature-256")) {
+				http.Error(w, WebhookSignatureError{}.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			delivery := WebhookDelivery{
+				Event:      r.Header.Get("X-GitHub-Event"),
+				DeliveryID: r.Header.Get("X-GitHub-Delivery"),
+				Payload:    payload,
+			}
+			r.Body = io.NopCloser(bytes.NewReader(payload))
+			inner.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), webhookDeliveryContextKey{}, delivery)))
+		})
+	}
+}
+
+// resolveWebhookScanID returns the active scan of an organization, or "" when it has never been scanned
+func resolveWebhookScanID(ctx context.Context, session *Neo4jSession, orgLogin string) (string, error) {
+	result, err := executeNeo4jReadQuery(ctx, session, buildWebhookActiveScanQuery(), map[string]interface{}{"org_login": orgLogin})
+	if err != nil {
+		return "", err
+	}
+	if len(result.Records) == 0 {
+		return "", nil
+	}
+	return getStringFromMap(result.Records[0], "scan_id"), nil
+}
+
+// refreshRepositoryCodeowners re-reads a repository's CODEOWNERS file and replaces its ownership in the active scan (Orchestrator)
+func refreshRepositoryCodeowners(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, orgLogin, scanID, fullName string) error {
+	changedAt := time.Now().UTC().Format(time.RFC3339)
+	if err := retireRepositoryCodeownerVersions(ctx, session, orgLogin, scanID, fullName, changedAt); err != nil {
+		return err
+	}
+	params := map[string]interface{}{"org_login": orgLogin, "scan_id": scanID, "full_name": fullName}
+	if _, err := executeNeo4jWrite(ctx, session, buildDeleteRepositoryCodeownersQuery(), params); err != nil {
+		return fmt.Errorf("failed to remove CODEOWNERS of %s: %w", fullName, err)
+	}
+
+	codeowners, err := fetchCodeownersForReposWithService(ctx, []GitHubRepository{{FullName: fullName}})
+	if err != nil {
+		return err
+	}
+	if err := storeScanDataInBatches(ctx, session, batch, orgLogin, scanID, nil, nil, nil, codeowners); err != nil {
+		return err
+	}
+	return reopenRepositoryCodeownerVersions(ctx, session, orgLogin, scanID, fullName, changedAt)
+}
+
+// replaceRepositoryInScan rewrites a repository, its topics and its CODEOWNERS in the active scan (Orchestrator)
+func replaceRepositoryInScan(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, orgLogin, scanID, previousName string, repo GitHubRepository) error {
+	changedAt := time.Now().UTC().Format(time.RFC3339)
+	for _, name := range []string{previousName, repo.FullName} {
+		if name == "" {
+			continue
+		}
+		if err := retireRepositoryCodeownerVersions(ctx, session, orgLogin, scanID, name, changedAt); err != nil {
+			return err
+		}
+		params := map[string]interface{}{"org_login": orgLogin, "scan_id": scanID, "full_name": name}
+		if _, err := executeNeo4jWrite(ctx, session, buildDeleteRepositoryFromScanQuery(), params); err != nil {
+			return fmt.Errorf("failed to remove repository %s: %w", name, err)
+		}
+	}
+
+	codeowners, err := fetchCodeownersForReposWithService(ctx, []GitHubRepository{repo})
+	if err != nil {
+		return err
+	}
+	if err := storeScanDataInBatches(ctx, session, batch, orgLogin, scanID, []GitHubRepository{repo}, nil, nil, codeowners); err != nil {
+		return err
+	}
+	return reopenRepositoryCodeownerVersions(ctx, session, orgLogin, scanID, repo.FullName, changedAt)
+}
+
+// applyPushEvent refreshes CODEOWNERS when a push to the default branch changed the file
+func applyPushEvent(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, scanID string, payload GitHubWebhookPayload, repo WebhookRepository, result *WebhookResult) error {
+	if payload.Ref != "refs/heads/"+repo.DefaultBranch {
+		result.Status, result.Reason = webhookStatusIgnored, "push is not to the default branch"
+		return nil
+	}
+	if !touchesCodeowners(payload.Commits, currentCodeownersSearch()) {
+		result.Status, result.Reason = webhookStatusIgnored, "push does not change a CODEOWNERS file"
+		return nil
+	}
+
+	return refreshRepositoryCodeowners(ctx, session, batch, result.Organization, scanID, repo.FullName)
+}
+
+// applyRepositoryEvent adds, updates or removes a repository in the active scan
+func applyRepositoryEvent(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, scanID string, payload GitHubWebhookPayload, result *WebhookResult) error {
+	var repo GitHubRepository
+	if err := json.Unmarshal(payload.Repository, &repo); err != nil {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"repository"}}
+	}
+
+	switch payload.Action {
+	case "deleted":
+		changedAt := time.Now().UTC().Format(time.RFC3339)
+		if err := retireRepositoryCodeownerVersions(ctx, session, result.Organization, scanID, repo.FullName, changedAt); err != nil {
+			return err
+		}
+		params := map[string]interface{}{"org_login": result.Organization, "scan_id": scanID, "full_name": repo.FullName}
+		_, err := executeNeo4jWrite(ctx, session, buildDeleteRepositoryFromScanQuery(), params)
+		return err
+	case "created", "edited", "renamed", "transferred", "archived", "unarchived", "publicized", "privatized":
+		previousName := ""
+		if from := payload.Changes.Repository.Name.From; payload.Action == "renamed" && from != "" {
+			previousName = result.Organization + "/" + from
+		}
+		return replaceRepositoryInScan(ctx, session, batch, result.Organization, scanID, previousName, repo)
+	}
+
+	result.Status, result.Reason = webhookStatusIgnored, "repository action does not change ownership"
+	return nil
+}
+
+// applyTeamEvent adds, updates or removes a team in the active scan; a renamed team's previous slug
+// is left for the next full scan to remove, as the payload does not carry it
+func applyTeamEvent(ctx *gofr.Context, session *Neo4jSession, batch Neo4jBatchConfig, scanID string, payload GitHubWebhookPayload, result *WebhookResult) error {
+	if payload.Team == nil || payload.Team.Slug == "" {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"team"}}
+	}
+	result.Team = payload.Team.Slug
+
+	switch payload.Action {
+	case "deleted":
+		params := map[string]interface{}{"org_login": result.Organization, "scan_id": scanID, "slug": payload.Team.Slug}
+		_, err := executeNeo4jWrite(ctx, session, buildDeleteTeamFromScanQuery(), params)
+		return err
+	case "created", "edited":
+		return storeScanDataInBatches(ctx, session, batch, result.Organization, scanID, nil, []GitHubTeam{*payload.Team}, nil, nil)
+	}
+
+	result.Status, result.Reason = webhookStatusIgnored, "team action does not change ownership"
+	return nil
+}
+
+// applyMembershipEvent adds a member to or removes one from a team of the active scan, keeping the
+// membership export and team expansion current between scans
+func applyMembershipEvent(ctx *gofr.Context, session *Neo4jSession, scanID string, payload GitHubWebhookPayload, result *WebhookResult) error {
+	if payload.Scope != "" && payload.Scope != "team" {
+		result.Status, result.Reason = webhookStatusIgnored, "membership scope is not a team"
+		return nil
+	}
+	if payload.Team == nil || payload.Team.Slug == "" {
+		// Deliveries for deleted teams carry no slug; the team event removes the team itself
+		result.Status, result.Reason = webhookStatusIgnored, "membership team no longer exists"
+		return nil
+	}
+	if payload.Member == nil || payload.Member.Login == "" {
+		return &gofrhttp.ErrorInvalidParam{Params: []string{"member"}}
+	}
+	result.Team, result.Member = payload.Team.Slug, payload.Member.Login
+
+	var query string
+	switch payload.Action {
+	case "added":
+		query = buildAddTeamMemberQuery()
+	case "removed":
+		query = buildRemoveTeamMemberQuery()
+	default:
+		result.Status, result.Reason = webhookStatusIgnored, "membership action does not change team members"
+		return nil
+	}
+
+	updated, err := executeNeo4jWrite(ctx, session, query, map[string]interface{}{
+		"org_login": result.Organization,
+		"scan_id":   scanID,
+		"slug":      payload.Team.Slug,
+		"login":     payload.Member.Login,
+	})
+	if err != nil {
+		return err
+	}
+	if len(updated.Records) == 0 || getIntFromMap(updated.Records[0], "updated") == 0 {
+		result.Status, result.Reason = webhookStatusIgnored, "team or its members are not in the active scan"
+	}
+	return nil
+}
+
+// recordWebhookGraphChange moves the organization's graph change cursor past an applied delivery and
+// wakes the long-poll waiters of this instance; waiters on other instances see it on their next recheck
+func recordWebhookGraphChange(ctx *gofr.Context, deps *AppDependencies, session *Neo4jSession, orgLogin string) {
+	_, err := executeNeo4jWrite(ctx, session, buildWebhookGraphRevisionQuery(), map[string]interface{}{
+		"org_login":  orgLogin,
+		"changed_at": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		logWarn(ctx, "Failed to record graph change of webhook delivery", LogFields{
+			"component":    "webhooks",
+			"operation":    "record_graph_change",
+			"organization": orgLogin,
+			"error":        err.Error(),
+		})
+	}
+	deps.GraphChanges.notify(orgLogin)
+}
+
+// applyWebhookDelivery updates the active scan of the delivery's organization in place (Orchestrator)
+func applyWebhookDelivery(ctx *gofr.Context, deps *AppDependencies, delivery WebhookDelivery) (WebhookResult, error) {
+	result := WebhookResult{Event: delivery.Event, DeliveryID: delivery.DeliveryID, Status: webhookStatusApplied}
+
+	switch delivery.Event {
+	case "push", "repository", "team", "membership":
+	case "ping":
+		result.Status = webhookStatusIgnored
+		return result, nil
+	default:
+		// member events concern repository collaborators, which the graph does not store
+		result.Status, result.Reason = webhookStatusIgnored, "event is not tracked in the graph"
+		return result, nil
+	}
+
+	var payload GitHubWebhookPayload
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return WebhookResult{}, &gofrhttp.ErrorInvalidParam{Params: []string{"body"}}
+	}
+	var repo WebhookRepository
+	if len(payload.Repository) > 0 {
+		if err := json.Unmarshal(payload.Repository, &repo); err != nil {
+			return WebhookResult{}, &gofrhttp.ErrorInvalidParam{Params: []string{"repository"}}
+		}
+	}
+	result.Action = payload.Action
+	result.Organization = webhookOrganization(payload, repo)
+	result.Repository = repo.FullName
+	if result.Organization == "" {
+		return WebhookResult{}, createMissingParamError("organization")
+	}
+
+	session, err := createNeo4jSession(ctx, deps.Neo4jConn)
+	if err != nil {
+		return WebhookResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	defer closeNeo4jSession(ctx, session)
+
+	scanID, err := resolveWebhookScanID(ctx, session, result.Organization)
+	if err != nil {
+		return WebhookResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	if scanID == "" {
+		result.Status, result.Reason = webhookStatusIgnored, "organization has not been scanned"
+		return result, nil
+	}
+
+	batch := deps.currentConfig().Neo4j.Batch
+	switch delivery.Event {
+	case "push":
+		err = applyPushEvent(ctx, session, batch, scanID, payload, repo, &result)
+	case "repository":
+		err = applyRepositoryEvent(ctx, session, batch, scanID, payload, &result)
+	case "team":
+		err = applyTeamEvent(ctx, session, batch, scanID, payload, &result)
+	case "membership":
+		err = applyMembershipEvent(ctx, session, scanID, payload, &result)
+	}
+	if err == nil && result.Status == webhookStatusApplied {
+		// Batch writes stage node properties on the scan, which is already active here
+		err = applyStagedProperties(ctx, session, result.Organization, scanID)
+	}
+	if err != nil {
+		logError(ctx, "Failed to apply webhook delivery", LogFields{
+			"component":    "webhooks",
+			"operation":    "apply_delivery",
+			"event":        delivery.Event,
+			"delivery_id":  delivery.DeliveryID,
+			"organization": result.Organization,
+			"error":        err.Error(),
+		})
+		return WebhookResult{}, convertNeo4jErrorToGoFr(err)
+	}
+	if result.Status == webhookStatusApplied {
+		recordWebhookGraphChange(ctx, deps, session, result.Organization)
+	}
+
+	logInfo(ctx, "Applied webhook delivery", LogFields{
+		"component":    "webhooks",
+		"operation":    "apply_delivery",
+		"event":        delivery.Event,
+		"action":       result.Action,
+		"delivery_id":  delivery.DeliveryID,
+		"organization": result.Organization,
+		"repository":   result.Repository,
+		"team":         result.Team,
+		"member":       result.Member,
+		"status":       result.Status,
+		"scan_running": deps.Scans.isRunning(result.Organization),
+	})
+
+	return result, nil
+}
+
+// handleGitHubWebhook applies a verified GitHub webhook delivery to the graph; deliveries failing on
+// the server side are kept for replay
+func (h *AppHandler) handleGitHubWebhook(ctx *gofr.Context) (interface{}, error) {
+	delivery, ok := ctx.Value(webhookDeliveryContextKey{}).(WebhookDelivery)
+	if !ok {
+		return nil, WebhookSignatureError{}
+	}
+	if delivery.Event == "" {
+		return nil, createMissingParamError("X-GitHub-Event")
+	}
+
+	result, err := applyWebhookDelivery(ctx, h.deps, delivery)
+	if isDeadLetterError(err) {
+		return result, storeFailedWebhookDelivery(ctx, h.deps, delivery, webhookDeadLetterID(delivery), err)
+	}
+	return result, err
+}